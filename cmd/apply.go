@@ -6,12 +6,20 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
+	"github.com/updatecli/updatecli/pkg/core/notification"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
 )
 
 var (
-	applyCommit bool
-	applyClean  bool
-	applyPush   bool
+	applyCommit       bool
+	applyClean        bool
+	applyPush         bool
+	applyParallel     int
+	applyReportFile   string
+	applyNotifiers    string
+	applyMetricsPush  string
+	applyMetricsJob   string
+	applyOTLPEndpoint string
 
 	applyCmd = &cobra.Command{
 		Use:   "apply",
@@ -25,6 +33,20 @@ var (
 			e.Options.Pipeline.Target.Push = applyPush
 			e.Options.Pipeline.Target.Clean = applyClean
 			e.Options.Pipeline.Target.DryRun = false
+			e.Options.Parallel = applyParallel
+			e.Options.ReportFile = applyReportFile
+			e.Options.MetricsPushURL = applyMetricsPush
+			e.Options.MetricsJob = applyMetricsJob
+			e.Options.OTLPEndpoint = applyOTLPEndpoint
+
+			if applyNotifiers != "" {
+				notifiers, err := notification.LoadConfigs(applyNotifiers)
+				if err != nil {
+					logrus.Errorf("loading notifiers: %s", err)
+					os.Exit(1)
+				}
+				e.Options.Notifiers = notifiers
+			}
 
 			err := run("apply")
 			if err != nil {
@@ -36,7 +58,7 @@ var (
 )
 
 func init() {
-	applyCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file or directory. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	applyCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
 	applyCmd.Flags().StringVar(&udashOAuthAudience, "reportAPI", "", "Set the report API URL where to publish pipeline reports")
 	applyCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
 	applyCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets Sops secrets file uses for templating")
@@ -45,4 +67,10 @@ func init() {
 	applyCmd.Flags().BoolVarP(&applyPush, "push", "", true, "Update remote refs '--push=false'")
 
 	applyCmd.Flags().BoolVar(&applyClean, "clean", false, "Remove updatecli working directory like '--clean=true'")
+	applyCmd.Flags().IntVar(&applyParallel, "parallel", 1, "Sets the maximum number of independent pipelines run concurrently like '--parallel=4'")
+	applyCmd.Flags().StringVar(&applyReportFile, "report-file", "", "Writes the run report to a JSON file, or a YAML file if it ends with '.yaml' or '.yml', like '--report-file=report.json'")
+	applyCmd.Flags().StringVar(&applyNotifiers, "notifiers", "", "Sets a YAML file defining, under 'notifiers', the notifiers receiving the run report like '--notifiers=notifiers.yaml'")
+	applyCmd.Flags().StringVar(&applyMetricsPush, "metrics-push", "", "Pushes the run metrics to a Prometheus Pushgateway like '--metrics-push=http://localhost:9091'")
+	applyCmd.Flags().StringVar(&applyMetricsJob, "metrics-job", telemetry.DefaultMetricsJob, "Sets the Prometheus job name of the pushed metrics like '--metrics-job=updatecli-nightly'")
+	applyCmd.Flags().StringVar(&applyOTLPEndpoint, "otlp-endpoint", "", "Exports the run traces to an OpenTelemetry OTLP/HTTP endpoint like '--otlp-endpoint=http://localhost:4318'. Default to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable")
 }
@@ -6,10 +6,18 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
+	"github.com/updatecli/updatecli/pkg/core/notification"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
 )
 
 var (
-	diffClean bool
+	diffClean        bool
+	diffParallel     int
+	diffReportFile   string
+	diffNotifiers    string
+	diffMetricsPush  string
+	diffMetricsJob   string
+	diffOTLPEndpoint string
 
 	diffCmd = &cobra.Command{
 		Use:   "diff",
@@ -23,6 +31,20 @@ var (
 			e.Options.Pipeline.Target.Push = false
 			e.Options.Pipeline.Target.Clean = diffClean
 			e.Options.Pipeline.Target.DryRun = true
+			e.Options.Parallel = diffParallel
+			e.Options.ReportFile = diffReportFile
+			e.Options.MetricsPushURL = diffMetricsPush
+			e.Options.MetricsJob = diffMetricsJob
+			e.Options.OTLPEndpoint = diffOTLPEndpoint
+
+			if diffNotifiers != "" {
+				notifiers, err := notification.LoadConfigs(diffNotifiers)
+				if err != nil {
+					logrus.Errorf("loading notifiers: %s", err)
+					os.Exit(1)
+				}
+				e.Options.Notifiers = notifiers
+			}
 
 			err := run("diff")
 			if err != nil {
@@ -34,10 +56,16 @@ var (
 )
 
 func init() {
-	diffCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file or directory. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	diffCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
 	diffCmd.Flags().StringVar(&udashOAuthAudience, "reportAPI", "", "Set the report API URL where to publish pipeline reports")
 	diffCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
 	diffCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets Sops secrets file uses for templating")
 	diffCmd.Flags().BoolVar(&diffClean, "clean", false, "Remove updatecli working directory like '--clean=true'")
+	diffCmd.Flags().IntVar(&diffParallel, "parallel", 1, "Sets the maximum number of independent pipelines run concurrently like '--parallel=4'")
+	diffCmd.Flags().StringVar(&diffReportFile, "report-file", "", "Writes the run report to a JSON file, or a YAML file if it ends with '.yaml' or '.yml', like '--report-file=report.json'")
+	diffCmd.Flags().StringVar(&diffNotifiers, "notifiers", "", "Sets a YAML file defining, under 'notifiers', the notifiers receiving the run report like '--notifiers=notifiers.yaml'")
+	diffCmd.Flags().StringVar(&diffMetricsPush, "metrics-push", "", "Pushes the run metrics to a Prometheus Pushgateway like '--metrics-push=http://localhost:9091'")
+	diffCmd.Flags().StringVar(&diffMetricsJob, "metrics-job", telemetry.DefaultMetricsJob, "Sets the Prometheus job name of the pushed metrics like '--metrics-job=updatecli-nightly'")
+	diffCmd.Flags().StringVar(&diffOTLPEndpoint, "otlp-endpoint", "", "Exports the run traces to an OpenTelemetry OTLP/HTTP endpoint like '--otlp-endpoint=http://localhost:4318'. Default to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable")
 
 }
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/spf13/cobra"
+
+	"github.com/updatecli/updatecli/pkg/core/engine"
+)
+
+var (
+	graphFormat string
+
+	graphCmd = &cobra.Command{
+		Use:   "graph",
+		Short: "graph prints the pipelines dependency graph",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.Options.Config.ManifestFile = cfgFile
+			e.Options.Config.ValuesFiles = valuesFiles
+			e.Options.Config.SecretsFiles = secretsFiles
+
+			err := run("graph")
+			if err != nil {
+				logrus.Errorf("command failed")
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	graphCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	graphCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
+	graphCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets secrets file uses for templating")
+	graphCmd.Flags().StringVar(&graphFormat, "format", engine.GraphFormatDOT, "Sets the graph format, either 'dot' or 'mermaid'")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	manifestExplainDisableTemplating bool
+
+	manifestExplainCmd = &cobra.Command{
+		Use:   "explain",
+		Short: "explain prints how the pipeline(s) are resolved, without running them",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.Options.Config.ManifestFile = cfgFile
+			e.Options.Config.ValuesFiles = valuesFiles
+			e.Options.Config.SecretsFiles = secretsFiles
+
+			e.Options.Config.DisableTemplating = manifestExplainDisableTemplating
+
+			err := run("manifest/explain")
+			if err != nil {
+				logrus.Errorf("command failed")
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	manifestExplainCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	manifestExplainCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
+	manifestExplainCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets secrets file uses for templating")
+	manifestExplainCmd.Flags().BoolVar(&manifestExplainDisableTemplating, "disable-templating", false, "Disable manifest templating")
+
+	manifestCmd.AddCommand(manifestExplainCmd)
+}
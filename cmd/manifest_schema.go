@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	manifestSchemaOutput string
+	manifestSchemaBaseID string
+
+	manifestSchemaCmd = &cobra.Command{
+		Use:   "schema",
+		Short: "schema exports the manifest json schema used for editor autocompletion",
+		Long: `schema exports the manifest json schema used for editor autocompletion.
+
+Once exported, the schema can be used by yaml-language-server, for example from VS Code,
+by adding the following comment at the top of an Updatecli manifest:
+
+  # yaml-language-server: $schema=<path to the schema>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := run("manifest/schema")
+			if err != nil {
+				logrus.Errorf("command failed")
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	manifestSchemaCmd.Flags().StringVarP(&manifestSchemaOutput, "output", "o", "", "Writes the json schema to a file instead of stdout like '--output=updatecli.schema.json'")
+	manifestSchemaCmd.Flags().StringVarP(&manifestSchemaBaseID, "baseid", "b", "https://www.updatecli.io/latest/schema", "Define schema baseid")
+
+	manifestCmd.AddCommand(manifestSchemaCmd)
+}
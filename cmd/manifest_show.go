@@ -39,7 +39,7 @@ var (
 )
 
 func init() {
-	manifestShowCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file or directory. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	manifestShowCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
 	manifestShowCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
 	manifestShowCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets secrets file uses for templating")
 	manifestShowCmd.Flags().BoolVar(&manifestShowClean, "clean", false, "Remove updatecli working directory like '--clean=true'")
@@ -2,15 +2,19 @@ package cmd
 
 import (
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/updatecli/updatecli/pkg/core/cache"
 	"github.com/updatecli/updatecli/pkg/core/cmdoptions"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/core/log"
 	"github.com/updatecli/updatecli/pkg/core/udash"
 
 	"github.com/updatecli/updatecli/pkg/core/engine"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
 
 	"github.com/spf13/cobra"
 )
@@ -22,6 +26,13 @@ var (
 	e            engine.Engine
 	verbose      bool
 	experimental bool
+	cloneCache   bool
+	httpRetries  int
+	httpBackoff  string
+	noCache      bool
+	cacheTTL     time.Duration
+	logFormat    string
+	logDir       string
 
 	rootCmd = &cobra.Command{
 		Use:   "updatecli",
@@ -51,7 +62,22 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "debug", "", false, "Debug Output")
 	rootCmd.PersistentFlags().BoolVarP(&experimental, "experimental", "", false, "Enable Experimental mode")
+	rootCmd.PersistentFlags().BoolVar(&cloneCache, "clone-cache", false, "Reuse git repository mirrors kept in the user cache directory between runs, like '--clone-cache=true'")
+	rootCmd.PersistentFlags().IntVar(&httpRetries, "http-retries", 0, "Retry failed http requests, such as rate limits or 5xx responses, up to the given number of times, like '--http-retries=3'")
+	rootCmd.PersistentFlags().StringVar(&httpBackoff, "http-retry-backoff", "", "Delay before the first retry of a failed http request, doubled after each attempt, like '--http-retry-backoff=2s'")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Disable the cache of source lookups, such as container image tags, kept in the user cache directory, like '--no-cache=true'")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", cache.DefaultTTL, "Duration during which cached source lookups are reused, like '--cache-ttl=1h'")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Sets the log format, either 'text' or 'json' whose entries contain the pipeline, stage, resource and plugin fields, like '--log-format=json'")
+	rootCmd.PersistentFlags().StringVar(&logDir, "log-dir", "", "Writes the logs of each run to a new file of this directory, in addition to the standard output, like '--log-dir=/var/log/updatecli'")
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		switch logFormat {
+		case "text":
+		case "json":
+			logrus.SetFormatter(log.NewJSONFormat())
+		default:
+			logrus.Errorf("%s unsupported log format %q, accepted values are 'text' and 'json'", result.FAILURE, logFormat)
+			os.Exit(1)
+		}
 		if verbose {
 			logrus.SetLevel(logrus.DebugLevel)
 		}
@@ -59,6 +85,36 @@ func init() {
 			cmdoptions.Experimental = true
 			logrus.Infof("Experimental Mode Enabled")
 		}
+		if cloneCache {
+			cacheDir, err := gitgeneric.UserCloneCacheDir()
+			if err != nil {
+				logrus.Warningf("clone cache disabled: %s", err)
+			} else {
+				gitgeneric.DefaultCloneCacheDir = cacheDir
+				logrus.Debugf("Clone cache enabled in %q", cacheDir)
+			}
+		}
+		if !noCache {
+			cacheDir, err := cache.UserCacheDir()
+			if err != nil {
+				logrus.Warningf("lookup cache disabled: %s", err)
+			} else {
+				cache.Dir = cacheDir
+				cache.TTL = cacheTTL
+				logrus.Debugf("Lookup cache enabled in %q", cacheDir)
+			}
+		}
+		if httpRetries > 0 {
+			err := httpclient.EnableRetries(httpclient.RetryPolicy{
+				Retries: httpRetries,
+				Backoff: httpBackoff,
+			})
+			if err != nil {
+				logrus.Errorf("%s %s", result.FAILURE, err)
+				os.Exit(1)
+			}
+			logrus.Debugf("Http retries enabled, up to %d retries", httpRetries)
+		}
 	}
 	rootCmd.AddCommand(
 		applyCmd,
@@ -67,14 +123,27 @@ func init() {
 		manifestCmd,
 		udashCmd,
 		showCmd,
+		graphCmd,
+		serverCmd,
 		versionCmd,
 		docsCmd,
 		manCmd,
-		jsonschemaCmd)
+		jsonschemaCmd,
+		validateCmd)
 }
 
 func run(command string) error {
 
+	// The server writes a log file per run of the manifests
+	if logDir != "" && command != "server" {
+		stopRunLog, err := startRunLog(command)
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+		defer stopRunLog()
+	}
+
 	switch command {
 	case "apply":
 		udash.Audience = udashOAuthAudience
@@ -139,6 +208,20 @@ func run(command string) error {
 			return err
 		}
 
+	case "manifest/explain":
+		err := e.Explain()
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+
+	case "manifest/schema":
+		err := engine.ManifestSchema(manifestSchemaBaseID, manifestSchemaOutput)
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+
 	case "udash/config":
 		configFilePath, err := udash.ConfigFilePath()
 		if err != nil {
@@ -186,6 +269,27 @@ func run(command string) error {
 			return err
 		}
 
+	case "server":
+		err := runServer()
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+
+	case "graph":
+		err := e.Graph(graphFormat)
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+
+	case "validate":
+		err := e.Validate()
+		if err != nil {
+			logrus.Errorf("%s %s", result.FAILURE, err)
+			return err
+		}
+
 	case "jsonschema":
 		err := engine.GenerateSchema(jsonschemaBaseID, jsonschemaDirectory)
 		if err != nil {
@@ -197,3 +301,15 @@ func run(command string) error {
 	}
 	return nil
 }
+
+// startRunLog writes the logs of the run to a new file of the log directory, until the returned function is called
+func startRunLog(name string) (stop func(), err error) {
+	path, stop, err := log.StartRunLog(logDir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("Writing logs to %q", path)
+
+	return stop, nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/spf13/cobra"
+
+	"github.com/updatecli/updatecli/pkg/core/engine"
+	"github.com/updatecli/updatecli/pkg/core/server"
+)
+
+// serverWebhookTokenEnv defines the environment variable used as webhook token if none is specified,
+// to avoid exposing it in the process arguments
+const serverWebhookTokenEnv = "UPDATECLI_WEBHOOK_TOKEN"
+
+var (
+	serverListen       string
+	serverSchedule     string
+	serverWebhookToken string
+	serverRunOnStartup bool
+	serverDryRun       bool
+	serverCommit       bool
+	serverPush         bool
+	serverClean        bool
+	serverParallel     int
+	serverOTLPEndpoint string
+
+	serverCmd = &cobra.Command{
+		Use:   "server",
+		Short: "server keeps running to apply the manifests on a schedule or when receiving a webhook",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.Options.Config.ManifestFile = cfgFile
+			e.Options.Config.ValuesFiles = valuesFiles
+			e.Options.Config.SecretsFiles = secretsFiles
+
+			e.Options.Pipeline.Target.Commit = serverCommit && !serverDryRun
+			e.Options.Pipeline.Target.Push = serverPush && !serverDryRun
+			e.Options.Pipeline.Target.Clean = serverClean
+			e.Options.Pipeline.Target.DryRun = serverDryRun
+			e.Options.Parallel = serverParallel
+			e.Options.OTLPEndpoint = serverOTLPEndpoint
+
+			if serverWebhookToken == "" {
+				serverWebhookToken = os.Getenv(serverWebhookTokenEnv)
+			}
+
+			err := run("server")
+			if err != nil {
+				logrus.Errorf("command failed")
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	serverCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	serverCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
+	serverCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets Sops secrets file uses for templating")
+
+	serverCmd.Flags().StringVar(&serverListen, "listen", server.DefaultListen, "Sets the address where the server http API listens like '--listen=:8080'")
+	serverCmd.Flags().StringVar(&serverSchedule, "schedule", "", "Runs the manifests on a cron schedule like '--schedule=\"0 2 * * *\"' or '--schedule=\"@every 6h\"'")
+	serverCmd.Flags().StringVar(&serverWebhookToken, "webhook-token", "", "Runs the manifests on webhooks authenticated by this bearer token or GitHub webhook secret. Default to the UPDATECLI_WEBHOOK_TOKEN environment variable, webhooks are disabled if both are empty")
+	serverCmd.Flags().BoolVar(&serverRunOnStartup, "run-on-startup", false, "Runs the manifests as soon as the server starts like '--run-on-startup=true'")
+	serverCmd.Flags().BoolVar(&serverDryRun, "dry-run", false, "Only shows changes, like 'updatecli diff', instead of applying them like '--dry-run=true'")
+	serverCmd.Flags().BoolVar(&serverCommit, "commit", true, "Record changes to the repository, '--commit=false'")
+	serverCmd.Flags().BoolVar(&serverPush, "push", true, "Update remote refs '--push=false'")
+	serverCmd.Flags().BoolVar(&serverClean, "clean", false, "Remove updatecli working directory after each run like '--clean=true'")
+	serverCmd.Flags().IntVar(&serverParallel, "parallel", 1, "Sets the maximum number of independent pipelines run concurrently like '--parallel=4'")
+	serverCmd.Flags().StringVar(&serverOTLPEndpoint, "otlp-endpoint", "", "Exports the traces of each run to an OpenTelemetry OTLP/HTTP endpoint like '--otlp-endpoint=http://localhost:4318'. Default to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable")
+}
+
+// runServer runs the Updatecli server until it receives an interrupt signal
+func runServer() error {
+	s, err := server.New(server.Options{
+		Listen:       serverListen,
+		Schedule:     serverSchedule,
+		WebhookToken: serverWebhookToken,
+		RunOnStartup: serverRunOnStartup,
+	}, runServerManifests)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return s.Start(ctx)
+}
+
+// runServerManifests prepares then runs the manifests using a new engine, so each run starts from the manifests
+func runServerManifests(trigger string) error {
+	if logDir != "" {
+		stopRunLog, err := startRunLog("server-" + trigger)
+		if err != nil {
+			return err
+		}
+		defer stopRunLog()
+	}
+
+	runEngine := engine.Engine{Options: e.Options}
+
+	if serverClean {
+		defer func() {
+			if err := runEngine.Clean(); err != nil {
+				logrus.Errorf("error in server clean - %s", err)
+			}
+		}()
+	}
+
+	if err := runEngine.Prepare(); err != nil {
+		return err
+	}
+
+	return runEngine.Run()
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateDisableTemplating bool
+
+	validateCmd = &cobra.Command{
+		Use:   "validate",
+		Short: "validate checks manifests against the Updatecli json schema without running them",
+		Run: func(cmd *cobra.Command, args []string) {
+			e.Options.Config.ManifestFile = cfgFile
+			e.Options.Config.ValuesFiles = valuesFiles
+			e.Options.Config.SecretsFiles = secretsFiles
+			e.Options.Config.DisableTemplating = validateDisableTemplating
+
+			err := run("validate")
+			if err != nil {
+				logrus.Errorf("command failed")
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	validateCmd.Flags().StringVarP(&cfgFile, "config", "c", "", "Sets config file, directory, or remote location such as 'https://github.com/owner/repository.git@v1.0.0//updatecli.d' or 'oci://ghcr.io/owner/manifests:v1.0.0'. By default, Updatecli looks for a file named 'updatecli.yaml' or a directory named 'updatecli.d'")
+	validateCmd.Flags().StringArrayVarP(&valuesFiles, "values", "v", []string{}, "Sets values file uses for templating")
+	validateCmd.Flags().StringArrayVar(&secretsFiles, "secrets", []string{}, "Sets Sops secrets file uses for templating")
+	validateCmd.Flags().BoolVar(&validateDisableTemplating, "disable-templating", false, "Disable manifest templating")
+}
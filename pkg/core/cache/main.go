@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultTTL defines how long a cached lookup is reused by default
+const DefaultTTL = 10 * time.Minute
+
+var (
+	// Dir defines the directory where lookups, such as the tags of a container image, are cached,
+	// such as the one returned by UserCacheDir. Default to empty which disables the cache.
+	Dir string
+	// TTL defines how long a cached lookup is reused before being looked up again
+	TTL = DefaultTTL
+)
+
+// entry is the content of a cache file
+type entry struct {
+	// Created defines when the lookup was cached
+	Created time.Time `json:"created"`
+	// Value contains the json encoded lookup result
+	Value json.RawMessage `json:"value"`
+}
+
+// UserCacheDir returns the lookup cache directory located in the user cache directory,
+// such as "$XDG_CACHE_HOME/updatecli/lookups" on Linux.
+func UserCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "updatecli", "lookups"), nil
+}
+
+// Key returns a cache key built from its parts, such as a registry, a repository and a username.
+// The key is hashed into the name of the cache file, but an unsalted hash of a secret can be cracked,
+// so credentials such as passwords or tokens must never be part of it.
+func Key(parts ...string) string {
+	return strings.Join(parts, "\x00")
+}
+
+// Get decodes into value the lookup cached for key in namespace, such as "dockerimage".
+// It returns false if the cache is disabled, or if the lookup isn't cached or expired.
+func Get(namespace, key string, value interface{}) bool {
+	if Dir == "" {
+		return false
+	}
+
+	content, err := os.ReadFile(filename(namespace, key))
+	if err != nil {
+		return false
+	}
+
+	e := entry{}
+	if err := json.Unmarshal(content, &e); err != nil {
+		logrus.Debugf("ignoring invalid %s cache entry: %s", namespace, err)
+		return false
+	}
+
+	if time.Since(e.Created) > TTL {
+		return false
+	}
+
+	if err := json.Unmarshal(e.Value, value); err != nil {
+		logrus.Debugf("ignoring invalid %s cache entry: %s", namespace, err)
+		return false
+	}
+
+	logrus.Debugf("using %s lookup cached at %s", namespace, e.Created.Format(time.RFC3339))
+
+	return true
+}
+
+// Set caches value as the lookup for key in namespace.
+// Failing to write the cache is only logged, as the lookup can always be done again.
+func Set(namespace, key string, value interface{}) {
+	if Dir == "" {
+		return
+	}
+
+	if err := set(namespace, key, value); err != nil {
+		logrus.Debugf("caching %s lookup: %s", namespace, err)
+	}
+}
+
+func set(namespace, key string, value interface{}) error {
+	rawValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(entry{
+		Created: time.Now(),
+		Value:   rawValue,
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheFile := filename(namespace, key)
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0700); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so concurrent runs never read a partially written entry
+	f, err := os.CreateTemp(filepath.Dir(cacheFile), ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), cacheFile)
+}
+
+// filename returns the cache file of key in namespace
+func filename(namespace, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(Dir, namespace, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	defaultDir, defaultTTL := Dir, TTL
+	defer func() { Dir, TTL = defaultDir, defaultTTL }()
+
+	key := Key("ghcr.io/updatecli/updatecli", "username")
+
+	t.Run("disabled", func(t *testing.T) {
+		Dir = ""
+		Set("dockerimage", key, []string{"v0.1.0"})
+
+		tags := []string{}
+		assert.False(t, Get("dockerimage", key, &tags))
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		Dir, TTL = t.TempDir(), DefaultTTL
+		Set("dockerimage", key, []string{"v0.1.0", "v0.2.0"})
+
+		tags := []string{}
+		assert.True(t, Get("dockerimage", key, &tags))
+		assert.Equal(t, []string{"v0.1.0", "v0.2.0"}, tags)
+
+		assert.False(t, Get("maven", key, &tags))
+		assert.False(t, Get("dockerimage", Key("ghcr.io/updatecli/updatecli"), &tags))
+	})
+
+	t.Run("keys aren't written", func(t *testing.T) {
+		Dir, TTL = t.TempDir(), DefaultTTL
+		Set("dockerimage", key, []string{"v0.1.0"})
+
+		err := filepath.Walk(Dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			content, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.False(t, strings.Contains(string(content), "username"))
+			assert.False(t, strings.Contains(path, "username"))
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		Dir, TTL = t.TempDir(), time.Nanosecond
+		Set("dockerimage", key, []string{"v0.1.0"})
+		time.Sleep(time.Millisecond)
+
+		tags := []string{}
+		assert.False(t, Get("dockerimage", key, &tags))
+	})
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+)
+
+// remoteHTTPClient is the http client used to retrieve remote manifests and imports
+var remoteHTTPClient httpclient.HTTPClient = http.DefaultClient
+
+// resolveImports merges every manifest imported by the spec, and recursively by the imported manifests.
+// location is the manifest location, and stack the chain of manifests importing it, used to detect import cycles.
+func (s *Spec) resolveImports(option Option, location string, stack []string) error {
+	stack = append(stack, location)
+
+	for _, i := range s.Imports {
+		importLocation, err := resolveImportLocation(location, i)
+		if err != nil {
+			return fmt.Errorf("import %q: %w", i, err)
+		}
+
+		for _, l := range stack {
+			if l == importLocation {
+				return fmt.Errorf("import cycle detected: %s", strings.Join(append(stack, importLocation), " -> "))
+			}
+		}
+
+		logrus.Debugf("Importing manifest %q", importLocation)
+
+		rawManifestContent, err := readImport(importLocation)
+		if err != nil {
+			return fmt.Errorf("import %q: %w", i, err)
+		}
+
+		importOption := option
+		importOption.ManifestFile = importLocation
+
+		templatedManifestContent, err := templateManifest(importOption, rawManifestContent)
+		if err != nil {
+			return fmt.Errorf("import %q: %w", i, err)
+		}
+
+		specs := []Spec{}
+		if err = unmarshalConfigSpec(templatedManifestContent, &specs); err != nil {
+			return fmt.Errorf("import %q: %w", i, err)
+		}
+
+		for id := range specs {
+			if err = specs[id].resolveImports(option, importLocation, stack); err != nil {
+				return err
+			}
+
+			s.merge(specs[id])
+		}
+	}
+
+	return nil
+}
+
+// merge adds the scms, actions, sources, conditions, targets and notifiers of an imported spec.
+// Definitions from the importing spec take precedence over the imported ones.
+func (s *Spec) merge(imported Spec) {
+	mergeMap(&s.SCMs, imported.SCMs)
+	mergeMap(&s.Actions, imported.Actions)
+	mergeMap(&s.Sources, imported.Sources)
+	mergeMap(&s.Conditions, imported.Conditions)
+	mergeMap(&s.Targets, imported.Targets)
+	mergeMap(&s.Notifiers, imported.Notifiers)
+}
+
+func mergeMap[T any](dst *map[string]T, src map[string]T) {
+	for id := range src {
+		if *dst == nil {
+			*dst = make(map[string]T)
+		}
+		if _, ok := (*dst)[id]; ok {
+			logrus.Debugf("skipping imported %q already defined", id)
+			continue
+		}
+		(*dst)[id] = src[id]
+	}
+}
+
+// resolveImportLocation returns the location of an import, relative to the importing manifest location
+func resolveImportLocation(location, importLocation string) (string, error) {
+	if isURL(importLocation) {
+		return importLocation, nil
+	}
+
+	if isURL(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(importLocation)
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+
+	if !filepath.IsAbs(importLocation) {
+		importLocation = filepath.Join(filepath.Dir(location), importLocation)
+	}
+
+	return filepath.Abs(importLocation)
+}
+
+// readImport reads an imported manifest from a file or an http(s) URL
+func readImport(location string) ([]byte, error) {
+	if !isURL(location) {
+		return os.ReadFile(location)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := remoteHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status %q", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+)
+
+func writeManifests(t *testing.T, manifests map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range manifests {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)
+		require.NoError(t, err)
+	}
+	return dir
+}
+
+func TestResolveImports(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"shared/scm.yaml": `
+scms:
+  default:
+    kind: git
+    spec:
+      url: https://github.com/updatecli/updatecli.git
+`,
+		"shared/sources.yaml": `
+imports:
+  - scm.yaml
+sources:
+  default:
+    name: shared source
+    kind: shell
+    spec:
+      command: echo shared
+  golang:
+    kind: golang
+`,
+		"cycle/a.yaml": `
+imports:
+  - b.yaml
+`,
+		"cycle/b.yaml": `
+imports:
+  - a.yaml
+`,
+	})
+
+	t.Run("merges imports recursively without overriding local definitions", func(t *testing.T) {
+		specs := []Spec{}
+		err := unmarshalConfigSpec([]byte(`
+imports:
+  - shared/sources.yaml
+sources:
+  default:
+    name: local source
+    kind: shell
+`), &specs)
+		require.NoError(t, err)
+		spec := specs[0]
+
+		err = spec.resolveImports(Option{}, filepath.Join(dir, "updatecli.yaml"), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "local source", spec.Sources["default"].Name)
+		assert.Equal(t, "golang", spec.Sources["golang"].Kind)
+		assert.Equal(t, "git", spec.SCMs["default"].Kind)
+	})
+
+	t.Run("detects import cycles", func(t *testing.T) {
+		spec := Spec{
+			Imports: []string{"cycle/a.yaml"},
+		}
+
+		err := spec.resolveImports(Option{}, filepath.Join(dir, "updatecli.yaml"), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "import cycle detected")
+	})
+
+	t.Run("fails on missing import", func(t *testing.T) {
+		spec := Spec{
+			Imports: []string{"missing.yaml"},
+		}
+
+		err := spec.resolveImports(Option{}, filepath.Join(dir, "updatecli.yaml"), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestResolveRemoteImports(t *testing.T) {
+	defaultClient := remoteHTTPClient
+	defer func() { remoteHTTPClient = defaultClient }()
+
+	remoteManifests := map[string]string{
+		"https://example.com/shared/sources.yaml": `
+imports:
+  - scm.yaml
+sources:
+  default:
+    kind: shell
+`,
+		"https://example.com/shared/scm.yaml": `
+scms:
+  default:
+    kind: github
+`,
+	}
+
+	remoteHTTPClient = &httpclient.MockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body, ok := remoteManifests[req.URL.String()]
+			if !ok {
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Status:     "404 Not Found",
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}
+
+	spec := Spec{
+		Imports: []string{"https://example.com/shared/sources.yaml"},
+	}
+
+	err := spec.resolveImports(Option{}, "/tmp/updatecli.yaml", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shell", spec.Sources["default"].Kind)
+	assert.Equal(t, "github", spec.SCMs["default"].Kind)
+
+	spec = Spec{
+		Imports: []string{"https://example.com/missing.yaml"},
+	}
+
+	err = spec.resolveImports(Option{}, "/tmp/updatecli.yaml", nil)
+	require.Error(t, err)
+}
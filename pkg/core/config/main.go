@@ -12,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/updatecli/updatecli/pkg/core/notification"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/action"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/autodiscovery"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
@@ -57,6 +58,13 @@ type Spec struct {
 	AutoDiscovery autodiscovery.Config `yaml:",omitempty"`
 	// Title is used for the full pipeline
 	Title string `yaml:",omitempty"`
+	// DependsOn defines the pipelines, referenced by their pipelineid or name, which must run before this one
+	DependsOn []string `yaml:",omitempty"`
+	// CommitMessage defines the commit message template used by targets, this value is propagated into each target if not defined at that level
+	CommitMessage string `yaml:",omitempty"`
+	// Imports defines the manifests, as file paths relative to this manifest or http(s) URLs, whose scms, actions, sources, conditions,
+	// targets and notifiers are merged into this one. Definitions from this manifest take precedence over imported ones.
+	Imports []string `yaml:",omitempty"`
 	// !Deprecated in favor of `actions`
 	PullRequests map[string]action.Config `yaml:",omitempty" jsonschema:"-"`
 	// Actions defines the list of action configurations which need to be managed
@@ -67,8 +75,13 @@ type Spec struct {
 	Sources map[string]source.Config `yaml:",omitempty"`
 	// Conditions defines the list of condition configuration
 	Conditions map[string]condition.Config `yaml:",omitempty"`
+	// When defines the boolean expression, referencing conditions by id, that must be true to run targets,
+	// such as '(dockerTagExists || latestRelease) && !frozen'. By default, every condition must succeed.
+	When string `yaml:",omitempty"`
 	// Targets defines the list of target configuration
 	Targets map[string]target.Config `yaml:",omitempty"`
+	// Notifiers defines the notifiers receiving the pipeline report at the end of the run
+	Notifiers map[string]notification.Config `yaml:",omitempty"`
 	// Version specifies the minimum updatecli version compatible with the manifest
 	Version string `yaml:",omitempty"`
 }
@@ -95,7 +108,7 @@ func (config *Config) Reset() {
 // New reads an updatecli configuration file
 func New(option Option) (configs []Config, err error) {
 
-	dirname, basename := filepath.Split(option.ManifestFile)
+	_, basename := filepath.Split(option.ManifestFile)
 
 	// We need to be sure to generate a file checksum before we inject
 	// templates values as in some situation those values changes for each run
@@ -115,7 +128,6 @@ func New(option Option) (configs []Config, err error) {
 
 	defer c.Close()
 
-	var templatedManifestContent []byte
 	rawManifestContent, err := io.ReadAll(c)
 	if err != nil {
 		return configs, err
@@ -123,38 +135,9 @@ func New(option Option) (configs []Config, err error) {
 
 	specs := []Spec{}
 
-	if !option.DisableTemplating {
-		// Try to template manifest no matter the extension
-		// templated manifest must respect its extension before and after templating
-
-		cwd, err := os.Getwd()
-		if err != nil {
-			return configs, err
-		}
-		fs := os.DirFS(cwd)
-
-		t := Template{
-			CfgFile:      filepath.Join(dirname, basename),
-			ValuesFiles:  option.ValuesFiles,
-			SecretsFiles: option.SecretsFiles,
-			fs:           fs,
-		}
-
-		templatedManifestContent, err = t.New(rawManifestContent)
-		if err != nil {
-			logrus.Errorf("Error while templating %q:\n---\n%s\n---\n\t%s\n", option.ManifestFile, string(rawManifestContent), err.Error())
-			return configs, err
-		}
-
-		if GolangTemplatingDiff {
-			diff := text.Diff("raw manifest", "templated manifest", string(rawManifestContent), string(templatedManifestContent))
-			switch diff {
-			case "":
-				logrus.Debugln("no Golang templating detected")
-			default:
-				logrus.Debugf("Golang templating change detected:\n%s\n\n---\n", diff)
-			}
-		}
+	templatedManifestContent, err := templateManifest(option, rawManifestContent)
+	if err != nil {
+		return configs, err
 	}
 
 	switch extension := filepath.Ext(basename); extension {
@@ -170,6 +153,11 @@ func New(option Option) (configs []Config, err error) {
 		return configs, ErrConfigFileTypeNotSupported
 	}
 
+	manifestLocation, err := filepath.Abs(option.ManifestFile)
+	if err != nil {
+		return configs, err
+	}
+
 	configs = make([]Config, len(specs))
 	for id := range specs {
 
@@ -186,6 +174,11 @@ func New(option Option) (configs []Config, err error) {
 			configs[id].Spec.Version = version.Version
 		}
 
+		if err = configs[id].Spec.resolveImports(option, manifestLocation, nil); err != nil {
+			logrus.Errorf("Skipping manifest in position %q from %q: %s", id, option.ManifestFile, err.Error())
+			continue
+		}
+
 		// Ensure there is a local SCM defined as specified
 		if err = configs[id].EnsureLocalScm(); err != nil {
 			logrus.Errorf("Skipping manifest in position %q from %q: %s", id, option.ManifestFile, err.Error())
@@ -228,6 +221,53 @@ func New(option Option) (configs []Config, err error) {
 
 }
 
+// templateManifest renders the Golang templating of an Updatecli manifest, then resolves its secret references, unless disabled
+func templateManifest(option Option, rawManifestContent []byte) ([]byte, error) {
+	if option.DisableTemplating {
+		return rawManifestContent, nil
+	}
+
+	// Try to template manifest no matter the extension
+	// templated manifest must respect its extension before and after templating
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	fs := os.DirFS(cwd)
+
+	t := Template{
+		CfgFile:      filepath.Clean(option.ManifestFile),
+		ValuesFiles:  option.ValuesFiles,
+		SecretsFiles: option.SecretsFiles,
+		fs:           fs,
+	}
+
+	templatedManifestContent, err := t.New(rawManifestContent)
+	if err != nil {
+		logrus.Errorf("Error while templating %q:\n---\n%s\n---\n\t%s\n", option.ManifestFile, string(rawManifestContent), err.Error())
+		return nil, err
+	}
+
+	if GolangTemplatingDiff {
+		diff := text.Diff("raw manifest", "templated manifest", string(rawManifestContent), string(templatedManifestContent))
+		switch diff {
+		case "":
+			logrus.Debugln("no Golang templating detected")
+		default:
+			logrus.Debugf("Golang templating change detected:\n%s\n\n---\n", diff)
+		}
+	}
+
+	resolvedManifestContent, err := resolveSecrets(templatedManifestContent)
+	if err != nil {
+		logrus.Errorf("Error while resolving secrets of %q: %s", option.ManifestFile, err)
+		return nil, err
+	}
+
+	return resolvedManifestContent, nil
+}
+
 // IsManifestDifferentThanOnDisk checks if an Updatecli manifest in memory is the same than the one on disk
 func (c *Config) IsManifestDifferentThanOnDisk() (bool, error) {
 
@@ -340,6 +380,20 @@ func (config *Config) validateActions() error {
 	return nil
 }
 
+func (config *Config) validateNotifiers() error {
+	for id, n := range config.Spec.Notifiers {
+		if err := n.Validate(); err != nil {
+			logrus.Errorf("bad parameters for notifier %q", id)
+			return err
+		}
+
+		// n.Validate may modify the object during validation
+		// so we want to be sure that we save those modifications
+		config.Spec.Notifiers[id] = n
+	}
+	return nil
+}
+
 func (config *Config) validateAutodiscovery() error {
 	// Then validate that the action specifies an existing SCM
 	if len(config.Spec.AutoDiscovery.ScmId) > 0 {
@@ -406,7 +460,8 @@ func (config *Config) validateTargets() error {
 		}
 
 		// Only check/guess the sourceID if the user did not disable it (default is enabled)
-		if !t.DisableSourceInput {
+		// or compose the target input from several sources
+		if !t.DisableSourceInput && len(t.SourceValue) == 0 {
 			// Try to guess SourceID
 			if len(t.SourceID) == 0 && len(config.Spec.Sources) > 1 {
 
@@ -467,6 +522,22 @@ func (config *Config) validateConditions() error {
 		config.Spec.Conditions[id] = c
 
 	}
+
+	if len(config.Spec.When) > 0 {
+		expression, err := condition.ParseExpression(config.Spec.When)
+		if err != nil {
+			logrus.Errorln(err)
+			return ErrBadConfig
+		}
+
+		for _, id := range expression.IDs() {
+			if _, ok := config.Spec.Conditions[id]; !ok {
+				logrus.Errorf("the condition %q referenced by %q does not exist", id, "when")
+				return ErrBadConfig
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -495,6 +566,13 @@ func (config *Config) Validate() error {
 			fmt.Errorf("actions validation error:\n%s", err))
 	}
 
+	err = config.validateNotifiers()
+	if err != nil {
+		errs = append(
+			errs,
+			fmt.Errorf("notifiers validation error:\n%s", err))
+	}
+
 	err = config.validateAutodiscovery()
 	if err != nil {
 		errs = append(
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+const (
+	// gitManifestPrefix forces a manifest location to be handled as a git repository
+	gitManifestPrefix = "git::"
+	// ociManifestPrefix identifies a manifest location as an OCI artifact
+	ociManifestPrefix = "oci://"
+	// ociTitleAnnotation is the OCI annotation defining the filename of an artifact layer
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+// remoteManifestGitHandler is the git client used to retrieve manifests from git repositories
+var remoteManifestGitHandler gitgeneric.GitHandler = gitgeneric.GoGit{}
+
+// IsRemoteManifest returns true if the manifest location references a git repository,
+// an http(s) URL or an OCI artifact, instead of a local file or directory
+func IsRemoteManifest(location string) bool {
+	return strings.HasPrefix(location, gitManifestPrefix) ||
+		strings.HasPrefix(location, ociManifestPrefix) ||
+		isURL(location) ||
+		strings.HasPrefix(location, "git@")
+}
+
+// FetchRemoteManifest retrieves the manifests of a remote location into the directory dir,
+// and returns their local path. Supported locations are:
+//   - a git repository such as "https://github.com/owner/repository.git@v1.0.0//updatecli.d",
+//     where both the reference and the path are optional. The "git::" prefix can be used for URLs not ending with ".git"
+//   - an OCI artifact such as "oci://ghcr.io/owner/manifests:v1.0.0", where every layer annotated with a
+//     filename is written to dir
+//   - an http(s) URL of a single manifest file such as "https://example.com/updatecli.yaml"
+func FetchRemoteManifest(location, dir string) (string, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.HasPrefix(location, ociManifestPrefix):
+		return fetchOCIManifest(strings.TrimPrefix(location, ociManifestPrefix), dir)
+	case isGitManifest(location):
+		return fetchGitManifest(strings.TrimPrefix(location, gitManifestPrefix), dir)
+	default:
+		return fetchHTTPManifest(location, dir)
+	}
+}
+
+func isGitManifest(location string) bool {
+	if strings.HasPrefix(location, gitManifestPrefix) || strings.HasPrefix(location, "git@") {
+		return true
+	}
+
+	repository, _, _ := parseGitManifestLocation(location)
+	return strings.HasSuffix(repository, ".git")
+}
+
+// parseGitManifestLocation splits a git manifest location such as "https://github.com/owner/repository.git@v1.0.0//updatecli.d"
+// into the repository URL, the git reference and the path inside the repository
+func parseGitManifestLocation(location string) (repository, ref, manifestPath string) {
+	repository = location
+
+	// Skip the scheme separator of the URL
+	start := 0
+	if i := strings.Index(repository, "://"); i >= 0 {
+		start = i + len("://")
+	}
+
+	if i := strings.Index(repository[start:], "//"); i >= 0 {
+		manifestPath = repository[start+i+len("//"):]
+		repository = repository[:start+i]
+	}
+
+	// The reference separator must follow the last path element, so the "git@" user of ssh URLs isn't a reference
+	if i := strings.LastIndex(repository, "@"); i > strings.LastIndexAny(repository, "/:") {
+		ref = repository[i+1:]
+		repository = repository[:i]
+	}
+
+	return repository, ref, manifestPath
+}
+
+func fetchGitManifest(location, dir string) (string, error) {
+	repository, ref, manifestPath := parseGitManifestLocation(location)
+
+	logrus.Debugf("fetching manifests from git repository %q at %q", repository, ref)
+
+	switch ref {
+	case "":
+		if err := remoteManifestGitHandler.Clone("", "", repository, dir); err != nil {
+			return "", err
+		}
+	default:
+		commit, err := remoteManifestGitHandler.RemoteRefHash("", "", repository, ref)
+		if err != nil {
+			// The reference may already be a commit hash
+			if !plumbing.IsHash(ref) {
+				return "", fmt.Errorf("resolving git reference %q: %w", ref, err)
+			}
+			commit = ref
+		}
+
+		if err := remoteManifestGitHandler.CloneCommit("", "", repository, commit, dir); err != nil {
+			return "", err
+		}
+	}
+
+	localPath, err := securePath(dir, manifestPath)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(localPath); err != nil {
+		return "", fmt.Errorf("manifest path %q not found in git repository %q: %w", manifestPath, repository, err)
+	}
+
+	return localPath, nil
+}
+
+func fetchHTTPManifest(location, dir string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+
+	filename := path.Base(u.Path)
+	if filename == "." || filename == "/" {
+		return "", fmt.Errorf("no manifest filename found in URL %q", location)
+	}
+
+	content, err := readImport(location)
+	if err != nil {
+		return "", err
+	}
+
+	localPath := filepath.Join(dir, filename)
+	if err := os.WriteFile(localPath, content, 0600); err != nil {
+		return "", err
+	}
+
+	return localPath, nil
+}
+
+func fetchOCIManifest(location, dir string) (string, error) {
+	ref, err := name.ParseReference(location)
+	if err != nil {
+		return "", err
+	}
+
+	image, err := remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", err
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return "", err
+	}
+
+	found := 0
+	for _, descriptor := range manifest.Layers {
+		filename := descriptor.Annotations[ociTitleAnnotation]
+		if filename == "" {
+			continue
+		}
+
+		localPath, err := securePath(dir, filename)
+		if err != nil {
+			return "", err
+		}
+
+		layer, err := image.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return "", err
+		}
+
+		err = writeLayer(layer.Uncompressed, localPath)
+		if err != nil {
+			return "", fmt.Errorf("writing %q: %w", filename, err)
+		}
+		found++
+	}
+
+	if found == 0 {
+		return "", fmt.Errorf("no manifest found in OCI artifact %q, layers must be annotated with %q", location, ociTitleAnnotation)
+	}
+
+	return dir, nil
+}
+
+func writeLayer(open func() (io.ReadCloser, error), localPath string) error {
+	rc, err := open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(localPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, rc)
+	return err
+}
+
+// securePath joins a remote relative path to dir, ensuring it doesn't escape dir
+func securePath(dir, remotePath string) (string, error) {
+	localPath := filepath.Join(dir, filepath.FromSlash(remotePath))
+
+	rel, err := filepath.Rel(dir, localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of the remote manifest location", remotePath)
+	}
+
+	return localPath, nil
+}
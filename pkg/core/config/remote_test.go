@@ -0,0 +1,172 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+func TestParseGitManifestLocation(t *testing.T) {
+	dataset := []struct {
+		location           string
+		expectedRepository string
+		expectedRef        string
+		expectedPath       string
+	}{
+		{
+			location:           "https://github.com/updatecli/policies.git",
+			expectedRepository: "https://github.com/updatecli/policies.git",
+		},
+		{
+			location:           "https://github.com/updatecli/policies.git@v1.0.0//updatecli.d/golang.yaml",
+			expectedRepository: "https://github.com/updatecli/policies.git",
+			expectedRef:        "v1.0.0",
+			expectedPath:       "updatecli.d/golang.yaml",
+		},
+		{
+			location:           "git@github.com:updatecli/policies.git//updatecli.d",
+			expectedRepository: "git@github.com:updatecli/policies.git",
+			expectedPath:       "updatecli.d",
+		},
+		{
+			location:           "git@github.com:updatecli/policies.git@main",
+			expectedRepository: "git@github.com:updatecli/policies.git",
+			expectedRef:        "main",
+		},
+	}
+
+	for _, d := range dataset {
+		t.Run(d.location, func(t *testing.T) {
+			repository, ref, manifestPath := parseGitManifestLocation(d.location)
+			assert.Equal(t, d.expectedRepository, repository)
+			assert.Equal(t, d.expectedRef, ref)
+			assert.Equal(t, d.expectedPath, manifestPath)
+		})
+	}
+}
+
+func TestIsRemoteManifest(t *testing.T) {
+	assert.True(t, IsRemoteManifest("https://example.com/updatecli.yaml"))
+	assert.True(t, IsRemoteManifest("git::https://example.com/policies"))
+	assert.True(t, IsRemoteManifest("git@github.com:updatecli/policies.git"))
+	assert.True(t, IsRemoteManifest("oci://ghcr.io/updatecli/policies:v1.0.0"))
+	assert.False(t, IsRemoteManifest("updatecli.d"))
+	assert.False(t, IsRemoteManifest("/tmp/updatecli.yaml"))
+}
+
+// mockGitHandler writes a manifest in the cloned directory instead of accessing a git repository
+type mockGitHandler struct {
+	gitgeneric.GitHandler
+	clonedCommit string
+}
+
+func (m *mockGitHandler) Clone(username, password, URL, workingDir string) error {
+	return m.CloneCommit(username, password, URL, "", workingDir)
+}
+
+func (m *mockGitHandler) RemoteRefHash(username, password, URL, ref string) (string, error) {
+	return "0123456789abcdef0123456789abcdef01234567", nil
+}
+
+func (m *mockGitHandler) CloneCommit(username, password, URL, commit, workingDir string) error {
+	m.clonedCommit = commit
+	if err := os.MkdirAll(filepath.Join(workingDir, "updatecli.d"), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(workingDir, "updatecli.d", "golang.yaml"), []byte("name: golang\n"), 0600)
+}
+
+func TestFetchGitManifest(t *testing.T) {
+	defaultHandler := remoteManifestGitHandler
+	defer func() { remoteManifestGitHandler = defaultHandler }()
+
+	handler := &mockGitHandler{}
+	remoteManifestGitHandler = handler
+
+	dir := t.TempDir()
+
+	localPath, err := FetchRemoteManifest("https://github.com/updatecli/policies.git@v1.0.0//updatecli.d", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "updatecli.d"), localPath)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", handler.clonedCommit)
+
+	_, err = FetchRemoteManifest("https://github.com/updatecli/policies.git//missing", dir)
+	require.Error(t, err)
+
+	_, err = FetchRemoteManifest("https://github.com/updatecli/policies.git//../../etc", dir)
+	require.Error(t, err)
+}
+
+func TestFetchHTTPManifest(t *testing.T) {
+	defaultClient := remoteHTTPClient
+	defer func() { remoteHTTPClient = defaultClient }()
+
+	remoteHTTPClient = &httpclient.MockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("name: remote\n")),
+			}, nil
+		},
+	}
+
+	dir := t.TempDir()
+
+	localPath, err := FetchRemoteManifest("https://example.com/policies/updatecli.yaml", dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "updatecli.yaml"), localPath)
+
+	content, err := os.ReadFile(localPath)
+	require.NoError(t, err)
+	assert.Equal(t, "name: remote\n", string(content))
+}
+
+func TestFetchOCIManifest(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	location := strings.TrimPrefix(server.URL, "http://") + "/updatecli/policies:v1.0.0"
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte("name: oci\n"))), nil
+	})
+	require.NoError(t, err)
+
+	image, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: layer,
+		Annotations: map[string]string{
+			ociTitleAnnotation: "updatecli.d/oci.yaml",
+		},
+	})
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(location)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, image))
+
+	dir := t.TempDir()
+
+	localPath, err := FetchRemoteManifest("oci://"+location, dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, localPath)
+
+	content, err := os.ReadFile(filepath.Join(dir, "updatecli.d", "oci.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: oci\n", string(content))
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/updatecli/updatecli/pkg/core/secret"
+	"gopkg.in/yaml.v3"
+)
+
+// resolveSecrets returns the manifest content with every secret reference, such as "vault://secret/data/updatecli#token",
+// replaced by its value. The content is returned as it is if it doesn't reference any secret.
+func resolveSecrets(content []byte) ([]byte, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+
+	documents := []*yaml.Node{}
+	found := false
+	for {
+		document := yaml.Node{}
+		if err := dec.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		resolved, err := resolveNodeSecrets(&document)
+		if err != nil {
+			return nil, err
+		}
+		found = found || resolved
+
+		documents = append(documents, &document)
+	}
+
+	if !found {
+		return content, nil
+	}
+
+	b := bytes.Buffer{}
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(YAMLSetIdent)
+
+	for _, document := range documents {
+		if err := enc.Encode(document); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// resolveNodeSecrets replaces the secret references of the string values of node, and of its children,
+// then returns true if at least one was found
+func resolveNodeSecrets(node *yaml.Node) (bool, error) {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag != "!!str" || !secret.IsReference(node.Value) {
+			return false, nil
+		}
+
+		value, err := secret.Resolve(node.Value)
+		if err != nil {
+			return false, err
+		}
+
+		node.Value = value
+		// Ensure the value is still decoded as a string, such as a numeric token
+		node.Style = yaml.DoubleQuotedStyle
+
+		return true, nil
+	}
+
+	found := false
+	for i, child := range node.Content {
+		// Mapping keys are never resolved
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+
+		resolved, err := resolveNodeSecrets(child)
+		if err != nil {
+			return false, err
+		}
+		found = found || resolved
+	}
+
+	return found, nil
+}
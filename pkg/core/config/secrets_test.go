@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("UPDATECLI_TEST_TOKEN", "012345")
+
+	content := []byte(`name: secrets
+scms:
+  default:
+    kind: github
+    spec:
+      owner: updatecli
+      token: env://UPDATECLI_TEST_TOKEN
+---
+name: no secrets
+sources:
+  env://UPDATECLI_TEST_TOKEN:
+    kind: shell
+`)
+
+	resolved, err := resolveSecrets(content)
+	require.NoError(t, err)
+
+	specs := []Spec{}
+	require.NoError(t, unmarshalConfigSpec(resolved, &specs))
+	require.Len(t, specs, 2)
+
+	spec := specs[0].SCMs["default"].Spec.(map[string]interface{})
+	assert.Equal(t, "012345", spec["token"])
+	assert.Equal(t, "updatecli", spec["owner"])
+
+	// Mapping keys aren't resolved
+	assert.Contains(t, specs[1].Sources, "env://UPDATECLI_TEST_TOKEN")
+
+	// Content without secret references is kept as it is
+	content = []byte("name: no secrets\n")
+	resolved, err = resolveSecrets(content)
+	require.NoError(t, err)
+	assert.Equal(t, content, resolved)
+
+	_, err = resolveSecrets([]byte("token: env://UPDATECLI_TEST_UNDEFINED_TOKEN\n"))
+	assert.Error(t, err)
+}
@@ -70,6 +70,40 @@ var (
       `,
 			ExpectedManifest: `
       hello BAR
+      `,
+		},
+
+		{
+			ID: "environment function",
+			ManifestTemplate: `
+      hello {{ env "FOO" }}{{ env "UNDEFINED" }}
+      `,
+			ExpectedManifest: `
+      hello BAR
+      `,
+		},
+
+		{
+			ID: "render one document per value",
+			ManifestTemplate: `
+      {{- range $name, $version := .tools }}
+      ---
+      name: {{ $name }}
+      version: {{ $version }}
+      {{- end }}
+      `,
+			Values1: `
+      tools:
+        golang: 1.21.0
+        nodejs: 20.5.0
+      `,
+			ExpectedManifest: `
+      ---
+      name: golang
+      version: 1.21.0
+      ---
+      name: nodejs
+      version: 20.5.0
       `,
 		},
 	}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	jschema "github.com/invopop/jsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/updatecli/updatecli/pkg/core/jsonschema"
+)
+
+var (
+	manifestSchema     *jschema.Schema
+	manifestSchemaOnce sync.Once
+)
+
+// ValidateManifest checks an Updatecli manifest against the Updatecli json schema
+// and returns every unknown field, type mismatch and missing required field, with its position.
+func ValidateManifest(option Option) ([]jsonschema.ValidationError, error) {
+
+	switch filepath.Ext(option.ManifestFile) {
+	case ".tpl", ".tmpl", ".yaml", ".yml", ".json":
+	default:
+		return nil, ErrConfigFileTypeNotSupported
+	}
+
+	rawManifestContent, err := os.ReadFile(option.ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	templatedManifestContent, err := templateManifest(option, rawManifestContent)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestSchemaOnce.Do(func() {
+		manifestSchema = jsonschema.Reflect(&Spec{})
+
+		// The pipeline name defaults to the manifest filename
+		required := []string{}
+		for _, field := range manifestSchema.Required {
+			if field != "name" {
+				required = append(required, field)
+			}
+		}
+		manifestSchema.Required = required
+	})
+
+	var validationErrors []jsonschema.ValidationError
+
+	dec := yaml.NewDecoder(bytes.NewReader(templatedManifestContent))
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return validationErrors, err
+		}
+
+		validationErrors = append(validationErrors, jsonschema.Validate(manifestSchema, &node)...)
+	}
+
+	return validationErrors, nil
+}
@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+)
+
+// Explain loads every pipeline, once templated and defaulted, then prints how each of them is resolved,
+// without running the prepare stage or any resource:
+// the scms with their branches, the sources, conditions and targets in their run order
+// with their input and dependencies, and the targets of each action.
+func (e *Engine) Explain() error {
+	err := e.LoadConfigurations()
+
+	if len(e.Pipelines) == 0 {
+		logrus.Errorln(err)
+		return fmt.Errorf("no valid pipeline found")
+	}
+
+	// Don't exit if we identify at least one valid pipeline configuration
+	if err != nil {
+		logrus.Errorln(err)
+		logrus.Infof("\n%d pipeline(s) successfully loaded\n", len(e.Pipelines))
+	}
+
+	for i := range e.Pipelines {
+		if err := writeExplanation(os.Stdout, &e.Pipelines[i]); err != nil {
+			return fmt.Errorf("explaining pipeline %q: %w", e.Pipelines[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// writeExplanation writes to w how the pipeline p is resolved
+func writeExplanation(w io.Writer, p *pipeline.Pipeline) error {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("Pipeline %q (pipelineid: %q)\n", p.Name, p.ID))
+
+	if len(p.SCMs) > 0 {
+		b.WriteString("\nSCMs:\n")
+		for _, id := range sortedKeys(p.SCMs) {
+			s := p.SCMs[id]
+			b.WriteString(fmt.Sprintf("  %s (kind: %s)\n", id, s.Config.Kind))
+
+			if s.Handler == nil {
+				continue
+			}
+
+			sourceBranch, workingBranch, targetBranch := s.Handler.GetBranches()
+			b.WriteString(fmt.Sprintf("     directory: %s\n", s.Handler.GetDirectory()))
+			b.WriteString(fmt.Sprintf("     branches: source %q, working %q, target %q\n", sourceBranch, workingBranch, targetBranch))
+		}
+	}
+
+	sourceIDs, err := pipeline.SortedSourcesKeys(&p.Sources)
+	if err != nil {
+		return err
+	}
+
+	if len(sourceIDs) > 0 {
+		b.WriteString("\nSources (run order):\n")
+		for i, id := range sourceIDs {
+			writeResourceExplanation(&b, i+1, id, p.Sources[id].Config.ResourceConfig)
+		}
+	}
+
+	conditionIDs, err := pipeline.SortedConditionsKeys(&p.Conditions)
+	if err != nil {
+		return err
+	}
+
+	if len(conditionIDs) > 0 {
+		b.WriteString("\nConditions (run order):\n")
+		for i, id := range conditionIDs {
+			c := p.Conditions[id].Config
+			writeResourceExplanation(&b, i+1, id, c.ResourceConfig)
+
+			input := fmt.Sprintf("source %q", c.SourceID)
+			if c.DisableSourceInput {
+				input = "disabled"
+			}
+			b.WriteString(fmt.Sprintf("     input: %s\n", input))
+
+			if c.FailWhen {
+				b.WriteString("     fails when the condition passes\n")
+			}
+		}
+	}
+
+	targetIDs, err := pipeline.SortedTargetsKeys(&p.Targets)
+	if err != nil {
+		return err
+	}
+
+	if len(targetIDs) > 0 {
+		b.WriteString("\nTargets (run order):\n")
+		for i, id := range targetIDs {
+			t := p.Targets[id].Config
+			writeResourceExplanation(&b, i+1, id, t.ResourceConfig)
+
+			var input string
+			switch {
+			case t.DisableSourceInput:
+				input = "disabled"
+			case t.SourceValue != "":
+				input = fmt.Sprintf("sourcevalue %q", t.SourceValue)
+			default:
+				input = fmt.Sprintf("source %q", t.SourceID)
+			}
+			b.WriteString(fmt.Sprintf("     input: %s\n", input))
+
+			for _, hook := range t.PostHooks {
+				b.WriteString(fmt.Sprintf("     post hook: %q\n", hook.Command))
+			}
+		}
+	}
+
+	if len(p.Actions) > 0 {
+		b.WriteString("\nActions:\n")
+		for _, id := range sortedKeys(p.Actions) {
+			a := p.Actions[id]
+			b.WriteString(fmt.Sprintf("  %s (kind: %s)\n", id, a.Config.Kind))
+			b.WriteString(fmt.Sprintf("     scm: %s\n", a.Config.ScmID))
+
+			targets, err := p.SearchAssociatedTargetsID(id)
+			if err != nil {
+				return err
+			}
+			sort.Strings(targets)
+			b.WriteString(fmt.Sprintf("     targets: %s\n", strings.Join(targets, ", ")))
+		}
+	}
+
+	b.WriteString("\n")
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// writeResourceExplanation writes to b the resource configuration shared by sources, conditions and targets
+func writeResourceExplanation(b *strings.Builder, position int, id string, r resource.ResourceConfig) {
+	b.WriteString(fmt.Sprintf("  %d. %s (kind: %s)\n", position, id, r.Kind))
+
+	if r.Name != "" {
+		b.WriteString(fmt.Sprintf("     name: %q\n", r.Name))
+	}
+
+	if r.SCMID != "" {
+		b.WriteString(fmt.Sprintf("     scm: %s\n", r.SCMID))
+	}
+
+	if len(r.DependsOn) > 0 {
+		b.WriteString(fmt.Sprintf("     depends on: %s\n", strings.Join(r.DependsOn, ", ")))
+	}
+}
+
+// sortedKeys returns the keys of m in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,66 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/source"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
+)
+
+func TestWriteExplanation(t *testing.T) {
+	p := pipeline.Pipeline{
+		Name: "Explain",
+		ID:   "explain",
+		Sources: map[string]source.Source{
+			"latest": {Config: source.Config{
+				ResourceConfig: resource.ResourceConfig{Kind: "shell", Name: "Get latest version"},
+			}},
+		},
+		Conditions: map[string]condition.Condition{
+			"check": {Config: condition.Config{
+				ResourceConfig:     resource.ResourceConfig{Kind: "shell"},
+				DisableSourceInput: true,
+			}},
+		},
+		Targets: map[string]target.Target{
+			"docs": {Config: target.Config{
+				ResourceConfig: resource.ResourceConfig{Kind: "file", DependsOn: []string{"readme"}},
+				SourceValue:    "v1.0.0",
+			}},
+			"readme": {Config: target.Config{
+				ResourceConfig: resource.ResourceConfig{Kind: "file"},
+				SourceID:       "latest",
+				PostHooks:      []target.PostHook{{Command: "make docs"}},
+			}},
+		},
+	}
+
+	var b strings.Builder
+	require.NoError(t, writeExplanation(&b, &p))
+
+	assert.Equal(t, `Pipeline "Explain" (pipelineid: "explain")
+
+Sources (run order):
+  1. latest (kind: shell)
+     name: "Get latest version"
+
+Conditions (run order):
+  1. check (kind: shell)
+     input: disabled
+
+Targets (run order):
+  1. readme (kind: file)
+     input: source "latest"
+     post hook: "make docs"
+  2. docs (kind: file)
+     depends on: readme
+     input: sourcevalue "v1.0.0"
+
+`, b.String())
+}
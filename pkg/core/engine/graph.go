@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+)
+
+const (
+	// GraphFormatDOT defines the Graphviz DOT format of the pipelines graph
+	GraphFormatDOT = "dot"
+	// GraphFormatMermaid defines the Mermaid flowchart format of the pipelines graph
+	GraphFormatMermaid = "mermaid"
+)
+
+var (
+	// ErrPipelineDependsOnNotFound is returned when a pipeline depends on a nonexistent pipeline
+	ErrPipelineDependsOnNotFound = errors.New("pipeline dependency not found")
+	// ErrPipelineDependsOnLoop is returned when pipelines depend on each other
+	ErrPipelineDependsOnLoop = errors.New("pipeline dependency loop detected")
+	// ErrGraphFormatNotSupported is returned when the pipelines graph format is not supported
+	ErrGraphFormatNotSupported = errors.New("graph format not supported")
+)
+
+// pipelineDependencies returns the indexes of the pipelines each pipeline depends on,
+// matching its dependsOn values with the pipelines id or name
+func pipelineDependencies(pipelines []pipeline.Pipeline) ([][]int, error) {
+	dependencies := make([][]int, len(pipelines))
+
+	for i := range pipelines {
+		if pipelines[i].Config == nil {
+			continue
+		}
+
+		for _, dependsOn := range pipelines[i].Config.Spec.DependsOn {
+			found := false
+			for j := range pipelines {
+				if j == i || (pipelines[j].ID != dependsOn && pipelines[j].Name != dependsOn) {
+					continue
+				}
+				found = true
+				dependencies[i] = append(dependencies[i], j)
+			}
+
+			if !found {
+				return nil, fmt.Errorf("%w: pipeline %q depends on %q", ErrPipelineDependsOnNotFound, pipelines[i].Name, dependsOn)
+			}
+		}
+	}
+
+	return dependencies, nil
+}
+
+// sortPipelines returns the pipelines indexes ordered so that every pipeline comes after its dependencies,
+// otherwise preserving the pipelines order
+func sortPipelines(pipelines []pipeline.Pipeline, dependencies [][]int) ([]int, error) {
+	// dependents holds the pipelines depending on each pipeline
+	dependents := make([][]int, len(pipelines))
+	// remaining holds the number of dependencies not yet ordered of each pipeline
+	remaining := make([]int, len(pipelines))
+
+	for i := range dependencies {
+		for _, j := range dependencies[i] {
+			dependents[j] = append(dependents[j], i)
+			remaining[i]++
+		}
+	}
+
+	ready := []int{}
+	for i := range pipelines {
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	order := []int{}
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+
+		order = append(order, i)
+		for _, j := range dependents[i] {
+			remaining[j]--
+			if remaining[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	if len(order) < len(pipelines) {
+		loop := []string{}
+		for i := range pipelines {
+			if remaining[i] > 0 {
+				loop = append(loop, fmt.Sprintf("%q", pipelines[i].Name))
+			}
+		}
+		return nil, fmt.Errorf("%w between pipelines %s", ErrPipelineDependsOnLoop, strings.Join(loop, ", "))
+	}
+
+	return order, nil
+}
+
+// Graph loads every pipeline then prints their dependency graph, using format, to the standard output
+func (e *Engine) Graph(format string) error {
+	err := e.LoadConfigurations()
+
+	if len(e.Pipelines) == 0 {
+		logrus.Errorln(err)
+		return fmt.Errorf("no valid pipeline found")
+	}
+
+	// Don't exit if we identify at least one valid pipeline configuration
+	if err != nil {
+		logrus.Errorln(err)
+		logrus.Infof("\n%d pipeline(s) successfully loaded\n", len(e.Pipelines))
+	}
+
+	dependencies, err := pipelineDependencies(e.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sortPipelines(e.Pipelines, dependencies); err != nil {
+		return err
+	}
+
+	return writeGraph(os.Stdout, e.Pipelines, dependencies, format)
+}
+
+// writeGraph writes the dependency graph of pipelines to w, using format.
+// Edges go from a pipeline to the pipelines depending on it.
+func writeGraph(w io.Writer, pipelines []pipeline.Pipeline, dependencies [][]int, format string) error {
+	var b strings.Builder
+
+	switch format {
+	case GraphFormatDOT:
+		b.WriteString("digraph updatecli {\n")
+		for i := range pipelines {
+			b.WriteString(fmt.Sprintf("  p%d [label=%q];\n", i, pipelines[i].Name))
+		}
+		for i := range dependencies {
+			for _, j := range dependencies[i] {
+				b.WriteString(fmt.Sprintf("  p%d -> p%d;\n", j, i))
+			}
+		}
+		b.WriteString("}\n")
+
+	case GraphFormatMermaid:
+		b.WriteString("flowchart TD\n")
+		for i := range pipelines {
+			b.WriteString(fmt.Sprintf("  p%d[%q]\n", i, strings.ReplaceAll(pipelines[i].Name, `"`, "#quot;")))
+		}
+		for i := range dependencies {
+			for _, j := range dependencies[i] {
+				b.WriteString(fmt.Sprintf("  p%d --> p%d\n", j, i))
+			}
+		}
+
+	default:
+		return fmt.Errorf("%w: %q, accepted values are %q and %q", ErrGraphFormatNotSupported, format, GraphFormatDOT, GraphFormatMermaid)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
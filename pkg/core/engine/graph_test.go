@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+)
+
+func newTestPipeline(name, id string, dependsOn ...string) pipeline.Pipeline {
+	return pipeline.Pipeline{
+		Name: name,
+		ID:   id,
+		Config: &config.Config{
+			Spec: config.Spec{
+				Name:       name,
+				PipelineID: id,
+				DependsOn:  dependsOn,
+			},
+		},
+	}
+}
+
+func TestSortPipelines(t *testing.T) {
+	testData := []struct {
+		name                 string
+		pipelines            []pipeline.Pipeline
+		expectedDependencies [][]int
+		expectedOrder        []int
+		expectedErr          error
+	}{
+		{
+			name: "Pipelines without dependencies keep their order",
+			pipelines: []pipeline.Pipeline{
+				newTestPipeline("a", "1"),
+				newTestPipeline("b", "2"),
+			},
+			expectedDependencies: [][]int{nil, nil},
+			expectedOrder:        []int{0, 1},
+		},
+		{
+			name: "Dependencies referenced by name and pipelineid run first",
+			pipelines: []pipeline.Pipeline{
+				newTestPipeline("a", "1", "c"),
+				newTestPipeline("b", "2"),
+				newTestPipeline("c", "3", "2"),
+			},
+			expectedDependencies: [][]int{{2}, nil, {1}},
+			expectedOrder:        []int{1, 2, 0},
+		},
+		{
+			name: "Dependency not found",
+			pipelines: []pipeline.Pipeline{
+				newTestPipeline("a", "1", "d"),
+			},
+			expectedErr: ErrPipelineDependsOnNotFound,
+		},
+		{
+			name: "Dependency loop",
+			pipelines: []pipeline.Pipeline{
+				newTestPipeline("a", "1", "b"),
+				newTestPipeline("b", "2", "a"),
+				newTestPipeline("c", "3"),
+			},
+			expectedDependencies: [][]int{{1}, {0}, nil},
+			expectedErr:          ErrPipelineDependsOnLoop,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			dependencies, err := pipelineDependencies(tt.pipelines)
+			if tt.expectedDependencies == nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDependencies, dependencies)
+
+			order, err := sortPipelines(tt.pipelines, dependencies)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
+func TestWriteGraph(t *testing.T) {
+	pipelines := []pipeline.Pipeline{
+		newTestPipeline("Bump app", "1", "Bump \"base\" image"),
+		newTestPipeline("Bump \"base\" image", "2"),
+	}
+	dependencies := [][]int{{1}, nil}
+
+	testData := []struct {
+		format      string
+		expected    string
+		expectedErr error
+	}{
+		{
+			format: GraphFormatDOT,
+			expected: `digraph updatecli {
+  p0 [label="Bump app"];
+  p1 [label="Bump \"base\" image"];
+  p1 -> p0;
+}
+`,
+		},
+		{
+			format: GraphFormatMermaid,
+			expected: `flowchart TD
+  p0["Bump app"]
+  p1["Bump #quot;base#quot; image"]
+  p1 --> p0
+`,
+		},
+		{
+			format:      "yaml",
+			expectedErr: ErrGraphFormatNotSupported,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.format, func(t *testing.T) {
+			var b strings.Builder
+			err := writeGraph(&b, pipelines, dependencies, tt.format)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, b.String())
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/updatecli/updatecli/pkg/core/cmdoptions"
 	"github.com/updatecli/updatecli/pkg/core/config"
 	"github.com/updatecli/updatecli/pkg/core/jsonschema"
+	"github.com/updatecli/updatecli/pkg/core/notification"
 	"github.com/updatecli/updatecli/pkg/core/pipeline"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/action"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/autodiscovery"
@@ -39,6 +40,8 @@ type Engine struct {
 	Pipelines      []pipeline.Pipeline
 	Options        Options
 	Reports        reports.Reports
+	// pipelineErrors holds the error of every pipeline run, in the pipelines order
+	pipelineErrors []error
 }
 
 // Clean remove every traces from an updatecli run.
@@ -200,7 +203,10 @@ func (e *Engine) LoadConfigurations() error {
 	// Read every strategy files
 	errs := []error{}
 
-	manifestFiles := GetFiles(e.Options.Config.ManifestFile)
+	manifestFiles, err := e.manifestFiles()
+	if err != nil {
+		return err
+	}
 
 	if len(manifestFiles) == 0 {
 		return ErrNoManifestDetected
@@ -268,17 +274,12 @@ func (e *Engine) Run() (err error) {
 	logrus.Infof("+ %s +\n", strings.ToTitle("Pipeline"))
 	logrus.Infof("%s\n\n", strings.Repeat("+", len("Pipeline")+4))
 
-	for _, pipeline := range e.Pipelines {
+	endTelemetry := e.startTelemetry()
+	defer func() { endTelemetry(err) }()
 
-		err := pipeline.Run()
-
-		e.Reports = append(e.Reports, pipeline.Report)
-
-		if err != nil {
-			logrus.Printf("Pipeline %q failed\n", pipeline.Title)
-			logrus.Printf("Skipping due to:\n\t%s\n", err)
-			continue
-		}
+	err = e.runPipelines()
+	if err != nil {
+		return err
 	}
 
 	err = e.Reports.Show()
@@ -298,6 +299,17 @@ func (e *Engine) Run() (err error) {
 	logrus.Infof("  * Succeeded:\t%d", totalSuccessPipeline)
 	logrus.Infof("  * Total:\t%d", totalPipeline)
 
+	runReport := e.runReport()
+
+	if e.Options.ReportFile != "" {
+		if err := reports.WriteFile(e.Options.ReportFile, runReport); err != nil {
+			return fmt.Errorf("write report file %q: %w", e.Options.ReportFile, err)
+		}
+		logrus.Infof("\nReport written to %q", e.Options.ReportFile)
+	}
+
+	e.notify(runReport)
+
 	// Exit on error if at least one pipeline failed
 	if totalFailedPipeline > 0 {
 		return fmt.Errorf("%d over %d pipeline failed", totalFailedPipeline, totalPipeline)
@@ -359,6 +371,26 @@ func GenerateSchema(baseSchemaID, schemaDir string) error {
 	return s.GenerateSchema(&config.Spec{})
 }
 
+// ManifestSchema exports the Updatecli manifest json schema, such as used by editors, to stdout or to a file
+func ManifestSchema(baseSchemaID, output string) error {
+	s := jsonschema.New(baseSchemaID, "")
+	s.ReflectSchema(&config.Spec{})
+
+	if output == "" {
+		fmt.Println(s)
+		return nil
+	}
+
+	err := os.WriteFile(output, []byte(s.String()), 0600)
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("Json schema exported to %q", output)
+
+	return nil
+}
+
 // LoadAutoDiscovery tries to guess available pipelines based on specific directory
 func (e *Engine) LoadAutoDiscovery(defaultEnabled bool) error {
 	// Default Autodiscovery pipeline
@@ -558,3 +590,55 @@ func (e *Engine) LoadAutoDiscovery(defaultEnabled bool) error {
 	return nil
 
 }
+
+// runReport returns the machine-readable report of the pipelines run
+func (e *Engine) runReport() reports.RunReport {
+	runReport := reports.RunReport{
+		Pipelines: []reports.PipelineReport{},
+	}
+
+	for i := range e.Pipelines {
+		p := &e.Pipelines[i]
+
+		pipelineReport := reports.NewPipelineReport(p.Report)
+		if i < len(e.pipelineErrors) && e.pipelineErrors[i] != nil && pipelineReport.Error == "" {
+			pipelineReport.Error = e.pipelineErrors[i].Error()
+		}
+
+		if len(p.Actions) > 0 {
+			pipelineReport.Actions = make(map[string]reports.ActionReport, len(p.Actions))
+			for id, action := range p.Actions {
+				pipelineReport.Actions[id] = reports.ActionReport{
+					Title: action.Report.Title,
+					Kind:  action.Config.Kind,
+					Link:  action.Report.Link,
+				}
+			}
+		}
+
+		runReport.Pipelines = append(runReport.Pipelines, pipelineReport)
+	}
+
+	runReport.Summary = reports.NewRunSummary(runReport.Pipelines)
+
+	return runReport
+}
+
+// notify sends the run report to the notifiers defined globally,
+// and the report of each pipeline to the notifiers defined by the pipeline
+func (e *Engine) notify(runReport reports.RunReport) {
+	notification.Notify(e.Options.Notifiers, runReport)
+
+	for i := range e.Pipelines {
+		if e.Pipelines[i].Config == nil || len(e.Pipelines[i].Config.Spec.Notifiers) == 0 || i >= len(runReport.Pipelines) {
+			continue
+		}
+
+		pipelineReports := []reports.PipelineReport{runReport.Pipelines[i]}
+
+		notification.Notify(e.Pipelines[i].Config.Spec.Notifiers, reports.RunReport{
+			Summary:   reports.NewRunSummary(pipelineReports),
+			Pipelines: pipelineReports,
+		})
+	}
+}
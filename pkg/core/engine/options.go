@@ -2,6 +2,7 @@ package engine
 
 import (
 	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/notification"
 	"github.com/updatecli/updatecli/pkg/core/pipeline"
 )
 
@@ -9,4 +10,20 @@ import (
 type Options struct {
 	Config   config.Option
 	Pipeline pipeline.Options
+	// Parallel defines the maximum number of independent pipelines run concurrently.
+	// Default to 0 which runs pipelines one at a time.
+	Parallel int
+	// ReportFile defines the file where the machine-readable report of the run is written, in JSON or YAML
+	// depending on its extension. Default to empty which disables the report file.
+	ReportFile string
+	// Notifiers defines the notifiers receiving the run report, in addition to the notifiers defined by each pipeline
+	Notifiers map[string]notification.Config
+	// MetricsPushURL defines the Prometheus Pushgateway URL where the run metrics are pushed, once the run is over.
+	// Default to empty which disables the metrics push.
+	MetricsPushURL string
+	// MetricsJob defines the Prometheus job name of the pushed metrics. Default to "updatecli"
+	MetricsJob string
+	// OTLPEndpoint defines the OpenTelemetry OTLP/HTTP endpoint where the run traces are exported, such as "http://localhost:4318".
+	// Default to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, tracing is disabled if both are empty.
+	OTLPEndpoint string
 }
@@ -0,0 +1,203 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/log"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+)
+
+// localFilesystem is the pipeline resource key of targets updating the local filesystem, without scm
+const localFilesystem = ""
+
+// runPipelines runs every pipeline after the pipelines it depends on, then records their reports, in the pipelines order.
+// Up to e.Options.Parallel groups of pipelines, see pipelineGroups, are run concurrently.
+func (e *Engine) runPipelines() error {
+	dependencies, err := pipelineDependencies(e.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	order, err := sortPipelines(e.Pipelines, dependencies)
+	if err != nil {
+		return err
+	}
+
+	e.pipelineErrors = make([]error, len(e.Pipelines))
+
+	if e.Options.Parallel <= 1 {
+		for _, i := range order {
+			e.pipelineErrors[i] = e.runPipeline(&e.Pipelines[i], dependencies[i])
+		}
+	} else {
+		groups := pipelineGroups(e.Pipelines, order, dependencies)
+
+		workers := e.Options.Parallel
+		if workers > len(groups) {
+			workers = len(groups)
+		}
+
+		logrus.Infof("Running %d pipeline(s) in %d group(s) using %d worker(s)\n",
+			len(e.Pipelines), len(groups), workers)
+
+		jobs := make(chan []int)
+		wg := sync.WaitGroup{}
+
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for group := range jobs {
+					for _, i := range group {
+						p := &e.Pipelines[i]
+						p.Logger = log.Scope(p.Logger).WithField(log.FieldLabel, pipelineLabel(p))
+						e.pipelineErrors[i] = e.runPipeline(p, dependencies[i])
+					}
+				}
+			}()
+		}
+
+		for _, group := range groups {
+			jobs <- group
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	for i := range e.Pipelines {
+		e.Reports = append(e.Reports, e.Pipelines[i].Report)
+	}
+
+	return nil
+}
+
+// runPipeline runs the pipeline p, unless one of its dependencies failed, then returns its error
+func (e *Engine) runPipeline(p *pipeline.Pipeline, dependencies []int) error {
+	for _, j := range dependencies {
+		if e.pipelineErrors[j] != nil {
+			err := fmt.Errorf("pipeline dependency %q failed", e.Pipelines[j].Name)
+			log.Scope(p.Logger).Printf("Pipeline %q skipped\n", p.Title)
+			log.Scope(p.Logger).Printf("Skipping due to:\n\t%s\n", err)
+			return err
+		}
+	}
+
+	err := p.Run()
+	if err != nil {
+		p.Logger.Printf("Pipeline %q failed\n", p.Title)
+		p.Logger.Printf("Skipping due to:\n\t%s\n", err)
+	}
+
+	return err
+}
+
+// pipelineLabel returns the label prefixing the log entries of the pipeline p when run concurrently
+func pipelineLabel(p *pipeline.Pipeline) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.ID
+}
+
+/*
+pipelineGroups returns the indexes of pipelines, grouped so that pipelines sharing a resource or depending
+on each other are in the same group, following order. Pipelines of different groups are independent and can run concurrently.
+Shared resources are the scm directories used by sources, conditions, and targets,
+and the local filesystem updated by targets without scm.
+*/
+func pipelineGroups(pipelines []pipeline.Pipeline, order []int, dependencies [][]int) [][]int {
+	// parents is a union-find of the pipeline indexes
+	parents := make([]int, len(pipelines))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	union := func(i, j int) {
+		a, b := find(i), find(j)
+		// The root is the first pipeline of a group to preserve the groups order
+		if a > b {
+			a, b = b, a
+		}
+		parents[b] = a
+	}
+
+	// owners holds the first pipeline using each resource
+	owners := map[string]int{}
+	for i := range pipelines {
+		for _, key := range pipelineResources(&pipelines[i]) {
+			owner, ok := owners[key]
+			if !ok {
+				owners[key] = i
+				continue
+			}
+			union(owner, i)
+		}
+
+		for _, j := range dependencies[i] {
+			union(i, j)
+		}
+	}
+
+	groups := [][]int{}
+	groupIndexes := map[int]int{}
+	for i := range pipelines {
+		root := find(i)
+		if _, ok := groupIndexes[root]; !ok {
+			groupIndexes[root] = len(groups)
+			groups = append(groups, []int{})
+		}
+	}
+
+	for _, i := range order {
+		index := groupIndexes[find(i)]
+		groups[index] = append(groups[index], i)
+	}
+
+	return groups
+}
+
+// pipelineResources returns the keys of the resources shared with other pipelines by the pipeline p
+func pipelineResources(p *pipeline.Pipeline) []string {
+	keys := []string{}
+
+	scmDirectory := func(r resource.ResourceConfig) (string, bool) {
+		s, ok := p.SCMs[r.SCMID]
+		if r.SCMID == "" || !ok || s.Handler == nil {
+			return "", false
+		}
+		return s.Handler.GetDirectory(), true
+	}
+
+	for _, s := range p.Sources {
+		if directory, ok := scmDirectory(s.Config.ResourceConfig); ok {
+			keys = append(keys, directory)
+		}
+	}
+
+	for _, c := range p.Conditions {
+		if directory, ok := scmDirectory(c.Config.ResourceConfig); ok {
+			keys = append(keys, directory)
+		}
+	}
+
+	for _, t := range p.Targets {
+		directory, ok := scmDirectory(t.Config.ResourceConfig)
+		if !ok {
+			directory = localFilesystem
+		}
+		keys = append(keys, directory)
+	}
+
+	return keys
+}
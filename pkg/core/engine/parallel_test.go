@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/updatecli/updatecli/pkg/core/pipeline"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/source"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
+)
+
+func TestPipelineGroups(t *testing.T) {
+	scms := func(directories map[string]string) map[string]scm.Scm {
+		s := map[string]scm.Scm{}
+		for id, directory := range directories {
+			s[id] = scm.Scm{Handler: &scm.MockScm{WorkingDir: directory}}
+		}
+		return s
+	}
+	sourceWithScm := func(scmID string) source.Source {
+		return source.Source{Config: source.Config{ResourceConfig: resource.ResourceConfig{SCMID: scmID}}}
+	}
+	conditionWithScm := func(scmID string) condition.Condition {
+		return condition.Condition{Config: condition.Config{ResourceConfig: resource.ResourceConfig{SCMID: scmID}}}
+	}
+	targetWithScm := func(scmID string) target.Target {
+		return target.Target{Config: target.Config{ResourceConfig: resource.ResourceConfig{SCMID: scmID}}}
+	}
+
+	testData := []struct {
+		name         string
+		pipelines    []pipeline.Pipeline
+		order        []int
+		dependencies [][]int
+		expected     [][]int
+	}{
+		{
+			name: "Pipelines without resources are independent",
+			pipelines: []pipeline.Pipeline{
+				{},
+				{Sources: map[string]source.Source{"default": {}}},
+			},
+			expected: [][]int{{0}, {1}},
+		},
+		{
+			name: "Pipelines using different scm directories are independent",
+			pipelines: []pipeline.Pipeline{
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/a"}),
+					Targets: map[string]target.Target{"default": targetWithScm("default")},
+				},
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/b"}),
+					Targets: map[string]target.Target{"default": targetWithScm("default")},
+				},
+			},
+			expected: [][]int{{0}, {1}},
+		},
+		{
+			name: "Pipelines sharing an scm directory are grouped",
+			pipelines: []pipeline.Pipeline{
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/a"}),
+					Sources: map[string]source.Source{"default": sourceWithScm("default")},
+				},
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/b"}),
+					Targets: map[string]target.Target{"default": targetWithScm("default")},
+				},
+				{
+					SCMs:       scms(map[string]string{"other": "/tmp/a"}),
+					Conditions: map[string]condition.Condition{"default": conditionWithScm("other")},
+				},
+			},
+			expected: [][]int{{0, 2}, {1}},
+		},
+		{
+			name: "Pipelines updating the local filesystem are grouped",
+			pipelines: []pipeline.Pipeline{
+				{Targets: map[string]target.Target{"default": {}}},
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/a"}),
+					Targets: map[string]target.Target{"default": targetWithScm("default")},
+				},
+				{Targets: map[string]target.Target{"default": {}}},
+			},
+			expected: [][]int{{0, 2}, {1}},
+		},
+		{
+			name: "Groups are merged by a pipeline sharing their resources",
+			pipelines: []pipeline.Pipeline{
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/a"}),
+					Sources: map[string]source.Source{"default": sourceWithScm("default")},
+				},
+				{
+					SCMs:    scms(map[string]string{"default": "/tmp/b"}),
+					Sources: map[string]source.Source{"default": sourceWithScm("default")},
+				},
+				{
+					SCMs:    scms(map[string]string{"a": "/tmp/a", "b": "/tmp/b"}),
+					Sources: map[string]source.Source{"a": sourceWithScm("a"), "b": sourceWithScm("b")},
+				},
+			},
+			expected: [][]int{{0, 1, 2}},
+		},
+		{
+			name:         "Pipelines depending on each other are grouped in their order",
+			pipelines:    []pipeline.Pipeline{{}, {}, {}},
+			order:        []int{1, 2, 0},
+			dependencies: [][]int{{1}, nil, nil},
+			expected:     [][]int{{1, 0}, {2}},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			order, dependencies := tt.order, tt.dependencies
+			if order == nil {
+				for i := range tt.pipelines {
+					order = append(order, i)
+				}
+			}
+			if dependencies == nil {
+				dependencies = make([][]int, len(tt.pipelines))
+			}
+
+			assert.Equal(t, tt.expected, pipelineGroups(tt.pipelines, order, dependencies))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/tmp"
+)
+
+// manifestFiles returns every manifest file to load, after fetching them
+// if the manifest option references a git repository, an http(s) URL or an OCI artifact
+func (e *Engine) manifestFiles() ([]string, error) {
+	location := e.Options.Config.ManifestFile
+
+	if !config.IsRemoteManifest(location) {
+		return GetFiles(location), nil
+	}
+
+	logrus.Infof("Fetching remote manifest %q", location)
+
+	dir := filepath.Join(tmp.Directory, "manifests", fmt.Sprintf("%x", sha256.Sum256([]byte(location))))
+
+	localPath, err := config.FetchRemoteManifest(location, dir)
+	if err != nil {
+		return nil, fmt.Errorf("fetching remote manifest %q: %w", location, err)
+	}
+
+	return GetFiles(localPath), nil
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
+)
+
+// startTelemetry starts the trace of the run, then returns the function to call once the run is over, with its error,
+// which exports the traces and pushes the metrics. Telemetry failures are reported but don't fail the run.
+func (e *Engine) startTelemetry() func(err error) {
+	telemetry.EnableTracing(e.Options.OTLPEndpoint)
+	run := telemetry.StartRun("updatecli run")
+
+	return func(err error) {
+		runResult := result.SUCCESS
+		if err != nil {
+			runResult = result.FAILURE
+		}
+		run.End(runResult)
+
+		if err := telemetry.ExportTraces(context.Background()); err != nil {
+			logrus.Warningf("Skipping traces export: %s", err)
+		}
+
+		if e.Options.MetricsPushURL != "" {
+			if err := telemetry.PushMetrics(e.Options.MetricsPushURL, e.Options.MetricsJob); err != nil {
+				logrus.Warningf("Skipping metrics push: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Validate checks every Updatecli manifest against the Updatecli json schema
+// without running any pipeline, and reports every invalid field with its position.
+func (e *Engine) Validate() error {
+	logrus.Infof("\n\n%s\n", strings.Repeat("+", len("Validate")+4))
+	logrus.Infof("+ %s +\n", strings.ToTitle("Validate"))
+	logrus.Infof("%s\n\n", strings.Repeat("+", len("Validate")+4))
+
+	manifestFiles, err := e.manifestFiles()
+	if err != nil {
+		return err
+	}
+
+	if len(manifestFiles) == 0 {
+		return ErrNoManifestDetected
+	}
+
+	totalValid, totalInvalid := 0, 0
+
+	for _, manifestFile := range manifestFiles {
+
+		validationErrors, err := config.ValidateManifest(
+			config.Option{
+				ManifestFile:      manifestFile,
+				SecretsFiles:      e.Options.Config.SecretsFiles,
+				ValuesFiles:       e.Options.Config.ValuesFiles,
+				DisableTemplating: e.Options.Config.DisableTemplating,
+			})
+
+		switch err {
+		case config.ErrConfigFileTypeNotSupported:
+			// Updatecli ignores unsupported files when browsing a directory
+			continue
+		case nil:
+			// nothing to do
+		default:
+			logrus.Errorf("%s %s: %s", result.FAILURE, manifestFile, err)
+			totalInvalid++
+			continue
+		}
+
+		if len(validationErrors) == 0 {
+			logrus.Infof("%s %s", result.SUCCESS, manifestFile)
+			totalValid++
+			continue
+		}
+
+		for _, validationError := range validationErrors {
+			logrus.Errorf("%s %s:%s", result.FAILURE, manifestFile, validationError)
+		}
+		totalInvalid++
+	}
+
+	logrus.Infof("\n\n\n")
+	logrus.Infof("Summary")
+	logrus.Infof("===========\n")
+	logrus.Infof("Manifest(s) validation:")
+	logrus.Infof("  * Invalid:\t%d", totalInvalid)
+	logrus.Infof("  * Valid:\t%d", totalValid)
+	logrus.Infof("  * Total:\t%d", totalValid+totalInvalid)
+
+	if totalInvalid > 0 {
+		return fmt.Errorf("%d over %d manifest(s) invalid", totalInvalid, totalValid+totalInvalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultRetryBackoff defines the default delay before retrying a failed http request, doubled after each attempt
+	DefaultRetryBackoff = time.Second
+	// DefaultMaxRetryBackoff defines the default maximum delay between two attempts
+	DefaultMaxRetryBackoff = time.Minute
+)
+
+// DefaultRetryPolicy is the global retry policy, used by http.DefaultClient once enabled with EnableRetries,
+// and by plugins without a specific retry policy
+var DefaultRetryPolicy RetryPolicy
+
+// RetryPolicy defines how failed http requests are retried, such as network errors,
+// "429 Too Many Requests" and 5xx responses
+type RetryPolicy struct {
+	// Retries defines the maximum number of retries of a failed request. Default to 0, which disables retries
+	Retries int `yaml:",omitempty"`
+	// Backoff defines the delay before the first retry, doubled after each attempt, such as "2s". Default to "1s"
+	Backoff string `yaml:",omitempty"`
+	// MaxBackoff defines the maximum delay between two attempts, including the one requested by a "Retry-After" header,
+	// such as "30s". Default to "1m"
+	MaxBackoff string `yaml:",omitempty"`
+}
+
+// IsZero returns true if the retry policy isn't defined
+func (p RetryPolicy) IsZero() bool {
+	return p == RetryPolicy{}
+}
+
+// Validate checks that the retry policy is valid
+func (p RetryPolicy) Validate() error {
+	if p.Retries < 0 {
+		return fmt.Errorf("retries %d must be positive", p.Retries)
+	}
+
+	if _, _, err := p.backoffs(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backoffs returns the first and the maximum delays between two attempts
+func (p RetryPolicy) backoffs() (backoff, maxBackoff time.Duration, err error) {
+	backoff, maxBackoff = DefaultRetryBackoff, DefaultMaxRetryBackoff
+
+	if p.Backoff != "" {
+		backoff, err = time.ParseDuration(p.Backoff)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid retry backoff %q: %w", p.Backoff, err)
+		}
+	}
+
+	if p.MaxBackoff != "" {
+		maxBackoff, err = time.ParseDuration(p.MaxBackoff)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid retry maxbackoff %q: %w", p.MaxBackoff, err)
+		}
+	}
+
+	return backoff, maxBackoff, nil
+}
+
+// EnableRetries sets the global retry policy, and applies it to http.DefaultClient
+func EnableRetries(policy RetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	DefaultRetryPolicy = policy
+	http.DefaultClient.Transport = NewRetryTransport(policy, http.DefaultTransport)
+
+	return nil
+}
+
+// NewRetryClient returns an http client retrying failed requests according to the policy,
+// or http.DefaultClient, which follows the global retry policy, if the policy isn't defined
+func NewRetryClient(policy RetryPolicy) *http.Client {
+	if policy.IsZero() {
+		return http.DefaultClient
+	}
+
+	return &http.Client{
+		Transport: NewRetryTransport(policy, http.DefaultTransport),
+	}
+}
+
+// RetryTransport is an http.RoundTripper retrying failed requests with an exponential backoff
+type RetryTransport struct {
+	roundTripperWrapper http.RoundTripper
+	policy              RetryPolicy
+}
+
+// NewRetryTransport returns an http.RoundTripper retrying the failed requests of transportWrap according to the policy
+func NewRetryTransport(policy RetryPolicy, transportWrap http.RoundTripper) http.RoundTripper {
+	return &RetryTransport{
+		roundTripperWrapper: transportWrap,
+		policy:              policy,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff, maxBackoff, err := t.policy.backoffs()
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		res, err := t.roundTripperWrapper.RoundTrip(req)
+
+		if attempt > t.policy.Retries || !isRetryable(res, err) {
+			return res, err
+		}
+
+		// A request body can only be sent again if it can be retrieved again
+		if req.Body != nil && req.GetBody == nil {
+			return res, err
+		}
+
+		delay := backoff
+		if retryAfter, ok := retryAfterDelay(res); ok {
+			delay = retryAfter
+		}
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+
+		reason := ""
+		switch err {
+		case nil:
+			reason = res.Status
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		default:
+			reason = err.Error()
+		}
+
+		logrus.Warningf("http request %s %s failed, retrying in %s (attempt %d/%d): %s",
+			req.Method, req.URL.Redacted(), delay, attempt, t.policy.Retries, reason)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		backoff *= 2
+	}
+}
+
+// isRetryable returns true if a request failed with a network error,
+// or with a response, such as a rate limit, which may not happen again
+func isRetryable(res *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		var netErr net.Error
+		return errors.As(err, &netErr) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	return res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented)
+}
+
+// retryAfterDelay returns the delay requested by the "Retry-After" header of a response,
+// defined either in seconds or as an http date
+func retryAfterDelay(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
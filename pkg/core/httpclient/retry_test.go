@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	dataset := []struct {
+		name             string
+		statuses         []int
+		retryAfter       string
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			name:             "retries server errors until success",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "honors retry-after on rate limit",
+			statuses:         []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "0",
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "doesn't retry client errors",
+			statuses:         []int{http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusNotFound,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "stops after the maximum number of retries",
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedAttempts: 3,
+		},
+	}
+
+	for _, d := range dataset {
+		t.Run(d.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "payload", string(body))
+
+				if d.retryAfter != "" {
+					w.Header().Set("Retry-After", d.retryAfter)
+				}
+				w.WriteHeader(d.statuses[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			client := NewRetryClient(RetryPolicy{
+				Retries: 2,
+				Backoff: "1ms",
+			})
+
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, d.expectedStatus, res.StatusCode)
+			assert.Equal(t, d.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	res := &http.Response{Header: http.Header{}}
+
+	_, ok := retryAfterDelay(res)
+	assert.False(t, ok)
+
+	res.Header.Set("Retry-After", "120")
+	delay, ok := retryAfterDelay(res)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	res.Header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	delay, ok = retryAfterDelay(res)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	assert.NoError(t, RetryPolicy{}.Validate())
+	assert.NoError(t, RetryPolicy{Retries: 3, Backoff: "2s", MaxBackoff: "30s"}.Validate())
+	assert.Error(t, RetryPolicy{Retries: -1}.Validate())
+	assert.Error(t, RetryPolicy{Retries: 3, Backoff: "soon"}.Validate())
+}
@@ -0,0 +1,170 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TransportSpec defines the network settings of plugins reaching http APIs and registries,
+// such as self-hosted ones behind a corporate proxy or using a private certificate authority
+type TransportSpec struct {
+	// Proxy defines the proxy URL used by every request, such as "http://proxy.example.com:3128".
+	// Default to the proxy defined by the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables
+	Proxy string `yaml:",omitempty"`
+	// CAFile defines a file containing PEM encoded certificate authorities, trusted in addition
+	// to the system ones to verify TLS certificates. Default to the system certificate authorities
+	CAFile string `yaml:",omitempty"`
+	// InsecureSkipVerify disables the TLS certificate verification.
+	// It must only be used with self-hosted services, and default to false
+	InsecureSkipVerify bool `yaml:",omitempty"`
+}
+
+// isZero returns true if the transport settings aren't defined.
+// It isn't exported so plugin specs embedding TransportSpec aren't considered empty by yaml marshalling
+func (s TransportSpec) isZero() bool {
+	return s == TransportSpec{}
+}
+
+// Validate checks that the transport settings are valid
+func (s TransportSpec) Validate() error {
+	if s.Proxy != "" {
+		if _, err := s.proxyURL(); err != nil {
+			return err
+		}
+	}
+
+	if s.CAFile != "" {
+		if _, err := s.certPool(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// proxyURL returns the parsed proxy URL
+func (s TransportSpec) proxyURL() (*url.URL, error) {
+	u, err := url.Parse(s.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy %q: %w", s.Proxy, err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: scheme and host are required", s.Proxy)
+	}
+
+	return u, nil
+}
+
+// certPool returns the system certificate authorities with the ones read from CAFile
+func (s TransportSpec) certPool() (*x509.CertPool, error) {
+	caBundle, err := os.ReadFile(s.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate authorities file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		logrus.Debugf("loading system certificate authorities: %s", err)
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("no PEM encoded certificate found in certificate authorities file %q", s.CAFile)
+	}
+
+	return pool, nil
+}
+
+// NewTransport returns an http.RoundTripper applying the transport settings, which retries failed requests
+// according to the retry policy, or to the global one if the retry policy isn't defined.
+// It returns http.DefaultTransport, honoring the proxy environment variables, if nothing is defined.
+func NewTransport(spec TransportSpec, retry RetryPolicy) (http.RoundTripper, error) {
+	return WrapTransport(http.DefaultTransport, spec, retry)
+}
+
+// WrapTransport returns base, or a copy of base applying the transport settings,
+// which retries failed requests as NewTransport does
+func WrapTransport(base http.RoundTripper, spec TransportSpec, retry RetryPolicy) (http.RoundTripper, error) {
+	if retry.IsZero() {
+		retry = DefaultRetryPolicy
+	}
+
+	if err := retry.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	transport := base
+
+	if !spec.isZero() {
+		baseTransport, ok := base.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("unsupported http transport %T", base)
+		}
+		customTransport := baseTransport.Clone()
+
+		if spec.Proxy != "" {
+			proxyURL, err := spec.proxyURL()
+			if err != nil {
+				return nil, err
+			}
+			logrus.Debugf("using proxy %q", proxyURL.Redacted())
+			customTransport.Proxy = http.ProxyURL(proxyURL)
+		}
+
+		if spec.CAFile != "" || spec.InsecureSkipVerify {
+			customTransport.TLSClientConfig = &tls.Config{
+				MinVersion: tls.VersionTLS12,
+			}
+		}
+
+		if spec.CAFile != "" {
+			pool, err := spec.certPool()
+			if err != nil {
+				return nil, err
+			}
+			customTransport.TLSClientConfig.RootCAs = pool
+		}
+
+		if spec.InsecureSkipVerify {
+			logrus.Warningln("insecure: TLS certificate verification disabled")
+			// #nosec G402 // certificate verification is explicitly disabled by the user
+			customTransport.TLSClientConfig.InsecureSkipVerify = true
+		}
+
+		transport = customTransport
+	}
+
+	if !retry.IsZero() {
+		transport = NewRetryTransport(retry, transport)
+	}
+
+	return transport, nil
+}
+
+// NewClient returns an http client applying the transport settings and the retry policy, as NewTransport does.
+// It returns http.DefaultClient if neither is defined.
+func NewClient(spec TransportSpec, retry RetryPolicy) (*http.Client, error) {
+	if spec.isZero() && retry.IsZero() {
+		return http.DefaultClient, nil
+	}
+
+	transport, err := NewTransport(spec, retry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
@@ -0,0 +1,104 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0600))
+
+	invalidCAFile := filepath.Join(t.TempDir(), "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidCAFile, []byte("not a certificate"), 0600))
+
+	dataset := []struct {
+		name            string
+		spec            TransportSpec
+		wantInitErr     bool
+		wantRequestErr  bool
+		wantDefaultUsed bool
+	}{
+		{
+			name:            "system certificate authorities",
+			wantRequestErr:  true,
+			wantDefaultUsed: true,
+		},
+		{
+			name: "custom certificate authorities",
+			spec: TransportSpec{CAFile: caFile},
+		},
+		{
+			name: "insecure skip verify",
+			spec: TransportSpec{InsecureSkipVerify: true},
+		},
+		{
+			name:        "missing certificate authorities file",
+			spec:        TransportSpec{CAFile: filepath.Join(t.TempDir(), "doNotExist")},
+			wantInitErr: true,
+		},
+		{
+			name:        "invalid certificate authorities file",
+			spec:        TransportSpec{CAFile: invalidCAFile},
+			wantInitErr: true,
+		},
+	}
+
+	for _, d := range dataset {
+		t.Run(d.name, func(t *testing.T) {
+			client, err := NewClient(d.spec, RetryPolicy{})
+			if d.wantInitErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, d.wantDefaultUsed, client == http.DefaultClient)
+
+			res, err := client.Get(server.URL)
+			if d.wantRequestErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
+
+func TestNewClientProxy(t *testing.T) {
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(TransportSpec{Proxy: proxy.URL}, RetryPolicy{})
+	require.NoError(t, err)
+
+	res, err := client.Get("http://registry.example.com/v2/")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "http://registry.example.com/v2/", proxied)
+
+	_, err = NewClient(TransportSpec{Proxy: "proxy.example.com"}, RetryPolicy{})
+	require.Error(t, err)
+}
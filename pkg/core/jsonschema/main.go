@@ -79,6 +79,27 @@ func (s *Schema) GenerateSchema(object interface{}) error {
 		return err
 	}
 
+	r := s.reflector()
+
+	r.CommentMap, err = GetPackageComments(commentDir)
+
+	if err != nil {
+		return err
+	}
+
+	s.JsonSchema = *r.Reflect(object)
+
+	return nil
+}
+
+// ReflectSchema generates updatecli json schema based the config struct,
+// without the code comments so it doesn't need the updatecli git repository
+func (s *Schema) ReflectSchema(object interface{}) {
+	s.JsonSchema = *s.reflector().Reflect(object)
+}
+
+// reflector returns the json schema reflector used to generate updatecli json schema
+func (s *Schema) reflector() *jschema.Reflector {
 	r := new(jschema.Reflector)
 
 	r.SetBaseSchemaID(s.BaseSchemaID)
@@ -88,15 +109,20 @@ func (s *Schema) GenerateSchema(object interface{}) error {
 
 	r.KeyNamer = strings.ToLower
 
-	r.CommentMap, err = GetPackageComments(commentDir)
+	return r
+}
 
-	if err != nil {
-		return err
-	}
+// Reflect returns the json schema of an object without any code comments,
+// such as the one used to validate Updatecli manifests.
+func Reflect(object interface{}) *jschema.Schema {
+	r := new(jschema.Reflector)
 
-	s.JsonSchema = *r.Reflect(object)
+	r.DoNotReference = true
+	r.RequiredFromJSONSchemaTags = true
 
-	return nil
+	r.KeyNamer = strings.ToLower
+
+	return r.Reflect(object)
 }
 
 // Save export a jsonschema to a local file
@@ -160,6 +186,13 @@ func GetPackageComments(rootPackagePath string) (map[string]string, error) {
 	return r.CommentMap, nil
 }
 
+// isCommentDirectory returns true if the updatecli git repository used to retrieve code comments has been cloned.
+// Code comments are only needed to populate the jsonschema field "description"
+func isCommentDirectory() bool {
+	_, err := os.Stat(commentDir)
+	return err == nil
+}
+
 // CloneCommentDirectory clones the updatecli git repository in a
 // temporary location so we can parse comments
 func CloneCommentDirectory() error {
@@ -204,11 +237,13 @@ func AppendOneOfToJsonSchema(baseConfig interface{}, anyOf map[string]interface{
 	}
 
 	// Retrieve Updatecli code comments
-	commentMap, err = GetPackageComments(commentDir)
+	if isCommentDirectory() {
+		commentMap, err = GetPackageComments(commentDir)
 
-	if err != nil {
-		logrus.Errorf(err.Error())
-		return nil
+		if err != nil {
+			logrus.Errorf(err.Error())
+			return nil
+		}
 	}
 
 	resourceSchema := jschema.Schema{}
@@ -252,11 +287,13 @@ func AppendMapToJsonSchema(baseConfig interface{}, mapConfig map[string]interfac
 	var commentMap map[string]string
 
 	// Retrieve Updatecli code comments
-	commentMap, err = GetPackageComments(commentDir)
+	if isCommentDirectory() {
+		commentMap, err = GetPackageComments(commentDir)
 
-	if err != nil {
-		logrus.Errorf(err.Error())
-		return nil
+		if err != nil {
+			logrus.Errorf(err.Error())
+			return nil
+		}
 	}
 
 	if baseConfig == nil {
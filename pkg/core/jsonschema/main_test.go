@@ -95,6 +95,18 @@ func TestGenerateSchema(t *testing.T) {
 	assert.Equal(t, expectedJsonSchema, s.String())
 }
 
+func TestReflectSchema(t *testing.T) {
+	s := New("https://www.updatecli.io/latest/schema", "")
+
+	s.ReflectSchema(&mockConfig{})
+
+	assert.Equal(t, "https://www.updatecli.io/latest/schema/mock-config", string(s.JsonSchema.ID))
+	assert.Equal(t, []string{"name", "pipelineid", "title", "conditions"}, s.JsonSchema.Properties.Keys())
+
+	// Code comments are only retrieved from the updatecli git repository
+	assert.NotContains(t, s.String(), "description")
+}
+
 func TestGenerateJsonSchema(t *testing.T) {
 	expectedJsonSchema := `{
     "oneOf": [
@@ -0,0 +1,396 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	jschema "github.com/invopop/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError describes a YAML node not respecting a json schema
+type ValidationError struct {
+	// Line is the line of the invalid node
+	Line int
+	// Column is the column of the invalid node
+	Column int
+	// Path is the path of the invalid node such as "sources.default.spec"
+	Path string
+	// Message explains why the node is invalid
+	Message string
+}
+
+// String implements the string interface
+func (e ValidationError) String() string {
+	path := e.Path
+	if path == "" {
+		path = "."
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, path, e.Message)
+}
+
+// Validate checks a YAML document against a json schema generated by Reflect,
+// and returns every unknown field, type mismatch and missing required field.
+func Validate(schema *jschema.Schema, node *yaml.Node) []ValidationError {
+	v := validator{}
+	v.validate(schema, node, "")
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+		return v.errors[i].Column < v.errors[j].Column
+	})
+
+	return v.errors
+}
+
+type validator struct {
+	errors []ValidationError
+}
+
+func (v *validator) errorf(node *yaml.Node, path, format string, a ...interface{}) {
+	v.errors = append(v.errors, ValidationError{
+		Line:    node.Line,
+		Column:  node.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (v *validator) validate(schema *jschema.Schema, node *yaml.Node, path string) {
+	if schema == nil || schema == jschema.TrueSchema || node == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			v.validate(schema, node.Content[0], path)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(schema, node.Alias, path)
+		return
+	}
+
+	// An empty value is equivalent to an unset field
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	if schema == jschema.FalseSchema {
+		v.errorf(node, path, "unexpected value")
+		return
+	}
+
+	if len(schema.OneOf) > 0 {
+		v.validateOneOf(schema.OneOf, node, path)
+		return
+	}
+
+	if len(schema.Enum) > 0 {
+		v.validateEnum(schema.Enum, node, path)
+	}
+
+	switch schema.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			v.errorf(node, path, "expected an object, got %s", kindOf(node))
+			return
+		}
+		v.validateMapping(schema, node, path)
+
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			v.errorf(node, path, "expected an array, got %s", kindOf(node))
+			return
+		}
+		for i := range node.Content {
+			v.validate(schema.Items, node.Content[i], fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case "string":
+		// Any scalar, such as 1.2, can be used as a string
+		if node.Kind != yaml.ScalarNode {
+			v.errorf(node, path, "expected a string, got %s", kindOf(node))
+		}
+
+	case "boolean":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.errorf(node, path, "expected a boolean, got %s", kindOf(node))
+		}
+
+	case "integer":
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.errorf(node, path, "expected an integer, got %s", kindOf(node))
+		}
+
+	case "number":
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			v.errorf(node, path, "expected a number, got %s", kindOf(node))
+		}
+	}
+}
+
+// validateMapping validates every field of a YAML mapping
+func (v *validator) validateMapping(schema *jschema.Schema, node *yaml.Node, path string) {
+	keys, values := mappingPairs(node)
+
+	found := make(map[string]bool)
+
+	for i := range keys {
+		key := keys[i]
+		found[key.Value] = true
+
+		fieldPath := joinPath(path, key.Value)
+
+		if property, ok := propertySchema(schema, key.Value); ok {
+			v.validate(property, values[i], fieldPath)
+			continue
+		}
+
+		if property, ok := patternPropertySchema(schema, key.Value); ok {
+			v.validate(property, values[i], fieldPath)
+			continue
+		}
+
+		switch schema.AdditionalProperties {
+		case nil:
+			// Any field is accepted
+		case jschema.FalseSchema:
+			message := fmt.Sprintf("unknown field %q", key.Value)
+			if suggestion := suggest(key.Value, propertyNames(schema)); suggestion != "" {
+				message = fmt.Sprintf("%s, did you mean %q?", message, suggestion)
+			}
+			v.errorf(key, fieldPath, "%s", message)
+		default:
+			v.validate(schema.AdditionalProperties, values[i], fieldPath)
+		}
+	}
+
+	for _, required := range schema.Required {
+		if !found[required] {
+			v.errorf(node, path, "missing required field %q", required)
+		}
+	}
+}
+
+// validateOneOf validates a YAML node against one of the schemas.
+// When the schemas are identified by a "kind" field, such as resources, the schema is selected by kind.
+func (v *validator) validateOneOf(schemas []*jschema.Schema, node *yaml.Node, path string) {
+	kinds := make(map[string]*jschema.Schema)
+	for _, s := range schemas {
+		kind, ok := propertySchema(s, "kind")
+		if !ok || kind == nil {
+			continue
+		}
+		for _, k := range kind.Enum {
+			kinds[fmt.Sprint(k)] = s
+		}
+	}
+
+	if len(kinds) > 0 && node.Kind == yaml.MappingNode {
+		keys, values := mappingPairs(node)
+		for i := range keys {
+			if keys[i].Value != "kind" {
+				continue
+			}
+
+			s, ok := kinds[values[i].Value]
+			if !ok {
+				names := make([]string, 0, len(kinds))
+				for k := range kinds {
+					names = append(names, k)
+				}
+
+				message := fmt.Sprintf("unknown kind %q", values[i].Value)
+				if suggestion := suggest(values[i].Value, names); suggestion != "" {
+					message = fmt.Sprintf("%s, did you mean %q?", message, suggestion)
+				}
+				v.errorf(values[i], joinPath(path, "kind"), "%s", message)
+				return
+			}
+
+			v.validate(s, node, path)
+			return
+		}
+	}
+
+	// Otherwise, report the errors of the closest schema
+	var closest []ValidationError
+	for i, s := range schemas {
+		sub := validator{}
+		sub.validate(s, node, path)
+		if len(sub.errors) == 0 {
+			return
+		}
+		if i == 0 || len(sub.errors) < len(closest) {
+			closest = sub.errors
+		}
+	}
+	v.errors = append(v.errors, closest...)
+}
+
+func (v *validator) validateEnum(enum []interface{}, node *yaml.Node, path string) {
+	if node.Kind != yaml.ScalarNode {
+		v.errorf(node, path, "expected one of %s, got %s", enumList(enum), kindOf(node))
+		return
+	}
+
+	for _, e := range enum {
+		if fmt.Sprint(e) == node.Value {
+			return
+		}
+	}
+
+	v.errorf(node, path, "expected one of %s, got %q", enumList(enum), node.Value)
+}
+
+// mappingPairs returns the keys and values of a YAML mapping, including merged mappings such as "<<: *default"
+func mappingPairs(node *yaml.Node) (keys, values []*yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if key.Tag == "!!merge" {
+			if value.Kind == yaml.AliasNode {
+				value = value.Alias
+			}
+			merged := []*yaml.Node{value}
+			if value.Kind == yaml.SequenceNode {
+				merged = value.Content
+			}
+			for _, m := range merged {
+				if m.Kind == yaml.AliasNode {
+					m = m.Alias
+				}
+				if m.Kind == yaml.MappingNode {
+					k, v := mappingPairs(m)
+					keys = append(keys, k...)
+					values = append(values, v...)
+				}
+			}
+			continue
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
+
+// propertySchema returns the schema of a property.
+// Properties without any schema, such as the ones set by AppendMapToJsonSchema, accept any value
+func propertySchema(schema *jschema.Schema, name string) (*jschema.Schema, bool) {
+	if schema == nil || schema.Properties == nil {
+		return nil, false
+	}
+
+	property, ok := schema.Properties.Get(name)
+	if !ok {
+		return nil, false
+	}
+
+	switch p := property.(type) {
+	case *jschema.Schema:
+		return p, true
+	case jschema.Schema:
+		return &p, true
+	}
+	return nil, true
+}
+
+func patternPropertySchema(schema *jschema.Schema, name string) (*jschema.Schema, bool) {
+	for pattern, s := range schema.PatternProperties {
+		if matched, err := regexp.MatchString(pattern, name); err == nil && matched {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+func propertyNames(schema *jschema.Schema) []string {
+	if schema.Properties == nil {
+		return nil
+	}
+	return schema.Properties.Keys()
+}
+
+// suggest returns the closest name to value, or an empty string if none is close enough
+func suggest(value string, names []string) string {
+	names = append([]string{}, names...)
+	sort.Strings(names)
+
+	suggestion := ""
+	best := 3
+	for _, name := range names {
+		if strings.EqualFold(name, value) {
+			return name
+		}
+		if d := levenshtein(strings.ToLower(value), name); d < best {
+			best = d
+			suggestion = name
+		}
+	}
+	return suggestion
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func enumList(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i := range enum {
+		values[i] = fmt.Sprintf("%q", fmt.Sprint(enum[i]))
+	}
+	return strings.Join(values, ", ")
+}
+
+// kindOf describes a YAML node for error messages
+func kindOf(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "an array"
+	}
+
+	switch node.Tag {
+	case "!!bool":
+		return "a boolean"
+	case "!!int":
+		return "an integer"
+	case "!!float":
+		return "a number"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
@@ -0,0 +1,152 @@
+package jsonschema
+
+import (
+	"testing"
+
+	jschema "github.com/invopop/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type mockValidateSpec struct {
+	Version  string
+	Retries  int
+	Disabled bool
+	Tags     []string
+	Image    string `jsonschema:"required"`
+}
+
+type mockValidateResourceConfig struct {
+	Name string
+	Kind string `jsonschema:"required"`
+	Spec interface{}
+}
+
+type mockValidateConfig struct {
+	Name    string
+	Sources map[string]mockValidateSource
+}
+
+type mockValidateSource mockValidateResourceConfig
+
+func (mockValidateSource) JSONSchema() *jschema.Schema {
+	type configAlias mockValidateResourceConfig
+
+	return AppendOneOfToJsonSchema(configAlias{}, map[string]interface{}{
+		"docker": &mockValidateSpec{},
+		"shell":  nil,
+	})
+}
+
+func TestValidate(t *testing.T) {
+
+	dataset := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "valid manifest",
+			manifest: `
+name: test
+sources:
+  default:
+    kind: docker
+    spec:
+      image: updatecli/updatecli
+      version: 1.2
+      retries: 3
+      tags:
+        - latest
+  anchor: &anchor
+    kind: shell
+    spec:
+      anything: true
+  alias: *anchor
+`,
+		},
+		{
+			name: "unknown fields",
+			manifest: `
+name: test
+title: foo
+sources:
+  default:
+    kind: docker
+    spec:
+      image: updatecli/updatecli
+      verison: 1.2
+`,
+			expected: []string{
+				`3:1: title: unknown field "title"`,
+				`9:7: sources.default.spec.verison: unknown field "verison", did you mean "version"?`,
+			},
+		},
+		{
+			name: "type mismatches",
+			manifest: `
+sources:
+  default:
+    kind: docker
+    spec:
+      image:
+        name: updatecli
+      retries: many
+      disabled: "yes"
+      tags: latest
+`,
+			expected: []string{
+				`7:9: sources.default.spec.image: expected a string, got an object`,
+				`8:16: sources.default.spec.retries: expected an integer, got "many"`,
+				`9:17: sources.default.spec.disabled: expected a boolean, got "yes"`,
+				`10:13: sources.default.spec.tags: expected an array, got "latest"`,
+			},
+		},
+		{
+			name: "missing required fields",
+			manifest: `
+sources:
+  default:
+    spec:
+      version: 1.2
+  docker:
+    kind: docker
+    spec:
+      version: 1.2
+`,
+			expected: []string{
+				`4:5: sources.default: missing required field "kind"`,
+				`9:7: sources.docker.spec: missing required field "image"`,
+			},
+		},
+		{
+			name: "unknown kind",
+			manifest: `
+sources:
+  default:
+    kind: dokcer
+`,
+			expected: []string{
+				`4:11: sources.default.kind: unknown kind "dokcer", did you mean "docker"?`,
+			},
+		},
+	}
+
+	schema := Reflect(&mockValidateConfig{})
+
+	for _, data := range dataset {
+		t.Run(data.name, func(t *testing.T) {
+			var node yaml.Node
+			err := yaml.Unmarshal([]byte(data.manifest), &node)
+			require.NoError(t, err)
+
+			var got []string
+			for _, validationError := range Validate(schema, &node) {
+				got = append(got, validationError.String())
+			}
+
+			assert.Equal(t, data.expected, got)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FieldPipeline is the field of the log entries containing the pipeline name
+	FieldPipeline = "pipeline"
+	// FieldStage is the field of the log entries containing the pipeline stage, such as "source"
+	FieldStage = "stage"
+	// FieldResource is the field of the log entries containing the id of the source, condition, or target run
+	FieldResource = "resource"
+	// FieldPlugin is the field of the log entries containing the plugin kind, such as "gittag"
+	FieldPlugin = "plugin"
+	// FieldLabel is the field of the log entries containing the label prefixing them in the text output,
+	// such as the pipeline name when pipelines run concurrently
+	FieldLabel = "label"
+)
+
+// Scope returns scope, the log entry carrying the fields of the pipeline or resource run,
+// or an entry of the standard logger without any field if scope is nil
+func Scope(scope *logrus.Entry) *logrus.Entry {
+	if scope == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	return scope
+}
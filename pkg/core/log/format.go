@@ -71,10 +71,25 @@ func (f *TextFormat) Format(entry *logrus.Entry) ([]byte, error) {
 		b.WriteString(" - ")
 	}
 
-	b.WriteString(entry.Message)
+	message := redact(entry.Message)
 
-	if !strings.HasSuffix(entry.Message, "\n") {
+	b.WriteString(message)
+
+	if !strings.HasSuffix(message, "\n") {
 		b.WriteByte('\n')
 	}
+
+	// Prefix every line with the label, to distinguish pipelines run concurrently
+	if label, ok := entry.Data[FieldLabel].(string); ok && label != "" {
+		lines := strings.SplitAfter(b.String(), "\n")
+		b.Reset()
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				b.WriteString("[" + label + "] ")
+			}
+			b.WriteString(line)
+		}
+	}
+
 	return b.Bytes(), nil
 }
@@ -0,0 +1,59 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// JSONFormat formats log entries as JSON lines, with their fields such as the pipeline, stage, resource and plugin ones
+type JSONFormat struct {
+	TimestampFormat string
+}
+
+// NewJSONFormat creates the JSON formatter
+func NewJSONFormat() *JSONFormat {
+	return &JSONFormat{
+		TimestampFormat: time.RFC3339,
+	}
+}
+
+// Format formats the log statement, skipping the ones without message such as the blank lines used in the text output
+func (f *JSONFormat) Format(entry *logrus.Entry) ([]byte, error) {
+	message := strings.TrimSpace(redact(entry.Message))
+	if message == "" {
+		return nil, nil
+	}
+
+	data := make(logrus.Fields, len(entry.Data)+3)
+
+	for key, value := range entry.Data {
+		// The label duplicates the pipeline field
+		if key == FieldLabel {
+			continue
+		}
+
+		switch value := value.(type) {
+		case error:
+			// errors don't marshal to JSON
+			data[key] = redact(value.Error())
+		case string:
+			data[key] = redact(value)
+		default:
+			data[key] = value
+		}
+	}
+
+	data["time"] = entry.Time.Format(f.TimestampFormat)
+	data["level"] = entry.Level.String()
+	data["msg"] = message
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
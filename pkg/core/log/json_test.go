@@ -0,0 +1,40 @@
+package log
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFormat(t *testing.T) {
+	f := NewJSONFormat()
+	entryTime := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	b, err := f.Format(&logrus.Entry{Level: logrus.InfoLevel, Time: entryTime, Message: "\n\n"})
+	require.NoError(t, err)
+	assert.Empty(t, b, "entries without message are skipped")
+
+	scope := logrus.WithFields(logrus.Fields{FieldPipeline: "Bump Golang", FieldLabel: "Bump Golang"}).
+		WithFields(logrus.Fields{FieldStage: "source", FieldResource: "golang", FieldPlugin: "golang"}).
+		WithError(errors.New("not found"))
+	scope.Time = entryTime
+	scope.Level = logrus.WarnLevel
+	scope.Message = "\nno matching version found\n"
+
+	b, err = f.Format(scope)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"time": "2024-01-15T10:30:00Z",
+		"level": "warning",
+		"msg": "no matching version found",
+		"error": "not found",
+		"pipeline": "Bump Golang",
+		"stage": "source",
+		"resource": "golang",
+		"plugin": "golang"
+	}`, string(b))
+}
@@ -0,0 +1,102 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// captures holds the writers receiving the log entries of each pipeline, indexed by the pipeline field value
+	captures = map[string][]io.Writer{}
+	// capturesMutex protects captures
+	capturesMutex sync.Mutex
+	// registerCaptureHook ensures the capture hook is only registered once
+	registerCaptureHook sync.Once
+)
+
+/*
+Capture copies to w, in addition to the logrus output, the log entries emitted with the pipeline field of scope,
+see FieldPipeline, until the returned function is called.
+Entries without pipeline field, such as the ones logged by plugins with the standard logger,
+are only copied while the captures all belong to the same pipeline, as they can't be told apart
+when pipelines run concurrently.
+*/
+func Capture(scope *logrus.Entry, w io.Writer) (stop func()) {
+	registerCaptureHook.Do(func() {
+		logrus.AddHook(captureHook{})
+	})
+
+	key := pipelineKey(Scope(scope).Data)
+
+	capturesMutex.Lock()
+	captures[key] = append(captures[key], w)
+	capturesMutex.Unlock()
+
+	return func() {
+		capturesMutex.Lock()
+		defer capturesMutex.Unlock()
+
+		writers := captures[key]
+		for i := range writers {
+			if writers[i] == w {
+				captures[key] = append(writers[:i:i], writers[i+1:]...)
+				break
+			}
+		}
+		if len(captures[key]) == 0 {
+			delete(captures, key)
+		}
+	}
+}
+
+// pipelineKey returns the value of the pipeline field of data, or an empty string if it isn't defined
+func pipelineKey(data logrus.Fields) string {
+	pipeline, ok := data[FieldPipeline]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(pipeline)
+}
+
+// captureHook is a logrus hook writing log entries to the captures of their pipeline
+type captureHook struct{}
+
+// Levels returns the log levels captured
+func (captureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes the log entry to the captures of its pipeline
+func (captureHook) Fire(entry *logrus.Entry) error {
+	capturesMutex.Lock()
+	defer capturesMutex.Unlock()
+
+	key := pipelineKey(entry.Data)
+
+	writers, ok := captures[key]
+	if !ok && key == "" && len(captures) == 1 {
+		for _, w := range captures {
+			writers = w
+		}
+	}
+
+	if len(writers) == 0 {
+		return nil
+	}
+
+	b, err := entry.Logger.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range writers {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package log
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel(t *testing.T) {
+	f := TextFormat{}
+
+	b, err := f.Format(&logrus.Entry{Level: logrus.InfoLevel, Message: "first line\nsecond line\n"})
+	assert.NoError(t, err)
+	assert.Equal(t, "first line\nsecond line\n", string(b))
+
+	b, err = f.Format(&logrus.Entry{
+		Level:   logrus.InfoLevel,
+		Message: "first line\n\nsecond line",
+		Data:    logrus.Fields{FieldLabel: "pipeline"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "[pipeline] first line\n\n[pipeline] second line\n", string(b))
+}
+
+func TestCapture(t *testing.T) {
+	logger := logrus.StandardLogger()
+	output, formatter := logger.Out, logger.Formatter
+	defer func() {
+		logrus.SetOutput(output)
+		logrus.SetFormatter(formatter)
+	}()
+
+	var out bytes.Buffer
+	var outMutex sync.Mutex
+	logrus.SetOutput(writerFunc(func(p []byte) (int, error) {
+		outMutex.Lock()
+		defer outMutex.Unlock()
+		return out.Write(p)
+	}))
+	logrus.SetFormatter(&TextFormat{})
+
+	captured := map[string]*bytes.Buffer{"a": {}, "b": {}}
+	started, logged := sync.WaitGroup{}, sync.WaitGroup{}
+	started.Add(len(captured))
+	logged.Add(len(captured))
+
+	wg := sync.WaitGroup{}
+	for label, buffer := range captured {
+		wg.Add(1)
+		go func(label string, buffer *bytes.Buffer) {
+			defer wg.Done()
+			scope := logrus.WithFields(logrus.Fields{FieldPipeline: label, FieldLabel: label})
+
+			stop := Capture(scope, buffer)
+			started.Done()
+			// Wait for every capture, so the entries without pipeline field can't be told apart
+			started.Wait()
+
+			for i := 0; i < 3; i++ {
+				scope.Infof("message from %s", label)
+			}
+			logrus.Infof("plugin message from %s", label)
+			logged.Done()
+			logged.Wait()
+			stop()
+			scope.Infof("message from %s after capture", label)
+		}(label, buffer)
+	}
+	wg.Wait()
+
+	for label, buffer := range captured {
+		expected := ""
+		for i := 0; i < 3; i++ {
+			expected += "[" + label + "] message from " + label + "\n"
+		}
+		assert.Equal(t, expected, buffer.String())
+		assert.Contains(t, out.String(), "["+label+"] message from "+label+" after capture\n")
+		assert.Contains(t, out.String(), "plugin message from "+label+"\n")
+	}
+	assert.Len(t, captures, 0)
+}
+
+func TestCaptureStandardLogger(t *testing.T) {
+	var captured bytes.Buffer
+	scope := logrus.WithField(FieldPipeline, "pipeline")
+
+	stop := Capture(scope, &captured)
+	scope.Infof("pipeline message")
+	logrus.Infof("plugin message")
+	stop()
+
+	assert.Contains(t, captured.String(), "pipeline message")
+	assert.Contains(t, captured.String(), "plugin message", "only one pipeline is captured")
+}
+
+// writerFunc is an io.Writer calling itself
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
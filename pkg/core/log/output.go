@@ -0,0 +1,112 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// outputs holds the writers receiving every log entry, in addition to the logrus output
+	outputs []io.Writer
+	// outputsMutex protects outputs
+	outputsMutex sync.Mutex
+	// registerOutputHook ensures the output hook is only registered once
+	registerOutputHook sync.Once
+)
+
+/*
+AddOutput copies every log entry, no matter its label, to w in addition to the logrus output,
+until the returned function is called.
+*/
+func AddOutput(w io.Writer) (stop func()) {
+	registerOutputHook.Do(func() {
+		logrus.AddHook(outputHook{})
+	})
+
+	outputsMutex.Lock()
+	outputs = append(outputs, w)
+	outputsMutex.Unlock()
+
+	return func() {
+		outputsMutex.Lock()
+		defer outputsMutex.Unlock()
+
+		for i := range outputs {
+			if outputs[i] == w {
+				outputs = append(outputs[:i:i], outputs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+/*
+StartRunLog copies every log entry to a new file of dir, named after the run name, such as "apply", and its start time,
+until the returned function is called. It returns the file path.
+*/
+func StartRunLog(dir, name string) (path string, stop func(), err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", nil, fmt.Errorf("creating log directory: %w", err)
+	}
+
+	// Run names, such as "manifest/show" or "server-webhook (GitHub release event)", aren't valid file names
+	name = strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' {
+			return r
+		}
+		return '-'
+	}, name), "-")
+	path = filepath.Join(dir, fmt.Sprintf("updatecli-%s-%s.log", name, time.Now().Format("20060102-150405.000")))
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", nil, fmt.Errorf("creating log file: %w", err)
+	}
+
+	stopOutput := AddOutput(file)
+
+	return path, func() {
+		stopOutput()
+		if err := file.Close(); err != nil {
+			logrus.Errorf("closing log file %q: %s", path, err)
+		}
+	}, nil
+}
+
+// outputHook is a logrus hook writing log entries to the outputs
+type outputHook struct{}
+
+// Levels returns the log levels written
+func (outputHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes the log entry to the outputs
+func (outputHook) Fire(entry *logrus.Entry) error {
+	outputsMutex.Lock()
+	defer outputsMutex.Unlock()
+
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	b, err := entry.Logger.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range outputs {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartRunLog(t *testing.T) {
+	logger := logrus.StandardLogger()
+	output, formatter := logger.Out, logger.Formatter
+	defer func() {
+		logrus.SetOutput(output)
+		logrus.SetFormatter(formatter)
+	}()
+
+	logrus.SetOutput(writerFunc(func(p []byte) (int, error) { return len(p), nil }))
+	logrus.SetFormatter(&TextFormat{})
+
+	dir := filepath.Join(t.TempDir(), "logs")
+
+	path, stop, err := StartRunLog(dir, "server-webhook (GitHub release event)")
+	require.NoError(t, err)
+
+	assert.Equal(t, dir, filepath.Dir(path))
+	assert.Regexp(t, `^updatecli-server-webhook--GitHub-release-event-\d{8}-\d{6}\.\d{3}\.log$`, filepath.Base(path))
+
+	logrus.Infof("message during the run")
+	stop()
+	logrus.Infof("message after the run")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "message during the run\n", string(content))
+}
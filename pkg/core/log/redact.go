@@ -0,0 +1,47 @@
+package log
+
+import (
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces the secrets in the log entries
+const redactedValue = "****"
+
+var (
+	// secrets holds the values redacted from the log entries
+	secrets []string
+	// secretsMutex protects secrets
+	secretsMutex sync.RWMutex
+)
+
+// AddSecret redacts value from the log entries formatted by TextFormat, such as a secret resolved from Vault
+func AddSecret(value string) {
+	// Short values would redact unrelated words
+	if len(strings.TrimSpace(value)) < 4 {
+		return
+	}
+
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+
+	for _, secret := range secrets {
+		if secret == value {
+			return
+		}
+	}
+
+	secrets = append(secrets, value)
+}
+
+// redact returns message with every secret replaced
+func redact(message string) string {
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+
+	for _, secret := range secrets {
+		message = strings.ReplaceAll(message, secret, redactedValue)
+	}
+
+	return message
+}
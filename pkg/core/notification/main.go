@@ -0,0 +1,136 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	jschema "github.com/invopop/jsonschema"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/jsonschema"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+	"github.com/updatecli/updatecli/pkg/plugins/notifications/slack"
+	"github.com/updatecli/updatecli/pkg/plugins/notifications/teams"
+	"github.com/updatecli/updatecli/pkg/plugins/notifications/webhook"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	slackIdentifier   = "slack"
+	teamsIdentifier   = "teams"
+	webhookIdentifier = "webhook"
+)
+
+var (
+	// ErrWrongConfig is returned when a notifier has missing mandatory attributes.
+	ErrWrongConfig = errors.New("wrong notifier configuration")
+)
+
+// Notifier interface defines required functions to be a notifier
+type Notifier interface {
+	Notify(report reports.RunReport) error
+}
+
+// Config defines a notifier provided via an updatecli configuration
+type Config struct {
+	// Kind defines the notifier `kind` which affects accepted "spec" values
+	Kind string `yaml:",omitempty" jsonschema:"required"`
+	// Spec defines parameters for a specific "kind"
+	Spec interface{} `yaml:",omitempty"`
+}
+
+// Validate ensures that a notifier configuration has required parameters.
+func (c *Config) Validate() error {
+	if c.Kind == "" {
+		return fmt.Errorf("%w: missing value for parameter(s) [%q]", ErrWrongConfig, "kind")
+	}
+
+	// Ensure kind is lowercase
+	if c.Kind != strings.ToLower(c.Kind) {
+		logrus.Warningf("kind value %q must be lowercase", c.Kind)
+		c.Kind = strings.ToLower(c.Kind)
+	}
+
+	if _, err := New(*c); err != nil {
+		if errors.Is(err, ErrWrongConfig) {
+			return err
+		}
+		return fmt.Errorf("%w: %s", ErrWrongConfig, err)
+	}
+
+	return nil
+}
+
+// New returns the notifier defined by the notifier configuration
+func New(config Config) (Notifier, error) {
+	// Don't forget to update the JSONSchema() method when adding/updating/removing a case
+	switch config.Kind {
+	case slackIdentifier:
+		return slack.New(config.Spec)
+	case teamsIdentifier:
+		return teams.New(config.Spec)
+	case webhookIdentifier:
+		return webhook.New(config.Spec)
+	default:
+		return nil, fmt.Errorf("%w: notifier of kind %q is not supported", ErrWrongConfig, config.Kind)
+	}
+}
+
+// Notify sends the run report to every notifier defined by configs, logging failures
+// so a notification doesn't fail the run
+func Notify(configs map[string]Config, report reports.RunReport) {
+	for id, config := range configs {
+		notifier, err := New(config)
+		if err != nil {
+			logrus.Errorf("notifier %q: %s", id, err)
+			continue
+		}
+
+		if err := notifier.Notify(report); err != nil {
+			logrus.Errorf("notifier %q: %s", id, err)
+			continue
+		}
+
+		logrus.Debugf("notifier %q notified", id)
+	}
+}
+
+// JSONSchema implements the json schema interface to generate the "notifier" jsonschema
+func (Config) JSONSchema() *jschema.Schema {
+
+	type configAlias Config
+
+	anyOfSpec := map[string]interface{}{
+		slackIdentifier:   &slack.Spec{},
+		teamsIdentifier:   &teams.Spec{},
+		webhookIdentifier: &webhook.Spec{},
+	}
+
+	return jsonschema.AppendOneOfToJsonSchema(configAlias{}, anyOfSpec)
+}
+
+// LoadConfigs returns the notifiers defined under the "notifiers" key of the YAML file filename
+func LoadConfigs(filename string) (map[string]Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	file := struct {
+		Notifiers map[string]Config `yaml:"notifiers"`
+	}{}
+
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing notifiers file %q: %w", filename, err)
+	}
+
+	for id, config := range file.Notifiers {
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("notifier %q: %w", id, err)
+		}
+		file.Notifiers[id] = config
+	}
+
+	return file.Notifiers, nil
+}
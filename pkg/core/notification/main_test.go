@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testData := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "Valid slack notifier",
+			config: Config{Kind: "Slack", Spec: map[string]interface{}{"url": "https://hooks.slack.com/services/xxx"}},
+		},
+		{
+			name:    "Missing kind",
+			config:  Config{Spec: map[string]interface{}{"url": "https://example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported kind",
+			config:  Config{Kind: "irc"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing url",
+			config:  Config{Kind: "webhook"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrWrongConfig)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "slack", tt.config.Kind)
+		})
+	}
+}
+
+func TestLoadConfigs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "notifiers.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte(`notifiers:
+  team:
+    kind: teams
+    spec:
+      url: https://example.webhook.office.com/webhookb2/xxx
+`), 0o600))
+
+	configs, err := LoadConfigs(filename)
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "teams", configs["team"].Kind)
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/updatecli/updatecli/pkg/core/jsonschema"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/reports"
+	azuredevops "github.com/updatecli/updatecli/pkg/plugins/resources/azuredevops/pullrequest"
+	bitbucket "github.com/updatecli/updatecli/pkg/plugins/resources/bitbucket/pullrequest"
 	gitea "github.com/updatecli/updatecli/pkg/plugins/resources/gitea/pullrequest"
 	gitlab "github.com/updatecli/updatecli/pkg/plugins/resources/gitlab/mergerequest"
 	stash "github.com/updatecli/updatecli/pkg/plugins/resources/stash/pullrequest"
+	azuredevopsscm "github.com/updatecli/updatecli/pkg/plugins/scms/azuredevops"
+	bitbucketscm "github.com/updatecli/updatecli/pkg/plugins/scms/bitbucket"
 	giteascm "github.com/updatecli/updatecli/pkg/plugins/scms/gitea"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/github"
 	gitlabscm "github.com/updatecli/updatecli/pkg/plugins/scms/gitlab"
@@ -21,10 +25,13 @@ import (
 )
 
 const (
-	gitlabIdentifier = "gitlab"
-	githubIdentifier = "github"
-	giteaIdentifier  = "gitea"
-	stashIdentifier  = "stash"
+	azuredevopsIdentifier = "azuredevops"
+	bitbucketIdentifier   = "bitbucket"
+	forgejoIdentifier     = "forgejo"
+	gitlabIdentifier      = "gitlab"
+	githubIdentifier      = "github"
+	giteaIdentifier       = "gitea"
+	stashIdentifier       = "stash"
 )
 
 var (
@@ -34,7 +41,7 @@ var (
 
 // ActionHandler interface defines required functions to be an action
 type ActionHandler interface {
-	CreateAction(report reports.Action) error
+	CreateAction(report *reports.Action) error
 }
 
 // Config define action provided via an updatecli configuration
@@ -140,10 +147,11 @@ func (a *Action) Update() error {
 func (a *Action) generateActionHandler() error {
 	// Don't forget to update the JSONSchema() method when adding/updating/removing a case
 	switch a.Config.Kind {
-	case "gitea/pullrequest", giteaIdentifier:
+	// Forgejo is a Gitea fork exposing the same api
+	case "gitea/pullrequest", giteaIdentifier, "forgejo/pullrequest":
 		actionSpec := gitea.Spec{}
 
-		if a.Scm.Config.Kind != giteaIdentifier {
+		if a.Scm.Config.Kind != giteaIdentifier && a.Scm.Config.Kind != forgejoIdentifier {
 			return fmt.Errorf("scm of kind %q is not compatible with action of kind %q",
 				a.Scm.Config.Kind,
 				a.Config.Kind)
@@ -252,6 +260,62 @@ func (a *Action) generateActionHandler() error {
 
 		a.Handler = &g
 
+	case "azuredevops/pullrequest":
+		actionSpec := azuredevops.Spec{}
+
+		if a.Scm.Config.Kind != azuredevopsIdentifier {
+			return fmt.Errorf("scm of kind %q is not compatible with action of kind %q",
+				a.Scm.Config.Kind,
+				a.Config.Kind)
+		}
+
+		err := mapstructure.Decode(a.Config.Spec, &actionSpec)
+		if err != nil {
+			return err
+		}
+
+		ad, ok := a.Scm.Handler.(*azuredevopsscm.AzureDevOps)
+
+		if !ok {
+			return fmt.Errorf("scm is not of kind 'azuredevops'")
+		}
+
+		g, err := azuredevops.New(actionSpec, ad)
+
+		if err != nil {
+			return err
+		}
+
+		a.Handler = &g
+
+	case "bitbucket/pullrequest":
+		actionSpec := bitbucket.Spec{}
+
+		if a.Scm.Config.Kind != bitbucketIdentifier {
+			return fmt.Errorf("scm of kind %q is not compatible with action of kind %q",
+				a.Scm.Config.Kind,
+				a.Config.Kind)
+		}
+
+		err := mapstructure.Decode(a.Config.Spec, &actionSpec)
+		if err != nil {
+			return err
+		}
+
+		bb, ok := a.Scm.Handler.(*bitbucketscm.Bitbucket)
+
+		if !ok {
+			return fmt.Errorf("scm is not of kind 'bitbucket'")
+		}
+
+		b, err := bitbucket.New(actionSpec, bb)
+
+		if err != nil {
+			return err
+		}
+
+		a.Handler = &b
+
 	default:
 		logrus.Errorf("scm of kind %q is not supported", a.Config.Kind)
 	}
@@ -265,10 +329,13 @@ func (Config) JSONSchema() *jschema.Schema {
 	type configAlias Config
 
 	anyOfSpec := map[string]interface{}{
-		"github/pullrequest":  &github.ActionSpec{},
-		"gitea/pullrequest":   &gitea.Spec{},
-		"stash/pullrequest":   &stash.Spec{},
-		"gitlab/mergerequest": &gitlab.Spec{},
+		"azuredevops/pullrequest": &azuredevops.Spec{},
+		"bitbucket/pullrequest":   &bitbucket.Spec{},
+		"forgejo/pullrequest":     &gitea.Spec{},
+		"github/pullrequest":      &github.ActionSpec{},
+		"gitea/pullrequest":       &gitea.Spec{},
+		"stash/pullrequest":       &stash.Spec{},
+		"gitlab/mergerequest":     &gitlab.Spec{},
 	}
 
 	return jsonschema.AppendOneOfToJsonSchema(configAlias{}, anyOfSpec)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 )
 
 func Test_Validate(t *testing.T) {
@@ -91,3 +92,57 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestNewForgejoPullRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		scmKind string
+		kind    string
+		wantErr bool
+	}{
+		{
+			name:    "Forgejo pullrequest with a forgejo scm",
+			scmKind: "forgejo",
+			kind:    "forgejo/pullrequest",
+		},
+		{
+			name:    "Gitea pullrequest with a forgejo scm",
+			scmKind: "forgejo",
+			kind:    "gitea/pullrequest",
+		},
+		{
+			name:    "Forgejo pullrequest with a gitea scm",
+			scmKind: "gitea",
+			kind:    "forgejo/pullrequest",
+		},
+		{
+			name:    "Forgejo pullrequest with a stash scm",
+			scmKind: "stash",
+			kind:    "forgejo/pullrequest",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := scm.New(&scm.Config{
+				Kind: tt.scmKind,
+				Spec: map[string]interface{}{
+					"url":        "codeberg.org",
+					"owner":      "updatecli",
+					"repository": "updatecli",
+					"directory":  t.TempDir(),
+				},
+			}, "xxx")
+			require.NoError(t, err)
+
+			a, err := New(&Config{Kind: tt.kind, ScmID: "default"}, &s)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, a.Handler)
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/action"
 	"github.com/updatecli/updatecli/pkg/core/reports"
 	"github.com/updatecli/updatecli/pkg/core/result"
@@ -14,36 +13,36 @@ import (
 func (p *Pipeline) RunActions() error {
 
 	if len(p.Targets) == 0 {
-		logrus.Debugln("no target found, skipping action")
+		p.Logger.Debugln("no target found, skipping action")
 		return nil
 	}
 
 	if len(p.Actions) == 0 {
-		logrus.Debugln("no action found, skipping")
+		p.Logger.Debugln("no action found, skipping")
 		return nil
 	}
 
 	if len(p.Actions) > 0 {
-		logrus.Infof("\n\n%s\n", strings.ToTitle("Actions"))
-		logrus.Infof("%s\n\n", strings.Repeat("=", len("Actions")+1))
+		p.Logger.Infof("\n\n%s\n", strings.ToTitle("Actions"))
+		p.Logger.Infof("%s\n\n", strings.Repeat("=", len("Actions")+1))
 	}
 
 	for id, action := range p.Actions {
 		relatedTargets, err := p.SearchAssociatedTargetsID(id)
 		if err != nil {
-			logrus.Errorf(err.Error())
+			p.Logger.Errorf(err.Error())
 			continue
 		}
 
 		// Update pipeline before each condition run
 		err = p.Update()
 		if err != nil {
-			logrus.Errorf(err.Error())
+			p.Logger.Errorf(err.Error())
 			continue
 		}
 
 		if err != nil {
-			logrus.Errorf(err.Error())
+			p.Logger.Errorf(err.Error())
 			continue
 		}
 
@@ -81,6 +80,9 @@ func (p *Pipeline) RunActions() error {
 				ID:          fmt.Sprintf("%x", sha256.Sum256([]byte(t))),
 				Title:       p.Targets[t].Config.Name,
 				Description: p.Targets[t].Result.Description,
+				OldValue:    p.Targets[t].Result.Information,
+				NewValue:    p.Targets[t].Result.NewInformation,
+				Files:       p.Targets[t].Result.Files,
 			}
 
 			if p.Sources[p.Targets[t].Config.SourceID].Changelog != "" {
@@ -123,7 +125,7 @@ func (p *Pipeline) RunActions() error {
 
 		// Ignoring failed targets
 		if len(failedTargetIDs) > 0 {
-			logrus.Errorf("%d target(s) (%s) failed for action %q", len(failedTargetIDs), strings.Join(failedTargetIDs, ","), id)
+			p.Logger.Errorf("%d target(s) (%s) failed for action %q", len(failedTargetIDs), strings.Join(failedTargetIDs, ","), id)
 		}
 
 		// Ignoring skipped targets
@@ -133,23 +135,23 @@ func (p *Pipeline) RunActions() error {
 
 		if p.Options.Target.DryRun || !p.Options.Target.Push {
 			if len(attentionTargetIDs) > 0 {
-				logrus.Infof("[Dry Run] An action of kind %q is expected.", action.Config.Kind)
+				p.Logger.Infof("[Dry Run] An action of kind %q is expected.", action.Config.Kind)
 
 				actionDebugOutput := fmt.Sprintf("The expected action would have the following information:\n\n##Title:\n%s\n##Report:\n\n%s\n\n=====\n",
 					actionTitle,
 					action.Report.String())
-				logrus.Debugf(strings.ReplaceAll(actionDebugOutput, "\n", "\n\t|\t"))
+				p.Logger.Debugf(strings.ReplaceAll(actionDebugOutput, "\n", "\n\t|\t"))
 			}
 
 			actionOutput := fmt.Sprintf("The expected action would have the following information:\n\n##Title:\n%s\n\n\n##Report:\n\n%s\n\n=====\n",
 				actionTitle,
 				action.Report.String())
-			logrus.Debugf(strings.ReplaceAll(actionOutput, "\n", "\n\t|\t"))
+			p.Logger.Debugf(strings.ReplaceAll(actionOutput, "\n", "\n\t|\t"))
 
 			return nil
 		}
 
-		err = action.Handler.CreateAction(action.Report)
+		err = action.Handler.CreateAction(&action.Report)
 
 		if err != nil {
 			return err
@@ -15,6 +15,7 @@ import (
 	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/helmfile"
 	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/maven"
 	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/npm"
+	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/precommit"
 )
 
 var (
@@ -29,6 +30,7 @@ var (
 			"helmfile":      helmfile.Spec{},
 			"maven":         maven.Spec{},
 			"npm":           npm.Spec{},
+			"precommit":     precommit.Spec{},
 			"rancher/fleet": fleet.Spec{},
 		},
 	}
@@ -42,6 +44,7 @@ var (
 		"helmfile":      &helmfile.Spec{},
 		"maven":         &maven.Spec{},
 		"npm":           &npm.Spec{},
+		"precommit":     &precommit.Spec{},
 		"rancher/fleet": &fleet.Spec{},
 	}
 )
@@ -176,6 +179,18 @@ func New(spec Config, workDir string) (*AutoDiscovery, error) {
 
 			g.crawlers = append(g.crawlers, crawler)
 
+		case "precommit":
+			crawler, err := precommit.New(
+				g.spec.Crawlers[kind],
+				workDir,
+				g.spec.ScmId)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s - %s", kind, err))
+				continue
+			}
+
+			g.crawlers = append(g.crawlers, crawler)
+
 		case "rancher/fleet":
 			crawler, err := fleet.New(
 				g.spec.Crawlers[kind],
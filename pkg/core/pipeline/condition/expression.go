@@ -0,0 +1,201 @@
+package condition
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/*
+Expression is a boolean expression referencing conditions by id, such as
+"(dockerTagExists || latestRelease) && !frozen".
+
+It supports the operators "&&", "||" and "!", evaluated in this order of precedence, and parentheses.
+*/
+type Expression interface {
+	// Evaluate returns the expression result, based on the result of each referenced condition
+	Evaluate(result func(id string) bool) bool
+	// IDs returns the id of every referenced condition
+	IDs() []string
+}
+
+type idExpression string
+
+func (e idExpression) Evaluate(result func(id string) bool) bool {
+	return result(string(e))
+}
+
+func (e idExpression) IDs() []string {
+	return []string{string(e)}
+}
+
+type notExpression struct {
+	expression Expression
+}
+
+func (e notExpression) Evaluate(result func(id string) bool) bool {
+	return !e.expression.Evaluate(result)
+}
+
+func (e notExpression) IDs() []string {
+	return e.expression.IDs()
+}
+
+type andExpression struct {
+	left, right Expression
+}
+
+func (e andExpression) Evaluate(result func(id string) bool) bool {
+	return e.left.Evaluate(result) && e.right.Evaluate(result)
+}
+
+func (e andExpression) IDs() []string {
+	return append(e.left.IDs(), e.right.IDs()...)
+}
+
+type orExpression struct {
+	left, right Expression
+}
+
+func (e orExpression) Evaluate(result func(id string) bool) bool {
+	return e.left.Evaluate(result) || e.right.Evaluate(result)
+}
+
+func (e orExpression) IDs() []string {
+	return append(e.left.IDs(), e.right.IDs()...)
+}
+
+// ParseExpression parses a boolean expression referencing conditions by id
+func ParseExpression(expression string) (Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty condition expression")
+	}
+
+	p := parser{tokens: tokens}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parsing condition expression %q: %w", expression, err)
+	}
+
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("parsing condition expression %q: unexpected %q", expression, p.tokens[p.position])
+	}
+
+	return e, nil
+}
+
+// tokenize splits an expression into operators, parentheses and condition ids
+func tokenize(expression string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(expression); {
+		switch c := expression[i]; {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')' || c == '!':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.HasPrefix(expression[i:], "&&"), strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, expression[i:i+2])
+			i += 2
+		case c == '&' || c == '|':
+			return nil, fmt.Errorf("unexpected %q in condition expression %q, did you mean %q?", string(c), expression, strings.Repeat(string(c), 2))
+		default:
+			end := i
+			for end < len(expression) && !strings.ContainsRune("()!&| \t\n\r", rune(expression[end])) {
+				end++
+			}
+			tokens = append(tokens, expression[i:end])
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens   []string
+	position int
+}
+
+// next returns the current token, or an empty string at the end of the expression
+func (p *parser) next() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+	return ""
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.next() == "||" {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpression{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.next() == "&&" {
+		p.position++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpression{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	token := p.next()
+
+	switch token {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "!":
+		p.position++
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpression{expression: e}, nil
+	case "(":
+		p.position++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.position++
+		return e, nil
+	case ")", "&&", "||":
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+
+	p.position++
+	return idExpression(token), nil
+}
@@ -0,0 +1,97 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	results := map[string]bool{
+		"dockerTagExists": false,
+		"latestRelease":   true,
+		"frozen":          false,
+		"docker-tag.v2":   true,
+	}
+
+	tests := []struct {
+		name           string
+		expression     string
+		expectedResult bool
+		expectedIDs    []string
+		wantErr        bool
+	}{
+		{
+			name:           "Single condition",
+			expression:     "latestRelease",
+			expectedResult: true,
+			expectedIDs:    []string{"latestRelease"},
+		},
+		{
+			name:           "Or combined with and and not",
+			expression:     "(dockerTagExists || latestRelease) && !frozen",
+			expectedResult: true,
+			expectedIDs:    []string{"dockerTagExists", "latestRelease", "frozen"},
+		},
+		{
+			name:           "And takes precedence over or",
+			expression:     "latestRelease || dockerTagExists && frozen",
+			expectedResult: true,
+			expectedIDs:    []string{"latestRelease", "dockerTagExists", "frozen"},
+		},
+		{
+			name:           "Parentheses override precedence",
+			expression:     "(latestRelease || dockerTagExists) && frozen",
+			expectedResult: false,
+			expectedIDs:    []string{"latestRelease", "dockerTagExists", "frozen"},
+		},
+		{
+			name:           "Double negation without spaces",
+			expression:     "!!docker-tag.v2&&!frozen",
+			expectedResult: true,
+			expectedIDs:    []string{"docker-tag.v2", "frozen"},
+		},
+		{
+			name:       "Empty expression",
+			expression: " ",
+			wantErr:    true,
+		},
+		{
+			name:       "Single ampersand",
+			expression: "latestRelease & frozen",
+			wantErr:    true,
+		},
+		{
+			name:       "Missing closing parenthesis",
+			expression: "(latestRelease || frozen",
+			wantErr:    true,
+		},
+		{
+			name:       "Missing operand",
+			expression: "latestRelease ||",
+			wantErr:    true,
+		},
+		{
+			name:       "Missing operator",
+			expression: "latestRelease frozen",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := ParseExpression(tt.expression)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, e.Evaluate(func(id string) bool {
+				return results[id]
+			}))
+			assert.Equal(t, tt.expectedIDs, e.IDs())
+		})
+	}
+}
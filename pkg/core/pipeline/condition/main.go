@@ -3,13 +3,12 @@ package condition
 import (
 	"bytes"
 	"errors"
-	"io"
-	"os"
 	"strings"
 
 	jschema "github.com/invopop/jsonschema"
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/jsonschema"
+	"github.com/updatecli/updatecli/pkg/core/log"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
@@ -29,6 +28,9 @@ type Condition struct {
 	Config Config
 	// Scm stores scm information
 	Scm *scm.ScmHandler
+	// Logger logs the entries of the condition run, with the fields of its pipeline, stage, and plugin.
+	// Default to the standard logger
+	Logger *logrus.Entry
 }
 
 // Config defines conditions input parameters
@@ -48,15 +50,15 @@ type Config struct {
 func (c *Condition) Run(source string) (err error) {
 
 	var consoleOutput bytes.Buffer
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	logrus.SetOutput(io.MultiWriter(os.Stderr, &consoleOutput))
+	// Copy the log entries of the current pipeline, still written to stderr, to the console output
+	logger := log.Scope(c.Logger)
+	stopCapture := log.Capture(logger, &consoleOutput)
 	/*
 		The last defer will be executed first,
 		so in this case we want to first save the console output
-		before setting back the logrus output to stdout.
+		before stopping its capture.
 	*/
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	defer logrus.SetOutput(os.Stderr)
+	defer stopCapture()
 	defer c.Result.SetConsoleOutput(&consoleOutput)
 
 	c.Result.Result = result.FAILURE
@@ -104,7 +106,7 @@ func (c *Condition) Run(source string) (err error) {
 	// FailWhen is used to reverse the expected condition value
 	// If failwhen is set to true, then we expected a condition returning "true" would be considered as a failure
 	if c.Config.FailWhen {
-		logrus.Debugf("Expected successful condition result to be %v", !c.Config.FailWhen)
+		logger.Debugf("Expected successful condition result to be %v", !c.Config.FailWhen)
 		if c.Result.Pass {
 			c.Result.Result = result.FAILURE
 			c.Result.Pass = false
@@ -114,7 +116,7 @@ func (c *Condition) Run(source string) (err error) {
 		}
 	}
 
-	logrus.Infof("%s %s", c.Result.Result, c.Result.Description)
+	logger.Infof("%s %s", c.Result.Result, c.Result.Description)
 
 	return nil
 }
@@ -3,15 +3,15 @@ package pipeline
 import (
 	"strings"
 
-	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
 // RunConditions run every conditions for a given configuration config.
 func (p *Pipeline) RunConditions() (globalResult bool, err error) {
 
-	logrus.Infof("\n\n%s:\n", strings.ToTitle("conditions"))
-	logrus.Infof("%s\n", strings.Repeat("=", len("conditions")+1))
+	p.Logger.Infof("\n\n%s:\n", strings.ToTitle("conditions"))
+	p.Logger.Infof("%s\n", strings.Repeat("=", len("conditions")+1))
 
 	// Sort conditions keys by building a dependency graph
 	sortedConditionsKeys, err := SortedConditionsKeys(&p.Conditions)
@@ -21,6 +21,14 @@ func (p *Pipeline) RunConditions() (globalResult bool, err error) {
 
 	globalResult = true
 
+	var expression condition.Expression
+	if len(p.Config.Spec.When) > 0 {
+		expression, err = condition.ParseExpression(p.Config.Spec.When)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	for _, id := range sortedConditionsKeys {
 		// Update pipeline before each condition run
 		err = p.Update()
@@ -32,14 +40,17 @@ func (p *Pipeline) RunConditions() (globalResult bool, err error) {
 		condition := p.Conditions[id]
 		condition.Config = p.Config.Spec.Conditions[id]
 
-		logrus.Infof("\n%s\n", id)
-		logrus.Infof("%s\n", strings.Repeat("-", len(id)))
+		p.Logger.Infof("\n%s\n", id)
+		p.Logger.Infof("%s\n", strings.Repeat("-", len(id)))
 
+		logger, done := p.instrumentResource("condition", id, condition.Config.Kind)
+		condition.Logger = logger
 		err := condition.Run(p.Sources[condition.Config.SourceID].Output)
 		if err != nil {
 			// Show error to end user if any but continue the flow execution
-			logrus.Error(err)
+			p.Logger.Error(err)
 		}
+		done(condition.Result.Result)
 
 		// If there was an error OR if the condition is not successful then defines the global result as false
 		if err != nil || condition.Result.Result != result.SUCCESS {
@@ -51,5 +62,13 @@ func (p *Pipeline) RunConditions() (globalResult bool, err error) {
 
 	}
 
+	// Conditions are combined by the "when" expression instead of all being required
+	if expression != nil {
+		globalResult = expression.Evaluate(func(id string) bool {
+			return p.Conditions[id].Result.Result == result.SUCCESS
+		})
+		p.Logger.Debugf("condition expression %q evaluated to %t", p.Config.Spec.When, globalResult)
+	}
+
 	return globalResult, nil
 }
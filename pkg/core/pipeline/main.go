@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/cmdoptions"
 	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/log"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/action"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
@@ -15,6 +16,7 @@ import (
 	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
 	"github.com/updatecli/updatecli/pkg/core/reports"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
 	"github.com/updatecli/updatecli/pkg/core/udash"
 )
 
@@ -35,6 +37,13 @@ type Pipeline struct {
 	Options Options
 
 	Config *config.Config
+
+	// Logger logs the pipeline entries, with the pipeline name as field once run.
+	// It can define other fields, such as the label prefixing the entries of pipelines run concurrently.
+	Logger *logrus.Entry
+
+	// span holds the trace span of the pipeline run
+	span *telemetry.Span
 }
 
 // Init initialize an updatecli context based on its configuration
@@ -193,9 +202,20 @@ func (p *Pipeline) Init(config *config.Config, options Options) error {
 // Run execute an single pipeline
 func (p *Pipeline) Run() error {
 
-	logrus.Infof("\n\n%s\n", strings.Repeat("#", len(p.Title)+4))
-	logrus.Infof("# %s #\n", strings.ToTitle(p.Title))
-	logrus.Infof("%s\n", strings.Repeat("#", len(p.Title)+4))
+	p.Logger = log.Scope(p.Logger).WithField(log.FieldPipeline, p.Name)
+
+	p.Logger.Infof("\n\n%s\n", strings.Repeat("#", len(p.Title)+4))
+	p.Logger.Infof("# %s #\n", strings.ToTitle(p.Title))
+	p.Logger.Infof("%s\n", strings.Repeat("#", len(p.Title)+4))
+
+	p.span = telemetry.StartSpan(nil, "pipeline "+p.Name, map[string]string{
+		"updatecli.pipeline":   p.Name,
+		"updatecli.pipelineid": p.ID,
+	})
+	defer func() {
+		telemetry.ObservePipeline(p.Report.Result)
+		p.span.End(p.Report.Result)
+	}()
 
 	if len(p.Sources) > 0 {
 		err := p.RunSources()
@@ -214,7 +234,7 @@ func (p *Pipeline) Run() error {
 			p.Report.Result = result.FAILURE
 			return fmt.Errorf("conditions stage:\t%q", err.Error())
 		} else if !ok {
-			logrus.Infof("\n%s condition not met, skipping pipeline\n", result.FAILURE)
+			p.Logger.Infof("\n%s condition not met, skipping pipeline\n", result.FAILURE)
 			return nil
 		}
 
@@ -243,8 +263,8 @@ func (p *Pipeline) Run() error {
 		if err != nil &&
 			!errors.Is(err, udash.ErrNoUdashBearerToken) &&
 			!errors.Is(err, udash.ErrNoUdashAPIURL) {
-			logrus.Infof("Skipping report publishing")
-			logrus.Debugf("publish report: %s", err)
+			p.Logger.Infof("Skipping report publishing")
+			p.Logger.Debugf("publish report: %s", err)
 		}
 	}
 
@@ -18,6 +18,7 @@ import (
 	giteaBranch "github.com/updatecli/updatecli/pkg/plugins/resources/gitea/branch"
 	giteaRelease "github.com/updatecli/updatecli/pkg/plugins/resources/gitea/release"
 	giteaTag "github.com/updatecli/updatecli/pkg/plugins/resources/gitea/tag"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/githubaction"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/githubrelease"
 	gitlabBranch "github.com/updatecli/updatecli/pkg/plugins/resources/gitlab/branch"
 	gitlabRelease "github.com/updatecli/updatecli/pkg/plugins/resources/gitlab/release"
@@ -28,14 +29,20 @@ import (
 	gomodule "github.com/updatecli/updatecli/pkg/plugins/resources/go/module"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/hcl"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/helm"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/http"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/jenkins"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/json"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/kubernetes"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/kustomize"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/maven"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/npm"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/nuget"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/pypi"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/shell"
 	stashBranch "github.com/updatecli/updatecli/pkg/plugins/resources/stash/branch"
 	stashTag "github.com/updatecli/updatecli/pkg/plugins/resources/stash/tag"
 	terraformLock "github.com/updatecli/updatecli/pkg/plugins/resources/terraform/lock"
+	terraformModule "github.com/updatecli/updatecli/pkg/plugins/resources/terraform/module"
 	terraformProvider "github.com/updatecli/updatecli/pkg/plugins/resources/terraform/provider"
 	terraformRegistry "github.com/updatecli/updatecli/pkg/plugins/resources/terraform/registry"
 	"github.com/updatecli/updatecli/pkg/plugins/resources/toml"
@@ -99,18 +106,22 @@ func New(rs ResourceConfig) (resource Resource, err error) {
 
 		return gitbranch.New(rs.Spec)
 
-	case "gitea/branch":
+	case "gitea/branch", "forgejo/branch":
 
 		return giteaBranch.New(rs.Spec)
 
-	case "gitea/tag":
+	case "gitea/tag", "forgejo/tag":
 
 		return giteaTag.New(rs.Spec)
 
-	case "gitea/release":
+	case "gitea/release", "forgejo/release":
 
 		return giteaRelease.New(rs.Spec)
 
+	case "githubaction":
+
+		return githubaction.New(rs.Spec)
+
 	case "githubrelease":
 
 		return githubrelease.New(rs.Spec)
@@ -155,6 +166,10 @@ func New(rs ResourceConfig) (resource Resource, err error) {
 
 		return helm.New(rs.Spec)
 
+	case "http":
+
+		return http.New(rs.Spec)
+
 	case "jenkins":
 
 		return jenkins.New(rs.Spec)
@@ -187,6 +202,10 @@ func New(rs ResourceConfig) (resource Resource, err error) {
 
 		return terraformLock.New(rs.Spec)
 
+	case "terraform/module":
+
+		return terraformModule.New(rs.Spec)
+
 	case "terraform/provider":
 
 		return terraformProvider.New(rs.Spec)
@@ -211,6 +230,22 @@ func New(rs ResourceConfig) (resource Resource, err error) {
 
 		return npm.New(rs.Spec)
 
+	case "pypi":
+
+		return pypi.New(rs.Spec)
+
+	case "nuget":
+
+		return nuget.New(rs.Spec)
+
+	case "kubernetes":
+
+		return kubernetes.New(rs.Spec)
+
+	case "kustomize":
+
+		return kustomize.New(rs.Spec)
+
 	default:
 
 		return nil, fmt.Errorf("%s Don't support resource kind: %v", result.FAILURE, rs.Kind)
@@ -237,27 +272,37 @@ func GetResourceMapping() map[string]interface{} {
 		"file":               &file.Spec{},
 		"gittag":             &gittag.Spec{},
 		"gitbranch":          &gitbranch.Spec{},
+		"forgejo/branch":     &giteaBranch.Spec{},
+		"forgejo/release":    &giteaRelease.Spec{},
+		"forgejo/tag":        &giteaTag.Spec{},
 		"gitea/branch":       &giteaBranch.Spec{},
 		"gitea/release":      &giteaRelease.Spec{},
 		"gitea/tag":          &giteaTag.Spec{},
 		"gitlab/branch":      &gitlabBranch.Spec{},
 		"gitlab/release":     &gitlabRelease.Spec{},
 		"gitlab/tag":         &gitlabTag.Spec{},
+		"githubaction":       &githubaction.Spec{},
 		"githubrelease":      &githubrelease.Spec{},
 		"golang":             &golang.Spec{},
 		"golang/gomod":       &gomod.Spec{},
 		"golang/module":      &gomodule.Spec{},
 		"hcl":                &hcl.Spec{},
 		"helmchart":          &helm.Spec{},
+		"http":               &http.Spec{},
 		"jenkins":            &jenkins.Spec{},
 		"json":               &json.Spec{},
+		"kubernetes":         &kubernetes.Spec{},
+		"kustomize":          &kustomize.Spec{},
 		"maven":              &maven.Spec{},
 		"npm":                &npm.Spec{},
+		"nuget":              &nuget.Spec{},
+		"pypi":               &pypi.Spec{},
 		"shell":              &shell.Spec{},
 		"stash/branch":       &stashBranch.Spec{},
 		"stash/tag":          &stashTag.Spec{},
 		"terraform/file":     &hcl.Spec{},
 		"terraform/lock":     &terraformLock.Spec{},
+		"terraform/module":   &terraformModule.Spec{},
 		"terraform/provider": &terraformProvider.Spec{},
 		"terraform/registry": &terraformRegistry.Spec{},
 		"toml":               &toml.Spec{},
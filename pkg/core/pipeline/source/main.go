@@ -3,12 +3,12 @@ package source
 import (
 	"bytes"
 	"errors"
-	"io"
 	"os"
 	"strings"
 
 	jschema "github.com/invopop/jsonschema"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/log"
 
 	"github.com/updatecli/updatecli/pkg/core/jsonschema"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
@@ -28,6 +28,9 @@ type Source struct {
 	Config Config
 	// Scm stores scm information
 	Scm *scm.ScmHandler
+	// Logger logs the entries of the source run, with the fields of its pipeline, stage, and plugin.
+	// Default to the standard logger
+	Logger *logrus.Entry
 }
 
 // Config struct defines a source configuration
@@ -44,15 +47,15 @@ var (
 func (s *Source) Run() (err error) {
 
 	var consoleOutput bytes.Buffer
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	logrus.SetOutput(io.MultiWriter(os.Stderr, &consoleOutput))
+	// Copy the log entries of the current pipeline, still written to stderr, to the console output
+	logger := log.Scope(s.Logger)
+	stopCapture := log.Capture(logger, &consoleOutput)
 	/*
 		The last defer will be executed first,
 		so in this case we want to first save the console output
-		before setting back the logrus output to stdout.
+		before stopping its capture.
 	*/
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	defer logrus.SetOutput(os.Stderr)
+	defer stopCapture()
 	defer s.Result.SetConsoleOutput(&consoleOutput)
 
 	source, err := resource.New(s.Config.ResourceConfig)
@@ -94,31 +97,31 @@ func (s *Source) Run() (err error) {
 
 	if err != nil {
 		s.Result.Result = result.FAILURE
-		logrus.Errorf("%s %s", s.Result.Result, err)
+		logger.Errorf("%s %s", s.Result.Result, err)
 		return err
 	}
 
-	logrus.Infof("%s %s", s.Result.Result, s.Result.Description)
+	logger.Infof("%s %s", s.Result.Result, s.Result.Description)
 
 	// Once the source is executed, then it can retrieve its changelog
 	// Any error means an empty changelog
 	s.Changelog = source.Changelog()
 	if s.Changelog == "" {
-		logrus.Debugln("empty changelog found for the source")
+		logger.Debugln("empty changelog found for the source")
 	}
 	s.Result.Changelog = s.Changelog
 
 	if len(s.Config.ResourceConfig.Transformers) > 0 {
 		s.Output, err = s.Config.ResourceConfig.Transformers.Apply(s.Output)
 		if err != nil {
-			logrus.Errorf("%s %s", s.Result.Result, err)
+			logger.Errorf("%s %s", s.Result.Result, err)
 			s.Result.Result = result.FAILURE
 			return err
 		}
 	}
 
 	if len(s.Output) == 0 && s.Result.Result == result.SUCCESS {
-		logrus.Debugln("empty source detected")
+		logger.Debugln("empty source detected")
 	}
 
 	return err
@@ -3,19 +3,18 @@ package pipeline
 import (
 	"strings"
 
-	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
 // RunSources iterates on every source definition to retrieve every information.
 func (p *Pipeline) RunSources() error {
 
-	logrus.Infof("\n\n%s\n", strings.ToTitle("Sources"))
-	logrus.Infof("%s\n", strings.Repeat("=", len("Source")+1))
+	p.Logger.Infof("\n\n%s\n", strings.ToTitle("Sources"))
+	p.Logger.Infof("%s\n", strings.Repeat("=", len("Source")+1))
 
 	sortedSourcesKeys, err := SortedSourcesKeys(&p.Sources)
 	if err != nil {
-		logrus.Errorf("%s %v\n", result.FAILURE, err)
+		p.Logger.Errorf("%s %v\n", result.FAILURE, err)
 		return err
 	}
 
@@ -28,13 +27,13 @@ func (p *Pipeline) RunSources() error {
 		source := p.Sources[id]
 		source.Config = p.Config.Spec.Sources[id]
 
-		logrus.Infof("\n%s\n", id)
-		logrus.Infof("%s\n", strings.Repeat("-", len(id)))
+		p.Logger.Infof("\n%s\n", id)
+		p.Logger.Infof("%s\n", strings.Repeat("-", len(id)))
 
 		shouldRunSource := true
 		for _, parentSource := range source.Config.DependsOn {
 			if p.Sources[parentSource].Result.Result != result.SUCCESS {
-				logrus.Warningf("Parent source[%q] did not succeed. Skipping execution of the source[%q]", parentSource, id)
+				p.Logger.Warningf("Parent source[%q] did not succeed. Skipping execution of the source[%q]", parentSource, id)
 				shouldRunSource = false
 			}
 		}
@@ -43,21 +42,26 @@ func (p *Pipeline) RunSources() error {
 			continue
 		}
 
+		logger, done := p.instrumentResource("source", id, source.Config.Kind)
+		source.Logger = logger
 		err = source.Run()
 		if err != nil {
 			source.Result.Result = result.FAILURE
+			done(source.Result.Result)
 
 			p.Sources[id] = source
 			p.Report.Sources[id] = &source.Result
 
-			logrus.Errorf("%s %v\n", source.Result, err)
+			p.Logger.Errorf("%s %v\n", source.Result, err)
 			continue
 		}
 
+		done(source.Result.Result)
+
 		if len(source.Changelog) > 0 {
-			logrus.Infof("\n\n%s:\n", strings.ToTitle("Changelog"))
-			logrus.Infof("%s\n", strings.Repeat("-", len("Changelog")+1))
-			logrus.Infof("%s\n", source.Changelog)
+			p.Logger.Infof("\n\n%s:\n", strings.ToTitle("Changelog"))
+			p.Logger.Infof("%s\n", strings.Repeat("-", len("Changelog")+1))
+			p.Logger.Infof("%s\n", source.Changelog)
 		}
 
 		p.Sources[id] = source
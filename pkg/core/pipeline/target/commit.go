@@ -0,0 +1,87 @@
+package target
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	/*
+		commitMessageLeftDelim and commitMessageRightDelim delimit the commit message template actions.
+		They differ from the default golang template ones, as the updatecli manifest is itself
+		a golang template evaluated before the target execution.
+	*/
+	commitMessageLeftDelim  = "[["
+	commitMessageRightDelim = "]]"
+)
+
+// CommitMessageData contains the target execution information available to commit message templates
+type CommitMessageData struct {
+	// Name holds the target name
+	Name string
+	// Description holds the target execution description
+	Description string
+	// SourceID holds the id of the source used by the target
+	SourceID string
+	// SourceName holds the name of the source used by the target
+	SourceName string
+	// OldValue holds the information detected by the target before its execution
+	OldValue string
+	// NewValue holds the information updated by the target execution
+	NewValue string
+	// File holds the first file modified by the target execution
+	File string
+	// Files holds the list of files modified by the target execution
+	Files []string
+}
+
+// commitMessage returns the message used to commit the changes made by the target
+func (t *Target) commitMessage() (string, error) {
+	/*
+		not every target have a name as it wasn't mandatory in the past
+		so we use the description as a fallback
+	*/
+	message := t.Config.Name
+	if message == "" {
+		message = t.Result.Description
+	}
+
+	if t.Config.CommitMessage == "" {
+		return message, nil
+	}
+
+	tmpl, err := template.New("commitmessage").
+		Delims(commitMessageLeftDelim, commitMessageRightDelim).
+		Option("missingkey=error").
+		Parse(t.Config.CommitMessage)
+	if err != nil {
+		return "", fmt.Errorf("parsing commit message template: %w", err)
+	}
+
+	data := CommitMessageData{
+		Name:        t.Config.Name,
+		Description: t.Result.Description,
+		SourceID:    t.Config.SourceID,
+		SourceName:  t.SourceName,
+		OldValue:    t.Result.Information,
+		NewValue:    t.Result.NewInformation,
+		Files:       t.Result.Files,
+	}
+
+	if len(t.Result.Files) > 0 {
+		data.File = t.Result.Files[0]
+	}
+
+	b := bytes.Buffer{}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("executing commit message template: %w", err)
+	}
+
+	if strings.TrimSpace(b.String()) == "" {
+		return "", fmt.Errorf("commit message template %q generated an empty commit message", t.Config.CommitMessage)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,85 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCommitMessage(t *testing.T) {
+	testData := []struct {
+		name          string
+		config        Config
+		expected      string
+		expectedError bool
+	}{
+		{
+			name: "Default to the target name",
+			config: Config{
+				ResourceConfig: resource.ResourceConfig{Name: "Bump nginx version"},
+			},
+			expected: "Bump nginx version",
+		},
+		{
+			name:     "Fallback to the target description",
+			expected: "nginx version updated",
+		},
+		{
+			name: "Template with change context",
+			config: Config{
+				ResourceConfig: resource.ResourceConfig{Name: "Bump nginx version"},
+				SourceID:       "nginx",
+				CommitMessage:  "bump [[ .SourceName ]] from [[ .OldValue ]] to [[ .NewValue ]] in [[ .File ]]",
+			},
+			expected: "bump nginx from 1.19 to 1.21 in docker-compose.yaml",
+		},
+		{
+			name: "Template with every modified file",
+			config: Config{
+				CommitMessage: "[[ .Description ]]\n\n[[ range .Files ]]* [[ . ]]\n[[ end ]]",
+			},
+			expected: "nginx version updated\n\n* docker-compose.yaml\n* Dockerfile\n",
+		},
+		{
+			name: "Template referencing an unknown field",
+			config: Config{
+				CommitMessage: "bump [[ .Version ]]",
+			},
+			expectedError: true,
+		},
+		{
+			name: "Template generating an empty message",
+			config: Config{
+				CommitMessage: "[[ .SourceID ]]",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			target := Target{
+				Config:     tt.config,
+				SourceName: "nginx",
+				Result: result.Target{
+					Description:    "nginx version updated",
+					Information:    "1.19",
+					NewInformation: "1.21",
+					Files:          []string{"docker-compose.yaml", "Dockerfile"},
+				},
+			}
+
+			got, err := target.commitMessage()
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package target
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+)
+
+// PostHook defines a command run once the target changed, such as "go mod tidy" or "helm dependency update"
+type PostHook struct {
+	/*
+		command specifies the command to run.
+
+		remark:
+			The command isn't run by a shell, so shell features such as pipes or variables aren't supported.
+			Please use a script for those.
+	*/
+	Command string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		workdir specifies the directory where the command runs, relative to the scm working directory,
+		or to the current directory if the target doesn't use a scm.
+	*/
+	WorkDir string `yaml:",omitempty"`
+}
+
+// Validate checks if a post hook is valid
+func (h PostHook) Validate() error {
+	if len(strings.Fields(h.Command)) == 0 {
+		return errors.New("post hook command is required")
+	}
+
+	if filepath.IsAbs(h.WorkDir) {
+		return fmt.Errorf("post hook workdir %q must be relative", h.WorkDir)
+	}
+
+	return nil
+}
+
+// Run runs the post hook command from the directory rootDir
+func (h PostHook) Run(rootDir string) error {
+	var stdout, stderr bytes.Buffer
+
+	cmdFields := strings.Fields(h.Command)
+	command := exec.Command(cmdFields[0], cmdFields[1:]...) //nolint: gosec
+
+	command.Dir = filepath.Join(rootDir, h.WorkDir)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	logrus.Infof("Running post hook %q", h.Command)
+
+	err := command.Run()
+
+	if stdout.Len() > 0 {
+		logrus.Debugf("%s", stdout.String())
+	}
+
+	if err != nil {
+		return fmt.Errorf("post hook %q failed: %w\n%s", h.Command, err, stderr.String())
+	}
+
+	return nil
+}
+
+// runPostHooks runs the target post hooks, then adds the files they changed in the scm working directory
+// to the target result so they are committed with the target changes.
+func (t *Target) runPostHooks(s scm.ScmHandler) error {
+	if len(t.Config.PostHooks) == 0 {
+		return nil
+	}
+
+	rootDir := ""
+	if s != nil {
+		rootDir = s.GetDirectory()
+	}
+
+	for _, hook := range t.Config.PostHooks {
+		if err := hook.Run(rootDir); err != nil {
+			return err
+		}
+	}
+
+	if s == nil {
+		return nil
+	}
+
+	changedFiles, err := s.GetChangedFiles(rootDir)
+	if err != nil {
+		return err
+	}
+
+	t.Result.Files = mergeChangedFiles(t.Result.Files, changedFiles, rootDir)
+
+	return nil
+}
+
+// mergeChangedFiles appends to files the changed files, relative to rootDir, not already listed
+func mergeChangedFiles(files, changedFiles []string, rootDir string) []string {
+	known := map[string]bool{}
+	for _, file := range files {
+		if filepath.IsAbs(file) {
+			if relativeFile, err := filepath.Rel(rootDir, file); err == nil {
+				file = relativeFile
+			}
+		}
+		known[filepath.ToSlash(file)] = true
+	}
+
+	// The changed files come from the git status, in no particular order
+	changedFiles = append([]string{}, changedFiles...)
+	sort.Strings(changedFiles)
+
+	for _, file := range changedFiles {
+		if !known[filepath.ToSlash(file)] {
+			known[filepath.ToSlash(file)] = true
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
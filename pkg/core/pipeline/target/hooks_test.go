@@ -0,0 +1,52 @@
+package target
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestRunPostHooks(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(workingDir, "charts"), 0o755))
+
+	target := Target{
+		Config: Config{
+			PostHooks: []PostHook{
+				{Command: "touch Chart.lock", WorkDir: "charts"},
+			},
+		},
+		Result: result.Target{
+			Files: []string{filepath.Join(workingDir, "charts", "Chart.yaml")},
+		},
+	}
+
+	s := &scm.MockScm{
+		WorkingDir:   workingDir,
+		ChangedFiles: []string{"charts/Chart.yaml", "charts/Chart.lock"},
+	}
+
+	require.NoError(t, target.runPostHooks(s))
+
+	assert.FileExists(t, filepath.Join(workingDir, "charts", "Chart.lock"))
+	assert.Equal(t, []string{
+		filepath.Join(workingDir, "charts", "Chart.yaml"),
+		"charts/Chart.lock",
+	}, target.Result.Files)
+
+	t.Run("failing post hook", func(t *testing.T) {
+		target.Config.PostHooks = []PostHook{{Command: "false"}}
+		require.Error(t, target.runPostHooks(s))
+	})
+}
+
+func TestPostHookValidate(t *testing.T) {
+	assert.NoError(t, PostHook{Command: "go mod tidy"}.Validate())
+	assert.Error(t, PostHook{Command: " "}.Validate())
+	assert.Error(t, PostHook{Command: "go mod tidy", WorkDir: "/tmp"}.Validate())
+}
@@ -4,13 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"strings"
 
 	jschema "github.com/invopop/jsonschema"
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/jsonschema"
+	"github.com/updatecli/updatecli/pkg/core/log"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
@@ -37,6 +36,11 @@ type Target struct {
 	DryRun bool
 	// Scm stores scm information
 	Scm *scm.ScmHandler
+	// SourceName holds the name of the source referenced by sourceid, available to commit message templates
+	SourceName string
+	// Logger logs the entries of the target run, with the fields of its pipeline, stage, and plugin.
+	// Default to the standard logger
+	Logger *logrus.Entry
 }
 
 // Config defines target parameters
@@ -47,12 +51,60 @@ type Config struct {
 	ReportTitle string `yaml:",omitempty"`
 	// ReportBody contains the updatecli reports body for sources and conditions run
 	ReportBody string `yaml:",omitempty"`
+	/*
+		commitmessage defines the golang template used to generate the message of the commit
+		created by the target, instead of the target name. Template actions are delimited by "[[" and "]]"
+		as the manifest is already a golang template, and can reference:
+			* [[ .Name ]]: the target name
+			* [[ .Description ]]: the target execution description
+			* [[ .SourceID ]] and [[ .SourceName ]]: the id and the name of the source referenced by sourceid
+			* [[ .OldValue ]] and [[ .NewValue ]]: the information before and after the target execution
+			* [[ .File ]] and [[ .Files ]]: the first file and the list of files modified by the target
+
+		example:
+			"bump [[ .SourceName ]] from [[ .OldValue ]] to [[ .NewValue ]]"
+
+		default:
+			the pipeline commitmessage if defined, otherwise the target name
+
+		remark:
+			the generated message is then formatted according to the scm "commitmessage" settings,
+			such as the conventional commit type and scope, unless its "title" is set.
+	*/
+	CommitMessage string `yaml:",omitempty"`
 	// ! Deprecated - please use all lowercase `sourceid`
 	DeprecatedSourceID string `yaml:"sourceID,omitempty" jsonschema:"-"`
 	// disablesourceinput disables the mechanism to retrieve a default value from a source. For example, if true, source information like changelog will not be accessible for a github/pullrequest action.
 	DisableSourceInput bool `yaml:",omitempty"`
 	// sourceid specifies where retrieving the default value
 	SourceID string `yaml:",omitempty"`
+	/*
+		sourcevalue defines the target input value from the output of several sources,
+		using the "source" template function.
+
+		example:
+			'{{ source "tag" }}-{{ source "variant" }}'
+
+		remark:
+			* sourcevalue is mutually exclusive with sourceid and disablesourceinput
+			* the "source" template function can already be used in any other target field
+	*/
+	SourceValue string `yaml:",omitempty"`
+	/*
+		posthooks defines the commands run, in order, once the target changed and before its changes are committed,
+		such as "go mod tidy" or "helm dependency update" to regenerate lock files.
+
+		example:
+			posthooks:
+			  - command: "go mod tidy"
+			  - command: "helm dependency update"
+			    workdir: "charts/updatecli"
+
+		remark:
+			* post hooks don't run in dry run mode, or if the target didn't change
+			* the files changed by the post hooks in the scm working directory are committed with the target changes
+	*/
+	PostHooks []PostHook `yaml:",omitempty"`
 }
 
 // Check verifies if mandatory Targets parameters are provided and return false if not.
@@ -75,15 +127,15 @@ func (t *Target) Check() (bool, error) {
 // Run applies a specific target configuration
 func (t *Target) Run(source string, o *Options) (err error) {
 	var consoleOutput bytes.Buffer
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	logrus.SetOutput(io.MultiWriter(os.Stderr, &consoleOutput))
+	// Copy the log entries of the current pipeline, still written to stderr, to the console output
+	logger := log.Scope(t.Logger)
+	stopCapture := log.Capture(logger, &consoleOutput)
 	/*
 		The last defer will be executed first,
 		so in this case we want to first save the console output
-		before setting back the logrus output to stdout.
+		before stopping its capture.
 	*/
-	// By default logrus logs to stderr, so I guess we want to keep this behavior...
-	defer logrus.SetOutput(os.Stderr)
+	defer stopCapture()
 	defer t.Result.SetConsoleOutput(&consoleOutput)
 
 	failTargetRun := func() {
@@ -100,7 +152,7 @@ func (t *Target) Run(source string, o *Options) (err error) {
 	}
 
 	if o.DryRun {
-		logrus.Infof("\n**Dry Run enabled**\n\n")
+		logger.Infof("\n**Dry Run enabled**\n\n")
 	}
 
 	target, err := resource.New(t.Config.ResourceConfig)
@@ -122,8 +174,15 @@ func (t *Target) Run(source string, o *Options) (err error) {
 			return err
 		}
 
+		if t.Result.Changed && !o.DryRun {
+			if err = t.runPostHooks(nil); err != nil {
+				failTargetRun()
+				return err
+			}
+		}
+
 		// Could be improve to show attention description in yellow, success in green, failure in red
-		logrus.Infof("%s - %s", t.Result.Result, t.Result.Description)
+		logger.Infof("%s - %s", t.Result.Result, t.Result.Description)
 
 		return nil
 	}
@@ -151,7 +210,7 @@ func (t *Target) Run(source string, o *Options) (err error) {
 	}
 
 	// Could be improve to show attention description in yellow, success in green, failure in red
-	logrus.Infof("%s - %s", t.Result.Result, t.Result.Description)
+	logger.Infof("%s - %s", t.Result.Result, t.Result.Description)
 
 	isRemoteBranchUpToDate, err := s.IsRemoteBranchUpToDate()
 	if err != nil {
@@ -164,11 +223,16 @@ func (t *Target) Run(source string, o *Options) (err error) {
 			return nil
 		}
 
-		logrus.Infof("\n\u26A0 While nothing change in the current pipeline run, according to the git history, some commits will be pushed\n")
+		logger.Infof("\n\u26A0 While nothing change in the current pipeline run, according to the git history, some commits will be pushed\n")
 	}
 
 	if !o.DryRun {
 		if t.Result.Changed {
+			if err = t.runPostHooks(s); err != nil {
+				failTargetRun()
+				return err
+			}
+
 			if t.Result.Description == "" {
 				failTargetRun()
 				return fmt.Errorf("target has no change message")
@@ -185,14 +249,12 @@ func (t *Target) Run(source string, o *Options) (err error) {
 					return err
 				}
 
-				/*
-					not every target have a name as it wasn't mandatory in the past
-					so we use the description as a fallback
-				*/
-				commitMessage := t.Config.Name
-				if commitMessage == "" {
-					commitMessage = t.Result.Description
+				commitMessage, err := t.commitMessage()
+				if err != nil {
+					failTargetRun()
+					return err
 				}
+
 				if err = s.Commit(commitMessage); err != nil {
 					failTargetRun()
 					return err
@@ -289,6 +351,18 @@ func (c *Config) Validate() error {
 		gotError = true
 	}
 
+	if len(c.SourceValue) > 0 && (len(c.SourceID) > 0 || c.DisableSourceInput) {
+		logrus.Errorln("sourcevalue is incompatible with sourceid and disablesourceinput")
+		gotError = true
+	}
+
+	for _, hook := range c.PostHooks {
+		if err := hook.Validate(); err != nil {
+			logrus.Errorln(err)
+			gotError = true
+		}
+	}
+
 	if len(missingParameters) > 0 {
 		logrus.Errorf("missing value for parameter(s) [%q]", strings.Join(missingParameters, ","))
 		gotError = true
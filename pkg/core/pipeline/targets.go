@@ -5,7 +5,8 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
@@ -16,8 +17,8 @@ var (
 
 // RunTargets iterates on every target to update each of them.
 func (p *Pipeline) RunTargets() error {
-	logrus.Infof("\n\n%s\n", strings.ToTitle("Targets"))
-	logrus.Infof("%s\n", strings.Repeat("=", len("Targets")+1))
+	p.Logger.Infof("\n\n%s\n", strings.ToTitle("Targets"))
+	p.Logger.Infof("%s\n", strings.Repeat("=", len("Targets")+1))
 
 	// Sort targets keys by building a dependency graph
 	sortedTargetsKeys, err := SortedTargetsKeys(&p.Targets)
@@ -38,17 +39,26 @@ func (p *Pipeline) RunTargets() error {
 			return err
 		}
 
-		logrus.Infof("\n%s\n", id)
-		logrus.Infof("%s\n", strings.Repeat("-", len(id)))
+		p.Logger.Infof("\n%s\n", id)
+		p.Logger.Infof("%s\n", strings.Repeat("-", len(id)))
 
 		target := p.Targets[id]
 		target.Config = p.Config.Spec.Targets[id]
 
+		// The pipeline commit message template is propagated into each target if not defined at that level
+		if target.Config.CommitMessage == "" {
+			target.Config.CommitMessage = p.Config.Spec.CommitMessage
+		}
+
+		if source, ok := p.Sources[target.Config.SourceID]; ok {
+			target.SourceName = source.Config.Name
+		}
+
 		shouldSkipTarget := false
 
 		for _, parentTarget := range target.Config.DependsOn {
 			if p.Targets[parentTarget].Result.Result == result.FAILURE {
-				logrus.Warningf("Parent target[%q] did not succeed. Skipping execution of the target[%q]", parentTarget, id)
+				p.Logger.Warningf("Parent target[%q] did not succeed. Skipping execution of the target[%q]", parentTarget, id)
 				shouldSkipTarget = true
 				target.Result.Result = result.SKIPPED
 			}
@@ -61,7 +71,12 @@ func (p *Pipeline) RunTargets() error {
 			continue
 		}
 
-		err = target.Run(p.Sources[target.Config.SourceID].Output, &p.Options.Target)
+		logger, done := p.instrumentResource("target", id, target.Config.Kind)
+		target.Logger = logger
+		input, err := p.targetInput(target.Config)
+		if err == nil {
+			err = target.Run(input, &p.Options.Target)
+		}
 
 		if err != nil {
 			p.Report.Result = result.FAILURE
@@ -69,6 +84,7 @@ func (p *Pipeline) RunTargets() error {
 
 			errs = append(errs, fmt.Errorf("something went wrong in target %q : %q", id, err))
 		}
+		done(target.Result.Result)
 
 		p.Targets[id] = target
 		p.Report.Targets[id] = &target.Result
@@ -79,11 +95,11 @@ func (p *Pipeline) RunTargets() error {
 	}
 
 	if len(errs) > 0 {
-		logrus.Infof("\n")
+		p.Logger.Infof("\n")
 		for _, e := range errs {
-			logrus.Errorln(e)
+			p.Logger.Errorln(e)
 		}
-		logrus.Infof("\n")
+		p.Logger.Infof("\n")
 
 		p.Report.Result = result.FAILURE
 		return ErrRunTargets
@@ -95,3 +111,17 @@ func (p *Pipeline) RunTargets() error {
 
 	return nil
 }
+
+// targetInput returns the target input value, from the source referenced by sourceid or composed by sourcevalue
+func (p *Pipeline) targetInput(c target.Config) (string, error) {
+	if len(c.SourceValue) == 0 {
+		return p.Sources[c.SourceID].Output, nil
+	}
+
+	// A source referenced by sourcevalue without output, such as a skipped one, is still templated
+	if config.IsTemplatedString(c.SourceValue) {
+		return "", fmt.Errorf("sourcevalue %q references a source without output", c.SourceValue)
+	}
+
+	return c.SourceValue, nil
+}
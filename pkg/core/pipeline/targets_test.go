@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/source"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
+)
+
+func TestTargetInput(t *testing.T) {
+	p := Pipeline{
+		Sources: map[string]source.Source{
+			"tag":     {Output: "1.21.3"},
+			"variant": {Output: "alpine"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		config        target.Config
+		expectedInput string
+		wantErr       bool
+	}{
+		{
+			name:          "Input from sourceid",
+			config:        target.Config{SourceID: "tag"},
+			expectedInput: "1.21.3",
+		},
+		{
+			name:          "Input composed from several sources",
+			config:        target.Config{SourceValue: "1.21.3-alpine"},
+			expectedInput: "1.21.3-alpine",
+		},
+		{
+			name:    "Input referencing a source without output",
+			config:  target.Config{SourceValue: `1.21.3-{{ source "skipped" }}`},
+			wantErr: true,
+		},
+		{
+			name:   "Source input disabled",
+			config: target.Config{DisableSourceInput: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.targetInput(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedInput, got)
+		})
+	}
+}
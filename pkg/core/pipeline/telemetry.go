@@ -0,0 +1,31 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/log"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
+)
+
+// instrumentResource starts recording the duration and the trace span of the resource id, run by stage such as "source",
+// then returns the logger of the resource run, and the function ending the record with the resource result.
+func (p *Pipeline) instrumentResource(stage, id, kind string) (*logrus.Entry, func(resourceResult string)) {
+	start := time.Now()
+	logger := log.Scope(p.Logger).WithFields(logrus.Fields{
+		log.FieldStage:    stage,
+		log.FieldResource: id,
+		log.FieldPlugin:   kind,
+	})
+	span := telemetry.StartSpan(p.span, stage+" "+id, map[string]string{
+		"updatecli.pipeline": p.Name,
+		"updatecli.stage":    stage,
+		"updatecli.id":       id,
+		"updatecli.kind":     kind,
+	})
+
+	return logger, func(resourceResult string) {
+		telemetry.ObserveResource(stage, kind, resourceResult, time.Since(start))
+		span.End(resourceResult)
+	}
+}
@@ -14,6 +14,8 @@ type Action struct {
 	PipelineTitle string         `xml:"h3,omitempty"`
 	Description   string         `xml:"p,omitempty"`
 	Targets       []ActionTarget `xml:"details,omitempty"`
+	// Link defines the url of the pullrequest created or updated by the action handler
+	Link string `xml:"-"`
 }
 
 type ActionTargetChangelog struct {
@@ -6,6 +6,12 @@ type ActionTarget struct {
 	Title       string                  `xml:"summary,omitempty"`
 	Description string                  `xml:"p,omitempty"`
 	Changelogs  []ActionTargetChangelog `xml:"details,omitempty"`
+	// OldValue holds the value found by the target before the pipeline run
+	OldValue string `xml:"-"`
+	// NewValue holds the value set by the target
+	NewValue string `xml:"-"`
+	// Files holds the files modified by the target
+	Files []string `xml:"-"`
 }
 
 func (a *ActionTarget) Merge(sourceActionTarget *ActionTarget) {
@@ -35,4 +41,16 @@ func (a *ActionTarget) Merge(sourceActionTarget *ActionTarget) {
 	}
 
 	a.Changelogs = c
+
+	// Values and files aren't part of the report published by actions,
+	// so they are only known for the targets of the current pipeline run
+	if a.OldValue == "" {
+		a.OldValue = sourceActionTarget.OldValue
+	}
+	if a.NewValue == "" {
+		a.NewValue = sourceActionTarget.NewValue
+	}
+	if len(a.Files) == 0 {
+		a.Files = sourceActionTarget.Files
+	}
 }
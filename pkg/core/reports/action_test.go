@@ -723,3 +723,44 @@ This is not a html formatted report
 		})
 	}
 }
+
+func TestExtractFromString(t *testing.T) {
+	report := `<Actions>
+    <action id="1234">
+        <h3>Pipeline</h3>
+    </action>
+</Actions>`
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Report only",
+			input:    report,
+			expected: report,
+		},
+		{
+			name:     "Report surrounded by a Pull Request body",
+			input:    "# Update images\n\n<p>Description</p>\n\n" + report + "\n\n---\n\n<table><tr><td>Footer</td></tr></table>",
+			expected: report,
+		},
+		{
+			name:     "Lowercase report",
+			input:    "Description\n<actions><action id=\"1234\"></action></actions>\n",
+			expected: `<actions><action id="1234"></action></actions>`,
+		},
+		{
+			name:     "No report",
+			input:    "<p>Description</p>",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractFromString(tt.input))
+		})
+	}
+}
@@ -3,11 +3,16 @@ package reports
 import (
 	"encoding/xml"
 	"fmt"
+	"regexp"
 	"sort"
 
 	"github.com/sirupsen/logrus"
 )
 
+// actionsRegex matches the report embedded in a text such as a Pull Request body.
+// Reports published by older versions may use a lowercase root element.
+var actionsRegex = regexp.MustCompile(`(?is)<actions>.*</actions>`)
+
 type Actions struct {
 	Actions []Action `xml:"action"`
 }
@@ -76,6 +81,12 @@ func MergeFromString(old, new string) string {
 	return newReport.String()
 }
 
+// ExtractFromString returns the report embedded in input, such as a Pull Request body
+// generated from a template, or an empty string if input doesn't contain one
+func ExtractFromString(input string) string {
+	return actionsRegex.FindString(input)
+}
+
 func (a *Actions) sort() {
 	actions := *a
 	sort.Slice(
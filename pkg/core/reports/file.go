@@ -0,0 +1,201 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// RunReport defines the machine-readable report of an Updatecli run, written by WriteFile
+type RunReport struct {
+	// Summary counts the pipelines per result
+	Summary RunSummary `json:"summary" yaml:"summary"`
+	// Pipelines contains the report of every pipeline, in their run order
+	Pipelines []PipelineReport `json:"pipelines" yaml:"pipelines"`
+}
+
+// RunSummary counts the pipelines of a run per result
+type RunSummary struct {
+	Changed   int `json:"changed" yaml:"changed"`
+	Failed    int `json:"failed" yaml:"failed"`
+	Skipped   int `json:"skipped" yaml:"skipped"`
+	Succeeded int `json:"succeeded" yaml:"succeeded"`
+	Total     int `json:"total" yaml:"total"`
+}
+
+// NewRunSummary returns the summary of the pipelines reports
+func NewRunSummary(pipelines []PipelineReport) RunSummary {
+	s := RunSummary{Total: len(pipelines)}
+
+	for _, p := range pipelines {
+		switch p.Result {
+		case resultName(result.ATTENTION):
+			s.Changed++
+		case resultName(result.FAILURE):
+			s.Failed++
+		case resultName(result.SKIPPED):
+			s.Skipped++
+		case resultName(result.SUCCESS):
+			s.Succeeded++
+		}
+	}
+
+	return s
+}
+
+// String returns the run summary s as a sentence
+func (s RunSummary) String() string {
+	return fmt.Sprintf("%d changed, %d failed, %d skipped, %d succeeded, %d total",
+		s.Changed, s.Failed, s.Skipped, s.Succeeded, s.Total)
+}
+
+// PipelineReport defines the machine-readable report of a pipeline.
+// Results are one of "success", "failure", "attention" when changes are detected, or "skipped".
+type PipelineReport struct {
+	Name       string                     `json:"name" yaml:"name"`
+	ID         string                     `json:"id,omitempty" yaml:"id,omitempty"`
+	Result     string                     `json:"result" yaml:"result"`
+	Error      string                     `json:"error,omitempty" yaml:"error,omitempty"`
+	Sources    map[string]SourceReport    `json:"sources,omitempty" yaml:"sources,omitempty"`
+	Conditions map[string]ConditionReport `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Targets    map[string]TargetReport    `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Actions    map[string]ActionReport    `json:"actions,omitempty" yaml:"actions,omitempty"`
+}
+
+// SourceReport defines the machine-readable report of a source
+type SourceReport struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Result      string `json:"result" yaml:"result"`
+	Value       string `json:"value" yaml:"value"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ConditionReport defines the machine-readable report of a condition
+type ConditionReport struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Result      string `json:"result" yaml:"result"`
+	Pass        bool   `json:"pass" yaml:"pass"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// TargetReport defines the machine-readable report of a target
+type TargetReport struct {
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Result      string   `json:"result" yaml:"result"`
+	Changed     bool     `json:"changed" yaml:"changed"`
+	DryRun      bool     `json:"dryRun" yaml:"dryRun"`
+	OldValue    string   `json:"oldValue,omitempty" yaml:"oldValue,omitempty"`
+	NewValue    string   `json:"newValue,omitempty" yaml:"newValue,omitempty"`
+	Files       []string `json:"files,omitempty" yaml:"files,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ActionReport defines the machine-readable report of an action such as a pullrequest
+type ActionReport struct {
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+	Kind  string `json:"kind" yaml:"kind"`
+	// Link defines the url of the pullrequest created or updated by the action
+	Link string `json:"link,omitempty" yaml:"link,omitempty"`
+}
+
+// resultNames defines the machine-readable name of each result
+var resultNames = map[string]string{
+	result.SUCCESS:   "success",
+	result.FAILURE:   "failure",
+	result.ATTENTION: "attention",
+	result.SKIPPED:   "skipped",
+}
+
+// resultName returns the machine-readable name of the result r, such as "success" for result.SUCCESS
+func resultName(r string) string {
+	if name, ok := resultNames[r]; ok {
+		return name
+	}
+	return r
+}
+
+// NewPipelineReport returns the machine-readable report of the pipeline report r
+func NewPipelineReport(r Report) PipelineReport {
+	p := PipelineReport{
+		Name:   r.Name,
+		ID:     r.ID,
+		Result: resultName(r.Result),
+		Error:  r.Err,
+	}
+
+	if len(r.Sources) > 0 {
+		p.Sources = make(map[string]SourceReport, len(r.Sources))
+		for id, s := range r.Sources {
+			p.Sources[id] = SourceReport{
+				Name:        s.Name,
+				Result:      resultName(s.Result),
+				Value:       s.Information,
+				Description: s.Description,
+			}
+		}
+	}
+
+	if len(r.Conditions) > 0 {
+		p.Conditions = make(map[string]ConditionReport, len(r.Conditions))
+		for id, c := range r.Conditions {
+			p.Conditions[id] = ConditionReport{
+				Name:        c.Name,
+				Result:      resultName(c.Result),
+				Pass:        c.Pass,
+				Description: c.Description,
+			}
+		}
+	}
+
+	if len(r.Targets) > 0 {
+		p.Targets = make(map[string]TargetReport, len(r.Targets))
+		for id, t := range r.Targets {
+			p.Targets[id] = TargetReport{
+				Name:        t.Name,
+				Result:      resultName(t.Result),
+				Changed:     t.Changed,
+				DryRun:      t.DryRun,
+				OldValue:    t.Information,
+				NewValue:    t.NewInformation,
+				Files:       t.Files,
+				Description: t.Description,
+			}
+		}
+	}
+
+	return p
+}
+
+/*
+WriteFile writes the run report r to filename.
+The report is formatted in YAML if filename ends with ".yaml" or ".yml", JSON otherwise.
+*/
+func WriteFile(filename string, r RunReport) error {
+	var data []byte
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(r)
+	default:
+		data, err = json.MarshalIndent(r, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(filename); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filename, data, 0o600)
+}
@@ -0,0 +1,104 @@
+package reports
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewPipelineReport(t *testing.T) {
+	r := Report{
+		Name:   "Bump version",
+		ID:     "1234",
+		Result: result.ATTENTION,
+		Sources: map[string]*result.Source{
+			"latest": {Name: "Get latest version", Result: result.SUCCESS, Information: "1.1.0"},
+		},
+		Conditions: map[string]*result.Condition{
+			"exists": {Name: "Check image", Result: result.SUCCESS, Pass: true},
+		},
+		Targets: map[string]*result.Target{
+			"file": {
+				Name:           "Update file",
+				Result:         result.ATTENTION,
+				Changed:        true,
+				Information:    "1.0.0",
+				NewInformation: "1.1.0",
+				Files:          []string{"version.txt"},
+			},
+		},
+	}
+
+	expected := PipelineReport{
+		Name:   "Bump version",
+		ID:     "1234",
+		Result: "attention",
+		Sources: map[string]SourceReport{
+			"latest": {Name: "Get latest version", Result: "success", Value: "1.1.0"},
+		},
+		Conditions: map[string]ConditionReport{
+			"exists": {Name: "Check image", Result: "success", Pass: true},
+		},
+		Targets: map[string]TargetReport{
+			"file": {
+				Name:     "Update file",
+				Result:   "attention",
+				Changed:  true,
+				OldValue: "1.0.0",
+				NewValue: "1.1.0",
+				Files:    []string{"version.txt"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, NewPipelineReport(r))
+}
+
+func TestWriteFile(t *testing.T) {
+	r := RunReport{
+		Summary: RunSummary{Changed: 1, Failed: 1, Total: 2},
+		Pipelines: []PipelineReport{
+			{
+				Name:   "Bump version",
+				Result: "attention",
+				Actions: map[string]ActionReport{
+					"default": {Title: "Bump version", Kind: "github/pullrequest", Link: "https://github.com/updatecli/updatecli/pull/1"},
+				},
+			},
+			{
+				Name:   "Bump chart",
+				Result: "failure",
+				Error:  "something went wrong",
+			},
+		},
+	}
+
+	dir := t.TempDir()
+
+	testData := []struct {
+		filename  string
+		unmarshal func([]byte, interface{}) error
+	}{
+		{filename: filepath.Join(dir, "report.json"), unmarshal: json.Unmarshal},
+		{filename: filepath.Join(dir, "reports", "report.yaml"), unmarshal: yaml.Unmarshal},
+	}
+
+	for _, tt := range testData {
+		t.Run(filepath.Base(tt.filename), func(t *testing.T) {
+			require.NoError(t, WriteFile(tt.filename, r))
+
+			data, err := os.ReadFile(tt.filename)
+			require.NoError(t, err)
+
+			var got RunReport
+			require.NoError(t, tt.unmarshal(data, &got))
+			assert.Equal(t, r, got)
+		})
+	}
+}
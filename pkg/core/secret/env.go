@@ -0,0 +1,24 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// envProvider resolves the secrets stored in environment variables, such as "env://GITHUB_TOKEN"
+type envProvider struct{}
+
+// Resolve returns the value of the environment variable location
+func (envProvider) Resolve(location, key string) (string, error) {
+	if key != "" {
+		return "", errors.New("environment variables don't support keys")
+	}
+
+	value, ok := os.LookupEnv(location)
+	if !ok || value == "" {
+		return "", fmt.Errorf("no value found for environment variable %s", location)
+	}
+
+	return value, nil
+}
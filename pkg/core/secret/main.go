@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/updatecli/updatecli/pkg/core/log"
+)
+
+/*
+Secrets are referenced from any manifest string value, instead of being written in plain text,
+using a reference "<provider>://<location>[#<key>]" such as:
+
+	env://GITHUB_TOKEN
+	sops://secrets.yaml#github.token
+	vault://secret/data/updatecli#github_token
+
+References are resolved when the manifest is loaded, then the resolved values are redacted from the logs.
+*/
+
+// Provider resolves the secrets of a reference scheme, such as "vault"
+type Provider interface {
+	// Resolve returns the secret value located at location, then identified by key, if any
+	Resolve(location, key string) (string, error)
+}
+
+// providers holds the secret providers, indexed by reference scheme
+var providers = map[string]Provider{
+	"env":   envProvider{},
+	"sops":  sopsProvider{},
+	"vault": vaultProvider{},
+}
+
+// IsReference returns true if value references a secret, such as "vault://secret/data/updatecli#token"
+func IsReference(value string) bool {
+	scheme, _, found := strings.Cut(value, "://")
+	if !found {
+		return false
+	}
+
+	_, ok := providers[scheme]
+	return ok
+}
+
+// Resolve returns the value of the secret referenced by reference, then redacts it from the logs
+func Resolve(reference string) (string, error) {
+	scheme, location, found := strings.Cut(reference, "://")
+	provider, ok := providers[scheme]
+	if !found || !ok {
+		return "", fmt.Errorf("secret reference %q: unsupported provider", reference)
+	}
+
+	location, key, _ := strings.Cut(location, "#")
+	if location == "" {
+		return "", fmt.Errorf("secret reference %q: missing location", reference)
+	}
+
+	value, err := provider.Resolve(location, key)
+	if err != nil {
+		return "", fmt.Errorf("secret reference %q: %w", reference, err)
+	}
+
+	log.AddSecret(value)
+
+	return value, nil
+}
+
+// formatValue returns the secret value of key, which must be a scalar such as a string or a number
+func formatValue(value interface{}, key string) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case map[string]interface{}, []interface{}, nil:
+		return "", fmt.Errorf("key %q isn't a value", key)
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
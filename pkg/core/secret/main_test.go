@@ -0,0 +1,176 @@
+package secret
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReference(t *testing.T) {
+	assert.True(t, IsReference("env://GITHUB_TOKEN"))
+	assert.True(t, IsReference("sops://secrets.yaml#github.token"))
+	assert.True(t, IsReference("vault://secret/data/updatecli#token"))
+	assert.False(t, IsReference("https://github.com/updatecli/updatecli"))
+	assert.False(t, IsReference("env:GITHUB_TOKEN"))
+}
+
+func TestResolveEnv(t *testing.T) {
+	t.Setenv("UPDATECLI_TEST_SECRET", "s3cr3t")
+
+	value, err := Resolve("env://UPDATECLI_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	_, err = Resolve("env://UPDATECLI_TEST_UNDEFINED_SECRET")
+	assert.EqualError(t, err, `secret reference "env://UPDATECLI_TEST_UNDEFINED_SECRET": no value found for environment variable UPDATECLI_TEST_UNDEFINED_SECRET`)
+
+	_, err = Resolve("env://")
+	assert.Error(t, err)
+}
+
+func TestResolveVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/updatecli":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"kv2-s3cr3t","port":8080},"metadata":{"version":1}}}`))
+		case "/v1/kv/updatecli":
+			_, _ = w.Write([]byte(`{"data":{"token":"kv1-s3cr3t"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(vaultAddrEnv, server.URL)
+	t.Setenv(vaultTokenEnv, "root")
+
+	testData := []struct {
+		reference     string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			reference:     "vault://secret/data/updatecli#token",
+			expectedValue: "kv2-s3cr3t",
+		},
+		{
+			reference:     "vault://secret/data/updatecli#port",
+			expectedValue: "8080",
+		},
+		{
+			reference:     "vault://kv/updatecli#token",
+			expectedValue: "kv1-s3cr3t",
+		},
+		{
+			reference:     "vault://kv/updatecli#username",
+			expectedError: `secret reference "vault://kv/updatecli#username": key "username" not found`,
+		},
+		{
+			reference:     "vault://kv/missing#token",
+			expectedError: `secret reference "vault://kv/missing#token": reading Vault secret: got 404 Not Found`,
+		},
+		{
+			reference:     "vault://kv/updatecli",
+			expectedError: `secret reference "vault://kv/updatecli": missing secret key, such as "vault://secret/data/updatecli#token"`,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.reference, func(t *testing.T) {
+			value, err := Resolve(tt.reference)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestResolveVaultTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/kv/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"data":{"token":"kv1-s3cr3t"}}`))
+	}))
+	defer server.Close()
+
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caBundlePath, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0600))
+
+	t.Setenv(vaultAddrEnv, server.URL)
+	t.Setenv(vaultTokenEnv, "root")
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := Resolve("vault://kv/updatecli#token")
+		assert.ErrorContains(t, err, "certificate")
+	})
+
+	t.Run("certificate authorities bundle", func(t *testing.T) {
+		t.Setenv(vaultCACertEnv, caBundlePath)
+
+		value, err := Resolve("vault://kv/updatecli#token")
+		require.NoError(t, err)
+		assert.Equal(t, "kv1-s3cr3t", value)
+	})
+
+	t.Run("certificate verification disabled", func(t *testing.T) {
+		t.Setenv(vaultSkipVerifyEnv, "true")
+
+		value, err := Resolve("vault://kv/updatecli#token")
+		require.NoError(t, err)
+		assert.Equal(t, "kv1-s3cr3t", value)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Setenv(vaultCACertEnv, caBundlePath)
+
+		defaultTimeout := vaultTimeout
+		t.Cleanup(func() { vaultTimeout = defaultTimeout })
+		vaultTimeout = 50 * time.Millisecond
+
+		_, err := Resolve("vault://kv/slow#token")
+		assert.ErrorContains(t, err, "Client.Timeout exceeded")
+	})
+}
+
+func TestLookupKey(t *testing.T) {
+	values := map[string]interface{}{
+		"github": map[string]interface{}{
+			"token": "s3cr3t",
+		},
+		"port": 22,
+	}
+
+	value, err := lookupKey(values, "github.token")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	value, err = lookupKey(values, "port")
+	require.NoError(t, err)
+	assert.Equal(t, "22", value)
+
+	_, err = lookupKey(values, "github")
+	assert.EqualError(t, err, `key "github" isn't a value`)
+
+	_, err = lookupKey(values, "github.token.value")
+	assert.EqualError(t, err, `key "github.token.value" not found`)
+}
@@ -0,0 +1,64 @@
+package secret
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go.mozilla.org/sops/v3/decrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// sopsProvider resolves the secrets stored in sops encrypted files, such as "sops://secrets.yaml#github.token"
+type sopsProvider struct{}
+
+// Resolve decrypts the sops file location, then returns the value of key, a dot separated path of yaml or json keys.
+// The whole decrypted file is returned if key is empty.
+func (sopsProvider) Resolve(location, key string) (string, error) {
+	format := "yaml"
+	switch filepath.Ext(location) {
+	case ".json":
+		format = "json"
+	case ".env":
+		format = "dotenv"
+	case ".ini":
+		format = "ini"
+	}
+
+	content, err := decrypt.File(location, format)
+	if err != nil {
+		return "", fmt.Errorf("decrypting sops file: %w", err)
+	}
+
+	if key == "" {
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	if format != "yaml" && format != "json" {
+		return "", fmt.Errorf("keys are only supported by yaml and json sops files")
+	}
+
+	var values interface{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return "", fmt.Errorf("parsing sops file: %w", err)
+	}
+
+	return lookupKey(values, key)
+}
+
+// lookupKey returns the value of the dot separated path key in values
+func lookupKey(values interface{}, key string) (string, error) {
+	for _, k := range strings.Split(key, ".") {
+		m, ok := values.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("key %q not found", key)
+		}
+
+		values, ok = m[k]
+		if !ok {
+			return "", fmt.Errorf("key %q not found", key)
+		}
+	}
+
+	return formatValue(values, key)
+}
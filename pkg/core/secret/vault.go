@@ -0,0 +1,134 @@
+package secret
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+)
+
+const (
+	// vaultAddrEnv defines the environment variable containing the Vault address, like the Vault cli
+	vaultAddrEnv = "VAULT_ADDR"
+	// vaultTokenEnv defines the environment variable containing the Vault token, like the Vault cli
+	vaultTokenEnv = "VAULT_TOKEN"
+	// vaultNamespaceEnv defines the environment variable containing the Vault Enterprise namespace, like the Vault cli
+	vaultNamespaceEnv = "VAULT_NAMESPACE"
+	// vaultCACertEnv defines the environment variable containing the path of the Vault certificate authorities bundle, like the Vault cli
+	vaultCACertEnv = "VAULT_CACERT"
+	// vaultSkipVerifyEnv defines the environment variable disabling the Vault TLS certificate verification, like the Vault cli
+	vaultSkipVerifyEnv = "VAULT_SKIP_VERIFY"
+)
+
+// vaultTimeout defines how long reading a Vault secret can take
+var vaultTimeout = 30 * time.Second
+
+// vaultProvider resolves the secrets stored in HashiCorp Vault, such as "vault://secret/data/updatecli#github_token",
+// using the address and token of the VAULT_ADDR and VAULT_TOKEN environment variables
+type vaultProvider struct{}
+
+// Resolve reads the Vault secret at path location, then returns the value of key.
+// Both the kv version 1 and 2 secrets engines are supported, the latter path containing "data/".
+func (vaultProvider) Resolve(location, key string) (string, error) {
+	if key == "" {
+		return "", errors.New("missing secret key, such as \"vault://secret/data/updatecli#token\"")
+	}
+
+	address := os.Getenv(vaultAddrEnv)
+	if address == "" {
+		return "", fmt.Errorf("no Vault address defined by the %s environment variable", vaultAddrEnv)
+	}
+
+	token := os.Getenv(vaultTokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("no Vault token defined by the %s environment variable", vaultTokenEnv)
+	}
+
+	URL := strings.TrimSuffix(address, "/") + "/v1/" + strings.TrimPrefix(location, "/")
+
+	req, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv(vaultNamespaceEnv); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client, err := newVaultClient()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reading Vault secret: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading Vault secret: got %s", res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("parsing Vault secret: %w", err)
+	}
+
+	data := secret.Data
+	// The kv version 2 secrets engine nests the secret data along with its metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found", key)
+	}
+
+	return formatValue(value, key)
+}
+
+// newVaultClient returns the http client used to read Vault secrets, applying the certificate authorities bundle
+// and the TLS verification settings of the VAULT_CACERT and VAULT_SKIP_VERIFY environment variables,
+// the proxy environment variables and the global retry policy.
+func newVaultClient() (*http.Client, error) {
+	spec := httpclient.TransportSpec{
+		CAFile: os.Getenv(vaultCACertEnv),
+	}
+
+	if skipVerify := os.Getenv(vaultSkipVerifyEnv); skipVerify != "" {
+		insecure, err := strconv.ParseBool(skipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the %s environment variable: %w", vaultSkipVerifyEnv, err)
+		}
+		spec.InsecureSkipVerify = insecure
+	}
+
+	client, err := httpclient.NewClient(spec, httpclient.RetryPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
+	// NewClient may return http.DefaultClient, which must not be modified
+	vaultClient := *client
+	vaultClient.Timeout = vaultTimeout
+
+	return &vaultClient, nil
+}
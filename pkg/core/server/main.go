@@ -0,0 +1,353 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/telemetry"
+)
+
+const (
+	// DefaultListen defines the default address where the server listens
+	DefaultListen = ":8080"
+
+	// TriggerSchedule is the trigger of the runs started by the schedule
+	TriggerSchedule = "schedule"
+	// TriggerWebhook is the trigger of the runs started by a webhook
+	TriggerWebhook = "webhook"
+	// TriggerStartup is the trigger of the run started when the server starts
+	TriggerStartup = "startup"
+
+	// maxWebhookBodySize limits the webhook payloads read to verify their signature
+	maxWebhookBodySize = 10 << 20
+)
+
+// RunFunc runs the manifests, trigger describes why they are run, such as "schedule" or "webhook"
+type RunFunc func(trigger string) error
+
+// Options defines the server parameters
+type Options struct {
+	// Listen defines the address where the server listens, such as ":8080"
+	Listen string
+	// Schedule defines, using the cron syntax, when the manifests are run, such as "0 * * * *".
+	// Default to empty which only runs the manifests on webhooks.
+	Schedule string
+	// WebhookToken defines the secret authenticating the webhooks, either sent as bearer token
+	// or used to sign the payload like GitHub does. Default to empty which disables webhooks.
+	WebhookToken string
+	// RunOnStartup runs the manifests as soon as the server starts
+	RunOnStartup bool
+}
+
+// Run defines a run of the manifests, as reported by the status API
+type Run struct {
+	Trigger string    `json:"trigger"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Status defines the server status, as reported by the status API
+type Status struct {
+	// Running is set while the manifests are run
+	Running bool `json:"running"`
+	// Queued is set when a run is requested while the manifests are run
+	Queued   bool       `json:"queued"`
+	Schedule string     `json:"schedule,omitempty"`
+	NextRun  *time.Time `json:"nextRun,omitempty"`
+	LastRun  *Run       `json:"lastRun,omitempty"`
+	Runs     int        `json:"runs"`
+	Failures int        `json:"failures"`
+}
+
+/*
+Server keeps Updatecli running, to run the manifests on a schedule or when receiving a webhook,
+such as a registry push event or a GitHub release webhook.
+
+Runs are never concurrent: a run requested while the manifests are run is queued,
+and requests received while a run is already queued are merged into it.
+*/
+type Server struct {
+	options  Options
+	schedule *Schedule
+	run      RunFunc
+	// triggers holds the queued run
+	triggers chan string
+
+	mu     sync.Mutex
+	status Status
+}
+
+// New returns a server running the manifests with run, or an error if options are invalid
+func New(options Options, run RunFunc) (*Server, error) {
+	if options.Listen == "" {
+		options.Listen = DefaultListen
+	}
+
+	s := &Server{
+		options:  options,
+		run:      run,
+		triggers: make(chan string, 1),
+		status: Status{
+			Schedule: options.Schedule,
+		},
+	}
+
+	if options.Schedule != "" {
+		schedule, err := ParseSchedule(options.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		s.schedule = &schedule
+	}
+
+	if s.schedule == nil && options.WebhookToken == "" {
+		return nil, errors.New("a schedule or a webhook token is required to run manifests")
+	}
+
+	return s, nil
+}
+
+// Start runs the server until ctx is done
+func (s *Server) Start(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:              s.options.Listen,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		logrus.Infof("Updatecli server listening on %q", s.options.Listen)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errs <- err
+		}
+	}()
+
+	if s.schedule != nil {
+		go s.scheduleRuns(ctx)
+	}
+
+	if s.options.RunOnStartup {
+		s.Trigger(TriggerStartup)
+	}
+
+	go s.executeRuns(ctx)
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("updatecli server: %w", err)
+	case <-ctx.Done():
+	}
+
+	logrus.Infof("Stopping Updatecli server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return httpServer.Shutdown(shutdownCtx)
+}
+
+// Handler returns the server http API:
+//
+//	GET  /healthz      reports the server is alive
+//	GET  /api/status   reports the server status, see Status
+//	POST /api/webhook  runs the manifests, if a webhook token is defined
+//	GET  /metrics      exposes the Prometheus metrics of the runs
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.Status())
+	})
+
+	if s.options.WebhookToken != "" {
+		mux.HandleFunc("/api/webhook", s.handleWebhook)
+	}
+
+	mux.Handle("/metrics", telemetry.MetricsHandler())
+
+	return mux
+}
+
+// Status returns the server status
+func (s *Server) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.status
+	if status.LastRun != nil {
+		lastRun := *status.LastRun
+		status.LastRun = &lastRun
+	}
+
+	return status
+}
+
+// Trigger queues a run of the manifests, then returns false if a run was already queued
+func (s *Server) Trigger(trigger string) bool {
+	select {
+	case s.triggers <- trigger:
+		s.mu.Lock()
+		s.status.Queued = true
+		s.mu.Unlock()
+
+		logrus.Infof("Run of the manifests queued by %s", trigger)
+		return true
+	default:
+		logrus.Infof("Run of the manifests already queued, ignoring %s", trigger)
+		return false
+	}
+}
+
+// handleWebhook queues a run of the manifests once the webhook is authenticated
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		http.Error(w, "reading webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	if !s.authenticateWebhook(r, body) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	trigger := TriggerWebhook
+	// GitHub sends a ping event when the webhook is created
+	switch event := r.Header.Get("X-GitHub-Event"); event {
+	case "ping":
+		w.WriteHeader(http.StatusOK)
+		return
+	case "":
+	default:
+		trigger = fmt.Sprintf("%s (GitHub %s event)", TriggerWebhook, event)
+	}
+
+	s.Trigger(trigger)
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// authenticateWebhook returns true if the webhook request is authenticated by the webhook token,
+// either as bearer token, or as key of the payload signature sent like GitHub does
+func (s *Server) authenticateWebhook(r *http.Request, body []byte) bool {
+	token := []byte(s.options.WebhookToken)
+
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return subtle.ConstantTimeCompare([]byte(bearer), token) == 1
+	}
+
+	if signature, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256="); ok {
+		expected, err := hex.DecodeString(signature)
+		if err != nil {
+			return false
+		}
+
+		mac := hmac.New(sha256.New, token)
+		mac.Write(body)
+
+		return hmac.Equal(mac.Sum(nil), expected)
+	}
+
+	return false
+}
+
+// scheduleRuns queues a run of the manifests every time the schedule is due, until ctx is done
+func (s *Server) scheduleRuns(ctx context.Context) {
+	for {
+		next := s.schedule.Next(time.Now())
+		if next.IsZero() {
+			logrus.Warningf("No next run found for schedule %q", s.options.Schedule)
+			return
+		}
+
+		s.mu.Lock()
+		s.status.NextRun = &next
+		s.mu.Unlock()
+
+		logrus.Infof("Next scheduled run at %s", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.Trigger(TriggerSchedule)
+		}
+	}
+}
+
+// executeRuns runs the manifests, one run at a time, every time a run is queued, until ctx is done
+func (s *Server) executeRuns(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case trigger := <-s.triggers:
+			s.execute(trigger)
+		}
+	}
+}
+
+// execute runs the manifests, recording the run in the server status
+func (s *Server) execute(trigger string) {
+	run := Run{
+		Trigger: trigger,
+		Start:   time.Now(),
+	}
+
+	s.mu.Lock()
+	s.status.Running = true
+	s.status.Queued = len(s.triggers) > 0
+	s.mu.Unlock()
+
+	logrus.Infof("Running manifests, triggered by %s", trigger)
+
+	err := s.run(trigger)
+
+	run.End = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+		logrus.Errorf("Run triggered by %s failed: %s", trigger, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.Running = false
+	s.status.Runs++
+	if err != nil {
+		s.status.Failures++
+	}
+	s.status.LastRun = &run
+}
@@ -0,0 +1,155 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(Options{}, nil)
+	assert.Error(t, err, "a schedule or a webhook token is required")
+
+	_, err = New(Options{Schedule: "every day"}, nil)
+	assert.Error(t, err)
+
+	s, err := New(Options{Schedule: "@daily"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultListen, s.options.Listen)
+}
+
+func TestWebhook(t *testing.T) {
+	s, err := New(Options{WebhookToken: "secret"}, nil)
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(body))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	testData := []struct {
+		name           string
+		method         string
+		headers        map[string]string
+		expectedStatus int
+		expectedQueued bool
+	}{
+		{
+			name:           "Missing authentication",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong bearer token",
+			method:         http.MethodPost,
+			headers:        map[string]string{"Authorization": "Bearer wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong GitHub signature",
+			method:         http.MethodPost,
+			headers:        map[string]string{"X-Hub-Signature-256": sign("other")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Wrong method",
+			method:         http.MethodGet,
+			headers:        map[string]string{"Authorization": "Bearer secret"},
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "GitHub ping event",
+			method: http.MethodPost,
+			headers: map[string]string{
+				"X-Hub-Signature-256": sign(`{"action":"published"}`),
+				"X-GitHub-Event":      "ping",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "GitHub release event",
+			headers: map[string]string{
+				"X-Hub-Signature-256": sign(`{"action":"published"}`),
+				"X-GitHub-Event":      "release",
+			},
+			method:         http.MethodPost,
+			expectedStatus: http.StatusAccepted,
+			expectedQueued: true,
+		},
+		{
+			name:           "Bearer token, while a run is already queued",
+			method:         http.MethodPost,
+			headers:        map[string]string{"Authorization": "Bearer secret"},
+			expectedStatus: http.StatusAccepted,
+			expectedQueued: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/webhook", strings.NewReader(`{"action":"published"}`))
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedQueued, s.Status().Queued)
+		})
+	}
+
+	assert.Equal(t, "webhook (GitHub release event)", <-s.triggers)
+}
+
+func TestWebhookDisabled(t *testing.T) {
+	s, err := New(Options{Schedule: "@hourly"}, nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/webhook", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestStatus(t *testing.T) {
+	runErr := errors.New("pipeline failed")
+	triggers := []string{}
+
+	s, err := New(Options{Schedule: "@hourly"}, func(trigger string) error {
+		triggers = append(triggers, trigger)
+		return runErr
+	})
+	require.NoError(t, err)
+
+	s.execute(TriggerSchedule)
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var status Status
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+
+	assert.Equal(t, []string{TriggerSchedule}, triggers)
+	assert.False(t, status.Running)
+	assert.Equal(t, "@hourly", status.Schedule)
+	assert.Equal(t, 1, status.Runs)
+	assert.Equal(t, 1, status.Failures)
+	require.NotNil(t, status.LastRun)
+	assert.Equal(t, TriggerSchedule, status.LastRun.Trigger)
+	assert.Equal(t, "pipeline failed", status.LastRun.Error)
+}
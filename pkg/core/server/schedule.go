@@ -0,0 +1,176 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleDescriptors defines the predefined schedules, like cron
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule defines when manifests are run, using the cron syntax
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// dayOfMonthAny and dayOfWeekAny are set when the field is "*",
+	// as cron runs on days matching either field if both are restricted
+	dayOfMonthAny, dayOfWeekAny bool
+	// every defines a fixed interval between runs, such as "@every 1h30m"
+	every time.Duration
+}
+
+// ParseSchedule parses a cron schedule made of the five fields "minute hour day-of-month month day-of-week",
+// such as "30 2 * * 1-5", where each field is either "*", a value, a range "1-5", a list "1,15" or a step "*/15".
+// The descriptors "@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@yearly", and "@every <duration>" are supported too.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return Schedule{}, fmt.Errorf("parsing schedule %q: %w", spec, err)
+		}
+		if every < time.Minute {
+			return Schedule{}, fmt.Errorf("parsing schedule %q: interval must be at least one minute", spec)
+		}
+		return Schedule{every: every}, nil
+	}
+
+	if descriptor, ok := scheduleDescriptors[spec]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("parsing schedule %q: expected 5 fields \"minute hour day-of-month month day-of-week\", got %d", spec, len(fields))
+	}
+
+	var s Schedule
+	var err error
+
+	bounds := []struct {
+		field    *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dayOfMonth, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dayOfWeek, 0, 7},
+	}
+
+	for i, b := range bounds {
+		*b.field, err = parseScheduleField(fields[i], b.min, b.max)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("parsing schedule %q: %w", spec, err)
+		}
+	}
+
+	// Sunday is both 0 and 7
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+
+	s.dayOfMonthAny = fields[2] == "*"
+	s.dayOfWeekAny = fields[4] == "*"
+
+	return s, nil
+}
+
+// parseScheduleField returns the bitset of the values, between min and max, matched by a schedule field
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		valueRange, stepValue, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepValue)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+		}
+
+		start, end := min, max
+		if valueRange != "*" {
+			first, last, isRange := strings.Cut(valueRange, "-")
+
+			var err error
+			start, err = strconv.Atoi(first)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+
+			end = start
+			if isRange {
+				end, err = strconv.Atoi(last)
+				if err != nil {
+					return 0, fmt.Errorf("invalid range %q", part)
+				}
+			} else if hasStep {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value %q out of range %d-%d", part, min, max)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first time, after t, matching the schedule, or the zero time if none is found within five years
+func (s Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay returns true if the day of t matches the schedule day of month and day of week
+func (s Schedule) matchDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthAny || s.dayOfWeekAny {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
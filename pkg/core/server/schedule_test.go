@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// Monday
+	from := time.Date(2024, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+	testData := []struct {
+		schedule string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, time.January, 16, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * 6-7", time.Date(2024, time.January, 20, 9, 0, 0, 0, time.UTC)},
+		{"30 8 1,15 * *", time.Date(2024, time.February, 1, 8, 30, 0, 0, time.UTC)},
+		// Either the day of month or the day of week matches when both are restricted
+		{"0 0 1 * 3", time.Date(2024, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2024, time.January, 15, 12, 0, 45, 0, time.UTC)},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.schedule, func(t *testing.T) {
+			s, err := ParseSchedule(tt.schedule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Next(from))
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, schedule := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 10s",
+		"@every soon",
+	} {
+		_, err := ParseSchedule(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// DefaultMetricsJob defines the Prometheus job name of the metrics pushed to a Pushgateway
+const DefaultMetricsJob = "updatecli"
+
+var (
+	// registry holds the Updatecli metrics, without the Go runtime ones which are meaningless for a short-lived run
+	registry = prometheus.NewRegistry()
+
+	pipelinesRun = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "updatecli_pipelines_run_total",
+		Help: "Number of pipelines run.",
+	})
+
+	pipelinesSucceeded = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "updatecli_pipelines_succeeded_total",
+		Help: "Number of pipelines run successfully, changing something or not.",
+	})
+
+	pipelinesFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "updatecli_pipelines_failed_total",
+		Help: "Number of pipelines which failed.",
+	})
+
+	resourceDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "updatecli_resource_duration_seconds",
+		Help:    "Duration of the sources, conditions, and targets runs, by plugin kind.",
+		Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"stage", "kind", "result"})
+)
+
+func init() {
+	registry.MustRegister(pipelinesRun, pipelinesSucceeded, pipelinesFailed, resourceDuration)
+}
+
+// ObservePipeline records the run of a pipeline, using its report result
+func ObservePipeline(pipelineResult string) {
+	pipelinesRun.Inc()
+
+	switch pipelineResult {
+	case result.SUCCESS, result.ATTENTION:
+		pipelinesSucceeded.Inc()
+	case result.FAILURE:
+		pipelinesFailed.Inc()
+	}
+}
+
+// ObserveResource records the duration of a source, condition, or target run, stage, of the plugin kind
+func ObserveResource(stage, kind, resourceResult string, duration time.Duration) {
+	resourceDuration.WithLabelValues(stage, kind, resultLabel(resourceResult)).Observe(duration.Seconds())
+}
+
+// PushMetrics pushes the metrics to the Prometheus Pushgateway located at URL, replacing the ones previously pushed for job,
+// so long-running scheduled jobs can be monitored.
+func PushMetrics(URL, job string) error {
+	if job == "" {
+		job = DefaultMetricsJob
+	}
+
+	if err := push.New(URL, job).Gatherer(registry).Push(); err != nil {
+		return fmt.Errorf("pushing metrics to %q: %w", URL, err)
+	}
+
+	return nil
+}
+
+// MetricsHandler returns the http handler exposing the metrics to Prometheus, such as used by "updatecli server"
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// resultLabel returns the result label value, as the result emojis aren't convenient in queries
+func resultLabel(resourceResult string) string {
+	switch resourceResult {
+	case result.SUCCESS:
+		return "success"
+	case result.ATTENTION:
+		return "changed"
+	case result.FAILURE:
+		return "failure"
+	case result.SKIPPED:
+		return "skipped"
+	}
+	return "unknown"
+}
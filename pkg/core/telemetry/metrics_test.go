@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestObservePipeline(t *testing.T) {
+	run := testutil.ToFloat64(pipelinesRun)
+	succeeded := testutil.ToFloat64(pipelinesSucceeded)
+	failed := testutil.ToFloat64(pipelinesFailed)
+
+	ObservePipeline(result.SUCCESS)
+	ObservePipeline(result.ATTENTION)
+	ObservePipeline(result.FAILURE)
+	ObservePipeline(result.SKIPPED)
+
+	assert.Equal(t, run+4, testutil.ToFloat64(pipelinesRun))
+	assert.Equal(t, succeeded+2, testutil.ToFloat64(pipelinesSucceeded))
+	assert.Equal(t, failed+1, testutil.ToFloat64(pipelinesFailed))
+}
+
+func TestPushMetrics(t *testing.T) {
+	ObserveResource("target", "yaml", result.ATTENTION, 2*time.Second)
+
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	require.NoError(t, PushMetrics(server.URL, ""))
+
+	assert.Equal(t, "/metrics/job/updatecli", path)
+	assert.True(t, strings.Contains(body, "updatecli_resource_duration_seconds"))
+
+	server.Close()
+	assert.Error(t, PushMetrics(server.URL, "nightly"))
+}
@@ -0,0 +1,281 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/version"
+)
+
+/*
+The traces are exported, once the run is over, to an OpenTelemetry collector
+using the OTLP/HTTP protocol with its JSON encoding, so no OpenTelemetry SDK is needed
+for the few spans of a run: one for the run, one per pipeline, and one per source, condition, and target.
+*/
+
+const (
+	// OTLPEndpointEnv defines the standard OpenTelemetry environment variable used as OTLP endpoint if none is specified
+	OTLPEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	otlpTracesPath = "/v1/traces"
+	serviceName    = "updatecli"
+
+	// OTLP span kind and status codes
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+// tracer holds the spans of the run, nil when tracing is disabled
+var tracer *spanRecorder
+
+// spanRecorder records the spans of a run, pipelines can run concurrently
+type spanRecorder struct {
+	mu       sync.Mutex
+	endpoint string
+	traceID  string
+	root     *Span
+	spans    []*Span
+}
+
+// Span is a unit of work of the run trace. A nil Span, returned when tracing is disabled, is a no-op.
+type Span struct {
+	spanID       string
+	parentSpanID string
+	name         string
+	attributes   map[string]string
+	start        time.Time
+	end          time.Time
+	result       string
+}
+
+// EnableTracing enables the trace of the run, exported by ExportTraces to the OTLP endpoint, such as "http://localhost:4318".
+// The OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used if endpoint is empty,
+// and tracing stays disabled if both are empty.
+func EnableTracing(endpoint string) {
+	if endpoint == "" {
+		endpoint = os.Getenv(OTLPEndpointEnv)
+	}
+
+	if endpoint == "" {
+		tracer = nil
+		return
+	}
+
+	tracer = &spanRecorder{
+		endpoint: tracesEndpoint(endpoint),
+		traceID:  randomID(16),
+	}
+}
+
+// StartRun starts the root span of the run, parent of the spans started without parent
+func StartRun(name string) *Span {
+	if tracer == nil {
+		return nil
+	}
+
+	span := StartSpan(nil, name, nil)
+
+	tracer.mu.Lock()
+	tracer.root = span
+	tracer.mu.Unlock()
+
+	return span
+}
+
+// StartSpan starts a span named name, child of parent, or of the run root span if parent is nil
+func StartSpan(parent *Span, name string, attributes map[string]string) *Span {
+	if tracer == nil {
+		return nil
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+
+	if parent == nil {
+		parent = tracer.root
+	}
+
+	span := &Span{
+		spanID:     randomID(8),
+		name:       name,
+		attributes: attributes,
+		start:      time.Now(),
+	}
+	if parent != nil {
+		span.parentSpanID = parent.spanID
+	}
+
+	tracer.spans = append(tracer.spans, span)
+
+	return span
+}
+
+// End ends the span, with the result of its unit of work, such as a pipeline or a target result
+func (s *Span) End(spanResult string) {
+	if s == nil || tracer == nil {
+		return
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+
+	s.end = time.Now()
+	s.result = spanResult
+}
+
+// ExportTraces exports the ended spans to the OTLP endpoint, if tracing is enabled
+func ExportTraces(ctx context.Context) error {
+	if tracer == nil {
+		return nil
+	}
+
+	body, err := tracer.marshal()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tracer.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting traces to %q: %w", tracer.endpoint, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("exporting traces to %q: got %s: %s", tracer.endpoint, res.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+type otlpKeyValue struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+// marshal returns the OTLP/JSON export request of the ended spans
+func (r *spanRecorder) marshal() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := []otlpSpan{}
+	for _, s := range r.spans {
+		if s.end.IsZero() {
+			continue
+		}
+
+		span := otlpSpan{
+			TraceID:           r.traceID,
+			SpanID:            s.spanID,
+			ParentSpanID:      s.parentSpanID,
+			Name:              s.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        keyValues(s.attributes),
+		}
+
+		span.Status.Code = otlpStatusCodeOk
+		if s.result == result.FAILURE {
+			span.Status.Code = otlpStatusCodeError
+		}
+		if s.result != "" {
+			span.Attributes = append(span.Attributes, keyValues(map[string]string{"updatecli.result": resultLabel(s.result)})...)
+		}
+
+		spans = append(spans, span)
+	}
+
+	request := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": keyValues(map[string]string{
+						"service.name":    serviceName,
+						"service.version": version.Version,
+					}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": serviceName},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+
+	return json.Marshal(request)
+}
+
+// keyValues returns the OTLP attributes of attributes, ordered by key
+func keyValues(attributes map[string]string) []otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keyValues := make([]otlpKeyValue, 0, len(keys))
+	for _, key := range keys {
+		kv := otlpKeyValue{Key: key}
+		kv.Value.StringValue = attributes[key]
+		keyValues = append(keyValues, kv)
+	}
+
+	return keyValues
+}
+
+// tracesEndpoint returns the OTLP/HTTP traces endpoint of the OTLP endpoint, like the OpenTelemetry SDKs do
+func tracesEndpoint(endpoint string) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if strings.HasSuffix(endpoint, otlpTracesPath) {
+		return endpoint
+	}
+	return endpoint + otlpTracesPath
+}
+
+// randomID returns a random hex encoded identifier of size bytes, as used for trace and span ids
+func randomID(size int) string {
+	b := make([]byte, size)
+	// crypto/rand.Read never fails on supported platforms
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestExportTraces(t *testing.T) {
+	var path string
+	var request struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Setenv(OTLPEndpointEnv, "")
+	EnableTracing(server.URL + "/")
+	defer EnableTracing("")
+
+	run := StartRun("updatecli run")
+	pipeline := StartSpan(nil, "pipeline demo", map[string]string{"updatecli.pipeline": "demo"})
+	target := StartSpan(pipeline, "target readme", nil)
+	target.End(result.FAILURE)
+	pipeline.End(result.FAILURE)
+	run.End(result.FAILURE)
+
+	// Spans still running aren't exported
+	StartSpan(nil, "pipeline unfinished", nil)
+
+	require.NoError(t, ExportTraces(context.Background()))
+
+	assert.Equal(t, "/v1/traces", path)
+	require.Len(t, request.ResourceSpans, 1)
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "updatecli run", spans[0].Name)
+	assert.Empty(t, spans[0].ParentSpanID)
+	assert.Equal(t, spans[0].SpanID, spans[1].ParentSpanID)
+	assert.Equal(t, spans[1].SpanID, spans[2].ParentSpanID)
+	assert.Equal(t, spans[0].TraceID, spans[2].TraceID)
+	assert.Len(t, spans[0].TraceID, 32)
+	assert.Len(t, spans[0].SpanID, 16)
+	assert.Equal(t, otlpStatusCodeError, spans[2].Status.Code)
+	assert.Equal(t, "demo", spans[1].Attributes[0].Value.StringValue)
+}
+
+func TestTracingDisabled(t *testing.T) {
+	t.Setenv(OTLPEndpointEnv, "")
+	EnableTracing("")
+
+	span := StartSpan(nil, "pipeline demo", nil)
+	assert.Nil(t, span)
+	span.End(result.SUCCESS)
+
+	assert.NoError(t, ExportTraces(context.Background()))
+}
+
+func TestTracesEndpoint(t *testing.T) {
+	assert.Equal(t, "http://localhost:4318/v1/traces", tracesEndpoint("http://localhost:4318"))
+	assert.Equal(t, "http://localhost:4318/v1/traces", tracesEndpoint("http://localhost:4318/v1/traces"))
+}
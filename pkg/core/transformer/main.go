@@ -34,6 +34,8 @@ type Transformer struct {
 	Replacers Replacers `yaml:",omitempty"`
 	// Replacer specifies what value needs to be changed and how
 	Replacer Replacer `yaml:",omitempty"`
+	// ReplaceAll replaces every match of a regular expression
+	ReplaceAll ReplaceAll `yaml:",omitempty"`
 	// Find searches for a specific value if it exists and return false if it doesn't
 	Find string `yaml:",omitempty"`
 	// Find searches for a specific value if it exists then return the value using regular expression
@@ -42,6 +44,8 @@ type Transformer struct {
 	// SemvVerInc specifies a  comma separated list semantic versioning component that needs to be upgraded.
 	SemVerInc           string `yaml:",omitempty"`
 	DeprecatedSemVerInc string `yaml:"semverInc,omitempty" jsonschema:"-"`
+	// SemVerExtract specifies a comma separated list of semantic versioning components to keep, such as "major,minor" to transform "v1.21.3" into "1.21"
+	SemVerExtract string `yaml:",omitempty"`
 }
 
 // Transformers defines a list of transformer applied in order
@@ -82,6 +86,13 @@ func (t *Transformer) Apply(input string) (output string, err error) {
 		output = r.Replace(output)
 	}
 
+	if t.ReplaceAll != (ReplaceAll{}) {
+		output, err = t.ReplaceAll.Apply(output)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	if len(t.Find) > 0 {
 		re, err := regexp.Compile(t.Find)
 		if err != nil {
@@ -110,6 +121,13 @@ func (t *Transformer) Apply(input string) (output string, err error) {
 		}
 	}
 
+	if len(t.SemVerExtract) > 0 {
+		output, err = applySemVerExtract(output, t.SemVerExtract)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	return output, nil
 }
 
@@ -162,6 +180,30 @@ func applySemVerInc(input, semVerInc string) (string, error) {
 
 }
 
+func applySemVerExtract(input, semVerExtract string) (string, error) {
+
+	v, err := semver.NewVersion(input)
+	if err != nil {
+		return "", fmt.Errorf("wrong semantic version input: %q", input)
+	}
+
+	var components []string
+	for _, rule := range strings.Split(semVerExtract, ",") {
+		switch rule {
+		case "major":
+			components = append(components, fmt.Sprint(v.Major()))
+		case "minor":
+			components = append(components, fmt.Sprint(v.Minor()))
+		case "patch":
+			components = append(components, fmt.Sprint(v.Patch()))
+		default:
+			return "", fmt.Errorf("unsupported semantic versioning component %q, only accept a comma separated list between major, minor, patch", rule)
+		}
+	}
+
+	return strings.Join(components, "."), nil
+}
+
 func (t *Transformer) Validate() error {
 
 	warningMessageToLowerCase := func(key string) {
@@ -376,6 +376,82 @@ var (
 			expectedOutput: "",
 			expectedErr:    nil,
 		},
+		Data{
+			input: "v1.21.3",
+			rules: Transformers{
+				Transformer{
+					SemVerExtract: "major,minor",
+				},
+			},
+			expectedOutput: "1.21",
+		},
+		Data{
+			input: "1.21.3-rc1",
+			rules: Transformers{
+				Transformer{
+					SemVerExtract: "major",
+				},
+			},
+			expectedOutput: "1",
+		},
+		Data{
+			input: "1.21.3",
+			rules: Transformers{
+				Transformer{
+					SemVerExtract: "major,build",
+				},
+			},
+			expectedOutput: "",
+			expectedErr:    fmt.Errorf("unsupported semantic versioning component \"build\", only accept a comma separated list between major, minor, patch"),
+		},
+		Data{
+			input: "latest",
+			rules: Transformers{
+				Transformer{
+					SemVerExtract: "major",
+				},
+			},
+			expectedOutput: "",
+			expectedErr:    fmt.Errorf("wrong semantic version input: \"latest\""),
+		},
+		Data{
+			input: "release-1_21_3",
+			rules: Transformers{
+				Transformer{
+					ReplaceAll: ReplaceAll{
+						Pattern:     `^release-(\d+)_(\d+)_(\d+)$`,
+						Replacement: "${1}.${2}.${3}",
+					},
+				},
+				Transformer{
+					SemVerExtract: "major,minor",
+				},
+			},
+			expectedOutput: "1.21",
+		},
+		Data{
+			input: "1.21.3",
+			rules: Transformers{
+				Transformer{
+					ReplaceAll: ReplaceAll{
+						Pattern: `\.`,
+					},
+				},
+			},
+			expectedOutput: "1213",
+		},
+		Data{
+			input: "1.21.3",
+			rules: Transformers{
+				Transformer{
+					ReplaceAll: ReplaceAll{
+						Pattern: `(`,
+					},
+				},
+			},
+			expectedOutput: "",
+			expectedErr:    fmt.Errorf("error parsing regexp: missing closing ): `(`"),
+		},
 		Data{
 			input: "", // explicit empty value
 			rules: Transformers{
@@ -0,0 +1,28 @@
+package transformer
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ReplaceAll is a struct used to feed regexp.ReplaceAllString
+type ReplaceAll struct {
+	// Pattern defines the regular expression matching the values to replace
+	Pattern string `yaml:",omitempty" jsonschema:"required"`
+	// Replacement defines the value replacing each match. It can reference capture groups such as "${1}"
+	Replacement string `yaml:",omitempty"`
+}
+
+func (r *ReplaceAll) Apply(input string) (string, error) {
+
+	if len(r.Pattern) == 0 {
+		return "", fmt.Errorf("no regex provided")
+	}
+
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return "", err
+	}
+
+	return re.ReplaceAllString(input, r.Replacement), nil
+}
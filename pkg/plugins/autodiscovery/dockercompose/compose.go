@@ -70,12 +70,14 @@ func (d DockerCompose) discoverDockerComposeImageManifests() ([][]byte, error) {
 			// without a significant amount f api call. More information on following issue
 			// https://github.com/google/go-containerregistry/issues/1297
 			// until a better solution, we don't handle docker image digest
-			if strings.Contains(svc.Spec.Image, "@sha256") {
+			// except for images pinned by Updatecli, which keep their tag, like image:tag@sha256:...
+			serviceImage, ok := trimDigest(svc.Spec.Image, d.spec.Digest)
+			if !ok {
 				logrus.Debugf("Docker Digest is not supported at the moment for %q", svc.Spec.Image)
 				continue
 			}
 
-			serviceImageArray := strings.Split(svc.Spec.Image, ":")
+			serviceImageArray := strings.Split(serviceImage, ":")
 
 			// Get container image name and tag
 			serviceImageName := ""
@@ -164,6 +166,7 @@ func (d DockerCompose) discoverDockerComposeImageManifests() ([][]byte, error) {
 				VersionFilterKind    string
 				VersionFilterPattern string
 				ScmID                string
+				Digest               bool
 			}{
 				ManifestName:         fmt.Sprintf("Bump Docker image tag for %q", serviceImageName),
 				ImageName:            serviceImageName,
@@ -179,6 +182,7 @@ func (d DockerCompose) discoverDockerComposeImageManifests() ([][]byte, error) {
 				VersionFilterKind:    sourceSpec.VersionFilter.Kind,
 				VersionFilterPattern: sourceSpec.VersionFilter.Pattern,
 				ScmID:                d.scmID,
+				Digest:               d.spec.Digest,
 			}
 
 			manifest := bytes.Buffer{}
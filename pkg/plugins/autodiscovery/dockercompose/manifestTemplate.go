@@ -12,6 +12,9 @@ sources:
       architecture: '{{ .ImageArchitecture }}'
 {{ end }}
       image: '{{ .ImageName }}'
+{{- if .Digest }}
+      digest: true
+{{- end }}
       tagfilter: '{{ .TagFilter }}'
       versionfilter:
         kind: '{{ .VersionFilterKind }}'
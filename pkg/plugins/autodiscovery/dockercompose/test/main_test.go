@@ -26,6 +26,7 @@ func TestDiscoverManifests(t *testing.T) {
 	testdata := []struct {
 		name              string
 		rootDir           string
+		digest            bool
 		expectedPipelines []config.Spec
 	}{
 		{
@@ -108,6 +109,51 @@ func TestDiscoverManifests(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "Scenario 2: digest pinning",
+			rootDir: "testdata_digest",
+			digest:  true,
+			expectedPipelines: []config.Spec{
+				{
+					Name: "Bump Docker image tag for \"jenkinsci/jenkins\"",
+					Sources: map[string]source.Config{
+						"jenkins-lts": {
+							ResourceConfig: resource.ResourceConfig{
+								Name: "[jenkinsci/jenkins] Get latest Docker image tag",
+								Kind: "dockerimage",
+								Spec: dockerimage.Spec{
+									Image:     "jenkinsci/jenkins",
+									Digest:    true,
+									TagFilter: `^\d*(\.\d*){2}-alpine$`,
+									VersionFilter: version.Filter{
+										Kind:    "semver",
+										Pattern: ">=2.150.1-alpine",
+									},
+								},
+							},
+						},
+					},
+					Targets: map[string]target.Config{
+						"jenkins-lts": {
+							SourceID: "jenkins-lts",
+							ResourceConfig: resource.ResourceConfig{
+								Name: "[jenkinsci/jenkins] Bump Docker image tag in \"docker-compose.yaml\"",
+								Kind: "yaml",
+								Spec: yaml.Spec{
+									File: "docker-compose.yaml",
+									Key:  "$.services.jenkins-lts.image",
+								},
+								Transformers: transformer.Transformers{
+									transformer.Transformer{
+										AddPrefix: "jenkinsci/jenkins:",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testdata {
@@ -115,6 +161,7 @@ func TestDiscoverManifests(t *testing.T) {
 			composefile, err := dockercompose.New(
 				dockercompose.Spec{
 					RootDir: tt.rootDir,
+					Digest:  tt.digest,
 				}, "", "")
 			require.NoError(t, err)
 
@@ -96,3 +96,46 @@ func TestGetDockerComposeSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimDigest(t *testing.T) {
+	testdata := []struct {
+		name          string
+		image         string
+		digest        bool
+		expectedImage string
+		expectedOk    bool
+	}{
+		{
+			name:          "Image with tag",
+			image:         "jenkinsci/jenkins:2.150.1-alpine",
+			expectedImage: "jenkinsci/jenkins:2.150.1-alpine",
+			expectedOk:    true,
+		},
+		{
+			name:          "Image pinned to the digest of a tag without digest pinning",
+			image:         "jenkinsci/jenkins:2.150.1-alpine@sha256:ce782db15ab5491c6c6178da8431b3db66988ccd11512034946a9667846952a6",
+			expectedImage: "jenkinsci/jenkins:2.150.1-alpine@sha256:ce782db15ab5491c6c6178da8431b3db66988ccd11512034946a9667846952a6",
+		},
+		{
+			name:          "Image pinned to the digest of a tag with digest pinning",
+			image:         "localhost:5000/jenkinsci/jenkins:2.150.1-alpine@sha256:ce782db15ab5491c6c6178da8431b3db66988ccd11512034946a9667846952a6",
+			digest:        true,
+			expectedImage: "localhost:5000/jenkinsci/jenkins:2.150.1-alpine",
+			expectedOk:    true,
+		},
+		{
+			name:          "Image pinned to a digest without tag",
+			image:         "localhost:5000/jenkinsci/jenkins@sha256:ce782db15ab5491c6c6178da8431b3db66988ccd11512034946a9667846952a6",
+			digest:        true,
+			expectedImage: "localhost:5000/jenkinsci/jenkins",
+		},
+	}
+
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			gotImage, gotOk := trimDigest(tt.image, tt.digest)
+			assert.Equal(t, tt.expectedImage, gotImage)
+			assert.Equal(t, tt.expectedOk, gotOk)
+		})
+	}
+}
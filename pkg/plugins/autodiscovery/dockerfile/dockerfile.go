@@ -60,12 +60,14 @@ func (h Dockerfile) discoverDockerfileManifests() ([][]byte, error) {
 			// without a significant amount f api call. More information on following issue
 			// https://github.com/google/go-containerregistry/issues/1297
 			// until a better solution, we don't handle docker image digest
-			if strings.Contains(instruction.image, "@sha256") {
+			// except for images pinned by Updatecli, which keep their tag, like image:tag@sha256:...
+			image, ok := trimDigest(instruction.image, h.spec.Digest)
+			if !ok {
 				logrus.Debugf("Docker Digest is not supported at the moment for %q", instruction.image)
 				continue
 			}
 
-			imageArray := strings.Split(instruction.image, ":")
+			imageArray := strings.Split(image, ":")
 
 			// Get container image name and tag
 			imageName := ""
@@ -166,6 +168,7 @@ func (h Dockerfile) discoverDockerfileManifests() ([][]byte, error) {
 				VersionFilterKind    string
 				VersionFilterPattern string
 				ScmID                string
+				Digest               bool
 			}{
 				ManifestName:         fmt.Sprintf("Bump Docker image tag for %q", imageName),
 				ImageName:            imageName,
@@ -179,6 +182,7 @@ func (h Dockerfile) discoverDockerfileManifests() ([][]byte, error) {
 				VersionFilterKind:    sourceSpec.VersionFilter.Kind,
 				VersionFilterPattern: sourceSpec.VersionFilter.Pattern,
 				ScmID:                h.scmID,
+				Digest:               h.spec.Digest,
 			}
 
 			manifest := bytes.Buffer{}
@@ -48,6 +48,8 @@ type Spec struct {
 		and its type like regex, semver, or just latest.
 	*/
 	VersionFilter version.Filter `yaml:",omitempty"`
+	// Digest pins the Docker images to their digest, like `image:tag@sha256:...`, instead of their tag
+	Digest bool `yaml:",omitempty"`
 }
 
 // Dockerfile hold all information needed to generate Dockerfile manifest.
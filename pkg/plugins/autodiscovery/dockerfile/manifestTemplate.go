@@ -10,6 +10,9 @@ sources:
     kind: 'dockerimage'
     spec:
       image: '{{ .ImageName }}'
+{{- if .Digest }}
+      digest: true
+{{- end }}
       tagfilter: '{{ .TagFilter }}'
       versionfilter:
         kind: '{{ .VersionFilterKind }}'
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -278,3 +279,25 @@ func parsePlatform(platform string) (arch string) {
 
 	return arch
 }
+
+// trimDigest returns image without its digest when digest pinning is enabled and image is pinned
+// to the digest of a tag, like image:tag@sha256:..., so the tag can still be updated.
+// It returns false if image is pinned to a digest that can't be updated.
+func trimDigest(image string, digest bool) (string, bool) {
+	if !strings.Contains(image, "@sha256") {
+		return image, true
+	}
+
+	if !digest {
+		return image, false
+	}
+
+	image = strings.Split(image, "@")[0]
+
+	// Without a tag, there is no version to update
+	if !strings.Contains(path.Base(image), ":") {
+		return image, false
+	}
+
+	return image, true
+}
@@ -8,6 +8,7 @@ import (
 	"text/template"
 
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
 )
 
 var (
@@ -107,6 +108,13 @@ func (h Helm) discoverHelmDependenciesManifests() ([][]byte, error) {
 
 			dependencyNameSlug := strings.ReplaceAll(dependency.Name, "/", "_")
 
+			// OCI registries may require credentials to retrieve the chart versions and update the chart dependencies
+			var registryAuth docker.InlineKeyChain
+			if strings.HasPrefix(dependency.Repository, "oci://") {
+				registry := strings.Split(strings.TrimPrefix(dependency.Repository, "oci://"), "/")[0]
+				registryAuth = h.spec.Auths[registry]
+			}
+
 			params := struct {
 				ManifestName                string
 				ImageName                   string
@@ -127,6 +135,10 @@ func (h Helm) discoverHelmDependenciesManifests() ([][]byte, error) {
 				TargetFile                  string
 				File                        string
 				ScmID                       string
+				RegistryUsername            string
+				RegistryPassword            string
+				RegistryToken               string
+				RegistryProvider            string
 			}{
 				ManifestName:                fmt.Sprintf("Bump dependency %q for Helm chart %q", dependency.Name, chartName),
 				ChartName:                   chartName,
@@ -146,6 +158,10 @@ func (h Helm) discoverHelmDependenciesManifests() ([][]byte, error) {
 				TargetFile:                  filepath.Base(foundChartFile),
 				File:                        relativeFoundChartFile,
 				ScmID:                       h.scmID,
+				RegistryUsername:            registryAuth.Username,
+				RegistryPassword:            registryAuth.Password,
+				RegistryToken:               registryAuth.Token,
+				RegistryProvider:            registryAuth.Provider,
 			}
 
 			manifest := bytes.Buffer{}
@@ -11,6 +11,18 @@ sources:
     spec:
       name: '{{ .DependencyName }}'
       url: '{{ .DependencyRepository }}'
+{{- if .RegistryUsername }}
+      username: '{{ .RegistryUsername }}'
+{{- end }}
+{{- if .RegistryPassword }}
+      password: '{{ .RegistryPassword }}'
+{{- end }}
+{{- if .RegistryToken }}
+      token: '{{ .RegistryToken }}'
+{{- end }}
+{{- if .RegistryProvider }}
+      provider: '{{ .RegistryProvider }}'
+{{- end }}
       versionfilter:
         kind: '{{ .SourceVersionFilterKind }}'
         pattern: '{{ .SourceVersionFilterPattern }}'
@@ -38,6 +50,18 @@ targets:
       key: '{{ .TargetKey }}'
       name: '{{ .TargetChartName }}'
       versionincrement: '{{ .TargetChartVersionIncrement }}'
+{{- if .RegistryUsername }}
+      username: '{{ .RegistryUsername }}'
+{{- end }}
+{{- if .RegistryPassword }}
+      password: '{{ .RegistryPassword }}'
+{{- end }}
+{{- if .RegistryToken }}
+      token: '{{ .RegistryToken }}'
+{{- end }}
+{{- if .RegistryProvider }}
+      provider: '{{ .RegistryProvider }}'
+{{- end }}
     sourceid: '{{ .SourceID }}'
 `
 )
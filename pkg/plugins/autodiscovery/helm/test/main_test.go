@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/fleet"
 	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/helm"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
 )
 
 func TestDiscoverManifests(t *testing.T) {
@@ -344,3 +345,52 @@ targets:
 		})
 	}
 }
+
+func TestDiscoverOCIDependencyManifests(t *testing.T) {
+	h, err := helm.New(
+		helm.Spec{
+			RootDir: "testdata-4/chart",
+			Auths: map[string]docker.InlineKeyChain{
+				"ghcr.io": {Provider: docker.ProviderDockerConfig},
+			},
+		}, "", "")
+	require.NoError(t, err)
+
+	rawPipelines, err := h.DiscoverManifests()
+	require.NoError(t, err)
+	require.Len(t, rawPipelines, 1)
+
+	assert.Equal(t, `name: 'Bump dependency "podinfo" for Helm chart "sample"'
+sources:
+  podinfo:
+    name: 'Get latest "podinfo" Helm chart version'
+    kind: 'helmchart'
+    spec:
+      name: 'podinfo'
+      url: 'oci://ghcr.io/stefanprodan/charts'
+      provider: 'dockerconfig'
+      versionfilter:
+        kind: 'semver'
+        pattern: '*'
+conditions:
+  podinfo:
+    name: 'Ensure Helm chart dependency "podinfo" is specified'
+    kind: 'yaml'
+    spec:
+      file: 'sample/Chart.yaml'
+      key: '$.dependencies[0].name'
+      value: 'podinfo'
+    disablesourceinput: true
+targets:
+  podinfo:
+    name: 'Bump Helm chart dependency "podinfo" for Helm chart "sample"'
+    kind: 'helmchart'
+    spec:
+      file: 'Chart.yaml'
+      key: '$.dependencies[0].version'
+      name: 'sample'
+      versionincrement: ''
+      provider: 'dockerconfig'
+    sourceid: 'podinfo'
+`, string(rawPipelines[0]))
+}
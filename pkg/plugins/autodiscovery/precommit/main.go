@@ -0,0 +1,100 @@
+package precommit
+
+import (
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+// Spec defines the pre-commit parameters.
+type Spec struct {
+	// rootdir defines the root directory used to recursively search for pre-commit configuration files
+	RootDir string `yaml:",omitempty"`
+	// Ignore allows to specify rule to ignore "autodiscovery" a specific pre-commit hook repository based on a rule
+	Ignore MatchingRules `yaml:",omitempty"`
+	// Only allows to specify rule to only "autodiscovery" manifest for a specific pre-commit hook repository based on a rule
+	Only MatchingRules `yaml:",omitempty"`
+	/*
+		versionfilter provides parameters to specify the version pattern used when generating manifest.
+
+		kind - semver
+			versionfilter of kind `semver` uses semantic versioning as version filtering
+			pattern accepts one of:
+				`patch` - patch only update patch version
+				`minor` - minor only update minor version
+				`major` - major only update major versions
+				`a version constraint` such as `>= 1.0.0`
+
+		kind - regex
+			versionfilter of kind `regex` uses regular expression as version filtering
+			pattern accepts a valid regular expression
+
+		example:
+		```
+			versionfilter:
+				kind: semver
+				pattern: minor
+		```
+
+		and its type like regex, semver, or just latest.
+	*/
+	VersionFilter version.Filter `yaml:",omitempty"`
+}
+
+// Precommit hold all information needed to generate pre-commit manifest.
+type Precommit struct {
+	// spec defines the settings provided via an updatecli manifest
+	spec Spec
+	// rootdir defines the root directory from where looking for pre-commit configuration files
+	rootDir string
+	// scmID holds the scmID used by the newly generated manifest
+	scmID string
+	// versionFilter holds the "valid" version.filter, that might be different from the user-specified filter (Spec.VersionFilter)
+	versionFilter version.Filter
+}
+
+// New return a new valid Precommit object.
+func New(spec interface{}, rootDir, scmID string) (Precommit, error) {
+	var s Spec
+
+	err := mapstructure.Decode(spec, &s)
+	if err != nil {
+		return Precommit{}, err
+	}
+
+	dir := rootDir
+	if len(s.RootDir) > 0 {
+		dir = s.RootDir
+	}
+
+	// Fallback to the current process path if no "rootdir" specified.
+	if len(dir) == 0 {
+		logrus.Errorln("no working directory defined")
+		return Precommit{}, err
+	}
+
+	newFilter := s.VersionFilter
+	if s.VersionFilter.IsZero() {
+		// By default, pre-commit hook repositories are tagged using semantic versioning
+		newFilter.Kind = "semver"
+		newFilter.Pattern = "*"
+	}
+
+	return Precommit{
+		spec:          s,
+		rootDir:       dir,
+		scmID:         scmID,
+		versionFilter: newFilter,
+	}, nil
+
+}
+
+func (p Precommit) DiscoverManifests() ([][]byte, error) {
+
+	logrus.Infof("\n\n%s\n", strings.ToTitle("pre-commit"))
+	logrus.Infof("%s\n", strings.Repeat("=", len("pre-commit")+1))
+
+	return p.discoverPrecommitManifests()
+}
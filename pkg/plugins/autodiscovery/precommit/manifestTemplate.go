@@ -0,0 +1,39 @@
+package precommit
+
+const (
+	// manifestTemplate is the Go template used to generate pre-commit manifests
+	manifestTemplate string = `name: '{{ .ManifestName }}'
+sources:
+  {{ .SourceID }}:
+    name: '{{ .SourceName }}'
+    kind: 'gittag'
+    spec:
+      url: '{{ .Repository }}'
+      versionfilter:
+        kind: '{{ .SourceVersionFilterKind }}'
+        pattern: '{{ .SourceVersionFilterPattern }}'
+conditions:
+  {{ .ConditionID }}:
+    name: '{{ .ConditionName }}'
+    kind: 'yaml'
+{{- if .ScmID }}
+    scmid: '{{ .ScmID }}'
+{{ end }}
+    spec:
+      file: '{{ .File }}'
+      key: '{{ .ConditionKey }}'
+      value: '{{ .Repository }}'
+    disablesourceinput: true
+targets:
+  {{ .TargetID }}:
+    name: '{{ .TargetName }}'
+    kind: 'yaml'
+{{- if .ScmID }}
+    scmid: '{{ .ScmID }}'
+{{ end }}
+    spec:
+      file: '{{ .File }}'
+      key: '{{ .TargetKey }}'
+    sourceid: '{{ .SourceID }}'
+`
+)
@@ -0,0 +1,73 @@
+package precommit
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MatchingRule allows to specifies rules to identify manifest
+type MatchingRule struct {
+	// Path specifies a pre-commit configuration file path pattern, the pattern requires to match all of name, not just a substring.
+	Path string
+	// Repos specifies a list of hook repository URL patterns such as "https://github.com/pre-commit/*"
+	Repos []string
+}
+
+type MatchingRules []MatchingRule
+
+// isMatchingRules tests that all defined rule are matching and return true if it's the case otherwise return false
+func (m MatchingRules) isMatchingRules(rootDir, filePath, repo string) bool {
+	for _, rule := range m {
+		var ruleResults []bool
+
+		// Only check if path rule defined
+		if rule.Path != "" {
+			if filepath.IsAbs(rule.Path) {
+				filePath = filepath.Join(rootDir, filePath)
+			}
+
+			match, err := filepath.Match(rule.Path, filePath)
+			if err != nil {
+				logrus.Errorf("%s - %q", err, rule.Path)
+			}
+			ruleResults = append(ruleResults, match)
+			if match {
+				logrus.Debugf("file path %q matching rule %q", filePath, rule.Path)
+			}
+		}
+
+		// Only check if repos rule defined
+		if len(rule.Repos) > 0 {
+			match := false
+			for _, pattern := range rule.Repos {
+				found, err := path.Match(pattern, repo)
+				if err != nil {
+					logrus.Errorf("%s - %q", err, pattern)
+					continue
+				}
+				if found {
+					logrus.Debugf("repository %q matching rule %q", repo, pattern)
+					match = true
+					break
+				}
+			}
+			ruleResults = append(ruleResults, match)
+		}
+
+		isAllMatching := len(ruleResults) > 0
+		for i := range ruleResults {
+			if !ruleResults[i] {
+				isAllMatching = false
+				break
+			}
+		}
+
+		if isAllMatching {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package precommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMatchingRules(t *testing.T) {
+	testdata := []struct {
+		name           string
+		rules          MatchingRules
+		rootDir        string
+		filePath       string
+		repo           string
+		expectedResult bool
+	}{
+		{
+			name: "Matching path rule",
+			rules: MatchingRules{
+				MatchingRule{
+					Path: ".pre-commit-config.yaml",
+				},
+			},
+			filePath:       ".pre-commit-config.yaml",
+			repo:           "https://github.com/psf/black",
+			expectedResult: true,
+		},
+		{
+			name: "Matching repository pattern",
+			rules: MatchingRules{
+				MatchingRule{
+					Repos: []string{"https://github.com/pre-commit/*"},
+				},
+			},
+			filePath:       ".pre-commit-config.yaml",
+			repo:           "https://github.com/pre-commit/pre-commit-hooks",
+			expectedResult: true,
+		},
+		{
+			name: "Not matching repository",
+			rules: MatchingRules{
+				MatchingRule{
+					Repos: []string{"https://github.com/psf/black"},
+				},
+			},
+			filePath:       ".pre-commit-config.yaml",
+			repo:           "https://github.com/psf/black-pre-commit-mirror",
+			expectedResult: false,
+		},
+		{
+			name: "Not matching every rule parameters",
+			rules: MatchingRules{
+				MatchingRule{
+					Path:  "website/.pre-commit-config.yaml",
+					Repos: []string{"https://github.com/psf/black"},
+				},
+			},
+			filePath:       ".pre-commit-config.yaml",
+			repo:           "https://github.com/psf/black",
+			expectedResult: false,
+		},
+		{
+			name: "Matching one of the rules",
+			rules: MatchingRules{
+				MatchingRule{
+					Path: "website/.pre-commit-config.yaml",
+				},
+				MatchingRule{
+					Repos: []string{"https://github.com/psf/black"},
+				},
+			},
+			filePath:       ".pre-commit-config.yaml",
+			repo:           "https://github.com/psf/black",
+			expectedResult: true,
+		},
+	}
+
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResult := tt.rules.isMatchingRules(tt.rootDir, tt.filePath, tt.repo)
+			assert.Equal(t, tt.expectedResult, gotResult)
+		})
+	}
+}
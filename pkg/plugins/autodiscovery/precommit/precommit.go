@@ -0,0 +1,155 @@
+package precommit
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// DefaultFilePattern specifies accepted pre-commit configuration filename
+	DefaultFilePattern [1]string = [1]string{".pre-commit-config.yaml"}
+	// commitSHARegex matches a rev pinned to a full commit SHA, which isn't updated to a tag
+	commitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// hookRepository holds the information of a repository defined in a pre-commit configuration.
+type hookRepository struct {
+	Repo string
+	Rev  string
+}
+
+// precommitConfig is the information retrieved from pre-commit configuration files.
+type precommitConfig struct {
+	Repos []hookRepository
+}
+
+// discoverPrecommitManifests search recursively from a root directory for pre-commit configuration files
+func (p Precommit) discoverPrecommitManifests() ([][]byte, error) {
+
+	var manifests [][]byte
+
+	foundPrecommitFiles, err := searchPrecommitFiles(
+		p.rootDir,
+		DefaultFilePattern[:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, foundPrecommitFile := range foundPrecommitFiles {
+		logrus.Debugf("parsing file %q", foundPrecommitFile)
+
+		relativeFoundPrecommitFile, err := filepath.Rel(p.rootDir, foundPrecommitFile)
+		if err != nil {
+			// Jump to the next pre-commit configuration if current failed
+			logrus.Debugln(err)
+			continue
+		}
+
+		config, err := getPrecommitConfig(foundPrecommitFile)
+		if err != nil {
+			logrus.Debugln(err)
+			continue
+		}
+
+		for i, repository := range config.Repos {
+			// "local" and "meta" repositories aren't git repositories
+			if repository.Repo == "local" || repository.Repo == "meta" {
+				continue
+			}
+
+			if repository.Repo == "" || repository.Rev == "" {
+				logrus.Debugf("no repository or rev specified for hook repository %d in %q, skipping", i, relativeFoundPrecommitFile)
+				continue
+			}
+
+			if commitSHARegex.MatchString(repository.Rev) {
+				logrus.Debugf("hook repository %q is pinned to the commit %q, skipping", repository.Repo, repository.Rev)
+				continue
+			}
+
+			// Test if the ignore rule based on path and repository doesn't match
+			if len(p.spec.Ignore) > 0 && p.spec.Ignore.isMatchingRules(p.rootDir, relativeFoundPrecommitFile, repository.Repo) {
+				logrus.Debugf("Ignoring hook repository %q from %q, as matching ignore rule(s)\n",
+					repository.Repo,
+					relativeFoundPrecommitFile)
+				continue
+			}
+
+			// Test if the only rule based on path and repository doesn't match
+			if len(p.spec.Only) > 0 && !p.spec.Only.isMatchingRules(p.rootDir, relativeFoundPrecommitFile, repository.Repo) {
+				logrus.Debugf("Ignoring hook repository %q from %q, as not matching only rule(s)\n",
+					repository.Repo,
+					relativeFoundPrecommitFile)
+				continue
+			}
+
+			sourceVersionFilterKind := "semver"
+			sourceVersionFilterPattern := "*"
+
+			if !p.spec.VersionFilter.IsZero() {
+				sourceVersionFilterKind = p.versionFilter.Kind
+				sourceVersionFilterPattern, err = p.versionFilter.GreaterThanPattern(repository.Rev)
+				if err != nil {
+					logrus.Debugf("building version filter pattern: %s", err)
+					sourceVersionFilterPattern = "*"
+				}
+			}
+
+			tmpl, err := template.New("manifest").Parse(manifestTemplate)
+			if err != nil {
+				logrus.Debugln(err)
+				continue
+			}
+
+			repositoryName := filepath.Base(repository.Repo)
+
+			params := struct {
+				ManifestName               string
+				Repository                 string
+				ConditionID                string
+				ConditionName              string
+				ConditionKey               string
+				SourceID                   string
+				SourceName                 string
+				SourceVersionFilterKind    string
+				SourceVersionFilterPattern string
+				TargetID                   string
+				TargetName                 string
+				TargetKey                  string
+				File                       string
+				ScmID                      string
+			}{
+				ManifestName:               fmt.Sprintf("Bump %q pre-commit hook version for %q", repository.Repo, relativeFoundPrecommitFile),
+				Repository:                 repository.Repo,
+				ConditionID:                repositoryName,
+				ConditionName:              fmt.Sprintf("Ensure hook repository %q is specified in %q", repository.Repo, relativeFoundPrecommitFile),
+				ConditionKey:               fmt.Sprintf("$.repos[%d].repo", i),
+				SourceID:                   repositoryName,
+				SourceName:                 fmt.Sprintf("Get latest %q git tag", repository.Repo),
+				SourceVersionFilterKind:    sourceVersionFilterKind,
+				SourceVersionFilterPattern: sourceVersionFilterPattern,
+				TargetID:                   repositoryName,
+				TargetName:                 fmt.Sprintf("Bump %q pre-commit hook version in %q", repository.Repo, relativeFoundPrecommitFile),
+				TargetKey:                  fmt.Sprintf("$.repos[%d].rev", i),
+				File:                       foundPrecommitFile,
+				ScmID:                      p.scmID,
+			}
+
+			manifest := bytes.Buffer{}
+			if err := tmpl.Execute(&manifest, params); err != nil {
+				logrus.Debugln(err)
+				continue
+			}
+
+			manifests = append(manifests, manifest.Bytes())
+		}
+	}
+
+	return manifests, nil
+}
@@ -0,0 +1,180 @@
+package precommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/config"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/condition"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/resource"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/source"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/target"
+	"github.com/updatecli/updatecli/pkg/plugins/autodiscovery/precommit"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/gittag"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/yaml"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/test"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+func TestDiscoverManifests(t *testing.T) {
+	precommitHooks := config.Spec{
+		Name: "Bump \"https://github.com/pre-commit/pre-commit-hooks\" pre-commit hook version for \"project/.pre-commit-config.yaml\"",
+		Sources: map[string]source.Config{
+			"pre-commit-hooks": {
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Get latest \"https://github.com/pre-commit/pre-commit-hooks\" git tag",
+					Kind: "gittag",
+					Spec: gittag.Spec{
+						URL: "https://github.com/pre-commit/pre-commit-hooks",
+						VersionFilter: version.Filter{
+							Kind:    "semver",
+							Pattern: "*",
+						},
+					},
+				},
+			},
+		},
+		Conditions: map[string]condition.Config{
+			"pre-commit-hooks": {
+				DisableSourceInput: true,
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Ensure hook repository \"https://github.com/pre-commit/pre-commit-hooks\" is specified in \"project/.pre-commit-config.yaml\"",
+					Kind: "yaml",
+					Spec: yaml.Spec{
+						File:  "testdata/project/.pre-commit-config.yaml",
+						Key:   "$.repos[0].repo",
+						Value: "https://github.com/pre-commit/pre-commit-hooks",
+					},
+				},
+			},
+		},
+		Targets: map[string]target.Config{
+			"pre-commit-hooks": {
+				SourceID: "pre-commit-hooks",
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Bump \"https://github.com/pre-commit/pre-commit-hooks\" pre-commit hook version in \"project/.pre-commit-config.yaml\"",
+					Kind: "yaml",
+					Spec: yaml.Spec{
+						File: "testdata/project/.pre-commit-config.yaml",
+						Key:  "$.repos[0].rev",
+					},
+				},
+			},
+		},
+	}
+
+	black := config.Spec{
+		Name: "Bump \"https://github.com/psf/black\" pre-commit hook version for \"project/.pre-commit-config.yaml\"",
+		Sources: map[string]source.Config{
+			"black": {
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Get latest \"https://github.com/psf/black\" git tag",
+					Kind: "gittag",
+					Spec: gittag.Spec{
+						URL: "https://github.com/psf/black",
+						VersionFilter: version.Filter{
+							Kind:    "semver",
+							Pattern: "*",
+						},
+					},
+				},
+			},
+		},
+		Conditions: map[string]condition.Config{
+			"black": {
+				DisableSourceInput: true,
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Ensure hook repository \"https://github.com/psf/black\" is specified in \"project/.pre-commit-config.yaml\"",
+					Kind: "yaml",
+					Spec: yaml.Spec{
+						File:  "testdata/project/.pre-commit-config.yaml",
+						Key:   "$.repos[1].repo",
+						Value: "https://github.com/psf/black",
+					},
+				},
+			},
+		},
+		Targets: map[string]target.Config{
+			"black": {
+				SourceID: "black",
+				ResourceConfig: resource.ResourceConfig{
+					Name: "Bump \"https://github.com/psf/black\" pre-commit hook version in \"project/.pre-commit-config.yaml\"",
+					Kind: "yaml",
+					Spec: yaml.Spec{
+						File: "testdata/project/.pre-commit-config.yaml",
+						Key:  "$.repos[1].rev",
+					},
+				},
+			},
+		},
+	}
+
+	testdata := []struct {
+		name              string
+		spec              precommit.Spec
+		expectedPipelines []config.Spec
+	}{
+		{
+			name:              "Every hook repository tagged",
+			spec:              precommit.Spec{RootDir: "testdata"},
+			expectedPipelines: []config.Spec{precommitHooks, black},
+		},
+		{
+			name: "Only pre-commit organization repositories",
+			spec: precommit.Spec{
+				RootDir: "testdata",
+				Only: precommit.MatchingRules{
+					precommit.MatchingRule{
+						Repos: []string{"https://github.com/pre-commit/*"},
+					},
+				},
+			},
+			expectedPipelines: []config.Spec{precommitHooks},
+		},
+		{
+			name: "Ignore pre-commit organization repositories",
+			spec: precommit.Spec{
+				RootDir: "testdata",
+				Ignore: precommit.MatchingRules{
+					precommit.MatchingRule{
+						Repos: []string{"https://github.com/pre-commit/*"},
+					},
+				},
+			},
+			expectedPipelines: []config.Spec{black},
+		},
+	}
+
+	for _, tt := range testdata {
+
+		t.Run(tt.name, func(t *testing.T) {
+			precommit, err := precommit.New(tt.spec, "", "")
+			require.NoError(t, err)
+
+			pipelines, err := precommit.DiscoverManifests()
+			require.NoError(t, err)
+			require.Len(t, pipelines, len(tt.expectedPipelines))
+
+			for i := range tt.expectedPipelines {
+				test.AssertConfigSpecEqualByteArray(t, &tt.expectedPipelines[i], string(pipelines[i]))
+			}
+		})
+	}
+
+	t.Run("Version filter based on the current rev", func(t *testing.T) {
+		precommit, err := precommit.New(precommit.Spec{
+			RootDir: "testdata",
+			VersionFilter: version.Filter{
+				Kind:    "semver",
+				Pattern: "minor",
+			},
+		}, "", "")
+		require.NoError(t, err)
+
+		pipelines, err := precommit.DiscoverManifests()
+		require.NoError(t, err)
+		require.Len(t, pipelines, 2)
+		assert.Contains(t, string(pipelines[0]), "pattern: '4.x'")
+	})
+}
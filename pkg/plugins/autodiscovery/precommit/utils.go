@@ -0,0 +1,63 @@
+package precommit
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	goyaml "gopkg.in/yaml.v3"
+)
+
+// searchPrecommitFiles search, recursively, for every pre-commit configuration files starting from a root directory.
+func searchPrecommitFiles(rootDir string, files []string) ([]string, error) {
+
+	precommitFiles := []string{}
+
+	err := filepath.WalkDir(rootDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			fmt.Printf("prevent panic by handling failure accessing a path %q: %v\n", path, err)
+			return err
+		}
+
+		for _, f := range files {
+			match, err := filepath.Match(f, d.Name())
+			if err != nil {
+				logrus.Errorln(err)
+				continue
+			}
+			if match && !d.IsDir() {
+				precommitFiles = append(precommitFiles, path)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("%d potential pre-commit configuration file(s) found", len(precommitFiles))
+
+	return precommitFiles, nil
+}
+
+// getPrecommitConfig loads file content from a pre-commit configuration file.
+func getPrecommitConfig(filename string) (*precommitConfig, error) {
+
+	var config precommitConfig
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = goyaml.Unmarshal(content, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
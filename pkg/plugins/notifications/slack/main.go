@@ -0,0 +1,98 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+	"github.com/updatecli/updatecli/pkg/plugins/notifications/webhook"
+)
+
+// Spec defines settings used to notify a Slack channel
+type Spec struct {
+	/*
+		"url" defines the Slack incoming webhook url
+
+		remark:
+			the incoming webhook url contains a secret, it is recommended to provide it using a secrets file
+	*/
+	URL string `yaml:",omitempty" jsonschema:"required"`
+}
+
+// Slack posts run summaries to a Slack incoming webhook
+type Slack struct {
+	spec   Spec
+	client httpclient.HTTPClient
+}
+
+// message defines the payload of a Slack incoming webhook
+type message struct {
+	Text string `json:"text"`
+}
+
+// New returns a new valid Slack notifier
+func New(spec interface{}) (*Slack, error) {
+	var s Spec
+
+	if err := mapstructure.Decode(spec, &s); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.ValidateURL(s.URL); err != nil {
+		return nil, err
+	}
+
+	return &Slack{
+		spec:   s,
+		client: &http.Client{},
+	}, nil
+}
+
+// Notify posts the summary of the run report r to Slack
+func (s *Slack) Notify(r reports.RunReport) error {
+	return webhook.Post(s.client, s.spec.URL, nil, message{Text: Text(r)})
+}
+
+// Text returns the summary of the run report r formatted using Slack mrkdwn
+func Text(r reports.RunReport) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("*Updatecli run:* %s\n", r.Summary))
+
+	for _, p := range r.Pipelines {
+		b.WriteString(fmt.Sprintf("• %s *%s*", p.Result, escape(p.Name)))
+		if p.Error != "" {
+			b.WriteString(fmt.Sprintf(": %s", escape(p.Error)))
+		}
+		b.WriteString("\n")
+
+		ids := []string{}
+		for id := range p.Actions {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			action := p.Actions[id]
+			if action.Link == "" {
+				continue
+			}
+			title := action.Title
+			if title == "" {
+				title = action.Link
+			}
+			b.WriteString(fmt.Sprintf("    ◦ <%s|%s>\n", action.Link, escape(title)))
+		}
+	}
+
+	return b.String()
+}
+
+// escape escapes the characters Slack mrkdwn uses for control sequences
+func escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
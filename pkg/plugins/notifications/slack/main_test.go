@@ -0,0 +1,35 @@
+package slack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestText(t *testing.T) {
+	report := reports.RunReport{
+		Summary: reports.RunSummary{Changed: 1, Failed: 1, Total: 2},
+		Pipelines: []reports.PipelineReport{
+			{
+				Name:   "Bump <go> version",
+				Result: "⚠",
+				Actions: map[string]reports.ActionReport{
+					"default": {Title: "Bump go version", Link: "https://github.com/updatecli/updatecli/pull/1"},
+				},
+			},
+			{
+				Name:   "Bump node version",
+				Result: "✗",
+				Error:  "source failed",
+			},
+		},
+	}
+
+	expected := "*Updatecli run:* 1 changed, 1 failed, 0 skipped, 0 succeeded, 2 total\n" +
+		"• ⚠ *Bump &lt;go&gt; version*\n" +
+		"    ◦ <https://github.com/updatecli/updatecli/pull/1|Bump go version>\n" +
+		"• ✗ *Bump node version*: source failed\n"
+
+	assert.Equal(t, expected, Text(report))
+}
@@ -0,0 +1,103 @@
+package teams
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+	"github.com/updatecli/updatecli/pkg/plugins/notifications/webhook"
+)
+
+// Spec defines settings used to notify a Microsoft Teams channel
+type Spec struct {
+	/*
+		"url" defines the Microsoft Teams incoming webhook url
+
+		remark:
+			the incoming webhook url contains a secret, it is recommended to provide it using a secrets file
+	*/
+	URL string `yaml:",omitempty" jsonschema:"required"`
+}
+
+// Teams posts run summaries to a Microsoft Teams incoming webhook
+type Teams struct {
+	spec   Spec
+	client httpclient.HTTPClient
+}
+
+// messageCard defines the payload of a Microsoft Teams incoming webhook
+type messageCard struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Title   string `json:"title"`
+	Text    string `json:"text"`
+}
+
+// New returns a new valid Microsoft Teams notifier
+func New(spec interface{}) (*Teams, error) {
+	var s Spec
+
+	if err := mapstructure.Decode(spec, &s); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.ValidateURL(s.URL); err != nil {
+		return nil, err
+	}
+
+	return &Teams{
+		spec:   s,
+		client: &http.Client{},
+	}, nil
+}
+
+// Notify posts the summary of the run report r to Microsoft Teams
+func (t *Teams) Notify(r reports.RunReport) error {
+	summary := fmt.Sprintf("Updatecli run: %s", r.Summary)
+
+	return webhook.Post(t.client, t.spec.URL, nil, messageCard{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Summary: summary,
+		Title:   summary,
+		Text:    Text(r),
+	})
+}
+
+// Text returns the pipelines of the run report r formatted using Markdown
+func Text(r reports.RunReport) string {
+	var b strings.Builder
+
+	for _, p := range r.Pipelines {
+		b.WriteString(fmt.Sprintf("- %s **%s**", p.Result, p.Name))
+		if p.Error != "" {
+			b.WriteString(fmt.Sprintf(": %s", p.Error))
+		}
+		b.WriteString("\n")
+
+		ids := []string{}
+		for id := range p.Actions {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			action := p.Actions[id]
+			if action.Link == "" {
+				continue
+			}
+			title := action.Title
+			if title == "" {
+				title = action.Link
+			}
+			b.WriteString(fmt.Sprintf("  - [%s](%s)\n", title, action.Link))
+		}
+	}
+
+	return b.String()
+}
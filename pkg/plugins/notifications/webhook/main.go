@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+// Spec defines settings used to notify a generic webhook
+type Spec struct {
+	/*
+		"url" defines the webhook url receiving the run report, in JSON, with a POST request
+	*/
+	URL string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		"headers" defines additional http headers sent to the webhook, such as an authorization header
+	*/
+	Headers map[string]string `yaml:",omitempty"`
+}
+
+// Webhook posts run reports to a generic webhook
+type Webhook struct {
+	spec   Spec
+	client httpclient.HTTPClient
+}
+
+// New returns a new valid Webhook notifier
+func New(spec interface{}) (*Webhook, error) {
+	var s Spec
+
+	if err := mapstructure.Decode(spec, &s); err != nil {
+		return nil, err
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Webhook{
+		spec:   s,
+		client: &http.Client{},
+	}, nil
+}
+
+// Validate ensures that the provided Spec is valid
+func (s Spec) Validate() error {
+	return ValidateURL(s.URL)
+}
+
+// Notify posts the run report r in JSON to the webhook
+func (w *Webhook) Notify(r reports.RunReport) error {
+	return Post(w.client, w.spec.URL, w.spec.Headers, r)
+}
+
+// ValidateURL ensures that URL is a valid http(s) webhook url
+func ValidateURL(URL string) error {
+	if URL == "" {
+		return fmt.Errorf("missing parameter [url]")
+	}
+
+	u, err := url.Parse(URL)
+	if err != nil {
+		return fmt.Errorf("parsing url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url scheme %q not supported, accepted values are %q and %q", u.Scheme, "http", "https")
+	}
+
+	return nil
+}
+
+// Post sends payload in JSON to the webhook URL, with headers, using client
+func Post(client httpclient.HTTPClient, URL string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling json: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		content, _ := io.ReadAll(res.Body)
+		logrus.Debugf("HTTP Response:\n\tReturn code: %d\n\tBody: %s\n", res.StatusCode, content)
+		return fmt.Errorf("notifying %q: unexpected return code %d", redactURL(URL), res.StatusCode)
+	}
+
+	return nil
+}
+
+// redactURL returns URL without its path and query, which often contain the webhook secret
+func redactURL(URL string) string {
+	u, err := url.Parse(URL)
+	if err != nil {
+		return URL
+	}
+
+	redacted := u.Scheme + "://" + u.Host
+	if u.Path != "" && u.Path != "/" {
+		redacted += "/" + strings.Repeat("*", 3)
+	}
+
+	return redacted
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestNotify(t *testing.T) {
+	var got reports.RunReport
+	var gotHeader string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		gotHeader = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	w, err := New(map[string]interface{}{
+		"url":     server.URL,
+		"headers": map[string]string{"Authorization": "Bearer xxx"},
+	})
+	require.NoError(t, err)
+
+	report := reports.RunReport{
+		Summary:   reports.RunSummary{Succeeded: 1, Total: 1},
+		Pipelines: []reports.PipelineReport{{Name: "Bump version", Result: "success"}},
+	}
+
+	require.NoError(t, w.Notify(report))
+	assert.Equal(t, report, got)
+	assert.Equal(t, "Bearer xxx", gotHeader)
+}
+
+func TestNotifyFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	w, err := New(map[string]interface{}{"url": server.URL + "/secret"})
+	require.NoError(t, err)
+
+	err = w.Notify(reports.RunReport{})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestValidateURL(t *testing.T) {
+	testData := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://hooks.example.com/services/xxx"},
+		{url: "http://localhost:8080"},
+		{url: "", wantErr: true},
+		{url: "ftp://example.com", wantErr: true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateURL(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package awsami
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
 
 // Filter represents the updatecli configuration describing AMI filters.
 type Filter struct {
@@ -29,3 +35,15 @@ func (f *Filters) String() string {
 
 	return str
 }
+
+// toEC2Filters returns the AWS API representation of the filters
+func (f Filters) toEC2Filters() []*ec2.Filter {
+	var ec2Filters []*ec2.Filter
+	for _, filter := range f {
+		ec2Filters = append(ec2Filters, &ec2.Filter{
+			Name:   aws.String(filter.Name),
+			Values: aws.StringSlice(strings.Split(filter.Values, ",")),
+		})
+	}
+	return ec2Filters
+}
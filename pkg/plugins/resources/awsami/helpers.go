@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/sirupsen/logrus"
@@ -14,7 +15,11 @@ import (
 func (a *AMI) getLatestAmiID() (string, error) {
 	input := ec2.DescribeImagesInput{
 		DryRun:  &a.Spec.DryRun,
-		Filters: a.ec2Filters,
+		Filters: a.Spec.Filters.toEC2Filters(),
+	}
+
+	if len(a.Spec.Owners) > 0 {
+		input.Owners = aws.StringSlice(a.Spec.Owners)
 	}
 
 	result, err := a.apiClient.DescribeImages(&input)
@@ -36,10 +41,11 @@ func (a *AMI) getLatestAmiID() (string, error) {
 	if nbImages := len(result.Images); nbImages > 0 {
 
 		switch a.Spec.SortBy {
-		case "creationdateasc":
-			sort.Sort(ByCreationDateAsc(result.Images))
 		case "creationdatedesc":
-			sort.Sort(ByCreationDateDesc(result.Images))
+			sort.Stable(ByCreationDateDesc(result.Images))
+		default:
+			// The AWS API doesn't sort images, the newest one must be the last one
+			sort.Stable(ByCreationDateAsc(result.Images))
 		}
 
 		logrus.Debugf("Latest AMI ID found:\n  ---\n  %s---\n\n",
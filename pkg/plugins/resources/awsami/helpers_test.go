@@ -3,6 +3,13 @@ package awsami
 import (
 	"strings"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
 func TestGetLatestAmiID(t *testing.T) {
@@ -26,3 +33,69 @@ func TestGetLatestAmiID(t *testing.T) {
 		}
 	}
 }
+
+type recordDescribeImagesInput struct {
+	ec2iface.EC2API
+	Resp     ec2.DescribeImagesOutput
+	GotInput *ec2.DescribeImagesInput
+}
+
+func (m *recordDescribeImagesInput) DescribeImages(in *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	m.GotInput = in
+	return &m.Resp, nil
+}
+
+func TestGetLatestAmiIDNewest(t *testing.T) {
+	mock := &recordDescribeImagesInput{
+		Resp: ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{
+				{ImageId: aws.String("ami-2"), CreationDate: aws.String("2023-09-12T08:15:00.000Z")},
+				{ImageId: aws.String("ami-3"), CreationDate: aws.String("2023-10-03T21:40:00.000Z")},
+				{ImageId: aws.String("ami-1"), CreationDate: aws.String("2023-08-01T10:00:00.000Z")},
+			},
+		},
+	}
+
+	ami := AMI{
+		Spec: Spec{
+			Owners: []string{"099720109477"},
+			Filters: Filters{
+				{Name: "name", Values: "ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-*-server-*"},
+				{Name: "architecture", Values: "x86_64,arm64"},
+			},
+		},
+		apiClient: mock,
+	}
+
+	got, err := ami.getLatestAmiID()
+	require.NoError(t, err)
+	assert.Equal(t, "ami-3", got)
+
+	assert.Equal(t, []*string{aws.String("099720109477")}, mock.GotInput.Owners)
+	assert.Equal(t, []*ec2.Filter{
+		{Name: aws.String("name"), Values: aws.StringSlice([]string{"ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-*-server-*"})},
+		{Name: aws.String("architecture"), Values: aws.StringSlice([]string{"x86_64", "arm64"})},
+	}, mock.GotInput.Filters)
+
+	ami.Spec.SortBy = "creationdatedesc"
+	got, err = ami.getLatestAmiID()
+	require.NoError(t, err)
+	assert.Equal(t, "ami-1", got)
+}
+
+func TestConditionImageIDFilter(t *testing.T) {
+	mock := &recordDescribeImagesInput{
+		Resp: ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{{ImageId: aws.String("ami-3")}},
+		},
+	}
+
+	ami := AMI{apiClient: mock}
+
+	gotResult := result.Condition{}
+	require.NoError(t, ami.Condition("ami-3", nil, &gotResult))
+	assert.True(t, gotResult.Pass)
+	assert.Equal(t, []*ec2.Filter{
+		{Name: aws.String("image-id"), Values: aws.StringSlice([]string{"ami-3"})},
+	}, mock.GotInput.Filters)
+}
@@ -2,7 +2,6 @@ package awsami
 
 import (
 	"errors"
-	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -26,9 +25,8 @@ var (
 
 // AMI contains information to manipulate AWS AMI information
 type AMI struct {
-	Spec       Spec
-	ec2Filters []*ec2.Filter
-	apiClient  ec2iface.EC2API
+	Spec      Spec
+	apiClient ec2iface.EC2API
 }
 
 // New returns a reference to a newly initialized AMI object from an AMISpec
@@ -49,15 +47,6 @@ func New(spec interface{}) (*AMI, error) {
 		return nil, ErrSpecNotValid
 	}
 
-	var newFilters []*ec2.Filter
-	for i := 0; i < len(newSpec.Filters); i++ {
-		filter := ec2.Filter{
-			Name:   aws.String(newSpec.Filters[i].Name),
-			Values: aws.StringSlice(strings.Split(newSpec.Filters[i].Values, ","))}
-
-		newFilters = append(newFilters, &filter)
-	}
-
 	newSession, err := session.NewSession()
 	if err != nil {
 		return nil, err
@@ -89,9 +78,8 @@ func New(spec interface{}) (*AMI, error) {
 	}
 
 	return &AMI{
-		Spec:       newSpec,
-		ec2Filters: newFilters,
-		apiClient:  newClient,
+		Spec:      newSpec,
+		apiClient: newClient,
 	}, nil
 }
 
@@ -17,19 +17,8 @@ func (images ByCreationDateAsc) Len() int {
 
 func (images ByCreationDateAsc) Less(i, j int) bool {
 
-	formatDate := time.RFC3339
-
-	dateI, err := time.Parse(formatDate, *images[i].CreationDate)
-
-	if err != nil {
-		logrus.Errorln(err)
-	}
-
-	dateJ, err := time.Parse(formatDate, *images[j].CreationDate)
-
-	if err != nil {
-		logrus.Errorln(err)
-	}
+	dateI := parseCreationDate(images[i].CreationDate)
+	dateJ := parseCreationDate(images[j].CreationDate)
 
 	return dateI.Before(dateJ)
 }
@@ -48,19 +37,8 @@ func (images ByCreationDateDesc) Len() int {
 
 func (images ByCreationDateDesc) Less(i, j int) bool {
 
-	formatDate := time.RFC3339
-
-	dateI, err := time.Parse(formatDate, *images[i].CreationDate)
-
-	if err != nil {
-		logrus.Errorln(err)
-	}
-
-	dateJ, err := time.Parse(formatDate, *images[j].CreationDate)
-
-	if err != nil {
-		logrus.Errorln(err)
-	}
+	dateI := parseCreationDate(images[i].CreationDate)
+	dateJ := parseCreationDate(images[j].CreationDate)
 
 	return dateI.After(dateJ)
 }
@@ -68,3 +46,20 @@ func (images ByCreationDateDesc) Less(i, j int) bool {
 func (images ByCreationDateDesc) Swap(i, j int) {
 	images[i], images[j] = images[j], images[i]
 }
+
+// parseCreationDate returns the time of an AMI creation date, such as "2020-06-26T10:20:30.000Z"
+func parseCreationDate(creationDate *string) time.Time {
+	if creationDate == nil {
+		return time.Time{}
+	}
+
+	for _, formatDate := range []string{time.RFC3339, "2006-01-02"} {
+		date, err := time.Parse(formatDate, *creationDate)
+		if err == nil {
+			return date
+		}
+	}
+
+	logrus.Errorf("unable to parse AMI creation date %q", *creationDate)
+	return time.Time{}
+}
@@ -19,8 +19,17 @@ type Spec struct {
 	AccessKey string `yaml:",omitempty"`
 	// secretkey specifies the aws secret key which combined with `accesskey`, is one of the way to authenticate
 	SecretKey string `yaml:",omitempty"`
-	// Filters specifies a list of AMI filters
+	// Filters specifies a list of AMI filters, such as "name", "architecture" or "owner-alias"
+	//
+	// example:
+	//   filters:
+	//     - name: "name"
+	//       values: "ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-*"
+	//     - name: "architecture"
+	//       values: "x86_64"
 	Filters Filters `yaml:",omitempty"`
+	// Owners specifies the AMI owners, such as "self", "amazon", "aws-marketplace" or an AWS account ID
+	Owners []string `yaml:",omitempty"`
 	// Region specifies the AWS region to use when looking for AMI
 	Region string `yaml:",omitempty"`
 	// Endpoint specifies the AWS endpoint to use when looking for AMI
@@ -28,6 +37,8 @@ type Spec struct {
 	// Dryrun allows to Check whether you have the required permissions for the action.
 	DryRun bool `yaml:",omitempty"`
 	// Sortby specifies the order of AMI-ID that will be used to retrieve the last element such as `creationdateasc`
+	//
+	// It defaults to `creationdateasc` so the newest AMI is retrieved
 	SortBy string `yaml:",omitempty"`
 }
 
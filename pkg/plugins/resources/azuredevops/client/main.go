@@ -0,0 +1,173 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AZUREDEVOPSURL defines the default Azure DevOps url
+	AZUREDEVOPSURL string = "https://dev.azure.com"
+	// APIVersion defines the Azure DevOps REST api version used by Updatecli
+	APIVersion string = "7.0"
+	// defaultGitUsername is the git username used with personal access tokens, Azure DevOps ignoring it
+	defaultGitUsername string = "updatecli"
+)
+
+// Spec defines a specification for an "Azure DevOps" resource
+// parsed from an updatecli manifest file
+type Spec struct {
+	/*
+		"url" defines the Azure DevOps url to interact with
+
+		default:
+			url defaults to "https://dev.azure.com"
+
+		remark:
+			Azure DevOps Server users must set the collection url such as "https://azuredevops.example.com/tfs"
+	*/
+	URL string `yaml:",omitempty"`
+	/*
+		"organization" defines the Azure DevOps organization, or the collection for Azure DevOps Server
+	*/
+	Organization string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		"username" defines the username used to authenticate with Azure DevOps
+
+		remark:
+			Azure DevOps ignores it when authenticating with a personal access token
+	*/
+	Username string `yaml:",omitempty"`
+	/*
+		"token" defines the personal access token used to authenticate with Azure DevOps
+
+		remark:
+			the personal access token must be granted the "Code (Read & write)" scope,
+			and the "Work Items (Read)" scope to link work items to pull requests
+	*/
+	Token string `yaml:",omitempty"`
+}
+
+// Client is an Azure DevOps REST api client
+type Client struct {
+	// baseURL is the Azure DevOps organization url, such as "https://dev.azure.com/updatecli"
+	baseURL string
+	// spec contains the Azure DevOps credentials
+	spec Spec
+	// httpClient is the http client querying the REST api
+	httpClient *http.Client
+}
+
+// New returns an Azure DevOps REST api client authenticated using the spec personal access token
+func New(s Spec) (Client, error) {
+	err := s.Validate()
+	if err != nil {
+		return Client{}, err
+	}
+
+	return Client{
+		baseURL:    strings.Join([]string{EnsureValidURL(s.URL), s.Organization}, "/"),
+		spec:       s,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Validate validates that a spec contains good content
+func (s Spec) Validate() error {
+
+	if len(s.Organization) == 0 {
+		logrus.Errorf("missing %q parameter", "organization")
+		return fmt.Errorf("wrong configuration")
+	}
+
+	return nil
+}
+
+// EnsureValidURL returns the Azure DevOps url, with a scheme and without trailing slash
+func EnsureValidURL(u string) string {
+	if u == "" {
+		u = AZUREDEVOPSURL
+	}
+
+	if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
+		u = "https://" + u
+	}
+
+	return strings.TrimSuffix(u, "/")
+}
+
+// GitCredentials returns the username and password used to authenticate git operations with Azure DevOps
+func (s Spec) GitCredentials() (username, password string) {
+	username = s.Username
+	if len(username) == 0 && len(s.Token) > 0 {
+		username = defaultGitUsername
+	}
+
+	return username, s.Token
+}
+
+// Do queries the Azure DevOps REST api endpoint, relative to the organization url,
+// sending in and decoding the response into out, if not nil
+func (c Client) Do(ctx context.Context, method, endpoint string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		content, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(content)
+	}
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	URL := fmt.Sprintf("%s/%s%sapi-version=%s", c.baseURL, endpoint, separator, APIVersion)
+
+	req, err := http.NewRequestWithContext(ctx, method, URL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.spec.Token) > 0 {
+		req.SetBasicAuth(c.spec.Username, c.spec.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		logrus.Debugf("RC: %d\nBody:\n%s", resp.StatusCode, content)
+		return fmt.Errorf("%s %s: unexpected return code %d", method, strings.SplitN(endpoint, "?", 2)[0], resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(content, out)
+}
+
+// WebURL returns the Azure DevOps web url of path, relative to the organization url
+func (c Client) WebURL(path string) string {
+	return strings.Join([]string{c.baseURL, path}, "/")
+}
@@ -0,0 +1,87 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+	utils "github.com/updatecli/updatecli/pkg/plugins/utils/action"
+)
+
+// CreateAction opens a Pull Request on Azure DevOps
+func (a *AzureDevOps) CreateAction(report *reports.Action) error {
+
+	title := report.Title
+	if len(a.spec.Title) > 0 {
+		title = a.spec.Title
+	}
+
+	// One Azure DevOps pullrequest description can contain multiple action report
+	// It would be better to refactor CreateAction
+	body, err := utils.GeneratePullRequestBody("", report.ToActionsString())
+	if err != nil {
+		logrus.Warningf("something wrong happened while generating Azure DevOps pullrequest body: %s", err)
+	}
+
+	if len(a.spec.Body) > 0 {
+		body = a.spec.Body
+	}
+
+	// Timeout api queries after 30sec
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Check if a pull-request is already opened then exit early if it does.
+	exist, err := a.isPullRequestExist(ctx)
+	if err != nil {
+		return fmt.Errorf("check if an Azure DevOps pullrequest already exist: %s", err.Error())
+	}
+
+	if exist {
+		return nil
+	}
+
+	// Test that both sourceBranch and targetBranch exists on remote before creating a new one
+	ok, err := a.isRemoteBranchesExist(ctx)
+	if err != nil {
+		return fmt.Errorf("check if remote branches exist: %s", err.Error())
+	}
+
+	/*
+		Due to the following scenario, Updatecli always tries to open a pullrequest
+			* A pullrequest has been "manually" closed via UI
+			* A previous Updatecli run failed during a pullrequest creation for example due to network issues
+
+
+		Therefore we always try to open a pullrequest, we don't consider being an error if all conditions are not met
+		such as missing remote branches.
+	*/
+	if !ok {
+		logrus.Debugln("skipping pullrequest creation")
+		return nil
+	}
+
+	input := a.newPullRequestInput(title, body)
+
+	logrus.Debugf("Title:\t%q\nBody:\t%v\nSource branch:\t%q\nTarget branch:\t%q\nReviewers:\t%v\nWork items:\t%v\n",
+		title,
+		body,
+		a.SourceBranch,
+		a.TargetBranch,
+		a.spec.Reviewers,
+		a.spec.WorkItems)
+
+	pr := pullRequestApi{}
+	err = a.client.Do(ctx, "POST", a.repositoryEndpoint("pullrequests"), input, &pr)
+	if err != nil {
+		return fmt.Errorf("create Azure DevOps pullrequest: %v", err)
+	}
+
+	report.Link = a.pullRequestLink(pr.PullRequestID)
+	logrus.Infof("Azure DevOps pullrequest successfully opened on %q", report.Link)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package pullrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestCreateAction(t *testing.T) {
+	testData := []struct {
+		name        string
+		spec        map[string]interface{}
+		existingPR  bool
+		refs        []string
+		wantCreated *pullRequestApi
+	}{
+		{
+			name: "Open a new pullrequest",
+			refs: []string{"refs/heads/main", "refs/heads/updatecli_xxx"},
+			wantCreated: &pullRequestApi{
+				SourceRefName: "refs/heads/updatecli_xxx",
+				TargetRefName: "refs/heads/main",
+				Title:         "Bump version",
+				Description:   "Changelog",
+			},
+		},
+		{
+			name: "Open a new draft pullrequest with reviewers and work items",
+			spec: map[string]interface{}{
+				"reviewers": []string{"d6245f20-2af8-44f4-9451-8107cb2767db"},
+				"workitems": []int{42},
+				"draft":     true,
+			},
+			refs: []string{"refs/heads/main", "refs/heads/updatecli_xxx"},
+			wantCreated: &pullRequestApi{
+				SourceRefName: "refs/heads/updatecli_xxx",
+				TargetRefName: "refs/heads/main",
+				Title:         "Bump version",
+				Description:   "Changelog",
+				IsDraft:       true,
+				Reviewers:     []refIDApi{{ID: "d6245f20-2af8-44f4-9451-8107cb2767db"}},
+				WorkItemRefs:  []refIDApi{{ID: "42"}},
+			},
+		},
+		{
+			name:       "Pullrequest already opened",
+			existingPR: true,
+			refs:       []string{"refs/heads/main", "refs/heads/updatecli_xxx"},
+		},
+		{
+			name: "Source branch not pushed",
+			refs: []string{"refs/heads/main", "refs/heads/updatecli_xxx_old"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *pullRequestApi
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				repositoryPath := "/updatecli/website/_apis/git/repositories/website"
+				w.Header().Set("Content-Type", "application/json")
+
+				assert.Equal(t, "7.0", r.URL.Query().Get("api-version"))
+				_, token, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "pat", token)
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == repositoryPath+"/pullrequests":
+					assert.Equal(t, "refs/heads/updatecli_xxx", r.URL.Query().Get("searchCriteria.sourceRefName"))
+					assert.Equal(t, "refs/heads/main", r.URL.Query().Get("searchCriteria.targetRefName"))
+					assert.Equal(t, "active", r.URL.Query().Get("searchCriteria.status"))
+
+					pullrequests := listApi[pullRequestApi]{}
+					if tt.existingPR {
+						pullrequests.Value = append(pullrequests.Value, pullRequestApi{PullRequestID: 7, Status: "active"})
+					}
+					require.NoError(t, json.NewEncoder(w).Encode(pullrequests))
+				case r.Method == http.MethodGet && r.URL.Path == repositoryPath+"/refs":
+					refs := listApi[refApi]{}
+					for _, ref := range tt.refs {
+						refs.Value = append(refs.Value, refApi{Name: ref})
+					}
+					require.NoError(t, json.NewEncoder(w).Encode(refs))
+				case r.Method == http.MethodPost && r.URL.Path == repositoryPath+"/pullrequests":
+					created = &pullRequestApi{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(created))
+					require.NoError(t, json.NewEncoder(w).Encode(pullRequestApi{PullRequestID: 8, Status: "active"}))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			// Specs are decoded from the manifest settings
+			spec := map[string]interface{}{
+				"url":          server.URL,
+				"organization": "updatecli",
+				"token":        "pat",
+				"project":      "website",
+				"repository":   "website",
+				"sourcebranch": "updatecli_xxx",
+				"targetbranch": "main",
+				"title":        "Bump version",
+				"body":         "Changelog",
+			}
+			for key, value := range tt.spec {
+				spec[key] = value
+			}
+
+			a, err := New(spec, nil)
+			require.NoError(t, err)
+
+			require.NoError(t, a.CreateAction(&reports.Action{Title: "Report"}))
+
+			assert.Equal(t, tt.wantCreated, created)
+		})
+	}
+}
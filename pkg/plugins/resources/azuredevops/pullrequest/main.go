@@ -0,0 +1,156 @@
+package pullrequest
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/azuredevops/client"
+	azuredevopsscm "github.com/updatecli/updatecli/pkg/plugins/scms/azuredevops"
+)
+
+// Spec defines settings used to interact with Azure DevOps pullrequest
+// It's a mapping of user input from a Updatecli manifest and it shouldn't modified
+type Spec struct {
+	client.Spec `yaml:",inline,omitempty"`
+	/*
+		"sourcebranch" defines the branch name used as a source to create the Azure DevOps pullrequest.
+
+		default:
+			"sourcebranch" inherits the value from the scm working branch if a scm of kind "azuredevops" is specified by the action.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	SourceBranch string `yaml:",omitempty"`
+	/*
+		"targetbranch" defines the branch name used as a target to create the Azure DevOps pullrequest.
+
+		default:
+			"targetbranch" inherits the value from the scm branch if a scm of kind "azuredevops" is specified by the action.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	TargetBranch string `yaml:",omitempty"`
+	/*
+		"project" defines the Azure DevOps project containing the repository.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	Project string `yaml:",omitempty"`
+	/*
+		"repository" defines the Azure DevOps repository for a specific project
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	Repository string `yaml:",omitempty"`
+	/*
+		"title" defines the Azure DevOps pullrequest title
+
+		default:
+			the action title, otherwise the first associated target title, otherwise the pipeline title
+	*/
+	Title string `yaml:",omitempty"`
+	/*
+		"body" defines a custom pullrequest description
+
+		default:
+			By default a pullrequest description is generated out of a pipeline execution.
+
+		remark:
+			Unless you know what you are doing, you shouldn't set this value and rely on the sane default.
+	*/
+	Body string `yaml:",omitempty"`
+	/*
+		"reviewers" defines the identity ids, of users or groups, added as reviewers of the pullrequest
+
+		example:
+			* 3b4a4d2c-8f4d-4b1c-9a40-3c1e3f3c5b8e
+	*/
+	Reviewers []string `yaml:",omitempty"`
+	/*
+		"workitems" defines the ids of the work items linked to the pullrequest
+
+		example:
+			* 42
+	*/
+	WorkItems []int `yaml:",omitempty"`
+	/*
+		"draft" opens the pullrequest as a draft
+
+		default:
+			false
+	*/
+	Draft bool `yaml:",omitempty"`
+}
+
+// AzureDevOps contains information to interact with Azure DevOps api
+type AzureDevOps struct {
+	// spec contains inputs coming from updatecli configuration
+	spec Spec
+	// client handle the api authentication
+	client client.Client
+	// scm allows to interact with a scm object
+	scm *azuredevopsscm.AzureDevOps
+	// SourceBranch specifies the pullrequest source branch.
+	SourceBranch string `yaml:",inline,omitempty"`
+	// TargetBranch specifies the pullrequest target branch
+	TargetBranch string `yaml:",inline,omitempty"`
+	// Project specifies the project containing the repository
+	Project string `yaml:",omitempty" jsonschema:"required"`
+	// Repository specifies the name of a repository for a specific project
+	Repository string `yaml:",omitempty" jsonschema:"required"`
+}
+
+// New returns a new valid Azure DevOps object.
+func New(spec interface{}, scm *azuredevopsscm.AzureDevOps) (AzureDevOps, error) {
+
+	var clientSpec client.Spec
+	var s Spec
+
+	// mapstructure.Decode cannot handle embedded fields
+	// hence we decode it in two steps
+	err := mapstructure.Decode(spec, &clientSpec)
+	if err != nil {
+		return AzureDevOps{}, err
+	}
+
+	err = mapstructure.Decode(spec, &s)
+	if err != nil {
+		return AzureDevOps{}, err
+	}
+
+	if scm != nil {
+
+		if len(clientSpec.URL) == 0 && len(scm.Spec.URL) > 0 {
+			clientSpec.URL = scm.Spec.URL
+		}
+
+		if len(clientSpec.Organization) == 0 && len(scm.Spec.Organization) > 0 {
+			clientSpec.Organization = scm.Spec.Organization
+		}
+
+		if len(clientSpec.Token) == 0 && len(scm.Spec.Token) > 0 {
+			clientSpec.Token = scm.Spec.Token
+		}
+
+		if len(clientSpec.Username) == 0 && len(scm.Spec.Username) > 0 {
+			clientSpec.Username = scm.Spec.Username
+		}
+	}
+
+	c, err := client.New(clientSpec)
+	if err != nil {
+		return AzureDevOps{}, err
+	}
+
+	a := AzureDevOps{
+		spec:   s,
+		client: c,
+		scm:    scm,
+	}
+
+	a.inheritFromScm()
+
+	return a, nil
+}
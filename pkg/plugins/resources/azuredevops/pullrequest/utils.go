@@ -0,0 +1,161 @@
+package pullrequest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// pullRequestApi contains the Azure DevOps pullrequest fields used by Updatecli
+type pullRequestApi struct {
+	PullRequestID int        `json:"pullRequestId,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	SourceRefName string     `json:"sourceRefName"`
+	TargetRefName string     `json:"targetRefName"`
+	Title         string     `json:"title,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	IsDraft       bool       `json:"isDraft,omitempty"`
+	Reviewers     []refIDApi `json:"reviewers,omitempty"`
+	WorkItemRefs  []refIDApi `json:"workItemRefs,omitempty"`
+}
+
+// refIDApi references an Azure DevOps identity or work item by id
+type refIDApi struct {
+	ID string `json:"id"`
+}
+
+// refApi contains the Azure DevOps git reference fields used by Updatecli
+type refApi struct {
+	Name string `json:"name"`
+}
+
+// listApi is the Azure DevOps envelope of lists
+type listApi[T any] struct {
+	Value []T `json:"value"`
+	Count int `json:"count"`
+}
+
+// repositoryEndpoint returns the Azure DevOps api endpoint of the repository resource path
+func (a *AzureDevOps) repositoryEndpoint(path string) string {
+	return fmt.Sprintf("%s/_apis/git/repositories/%s/%s",
+		url.PathEscape(a.Project),
+		url.PathEscape(a.Repository),
+		path)
+}
+
+// pullRequestLink returns the Azure DevOps web url of the pullrequest id
+func (a *AzureDevOps) pullRequestLink(id int) string {
+	return a.client.WebURL(fmt.Sprintf("%s/_git/%s/pullrequest/%d",
+		url.PathEscape(a.Project),
+		url.PathEscape(a.Repository),
+		id))
+}
+
+// isPullRequestExist queries Azure DevOps to know if a pullrequest already exists.
+func (a *AzureDevOps) isPullRequestExist(ctx context.Context) (bool, error) {
+	query := url.Values{}
+	query.Set("searchCriteria.sourceRefName", "refs/heads/"+a.SourceBranch)
+	query.Set("searchCriteria.targetRefName", "refs/heads/"+a.TargetBranch)
+	query.Set("searchCriteria.status", "active")
+
+	pullrequests := listApi[pullRequestApi]{}
+	err := a.client.Do(ctx, "GET", a.repositoryEndpoint("pullrequests?"+query.Encode()), nil, &pullrequests)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range pullrequests.Value {
+		logrus.Infof("%s Nothing else to do, our pullrequest already exist on:\n\t%s",
+			result.SUCCESS,
+			a.pullRequestLink(p.PullRequestID))
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// isRemoteBranchesExist queries Azure DevOps to know if both the pull-request source branch and the target branch exist.
+func (a *AzureDevOps) isRemoteBranchesExist(ctx context.Context) (bool, error) {
+
+	for _, branch := range []string{a.SourceBranch, a.TargetBranch} {
+		query := url.Values{}
+		query.Set("filter", "heads/"+branch)
+
+		refs := listApi[refApi]{}
+		err := a.client.Do(ctx, "GET", a.repositoryEndpoint("refs?"+query.Encode()), nil, &refs)
+		if err != nil {
+			return false, err
+		}
+
+		found := false
+		// The filter matches every reference starting with the branch name
+		for _, ref := range refs.Value {
+			if ref.Name == "refs/heads/"+branch {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			logrus.Debugf("Branch %q not found on remote repository %s/%s",
+				branch,
+				a.Project,
+				a.Repository)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// newPullRequestInput returns the Azure DevOps pullrequest to create
+func (a *AzureDevOps) newPullRequestInput(title, body string) pullRequestApi {
+	input := pullRequestApi{
+		SourceRefName: "refs/heads/" + a.SourceBranch,
+		TargetRefName: "refs/heads/" + a.TargetBranch,
+		Title:         title,
+		Description:   body,
+		IsDraft:       a.spec.Draft,
+	}
+
+	for _, reviewer := range a.spec.Reviewers {
+		input.Reviewers = append(input.Reviewers, refIDApi{ID: reviewer})
+	}
+
+	for _, workItem := range a.spec.WorkItems {
+		input.WorkItemRefs = append(input.WorkItemRefs, refIDApi{ID: strconv.Itoa(workItem)})
+	}
+
+	return input
+}
+
+// inheritFromScm retrieve missing Azure DevOps settings from the Azure DevOps scm object.
+func (a *AzureDevOps) inheritFromScm() {
+
+	if a.scm != nil {
+		_, a.SourceBranch, a.TargetBranch = a.scm.GetBranches()
+		a.Project = a.scm.Spec.Project
+		a.Repository = a.scm.Spec.Repository
+	}
+
+	if len(a.spec.SourceBranch) > 0 {
+		a.SourceBranch = a.spec.SourceBranch
+	}
+
+	if len(a.spec.TargetBranch) > 0 {
+		a.TargetBranch = a.spec.TargetBranch
+	}
+
+	if len(a.spec.Project) > 0 {
+		a.Project = a.spec.Project
+	}
+
+	if len(a.spec.Repository) > 0 {
+		a.Repository = a.spec.Repository
+	}
+}
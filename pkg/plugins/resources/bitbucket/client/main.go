@@ -0,0 +1,85 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/drone/go-scm/scm/driver/bitbucket"
+	"github.com/drone/go-scm/scm/transport"
+	"github.com/drone/go-scm/scm/transport/oauth2"
+)
+
+const (
+	// BITBUCKETDOMAIN defines the Bitbucket Cloud domain hosting git repositories
+	BITBUCKETDOMAIN string = "bitbucket.org"
+	// BITBUCKETAPIURL defines the Bitbucket Cloud api url
+	BITBUCKETAPIURL string = "https://api.bitbucket.org"
+	// tokenGitUsername is the git username used to authenticate with a Bitbucket access token
+	tokenGitUsername string = "x-token-auth"
+)
+
+// Spec defines a specification for a "Bitbucket Cloud" resource
+// parsed from an updatecli manifest file
+type Spec struct {
+	/*
+		"username" defines the username used to authenticate with Bitbucket Cloud, together with "password"
+	*/
+	Username string `yaml:",omitempty"`
+	/*
+		"password" defines the Bitbucket Cloud app password used to authenticate with Bitbucket Cloud
+
+		remark:
+			the app password must be granted the "repositories:write" and "pullrequests:write" permissions
+	*/
+	Password string `yaml:",omitempty"`
+	/*
+		"token" defines the repository, project, or workspace access token used to authenticate with Bitbucket Cloud
+
+		remark:
+			"token" takes precedence over "username" and "password"
+	*/
+	Token string `yaml:",omitempty"`
+}
+
+type Client *scm.Client
+
+// New returns a Bitbucket Cloud api client authenticated using the spec credentials
+func New(s Spec) (Client, error) {
+	client, err := bitbucket.New(BITBUCKETAPIURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Client = &http.Client{}
+
+	switch {
+	case len(s.Token) > 0:
+		client.Client = &http.Client{
+			Transport: &oauth2.Transport{
+				Source: oauth2.StaticTokenSource(
+					&scm.Token{
+						Token: s.Token,
+					},
+				),
+			},
+		}
+	case len(s.Password) > 0:
+		client.Client = &http.Client{
+			Transport: &transport.BasicAuth{
+				Username: s.Username,
+				Password: s.Password,
+			},
+		}
+	}
+
+	return client, nil
+}
+
+// GitCredentials returns the username and password used to authenticate git operations with Bitbucket Cloud
+func (s Spec) GitCredentials() (username, password string) {
+	if len(s.Token) > 0 {
+		return tokenGitUsername, s.Token
+	}
+
+	return s.Username, s.Password
+}
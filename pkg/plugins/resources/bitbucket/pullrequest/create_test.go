@@ -0,0 +1,101 @@
+package pullrequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drone/go-scm/scm/driver/bitbucket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestCreateAction(t *testing.T) {
+	branch := func(name string) map[string]interface{} {
+		return map[string]interface{}{"branch": map[string]interface{}{"name": name}}
+	}
+
+	testData := []struct {
+		name        string
+		existingPR  bool
+		branches    []map[string]string
+		wantCreated bool
+	}{
+		{
+			name:        "Open a new pullrequest",
+			branches:    []map[string]string{{"name": "main"}, {"name": "updatecli_xxx"}},
+			wantCreated: true,
+		},
+		{
+			name:       "Pullrequest already opened",
+			existingPR: true,
+			branches:   []map[string]string{{"name": "main"}, {"name": "updatecli_xxx"}},
+		},
+		{
+			name:     "Source branch not pushed",
+			branches: []map[string]string{{"name": "main"}},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]interface{}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				repositoryPath := "/2.0/repositories/updatecli/updatecli"
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == repositoryPath+"/pullrequests":
+					pullrequests := []map[string]interface{}{}
+					if tt.existingPR {
+						pullrequests = append(pullrequests, map[string]interface{}{
+							"id":          7,
+							"state":       "OPEN",
+							"source":      branch("updatecli_xxx"),
+							"destination": branch("main"),
+						})
+					}
+					require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"values": pullrequests}))
+				case r.Method == http.MethodGet && r.URL.Path == repositoryPath+"/refs/branches":
+					require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"values": tt.branches}))
+				case r.Method == http.MethodPost && r.URL.Path == repositoryPath+"/pullrequests":
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+					require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"id": 8, "state": "OPEN"}))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			b, err := New(map[string]interface{}{
+				"owner":        "updatecli",
+				"repository":   "updatecli",
+				"sourcebranch": "updatecli_xxx",
+				"targetbranch": "main",
+				"title":        "Bump version",
+				"body":         "Changelog",
+			}, nil)
+			require.NoError(t, err)
+
+			client, err := bitbucket.New(server.URL)
+			require.NoError(t, err)
+			b.client = client
+
+			require.NoError(t, b.CreateAction(&reports.Action{Title: "Report"}))
+
+			if !tt.wantCreated {
+				assert.Nil(t, created)
+				return
+			}
+
+			assert.Equal(t, "Bump version", created["title"])
+			assert.Equal(t, "Changelog", created["description"])
+			assert.Equal(t, branch("updatecli_xxx"), created["source"])
+			assert.Equal(t, branch("main"), created["destination"])
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package pullrequest
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/bitbucket/client"
+	bitbucketscm "github.com/updatecli/updatecli/pkg/plugins/scms/bitbucket"
+)
+
+// Spec defines settings used to interact with Bitbucket Cloud pullrequest
+// It's a mapping of user input from a Updatecli manifest and it shouldn't modified
+type Spec struct {
+	client.Spec `yaml:",inline,omitempty"`
+	/*
+		"sourcebranch" defines the branch name used as a source to create the Bitbucket pullrequest.
+
+		default:
+			"sourcebranch" inherits the value from the scm working branch if a scm of kind "bitbucket" is specified by the action.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	SourceBranch string `yaml:",omitempty"`
+	/*
+		"targetbranch" defines the branch name used as a target to create the Bitbucket pullrequest.
+
+		default:
+			"targetbranch" inherits the value from the scm branch if a scm of kind "bitbucket" is specified by the action.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	TargetBranch string `yaml:",omitempty"`
+	/*
+		"owner" defines the Bitbucket Cloud workspace owning the repository.
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	Owner string `yaml:",omitempty"`
+	/*
+		"repository" defines the Bitbucket repository for a specific owner
+
+		remark:
+			unless you know what you are doing, you shouldn't set this value and rely on the scmid to provide the sane default.
+	*/
+	Repository string `yaml:",omitempty"`
+	/*
+		"title" defines the Bitbucket pullrequest title
+
+		default:
+			the action title, otherwise the first associated target title, otherwise the pipeline title
+	*/
+	Title string `yaml:",omitempty"`
+	/*
+		"body" defines a custom pullrequest body
+
+		default:
+			By default a pullrequest body is generated out of a pipeline execution.
+
+		remark:
+			Unless you know what you are doing, you shouldn't set this value and rely on the sane default.
+	*/
+	Body string `yaml:",omitempty"`
+}
+
+// Bitbucket contains information to interact with Bitbucket Cloud api
+type Bitbucket struct {
+	// spec contains inputs coming from updatecli configuration
+	spec Spec
+	// client handle the api authentication
+	client client.Client
+	// scm allows to interact with a scm object
+	scm *bitbucketscm.Bitbucket
+	// SourceBranch specifies the pullrequest source branch.
+	SourceBranch string `yaml:",inline,omitempty"`
+	// TargetBranch specifies the pullrequest target branch
+	TargetBranch string `yaml:",inline,omitempty"`
+	// Owner specifies repository owner
+	Owner string `yaml:",omitempty" jsonschema:"required"`
+	// Repository specifies the name of a repository for a specific owner
+	Repository string `yaml:",omitempty" jsonschema:"required"`
+}
+
+// New returns a new valid Bitbucket Cloud object.
+func New(spec interface{}, scm *bitbucketscm.Bitbucket) (Bitbucket, error) {
+
+	var clientSpec client.Spec
+	var s Spec
+
+	// mapstructure.Decode cannot handle embedded fields
+	// hence we decode it in two steps
+	err := mapstructure.Decode(spec, &clientSpec)
+	if err != nil {
+		return Bitbucket{}, err
+	}
+
+	err = mapstructure.Decode(spec, &s)
+	if err != nil {
+		return Bitbucket{}, err
+	}
+
+	if scm != nil && clientSpec == (client.Spec{}) {
+		clientSpec = scm.Spec.Spec
+	}
+
+	c, err := client.New(clientSpec)
+	if err != nil {
+		return Bitbucket{}, err
+	}
+
+	b := Bitbucket{
+		spec:   s,
+		client: c,
+		scm:    scm,
+	}
+
+	b.inheritFromScm()
+
+	return b, nil
+}
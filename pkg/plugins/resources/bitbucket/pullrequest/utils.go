@@ -0,0 +1,164 @@
+package pullrequest
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// isPullRequestExist queries Bitbucket Cloud to know if a pullrequest already exists.
+func (b *Bitbucket) isPullRequestExist() (bool, error) {
+	ctx := context.Background()
+	// Timeout api query after 30sec
+	ctx, cancelList := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelList()
+
+	page := 1
+	for {
+		optsSearch := scm.PullRequestListOptions{
+			Page:   page,
+			Size:   30,
+			Open:   true,
+			Closed: false,
+		}
+
+		pullrequests, resp, err := b.client.PullRequests.List(
+			ctx,
+			strings.Join([]string{
+				b.Owner,
+				b.Repository}, "/"),
+			optsSearch,
+		)
+
+		if err != nil {
+			if resp != nil {
+				logrus.Debugf("RC: %d\nBody:\n%s", resp.Status, resp.Body)
+			}
+			return false, err
+		}
+
+		if resp.Status > 400 {
+			logrus.Debugf("RC: %d\nBody:\n%s", resp.Status, resp.Body)
+		}
+
+		for _, p := range pullrequests {
+			if p.Source == b.SourceBranch &&
+				p.Target == b.TargetBranch &&
+				!p.Closed &&
+				!p.Merged {
+
+				logrus.Infof("%s Nothing else to do, our pullrequest already exist on:\n\t%s",
+					result.SUCCESS,
+					p.Link)
+
+				return true, nil
+			}
+		}
+
+		page = resp.Page.Next
+		if page == 0 {
+			break
+		}
+	}
+
+	return false, nil
+}
+
+// isRemoteBranchesExist queries Bitbucket Cloud to know if both the pull-request source branch and the target branch exist.
+func (b *Bitbucket) isRemoteBranchesExist() (bool, error) {
+
+	// Timeout api query after 30sec
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	foundRemoteSourceBranch := false
+	foundRemoteTargetBranch := false
+
+	page := 1
+	for {
+		remoteBranches, resp, err := b.client.Git.ListBranches(
+			ctx,
+			strings.Join([]string{b.Owner, b.Repository}, "/"),
+			scm.ListOptions{
+				Page: page,
+				Size: 30,
+			},
+		)
+
+		if err != nil {
+			if resp != nil {
+				logrus.Debugf("RC: %d\nBody:\n%s", resp.Status, resp.Body)
+			}
+			return false, err
+		}
+
+		if resp.Status > 400 {
+			logrus.Debugf("RC: %d\nBody:\n%s", resp.Status, resp.Body)
+		}
+
+		for _, remoteBranch := range remoteBranches {
+			if remoteBranch.Name == b.SourceBranch {
+				foundRemoteSourceBranch = true
+			}
+			if remoteBranch.Name == b.TargetBranch {
+				foundRemoteTargetBranch = true
+			}
+
+			if foundRemoteSourceBranch && foundRemoteTargetBranch {
+				return true, nil
+			}
+		}
+
+		page = resp.Page.Next
+		if page == 0 {
+			break
+		}
+	}
+
+	if !foundRemoteSourceBranch {
+		logrus.Debugf("Branch %q not found on remote repository %s/%s",
+			b.SourceBranch,
+			b.Owner,
+			b.Repository)
+	}
+
+	if !foundRemoteTargetBranch {
+		logrus.Debugf("Branch %q not found on remote repository %s/%s",
+			b.TargetBranch,
+			b.Owner,
+			b.Repository)
+	}
+
+	return false, nil
+}
+
+// inheritFromScm retrieve missing Bitbucket settings from the Bitbucket scm object.
+func (b *Bitbucket) inheritFromScm() {
+
+	if b.scm != nil {
+		_, b.SourceBranch, b.TargetBranch = b.scm.GetBranches()
+		b.Owner = b.scm.Spec.Owner
+		b.Repository = b.scm.Spec.Repository
+	}
+
+	if len(b.spec.SourceBranch) > 0 {
+		b.SourceBranch = b.spec.SourceBranch
+	}
+
+	if len(b.spec.TargetBranch) > 0 {
+		b.TargetBranch = b.spec.TargetBranch
+	}
+
+	if len(b.spec.Owner) > 0 {
+		b.Owner = b.spec.Owner
+	}
+
+	if len(b.spec.Repository) > 0 {
+		b.Repository = b.spec.Repository
+	}
+}
@@ -7,11 +7,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+
 	"github.com/updatecli/updatecli/pkg/plugins/utils/cargo"
 
 	"github.com/mitchellh/mapstructure"
@@ -23,6 +27,8 @@ import (
 const (
 	// URL of the default Crates index api
 	cratesDefaultIndexApiUrl string = "https://crates.io/api/v1/crates"
+	// sparseIndexPrefix identifies a registry url using the sparse index protocol, such as "sparse+https://index.crates.io/"
+	sparseIndexPrefix string = "sparse+"
 )
 
 // CargoPackage defines a resource of type "cargopackage"
@@ -85,8 +91,10 @@ func New(spec interface{}, isSCM bool) (*CargoPackage, error) {
 		return nil, err
 	}
 
-	webClient := http.DefaultClient
-	webClient.Transport = httpclient.NewThrottledTransport(1*time.Second, 1, http.DefaultTransport)
+	// Don't alter http.DefaultClient which is shared by every resource
+	webClient := &http.Client{
+		Transport: httpclient.NewThrottledTransport(1*time.Second, 1, http.DefaultTransport),
+	}
 	newResource := &CargoPackage{
 		spec:          newSpec,
 		versionFilter: newFilter,
@@ -125,7 +133,7 @@ func (cp *CargoPackage) getVersions() (v string, versions []string, err error) {
 		// No versions found
 		return "", versions, nil
 	}
-	sort.Strings(versions)
+	sortVersions(versions)
 	cp.foundVersion, err = cp.versionFilter.Search(versions)
 	if err != nil {
 		return "", nil, err
@@ -161,13 +169,7 @@ func (cp *CargoPackage) getPackageDataFromApi(name string, indexUrl string) (Pac
 		return PackageData{}, err
 	}
 
-	if cp.registry.Auth.Token != "" {
-		format := "Bearer %s"
-		if cp.registry.Auth.HeaderFormat != "" {
-			format = cp.registry.Auth.HeaderFormat
-		}
-		req.Header.Set("Authorization", fmt.Sprintf(format, cp.registry.Auth.Token))
-	}
+	cp.setAuthorization(req)
 
 	res, err := cp.webClient.Do(req)
 	if err != nil {
@@ -176,6 +178,15 @@ func (cp *CargoPackage) getPackageDataFromApi(name string, indexUrl string) (Pac
 	}
 	defer res.Body.Close()
 
+	// Unknown packages are reported without versions, like with a filesystem index
+	if res.StatusCode == http.StatusNotFound {
+		return PackageData{}, nil
+	}
+
+	if res.StatusCode >= 400 {
+		return PackageData{}, fmt.Errorf("cargo registry %q returned %q", packageUrl, res.Status)
+	}
+
 	var d PackageData
 	err = json.NewDecoder(res.Body).Decode(&d)
 	if err != nil && err != io.EOF {
@@ -205,21 +216,114 @@ func (cp *CargoPackage) getPackageDataFromFS(name string, indexDir string) (Pack
 		}
 	}(packageInfoFile)
 
-	scanner := bufio.NewScanner(packageInfoFile)
+	pd.Versions = parseIndexFile(packageInfoFile)
+	return pd, nil
+}
+
+// getPackageDataFromSparseIndex retrieves the package index file from a registry using the sparse index protocol
+// as explained on https://doc.rust-lang.org/cargo/reference/registry-index.html#sparse-protocol
+func (cp *CargoPackage) getPackageDataFromSparseIndex(name string, indexUrl string) (PackageData, error) {
+	var pd PackageData
+	pd.Crate.Name = name
+
+	// Index files are named after the lowercased package name
+	packageDir, err := getPackageFileDir(strings.ToLower(name))
+	if err != nil {
+		logrus.Errorf("something went wrong while getting the package directory from its name %q\n", err)
+		return pd, err
+	}
+
+	packageUrl, err := url.JoinPath(indexUrl, packageDir, strings.ToLower(name))
+	if err != nil {
+		return pd, err
+	}
+
+	req, err := http.NewRequest("GET", packageUrl, nil)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting cargo sparse index data %q\n", err)
+		return pd, err
+	}
+
+	cp.setAuthorization(req)
+
+	res, err := cp.webClient.Do(req)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting cargo sparse index data %q\n", err)
+		return pd, err
+	}
+	defer res.Body.Close()
+
+	// Unknown packages are reported without versions, like with a filesystem index
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return pd, nil
+	}
+
+	if res.StatusCode >= 400 {
+		return pd, fmt.Errorf("cargo sparse index %q returned %q", packageUrl, res.Status)
+	}
+
+	pd.Versions = parseIndexFile(res.Body)
+	return pd, nil
+}
+
+// parseIndexFile returns the versions, not yanked, of a registry index file
+// containing one JSON object per line and version
+func parseIndexFile(r io.Reader) []PackageVersion {
+	var versions []PackageVersion
+
+	scanner := bufio.NewScanner(r)
+	// Index file lines can be longer than the default scanner buffer
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
 	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
 		var packageVersion PackageVersion
-		err = json.Unmarshal(scanner.Bytes(), &packageVersion)
+		err := json.Unmarshal(scanner.Bytes(), &packageVersion)
 		if err != nil {
 			logrus.Errorf("something went wrong while parsing the version %q\n", err)
+			continue
 		}
 		if packageVersion.Yanked {
 			continue
 		}
 		// File index store version info in Version Field
 		packageVersion.Num = packageVersion.Version
-		pd.Versions = append(pd.Versions, packageVersion)
+		versions = append(versions, packageVersion)
 	}
-	return pd, nil
+
+	return versions
+}
+
+// setAuthorization sets the registry authentication header on req, if a token is defined
+func (cp *CargoPackage) setAuthorization(req *http.Request) {
+	if cp.registry.Auth.Token == "" {
+		return
+	}
+
+	format := "Bearer %s"
+	if cp.registry.Auth.HeaderFormat != "" {
+		format = cp.registry.Auth.HeaderFormat
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(format, cp.registry.Auth.Token))
+}
+
+// sortVersions sorts versions from the oldest to the newest according to semantic versioning,
+// versions not following semantic versioning sorting before the other ones
+func sortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := semver.NewVersion(versions[i])
+		vj, errJ := semver.NewVersion(versions[j])
+
+		switch {
+		case errI == nil && errJ == nil:
+			return vi.LessThan(vj)
+		case errI != nil && errJ != nil:
+			return versions[i] < versions[j]
+		}
+		return errI != nil
+	})
 }
 
 // Get package data from Json API
@@ -227,5 +331,8 @@ func (cp *CargoPackage) getPackageData() (PackageData, error) {
 	if cp.registry.RootDir != "" {
 		return cp.getPackageDataFromFS(cp.spec.Package, cp.registry.RootDir)
 	}
+	if strings.HasPrefix(cp.registry.URL, sparseIndexPrefix) {
+		return cp.getPackageDataFromSparseIndex(cp.spec.Package, strings.TrimPrefix(cp.registry.URL, sparseIndexPrefix))
+	}
 	return cp.getPackageDataFromApi(cp.spec.Package, cp.registry.URL)
 }
@@ -183,3 +183,9 @@ func TestRegistrySettings(t *testing.T) {
 		})
 	}
 }
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"0.10.0", "0.9.0", "1.0.0-rc.1", "1.0.0", "0.2.2"}
+	sortVersions(versions)
+	assert.Equal(t, []string{"0.2.2", "0.9.0", "0.10.0", "1.0.0-rc.1", "1.0.0"}, versions)
+}
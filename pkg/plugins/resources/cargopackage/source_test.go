@@ -1,6 +1,9 @@
 package cargopackage
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -165,3 +168,83 @@ func TestSource(t *testing.T) {
 	}
 
 }
+
+func TestSourceSparseIndex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "mytoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/index/cr/at/crate-test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for _, v := range []string{"0.9.0", "0.10.0", "0.11.0"} {
+			fmt.Fprintf(w, "{\"name\":\"crate-test\",\"vers\":%q,\"deps\":[],\"yanked\":%t}\n", v, v == "0.11.0")
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		spec           Spec
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name: "Passing case of retrieving crate-test latest version from a sparse index",
+			spec: Spec{
+				Registry: cargo.Registry{
+					URL: "sparse+" + server.URL + "/index/",
+					Auth: cargo.InlineKeyChain{
+						Token:        "mytoken",
+						HeaderFormat: "%s",
+					},
+				},
+				Package: "Crate-Test",
+			},
+			expectedResult: "0.10.0",
+		},
+		{
+			name: "Failing case of retrieving nonexistent package from a sparse index",
+			spec: Spec{
+				Registry: cargo.Registry{
+					URL: "sparse+" + server.URL + "/index/",
+					Auth: cargo.InlineKeyChain{
+						Token:        "mytoken",
+						HeaderFormat: "%s",
+					},
+				},
+				Package: "crate-test-nonexistent",
+			},
+			expectedError: true,
+		},
+		{
+			name: "Failing case of retrieving crate-test from a sparse index with bad auth",
+			spec: Spec{
+				Registry: cargo.Registry{
+					URL: "sparse+" + server.URL + "/index/",
+					Auth: cargo.InlineKeyChain{
+						Token: "bad token",
+					},
+				},
+				Package: "crate-test",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.spec, false)
+			require.NoError(t, err)
+			gotResult := result.Source{}
+			err = got.Source("", &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
@@ -1,14 +1,17 @@
 package csv
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	das "github.com/tomwright/dasel"
 
 	"github.com/tomwright/dasel/storage"
+	"github.com/updatecli/updatecli/pkg/core/text"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/dasel"
 )
 
@@ -18,6 +21,8 @@ type csvContent struct {
 	csvDocument storage.CSVDocument
 	comma       rune
 	comment     rune
+	// rawContent contains the csv file content as read from disk
+	rawContent string
 }
 
 func (c *csvContent) Read(rootDir string) error {
@@ -34,6 +39,8 @@ func (c *csvContent) Read(rootDir string) error {
 		return err
 	}
 
+	c.rawContent = textContent
+
 	r := csv.NewReader(strings.NewReader(textContent))
 
 	r.Comma = c.comma
@@ -86,7 +93,22 @@ func (c *csvContent) Write() error {
 
 	defer newFile.Close()
 
-	writer := csv.NewWriter(newFile)
+	return c.write(newFile)
+}
+
+// Diff returns the unified diff between the csv file content read from disk and the csv document, as it would be written by Write
+func (c *csvContent) Diff() (string, error) {
+	var b bytes.Buffer
+	if err := c.write(&b); err != nil {
+		return "", err
+	}
+
+	return text.Diff(c.FilePath, c.FilePath, c.rawContent, b.String()), nil
+}
+
+// write writes the csv document to w
+func (c *csvContent) write(w io.Writer) error {
+	writer := csv.NewWriter(w)
 
 	writer.Comma = c.comma
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
@@ -91,7 +93,7 @@ func (c *CSV) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarge
 			}
 		}
 
-		if !fileChanged || dryRun {
+		if !fileChanged {
 			continue
 		}
 
@@ -112,6 +114,16 @@ func (c *CSV) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarge
 			}
 		}
 
+		diff, err := c.contents[i].Diff()
+		if err != nil {
+			return err
+		}
+		logrus.Infof("```\n%s\n```\n", diff)
+
+		if dryRun {
+			continue
+		}
+
 		err = c.contents[i].Write()
 		if err != nil {
 			return err
@@ -1,7 +1,6 @@
 package dockerdigest
 
 import (
-	"github.com/google/go-containerregistry/pkg/authn"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/mitchellh/mapstructure"
@@ -85,19 +84,12 @@ func New(spec interface{}) (*DockerDigest, error) {
 		return nil, err
 	}
 
-	keychains := []authn.Keychain{}
-
-	if !newSpec.InlineKeyChain.Empty() {
-		keychains = append(keychains, newSpec.InlineKeyChain)
-	}
-
-	keychains = append(keychains, authn.DefaultKeychain)
 	arch := newSpec.Architecture
 	if arch == "" {
 		arch = "amd64"
 	}
 	newResource.options = append(newResource.options, remote.WithPlatform(v1.Platform{Architecture: arch, OS: "linux"}))
-	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(authn.NewMultiKeychain(keychains...)))
+	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(newSpec.InlineKeyChain.Keychain()))
 	return newResource, nil
 
 }
@@ -16,6 +16,10 @@ type Spec struct {
 	// File specifies the dockerimage file such as Dockerfile
 	File string `yaml:"file,omitempty"`
 	// Instruction specifies a DockerImage instruction such as ENV
+	//
+	// With the keyword "FROM", the tag and/or digest of the image matching the matcher is updated,
+	// skipping flags such as "--platform". If the image version is defined by an ARG instruction,
+	// such as "FROM golang:${GO_VERSION}", then the ARG instruction is updated instead.
 	Instruction types.Instruction `yaml:"instruction,omitempty"`
 	// Value specifies the value for a specified Dockerfile instruction.
 	Value string `yaml:"value,omitempty"`
@@ -1,32 +1,36 @@
 package keywords
 
 import (
-	"fmt"
+	"regexp"
 	"strings"
+
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
 )
 
+// argReferenceRegex matches a version only defined by an ARG reference such as "${GO_VERSION}" or "$GO_VERSION"
+var argReferenceRegex = regexp.MustCompile(`^\$(?:\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))$`)
+
 type From struct{}
 
+// ReplaceLine updates the tag and/or digest of the image used by a FROM instruction.
+// The source value can be a tag such as "3.14", a digest such as "sha256:..." or both such as "3.14@sha256:..."
 func (f From) ReplaceLine(source, originalLine, matcher string) string {
 
 	if !f.IsLineMatching(originalLine, matcher) {
 		return originalLine
 	}
 
-	// With a FROM instruction, Only the first word following "FROM" has to be processed
+	// With a FROM instruction, Only the image reference following the flags has to be processed
 	parsedLine := strings.Fields(originalLine)
-	parsedValue := strings.Split(parsedLine[1], ":")
-
-	if len(parsedValue) == 1 {
-		// Case with no tags specified: append the new tag to the current value
-		parsedLine[1] = fmt.Sprintf("%s:%s", parsedLine[1], source)
-	} else {
-		// Case with a tag already specified: replace only the tag's value
-		parsedLine[1] = fmt.Sprintf("%s:%s", parsedValue[0], source)
-	}
+	position := imagePosition(parsedLine)
 
-	return strings.Join(parsedLine, " ")
+	imageName, version := docker.SplitImage(parsedLine[position])
+	if strings.Contains(version, "$") {
+		// The version is defined by an ARG instruction which is updated instead, see VersionArg
+		return originalLine
+	}
 
+	return replaceField(originalLine, position, docker.JoinImage(imageName, source))
 }
 
 func (f From) IsLineMatching(originalLine, matcher string) bool {
@@ -35,7 +39,59 @@ func (f From) IsLineMatching(originalLine, matcher string) bool {
 		// Empty line
 		return false
 	}
-	found := strings.ToLower(parsedLine[0]) == "from" && strings.HasPrefix(parsedLine[1], matcher)
+
+	position := imagePosition(parsedLine)
+	found := strings.ToLower(parsedLine[0]) == "from" && position > 0 && strings.HasPrefix(parsedLine[position], matcher)
 
 	return found
 }
+
+// VersionArg returns the name of the ARG instruction defining the version of the image
+// used by a matching FROM instruction such as "FROM golang:${GO_VERSION}", or an empty string
+func (f From) VersionArg(originalLine, matcher string) string {
+	if !f.IsLineMatching(originalLine, matcher) {
+		return ""
+	}
+
+	parsedLine := strings.Fields(originalLine)
+	_, version := docker.SplitImage(parsedLine[imagePosition(parsedLine)])
+
+	found := argReferenceRegex.FindStringSubmatch(version)
+	if found == nil {
+		return ""
+	}
+
+	return found[1] + found[2]
+}
+
+// imagePosition returns the position of the image reference in a FROM instruction,
+// skipping flags such as "--platform", or -1 if there is none
+func imagePosition(parsedLine []string) int {
+	for i := 1; i < len(parsedLine); i++ {
+		if !strings.HasPrefix(parsedLine[i], "--") {
+			return i
+		}
+	}
+	return -1
+}
+
+// replaceField replaces the word at the given position in the line, keeping the original spacing
+func replaceField(line string, position int, value string) string {
+	start := 0
+	for i := 0; ; i++ {
+		// Skip spaces before the word
+		for start < len(line) && (line[start] == ' ' || line[start] == '\t') {
+			start++
+		}
+
+		end := start
+		for end < len(line) && line[end] != ' ' && line[end] != '\t' {
+			end++
+		}
+
+		if i == position || end == len(line) {
+			return line[:start] + value + line[end:]
+		}
+		start = end
+	}
+}
@@ -63,6 +63,48 @@ func TestFrom_ReplaceLine(t *testing.T) {
 			matcher:      "alpine",
 			want:         "FROM alpine:3.12",
 		},
+		{
+			name:         "Match and change with platform flag",
+			source:       "3.14",
+			originalLine: "FROM --platform=$BUILDPLATFORM alpine:3.12 AS builder",
+			matcher:      "alpine",
+			want:         "FROM --platform=$BUILDPLATFORM alpine:3.14 AS builder",
+		},
+		{
+			name:         "Match and change keeping spacing",
+			source:       "3.14",
+			originalLine: "FROM   alpine:3.12\tAS  builder",
+			matcher:      "alpine",
+			want:         "FROM   alpine:3.14\tAS  builder",
+		},
+		{
+			name:         "Match and change with registry port",
+			source:       "3.14",
+			originalLine: "FROM localhost:5000/alpine:3.12",
+			matcher:      "localhost:5000/alpine",
+			want:         "FROM localhost:5000/alpine:3.14",
+		},
+		{
+			name:         "Match and change to a digest",
+			source:       "sha256:abc",
+			originalLine: "FROM alpine:3.12",
+			matcher:      "alpine",
+			want:         "FROM alpine@sha256:abc",
+		},
+		{
+			name:         "Match and change tag and digest",
+			source:       "3.14@sha256:def",
+			originalLine: "FROM alpine:3.12@sha256:abc AS builder",
+			matcher:      "alpine",
+			want:         "FROM alpine:3.14@sha256:def AS builder",
+		},
+		{
+			name:         "Match but version defined by ARG",
+			source:       "3.14",
+			originalLine: "FROM alpine:${ALPINE_VERSION}",
+			matcher:      "alpine",
+			want:         "FROM alpine:${ALPINE_VERSION}",
+		},
 		{
 			name:         "No Match at all",
 			source:       "3.13",
@@ -163,6 +205,18 @@ func TestFrom_IsLineMatching(t *testing.T) {
 			matcher:      "alpine",
 			want:         false,
 		},
+		{
+			name:         "Match with platform flag",
+			originalLine: "FROM --platform=linux/amd64 alpine:3.12",
+			matcher:      "alpine",
+			want:         true,
+		},
+		{
+			name:         "No Match for flags only",
+			originalLine: "FROM --platform=linux/amd64",
+			matcher:      "alpine",
+			want:         false,
+		},
 		{
 			name:         "Empty line",
 			originalLine: "",
@@ -180,3 +234,51 @@ func TestFrom_IsLineMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestFrom_VersionArg(t *testing.T) {
+	tests := []struct {
+		name         string
+		originalLine string
+		matcher      string
+		want         string
+	}{
+		{
+			name:         "ARG with braces",
+			originalLine: "FROM --platform=$BUILDPLATFORM golang:${GO_VERSION} AS builder",
+			matcher:      "golang",
+			want:         "GO_VERSION",
+		},
+		{
+			name:         "ARG without braces",
+			originalLine: "FROM golang:$GO_VERSION",
+			matcher:      "golang",
+			want:         "GO_VERSION",
+		},
+		{
+			name:         "ARG only defining part of the tag",
+			originalLine: "FROM golang:${GO_VERSION}-alpine",
+			matcher:      "golang",
+			want:         "",
+		},
+		{
+			name:         "No ARG",
+			originalLine: "FROM golang:1.21",
+			matcher:      "golang",
+			want:         "",
+		},
+		{
+			name:         "No Match",
+			originalLine: "FROM ubuntu:${UBUNTU_VERSION}",
+			matcher:      "golang",
+			want:         "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := From{}
+
+			got := f.VersionArg(tt.originalLine, tt.matcher)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
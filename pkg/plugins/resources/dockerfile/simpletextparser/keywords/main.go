@@ -4,3 +4,9 @@ type Logic interface {
 	IsLineMatching(originalLine, matcher string) bool
 	ReplaceLine(source, originalLine, matcher string) string
 }
+
+// ArgLogic is implemented by keywords whose value can be defined by an ARG instruction,
+// such as "FROM golang:${GO_VERSION}"
+type ArgLogic interface {
+	VersionArg(originalLine, matcher string) string
+}
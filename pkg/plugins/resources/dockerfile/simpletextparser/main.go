@@ -52,6 +52,8 @@ func (s SimpleTextDockerfileParser) ReplaceInstructions(dockerfileContent []byte
 		return dockerfileContent, changedLines, fmt.Errorf("%s No line found matching the keyword %q and the matcher %q.", result.FAILURE, s.Keyword, s.Matcher)
 	}
 
+	versionArgs := s.versionArgs(dockerfileContent)
+
 	var newDockerfile bytes.Buffer
 	scanner := bufio.NewScanner(bytes.NewReader(dockerfileContent))
 	linePosition := 0
@@ -62,7 +64,22 @@ func (s SimpleTextDockerfileParser) ReplaceInstructions(dockerfileContent []byte
 		newLine := originalLine
 		linePosition += 1
 
-		if s.KeywordLogic.IsLineMatching(originalLine, s.Matcher) {
+		if argName := argDeclaration(originalLine); argName != "" && versionArgs[argName] {
+			newLine = keywords.Arg{}.ReplaceLine(sourceValue, originalLine, argName)
+
+			if newLine != originalLine {
+				logrus.Infof("%s The line #%d, declaring the ARG %q used by the keyword %q and the matcher %q, is changed from %q to %q.",
+					result.ATTENTION,
+					linePosition,
+					argName,
+					s.Keyword,
+					s.Matcher,
+					originalLine,
+					newLine,
+				)
+				changedLines[linePosition] = types.LineDiff{Original: originalLine, New: newLine}
+			}
+		} else if s.KeywordLogic.IsLineMatching(originalLine, s.Matcher) {
 			// if strings.HasPrefix(strings.ToLower(originalLine), strings.ToLower(d.spec.Instruction.Keyword)) {
 			newLine = s.KeywordLogic.ReplaceLine(sourceValue, originalLine, s.Matcher)
 
@@ -93,6 +110,51 @@ func (s SimpleTextDockerfileParser) ReplaceInstructions(dockerfileContent []byte
 	return newDockerfile.Bytes(), changedLines, nil
 }
 
+// versionArgs returns the names of the ARG instructions defining the version used by the matching instructions
+func (s SimpleTextDockerfileParser) versionArgs(dockerfileContent []byte) map[string]bool {
+	argLogic, ok := s.KeywordLogic.(keywords.ArgLogic)
+	if !ok {
+		return nil
+	}
+
+	versionArgs := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(dockerfileContent))
+	for scanner.Scan() {
+		if argName := argLogic.VersionArg(scanner.Text(), s.Matcher); argName != "" {
+			versionArgs[argName] = true
+		}
+	}
+
+	for argName := range versionArgs {
+		if !s.hasArgDeclaration(dockerfileContent, argName) {
+			logrus.Warningf("%s No ARG instruction found declaring %q, used by the keyword %q and the matcher %q.", result.FAILURE, argName, s.Keyword, s.Matcher)
+		}
+	}
+
+	return versionArgs
+}
+
+func (s SimpleTextDockerfileParser) hasArgDeclaration(dockerfileContent []byte, argName string) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(dockerfileContent))
+	for scanner.Scan() {
+		if argDeclaration(scanner.Text()) == argName {
+			return true
+		}
+	}
+	return false
+}
+
+// argDeclaration returns the name of the argument declared by an ARG instruction, or an empty string
+func argDeclaration(line string) string {
+	parsedLine := strings.Fields(line)
+	if len(parsedLine) < 2 || strings.ToLower(parsedLine[0]) != "arg" {
+		return ""
+	}
+
+	argName, _, _ := strings.Cut(parsedLine[1], "=")
+	return argName
+}
+
 func NewSimpleTextDockerfileParser(input map[string]string) (SimpleTextDockerfileParser, error) {
 
 	var newParser SimpleTextDockerfileParser
@@ -216,6 +216,36 @@ func TestSimpleTextDockerfileParser_ReplaceInstruction(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:              "Change golang FROM instruction defined by ARG",
+			fixtureDockerfile: "FROM_ARG.Dockerfile",
+			givenSource:       "1.21",
+			givenInstruction: map[string]string{
+				"keyword": "FROM",
+				"matcher": "golang",
+			},
+			expectedChanges: types.ChangedLines{
+				1: types.LineDiff{
+					Original: "ARG GO_VERSION=1.20",
+					New:      "ARG GO_VERSION=1.21",
+				},
+			},
+		},
+		{
+			name:              "Change FROM instruction using a registry port and a digest",
+			fixtureDockerfile: "FROM_ARG.Dockerfile",
+			givenSource:       "1.21@sha256:2c3d94b3496ea4b8e5d4a3b6c3e2a1f6f7c5ad8d1e9b76a6f7e5c3bde3db6e7c",
+			givenInstruction: map[string]string{
+				"keyword": "FROM",
+				"matcher": "localhost:5000/golang",
+			},
+			expectedChanges: types.ChangedLines{
+				12: types.LineDiff{
+					Original: "FROM localhost:5000/golang:1.20@sha256:0b76a6f7e5c3bde3db6e7c0a7e2e4e3bd7b2aa1ed9c7e43c1c6c0f7c5ad8d1e9 AS release",
+					New:      "FROM localhost:5000/golang:1.21@sha256:2c3d94b3496ea4b8e5d4a3b6c3e2a1f6f7c5ad8d1e9b76a6f7e5c3bde3db6e7c AS release",
+				},
+			},
+		},
 		{
 			name:              "Instruction not matched",
 			fixtureDockerfile: "FROM.Dockerfile",
@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
 // Target updates a targeted Dockerfile from source control management system
@@ -55,6 +56,9 @@ func (d *Dockerfile) target(source string, dryRun bool, resultTarget *result.Tar
 	resultTarget.Changed = true
 	resultTarget.Result = result.ATTENTION
 
+	logrus.Infof("```\n%s\n```\n",
+		text.Diff(d.spec.File, d.spec.File, dockerfileContent, string(newDockerfileContent)))
+
 	if !dryRun {
 		// Write the new Dockerfile content from buffer to file
 		err := d.contentRetriever.WriteToFile(string(newDockerfileContent), d.spec.File)
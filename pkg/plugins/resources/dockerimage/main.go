@@ -4,13 +4,13 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
 
@@ -60,16 +60,17 @@ func New(spec interface{}) (*DockerImage, error) {
 		return nil, err
 	}
 
-	keychains := []authn.Keychain{}
+	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(newSpec.InlineKeyChain.Keychain()))
 
-	if !newSpec.InlineKeyChain.Empty() {
-		keychains = append(keychains, newSpec.InlineKeyChain)
+	// Keep the registry default transport unless transport settings or retries are defined
+	if newSpec.TransportSpec != (httpclient.TransportSpec{}) || !newSpec.Retry.IsZero() || !httpclient.DefaultRetryPolicy.IsZero() {
+		transport, err := httpclient.WrapTransport(remote.DefaultTransport, newSpec.TransportSpec, newSpec.Retry)
+		if err != nil {
+			return nil, err
+		}
+		newResource.options = append(newResource.options, remote.WithTransport(transport))
 	}
 
-	keychains = append(keychains, authn.DefaultKeychain)
-
-	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(authn.NewMultiKeychain(keychains...)))
-
 	return newResource, nil
 }
 
@@ -90,6 +91,18 @@ func (di *DockerImage) createRef(source string) (name.Reference, error) {
 	return ref, nil
 }
 
+// digest returns the manifest digest of a container reference, such as `sha256:...`.
+// For a multi-architecture image, it's the digest of the image index so the pinned reference stays valid on every platform.
+func (di *DockerImage) digest(ref name.Reference) (string, error) {
+	// A HEAD request doesn't count toward the Docker Hub pull rate limit
+	descriptor, err := remote.Head(ref, di.options...)
+	if err != nil {
+		return "", fmt.Errorf("unable to retrieve image digest %s: %w", ref.String(), err)
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
 // checkImage checks if a container reference exists on the "remote" registry with a given set of options
 func (di *DockerImage) checkImage(ref name.Reference, arch string) (bool, error) {
 	var remoteOptions []remote.Option = di.options
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -121,6 +122,24 @@ func TestNew(t *testing.T) {
 			},
 			wantRemoteOptionsSize: 1,
 		},
+		{
+			name: "Normal case with retries",
+			spec: Spec{
+				Architectures: []string{"amd64"},
+				Image:         "ghcr.io/updatecli/updatecli",
+				Retry:         httpclient.RetryPolicy{Retries: 3},
+			},
+			wantSpec: Spec{
+				Architectures: []string{"amd64"},
+				Image:         "ghcr.io/updatecli/updatecli",
+				Retry:         httpclient.RetryPolicy{Retries: 3},
+			},
+			wantVersionFilter: version.Filter{
+				Kind:    "latest",
+				Pattern: "latest",
+			},
+			wantRemoteOptionsSize: 2,
+		},
 		{
 			name: "Invalid Spec provided (no password but username)",
 			spec: Spec{
@@ -7,9 +7,13 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/cache"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
+// cacheNamespace is the lookup cache namespace of the container image tags
+const cacheNamespace = "dockerimage"
+
 func (di *DockerImage) Source(workingDir string, resultSource *result.Source) error {
 	repo, err := name.NewRepository(di.spec.Image)
 	if err != nil {
@@ -20,7 +24,7 @@ func (di *DockerImage) Source(workingDir string, resultSource *result.Source) er
 		repo,
 	)
 
-	tags, err := remote.List(repo, di.options...)
+	tags, err := di.listTags(repo)
 	if err != nil {
 		return fmt.Errorf("unable to list tags for repository %s: %w", repo, err)
 	}
@@ -63,6 +67,20 @@ func (di *DockerImage) Source(workingDir string, resultSource *result.Source) er
 		return fmt.Errorf("no Docker Image for architecture %s", di.spec.Architectures[0])
 	}
 
+	if di.spec.Digest {
+		digest, err := di.digest(ref)
+		if err != nil {
+			return err
+		}
+
+		resultSource.Result = result.SUCCESS
+		resultSource.Information = tag + "@" + digest
+		resultSource.Description = fmt.Sprintf("Docker Image Tag %q found matching pattern %q, resolved to digest %s",
+			tag, di.versionFilter.Pattern, digest)
+
+		return nil
+	}
+
 	resultSource.Result = result.SUCCESS
 	resultSource.Information = tag
 	resultSource.Description = fmt.Sprintf("Docker Image Tag %q found matching pattern %q", tag, di.versionFilter.Pattern)
@@ -88,3 +106,23 @@ func (di *DockerImage) filterTags(tags []string) []string {
 
 	return results
 }
+
+// listTags returns the tags of the repository, from the lookup cache if they were recently listed
+func (di *DockerImage) listTags(repo name.Repository) ([]string, error) {
+	// Lookups are cached per registry, repository and username, as credentials must not be part of the cache key
+	cacheKey := cache.Key(repo.String(), di.spec.Username)
+
+	tags := []string{}
+	if cache.Get(cacheNamespace, cacheKey, &tags) {
+		return tags, nil
+	}
+
+	tags, err := remote.List(repo, di.options...)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Set(cacheNamespace, cacheKey, tags)
+
+	return tags, nil
+}
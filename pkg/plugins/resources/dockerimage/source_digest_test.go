@@ -0,0 +1,71 @@
+package dockerimage
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+func TestSourceDigest(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	image := u.Host + "/updatecli/updatecli"
+
+	index, err := random.Index(256, 1, 2)
+	require.NoError(t, err)
+
+	for _, tag := range []string{"v0.1.0", "v0.2.0"} {
+		ref, err := name.ParseReference(image + ":" + tag)
+		require.NoError(t, err)
+		require.NoError(t, remote.WriteIndex(ref, index))
+	}
+
+	digest, err := index.Digest()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		digest         bool
+		expectedResult string
+	}{
+		{
+			name:           "Tag",
+			expectedResult: "v0.2.0",
+		},
+		{
+			name:           "Tag pinned to its digest",
+			digest:         true,
+			expectedResult: "v0.2.0@" + digest.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(Spec{
+				Image: image,
+				VersionFilter: version.Filter{
+					Kind: "semver",
+				},
+				Digest: tt.digest,
+			})
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			require.NoError(t, got.Source("", &gotResult))
+
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
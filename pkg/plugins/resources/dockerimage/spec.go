@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -27,6 +28,14 @@ type Spec struct {
 	VersionFilter version.Filter `yaml:",omitempty"`
 	// [S] tagfilter allows to restrict tags retrieved from a remote registry by using a regular expression.
 	TagFilter string `yaml:",omitempty"`
+	// [S] digest specifies whether the source returns the tag pinned to its manifest digest, like `v0.1.0@sha256:...`, instead of the tag
+	Digest bool `yaml:",omitempty"`
+	// [S][C] proxy, cafile and insecureskipverify define how the registry is reached,
+	// such as a self-hosted registry behind a proxy or using a private certificate authority
+	httpclient.TransportSpec `yaml:",inline" mapstructure:",squash"`
+	// [S][C] retry defines how failed registry requests, such as network errors, rate limits or 5xx responses, are retried.
+	// Default to the global retry policy set by the "--http-retries" flag
+	Retry httpclient.RetryPolicy `yaml:",omitempty"`
 }
 
 func sanitizeRegistryEndpoint(repository string) string {
@@ -75,6 +84,9 @@ func NewDockerImageSpecFromImage(image, tag string, auths map[string]docker.Inli
 		if credential.Username != "" {
 			dockerimagespec.Username = credential.Username
 		}
+		if credential.Provider != "" {
+			dockerimagespec.Provider = credential.Provider
+		}
 	default:
 
 		registryAuths := []string{}
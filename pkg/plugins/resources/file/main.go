@@ -23,9 +23,25 @@ type Spec struct {
 	Content string `yaml:",omitempty"`
 	// ForceCreate specifies if nonexistent file(s) should be created if they are targets
 	ForceCreate bool `yaml:",omitempty"`
-	// MatchPattern specifies the regexp pattern to match on the file(s)
+	/*
+		MatchPattern specifies the regexp pattern to match on the file(s)
+
+		remark:
+			* only the matched content is replaced, so a single line can be updated inside a larger file
+			* "^" and "$" match the beginning and end of the file unless the flag "(?m)" is specified, such as "(?m)^ARG VERSION=.*$"
+	*/
 	MatchPattern string `yaml:",omitempty"`
-	// ReplacePattern specifies the regexp replace pattern to apply on the file(s) content
+	/*
+		ReplacePattern specifies the regexp replace pattern to apply on the file(s) content
+
+		remark:
+			* capture groups of the MatchPattern are referenced using "$1" or "${1}", and named capture groups using "${name}"
+			* default to the source output, or "content" if specified
+
+		example:
+			* matchpattern: "(ARG VERSION=).*"
+			  replacepattern: '${1}{{ source "version" }}'
+	*/
 	ReplacePattern string `yaml:",omitempty"`
 }
 
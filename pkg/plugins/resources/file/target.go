@@ -77,12 +77,27 @@ func (f *File) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 			return err
 		}
 
-		for filePath, file := range f.files {
+		var filePaths []string
+		for filePath := range f.files {
+			filePaths = append(filePaths, filePath)
+		}
+		sort.Strings(filePaths)
+
+		for i, filePath := range filePaths {
+			file := f.files[filePath]
 			// Check if there is any match in the file
-			if !reg.MatchString(file.content) {
+			match := reg.FindStringSubmatchIndex(file.content)
+			if match == nil {
 				// We allow the possibility to match only some files. In that case, just a warning here
 				return fmt.Errorf("no line matched in file %q for pattern %q", filePath, f.spec.MatchPattern)
 			}
+
+			// Report the first matched content, and its replacement, from the first file
+			if i == 0 {
+				resultTarget.Information = file.content[match[0]:match[1]]
+				resultTarget.NewInformation = string(reg.ExpandString(nil, inputContent, file.content, match))
+			}
+
 			// Keep the original content for later comparison
 			originalContents[filePath] = file.content
 			file.content = reg.ReplaceAllString(file.content, inputContent)
@@ -625,3 +625,86 @@ func TestFile_TargetFromSCM(t *testing.T) {
 		})
 	}
 }
+
+func TestFile_TargetReplaceLine(t *testing.T) {
+	tests := []struct {
+		name                 string
+		spec                 Spec
+		inputSourceValue     string
+		mockedContent        string
+		wantedContent        string
+		wantedInformation    string
+		wantedNewInformation string
+		wantedChanged        bool
+	}{
+		{
+			name: "Update a Dockerfile ARG",
+			spec: Spec{
+				File:           "Dockerfile",
+				MatchPattern:   `(?m)^(ARG GO_VERSION=).*$`,
+				ReplacePattern: "${1}1.21.1",
+			},
+			mockedContent:        "ARG GO_VERSION=1.20.4\nFROM golang:${GO_VERSION}\nARG GO_VERSION_SUFFIX=-alpine\n",
+			wantedContent:        "ARG GO_VERSION=1.21.1\nFROM golang:${GO_VERSION}\nARG GO_VERSION_SUFFIX=-alpine\n",
+			wantedInformation:    "ARG GO_VERSION=1.20.4",
+			wantedNewInformation: "ARG GO_VERSION=1.21.1",
+			wantedChanged:        true,
+		},
+		{
+			name: "Update a Makefile variable using a named capture group",
+			spec: Spec{
+				File:           "Makefile",
+				MatchPattern:   `(?m)^(?P<variable>HELM_VERSION\s*\?=\s*)v.*$`,
+				ReplacePattern: "${variable}v3.12.3",
+			},
+			mockedContent:        "HELM_VERSION ?= v3.11.0\n\nbuild:\n\thelm package .\n",
+			wantedContent:        "HELM_VERSION ?= v3.12.3\n\nbuild:\n\thelm package .\n",
+			wantedInformation:    "HELM_VERSION ?= v3.11.0",
+			wantedNewInformation: "HELM_VERSION ?= v3.12.3",
+			wantedChanged:        true,
+		},
+		{
+			name: "Update a .env entry from the source",
+			spec: Spec{
+				File:         ".env",
+				MatchPattern: `(?m)^UPDATECLI_VERSION=.*$`,
+			},
+			inputSourceValue:     "UPDATECLI_VERSION=0.60.0",
+			mockedContent:        "# Versions\nUPDATECLI_VERSION=0.59.0\nGO_VERSION=1.21\n",
+			wantedContent:        "# Versions\nUPDATECLI_VERSION=0.60.0\nGO_VERSION=1.21\n",
+			wantedInformation:    "UPDATECLI_VERSION=0.59.0",
+			wantedNewInformation: "UPDATECLI_VERSION=0.60.0",
+			wantedChanged:        true,
+		},
+		{
+			name: "Line already up to date",
+			spec: Spec{
+				File:           "Dockerfile",
+				MatchPattern:   `(?m)^(ARG GO_VERSION=).*$`,
+				ReplacePattern: "${1}1.21.1",
+			},
+			mockedContent:        "ARG GO_VERSION=1.21.1\nFROM golang:${GO_VERSION}\n",
+			wantedContent:        "ARG GO_VERSION=1.21.1\nFROM golang:${GO_VERSION}\n",
+			wantedInformation:    "ARG GO_VERSION=1.21.1",
+			wantedNewInformation: "ARG GO_VERSION=1.21.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedText := text.MockTextRetriever{
+				Contents: map[string]string{tt.spec.File: tt.mockedContent},
+			}
+			f, err := New(tt.spec)
+			require.NoError(t, err)
+			f.contentRetriever = &mockedText
+
+			gotResultTarget := result.Target{}
+			require.NoError(t, f.Target(tt.inputSourceValue, nil, false, &gotResultTarget))
+
+			assert.Equal(t, tt.wantedChanged, gotResultTarget.Changed)
+			assert.Equal(t, tt.wantedInformation, gotResultTarget.Information)
+			assert.Equal(t, tt.wantedNewInformation, gotResultTarget.NewInformation)
+			assert.Equal(t, tt.wantedContent, mockedText.Contents[tt.spec.File])
+		})
+	}
+}
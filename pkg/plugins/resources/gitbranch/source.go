@@ -6,7 +6,8 @@ import (
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
 
-// Source returns the latest git tag based on create time
+// Source returns the latest git branch matching the version filter, based on its latest commit time.
+// The branches of the git remote are used, falling back to local ones for repositories without any.
 func (gt *GitBranch) Source(workingDir string, resultSource *result.Source) error {
 
 	if len(gt.spec.Path) == 0 && len(workingDir) > 0 {
@@ -18,13 +19,19 @@ func (gt *GitBranch) Source(workingDir string, resultSource *result.Source) erro
 		return fmt.Errorf("validating git branch: %w", err)
 	}
 
-	tags, err := gt.nativeGitHandler.Branches(workingDir)
-
+	branches, err := gt.nativeGitHandler.RemoteBranches(gt.spec.Path)
 	if err != nil {
-		return fmt.Errorf("retrieving branches: %w", err)
+		return fmt.Errorf("retrieving remote branches: %w", err)
+	}
+
+	if len(branches) == 0 {
+		branches, err = gt.nativeGitHandler.Branches(gt.spec.Path)
+		if err != nil {
+			return fmt.Errorf("retrieving branches: %w", err)
+		}
 	}
 
-	gt.foundVersion, err = gt.versionFilter.Search(tags)
+	gt.foundVersion, err = gt.versionFilter.Search(branches)
 	if err != nil {
 		return fmt.Errorf("filtering branches: %w", err)
 	}
@@ -0,0 +1,98 @@
+package gitbranch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+type mockNativeGitHandler struct {
+	gitgeneric.GitHandler
+	branches       []string
+	remoteBranches []string
+}
+
+func (m *mockNativeGitHandler) Branches(workingDir string) ([]string, error) {
+	return m.branches, nil
+}
+
+func (m *mockNativeGitHandler) RemoteBranches(workingDir string) ([]string, error) {
+	return m.remoteBranches, nil
+}
+
+func TestGitBranch_Source(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           map[string]interface{}
+		branches       []string
+		remoteBranches []string
+		wantValue      string
+		wantErr        bool
+	}{
+		{
+			name: "Latest remote release branch matching a regex",
+			spec: map[string]interface{}{
+				"versionfilter": map[string]interface{}{
+					"kind":    "regex",
+					"pattern": `^release-\d+\.x$`,
+				},
+			},
+			branches:       []string{"main", "updatecli_xxx"},
+			remoteBranches: []string{"release-1.x", "main", "release-2.x", "feature"},
+			wantValue:      "release-2.x",
+		},
+		{
+			name: "Greatest remote branch matching a semver constraint",
+			spec: map[string]interface{}{
+				"versionfilter": map[string]interface{}{
+					"kind":    "semver",
+					"pattern": "~1",
+				},
+			},
+			remoteBranches: []string{"v1.10", "v2.0", "v1.2", "main"},
+			wantValue:      "v1.10",
+		},
+		{
+			name:      "Repository without git remote",
+			branches:  []string{"main", "dev"},
+			wantValue: "dev",
+		},
+		{
+			name: "No branch matching",
+			spec: map[string]interface{}{
+				"versionfilter": map[string]interface{}{
+					"kind":    "regex",
+					"pattern": `^release-`,
+				},
+			},
+			remoteBranches: []string{"main"},
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gb, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gb.nativeGitHandler = &mockNativeGitHandler{
+				branches:       tt.branches,
+				remoteBranches: tt.remoteBranches,
+			}
+
+			gotResult := result.Source{}
+			err = gb.Source(t.TempDir(), &gotResult)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantValue, gotResult.Information)
+			assert.Equal(t, result.SUCCESS, gotResult.Result)
+		})
+	}
+}
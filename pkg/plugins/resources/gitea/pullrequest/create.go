@@ -12,7 +12,7 @@ import (
 )
 
 // CreateAction opens a Pull Request on the Gitea server
-func (g *Gitea) CreateAction(report reports.Action) error {
+func (g *Gitea) CreateAction(report *reports.Action) error {
 
 	title := report.Title
 
@@ -102,6 +102,7 @@ func (g *Gitea) CreateAction(report reports.Action) error {
 	}
 
 	logrus.Infof("Gitea pullrequest successfully opened on %q", pr.Link)
+	report.Link = pr.Link
 
 	return nil
 }
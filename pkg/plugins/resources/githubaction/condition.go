@@ -0,0 +1,51 @@
+package githubaction
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that every reference to the action uses the expected version
+func (g *GitHubAction) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	workDir := ""
+	if scm != nil {
+		workDir = scm.GetDirectory()
+	}
+
+	if err := g.Read(workDir); err != nil {
+		return err
+	}
+
+	value := source
+	if g.spec.Value != "" {
+		value = g.spec.Value
+	}
+
+	var outdated []string
+	err := g.foreachWorkflow(func(filePath string, w *workflow, references []reference) error {
+		for _, r := range references {
+			if r.version() != value {
+				outdated = append(outdated, fmt.Sprintf("%q in file %q", r.version(), g.files[filePath].originalFilePath))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(outdated) > 0 {
+		resultCondition.Pass = false
+		resultCondition.Result = result.FAILURE
+		resultCondition.Description = fmt.Sprintf("GitHub action %q should be set to %q, but is set to %v", g.spec.Action, value, outdated)
+		return nil
+	}
+
+	resultCondition.Pass = true
+	resultCondition.Result = result.SUCCESS
+	resultCondition.Description = fmt.Sprintf("GitHub action %q is correctly set to %q", g.spec.Action, value)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package githubaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	dir := copyTestdata(t)
+
+	testData := []struct {
+		name         string
+		spec         Spec
+		source       string
+		expectedPass bool
+		wantErr      bool
+	}{
+		{
+			name:         "Every reference uses the version",
+			spec:         Spec{Action: "github/codeql-action"},
+			source:       "v2",
+			expectedPass: true,
+		},
+		{
+			name:   "References use different versions",
+			spec:   Spec{Action: "actions/checkout"},
+			source: "v3",
+		},
+		{
+			name:         "Pinned action uses the version from spec",
+			spec:         Spec{File: ".github/workflows/release.yml", Action: "actions/setup-go", Value: "v4.1.0"},
+			source:       "v4.0.0",
+			expectedPass: true,
+		},
+		{
+			name:    "Action not referenced",
+			spec:    Spec{Action: "actions/cache"},
+			source:  "v3",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = g.Condition(tt.source, &scm.MockScm{WorkingDir: dir}, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPass, gotResult.Pass)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package githubaction
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+	"github.com/updatecli/updatecli/pkg/plugins/utils"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+const (
+	// githubDefaultURL defines the default url hosting the action git repositories
+	githubDefaultURL string = "https://github.com"
+)
+
+var (
+	// workflowPatterns defines the workflow files used when no file is specified
+	workflowPatterns = []string{
+		filepath.Join(".github", "workflows", "*.yml"),
+		filepath.Join(".github", "workflows", "*.yaml"),
+	}
+)
+
+// GitHubAction defines a resource of kind "githubaction"
+type GitHubAction struct {
+	spec             Spec
+	contentRetriever text.TextRetriever
+	gitHandler       gitgeneric.GitHandler
+	files            map[string]file // map of file paths to file contents
+}
+
+type file struct {
+	originalFilePath string
+	filePath         string
+	content          string
+}
+
+// New returns a reference to a newly initialized GitHubAction object from a Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*GitHubAction, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubAction{
+		spec:             newSpec,
+		contentRetriever: &text.Text{},
+		gitHandler:       gitgeneric.GoGit{},
+		files:            make(map[string]file),
+	}, nil
+}
+
+// Read discovers, relatively to the working directory, and reads the workflow files
+func (g *GitHubAction) Read(workDir string) error {
+	filePaths := g.spec.Files
+	if len(g.spec.File) > 0 {
+		filePaths = []string{g.spec.File}
+	}
+
+	if len(filePaths) == 0 {
+		for _, pattern := range workflowPatterns {
+			matches, err := filepath.Glob(utils.JoinFilePathWithWorkingDirectoryPath(pattern, workDir))
+			if err != nil {
+				return err
+			}
+			for _, match := range matches {
+				relPath := match
+				if workDir != "" {
+					if p, err := filepath.Rel(workDir, match); err == nil {
+						relPath = p
+					}
+				}
+				filePaths = append(filePaths, relPath)
+			}
+		}
+
+		if len(filePaths) == 0 {
+			return fmt.Errorf("%s no workflow file found matching %v", result.FAILURE, workflowPatterns)
+		}
+	}
+
+	g.files = make(map[string]file)
+	for _, filePath := range filePaths {
+		f := file{
+			originalFilePath: filePath,
+			filePath:         filePath,
+		}
+
+		if workDir != "" {
+			f.filePath = utils.JoinFilePathWithWorkingDirectoryPath(filePath, workDir)
+			logrus.Debugf("Relative path detected: changing from %q to absolute path from SCM: %q", f.originalFilePath, f.filePath)
+		}
+
+		if !g.contentRetriever.FileExists(f.filePath) {
+			return fmt.Errorf("%s The specified file %q does not exist", result.FAILURE, f.filePath)
+		}
+
+		content, err := g.contentRetriever.ReadAll(f.filePath)
+		if err != nil {
+			return err
+		}
+		f.content = content
+
+		g.files[filePath] = f
+	}
+
+	return nil
+}
+
+// foreachWorkflow runs fn on every workflow file referencing the action, sorted by file path,
+// and returns an error if no workflow file references the action.
+func (g *GitHubAction) foreachWorkflow(fn func(filePath string, w *workflow, references []reference) error) error {
+	var filePaths []string
+	for filePath := range g.files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	found := false
+	for _, filePath := range filePaths {
+		w := parseWorkflow(g.files[filePath].content)
+
+		references := w.references(g.spec.Action)
+		if len(references) == 0 {
+			continue
+		}
+		found = true
+
+		if err := fn(filePath, w, references); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("%s no reference to the GitHub action %q found in %v", result.FAILURE, g.spec.Action, filePaths)
+	}
+
+	return nil
+}
+
+// commitSHA returns the commit SHA of the action version, retrieved from the action git repository
+func (g *GitHubAction) commitSHA(version string) (string, error) {
+	if shaRegex.MatchString(version) {
+		return version, nil
+	}
+
+	baseURL := g.spec.URL
+	if baseURL == "" {
+		baseURL = githubDefaultURL
+	}
+
+	// Actions located in a subdirectory share the repository git references
+	parts := strings.Split(strings.Trim(g.spec.Action, "/"), "/")
+	URL, err := url.JoinPath(baseURL, parts[0], parts[1]+".git")
+	if err != nil {
+		return "", err
+	}
+
+	sha, err := g.gitHandler.RemoteRefHash(g.spec.Username, g.spec.Token, URL, version)
+	if err != nil {
+		return "", fmt.Errorf("retrieving the commit SHA of the GitHub action %q version %q: %w", g.spec.Action, version, err)
+	}
+
+	return sha, nil
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (g *GitHubAction) Changelog() string {
+	return ""
+}
@@ -0,0 +1,44 @@
+package githubaction
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+// mockGit returns the commit SHA of the git references of the action repositories
+type mockGit struct {
+	gitgeneric.GitHandler
+	// hashes maps "<repository url>@<reference>" to a commit SHA
+	hashes map[string]string
+}
+
+func (m mockGit) RemoteRefHash(username, password, URL, ref string) (string, error) {
+	hash, ok := m.hashes[URL+"@"+ref]
+	if !ok {
+		return "", fmt.Errorf("remote reference %q not found", ref)
+	}
+	return hash, nil
+}
+
+// copyTestdata copies the testdata files to the workflow directory of a temporary directory, so targets can update them
+func copyTestdata(t *testing.T) string {
+	dir := t.TempDir()
+	workflowDir := filepath.Join(dir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowDir, 0o755))
+
+	entries, err := os.ReadDir("testdata")
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join("testdata", entry.Name()))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(workflowDir, entry.Name()), content, 0o600))
+	}
+
+	return dir
+}
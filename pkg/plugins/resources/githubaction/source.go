@@ -0,0 +1,33 @@
+package githubaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// errStopIteration stops the iteration over workflow files once a value is found
+var errStopIteration = errors.New("stop iteration")
+
+// Source returns the action version used by the first workflow file referencing it
+func (g *GitHubAction) Source(workingDir string, resultSource *result.Source) error {
+	if err := g.Read(workingDir); err != nil {
+		return err
+	}
+
+	var version string
+	err := g.foreachWorkflow(func(filePath string, w *workflow, references []reference) error {
+		version = references[0].version()
+		resultSource.Description = fmt.Sprintf("GitHub action %q is set to %q, in file %q", g.spec.Action, version, g.files[filePath].originalFilePath)
+		return errStopIteration
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return err
+	}
+
+	resultSource.Information = version
+	resultSource.Result = result.SUCCESS
+
+	return nil
+}
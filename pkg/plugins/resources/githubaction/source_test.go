@@ -0,0 +1,69 @@
+package githubaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestSource(t *testing.T) {
+	dir := copyTestdata(t)
+
+	testData := []struct {
+		name           string
+		spec           Spec
+		expectedResult string
+		wantErr        bool
+	}{
+		{
+			name:           "Action version from the first workflow file",
+			spec:           Spec{Action: "actions/checkout"},
+			expectedResult: "v3",
+		},
+		{
+			name:           "Pinned action version from a specific workflow file",
+			spec:           Spec{File: ".github/workflows/release.yml", Action: "actions/checkout"},
+			expectedResult: "v3.6.0",
+		},
+		{
+			name:    "Action not referenced",
+			spec:    Spec{Action: "actions/cache"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing workflow file",
+			spec:    Spec{File: ".github/workflows/missing.yaml", Action: "actions/checkout"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = g.Source(dir, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Spec{Action: "actions/checkout", File: "ci.yaml", Files: []string{"release.yaml"}})
+	assert.ErrorIs(t, err, ErrWrongSpec)
+
+	_, err = New(Spec{})
+	assert.ErrorIs(t, err, ErrWrongSpec)
+
+	_, err = New(Spec{Action: "checkout"})
+	assert.ErrorIs(t, err, ErrWrongSpec)
+}
@@ -0,0 +1,143 @@
+package githubaction
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+"githubaction" defines the specification for manipulating the version of a GitHub Action
+referenced by the "uses" keys of GitHub workflow files, such as "uses: actions/checkout@v4".
+It can be used as a "source", a "condition", or a "target".
+*/
+type Spec struct {
+	/*
+		"file" defines the workflow file path to interact with.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* "file" and "files" are mutually exclusive
+			* when neither "file" nor "files" are defined, every workflow file matching ".github/workflows/*.yml" or ".github/workflows/*.yaml" is used
+
+		example:
+			* .github/workflows/release.yaml
+	*/
+	File string `yaml:",omitempty"`
+	/*
+		"files" defines the list of workflow files path to interact with.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* "file" and "files" are mutually exclusive
+			* when using as a source only the first file defining the action is used
+	*/
+	Files []string `yaml:",omitempty"`
+	/*
+		"action" defines the GitHub Action to interact with, as "owner/repository".
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* actions located in a subdirectory of the repository, such as "github/codeql-action/init", are matched by the repository "github/codeql-action"
+
+		example:
+			* actions/checkout
+			* github/codeql-action
+	*/
+	Action string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		"value" defines the action version, such as a tag "v4.1.0" or a commit SHA.
+
+		compatible:
+			* condition
+			* target
+
+		default:
+			When used from a condition or a target, the default value is set to linked source output.
+	*/
+	Value string `yaml:",omitempty"`
+	/*
+		"pin" pins the action to the full commit SHA of the version, followed by the version as a comment,
+		such as "uses: actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11 # v4.1.1"
+
+		compatible:
+			* target
+
+		remark:
+			* the commit SHA is retrieved from the action git repository, like "git ls-remote"
+	*/
+	Pin bool `yaml:",omitempty"`
+	/*
+		"url" defines the GitHub url hosting the action git repositories.
+
+		compatible:
+			* target
+
+		default:
+			https://github.com
+	*/
+	URL string `yaml:",omitempty"`
+	/*
+		"username" defines the username used to authenticate with the action git repository.
+
+		compatible:
+			* target
+	*/
+	Username string `yaml:",omitempty"`
+	/*
+		"token" defines the token used to authenticate with the action git repository, such as a private action.
+
+		compatible:
+			* target
+	*/
+	Token string `yaml:",omitempty"`
+}
+
+var (
+	// ErrSpecActionUndefined is returned if no action was specified
+	ErrSpecActionUndefined = errors.New("github action undefined")
+	// ErrSpecActionWrongFormat is returned if the action isn't defined as "owner/repository"
+	ErrSpecActionWrongFormat = errors.New("github action must be defined as \"owner/repository\"")
+	// ErrSpecFileAndFilesDefined when we both spec.File and spec.Files have been specified
+	ErrSpecFileAndFilesDefined = errors.New("parameters \"file\" and \"files\" are mutually exclusive")
+	// ErrWrongSpec is returned when the Spec has wrong content
+	ErrWrongSpec error = errors.New("wrong spec content")
+)
+
+// Validate ensures that the provided Spec is valid
+func (s *Spec) Validate() error {
+	var errs []error
+
+	if len(s.File) > 0 && len(s.Files) > 0 {
+		errs = append(errs, ErrSpecFileAndFilesDefined)
+	}
+
+	if len(s.Action) == 0 {
+		errs = append(errs, ErrSpecActionUndefined)
+	} else if len(strings.Split(strings.Trim(s.Action, "/"), "/")) < 2 {
+		errs = append(errs, ErrSpecActionWrongFormat)
+	}
+
+	for _, e := range errs {
+		logrus.Errorln(e)
+	}
+
+	if len(errs) > 0 {
+		return ErrWrongSpec
+	}
+
+	return nil
+}
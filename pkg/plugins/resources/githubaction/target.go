@@ -0,0 +1,129 @@
+package githubaction
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+)
+
+// Target updates the action version referenced by the workflow files, optionally pinning it to its commit SHA
+func (g *GitHubAction) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
+	workDir := ""
+	if scm != nil {
+		workDir = scm.GetDirectory()
+	}
+
+	if err := g.Read(workDir); err != nil {
+		return err
+	}
+
+	valueToWrite := source
+	if g.spec.Value != "" {
+		valueToWrite = g.spec.Value
+		logrus.Debug("Using spec.Value instead of source input value.")
+	}
+
+	if valueToWrite == "" {
+		return fmt.Errorf("%s no version defined for the GitHub action %q", result.FAILURE, g.spec.Action)
+	}
+
+	sha := ""
+	if g.spec.Pin && !shaRegex.MatchString(valueToWrite) {
+		var err error
+		sha, err = g.commitSHA(valueToWrite)
+		if err != nil {
+			return err
+		}
+	}
+
+	resultTarget.NewInformation = valueToWrite
+	resultTarget.Result = result.SUCCESS
+
+	err := g.foreachWorkflow(func(filePath string, w *workflow, references []reference) error {
+		resourceFile := g.files[filePath]
+
+		var outdated []string
+		for _, r := range references {
+			resultTarget.Information = r.version()
+
+			updated := updateReference(r, valueToWrite, sha)
+			if updated == r {
+				continue
+			}
+
+			outdated = append(outdated, r.version())
+			w.set(updated)
+		}
+
+		if len(outdated) == 0 {
+			resultTarget.Description = fmt.Sprintf("%s\nGitHub action %q already set to %q, from file %q",
+				resultTarget.Description,
+				g.spec.Action,
+				valueToWrite,
+				resourceFile.originalFilePath)
+			return nil
+		}
+
+		newContent := w.String()
+
+		resultTarget.Changed = true
+		resultTarget.Result = result.ATTENTION
+		resultTarget.Files = append(resultTarget.Files, resourceFile.originalFilePath)
+		resultTarget.Description = fmt.Sprintf("%s\nGitHub action %q updated from %q to %q, in file %q",
+			resultTarget.Description,
+			g.spec.Action,
+			strings.Join(outdated, ", "),
+			valueToWrite,
+			resourceFile.originalFilePath)
+
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, newContent))
+
+		resourceFile.content = newContent
+		g.files[filePath] = resourceFile
+
+		if dryRun {
+			return nil
+		}
+
+		return g.contentRetriever.WriteToFile(resourceFile.content, resourceFile.filePath)
+	})
+	if err != nil {
+		return err
+	}
+
+	resultTarget.Description = strings.TrimPrefix(resultTarget.Description, "\n")
+	sort.Strings(resultTarget.Files)
+
+	return nil
+}
+
+// updateReference returns the reference set to the version, or pinned to the commit SHA of the version if defined
+func updateReference(r reference, version, sha string) reference {
+	if sha != "" {
+		r.ref = sha
+		if found := versionCommentRegex.FindStringSubmatchIndex(r.comment); found != nil {
+			r.comment = r.comment[:found[2]] + version + r.comment[found[3]:]
+		} else {
+			r.comment = " # " + version
+		}
+		return r
+	}
+
+	if r.ref == version {
+		return r
+	}
+
+	// The version comment of a pinned action would be outdated
+	if r.isPinned() && r.version() != r.ref {
+		r.comment = ""
+	}
+	r.ref = version
+
+	return r
+}
@@ -0,0 +1,150 @@
+package githubaction
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestTarget(t *testing.T) {
+	checkoutSHA := "b4ffde65f46336ab88eb53be808477a3936bae11"
+
+	testData := []struct {
+		name            string
+		spec            Spec
+		sourceInput     string
+		dryRun          bool
+		expectedChanged bool
+		expectedFiles   []string
+		// expectedReplacements defines, by workflow file, the lines expected to change
+		expectedReplacements map[string]map[string]string
+		wantErr              bool
+	}{
+		{
+			name:            "Update every workflow file",
+			spec:            Spec{Action: "actions/checkout"},
+			sourceInput:     "v4",
+			expectedChanged: true,
+			expectedFiles:   []string{".github/workflows/ci.yaml", ".github/workflows/release.yml"},
+			expectedReplacements: map[string]map[string]string{
+				"ci.yaml": {
+					"      - uses: actions/checkout@v3": "      - uses: actions/checkout@v4",
+				},
+				"release.yml": {
+					"      - uses: actions/checkout@f43a0e5ff2bd294095638e18286ca9a3d1956744 # v3.6.0": "      - uses: actions/checkout@v4",
+				},
+			},
+		},
+		{
+			name:            "Pin every workflow file",
+			spec:            Spec{Action: "actions/checkout", Pin: true},
+			sourceInput:     "v4.1.1",
+			expectedChanged: true,
+			expectedFiles:   []string{".github/workflows/ci.yaml", ".github/workflows/release.yml"},
+			expectedReplacements: map[string]map[string]string{
+				"ci.yaml": {
+					"      - uses: actions/checkout@v3": "      - uses: actions/checkout@" + checkoutSHA + " # v4.1.1",
+				},
+				"release.yml": {
+					"      - uses: actions/checkout@f43a0e5ff2bd294095638e18286ca9a3d1956744 # v3.6.0": "      - uses: actions/checkout@" + checkoutSHA + " # v4.1.1",
+				},
+			},
+		},
+		{
+			name:            "Update a quoted action in a specific workflow file",
+			spec:            Spec{File: ".github/workflows/ci.yaml", Action: "actions/setup-go"},
+			sourceInput:     "v4.1.0",
+			expectedChanged: true,
+			expectedFiles:   []string{".github/workflows/ci.yaml"},
+			expectedReplacements: map[string]map[string]string{
+				"ci.yaml": {
+					`        uses: "actions/setup-go@v4.0.0" # keep in sync with release.yaml`: `        uses: "actions/setup-go@v4.1.0" # keep in sync with release.yaml`,
+				},
+			},
+		},
+		{
+			name:            "Update actions located in subdirectories",
+			spec:            Spec{Action: "github/codeql-action", Value: "v3"},
+			sourceInput:     "v2",
+			expectedChanged: true,
+			expectedFiles:   []string{".github/workflows/ci.yaml", ".github/workflows/release.yml"},
+			expectedReplacements: map[string]map[string]string{
+				"ci.yaml": {
+					"      - uses: github/codeql-action/init@v2": "      - uses: github/codeql-action/init@v3",
+				},
+				"release.yml": {
+					"      - uses: github/codeql-action/analyze@v2": "      - uses: github/codeql-action/analyze@v3",
+				},
+			},
+		},
+		{
+			name:        "Already up to date",
+			spec:        Spec{Action: "updatecli/workflows"},
+			sourceInput: "v1",
+		},
+		{
+			name:            "Dry run",
+			spec:            Spec{Action: "actions/checkout"},
+			sourceInput:     "v4",
+			dryRun:          true,
+			expectedChanged: true,
+			expectedFiles:   []string{".github/workflows/ci.yaml", ".github/workflows/release.yml"},
+		},
+		{
+			name:        "Unknown version to pin",
+			spec:        Spec{Action: "actions/checkout", Pin: true},
+			sourceInput: "v5",
+			wantErr:     true,
+		},
+		{
+			name:        "Action not referenced",
+			spec:        Spec{Action: "actions/cache"},
+			sourceInput: "v3",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := copyTestdata(t)
+
+			g, err := New(tt.spec)
+			require.NoError(t, err)
+			g.gitHandler = mockGit{hashes: map[string]string{
+				"https://github.com/actions/checkout.git@v4.1.1": checkoutSHA,
+			}}
+
+			gotResult := result.Target{}
+			err = g.Target(tt.sourceInput, &scm.MockScm{WorkingDir: dir}, tt.dryRun, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedChanged, gotResult.Changed)
+			assert.Equal(t, tt.expectedFiles, gotResult.Files)
+
+			for _, name := range []string{"ci.yaml", "release.yml"} {
+				original, err := os.ReadFile(filepath.Join("testdata", name))
+				require.NoError(t, err)
+
+				expected := string(original)
+				for from, to := range tt.expectedReplacements[name] {
+					require.Contains(t, expected, from)
+					expected = strings.Replace(expected, from, to, 1)
+				}
+
+				got, err := os.ReadFile(filepath.Join(dir, ".github", "workflows", name))
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(got))
+			}
+		})
+	}
+}
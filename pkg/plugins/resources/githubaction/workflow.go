@@ -0,0 +1,104 @@
+package githubaction
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// usesRegex matches a "uses" key referencing an action version, such as `- uses: actions/checkout@v4 # comment`
+	usesRegex = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^@\s"'#]+)@([^\s"'#]+)(["']?)(\s+#.*)?$`)
+	// versionCommentRegex matches the comment following a pinned action, such as `# v4.1.1`
+	versionCommentRegex = regexp.MustCompile(`^\s+#\s*(\S+)\s*$`)
+	// shaRegex matches a full commit SHA
+	shaRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// reference defines an action referenced by a "uses" key of a workflow file
+type reference struct {
+	// line is the position of the reference in the workflow lines
+	line int
+	// prefix is the text preceding the action, such as "      - uses: "
+	prefix string
+	// quote is the quote surrounding the action, if any
+	quote string
+	// action is the action path, such as "actions/checkout"
+	action string
+	// ref is the action git reference, such as "v4" or a commit SHA
+	ref string
+	// comment is the comment following the action, if any
+	comment string
+}
+
+// isPinned returns true if the action is pinned to a commit SHA
+func (r reference) isPinned() bool {
+	return shaRegex.MatchString(r.ref)
+}
+
+// version returns the version of the action, which is the comment following a pinned action, such as "v4.1.1", or the reference
+func (r reference) version() string {
+	if r.isPinned() {
+		if found := versionCommentRegex.FindStringSubmatch(r.comment); found != nil {
+			return found[1]
+		}
+	}
+	return r.ref
+}
+
+// String returns the "uses" line referencing the action
+func (r reference) String() string {
+	return r.prefix + r.quote + r.action + "@" + r.ref + r.quote + r.comment
+}
+
+// workflow defines the content of a workflow file
+type workflow struct {
+	lines []string
+}
+
+func parseWorkflow(content string) *workflow {
+	return &workflow{lines: strings.Split(content, "\n")}
+}
+
+// String returns the workflow content
+func (w *workflow) String() string {
+	return strings.Join(w.lines, "\n")
+}
+
+// references returns every reference to the action, or to an action located in a subdirectory of its repository
+func (w *workflow) references(action string) []reference {
+	action = strings.Trim(action, "/")
+
+	var references []reference
+	for i, line := range w.lines {
+		found := usesRegex.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if found == nil || found[2] != found[5] {
+			continue
+		}
+
+		// GitHub repository names are case insensitive
+		path := strings.ToLower(found[3])
+		if path != strings.ToLower(action) && !strings.HasPrefix(path, strings.ToLower(action)+"/") {
+			continue
+		}
+
+		references = append(references, reference{
+			line:    i,
+			prefix:  found[1],
+			quote:   found[2],
+			action:  found[3],
+			ref:     found[4],
+			comment: found[6],
+		})
+	}
+
+	return references
+}
+
+// set updates the reference, keeping the end of line of the original line
+func (w *workflow) set(r reference) {
+	suffix := ""
+	if strings.HasSuffix(w.lines[r.line], "\r") {
+		suffix = "\r"
+	}
+	w.lines[r.line] = r.String() + suffix
+}
@@ -0,0 +1,142 @@
+package githubaction
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowReferences(t *testing.T) {
+	ci, err := os.ReadFile("testdata/ci.yaml")
+	require.NoError(t, err)
+	release, err := os.ReadFile("testdata/release.yml")
+	require.NoError(t, err)
+
+	testData := []struct {
+		name             string
+		content          string
+		action           string
+		expectedVersions []string
+		expectedPinned   []bool
+	}{
+		{
+			name:             "Step action",
+			content:          string(ci),
+			action:           "actions/checkout",
+			expectedVersions: []string{"v3"},
+			expectedPinned:   []bool{false},
+		},
+		{
+			name:             "Quoted step action with comment",
+			content:          string(ci),
+			action:           "actions/setup-go",
+			expectedVersions: []string{"v4.0.0"},
+			expectedPinned:   []bool{false},
+		},
+		{
+			name:             "Action located in a subdirectory",
+			content:          string(ci),
+			action:           "github/codeql-action",
+			expectedVersions: []string{"v2"},
+			expectedPinned:   []bool{false},
+		},
+		{
+			name:             "Reusable workflow",
+			content:          string(ci),
+			action:           "updatecli/workflows",
+			expectedVersions: []string{"v1"},
+			expectedPinned:   []bool{false},
+		},
+		{
+			name:             "Pinned actions with a version comment",
+			content:          string(release),
+			action:           "Actions/Setup-Go",
+			expectedVersions: []string{"v4.1.0"},
+			expectedPinned:   []bool{true},
+		},
+		{
+			name:    "Action not referenced",
+			content: string(ci),
+			action:  "actions/cache",
+		},
+		{
+			name:    "Action with a similar name",
+			content: string(ci),
+			action:  "actions/setup",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			references := parseWorkflow(tt.content).references(tt.action)
+
+			var versions []string
+			var pinned []bool
+			for _, r := range references {
+				versions = append(versions, r.version())
+				pinned = append(pinned, r.isPinned())
+			}
+
+			assert.Equal(t, tt.expectedVersions, versions)
+			assert.Equal(t, tt.expectedPinned, pinned)
+		})
+	}
+}
+
+func TestUpdateReference(t *testing.T) {
+	sha := "b4ffde65f46336ab88eb53be808477a3936bae11"
+
+	testData := []struct {
+		name     string
+		line     string
+		version  string
+		sha      string
+		expected string
+	}{
+		{
+			name:     "Update a tag",
+			line:     "      - uses: actions/checkout@v3",
+			version:  "v4",
+			expected: "      - uses: actions/checkout@v4",
+		},
+		{
+			name:     "Update a quoted tag keeping its comment",
+			line:     `        uses: "actions/checkout@v3" # checkout`,
+			version:  "v4",
+			expected: `        uses: "actions/checkout@v4" # checkout`,
+		},
+		{
+			name:     "Pin a tag",
+			line:     "      - uses: actions/checkout@v3",
+			version:  "v4.1.1",
+			sha:      sha,
+			expected: "      - uses: actions/checkout@" + sha + " # v4.1.1",
+		},
+		{
+			name:     "Update a pinned action keeping the comment spacing",
+			line:     "      - uses: actions/checkout@f43a0e5ff2bd294095638e18286ca9a3d1956744  #  v3.6.0",
+			version:  "v4.1.1",
+			sha:      sha,
+			expected: "      - uses: actions/checkout@" + sha + "  #  v4.1.1",
+		},
+		{
+			name:     "Unpin an action",
+			line:     "      - uses: actions/checkout@f43a0e5ff2bd294095638e18286ca9a3d1956744 # v3.6.0",
+			version:  "v4",
+			expected: "      - uses: actions/checkout@v4",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			w := parseWorkflow(tt.line)
+			references := w.references("actions/checkout")
+			require.Len(t, references, 1)
+
+			w.set(updateReference(references[0], tt.version, tt.sha))
+			assert.Equal(t, tt.expected, w.String())
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package githubrelease
 
 import (
+	"fmt"
+	"path"
+
 	"github.com/mitchellh/mapstructure"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/github"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
@@ -13,8 +16,10 @@ type Spec struct {
 	Owner string `yaml:",omitempty" jsonschema:"required"`
 	// [s][c] Repository specifies the name of a repository for a specific owner
 	Repository string `yaml:",omitempty" jsonschema:"required"`
-	// [s][c] Token specifies the credential used to authenticate with
-	Token string `yaml:",omitempty" jsonschema:"required"`
+	// [s][c] Token specifies the credential used to authenticate with, required unless App is defined
+	Token string `yaml:",omitempty"`
+	// [s][c] App specifies the GitHub App used to authenticate with, instead of Token
+	App github.AppSpec `yaml:",omitempty"`
 	// [s][c] URL specifies the default github url in case of GitHub enterprise
 	URL string `yaml:",omitempty"`
 	// [s][c] Username specifies the username used to authenticate with GitHub API
@@ -25,6 +30,8 @@ type Spec struct {
 	TypeFilter github.ReleaseType `yaml:",omitempty"`
 	// [c] Tag allows to check for a specific release tag, default to source output
 	Tag string `yaml:",omitempty"`
+	// [s] Asset specifies the name of a release asset, accepting glob patterns like "*_Linux_x86_64.tar.gz", to return its download url instead of the release tag
+	Asset string `yaml:",omitempty"`
 }
 
 // GitHubRelease defines a resource of kind "githubrelease"
@@ -45,10 +52,15 @@ func New(spec interface{}) (*GitHubRelease, error) {
 		return &GitHubRelease{}, err
 	}
 
+	if _, err := path.Match(newSpec.Asset, ""); err != nil {
+		return &GitHubRelease{}, fmt.Errorf("wrong asset pattern %q: %w", newSpec.Asset, err)
+	}
+
 	newHandler, err := github.New(github.Spec{
 		Owner:      newSpec.Owner,
 		Repository: newSpec.Repository,
 		Token:      newSpec.Token,
+		App:        newSpec.App,
 		URL:        newSpec.URL,
 		Username:   newSpec.Username,
 	}, "")
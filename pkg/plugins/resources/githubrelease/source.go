@@ -2,6 +2,7 @@ package githubrelease
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/result"
@@ -47,6 +48,10 @@ func (gr *GitHubRelease) Source(workingDir string, resultSource *result.Source)
 
 	}
 
+	if gr.spec.Asset != "" {
+		return gr.sourceAsset(value, resultSource)
+	}
+
 	resultSource.Result = result.SUCCESS
 	resultSource.Information = value
 	resultSource.Description = fmt.Sprintf("GitHub release version %q found matching pattern %q of kind %q",
@@ -56,3 +61,26 @@ func (gr *GitHubRelease) Source(workingDir string, resultSource *result.Source)
 
 	return nil
 }
+
+// sourceAsset retrieves the download url of the asset matching the spec asset pattern, from the GitHub release of tag.
+func (gr *GitHubRelease) sourceAsset(tag string, resultSource *result.Source) error {
+	assets, err := gr.ghHandler.SearchReleaseAssets(tag)
+	if err != nil {
+		return fmt.Errorf("searching GitHub release assets: %w", err)
+	}
+
+	for _, asset := range assets {
+		// The asset pattern is validated by New
+		if ok, _ := path.Match(gr.spec.Asset, asset.Name); !ok {
+			continue
+		}
+
+		resultSource.Result = result.SUCCESS
+		resultSource.Information = asset.DownloadURL
+		resultSource.Description = fmt.Sprintf("GitHub release asset %q found in release %q", asset.Name, tag)
+
+		return nil
+	}
+
+	return fmt.Errorf("no GitHub release asset matching %q found in release %q", gr.spec.Asset, tag)
+}
@@ -17,6 +17,8 @@ type mockGhHandler struct {
 	releaseErr error
 	tags       []string
 	tagErr     error
+	assets     []github.ReleaseAsset
+	assetErr   error
 }
 
 func (m *mockGhHandler) SearchReleases(releaseType github.ReleaseType) (releases []string, err error) {
@@ -27,12 +29,17 @@ func (m *mockGhHandler) SearchTags() (releases []string, err error) {
 	return m.tags, m.tagErr
 }
 
+func (m *mockGhHandler) SearchReleaseAssets(tag string) (assets []github.ReleaseAsset, err error) {
+	return m.assets, m.assetErr
+}
+
 func TestGitHubRelease_Source(t *testing.T) {
 	tests := []struct {
 		name            string
 		workingDir      string
 		mockedGhHandler github.GithubHandler
 		versionFilter   version.Filter
+		asset           string
 		wantValue       string
 		wantErr         bool
 	}{
@@ -100,6 +107,37 @@ func TestGitHubRelease_Source(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "3 releases found, filter with latest and asset pattern",
+			mockedGhHandler: &mockGhHandler{
+				releases: []string{"1.0.0", "2.0.0", "3.0.0"},
+				assets: []github.ReleaseAsset{
+					{Name: "checksums.txt", DownloadURL: "https://github.com/updatecli/updatecli/releases/download/3.0.0/checksums.txt"},
+					{Name: "updatecli_Linux_x86_64.tar.gz", DownloadURL: "https://github.com/updatecli/updatecli/releases/download/3.0.0/updatecli_Linux_x86_64.tar.gz"},
+				},
+			},
+			versionFilter: version.Filter{
+				Kind:    "latest",
+				Pattern: "latest",
+			},
+			asset:     "*_Linux_x86_64.tar.gz",
+			wantValue: "https://github.com/updatecli/updatecli/releases/download/3.0.0/updatecli_Linux_x86_64.tar.gz",
+		},
+		{
+			name: "Error: no release asset matching pattern",
+			mockedGhHandler: &mockGhHandler{
+				releases: []string{"1.0.0", "2.0.0", "3.0.0"},
+				assets: []github.ReleaseAsset{
+					{Name: "checksums.txt", DownloadURL: "https://github.com/updatecli/updatecli/releases/download/3.0.0/checksums.txt"},
+				},
+			},
+			versionFilter: version.Filter{
+				Kind:    "latest",
+				Pattern: "latest",
+			},
+			asset:   "*_Linux_x86_64.tar.gz",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -109,6 +147,7 @@ func TestGitHubRelease_Source(t *testing.T) {
 			gr := &GitHubRelease{
 				ghHandler:     tt.mockedGhHandler,
 				versionFilter: tt.versionFilter,
+				spec:          Spec{Asset: tt.asset},
 			}
 
 			gotResult := result.Source{}
@@ -3,6 +3,9 @@ package mergerequest
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -13,8 +16,9 @@ import (
 	utils "github.com/updatecli/updatecli/pkg/plugins/utils/action"
 )
 
-// CreateAction opens a Merge Request on the GitLab server
-func (g *Gitlab) CreateAction(report reports.Action) error {
+// CreateAction opens a Merge Request on the GitLab server,
+// or updates the title, the description, and the labels of the already opened one
+func (g *Gitlab) CreateAction(report *reports.Action) error {
 
 	title := report.Title
 	if len(g.spec.Title) > 0 {
@@ -33,15 +37,15 @@ func (g *Gitlab) CreateAction(report reports.Action) error {
 		body = g.spec.Body
 	}
 
-	// Check if a merge-request is already opened then exit early if it does.
-	exist, err := g.isMergeRequestExist()
+	// Check if a merge-request is already opened then update it if it does.
+	mr, err := g.findMergeRequest()
 	if err != nil {
 		return fmt.Errorf("check if a mergerequest already exist: %s", err.Error())
 	}
 
-	if exist {
-		logrus.Debugln("GitLab mergerequest already exist, nothing to do")
-		return nil
+	if mr != nil {
+		report.Link = mr.Link
+		return g.updateMergeRequest(mr.Number, title, body)
 	}
 
 	// Test that both sourceBranch and targetBranch exists on remote before creating a new one
@@ -104,6 +108,58 @@ func (g *Gitlab) CreateAction(report reports.Action) error {
 	}
 
 	logrus.Infof("GitLab mergerequest successfully opened on %q", pr.Link)
+	report.Link = pr.Link
+
+	// Labels can't be set when opening the mergerequest
+	if len(g.spec.Labels) > 0 {
+		return g.updateMergeRequest(pr.Number, title, body)
+	}
+
+	return nil
+}
+
+// updateMergeRequest updates the title, the description, and the labels of the GitLab mergerequest number
+func (g *Gitlab) updateMergeRequest(number int, title, body string) error {
+
+	in := url.Values{}
+	in.Set("title", title)
+	in.Set("description", body)
+	if len(g.spec.Labels) > 0 {
+		// add_labels keeps the labels already set on the mergerequest
+		in.Set("add_labels", strings.Join(g.spec.Labels, ","))
+	}
+
+	repository := strings.Join([]string{g.Owner, g.Repository}, "/")
+
+	// The GitLab driver doesn't support updating mergerequests, hence we query the api directly
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d?%s",
+		strings.ReplaceAll(repository, "/", "%2F"),
+		number,
+		in.Encode())
+
+	logrus.Debugf("Updating GitLab mergerequest %d\nTitle:\t%q\nLabels:\t%v\n", number, title, g.spec.Labels)
+
+	// Timeout api query after 30sec
+	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := (*scm.Client)(g.client).Do(ctx, &scm.Request{
+		Method: http.MethodPut,
+		Path:   path,
+	})
+	if err != nil {
+		return fmt.Errorf("update GitLab mergerequest %d: %v", number, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Status >= 300 {
+		content, _ := io.ReadAll(resp.Body)
+		logrus.Debugf("HTTP Response:\n\tReturn code: %d\n\tBody: %s\n", resp.Status, content)
+		return fmt.Errorf("update GitLab mergerequest %d: unexpected return code %d", number, resp.Status)
+	}
+
+	logrus.Infof("GitLab mergerequest %d successfully updated", number)
 
 	return nil
 }
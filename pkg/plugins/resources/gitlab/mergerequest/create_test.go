@@ -0,0 +1,123 @@
+package mergerequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestCreateAction(t *testing.T) {
+	testData := []struct {
+		name             string
+		labels           []string
+		existingMR       bool
+		wantCreated      bool
+		wantUpdatedMR    string
+		wantUpdateLabels string
+	}{
+		{
+			name:        "Open a new mergerequest",
+			wantCreated: true,
+		},
+		{
+			name:             "Open a new mergerequest with labels",
+			labels:           []string{"dependencies", "updatecli"},
+			wantCreated:      true,
+			wantUpdatedMR:    "8",
+			wantUpdateLabels: "dependencies,updatecli",
+		},
+		{
+			name:          "Update the existing mergerequest",
+			existingMR:    true,
+			wantUpdatedMR: "7",
+		},
+		{
+			name:             "Update the existing mergerequest labels",
+			labels:           []string{"dependencies"},
+			existingMR:       true,
+			wantUpdatedMR:    "7",
+			wantUpdateLabels: "dependencies",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			updatedMR := ""
+			var updateQuery url.Values
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				projectPath := "/api/v4/projects/updatecli/updatecli"
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == projectPath+"/merge_requests":
+					mergeRequests := []map[string]interface{}{}
+					if tt.existingMR {
+						mergeRequests = append(mergeRequests, map[string]interface{}{
+							"iid":           7,
+							"state":         "opened",
+							"source_branch": "updatecli_xxx",
+							"target_branch": "main",
+							"web_url":       "https://gitlab.com/updatecli/updatecli/-/merge_requests/7",
+						})
+					}
+					require.NoError(t, json.NewEncoder(w).Encode(mergeRequests))
+				case r.Method == http.MethodGet && r.URL.Path == projectPath+"/repository/branches":
+					require.NoError(t, json.NewEncoder(w).Encode([]map[string]string{
+						{"name": "main"},
+						{"name": "updatecli_xxx"},
+					}))
+				case r.Method == http.MethodPost && r.URL.Path == projectPath+"/merge_requests":
+					created = true
+					require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+						"iid":     8,
+						"state":   "opened",
+						"web_url": "https://gitlab.com/updatecli/updatecli/-/merge_requests/8",
+					}))
+				case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, projectPath+"/merge_requests/"):
+					updatedMR = strings.TrimPrefix(r.URL.Path, projectPath+"/merge_requests/")
+					updateQuery = r.URL.Query()
+					_, err := w.Write([]byte("{}"))
+					require.NoError(t, err)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			// Specs are decoded from the manifest settings
+			g, err := New(map[string]interface{}{
+				"url":          server.URL,
+				"token":        "xxx",
+				"owner":        "updatecli",
+				"repository":   "updatecli",
+				"sourcebranch": "updatecli_xxx",
+				"targetbranch": "main",
+				"title":        "Bump version",
+				"body":         "Changelog",
+				"labels":       tt.labels,
+			}, nil)
+			require.NoError(t, err)
+
+			require.NoError(t, g.CreateAction(&reports.Action{Title: "Report"}))
+
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, tt.wantUpdatedMR, updatedMR)
+			if tt.wantUpdatedMR == "" {
+				return
+			}
+			assert.Equal(t, "Bump version", updateQuery.Get("title"))
+			assert.Equal(t, "Changelog", updateQuery.Get("description"))
+			assert.Equal(t, tt.wantUpdateLabels, updateQuery.Get("add_labels"))
+		})
+	}
+}
@@ -84,4 +84,12 @@ type Spec struct {
 			"body" is useful to provide additional information when reviewing mergerequest, such as changelog url.
 	*/
 	Body string `yaml:",omitempty"`
+	/*
+		"labels" defines the labels added to the GitLab mergerequest
+
+		remark:
+			labels are added to both new and existing mergerequests, labels added from the GitLab UI are kept.
+			GitLab creates the labels which don't exist yet on the project.
+	*/
+	Labels []string `yaml:",omitempty"`
 }
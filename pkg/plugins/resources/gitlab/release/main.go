@@ -2,7 +2,11 @@ package release
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"path"
 	"strings"
 	"time"
 
@@ -34,6 +38,8 @@ type Spec struct {
 	Draft bool `yaml:",omitempty"`
 	// [T] Prerelease defines if the release is a pre-release release
 	Prerelease bool `yaml:",omitempty"`
+	// [S] Asset specifies the name of a release asset link, accepting glob patterns like "*_Linux_x86_64.tar.gz", to return its url instead of the release tag
+	Asset string `yaml:",omitempty"`
 }
 
 const (
@@ -78,6 +84,10 @@ func New(spec interface{}) (*Gitlab, error) {
 		return &Gitlab{}, err
 	}
 
+	if _, err := path.Match(s.Asset, ""); err != nil {
+		return &Gitlab{}, fmt.Errorf("wrong asset pattern %q: %w", s.Asset, err)
+	}
+
 	c, err := client.New(clientSpec)
 
 	if err != nil {
@@ -146,6 +156,91 @@ func (g *Gitlab) SearchReleases() ([]string, error) {
 	return results, nil
 }
 
+// SearchTags retrieves git tags from a remote GitLab repository
+func (g *Gitlab) SearchTags() (tags []string, err error) {
+
+	ctx := context.Background()
+	// Timeout api query after 30sec
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	page := 0
+	for {
+		references, resp, err := g.client.Git.ListTags(
+			ctx,
+			strings.Join([]string{g.spec.Owner, g.spec.Repository}, "/"),
+			scm.ListOptions{
+				Page: page,
+				Size: 100,
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.Status > 400 {
+			logrus.Debugf("GitLab Api Response:\n%+v", resp)
+		}
+
+		for _, ref := range references {
+			tags = append(tags, ref.Name)
+		}
+
+		if page >= resp.Page.Last {
+			break
+		}
+		page++
+	}
+
+	// GitLab returns the most recent tags first while version filters expect them last
+	for i, j := 0, len(tags)-1; i < j; i, j = i+1, j-1 {
+		tags[i], tags[j] = tags[j], tags[i]
+	}
+
+	return tags, nil
+}
+
+// releaseAssetLink defines a link attached to a GitLab release, as returned by the GitLab api
+type releaseAssetLink struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+// SearchReleaseAssetLinks retrieves the asset links of the GitLab release of the git tag tag
+func (g *Gitlab) SearchReleaseAssetLinks(tag string) ([]releaseAssetLink, error) {
+
+	ctx := context.Background()
+	// Timeout api query after 30sec
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// go-scm doesn't expose GitLab release assets, hence we query the GitLab api directly
+	resp, err := (*scm.Client)(g.client).Do(ctx, &scm.Request{
+		Method: http.MethodGet,
+		Path: fmt.Sprintf("api/v4/projects/%s/releases/%s/assets/links",
+			url.PathEscape(strings.Join([]string{g.spec.Owner, g.spec.Repository}, "/")),
+			url.PathEscape(tag)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.Status >= 300 {
+		logrus.Debugf("GitLab Api Response:\n%+v", resp)
+		return nil, fmt.Errorf("retrieving GitLab release %q asset links: unexpected return code %d", tag, resp.Status)
+	}
+
+	links := []releaseAssetLink{}
+	if err := json.NewDecoder(resp.Body).Decode(&links); err != nil {
+		return nil, fmt.Errorf("decoding GitLab release %q asset links: %w", tag, err)
+	}
+
+	return links, nil
+}
+
 func (s Spec) Validate() error {
 	gotError := false
 	missingParameters := []string{}
@@ -2,6 +2,9 @@ package release
 
 import (
 	"fmt"
+	"path"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/updatecli/updatecli/pkg/core/result"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
@@ -15,7 +18,15 @@ func (g *Gitlab) Source(workingDir string, resultSource *result.Source) error {
 	}
 
 	if len(versions) == 0 {
-		return fmt.Errorf("no GitLab release found")
+		logrus.Warningf("%s No GitLab Release found, we fallback to published git tags", result.ATTENTION)
+
+		versions, err = g.SearchTags()
+		if err != nil {
+			return fmt.Errorf("searching git tag: %w", err)
+		}
+		if len(versions) == 0 {
+			return fmt.Errorf("no GitLab release or git tags found")
+		}
 	}
 
 	g.foundVersion, err = g.spec.VersionFilter.Search(versions)
@@ -41,6 +52,10 @@ func (g *Gitlab) Source(workingDir string, resultSource *result.Source) error {
 		)
 	}
 
+	if g.spec.Asset != "" {
+		return g.sourceAsset(resultSource.Information, resultSource)
+	}
+
 	resultSource.Result = result.SUCCESS
 	resultSource.Description = fmt.Sprintf("GitLab release tag %q found matching pattern %q of kind %q",
 		resultSource.Information,
@@ -50,3 +65,29 @@ func (g *Gitlab) Source(workingDir string, resultSource *result.Source) error {
 	return nil
 
 }
+
+// sourceAsset retrieves the url of the asset link matching the spec asset pattern, from the GitLab release of tag.
+func (g *Gitlab) sourceAsset(tag string, resultSource *result.Source) error {
+	links, err := g.SearchReleaseAssetLinks(tag)
+	if err != nil {
+		return fmt.Errorf("searching GitLab release assets: %w", err)
+	}
+
+	for _, link := range links {
+		// The asset pattern is validated by New
+		if ok, _ := path.Match(g.spec.Asset, link.Name); !ok {
+			continue
+		}
+
+		resultSource.Result = result.SUCCESS
+		resultSource.Information = link.URL
+		if link.DirectAssetURL != "" {
+			resultSource.Information = link.DirectAssetURL
+		}
+		resultSource.Description = fmt.Sprintf("GitLab release asset %q found in release %q", link.Name, tag)
+
+		return nil
+	}
+
+	return fmt.Errorf("no GitLab release asset matching %q found in release %q", g.spec.Asset, tag)
+}
@@ -0,0 +1,110 @@
+package release
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drone/go-scm/scm/driver/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestSourceFromAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		releases   []map[string]interface{}
+		tags       []map[string]interface{}
+		links      []map[string]interface{}
+		asset      string
+		wantResult string
+		wantErr    bool
+	}{
+		{
+			name:       "Latest release",
+			releases:   []map[string]interface{}{{"tag_name": "v0.2.0"}, {"tag_name": "v0.1.0"}},
+			wantResult: "v0.2.0",
+		},
+		{
+			name:       "No release, fallback to git tags",
+			tags:       []map[string]interface{}{{"name": "v0.2.0"}, {"name": "v0.1.0"}},
+			wantResult: "v0.2.0",
+		},
+		{
+			name:    "No release nor git tags",
+			wantErr: true,
+		},
+		{
+			name:     "Release asset link",
+			releases: []map[string]interface{}{{"tag_name": "v0.2.0"}},
+			links: []map[string]interface{}{
+				{"name": "checksums.txt", "url": "https://gitlab.com/olblak/updatecli/-/releases/v0.2.0/downloads/checksums.txt"},
+				{
+					"name":             "updatecli_Linux_x86_64.tar.gz",
+					"url":              "https://example.com/updatecli_Linux_x86_64.tar.gz",
+					"direct_asset_url": "https://gitlab.com/olblak/updatecli/-/releases/v0.2.0/downloads/updatecli_Linux_x86_64.tar.gz",
+				},
+			},
+			asset:      "*_Linux_x86_64.tar.gz",
+			wantResult: "https://gitlab.com/olblak/updatecli/-/releases/v0.2.0/downloads/updatecli_Linux_x86_64.tar.gz",
+		},
+		{
+			name:     "Error: no release asset link matching",
+			releases: []map[string]interface{}{{"tag_name": "v0.2.0"}},
+			asset:    "*_Linux_x86_64.tar.gz",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				projectPath := "/api/v4/projects/olblak/updatecli"
+				w.Header().Set("Content-Type", "application/json")
+
+				var body interface{}
+				switch r.URL.Path {
+				case projectPath + "/releases":
+					body = tt.releases
+				case projectPath + "/repository/tags":
+					body = tt.tags
+				case projectPath + "/releases/v0.2.0/assets/links":
+					body = tt.links
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if body == nil {
+					body = []interface{}{}
+				}
+				require.NoError(t, json.NewEncoder(w).Encode(body))
+			}))
+			defer server.Close()
+
+			g, err := New(map[string]interface{}{
+				"owner":      "olblak",
+				"repository": "updatecli",
+				"asset":      tt.asset,
+			})
+			require.NoError(t, err)
+
+			c, err := gitlab.New(server.URL)
+			require.NoError(t, err)
+			g.client = c
+
+			gotResult := result.Source{}
+			err = g.Source("", &gotResult)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, gotResult.Information)
+		})
+	}
+}
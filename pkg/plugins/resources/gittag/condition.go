@@ -33,9 +33,20 @@ func (gt *GitTag) Condition(source string, scm scm.ScmHandler, resultCondition *
 		return err
 	}
 
-	tags, err := gt.nativeGitHandler.Tags(gt.spec.Path)
-	if err != nil {
-		return err
+	var tags []string
+	if gt.spec.URL != "" {
+		refs, err := gt.tagRefs()
+		if err != nil {
+			return err
+		}
+		for i := range refs {
+			tags = append(tags, refs[i].Name)
+		}
+	} else {
+		tags, err = gt.nativeGitHandler.Tags(gt.spec.Path)
+		if err != nil {
+			return err
+		}
 	}
 
 	gt.foundVersion, err = gt.versionFilter.Search(tags)
@@ -14,6 +14,18 @@ import (
 type Spec struct {
 	// Path contains the git repository path
 	Path string `yaml:",omitempty"`
+	// URL contains the git repository URL to retrieve tags from, like "git ls-remote", without cloning it.
+	// It takes precedence over Path and isn't supported by targets.
+	// As remote tags have no creation date, the "latest" version filter returns the last tag by name.
+	URL string `yaml:",omitempty"`
+	// Username is used with URL to authenticate against the git repository
+	Username string `yaml:",omitempty"`
+	// Password is used with URL to authenticate against the git repository
+	Password string `yaml:",omitempty"`
+	// InMemory fetches, with URL, the git repository in memory instead of only listing its remote tags,
+	// so tags are ordered by creation date without creating a working directory.
+	// The whole fetched history is held in memory.
+	InMemory bool `yaml:",omitempty"`
 	// VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest.
 	VersionFilter version.Filter `yaml:",omitempty"`
 	// Message associated to the git tag
@@ -60,8 +72,11 @@ func New(spec interface{}) (*GitTag, error) {
 // Validate tests that tag struct is correctly configured
 func (gt *GitTag) Validate() error {
 	validationErrors := []string{}
-	if gt.spec.Path == "" {
-		validationErrors = append(validationErrors, "Git working directory path is empty while it must be specified. Did you specify an `scmID` or a `spec.path`?")
+	if gt.spec.Path == "" && gt.spec.URL == "" {
+		validationErrors = append(validationErrors, "Git working directory path is empty while it must be specified. Did you specify an `scmID`, a `spec.path` or a `spec.url`?")
+	}
+	if gt.spec.InMemory && gt.spec.URL == "" {
+		validationErrors = append(validationErrors, "`spec.inmemory` requires a `spec.url` to fetch the git repository from.")
 	}
 	if gt.spec.Key != "" && gt.spec.Key != "hash" && gt.spec.Key != "name" {
 		validationErrors = append(validationErrors, "The only valid values for Key are 'name', 'hash', or empty.")
@@ -75,6 +90,26 @@ func (gt *GitTag) Validate() error {
 	return nil
 }
 
+// tagRefs returns the tags of the remote git repository when an URL is specified, fetched in memory if InMemory is set,
+// otherwise of the local one
+func (gt *GitTag) tagRefs() ([]gitgeneric.DatedTag, error) {
+	if gt.spec.URL != "" && gt.spec.InMemory {
+		return gt.nativeGitHandler.InMemoryTagRefs(gt.spec.Username, gt.spec.Password, gt.spec.URL)
+	}
+	if gt.spec.URL != "" {
+		return gt.nativeGitHandler.RemoteTagRefs(gt.spec.Username, gt.spec.Password, gt.spec.URL)
+	}
+	return gt.nativeGitHandler.TagRefs(gt.spec.Path)
+}
+
+// location returns the git repository used to retrieve tags, for logging purpose
+func (gt *GitTag) location() string {
+	if gt.spec.URL != "" {
+		return gt.spec.URL
+	}
+	return gt.spec.Path
+}
+
 // Changelog returns the changelog for this resource, or an empty string if not supported
 func (gt *GitTag) Changelog() string {
 	return ""
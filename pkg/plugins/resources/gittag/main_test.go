@@ -104,6 +104,37 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "URL without Path",
+			spec: Spec{
+				URL: "https://github.com/updatecli/updatecli",
+			},
+			want: GitTag{
+				spec: Spec{},
+				versionFilter: version.Filter{
+					Kind:    "latest",
+					Pattern: "latest",
+				},
+				nativeGitHandler: gitgeneric.GoGit{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "InMemory without URL",
+			spec: Spec{
+				Path:     "github.com/updatecli/updatecli",
+				InMemory: true,
+			},
+			want: GitTag{
+				spec: Spec{},
+				versionFilter: version.Filter{
+					Kind:    "latest",
+					Pattern: "latest",
+				},
+				nativeGitHandler: gitgeneric.GoGit{},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Bad Key",
 			spec: Spec{
@@ -18,13 +18,13 @@ func (gt *GitTag) Source(workingDir string, resultSource *result.Source) error {
 		return fmt.Errorf("validate git tag: %w", err)
 	}
 
-	refs, err := gt.nativeGitHandler.TagRefs(gt.spec.Path)
+	refs, err := gt.tagRefs()
 	if err != nil {
 		return fmt.Errorf("retrieving tag refs: %w", err)
 	}
 
 	if len(refs) == 0 {
-		return fmt.Errorf("no tags found at path %q", gt.spec.Path)
+		return fmt.Errorf("no tags found in %q", gt.location())
 	}
 
 	var tags []string
@@ -15,12 +15,32 @@ type mockNativeGitHandler struct {
 	gitgeneric.GitHandler
 	tagRefs      []gitgeneric.DatedTag
 	tagRefsError error
+	// remoteTagRefs maps a repository URL to its tags
+	remoteTagRefs map[string][]gitgeneric.DatedTag
+	// inMemoryTagRefs maps a repository URL to its tags ordered by creation time
+	inMemoryTagRefs map[string][]gitgeneric.DatedTag
 }
 
 func (m *mockNativeGitHandler) TagRefs(workingDir string) (refs []gitgeneric.DatedTag, err error) {
 	return m.tagRefs, m.tagRefsError
 }
 
+func (m *mockNativeGitHandler) RemoteTagRefs(username, password, URL string) ([]gitgeneric.DatedTag, error) {
+	refs, ok := m.remoteTagRefs[URL]
+	if !ok {
+		return nil, fmt.Errorf("listing remote references from %q: repository not found", URL)
+	}
+	return refs, nil
+}
+
+func (m *mockNativeGitHandler) InMemoryTagRefs(username, password, URL string) ([]gitgeneric.DatedTag, error) {
+	refs, ok := m.inMemoryTagRefs[URL]
+	if !ok {
+		return nil, fmt.Errorf("fetching %q in memory: repository not found", URL)
+	}
+	return refs, nil
+}
+
 func TestGitTag_Source(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -274,6 +294,100 @@ func TestGitTag_Source(t *testing.T) {
 			wantValue: "mno345",
 			wantErr:   false,
 		},
+		{
+			name:       "Remote tags found, filter with semver",
+			workingDir: "github.com/updatecli/updatecli",
+			mockedNativeGitHandler: &mockNativeGitHandler{
+				tagRefs: []gitgeneric.DatedTag{
+					{
+						Name: "9.0.0",
+						Hash: "abc123",
+					},
+				},
+				remoteTagRefs: map[string][]gitgeneric.DatedTag{
+					"https://github.com/pre-commit/pre-commit-hooks": {
+						{
+							Name: "v4.4.0",
+							Hash: "def456",
+						},
+						{
+							Name: "v4.5.0",
+							Hash: "ghi789",
+						},
+					},
+				},
+			},
+			versionFilter: version.Filter{
+				Kind:    "semver",
+				Pattern: "*",
+			},
+			spec: Spec{
+				URL: "https://github.com/pre-commit/pre-commit-hooks",
+			},
+			wantValue: "v4.5.0",
+			wantErr:   false,
+		},
+		{
+			name:       "Tags fetched in memory, filter with latest",
+			workingDir: "github.com/updatecli/updatecli",
+			mockedNativeGitHandler: &mockNativeGitHandler{
+				remoteTagRefs: map[string][]gitgeneric.DatedTag{
+					"https://github.com/updatecli/updatecli": {
+						{
+							Name: "v0.9.1",
+							Hash: "abc123",
+						},
+						{
+							Name: "v1.0.0",
+							Hash: "def456",
+						},
+					},
+				},
+				inMemoryTagRefs: map[string][]gitgeneric.DatedTag{
+					"https://github.com/updatecli/updatecli": {
+						{
+							Name: "v1.0.0",
+							Hash: "def456",
+						},
+						{
+							Name: "v0.9.1",
+							Hash: "abc123",
+						},
+					},
+				},
+			},
+			versionFilter: version.Filter{
+				Kind:    "latest",
+				Pattern: "latest",
+			},
+			spec: Spec{
+				URL:      "https://github.com/updatecli/updatecli",
+				InMemory: true,
+			},
+			wantValue: "v0.9.1",
+			wantErr:   false,
+		},
+		{
+			name:       "Error: remote repository not found",
+			workingDir: "github.com/updatecli/updatecli",
+			mockedNativeGitHandler: &mockNativeGitHandler{
+				tagRefs: []gitgeneric.DatedTag{
+					{
+						Name: "9.0.0",
+						Hash: "abc123",
+					},
+				},
+			},
+			versionFilter: version.Filter{
+				Kind:    "latest",
+				Pattern: "latest",
+			},
+			spec: Spec{
+				URL: "https://github.com/updatecli/missing",
+			},
+			wantValue: "",
+			wantErr:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,7 +9,8 @@ import (
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
 
-// Target creates a tag if needed from a local git repository, without pushing the tag
+// Target creates an annotated tag if needed from a local git repository,
+// then pushes it when the target is linked to an scm
 func (gt *GitTag) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
 	if scm != nil {
 		if len(gt.spec.Path) > 0 {
@@ -55,6 +56,11 @@ func (gt *GitTag) target(source string, dryRun bool, resultTarget *result.Target
 	// to know why the following line is needed at the moment
 	resultTarget.Files = []string{""}
 
+	// Tags can only be created in a local git repository
+	if gt.spec.URL != "" {
+		return fmt.Errorf("target validation error: spec.url is not allowed for targets of type gittag")
+	}
+
 	// Fail if a pattern is specified
 	if gt.spec.VersionFilter.Pattern != "" {
 		return fmt.Errorf("target validation error: spec.versionfilter.pattern is not allowed for targets of type gittag")
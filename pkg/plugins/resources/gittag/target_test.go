@@ -0,0 +1,123 @@
+package gittag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+type mockTargetGitHandler struct {
+	gitgeneric.GitHandler
+	tags       []string
+	newTag     string
+	newMessage string
+}
+
+func (m *mockTargetGitHandler) Tags(workingDir string) ([]string, error) {
+	return m.tags, nil
+}
+
+func (m *mockTargetGitHandler) NewTag(tag, message, workingDir string) (bool, error) {
+	m.newTag = tag
+	m.newMessage = message
+	return true, nil
+}
+
+type mockTagScm struct {
+	scm.MockScm
+	pushedTag string
+}
+
+func (m *mockTagScm) PushTag(tag string) error {
+	m.pushedTag = tag
+	return nil
+}
+
+func TestGitTag_Target(t *testing.T) {
+	tests := []struct {
+		name            string
+		source          string
+		tags            []string
+		spec            Spec
+		dryRun          bool
+		wantChanged     bool
+		wantNewTag      string
+		wantMessage     string
+		wantPushedTag   string
+		wantDescription string
+		wantErr         bool
+	}{
+		{
+			name:            "Create and push a new tag",
+			source:          "v1.1.0",
+			tags:            []string{"v1.0.0"},
+			spec:            Spec{Message: "Release v1.1.0"},
+			wantChanged:     true,
+			wantNewTag:      "v1.1.0",
+			wantMessage:     "Release v1.1.0",
+			wantPushedTag:   "v1.1.0",
+			wantDescription: "Release v1.1.0",
+		},
+		{
+			name:            "Create a new tag with the default message",
+			source:          "v1.1.0",
+			tags:            []string{"v1.0.0"},
+			wantChanged:     true,
+			wantNewTag:      "v1.1.0",
+			wantMessage:     "Generated by updatecli",
+			wantPushedTag:   "v1.1.0",
+			wantDescription: "Generated by updatecli",
+		},
+		{
+			name:            "Tag already exists",
+			source:          "v1.0.0",
+			tags:            []string{"v1.0.0"},
+			wantDescription: `git tag "v1.0.0" already exists`,
+		},
+		{
+			name:            "Dry run",
+			source:          "v1.1.0",
+			tags:            []string{"v1.0.0"},
+			dryRun:          true,
+			wantChanged:     true,
+			wantDescription: `git tag "v1.1.0" should be created`,
+		},
+		{
+			name:    "Version filter pattern is not allowed",
+			source:  "v1.1.0",
+			spec:    Spec{VersionFilter: version.Filter{Kind: "semver", Pattern: "~1"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gt, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gitHandler := &mockTargetGitHandler{tags: tt.tags}
+			gt.nativeGitHandler = gitHandler
+
+			s := &mockTagScm{MockScm: scm.MockScm{WorkingDir: t.TempDir()}}
+
+			gotResult := result.Target{}
+			err = gt.Target(tt.source, s, tt.dryRun, &gotResult)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantChanged, gotResult.Changed)
+			assert.Equal(t, tt.wantDescription, gotResult.Description)
+			assert.Equal(t, tt.wantNewTag, gitHandler.newTag)
+			assert.Equal(t, tt.wantMessage, gitHandler.newMessage)
+			assert.Equal(t, tt.wantPushedTag, s.pushedTag)
+		})
+	}
+}
@@ -6,10 +6,8 @@ import (
 	"os"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/hexops/gotextdiff"
-	"github.com/hexops/gotextdiff/myers"
-	"github.com/hexops/gotextdiff/span"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/text"
 	"golang.org/x/mod/modfile"
 )
 
@@ -131,8 +129,10 @@ func (g *GoMod) setVersion(version, filename string, dryrun bool) (oldVersion, n
 		return oldVersion, newVersion, changed, fmt.Errorf("failed formatting %q", filename)
 	}
 
-	edits := myers.ComputeEdits(span.URIFromPath(filename), string(oldContent), string(newContent))
-	logrus.Debugf("\n---\n%v\n---\n", gotextdiff.ToUnified("old", "new", string(oldContent), edits))
+	if changed {
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(filename, filename, string(oldContent), string(newContent)))
+	}
 
 	if !changed || dryrun {
 		return oldVersion, newVersion, changed, nil
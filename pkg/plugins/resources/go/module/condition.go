@@ -32,5 +32,18 @@ func (g *GoModule) Condition(source string, scm scm.ScmHandler, resultCondition
 		}
 	}
 
+	// Pseudo-versions aren't listed by go proxies
+	published, err := g.isPublished(versionToCheck)
+	if err != nil {
+		return fmt.Errorf("searching version: %w", err)
+	}
+
+	if published {
+		resultCondition.Pass = true
+		resultCondition.Result = result.SUCCESS
+		resultCondition.Description = fmt.Sprintf("version %q available", versionToCheck)
+		return nil
+	}
+
 	return fmt.Errorf("version %q doesn't exist", versionToCheck)
 }
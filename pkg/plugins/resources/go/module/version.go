@@ -1,78 +1,95 @@
 package gomodule
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"os"
-	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
 )
 
-// GetVersions fetch all versions of a Golang module
-func (g *GoModule) versions() (v string, versions []string, err error) {
+// errProxyNotFound is returned when a go proxy doesn't know the requested module or version
+var errProxyNotFound = errors.New("not found on go proxy")
+
+// proxyInfo defines the version information returned by the go proxy "@latest" and "@v/<version>.info" endpoints
+type proxyInfo struct {
+	Version string
+}
 
-	var GOPROXY string
+// goProxy returns the GOPROXY value used to query go modules
+func (g *GoModule) goProxy() string {
 	if g.Spec.Proxy != "" {
-		GOPROXY = g.Spec.Proxy
+		return g.Spec.Proxy
 	} else if os.Getenv("GOPROXY") != "" {
-		GOPROXY = os.Getenv("GOPROXY")
-	} else {
-		GOPROXY = goModuleDefaultProxy
+		return os.Getenv("GOPROXY")
 	}
+	return goModuleDefaultProxy
+}
 
-	for _, proxy := range strings.Split(GOPROXY, ",") {
+// goProxies returns the supported go proxies, in the order they must be queried
+func (g *GoModule) goProxies() (proxies []string) {
+	// Updatecli falls back to the next proxy on any error, so "," and "|" separators are handled the same way
+	for _, proxy := range strings.FieldsFunc(g.goProxy(), func(r rune) bool { return r == ',' || r == '|' }) {
 		if !isSupportedGoProxy(proxy) {
 			continue
 		}
+		proxies = append(proxies, sanitizeGoProxy(proxy))
+	}
+	return proxies
+}
 
-		URL, err := url.JoinPath(
-			sanitizeGoProxy(proxy),
-			sanitizeGoModuleNameForProxy(g.Spec.Module),
-			"@v", "list")
-		if err != nil {
-			logrus.Errorf("something went wrong while getting go module api data %q\n", err)
-			return "", []string{}, err
-		}
-
-		req, err := http.NewRequest("GET", URL, nil)
-		if err != nil {
-			logrus.Errorf("something went wrong while getting go module api data %q\n", err)
-			return "", []string{}, err
-		}
-
-		res, err := g.webClient.Do(req)
-		if err != nil {
-			logrus.Errorf("something went wrong while getting go module api data %q\n", err)
-			return "", []string{}, err
-		}
-
-		defer res.Body.Close()
-		if res.StatusCode >= 400 {
-			body, err := httputil.DumpResponse(res, false)
-			logrus.Errorf("something went wrong while getting golang module data %q\n", err)
-			logrus.Debugf("skipping proxy %q due to %q\n", proxy, err)
-			logrus.Debugf("\n%v\n", string(body))
+// GetVersions fetch all versions of a Golang module
+func (g *GoModule) versions() (v string, versions []string, err error) {
+	for _, proxy := range g.goProxies() {
+		data, err := g.proxyGet(proxy, "@v", "list")
+		if errors.Is(err, errProxyNotFound) {
+			logrus.Debugf("skipping proxy %q: %s", proxy, err)
 			continue
-		}
-
-		data, err := io.ReadAll(res.Body)
-		if err != nil {
-			logrus.Errorf("something went wrong while getting npm api data%q\n", err)
+		} else if err != nil {
 			return "", []string{}, err
 		}
 
 		/*
 			The response should be a list of version separated by \n
 			as explained on https://go.dev/ref/mod#goproxy-protocol
+			It doesn't contain pseudo-versions.
 		*/
-		versions = append(versions, strings.Split(string(data), "\n")...)
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if semver.IsValid(line) {
+				versions = append(versions, line)
+			}
+		}
 
-		sort.Strings(versions)
+		if len(versions) == 0 {
+			// A module without any tagged version can only be retrieved using a pseudo-version
+			latest, err := g.proxyInfo(proxy, "@latest")
+			if errors.Is(err, errProxyNotFound) {
+				logrus.Debugf("skipping proxy %q: %s", proxy, err)
+				continue
+			} else if err != nil {
+				return "", []string{}, err
+			}
+
+			versions = append(versions, latest.Version)
+
+			// Pseudo-versions are pre-releases, ignored by the default semantic versioning filter
+			if g.Spec.VersionFilter.IsZero() {
+				g.Version.OriginalVersion = latest.Version
+				g.Version.ParsedVersion = latest.Version
+				return latest.Version, versions, nil
+			}
+		}
+
+		// +incompatible versions are sorted according to their semantic version, ignoring the build metadata
+		semver.Sort(versions)
 		g.Version, err = g.versionFilter.Search(versions)
 		if err != nil {
 			return "", nil, err
@@ -82,5 +99,76 @@ func (g *GoModule) versions() (v string, versions []string, err error) {
 
 	}
 
-	return "", nil, fmt.Errorf("GO module %q not found on proxy %q", g.Spec.Module, GOPROXY)
+	return "", nil, fmt.Errorf("GO module %q not found on proxy %q", g.Spec.Module, g.goProxy())
+}
+
+// isPublished checks if a go proxy knows a specific version of the go module, such as a pseudo-version
+// which isn't listed by the go proxy
+func (g *GoModule) isPublished(version string) (bool, error) {
+	for _, proxy := range g.goProxies() {
+		info, err := g.proxyInfo(proxy, "@v", version+".info")
+		if errors.Is(err, errProxyNotFound) {
+			logrus.Debugf("skipping proxy %q: %s", proxy, err)
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		return info.Version == version, nil
+	}
+
+	return false, nil
+}
+
+// proxyInfo returns the version information returned by a go proxy endpoint
+func (g *GoModule) proxyInfo(proxy string, elem ...string) (proxyInfo, error) {
+	var info proxyInfo
+
+	data, err := g.proxyGet(proxy, elem...)
+	if err != nil {
+		return info, err
+	}
+
+	err = json.Unmarshal(data, &info)
+	if err != nil {
+		return info, fmt.Errorf("decoding go proxy response: %w", err)
+	}
+
+	return info, nil
+}
+
+// proxyGet returns the body of a go proxy endpoint for the go module
+func (g *GoModule) proxyGet(proxy string, elem ...string) ([]byte, error) {
+	URL, err := url.JoinPath(proxy, append([]string{sanitizeGoModuleNameForProxy(g.Spec.Module)}, elem...)...)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting go module api data %q\n", err)
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting go module api data %q\n", err)
+		return nil, err
+	}
+
+	res, err := g.webClient.Do(req)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting go module api data %q\n", err)
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		body, _ := httputil.DumpResponse(res, false)
+		logrus.Debugf("\n%v\n", string(body))
+		return nil, fmt.Errorf("%s: %w", URL, errProxyNotFound)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting go module api data %q\n", err)
+		return nil, err
+	}
+
+	return data, nil
 }
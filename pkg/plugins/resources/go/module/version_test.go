@@ -0,0 +1,169 @@
+package gomodule
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+const testPseudoVersion = "v0.0.0-20230920143941-7cbd4c7f9b63"
+
+// newTestGoProxy returns a go proxy serving a module with tagged versions, "example.com/tagged",
+// and a module only available using a pseudo-version, "example.com/untagged"
+func newTestGoProxy(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/example.com/tagged/@v/list":
+			fmt.Fprint(w, "v0.9.0\nv0.10.0\nv2.0.0+incompatible\nv1.0.0\n")
+		case "/example.com/untagged/@v/list":
+			fmt.Fprint(w, "")
+		case "/example.com/untagged/@latest", "/example.com/untagged/@v/" + testPseudoVersion + ".info":
+			fmt.Fprintf(w, `{"Version":%q,"Time":"2023-09-20T14:39:41Z"}`, testPseudoVersion)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVersionsFromProxy(t *testing.T) {
+	server := newTestGoProxy(t)
+
+	tests := []struct {
+		name           string
+		spec           Spec
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name: "Latest semantic version, including +incompatible ones",
+			spec: Spec{
+				Module: "example.com/tagged",
+			},
+			expectedResult: "v2.0.0+incompatible",
+		},
+		{
+			name: "Latest version sorted according to semantic versioning",
+			spec: Spec{
+				Module: "example.com/tagged",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~0",
+				},
+			},
+			expectedResult: "v0.10.0",
+		},
+		{
+			name: "Latest version using the latest filter",
+			spec: Spec{
+				Module: "example.com/tagged",
+				VersionFilter: version.Filter{
+					Kind:    "latest",
+					Pattern: "latest",
+				},
+			},
+			expectedResult: "v2.0.0+incompatible",
+		},
+		{
+			name: "Pseudo-version of a module without tagged version",
+			spec: Spec{
+				Module: "example.com/untagged",
+			},
+			expectedResult: testPseudoVersion,
+		},
+		{
+			name: "Skip unsupported proxies",
+			spec: Spec{
+				Proxy:  "off|" + server.URL,
+				Module: "example.com/tagged",
+			},
+			expectedResult: "v2.0.0+incompatible",
+		},
+		{
+			name: "Unknown module",
+			spec: Spec{
+				Module: "example.com/unknown",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.spec.Proxy == "" {
+				tt.spec.Proxy = server.URL
+			}
+
+			got, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = got.Source("", &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
+
+func TestConditionFromProxy(t *testing.T) {
+	server := newTestGoProxy(t)
+
+	tests := []struct {
+		name          string
+		spec          Spec
+		expectedError bool
+	}{
+		{
+			name: "Tagged version",
+			spec: Spec{
+				Module:  "example.com/tagged",
+				Version: "v2.0.0+incompatible",
+			},
+		},
+		{
+			name: "Pseudo-version",
+			spec: Spec{
+				Module:  "example.com/untagged",
+				Version: testPseudoVersion,
+			},
+		},
+		{
+			name: "Unknown version",
+			spec: Spec{
+				Module:  "example.com/tagged",
+				Version: "v3.0.0",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Proxy = server.URL
+
+			got, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = got.Condition("", nil, &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, gotResult.Pass)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
 func (h *Hcl) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
@@ -69,12 +70,14 @@ func (h *Hcl) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarge
 			valueToWrite,
 			resourceFile.originalFilePath)
 
-		if !dryRun {
+		if err := h.Apply(fileKey, valueToWrite); err != nil {
+			return err
+		}
 
-			if err := h.Apply(fileKey, valueToWrite); err != nil {
-				return err
-			}
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, h.files[fileKey].content))
 
+		if !dryRun {
 			if err := h.contentRetriever.WriteToFile(
 				h.files[fileKey].content,
 				h.files[fileKey].filePath,
@@ -0,0 +1,122 @@
+package helm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// ociDependencyRegistries returns the OCI registries hosting the dependencies of the Helm chart located in chartPath
+func ociDependencyRegistries(chartPath string) ([]name.Registry, error) {
+	metadata, err := chartutil.LoadChartfile(filepath.Join(chartPath, chartutil.ChartfileName))
+	if err != nil {
+		return nil, err
+	}
+
+	registries := []name.Registry{}
+	found := map[string]bool{}
+
+	for _, dependency := range metadata.Dependencies {
+		if !strings.HasPrefix(dependency.Repository, "oci://") {
+			continue
+		}
+
+		repo, err := name.NewRepository(strings.TrimPrefix(dependency.Repository, "oci://"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid OCI repository %q for Helm chart dependency %q: %w",
+				dependency.Repository, dependency.Name, err)
+		}
+
+		if found[repo.RegistryStr()] {
+			continue
+		}
+		found[repo.RegistryStr()] = true
+
+		registries = append(registries, repo.Registry)
+	}
+
+	return registries, nil
+}
+
+/*
+writeOCICredentialsFile writes to filename the Helm registry credentials file credentialsFile,
+completed with the credentials resolved by keychain for registries, so the Helm registry client
+can authenticate with every OCI registry hosting a chart dependency.
+It returns false, without writing filename, if keychain doesn't resolve any credentials.
+*/
+func writeOCICredentialsFile(filename, credentialsFile string, keychain authn.Keychain, registries []name.Registry) (bool, error) {
+	config := map[string]interface{}{}
+
+	data, err := os.ReadFile(credentialsFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &config); err != nil {
+			return false, fmt.Errorf("parsing Helm registry credentials file %q: %w", credentialsFile, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return false, err
+	}
+
+	auths, ok := config["auths"].(map[string]interface{})
+	if !ok {
+		auths = map[string]interface{}{}
+	}
+
+	resolved := false
+	for _, registry := range registries {
+		authenticator, err := keychain.Resolve(registry)
+		if err != nil {
+			return false, fmt.Errorf("resolving credentials for OCI registry %q: %w", registry.RegistryStr(), err)
+		}
+
+		authConfig, err := authenticator.Authorization()
+		if err != nil {
+			return false, fmt.Errorf("resolving credentials for OCI registry %q: %w", registry.RegistryStr(), err)
+		}
+
+		if *authConfig == (authn.AuthConfig{}) {
+			continue
+		}
+
+		auth := map[string]string{}
+		if authConfig.Username != "" || authConfig.Password != "" {
+			auth["auth"] = base64.StdEncoding.EncodeToString([]byte(authConfig.Username + ":" + authConfig.Password))
+		}
+		if authConfig.IdentityToken != "" {
+			auth["identitytoken"] = authConfig.IdentityToken
+		}
+		if authConfig.RegistryToken != "" {
+			auth["registrytoken"] = authConfig.RegistryToken
+		}
+
+		logrus.Debugf("using credentials for OCI registry %q", registry.RegistryStr())
+		auths[registry.RegistryStr()] = auth
+		resolved = true
+	}
+
+	if !resolved {
+		return false, nil
+	}
+
+	config["auths"] = auths
+
+	data, err = json.Marshal(config)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
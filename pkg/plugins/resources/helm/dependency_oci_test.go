@@ -0,0 +1,65 @@
+package helm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
+)
+
+func TestWriteOCICredentialsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	chart := `apiVersion: v2
+name: test
+version: 0.1.0
+dependencies:
+  - name: minio
+    version: 1.0.0
+    repository: https://charts.min.io/
+  - name: epinio
+    version: 1.0.0
+    repository: oci://ghcr.io/olblak/charts
+  - name: podinfo
+    version: 6.0.0
+    repository: oci://ghcr.io/stefanprodan/charts
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(chart), 0o600))
+
+	registries, err := ociDependencyRegistries(dir)
+	require.NoError(t, err)
+	require.Len(t, registries, 1)
+	assert.Equal(t, "ghcr.io", registries[0].RegistryStr())
+
+	credentialsFile := filepath.Join(dir, "registry.json")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte(`{"auths": {"registry.example.com": {"auth": "xxx"}}}`), 0o600))
+
+	filename := filepath.Join(dir, "config.json")
+
+	// Without credentials, the Helm registry credentials file is used as is
+	written, err := writeOCICredentialsFile(filename, credentialsFile, authn.NewMultiKeychain(), registries)
+	require.NoError(t, err)
+	assert.False(t, written)
+
+	keychain := docker.InlineKeyChain{Username: "john", Password: "secret"}
+	written, err = writeOCICredentialsFile(filename, credentialsFile, keychain, registries)
+	require.NoError(t, err)
+	require.True(t, written)
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	got := map[string]map[string]map[string]string{}
+	require.NoError(t, json.Unmarshal(data, &got))
+
+	assert.Equal(t, map[string]map[string]string{
+		"registry.example.com": {"auth": "xxx"},
+		"ghcr.io":              {"auth": base64.StdEncoding.EncodeToString([]byte("john:secret"))},
+	}, got["auths"])
+}
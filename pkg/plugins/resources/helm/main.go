@@ -1,7 +1,6 @@
 package helm
 
 import (
-	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/mitchellh/mapstructure"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
@@ -183,15 +182,7 @@ func New(spec interface{}) (*Chart, error) {
 		return nil, err
 	}
 
-	keychains := []authn.Keychain{}
-
-	if !newSpec.InlineKeyChain.Empty() {
-		keychains = append(keychains, newSpec.InlineKeyChain)
-	}
-
-	keychains = append(keychains, authn.DefaultKeychain)
-
-	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(authn.NewMultiKeychain(keychains...)))
+	newResource.options = append(newResource.options, remote.WithAuthFromKeychain(newSpec.InlineKeyChain.Keychain()))
 
 	return newResource, nil
 }
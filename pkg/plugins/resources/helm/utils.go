@@ -32,10 +32,16 @@ func (c *Chart) DependencyUpdate(out *bytes.Buffer, chartPath string) error {
 	client := action.NewDependency()
 	settings := cli.New()
 
+	credentialsFile, cleanup, err := c.ociCredentialsFile(chartPath, settings.RegistryConfig)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
 	registryClient, err := registry.NewClient(
 		registry.ClientOptDebug(settings.Debug),
 		registry.ClientOptWriter(out),
-		registry.ClientOptCredentialsFile(settings.RegistryConfig),
+		registry.ClientOptCredentialsFile(credentialsFile),
 	)
 	if err != nil {
 		return err
@@ -62,6 +68,46 @@ func (c *Chart) DependencyUpdate(out *bytes.Buffer, chartPath string) error {
 	return nil
 }
 
+// ociCredentialsFile returns the Helm registry credentials file used to update the dependencies of the chart located in chartPath,
+// which is a temporary copy of credentialsFile completed with the spec credentials if the chart has OCI dependencies.
+// The returned cleanup function removes the temporary file.
+func (c *Chart) ociCredentialsFile(chartPath, credentialsFile string) (string, func(), error) {
+	nop := func() {}
+
+	registries, err := ociDependencyRegistries(chartPath)
+	if err != nil {
+		return "", nop, fmt.Errorf("retrieving Helm chart OCI dependencies: %w", err)
+	}
+
+	if len(registries) == 0 {
+		return credentialsFile, nop, nil
+	}
+
+	dir, err := os.MkdirTemp("", "updatecli-helm-registry-")
+	if err != nil {
+		return "", nop, err
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Debugln(err)
+		}
+	}
+
+	filename := filepath.Join(dir, "config.json")
+	written, err := writeOCICredentialsFile(filename, credentialsFile, c.spec.InlineKeyChain.Keychain(), registries)
+	if err != nil {
+		cleanup()
+		return "", nop, err
+	}
+
+	if !written {
+		cleanup()
+		return credentialsFile, nop, nil
+	}
+
+	return filename, cleanup, nil
+}
+
 // GetRepoIndexFromFile loads an index file from a local file and does minimal validity checking.
 // It fails if API Version isn't set (ErrNoAPIVersion) or if the "unmarshal" operation fails.
 func (c *Chart) GetRepoIndexFromFile(rootDir string) (repo.IndexFile, error) {
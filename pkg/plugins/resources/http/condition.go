@@ -0,0 +1,36 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that the value extracted from the http response is the source value
+func (h *HTTP) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	if source == "" {
+		return errors.New("no value to check defined")
+	}
+
+	values, err := h.values()
+	if err != nil {
+		return fmt.Errorf("searching http value: %w", err)
+	}
+
+	for _, value := range values {
+		if value == source {
+			resultCondition.Pass = true
+			resultCondition.Result = result.SUCCESS
+			resultCondition.Description = fmt.Sprintf("value %q found from %q", source, h.spec.URL)
+			return nil
+		}
+	}
+
+	resultCondition.Pass = false
+	resultCondition.Result = result.FAILURE
+	resultCondition.Description = fmt.Sprintf("value %q not found from %q", source, h.spec.URL)
+
+	return nil
+}
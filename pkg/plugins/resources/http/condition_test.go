@@ -0,0 +1,63 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		spec          Spec
+		source        string
+		expectedPass  bool
+		expectedError bool
+	}{
+		{
+			name: "Source value returned by the query",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[*].tag_name",
+			},
+			source:       "v1.2.0",
+			expectedPass: true,
+		},
+		{
+			name: "Source value not returned by the query",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[*].tag_name",
+			},
+			source: "v1.4.0",
+		},
+		{
+			name: "No source value",
+			spec: Spec{
+				URL: server.URL + "/version.txt",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = got.Condition(tt.source, nil, &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPass, gotResult.Pass)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/dasel"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+// HTTP defines a resource of type "http"
+type HTTP struct {
+	spec Spec
+	// versionFilter holds the "valid" version.filter, that might be different from the user-specified filter (Spec.VersionFilter)
+	versionFilter version.Filter
+	// query holds the Dasel query, converted from the JSONPath expression if needed
+	query     string
+	webClient httpclient.HTTPClient
+}
+
+// New returns a reference to a newly initialized HTTP object from a http.Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*HTTP, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	newFilter, err := newSpec.VersionFilter.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	query := newSpec.Query
+	if dasel.IsJSONPath(query) {
+		query, err = dasel.JSONPathToDasel(query)
+		if err != nil {
+			return nil, err
+		}
+		logrus.Debugf("JSONPath %q converted to the Dasel query %q", newSpec.Query, query)
+	}
+
+	webClient, err := httpclient.NewClient(
+		httpclient.TransportSpec{InsecureSkipVerify: newSpec.SkipTLSVerify},
+		httpclient.RetryPolicy{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTP{
+		spec:          newSpec,
+		versionFilter: newFilter,
+		query:         query,
+		webClient:     webClient,
+	}, nil
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (h *HTTP) Changelog() string {
+	return ""
+}
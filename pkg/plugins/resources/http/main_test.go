@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const releasesData = `[
+  {"tag_name": "v1.3.0-rc.1", "prerelease": true},
+  {"tag_name": "v1.2.0", "prerelease": false},
+  {"tag_name": "v1.1.0", "prerelease": false}
+]`
+
+const releasesYAMLData = `releases:
+  - version: 2.1.0
+  - version: 2.0.0
+`
+
+// newTestServer returns a server answering the path /releases.json, /releases.yaml and /version.txt
+// and requiring the header "Authorization: Bearer mytoken" on /private
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases.json":
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = w.Write([]byte(releasesData))
+		case "/releases.yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte(releasesYAMLData))
+		case "/version.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("stable: go1.21.3\n"))
+		case "/private":
+			if r.Header.Get("Authorization") != "Bearer mytoken" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"version": "3.0.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      Spec
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "Dasel query",
+			spec:      Spec{URL: "https://example.com", Query: ".tag_name"},
+			wantQuery: ".tag_name",
+		},
+		{
+			name:      "JSONPath expression",
+			spec:      Spec{URL: "https://example.com", Query: "$[0].tag_name"},
+			wantQuery: ".[0].tag_name",
+		},
+		{
+			name:    "Missing url",
+			spec:    Spec{Query: ".tag_name"},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported url scheme",
+			spec:    Spec{URL: "ftp://example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported format",
+			spec:    Spec{URL: "https://example.com", Query: ".tag_name", Format: "xml"},
+			wantErr: true,
+		},
+		{
+			name:    "Format without query",
+			spec:    Spec{URL: "https://example.com", Format: "json"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid regex",
+			spec:    Spec{URL: "https://example.com", Regex: "v(\\d+"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, got.query)
+		})
+	}
+}
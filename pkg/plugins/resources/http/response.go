@@ -0,0 +1,151 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/tomwright/dasel"
+	"github.com/tomwright/dasel/storage"
+)
+
+// value returns the value extracted from the response, selected by the version filter if defined
+func (h *HTTP) value() (string, error) {
+	values, err := h.values()
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) == 0 {
+		return "", fmt.Errorf("no value found in the response of %q", h.spec.URL)
+	}
+
+	if h.spec.VersionFilter.IsZero() {
+		return values[0], nil
+	}
+
+	found, err := h.versionFilter.Search(values)
+	if err != nil {
+		return "", err
+	}
+
+	return found.GetVersion(), nil
+}
+
+// values returns every value extracted from the response by the query and the regex
+func (h *HTTP) values() ([]string, error) {
+	body, contentType, err := h.get()
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{strings.TrimSpace(string(body))}
+
+	if h.query != "" {
+		values, err = h.queryValues(body, contentType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if h.spec.Regex == "" {
+		return values, nil
+	}
+
+	re := regexp.MustCompile(h.spec.Regex)
+
+	var matches []string
+	for _, value := range values {
+		for _, found := range re.FindAllStringSubmatch(value, -1) {
+			// Retrieve the first capture group if any
+			if len(found) > 1 {
+				matches = append(matches, found[1])
+				continue
+			}
+			matches = append(matches, found[0])
+		}
+	}
+
+	return matches, nil
+}
+
+// queryValues decodes the response body and returns every value matching the query
+func (h *HTTP) queryValues(body []byte, contentType string) ([]string, error) {
+	format := h.spec.Format
+	if format == "" {
+		format = formatFromContentType(contentType)
+	}
+
+	parser, err := storage.NewReadParserFromString(format)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parser.FromBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s response of %q: %w", format, h.spec.URL, err)
+	}
+
+	nodes, err := dasel.New(data).QueryMultiple(h.query)
+	if err != nil {
+		return nil, fmt.Errorf("querying %q in the response of %q: %w", h.spec.Query, h.spec.URL, err)
+	}
+
+	values := []string{}
+	for _, node := range nodes {
+		values = append(values, node.String())
+	}
+
+	return values, nil
+}
+
+// formatFromContentType returns the format matching the response content type,
+// defaulting to "yaml" which also decodes JSON documents
+func formatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return yamlFormat
+	}
+
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return jsonFormat
+	}
+
+	return yamlFormat
+}
+
+// get returns the body and the content type of the response to a GET request on the url
+func (h *HTTP) get() ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, h.spec.URL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for key, value := range h.spec.Headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := h.webClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("requesting %q: %w", h.spec.URL, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		dump, _ := httputil.DumpResponse(res, false)
+		logrus.Debugf("\n%v\n", string(dump))
+		return nil, "", fmt.Errorf("requesting %q: unexpected status %q", h.spec.URL, res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading response of %q: %w", h.spec.URL, err)
+	}
+
+	return body, res.Header.Get("Content-Type"), nil
+}
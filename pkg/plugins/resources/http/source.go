@@ -0,0 +1,21 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Source returns the value extracted from the http response
+func (h *HTTP) Source(workingDir string, resultSource *result.Source) error {
+	value, err := h.value()
+	if err != nil {
+		return fmt.Errorf("searching http value: %w", err)
+	}
+
+	resultSource.Information = value
+	resultSource.Result = result.SUCCESS
+	resultSource.Description = fmt.Sprintf("value %q found from %q", value, h.spec.URL)
+
+	return nil
+}
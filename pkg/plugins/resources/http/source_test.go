@@ -0,0 +1,139 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+func TestSource(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		spec           Spec
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name: "Retrieve the first release from a JSON response",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[0].tag_name",
+			},
+			expectedResult: "v1.3.0-rc.1",
+		},
+		{
+			name: "Retrieve the first release using a JSONPath expression",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: `$[?(@.prerelease=="false")].tag_name`,
+			},
+			expectedResult: "v1.2.0",
+		},
+		{
+			name: "Retrieve the latest release matching a semver constraint",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[*].tag_name",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~1.1",
+				},
+			},
+			expectedResult: "v1.1.0",
+		},
+		{
+			name: "Retrieve a version from a YAML response",
+			spec: Spec{
+				URL:   server.URL + "/releases.yaml",
+				Query: ".releases.[0].version",
+			},
+			expectedResult: "2.1.0",
+		},
+		{
+			name: "Retrieve a version from a text response using a regex",
+			spec: Spec{
+				URL:   server.URL + "/version.txt",
+				Regex: `go(\d+\.\d+\.\d+)`,
+			},
+			expectedResult: "1.21.3",
+		},
+		{
+			name: "Retrieve a version using a query and a regex",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[1].tag_name",
+				Regex: `^v(.*)$`,
+			},
+			expectedResult: "1.2.0",
+		},
+		{
+			name: "Retrieve the raw response",
+			spec: Spec{
+				URL: server.URL + "/version.txt",
+			},
+			expectedResult: "stable: go1.21.3",
+		},
+		{
+			name: "Retrieve a version using an authorization header",
+			spec: Spec{
+				URL:     server.URL + "/private",
+				Query:   ".version",
+				Headers: map[string]string{"Authorization": "Bearer mytoken"},
+			},
+			expectedResult: "3.0.0",
+		},
+		{
+			name: "Fail without authorization header",
+			spec: Spec{
+				URL:   server.URL + "/private",
+				Query: ".version",
+			},
+			expectedError: true,
+		},
+		{
+			name: "Fail on a missing query result",
+			spec: Spec{
+				URL:   server.URL + "/releases.json",
+				Query: ".[0].name",
+			},
+			expectedError: true,
+		},
+		{
+			name: "Fail on a regex without match",
+			spec: Spec{
+				URL:   server.URL + "/version.txt",
+				Regex: `rust(\d+)`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "Fail on a missing page",
+			spec: Spec{
+				URL: server.URL + "/missing",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = got.Source("", &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
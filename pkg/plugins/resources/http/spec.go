@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+var (
+	// ErrSpecURLUndefined is returned if no url is defined
+	ErrSpecURLUndefined = errors.New("http url not defined")
+	// ErrSpecQueryAndFormat is returned if a format is defined without query
+	ErrSpecQueryAndFormat = errors.New("http format is only used with a query")
+)
+
+const (
+	// jsonFormat defines a response decoded as JSON
+	jsonFormat string = "json"
+	// yamlFormat defines a response decoded as YAML
+	yamlFormat string = "yaml"
+)
+
+// Spec defines a specification for a "http" resource
+// parsed from an updatecli manifest file
+type Spec struct {
+	// [S][C] URL defines the url requested with a GET request
+	URL string `yaml:",omitempty" jsonschema:"required"`
+	// [S][C] Headers defines additional http headers, such as an authorization header
+	//
+	// example:
+	//   headers:
+	//     Authorization: 'Bearer {{ requiredEnv "GITHUB_TOKEN" }}'
+	Headers map[string]string `yaml:",omitempty"`
+	// [S][C] SkipTLSVerify disables the verification of the server certificate
+	SkipTLSVerify bool `yaml:",omitempty"`
+	// [S][C] Format defines how the response is decoded before running the query, accepted values are "json" and "yaml".
+	//
+	// It defaults to the response content type, or "yaml" which also decodes JSON documents.
+	Format string `yaml:",omitempty"`
+	// [S][C] Query defines the Dasel query, such as ".tag_name" or ".[*].version", or the JSONPath expression,
+	// such as "$.releases[0].version", extracting the value from the response
+	Query string `yaml:",omitempty"`
+	// [S][C] Regex defines the regular expression extracting the value from the response, or from the query result.
+	//
+	// If the regular expression contains a capture group, then the value of the first capture group is retrieved
+	Regex string `yaml:",omitempty"`
+	// [S] VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest
+	// to select a value among every value returned by the query or the regex.
+	//
+	// If undefined, then the first value is retrieved
+	VersionFilter version.Filter `yaml:",omitempty"`
+}
+
+// Validate ensures that the provided Spec is valid
+func (s Spec) Validate() error {
+	if s.URL == "" {
+		return ErrSpecURLUndefined
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("parsing http url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("http url scheme %q not supported, accepted values are %q and %q", u.Scheme, "http", "https")
+	}
+
+	switch s.Format {
+	case "", jsonFormat, yamlFormat:
+	default:
+		return fmt.Errorf("http format %q not supported, accepted values are %q and %q", s.Format, jsonFormat, yamlFormat)
+	}
+
+	if s.Format != "" && s.Query == "" {
+		return ErrSpecQueryAndFormat
+	}
+
+	if s.Regex != "" {
+		if _, err := regexp.Compile(s.Regex); err != nil {
+			return fmt.Errorf("compiling http regex: %w", err)
+		}
+	}
+
+	return nil
+}
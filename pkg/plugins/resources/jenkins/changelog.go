@@ -6,6 +6,10 @@ import (
 
 // Changelog returns the link to the found Jenkins version's changelog
 func (j Jenkins) Changelog() string {
+	if j.spec.Plugin != "" {
+		return fmt.Sprintf("Jenkins plugin changelog is available at: https://plugins.jenkins.io/%s/releases/\n", j.spec.Plugin)
+	}
+
 	var changelogURI string
 	switch j.spec.Release {
 	case WEEKLY:
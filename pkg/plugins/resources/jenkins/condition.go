@@ -22,6 +22,10 @@ func (j Jenkins) Condition(source string, scm scm.ScmHandler, resultCondition *r
 		versionToCheck = source
 	}
 
+	if j.spec.Plugin != "" {
+		return j.pluginCondition(versionToCheck, resultCondition)
+	}
+
 	releaseType, err := ReleaseType(versionToCheck)
 	if err != nil {
 		return err
@@ -54,3 +58,29 @@ func (j Jenkins) Condition(source string, scm scm.ScmHandler, resultCondition *r
 	resultCondition.Description = fmt.Sprintf("version %q doesn't exist", versionToCheck)
 	return nil
 }
+
+// pluginCondition checks that a Jenkins plugin version is published on the update center
+func (j Jenkins) pluginCondition(versionToCheck string, resultCondition *result.Condition) error {
+	if versionToCheck == "" {
+		return fmt.Errorf("no version defined for the Jenkins plugin %q", j.spec.Plugin)
+	}
+
+	versions, err := j.getPluginVersions()
+	if err != nil {
+		return fmt.Errorf("searching jenkins plugin version: %w", err)
+	}
+
+	for _, v := range versions {
+		if v == versionToCheck {
+			resultCondition.Result = result.SUCCESS
+			resultCondition.Pass = true
+			resultCondition.Description = fmt.Sprintf("version %q available for the Jenkins plugin %q", versionToCheck, j.spec.Plugin)
+			return nil
+		}
+	}
+
+	resultCondition.Result = result.FAILURE
+	resultCondition.Pass = false
+	resultCondition.Description = fmt.Sprintf("version %q doesn't exist for the Jenkins plugin %q", versionToCheck, j.spec.Plugin)
+	return nil
+}
@@ -2,11 +2,14 @@ package jenkins
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/core/text"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/mavenmetadata"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -18,12 +21,20 @@ type Spec struct {
 	Release string `yaml:",omitempty"`
 	// [s][c] Defines a specific release version (condition only)
 	Version string `yaml:",omitempty"`
+	// [s][c][t] Defines the Jenkins plugin id, such as "git", to retrieve the plugin version instead of the Jenkins version
+	Plugin string `yaml:",omitempty"`
+	// [s][c] Defines the Jenkins update center url (defaults to "https://updates.jenkins.io")
+	UpdateCenter string `yaml:",omitempty"`
+	// [t] Defines the plugins.txt file, used by Docker-based Jenkins builds, where the plugin version is updated
+	File string `yaml:",omitempty"`
 }
 
 // Jenkins defines a resource of kind "githubrelease"
 type Jenkins struct {
 	spec             Spec
 	mavenMetaHandler mavenmetadata.Handler
+	webClient        httpclient.HTTPClient
+	contentRetriever text.TextRetriever
 	foundVersion     string
 }
 
@@ -36,6 +47,8 @@ const (
 	WRONG string = "unknown"
 	// URL of the default Jenkins Maven metadata file
 	jenkinsDefaultMetaURL string = "https://repo.jenkins-ci.org/releases/org/jenkins-ci/main/jenkins-war/maven-metadata.xml"
+	// URL of the default Jenkins update center
+	jenkinsDefaultUpdateCenterURL string = "https://updates.jenkins.io"
 )
 
 // New returns a new valid GitHubRelease object.
@@ -59,6 +72,8 @@ func New(spec interface{}) (*Jenkins, error) {
 	return &Jenkins{
 		spec:             newSpec,
 		mavenMetaHandler: mavenmetadata.New(jenkinsDefaultMetaURL, version.Filter{}),
+		webClient:        http.DefaultClient,
+		contentRetriever: &text.Text{},
 	}, nil
 }
 
@@ -2,11 +2,13 @@ package jenkins
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/text"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/mavenmetadata"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -90,6 +92,8 @@ func Test_New(t *testing.T) {
 					Release: STABLE,
 				},
 				mavenMetaHandler: mavenmetadata.New(jenkinsDefaultMetaURL, version.Filter{}),
+				webClient:        http.DefaultClient,
+				contentRetriever: &text.Text{},
 			},
 		},
 		{
@@ -102,6 +106,8 @@ func Test_New(t *testing.T) {
 					Release: WEEKLY,
 				},
 				mavenMetaHandler: mavenmetadata.New(jenkinsDefaultMetaURL, version.Filter{}),
+				webClient:        http.DefaultClient,
+				contentRetriever: &text.Text{},
 			},
 		},
 		{
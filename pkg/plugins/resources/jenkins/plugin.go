@@ -0,0 +1,89 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// updateCenter defines the part of the update center JSON used to retrieve the latest plugin versions
+type updateCenter struct {
+	Plugins map[string]struct {
+		Version string `json:"version"`
+	} `json:"plugins"`
+}
+
+// pluginVersions defines the part of the plugin versions JSON used to retrieve every published plugin version
+type pluginVersions struct {
+	Plugins map[string]map[string]json.RawMessage `json:"plugins"`
+}
+
+// getLatestPluginVersion returns the latest version of the plugin published on the update center
+func (j *Jenkins) getLatestPluginVersion() (string, error) {
+	var u updateCenter
+	if err := j.getUpdateCenterData("update-center.actual.json", &u); err != nil {
+		return "", err
+	}
+
+	plugin, ok := u.Plugins[j.spec.Plugin]
+	if !ok || plugin.Version == "" {
+		return "", fmt.Errorf("jenkins plugin %q not found on the update center", j.spec.Plugin)
+	}
+
+	return plugin.Version, nil
+}
+
+// getPluginVersions returns every version of the plugin published on the update center
+func (j *Jenkins) getPluginVersions() ([]string, error) {
+	var p pluginVersions
+	if err := j.getUpdateCenterData("plugin-versions.json", &p); err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for v := range p.Plugins[j.spec.Plugin] {
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// getUpdateCenterData decodes the update center JSON file into v
+func (j *Jenkins) getUpdateCenterData(file string, v interface{}) error {
+	baseURL := j.spec.UpdateCenter
+	if baseURL == "" {
+		baseURL = jenkinsDefaultUpdateCenterURL
+	}
+
+	URL, err := url.JoinPath(baseURL, file)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := j.webClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("retrieving jenkins update center data: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		body, _ := httputil.DumpResponse(res, false)
+		logrus.Debugf("\n%v\n", string(body))
+		return fmt.Errorf("jenkins update center %q returned %q", URL, res.Status)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding jenkins update center data: %w", err)
+	}
+
+	return nil
+}
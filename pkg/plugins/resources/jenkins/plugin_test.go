@@ -0,0 +1,123 @@
+package jenkins
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func newUpdateCenterServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/update-center.actual.json":
+			_, _ = w.Write([]byte(`{"core": {"version": "2.430"}, "plugins": {"git": {"name": "git", "version": "5.2.1"}}}`))
+		case "/plugin-versions.json":
+			_, _ = w.Write([]byte(`{"plugins": {"git": {"5.2.0": {"version": "5.2.0"}, "5.2.1": {"version": "5.2.1"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestJenkins_PluginSource(t *testing.T) {
+	server := newUpdateCenterServer()
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		plugin  string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Latest git plugin version",
+			plugin: "git",
+			url:    server.URL,
+			want:   "5.2.1",
+		},
+		{
+			name:    "Unknown plugin",
+			plugin:  "doesnotexist",
+			url:     server.URL,
+			wantErr: true,
+		},
+		{
+			name:    "Unavailable update center",
+			plugin:  "git",
+			url:     server.URL + "/missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := New(Spec{Plugin: tt.plugin, UpdateCenter: tt.url})
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			gotErr := j.Source("", &gotResult)
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				return
+			}
+
+			require.NoError(t, gotErr)
+			assert.Equal(t, tt.want, gotResult.Information)
+			assert.Equal(t, "Jenkins plugin changelog is available at: https://plugins.jenkins.io/git/releases/\n", j.Changelog())
+		})
+	}
+}
+
+func TestJenkins_PluginCondition(t *testing.T) {
+	server := newUpdateCenterServer()
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		source   string
+		version  string
+		wantPass bool
+		wantErr  bool
+	}{
+		{
+			name:     "Published version from source",
+			source:   "5.2.0",
+			wantPass: true,
+		},
+		{
+			name:     "Published version from spec",
+			source:   "1.0.0",
+			version:  "5.2.1",
+			wantPass: true,
+		},
+		{
+			name:   "Unpublished version",
+			source: "6.0.0",
+		},
+		{
+			name:    "No version",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := New(Spec{Plugin: "git", Version: tt.version, UpdateCenter: server.URL})
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			gotErr := j.Condition(tt.source, nil, &gotResult)
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				return
+			}
+
+			require.NoError(t, gotErr)
+			assert.Equal(t, tt.wantPass, gotResult.Pass)
+		})
+	}
+}
@@ -10,6 +10,10 @@ import (
 
 // Source returns the latest Jenkins version based on release type
 func (j *Jenkins) Source(workingDir string, resultSource *result.Source) error {
+	if j.spec.Plugin != "" {
+		return j.pluginSource(resultSource)
+	}
+
 	latest, versions, err := j.getVersions()
 	if err != nil {
 		return fmt.Errorf("searching jenkins version: %w", err)
@@ -42,3 +46,19 @@ func (j *Jenkins) Source(workingDir string, resultSource *result.Source) error {
 
 	return nil
 }
+
+// pluginSource returns the latest Jenkins plugin version published on the update center
+func (j *Jenkins) pluginSource(resultSource *result.Source) error {
+	latest, err := j.getLatestPluginVersion()
+	if err != nil {
+		return fmt.Errorf("searching jenkins plugin version: %w", err)
+	}
+
+	j.foundVersion = latest
+
+	resultSource.Information = j.foundVersion
+	resultSource.Result = result.SUCCESS
+	resultSource.Description = fmt.Sprintf("version %q found for the Jenkins plugin %q", j.foundVersion, j.spec.Plugin)
+
+	return nil
+}
@@ -1,12 +1,95 @@
 package jenkins
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
+// Target updates the Jenkins plugin version in a plugins.txt file, as used by Docker-based Jenkins builds
 func (j Jenkins) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
-	return fmt.Errorf("Target not supported for the plugin Jenkins")
+	if j.spec.Plugin == "" || j.spec.File == "" {
+		return errors.New("Target only supported for a Jenkins plugin in a plugins.txt file, with the parameters \"plugin\" and \"file\"")
+	}
+
+	file := j.spec.File
+	if !filepath.IsAbs(file) && scm != nil {
+		file = filepath.Join(scm.GetDirectory(), file)
+		logrus.Debugf("Relative path detected: changing to absolute path from SCM: %q", file)
+	}
+
+	content, err := j.contentRetriever.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	newContent, oldVersion, err := setPluginVersion(content, j.spec.Plugin, source)
+	if err != nil {
+		return fmt.Errorf("updating %q: %w", file, err)
+	}
+
+	resultTarget.Information = oldVersion
+	resultTarget.NewInformation = source
+
+	if newContent == content {
+		resultTarget.Result = result.SUCCESS
+		resultTarget.Description = fmt.Sprintf("Jenkins plugin %q already set to version %q in %q", j.spec.Plugin, source, file)
+		return nil
+	}
+
+	resultTarget.Changed = true
+	resultTarget.Result = result.ATTENTION
+	resultTarget.Files = append(resultTarget.Files, file)
+	resultTarget.Description = fmt.Sprintf("Jenkins plugin %q updated from version %q to %q in %q", j.spec.Plugin, oldVersion, source, file)
+
+	logrus.Infof("```\n%s\n```\n", text.Diff(file, file, content, newContent))
+
+	if dryRun {
+		return nil
+	}
+
+	return j.contentRetriever.WriteToFile(newContent, file)
+}
+
+// setPluginVersion sets the version of the plugin in the content of a plugins.txt file,
+// where each line looks like "<plugin>:<version>" optionally followed by ":<url>",
+// and returns the new content with the previous version
+func setPluginVersion(content, plugin, version string) (newContent, oldVersion string, err error) {
+	lines := strings.Split(content, "\n")
+	found := false
+
+	for i, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+		if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(trimmedLine, ":", 3)
+		if fields[0] != plugin {
+			continue
+		}
+
+		found = true
+		if len(fields) > 1 {
+			oldVersion = fields[1]
+		}
+
+		newLine := plugin + ":" + version
+		if len(fields) == 3 {
+			newLine += ":" + fields[2]
+		}
+		lines[i] = strings.Replace(line, trimmedLine, newLine, 1)
+	}
+
+	if !found {
+		return content, "", fmt.Errorf("jenkins plugin %q not found", plugin)
+	}
+
+	return strings.Join(lines, "\n"), oldVersion, nil
 }
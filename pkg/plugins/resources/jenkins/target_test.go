@@ -0,0 +1,110 @@
+package jenkins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+const pluginsTxt = `# Plugins installed in the controller image
+git:5.2.0
+  workflow-aggregator:596.v8c21c963d92d
+configuration-as-code:1670.v564dc8b_982d0:https://updates.jenkins.io/download/plugins/configuration-as-code/1670.v564dc8b_982d0/configuration-as-code.hpi
+kubernetes
+`
+
+func TestJenkins_Target(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        Spec
+		source      string
+		wantChanged bool
+		wantOld     string
+		wantContent string
+		wantErr     bool
+	}{
+		{
+			name:        "Update plugin version",
+			spec:        Spec{Plugin: "git"},
+			source:      "5.2.1",
+			wantChanged: true,
+			wantOld:     "5.2.0",
+			wantContent: "git:5.2.1\n",
+		},
+		{
+			name:        "Update indented plugin version",
+			spec:        Spec{Plugin: "workflow-aggregator"},
+			source:      "600.vb_57cdd26fdd7",
+			wantChanged: true,
+			wantOld:     "596.v8c21c963d92d",
+			wantContent: "  workflow-aggregator:600.vb_57cdd26fdd7\n",
+		},
+		{
+			name:        "Update plugin version with url",
+			spec:        Spec{Plugin: "configuration-as-code"},
+			source:      "1700.v6f448841296e",
+			wantChanged: true,
+			wantOld:     "1670.v564dc8b_982d0",
+			wantContent: "configuration-as-code:1700.v6f448841296e:https://updates.jenkins.io/download/plugins/",
+		},
+		{
+			name:        "Pin plugin without version",
+			spec:        Spec{Plugin: "kubernetes"},
+			source:      "4029.v5712230ccb_f8",
+			wantChanged: true,
+			wantContent: "kubernetes:4029.v5712230ccb_f8\n",
+		},
+		{
+			name:        "Plugin already up to date",
+			spec:        Spec{Plugin: "git"},
+			source:      "5.2.0",
+			wantOld:     "5.2.0",
+			wantContent: "git:5.2.0\n",
+		},
+		{
+			name:    "Plugin not found",
+			spec:    Spec{Plugin: "docker-workflow"},
+			source:  "1.0",
+			wantErr: true,
+		},
+		{
+			name:    "Jenkins version not supported",
+			spec:    Spec{},
+			source:  "2.426.1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "plugins.txt")
+			require.NoError(t, os.WriteFile(file, []byte(pluginsTxt), 0600))
+
+			if tt.spec.Plugin != "" {
+				tt.spec.File = file
+			}
+			j, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Target{}
+			gotErr := j.Target(tt.source, nil, false, &gotResult)
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+
+			assert.Equal(t, tt.wantChanged, gotResult.Changed)
+			assert.Equal(t, tt.wantOld, gotResult.Information)
+			assert.Equal(t, tt.source, gotResult.NewInformation)
+
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tt.wantContent)
+			assert.Contains(t, string(content), "# Plugins installed in the controller image\n")
+		})
+	}
+}
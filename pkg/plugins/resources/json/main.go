@@ -47,6 +47,21 @@ func New(spec interface{}) (*Json, error) {
 		}
 	}
 
+	// JSONPath expressions are converted to their Dasel equivalent
+	for _, query := range []*string{&newSpec.Key, &newSpec.Query} {
+		if !dasel.IsJSONPath(*query) {
+			continue
+		}
+
+		daselQuery, err := dasel.JSONPathToDasel(*query)
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.Debugf("JSONPath %q converted to the Dasel query %q", *query, daselQuery)
+		*query = daselQuery
+	}
+
 	newSpec.File = strings.TrimPrefix(newSpec.File, "file://")
 
 	newFilter, err := newSpec.VersionFilter.Init()
@@ -53,6 +53,38 @@ func TestSource(t *testing.T) {
 			},
 			expectedResult: "Thomas",
 		},
+		{
+			name: "JSONPath array element",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  "$.children[1]",
+			},
+			expectedResult: "Thomas",
+		},
+		{
+			name: "JSONPath bracket notation",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  `$["address"]['city']`,
+			},
+			expectedResult: "New York",
+		},
+		{
+			name: "JSONPath bracket notation with key containing dots",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  `$["phoneNumbers.[*].type"]`,
+			},
+			expectedResult: "another home",
+		},
+		{
+			name: "JSONPath filter",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  `$.phoneNumbers[?(@.type=="office")].number`,
+			},
+			expectedResult: "646 555-4567",
+		},
 	}
 
 	for _, tt := range testData {
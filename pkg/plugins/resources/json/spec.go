@@ -45,11 +45,14 @@ type Spec struct {
 			* target
 
 		remark:
-			* key is a simpler version of Jsonpath accepts keys.
 			* key accepts Dasel query, more information on https://github.com/tomwright/dasel
+			* key accepts JSONPath expressions starting with "$", supporting dot and bracket notation,
+			  array indexes, wildcards, and equality filters. Recursive descent and unions aren't supported.
 
 		example:
 			* key: $.name
+			* key: $.dependencies["react"]
+			* key: $.phoneNumbers[?(@.type=="home")].number
 			* key: name
 			* file: https://nodejs.org/dist/index.json
 			  key: .(lts!=false).version
@@ -79,9 +82,11 @@ type Spec struct {
 		example:
 			* query: .name
 			* query: ".[*].tag_name"
+			* query: "$[*].tag_name"
 
 		remark:
 			* query accepts Dasel query, more information on https://github.com/tomwright/dasel
+			* query accepts JSONPath expressions starting with "$", like key
 	*/
 	Query string `yaml:",omitempty"`
 	/*
@@ -96,7 +96,7 @@ func (j *Json) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 			}
 		}
 
-		if !resultTarget.Changed || dryRun {
+		if !resultTarget.Changed {
 			continue
 		}
 
@@ -117,6 +117,16 @@ func (j *Json) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 			}
 		}
 
+		diff, err := j.contents[i].Diff()
+		if err != nil {
+			return err
+		}
+		logrus.Infof("```\n%s\n```\n", diff)
+
+		if dryRun {
+			continue
+		}
+
 		err = j.contents[i].Write()
 		if err != nil {
 			return err
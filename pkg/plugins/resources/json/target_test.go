@@ -45,12 +45,42 @@ func TestTarget(t *testing.T) {
 			sourceInput:    "Tom",
 			expectedResult: true,
 		},
+		{
+			name: "JSONPath array element already up to date",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  "$.children[0]",
+			},
+			sourceInput:    "Catherine",
+			expectedResult: false,
+		},
+		{
+			name: "JSONPath wildcard",
+			spec: Spec{
+				File:  "testdata/data.json",
+				Query: "$.phoneNumbers[*].number",
+			},
+			sourceInput:    "212 555-1234",
+			expectedResult: true,
+		},
+		{
+			name: "Unsupported JSONPath",
+			spec: Spec{
+				File: "testdata/data.json",
+				Key:  "$..number",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range testData {
 
 		t.Run(tt.name, func(t *testing.T) {
 			j, err := New(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
 
 			require.NoError(t, err)
 
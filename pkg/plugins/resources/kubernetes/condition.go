@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that every occurrence of the container image, in the Kubernetes manifests, uses the expected version
+func (k *Kubernetes) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	if scm != nil {
+		k.UpdateAbsoluteFilePath(scm.GetDirectory())
+	}
+
+	if err := k.Read(); err != nil {
+		return err
+	}
+
+	value := source
+	if k.spec.Value != "" {
+		value = k.spec.Value
+	}
+
+	var outdated []string
+	found, err := k.foreachManifest(func(filePath string, m *manifest, occurrences []imageOccurrence) error {
+		for _, occurrence := range occurrences {
+			if occurrence.version != value {
+				outdated = append(outdated, fmt.Sprintf("%q in file %q", occurrence.version, k.files[filePath].originalFilePath))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s no container image %q found", result.FAILURE, k.spec.Image)
+	}
+
+	if len(outdated) > 0 {
+		resultCondition.Pass = false
+		resultCondition.Result = result.FAILURE
+		resultCondition.Description = fmt.Sprintf("image %q should be set to %q, but is set to %v", k.spec.Image, value, outdated)
+		return nil
+	}
+
+	resultCondition.Pass = true
+	resultCondition.Result = result.SUCCESS
+	resultCondition.Description = fmt.Sprintf("image %q is correctly set to %q", k.spec.Image, value)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	testData := []struct {
+		name           string
+		spec           Spec
+		source         string
+		expectedResult bool
+		wantErr        bool
+	}{
+		{
+			name: "Every occurrence up to date",
+			spec: Spec{
+				Directory: "testdata/manifests",
+				Image:     "ghcr.io/updatecli/web",
+				Value:     "0.1.0",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "Kustomization image outdated",
+			spec: Spec{
+				Directory: "testdata/manifests",
+				Image:     "nginx",
+			},
+			source:         "1.24",
+			expectedResult: false,
+		},
+		{
+			name: "Image not found",
+			spec: Spec{
+				File:  "testdata/manifests/deployment.yaml",
+				Image: "redis",
+				Value: "7.0",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = k.Condition(tt.source, nil, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Pass)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+	"github.com/updatecli/updatecli/pkg/plugins/utils"
+)
+
+// Kubernetes defines a resource of kind "kubernetes"
+type Kubernetes struct {
+	spec             Spec
+	contentRetriever text.TextRetriever
+	// directory is the directory searched for manifest files, relative to the scm directory if any
+	directory string
+	files     map[string]file // map of file paths to file contents
+}
+
+type file struct {
+	originalFilePath string
+	filePath         string
+	content          string
+	// discovered is true if the file was found while searching the directory
+	discovered bool
+}
+
+// New returns a reference to a newly initialized Kubernetes object from a Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*Kubernetes, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	newResource := &Kubernetes{
+		spec:             newSpec,
+		contentRetriever: &text.Text{},
+		directory:        newSpec.Directory,
+		files:            make(map[string]file),
+	}
+
+	filePaths := newSpec.Files
+	if len(newSpec.File) > 0 {
+		filePaths = []string{newSpec.File}
+	}
+
+	for _, filePath := range filePaths {
+		filePath := strings.TrimPrefix(filePath, "file://")
+		newResource.files[filePath] = file{
+			originalFilePath: filePath,
+			filePath:         filePath,
+		}
+	}
+
+	return newResource, nil
+}
+
+// UpdateAbsoluteFilePath updates the file and directory paths relatively to the working directory
+func (k *Kubernetes) UpdateAbsoluteFilePath(workDir string) {
+	if workDir == "" {
+		return
+	}
+
+	if k.spec.Directory != "" {
+		k.directory = utils.JoinFilePathWithWorkingDirectoryPath(k.spec.Directory, workDir)
+	}
+
+	for filePath := range k.files {
+		f := k.files[filePath]
+		f.filePath = utils.JoinFilePathWithWorkingDirectoryPath(f.originalFilePath, workDir)
+		logrus.Debugf("Relative path detected: changing from %q to absolute path from SCM: %q", f.originalFilePath, f.filePath)
+		k.files[filePath] = f
+	}
+}
+
+// Read puts the content of the file(s) as value of the k.files map, searching the directory first if defined
+func (k *Kubernetes) Read() error {
+	if k.directory != "" {
+		if err := k.discoverFiles(); err != nil {
+			return err
+		}
+	}
+
+	for filePath := range k.files {
+		f := k.files[filePath]
+		if !k.contentRetriever.FileExists(f.filePath) {
+			return fmt.Errorf("%s The specified file %q does not exist", result.FAILURE, f.filePath)
+		}
+
+		content, err := k.contentRetriever.ReadAll(f.filePath)
+		if err != nil {
+			return err
+		}
+		f.content = content
+		k.files[filePath] = f
+	}
+
+	return nil
+}
+
+// discoverFiles adds the yaml files found in the directory to k.files
+func (k *Kubernetes) discoverFiles() error {
+	return filepath.WalkDir(k.directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		relPath, err := filepath.Rel(k.directory, path)
+		if err != nil {
+			return err
+		}
+
+		originalFilePath := filepath.Join(k.spec.Directory, relPath)
+		k.files[originalFilePath] = file{
+			originalFilePath: originalFilePath,
+			filePath:         path,
+			discovered:       true,
+		}
+
+		return nil
+	})
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (k *Kubernetes) Changelog() string {
+	return ""
+}
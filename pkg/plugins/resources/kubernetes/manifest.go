@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/kustomize"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/yamlnode"
+)
+
+// podSpecPaths defines the yamlpath of the pod specification of each Kubernetes workload kind
+var podSpecPaths = map[string]string{
+	"Pod":                   "$.spec",
+	"Deployment":            "$.spec.template.spec",
+	"StatefulSet":           "$.spec.template.spec",
+	"DaemonSet":             "$.spec.template.spec",
+	"ReplicaSet":            "$.spec.template.spec",
+	"ReplicationController": "$.spec.template.spec",
+	"Job":                   "$.spec.template.spec",
+	"CronJob":               "$.spec.jobTemplate.spec.template.spec",
+}
+
+// containerFields defines the pod specification fields listing containers
+var containerFields = []string{"initContainers", "containers"}
+
+// imageOccurrence defines a container image found in a Kubernetes manifest
+type imageOccurrence struct {
+	// path is the yamlpath of the image reference, or of the kustomization image entry
+	path string
+	// kind is the Kubernetes kind of the manifest document
+	kind string
+	// version is the current image tag and/or digest
+	version string
+}
+
+// manifest defines a parsed Kubernetes manifest file, which can contain many yaml documents
+type manifest struct {
+	file *ast.File
+	// occurrences holds the container image occurrences found by images, per yaml document
+	occurrences map[*ast.File][]imageOccurrence
+}
+
+// parseManifest parses the content of a Kubernetes manifest file
+func parseManifest(content string) (*manifest, error) {
+	f, err := parser.ParseBytes([]byte(content), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing yaml file: %w", err)
+	}
+	return &manifest{file: f}, nil
+}
+
+// String returns the manifest content
+func (m *manifest) String() string {
+	return m.file.String()
+}
+
+// images returns the occurrences of the container image, in the order they appear
+func (m *manifest) images(image string) ([]imageOccurrence, error) {
+	var occurrences []imageOccurrence
+	m.occurrences = map[*ast.File][]imageOccurrence{}
+
+	for _, doc := range yamlnode.Documents(m.file) {
+		kind, err := yamlnode.LookupString(doc, "$.kind")
+		if err != nil {
+			return nil, err
+		}
+
+		var found []imageOccurrence
+		switch {
+		case kind == kustomize.Kind:
+			found, err = kustomizationImages(doc, image)
+		case podSpecPaths[kind] != "":
+			found, err = containerImages(doc, kind, image)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found) > 0 {
+			m.occurrences[doc] = found
+			occurrences = append(occurrences, found...)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// update sets the version of every occurrence of the container image found by images
+func (m *manifest) update(version string) error {
+	for doc, images := range m.occurrences {
+		for _, occurrence := range images {
+			if occurrence.version == version {
+				continue
+			}
+
+			var err error
+			if occurrence.kind == kustomize.Kind {
+				err = kustomize.SetImageVersion(doc, occurrence.path, version)
+			} else {
+				reference, _ := yamlnode.LookupString(doc, occurrence.path)
+				imageName, _ := docker.SplitImage(reference)
+				err = yamlnode.SetString(doc, occurrence.path, docker.JoinImage(imageName, version))
+			}
+			if err != nil {
+				return fmt.Errorf("replacing %q: %w", occurrence.path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// containerImages returns the containers of a workload using the container image
+func containerImages(doc *ast.File, kind, image string) ([]imageOccurrence, error) {
+	var occurrences []imageOccurrence
+
+	for _, field := range containerFields {
+		containersPath := fmt.Sprintf("%s.%s", podSpecPaths[kind], field)
+
+		count, err := yamlnode.LookupSequenceLength(doc, containersPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < count; i++ {
+			imagePath := fmt.Sprintf("%s[%d].image", containersPath, i)
+
+			reference, err := yamlnode.LookupString(doc, imagePath)
+			if err != nil {
+				return nil, err
+			}
+
+			imageName, version := docker.SplitImage(reference)
+			if reference == "" || !docker.IsSameImage(imageName, image) {
+				continue
+			}
+
+			occurrences = append(occurrences, imageOccurrence{
+				path:    imagePath,
+				kind:    kind,
+				version: version,
+			})
+		}
+	}
+
+	return occurrences, nil
+}
+
+// kustomizationImages returns the kustomization image entries overriding the container image
+func kustomizationImages(doc *ast.File, image string) ([]imageOccurrence, error) {
+	entries, err := kustomize.FindImages(doc, image)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []imageOccurrence
+	for _, entry := range entries {
+		occurrences = append(occurrences, imageOccurrence{
+			path:    entry.Path,
+			kind:    kustomize.Kind,
+			version: entry.Version,
+		})
+	}
+
+	return occurrences, nil
+}
@@ -0,0 +1,12 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Source is not supported for kubernetes
+func (k *Kubernetes) Source(workingDir string, resultSource *result.Source) error {
+	return fmt.Errorf("Source not supported for the plugin kubernetes")
+}
@@ -0,0 +1,130 @@
+package kubernetes
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+"kubernetes" defines the specification for updating container images in Kubernetes manifests.
+It can be used as a "condition", or a "target".
+*/
+type Spec struct {
+	/*
+		"file" defines the Kubernetes manifest file path to interact with.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* "file", "files", and "directory" are mutually exclusive
+	*/
+	File string `yaml:",omitempty"`
+	/*
+		"files" defines the list of Kubernetes manifest files path to interact with.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* "file", "files", and "directory" are mutually exclusive
+	*/
+	Files []string `yaml:",omitempty"`
+	/*
+		"directory" defines a directory searched recursively for Kubernetes manifest files, ending with ".yaml" or ".yml".
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* "file", "files", and "directory" are mutually exclusive
+			* files which can't be parsed, such as Helm templates, are ignored
+	*/
+	Directory string `yaml:",omitempty"`
+	/*
+		"image" defines the container image name, without tag or digest, to update.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* the image is updated in the containers and init containers of
+			  Pods, Deployments, StatefulSets, DaemonSets, ReplicaSets, ReplicationControllers, Jobs, and CronJobs
+			* the image is updated in the "images" of Kustomizations
+			* "nginx" and "docker.io/library/nginx" designate the same image
+
+		example:
+			* nginx
+			* ghcr.io/updatecli/updatecli
+	*/
+	Image string `yaml:",omitempty"`
+	/*
+		"value" defines the container image tag and/or digest.
+
+		compatible:
+			* condition
+			* target
+
+		default:
+			When used from a condition or a target, the default value is set to linked source output.
+
+		example:
+			* 1.25.3
+			* 1.25.3@sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac
+			* sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac
+	*/
+	Value string `yaml:",omitempty"`
+}
+
+var (
+	// ErrSpecFileUndefined is returned if no file or directory was specified
+	ErrSpecFileUndefined = errors.New("kubernetes file, files, or directory undefined")
+	// ErrSpecFilesMutuallyExclusive is returned if more than one of file, files, and directory are specified
+	ErrSpecFilesMutuallyExclusive = errors.New("parameters \"file\", \"files\", and \"directory\" are mutually exclusive")
+	// ErrSpecImageUndefined is returned if an image wasn't specified
+	ErrSpecImageUndefined = errors.New("kubernetes image undefined")
+	// ErrWrongSpec is returned when the Spec has wrong content
+	ErrWrongSpec error = errors.New("wrong spec content")
+)
+
+// Validate ensures that the provided Spec is valid
+func (s *Spec) Validate() error {
+	var errs []error
+
+	defined := 0
+	if len(s.File) > 0 {
+		defined++
+	}
+	if len(s.Files) > 0 {
+		defined++
+	}
+	if len(s.Directory) > 0 {
+		defined++
+	}
+
+	switch {
+	case defined == 0:
+		errs = append(errs, ErrSpecFileUndefined)
+	case defined > 1:
+		errs = append(errs, ErrSpecFilesMutuallyExclusive)
+	}
+
+	if len(s.Image) == 0 {
+		errs = append(errs, ErrSpecImageUndefined)
+	}
+
+	for _, e := range errs {
+		logrus.Errorln(e)
+	}
+
+	if len(errs) > 0 {
+		return ErrWrongSpec
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+)
+
+// Target updates the container image version in the Kubernetes manifests
+func (k *Kubernetes) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
+	if scm != nil {
+		k.UpdateAbsoluteFilePath(scm.GetDirectory())
+	}
+
+	for _, resourceFile := range k.files {
+		if text.IsURL(resourceFile.originalFilePath) {
+			return fmt.Errorf("%s URL scheme is not supported for kubernetes target: %q", result.FAILURE, resourceFile.originalFilePath)
+		}
+	}
+
+	if err := k.Read(); err != nil {
+		return err
+	}
+
+	valueToWrite := source
+	if k.spec.Value != "" {
+		valueToWrite = k.spec.Value
+		logrus.Debug("Using spec.Value instead of source input value.")
+	}
+
+	if valueToWrite == "" {
+		return fmt.Errorf("%s no image version defined for %q", result.FAILURE, k.spec.Image)
+	}
+
+	resultTarget.NewInformation = valueToWrite
+	resultTarget.Result = result.SUCCESS
+
+	found, err := k.foreachManifest(func(filePath string, m *manifest, occurrences []imageOccurrence) error {
+		resourceFile := k.files[filePath]
+
+		var outdated []string
+		for _, occurrence := range occurrences {
+			resultTarget.Information = occurrence.version
+			if occurrence.version != valueToWrite {
+				outdated = append(outdated, occurrence.version)
+			}
+		}
+
+		if len(outdated) == 0 {
+			resultTarget.Description = fmt.Sprintf("%s\nimage %q already set to %q, from file %q",
+				resultTarget.Description,
+				k.spec.Image,
+				valueToWrite,
+				resourceFile.originalFilePath)
+			return nil
+		}
+
+		if err := m.update(valueToWrite); err != nil {
+			return err
+		}
+
+		newContent := m.String()
+
+		resultTarget.Changed = true
+		resultTarget.Result = result.ATTENTION
+		resultTarget.Files = append(resultTarget.Files, resourceFile.originalFilePath)
+		resultTarget.Description = fmt.Sprintf("%s\nimage %q updated from %q to %q, in file %q",
+			resultTarget.Description,
+			k.spec.Image,
+			strings.Join(outdated, ", "),
+			valueToWrite,
+			resourceFile.originalFilePath)
+
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, newContent))
+
+		resourceFile.content = newContent
+		k.files[filePath] = resourceFile
+
+		if dryRun {
+			return nil
+		}
+
+		return k.contentRetriever.WriteToFile(resourceFile.content, resourceFile.filePath)
+	})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s no container image %q found", result.FAILURE, k.spec.Image)
+	}
+
+	resultTarget.Description = strings.TrimPrefix(resultTarget.Description, "\n")
+	sort.Strings(resultTarget.Files)
+
+	return nil
+}
+
+// foreachManifest runs fn on every manifest file using the container image, sorted by file path,
+// and returns false if none uses it. Files found in the directory which can't be parsed are ignored.
+func (k *Kubernetes) foreachManifest(fn func(filePath string, m *manifest, occurrences []imageOccurrence) error) (bool, error) {
+	var filePaths []string
+	for filePath := range k.files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	found := false
+	for _, filePath := range filePaths {
+		resourceFile := k.files[filePath]
+
+		m, err := parseManifest(resourceFile.content)
+		if err != nil {
+			if resourceFile.discovered {
+				logrus.Debugf("ignoring file %q: %s", resourceFile.originalFilePath, err)
+				continue
+			}
+			return false, fmt.Errorf("file %q: %w", resourceFile.originalFilePath, err)
+		}
+
+		occurrences, err := m.images(k.spec.Image)
+		if err != nil {
+			return false, fmt.Errorf("file %q: %w", resourceFile.originalFilePath, err)
+		}
+
+		if len(occurrences) == 0 {
+			continue
+		}
+		found = true
+
+		if err := fn(filePath, m, occurrences); err != nil {
+			return false, err
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,203 @@
+package kubernetes
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestTarget(t *testing.T) {
+	testData := []struct {
+		name             string
+		spec             Spec
+		sourceInput      string
+		expectedChanged  bool
+		expectedFiles    []string
+		expectedContents map[string]string
+		wantErr          bool
+	}{
+		{
+			name: "Update an image in workloads",
+			spec: Spec{
+				File:  "manifests/deployment.yaml",
+				Image: "nginx",
+			},
+			sourceInput:     "1.25",
+			expectedChanged: true,
+			expectedFiles:   []string{"manifests/deployment.yaml"},
+			expectedContents: map[string]string{
+				"manifests/deployment.yaml": `# Web application
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+        - name: migrate
+          image: ghcr.io/updatecli/web:0.1.0
+      containers:
+        - name: web
+          image: ghcr.io/updatecli/web:0.1.0 # application
+          ports:
+            - containerPort: 8080
+        - name: proxy
+          image: nginx:1.25
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cleanup
+spec:
+  schedule: "0 * * * *"
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: cleanup
+              image: docker.io/library/nginx:1.25
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+data:
+  image: nginx:1.24
+`,
+			},
+		},
+		{
+			name: "Update an image digest across a directory",
+			spec: Spec{
+				Directory: "manifests",
+				Image:     "ghcr.io/updatecli/web",
+				Value:     "0.2.0@sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+			},
+			expectedChanged: true,
+			expectedFiles:   []string{"manifests/deployment.yaml", "manifests/kustomization.yaml"},
+			expectedContents: map[string]string{
+				"manifests/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - deployment.yaml
+images:
+  - name: ghcr.io/updatecli/web
+    newTag: 0.2.0
+    digest: sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac
+  - name: nginx
+    newName: registry.example.com/nginx
+    newTag: 1.0
+`,
+			},
+		},
+		{
+			name: "Update a kustomization image",
+			spec: Spec{
+				Files: []string{"manifests/kustomization.yaml"},
+				Image: "nginx",
+				Value: "1.25",
+			},
+			expectedChanged: true,
+			expectedFiles:   []string{"manifests/kustomization.yaml"},
+			expectedContents: map[string]string{
+				"manifests/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+  - deployment.yaml
+images:
+  - name: ghcr.io/updatecli/web
+    newTag: "0.1.0"
+  - name: nginx
+    newName: registry.example.com/nginx
+    newTag: "1.25"
+`,
+			},
+		},
+		{
+			name: "Image already up to date",
+			spec: Spec{
+				Directory: "manifests",
+				Image:     "ghcr.io/updatecli/web",
+				Value:     "0.1.0",
+			},
+			expectedChanged: false,
+		},
+		{
+			name: "Image not found",
+			spec: Spec{
+				Directory: "manifests",
+				Image:     "redis",
+				Value:     "7.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unparsable file",
+			spec: Spec{
+				File:  "manifests/templates/deployment.yaml",
+				Image: "nginx",
+				Value: "1.25",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			require.NoError(t, copyTestdata(workDir))
+
+			k, err := New(tt.spec)
+			require.NoError(t, err)
+			k.UpdateAbsoluteFilePath(workDir)
+
+			gotResult := result.Target{}
+			err = k.Target(tt.sourceInput, nil, false, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedChanged, gotResult.Changed)
+			assert.Equal(t, tt.expectedFiles, gotResult.Files)
+
+			for filePath, expectedContent := range tt.expectedContents {
+				gotContent, err := os.ReadFile(filepath.Join(workDir, filePath))
+				require.NoError(t, err)
+				assert.Equal(t, expectedContent, string(gotContent))
+			}
+		})
+	}
+}
+
+// copyTestdata copies the testdata directory into dir, so targets can update its files
+func copyTestdata(dir string) error {
+	return filepath.WalkDir("testdata", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel("testdata", path)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dir, relPath), 0o755)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, relPath), content, 0o600)
+	})
+}
@@ -0,0 +1,50 @@
+package kustomize
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that every kustomization entry, of the image or Helm chart, uses the expected version
+func (k *Kustomize) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	if scm != nil {
+		k.UpdateAbsoluteFilePath(scm.GetDirectory())
+	}
+
+	if err := k.Read(); err != nil {
+		return err
+	}
+
+	value := source
+	if k.spec.Value != "" {
+		value = k.spec.Value
+	}
+
+	var outdated []string
+	err := k.foreachKustomization(func(filePath string, kustomization *kustomization, entries []Entry) error {
+		for _, entry := range entries {
+			if entry.Version != value {
+				outdated = append(outdated, fmt.Sprintf("%q in file %q", entry.Version, k.files[filePath].originalFilePath))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(outdated) > 0 {
+		resultCondition.Pass = false
+		resultCondition.Result = result.FAILURE
+		resultCondition.Description = fmt.Sprintf("%s should be set to %q, but is set to %v", k.spec.entryDescription(), value, outdated)
+		return nil
+	}
+
+	resultCondition.Pass = true
+	resultCondition.Result = result.SUCCESS
+	resultCondition.Description = fmt.Sprintf("%s is correctly set to %q", k.spec.entryDescription(), value)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	testData := []struct {
+		name         string
+		spec         Spec
+		sourceInput  string
+		expectedPass bool
+		wantErr      bool
+	}{
+		{
+			name:         "Image tag up to date",
+			spec:         Spec{File: "testdata/kustomization.yaml", Image: "ghcr.io/updatecli/web"},
+			sourceInput:  "0.1.0",
+			expectedPass: true,
+		},
+		{
+			name:        "Image tag outdated in one file",
+			spec:        Spec{Files: []string{"testdata/kustomization.yaml", "testdata/base.yaml"}, Image: "nginx", Value: "1.24"},
+			sourceInput: "1.25",
+		},
+		{
+			name:         "Helm chart version up to date",
+			spec:         Spec{File: "testdata/kustomization.yaml", HelmChart: "minecraft", Value: "3.1.3"},
+			expectedPass: true,
+		},
+		{
+			name:    "Helm chart not found",
+			spec:    Spec{File: "testdata/kustomization.yaml", HelmChart: "mysql", Value: "1.0.0"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = k.Condition(tt.sourceInput, nil, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPass, gotResult.Pass)
+		})
+	}
+}
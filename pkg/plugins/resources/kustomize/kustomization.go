@@ -0,0 +1,258 @@
+package kustomize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/docker"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/yamlnode"
+)
+
+const (
+	// Kind defines the kind of kustomize configuration files
+	Kind string = "Kustomization"
+)
+
+// Entry defines a kustomization entry overriding a version, such as an image or a Helm chart
+type Entry struct {
+	// Path is the yamlpath of the entry
+	Path string
+	// Version is the version currently defined by the entry
+	Version string
+}
+
+// kustomization defines a parsed kustomize configuration file
+type kustomization struct {
+	file *ast.File
+}
+
+// parseKustomization parses the content of a kustomize configuration file
+func parseKustomization(content string) (*kustomization, error) {
+	f, err := parser.ParseBytes([]byte(content), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing yaml file: %w", err)
+	}
+	return &kustomization{file: f}, nil
+}
+
+// String returns the kustomization content
+func (k *kustomization) String() string {
+	return k.file.String()
+}
+
+// entries returns the image or Helm chart entries defined by the spec, in the order they appear
+func (k *kustomization) entries(spec Spec) ([]Entry, error) {
+	var entries []Entry
+
+	for _, doc := range yamlnode.Documents(k.file) {
+		var found []Entry
+		var err error
+
+		switch {
+		case spec.Image != "":
+			found, err = FindImages(doc, spec.Image)
+		case spec.HelmChart != "":
+			found, err = findHelmCharts(doc, spec.HelmChart)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, found...)
+	}
+
+	return entries, nil
+}
+
+// update sets the version of every entry defined by the spec
+func (k *kustomization) update(spec Spec, version string) error {
+	for _, doc := range yamlnode.Documents(k.file) {
+		var entries []Entry
+		var err error
+
+		switch {
+		case spec.Image != "":
+			entries, err = FindImages(doc, spec.Image)
+		case spec.HelmChart != "":
+			entries, err = findHelmCharts(doc, spec.HelmChart)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.Version == version {
+				continue
+			}
+
+			switch {
+			case spec.Image != "":
+				err = SetImageVersion(doc, entry.Path, version)
+			case spec.HelmChart != "":
+				err = yamlnode.SetString(doc, entry.Path+".version", version)
+			}
+			if err != nil {
+				return fmt.Errorf("updating %q: %w", entry.Path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindImages returns the kustomization image entries overriding the container image
+// as explained on https://kubectl.docs.kubernetes.io/references/kustomize/kustomization/images/
+// Their version is the image tag and/or digest, such as "1.25.3", "1.25.3@sha256:..." or "sha256:..."
+func FindImages(doc *ast.File, image string) ([]Entry, error) {
+	var entries []Entry
+
+	count, err := yamlnode.LookupSequenceLength(doc, "$.images")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < count; i++ {
+		entryPath := fmt.Sprintf("$.images[%d]", i)
+
+		// Fields are read from the yaml nodes to keep their original text, such as "1.0"
+		var fields = map[string]string{"name": "", "newTag": "", "digest": ""}
+		for field := range fields {
+			fields[field], err = yamlnode.LookupString(doc, entryPath+"."+field)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entryName, tag, digest := fields["name"], fields["newTag"], fields["digest"]
+
+		if !docker.IsSameImage(entryName, image) {
+			continue
+		}
+
+		version := tag
+		switch {
+		case tag != "" && digest != "":
+			version = tag + "@" + digest
+		case digest != "":
+			version = digest
+		}
+
+		entries = append(entries, Entry{
+			Path:    entryPath,
+			Version: version,
+		})
+	}
+
+	return entries, nil
+}
+
+// SetImageVersion sets the tag and/or digest of the kustomization image entry found at the yamlpath.
+// Existing fields are updated in place to keep their comments, the entry is only rewritten
+// when the "newTag" or "digest" field must be added or removed.
+func SetImageVersion(doc *ast.File, path, version string) error {
+	tag, digest := splitVersion(version)
+
+	currentTag, err := yamlnode.LookupString(doc, path+".newTag")
+	if err != nil {
+		return err
+	}
+	currentDigest, err := yamlnode.LookupString(doc, path+".digest")
+	if err != nil {
+		return err
+	}
+
+	if (tag != "") == (currentTag != "") && (digest != "") == (currentDigest != "") {
+		if tag != "" {
+			if err := yamlnode.SetString(doc, path+".newTag", tag); err != nil {
+				return err
+			}
+		}
+		if digest != "" {
+			if err := yamlnode.SetString(doc, path+".digest", digest); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	yamlPath, err := yaml.PathString(path)
+	if err != nil {
+		return fmt.Errorf("crafting yamlpath query: %w", err)
+	}
+
+	var entry yaml.MapSlice
+	if err := yamlPath.Read(strings.NewReader(doc.String()), &entry); err != nil {
+		return fmt.Errorf("reading kustomization image: %w", err)
+	}
+
+	var updated yaml.MapSlice
+	for _, item := range entry {
+		switch item.Key {
+		case "newTag", "digest":
+			continue
+		}
+		updated = append(updated, item)
+	}
+
+	if tag != "" {
+		updated = append(updated, yaml.MapItem{Key: "newTag", Value: tag})
+	}
+	if digest != "" {
+		updated = append(updated, yaml.MapItem{Key: "digest", Value: digest})
+	}
+
+	data, err := yaml.MarshalWithOptions(updated, yaml.UseLiteralStyleIfMultiline(true))
+	if err != nil {
+		return fmt.Errorf("writing kustomization image: %w", err)
+	}
+
+	return yamlPath.ReplaceWithReader(doc, strings.NewReader(string(data)))
+}
+
+// findHelmCharts returns the kustomization Helm chart entries inflating the Helm chart
+// as explained on https://kubectl.docs.kubernetes.io/references/kustomize/builtins/#_helmchartinflationgenerator_
+func findHelmCharts(doc *ast.File, chart string) ([]Entry, error) {
+	var entries []Entry
+
+	count, err := yamlnode.LookupSequenceLength(doc, "$.helmCharts")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < count; i++ {
+		entryPath := fmt.Sprintf("$.helmCharts[%d]", i)
+
+		name, err := yamlnode.LookupString(doc, entryPath+".name")
+		if err != nil {
+			return nil, err
+		}
+
+		if name != chart {
+			continue
+		}
+
+		version, err := yamlnode.LookupString(doc, entryPath+".version")
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, Entry{
+			Path:    entryPath,
+			Version: version,
+		})
+	}
+
+	return entries, nil
+}
+
+// splitVersion splits an image version into its tag and its digest
+func splitVersion(version string) (tag, digest string) {
+	if strings.HasPrefix(version, "sha256:") {
+		return "", version
+	}
+
+	tag, digest, _ = strings.Cut(version, "@")
+	return tag, digest
+}
@@ -0,0 +1,135 @@
+package kustomize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+	"github.com/updatecli/updatecli/pkg/plugins/utils"
+)
+
+// Kustomize defines a resource of kind "kustomize"
+type Kustomize struct {
+	spec             Spec
+	contentRetriever text.TextRetriever
+	files            map[string]file // map of file paths to file contents
+}
+
+type file struct {
+	originalFilePath string
+	filePath         string
+	content          string
+}
+
+// New returns a reference to a newly initialized Kustomize object from a Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*Kustomize, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	newResource := &Kustomize{
+		spec:             newSpec,
+		contentRetriever: &text.Text{},
+		files:            make(map[string]file),
+	}
+
+	filePaths := newSpec.Files
+	if len(newSpec.File) > 0 {
+		filePaths = []string{newSpec.File}
+	}
+
+	for _, filePath := range filePaths {
+		filePath := strings.TrimPrefix(filePath, "file://")
+		newResource.files[filePath] = file{
+			originalFilePath: filePath,
+			filePath:         filePath,
+		}
+	}
+
+	return newResource, nil
+}
+
+// UpdateAbsoluteFilePath updates the file paths relatively to the working directory
+func (k *Kustomize) UpdateAbsoluteFilePath(workDir string) {
+	if workDir == "" {
+		return
+	}
+
+	for filePath := range k.files {
+		f := k.files[filePath]
+		f.filePath = utils.JoinFilePathWithWorkingDirectoryPath(f.originalFilePath, workDir)
+		logrus.Debugf("Relative path detected: changing from %q to absolute path from SCM: %q", f.originalFilePath, f.filePath)
+		k.files[filePath] = f
+	}
+}
+
+// Read puts the content of the file(s) as value of the k.files map
+func (k *Kustomize) Read() error {
+	for filePath := range k.files {
+		f := k.files[filePath]
+		if !k.contentRetriever.FileExists(f.filePath) {
+			return fmt.Errorf("%s The specified file %q does not exist", result.FAILURE, f.filePath)
+		}
+
+		content, err := k.contentRetriever.ReadAll(f.filePath)
+		if err != nil {
+			return err
+		}
+		f.content = content
+		k.files[filePath] = f
+	}
+
+	return nil
+}
+
+// foreachKustomization runs fn on every kustomization file, sorted by file path,
+// with the entries defined by the spec, and returns an error if there is no entry in a file.
+func (k *Kustomize) foreachKustomization(fn func(filePath string, kustomization *kustomization, entries []Entry) error) error {
+	var filePaths []string
+	for filePath := range k.files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		resourceFile := k.files[filePath]
+
+		kustomization, err := parseKustomization(resourceFile.content)
+		if err != nil {
+			return fmt.Errorf("file %q: %w", resourceFile.originalFilePath, err)
+		}
+
+		entries, err := kustomization.entries(k.spec)
+		if err != nil {
+			return fmt.Errorf("file %q: %w", resourceFile.originalFilePath, err)
+		}
+
+		if len(entries) == 0 {
+			return fmt.Errorf("%s no %s found in file %q", result.FAILURE, k.spec.entryDescription(), resourceFile.originalFilePath)
+		}
+
+		if err := fn(filePath, kustomization, entries); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (k *Kustomize) Changelog() string {
+	return ""
+}
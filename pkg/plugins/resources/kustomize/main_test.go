@@ -0,0 +1,25 @@
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// copyTestdata copies the testdata files to a temporary directory, so targets can update them
+func copyTestdata(t *testing.T) string {
+	dir := t.TempDir()
+
+	entries, err := os.ReadDir("testdata")
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join("testdata", entry.Name()))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, entry.Name()), content, 0o600))
+	}
+
+	return dir
+}
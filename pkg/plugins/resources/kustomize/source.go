@@ -0,0 +1,39 @@
+package kustomize
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Source returns the image tag and/or digest, or the Helm chart version, defined by the kustomization file
+func (k *Kustomize) Source(workingDir string, resultSource *result.Source) error {
+	if len(k.files) > 1 {
+		return fmt.Errorf("%s kustomize source only supports one file", result.FAILURE)
+	}
+
+	k.UpdateAbsoluteFilePath(workingDir)
+
+	if err := k.Read(); err != nil {
+		return err
+	}
+
+	var version string
+	err := k.foreachKustomization(func(filePath string, kustomization *kustomization, entries []Entry) error {
+		version = entries[0].Version
+		if version == "" {
+			return fmt.Errorf("%s no version defined by %s, in file %q", result.FAILURE, k.spec.entryDescription(), k.files[filePath].originalFilePath)
+		}
+
+		resultSource.Description = fmt.Sprintf("%s is set to %q, in file %q", k.spec.entryDescription(), version, k.files[filePath].originalFilePath)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	resultSource.Information = version
+	resultSource.Result = result.SUCCESS
+
+	return nil
+}
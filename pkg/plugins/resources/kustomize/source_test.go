@@ -0,0 +1,70 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestSource(t *testing.T) {
+	testData := []struct {
+		name           string
+		spec           Spec
+		expectedResult string
+		wantErr        bool
+	}{
+		{
+			name:           "Image tag",
+			spec:           Spec{File: "testdata/kustomization.yaml", Image: "ghcr.io/updatecli/web"},
+			expectedResult: "0.1.0",
+		},
+		{
+			name:           "Image tag written as a number",
+			spec:           Spec{File: "testdata/kustomization.yaml", Image: "docker.io/library/nginx"},
+			expectedResult: "1.24",
+		},
+		{
+			name:           "Image digest",
+			spec:           Spec{File: "testdata/kustomization.yaml", Image: "redis"},
+			expectedResult: "sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+		},
+		{
+			name:           "Helm chart version",
+			spec:           Spec{File: "testdata/kustomization.yaml", HelmChart: "minecraft"},
+			expectedResult: "3.1.3",
+		},
+		{
+			name:    "Helm chart without version",
+			spec:    Spec{File: "testdata/kustomization.yaml", HelmChart: "latest"},
+			wantErr: true,
+		},
+		{
+			name:    "Image not found",
+			spec:    Spec{File: "testdata/kustomization.yaml", Image: "postgres"},
+			wantErr: true,
+		},
+		{
+			name:    "Many files",
+			spec:    Spec{Files: []string{"testdata/kustomization.yaml", "testdata/base.yaml"}, Image: "nginx"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = k.Source("", &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
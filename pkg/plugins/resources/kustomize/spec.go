@@ -0,0 +1,139 @@
+package kustomize
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+"kustomize" defines the specification for manipulating the "images" and "helmCharts" versions of kustomization files.
+It can be used as a "source", a "condition", or a "target".
+*/
+type Spec struct {
+	/*
+		"file" defines the kustomization file path to interact with.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* "file" and "files" are mutually exclusive
+			* when using as a source only one file is supported
+
+		example:
+			* kustomization.yaml
+			* overlays/production/kustomization.yaml
+	*/
+	File string `yaml:",omitempty"`
+	/*
+		"files" defines the list of kustomization files path to interact with.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* "file" and "files" are mutually exclusive
+	*/
+	Files []string `yaml:",omitempty"`
+	/*
+		"image" defines the container image name of the "images" entry to interact with.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* "image" and "helmchart" are mutually exclusive
+			* the version is the "newTag" and/or "digest" of the entry, such as "1.25.3", "1.25.3@sha256:..." or "sha256:..."
+			* "nginx" and "docker.io/library/nginx" designate the same image
+
+		example:
+			* nginx
+			* ghcr.io/updatecli/updatecli
+	*/
+	Image string `yaml:",omitempty"`
+	/*
+		"helmchart" defines the Helm chart name of the "helmCharts" entry to interact with.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		remark:
+			* "image" and "helmchart" are mutually exclusive
+			* the version is the "version" of the entry
+
+		example:
+			* minecraft
+	*/
+	HelmChart string `yaml:",omitempty"`
+	/*
+		"value" defines the image tag and/or digest, or the Helm chart version.
+
+		compatible:
+			* condition
+			* target
+
+		default:
+			When used from a condition or a target, the default value is set to linked source output.
+	*/
+	Value string `yaml:",omitempty"`
+}
+
+var (
+	// ErrSpecFileUndefined is returned if a file wasn't specified
+	ErrSpecFileUndefined = errors.New("kustomize file undefined")
+	// ErrSpecFileAndFilesDefined when we both spec.File and spec.Files have been specified
+	ErrSpecFileAndFilesDefined = errors.New("parameters \"file\" and \"files\" are mutually exclusive")
+	// ErrSpecEntryUndefined is returned if neither an image nor a Helm chart were specified
+	ErrSpecEntryUndefined = errors.New("kustomize image or helmchart undefined")
+	// ErrSpecImageAndHelmChartDefined is returned if both an image and a Helm chart were specified
+	ErrSpecImageAndHelmChartDefined = errors.New("parameters \"image\" and \"helmchart\" are mutually exclusive")
+	// ErrWrongSpec is returned when the Spec has wrong content
+	ErrWrongSpec error = errors.New("wrong spec content")
+)
+
+// Validate ensures that the provided Spec is valid
+func (s *Spec) Validate() error {
+	var errs []error
+
+	if len(s.File) == 0 && len(s.Files) == 0 {
+		errs = append(errs, ErrSpecFileUndefined)
+	}
+
+	if len(s.File) > 0 && len(s.Files) > 0 {
+		errs = append(errs, ErrSpecFileAndFilesDefined)
+	}
+
+	if len(s.Image) == 0 && len(s.HelmChart) == 0 {
+		errs = append(errs, ErrSpecEntryUndefined)
+	}
+
+	if len(s.Image) > 0 && len(s.HelmChart) > 0 {
+		errs = append(errs, ErrSpecImageAndHelmChartDefined)
+	}
+
+	for _, e := range errs {
+		logrus.Errorln(e)
+	}
+
+	if len(errs) > 0 {
+		return ErrWrongSpec
+	}
+
+	return nil
+}
+
+// entryDescription returns a description of the kustomization entry defined by the spec, used in messages
+func (s *Spec) entryDescription() string {
+	if s.Image != "" {
+		return "image \"" + s.Image + "\""
+	}
+	return "helm chart \"" + s.HelmChart + "\""
+}
@@ -0,0 +1,55 @@
+package kustomize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecValidate(t *testing.T) {
+	testData := []struct {
+		name    string
+		spec    Spec
+		wantErr bool
+	}{
+		{
+			name: "Image",
+			spec: Spec{File: "kustomization.yaml", Image: "nginx"},
+		},
+		{
+			name: "Helm chart",
+			spec: Spec{Files: []string{"kustomization.yaml"}, HelmChart: "minecraft"},
+		},
+		{
+			name:    "No file",
+			spec:    Spec{Image: "nginx"},
+			wantErr: true,
+		},
+		{
+			name:    "File and files",
+			spec:    Spec{File: "kustomization.yaml", Files: []string{"kustomization.yaml"}, Image: "nginx"},
+			wantErr: true,
+		},
+		{
+			name:    "No image nor helm chart",
+			spec:    Spec{File: "kustomization.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "Image and helm chart",
+			spec:    Spec{File: "kustomization.yaml", Image: "nginx", HelmChart: "minecraft"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrWrongSpec)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package kustomize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+)
+
+// Target updates the image tag and/or digest, or the Helm chart version, in the kustomization files
+func (k *Kustomize) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
+	if scm != nil {
+		k.UpdateAbsoluteFilePath(scm.GetDirectory())
+	}
+
+	for _, resourceFile := range k.files {
+		if text.IsURL(resourceFile.originalFilePath) {
+			return fmt.Errorf("%s URL scheme is not supported for kustomize target: %q", result.FAILURE, resourceFile.originalFilePath)
+		}
+	}
+
+	if err := k.Read(); err != nil {
+		return err
+	}
+
+	valueToWrite := source
+	if k.spec.Value != "" {
+		valueToWrite = k.spec.Value
+		logrus.Debug("Using spec.Value instead of source input value.")
+	}
+
+	if valueToWrite == "" {
+		return fmt.Errorf("%s no version defined for %s", result.FAILURE, k.spec.entryDescription())
+	}
+
+	resultTarget.NewInformation = valueToWrite
+	resultTarget.Result = result.SUCCESS
+
+	err := k.foreachKustomization(func(filePath string, kustomization *kustomization, entries []Entry) error {
+		resourceFile := k.files[filePath]
+
+		var outdated []string
+		for _, entry := range entries {
+			resultTarget.Information = entry.Version
+			if entry.Version != valueToWrite {
+				outdated = append(outdated, entry.Version)
+			}
+		}
+
+		if len(outdated) == 0 {
+			resultTarget.Description = fmt.Sprintf("%s\n%s already set to %q, from file %q",
+				resultTarget.Description,
+				k.spec.entryDescription(),
+				valueToWrite,
+				resourceFile.originalFilePath)
+			return nil
+		}
+
+		if err := kustomization.update(k.spec, valueToWrite); err != nil {
+			return fmt.Errorf("file %q: %w", resourceFile.originalFilePath, err)
+		}
+
+		newContent := kustomization.String()
+
+		resultTarget.Changed = true
+		resultTarget.Result = result.ATTENTION
+		resultTarget.Files = append(resultTarget.Files, resourceFile.originalFilePath)
+		resultTarget.Description = fmt.Sprintf("%s\n%s updated from %q to %q, in file %q",
+			resultTarget.Description,
+			k.spec.entryDescription(),
+			strings.Join(outdated, ", "),
+			valueToWrite,
+			resourceFile.originalFilePath)
+
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, newContent))
+
+		resourceFile.content = newContent
+		k.files[filePath] = resourceFile
+
+		if dryRun {
+			return nil
+		}
+
+		return k.contentRetriever.WriteToFile(resourceFile.content, resourceFile.filePath)
+	})
+	if err != nil {
+		return err
+	}
+
+	resultTarget.Description = strings.TrimPrefix(resultTarget.Description, "\n")
+	sort.Strings(resultTarget.Files)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestTarget(t *testing.T) {
+	original, err := os.ReadFile("testdata/kustomization.yaml")
+	require.NoError(t, err)
+
+	testData := []struct {
+		name            string
+		spec            Spec
+		sourceInput     string
+		expectedChanged bool
+		// expectedReplacements defines the lines expected to change in kustomization.yaml
+		expectedReplacements map[string]string
+		wantErr              bool
+	}{
+		{
+			name:            "Update an image tag, keeping its comment",
+			spec:            Spec{File: "kustomization.yaml", Image: "ghcr.io/updatecli/web"},
+			sourceInput:     "0.2.0",
+			expectedChanged: true,
+			expectedReplacements: map[string]string{
+				`    newTag: "0.1.0" # application`: `    newTag: "0.2.0" # application`,
+			},
+		},
+		{
+			name:            "Update an image tag written as a number",
+			spec:            Spec{File: "kustomization.yaml", Image: "nginx"},
+			sourceInput:     "1.25",
+			expectedChanged: true,
+			expectedReplacements: map[string]string{
+				`    newTag: 1.24`: `    newTag: "1.25"`,
+			},
+		},
+		{
+			name:            "Update an image digest",
+			spec:            Spec{File: "kustomization.yaml", Image: "redis"},
+			sourceInput:     "sha256:5f8fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+			expectedChanged: true,
+			expectedReplacements: map[string]string{
+				`    digest: sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac`: `    digest: sha256:5f8fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac`,
+			},
+		},
+		{
+			name:            "Add a digest to an image tag",
+			spec:            Spec{File: "kustomization.yaml", Image: "redis"},
+			sourceInput:     "7.2@sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+			expectedChanged: true,
+			expectedReplacements: map[string]string{
+				`    digest: sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac`: `    newTag: "7.2"
+    digest: sha256:4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac`,
+			},
+		},
+		{
+			name:            "Update a Helm chart version, keeping the field order",
+			spec:            Spec{File: "kustomization.yaml", HelmChart: "minecraft"},
+			sourceInput:     "3.2.0",
+			expectedChanged: true,
+			expectedReplacements: map[string]string{
+				`    version: 3.1.3 # pinned`: `    version: 3.2.0 # pinned`,
+			},
+		},
+		{
+			name:        "Helm chart already up to date",
+			spec:        Spec{File: "kustomization.yaml", HelmChart: "minecraft"},
+			sourceInput: "3.1.3",
+		},
+		{
+			name:        "Helm chart without version",
+			spec:        Spec{File: "kustomization.yaml", HelmChart: "latest"},
+			sourceInput: "1.0.0",
+			wantErr:     true,
+		},
+		{
+			name:        "Image not found",
+			spec:        Spec{File: "kustomization.yaml", Image: "postgres"},
+			sourceInput: "16",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := copyTestdata(t)
+
+			k, err := New(tt.spec)
+			require.NoError(t, err)
+			k.UpdateAbsoluteFilePath(dir)
+
+			gotResult := result.Target{}
+			err = k.Target(tt.sourceInput, nil, false, &gotResult)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedChanged, gotResult.Changed)
+
+			expectedContent := string(original)
+			for oldLine, newLine := range tt.expectedReplacements {
+				require.Contains(t, expectedContent, oldLine)
+				expectedContent = strings.Replace(expectedContent, oldLine, newLine, 1)
+			}
+
+			gotContent, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+			require.NoError(t, err)
+			assert.Equal(t, expectedContent, string(gotContent))
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/mavenmetadata"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -37,6 +38,12 @@ type Spec struct {
 	Version string `yaml:",omitempty"`
 	// [S] VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest.
 	VersionFilter version.Filter `yaml:",omitempty"`
+	// Proxy, CAFile and InsecureSkipVerify define how the repositories are reached,
+	// such as self-hosted repositories behind a proxy or using a private certificate authority
+	httpclient.TransportSpec `yaml:",inline" mapstructure:",squash"`
+	// Retry defines how failed repository requests, such as network errors, rate limits or 5xx responses, are retried.
+	// Default to the global retry policy set by the "--http-retries" flag
+	Retry httpclient.RetryPolicy `yaml:",omitempty"`
 }
 
 // Maven defines a resource of kind "maven"
@@ -64,6 +71,11 @@ func New(spec interface{}) (*Maven, error) {
 		spec: newSpec,
 	}
 
+	webClient, err := httpclient.NewClient(newSpec.TransportSpec, newSpec.Retry)
+	if err != nil {
+		return &Maven{}, err
+	}
+
 	if len(newSpec.Repository) > 0 {
 
 		u, err := url.Parse(newSpec.Repository)
@@ -79,7 +91,7 @@ func New(spec interface{}) (*Maven, error) {
 
 		newResource.metadataHandlers = append(
 			newResource.metadataHandlers,
-			mavenmetadata.New(u.String(), newSpec.VersionFilter))
+			mavenmetadata.NewWithClient(u.String(), newSpec.VersionFilter, webClient))
 
 		return newResource, nil
 	}
@@ -98,7 +110,7 @@ func New(spec interface{}) (*Maven, error) {
 
 		newResource.metadataHandlers = append(
 			newResource.metadataHandlers,
-			mavenmetadata.New(u.String(), newSpec.VersionFilter))
+			mavenmetadata.NewWithClient(u.String(), newSpec.VersionFilter, webClient))
 	}
 
 	mavenCentralNotFound, err := isRepositoriesContainsMavenCentral(newSpec.Repositories)
@@ -117,7 +129,7 @@ func New(spec interface{}) (*Maven, error) {
 
 		newResource.metadataHandlers = append(
 			newResource.metadataHandlers,
-			mavenmetadata.New(u.String(), newSpec.VersionFilter))
+			mavenmetadata.NewWithClient(u.String(), newSpec.VersionFilter, webClient))
 	}
 
 	return newResource, nil
@@ -33,15 +33,13 @@ type Spec struct {
 	RegistryToken string `yaml:",omitempty"`
 	// VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest.
 	VersionFilter version.Filter `yaml:",omitempty"`
+	// DistTag defines the npm dist-tag, such as "next" or "beta", whose version is retrieved instead of the latest one.
+	// If a version filter pattern is also defined, the dist-tag version must match it.
+	DistTag string `yaml:"disttag,omitempty"`
 	// NpmrcPath defines the path to the .npmrc file
 	NpmrcPath string `yaml:"npmrcpath,omitempty"`
 }
 
-type distTags struct {
-	Latest string
-	Next   string
-}
-
 type versions struct {
 	Name       string
 	Version    string
@@ -50,7 +48,8 @@ type versions struct {
 
 type Data struct {
 	Versions map[string]versions
-	DistTags distTags `json:"dist-tags,omitempty"`
+	// DistTags maps each npm dist-tag, such as "latest", to the package version it points to
+	DistTags map[string]string `json:"dist-tags,omitempty"`
 }
 
 // Npm defines a resource of kind "npm"
@@ -190,8 +189,24 @@ func (n *Npm) getVersions() (v string, versions []string, err error) {
 		versions = append(versions, value.Version)
 	}
 
+	if n.spec.DistTag != "" {
+		v, ok := n.data.DistTags[n.spec.DistTag]
+		if !ok {
+			return "", nil, fmt.Errorf("dist-tag %q not found for package name %q", n.spec.DistTag, n.spec.Name)
+		}
+
+		if n.versionFilter.Kind != version.LATESTVERSIONKIND {
+			n.foundVersion, err = n.versionFilter.Search([]string{v})
+			if err != nil {
+				return "", nil, fmt.Errorf("dist-tag %q version %q doesn't match the version filter: %w", n.spec.DistTag, v, err)
+			}
+		}
+
+		return v, versions, nil
+	}
+
 	if n.versionFilter.Kind == version.LATESTVERSIONKIND {
-		return n.data.DistTags.Latest, versions, nil
+		return n.data.DistTags["latest"], versions, nil
 	}
 
 	sort.Strings(versions)
@@ -245,7 +260,7 @@ func (n *Npm) getPackageData(packageName string) (Data, error) {
 		logrus.Errorf("something went wrong while getting npm api data %q\n", URL)
 		logrus.Errorf("Error %q\n", err)
 		logrus.Debugf("\n%v\n", string(body))
-		return Data{}, err
+		return Data{}, fmt.Errorf("npm registry returned %q for package %q", res.Status, packageName)
 	}
 
 	data, err := io.ReadAll(res.Body)
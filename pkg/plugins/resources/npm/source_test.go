@@ -95,6 +95,88 @@ func TestSource(t *testing.T) {
 			mockedUrl:            "https://mycustomregistry.updatecli.io",
 			expectedError:        true,
 		},
+		{
+			name: "Passing case of retrieving the latest dist-tag axios version",
+			spec: Spec{
+				Name:          "axios",
+				URL:           "https://mycustomregistry.updatecli.io",
+				RegistryToken: "mytoken",
+			},
+			mockedResponse:       true,
+			mockedBody:           existingPackageData,
+			mockedHTTPStatusCode: 200,
+			mockedToken:          "mytoken",
+			mockedUrl:            "https://mycustomregistry.updatecli.io",
+			expectedResult:       "0.1.0",
+		},
+		{
+			name: "Passing case of retrieving the next dist-tag axios version",
+			spec: Spec{
+				Name:          "axios",
+				DistTag:       "next",
+				URL:           "https://mycustomregistry.updatecli.io",
+				RegistryToken: "mytoken",
+			},
+			mockedResponse:       true,
+			mockedBody:           existingPackageData,
+			mockedHTTPStatusCode: 200,
+			mockedToken:          "mytoken",
+			mockedUrl:            "https://mycustomregistry.updatecli.io",
+			expectedResult:       "0.2.0",
+		},
+		{
+			name: "Passing case of retrieving the next dist-tag axios version matching a semver constraint",
+			spec: Spec{
+				Name:    "axios",
+				DistTag: "next",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~0.2",
+				},
+				URL:           "https://mycustomregistry.updatecli.io",
+				RegistryToken: "mytoken",
+			},
+			mockedResponse:       true,
+			mockedBody:           existingPackageData,
+			mockedHTTPStatusCode: 200,
+			mockedToken:          "mytoken",
+			mockedUrl:            "https://mycustomregistry.updatecli.io",
+			expectedResult:       "0.2.0",
+		},
+		{
+			name: "Failing case of retrieving the next dist-tag axios version not matching a semver constraint",
+			spec: Spec{
+				Name:    "axios",
+				DistTag: "next",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~0.1",
+				},
+				URL:           "https://mycustomregistry.updatecli.io",
+				RegistryToken: "mytoken",
+			},
+			mockedResponse:       true,
+			mockedBody:           existingPackageData,
+			mockedHTTPStatusCode: 200,
+			mockedToken:          "mytoken",
+			mockedUrl:            "https://mycustomregistry.updatecli.io",
+			expectedError:        true,
+		},
+		{
+			name: "Failing case of retrieving a nonexistent dist-tag axios version",
+			spec: Spec{
+				Name:          "axios",
+				DistTag:       "beta",
+				URL:           "https://mycustomregistry.updatecli.io",
+				RegistryToken: "mytoken",
+			},
+			mockedResponse:       true,
+			mockedBody:           existingPackageData,
+			mockedHTTPStatusCode: 200,
+			mockedToken:          "mytoken",
+			mockedUrl:            "https://mycustomregistry.updatecli.io",
+			expectedError:        true,
+		},
 		{
 			name: "Passing case of retrieving latest @TestScope:registry version using private registry in npmrc",
 			spec: Spec{
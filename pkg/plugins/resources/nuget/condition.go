@@ -0,0 +1,42 @@
+package nuget
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that a NuGet package version is published
+func (n *Nuget) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	versionToCheck := n.spec.Version
+	if versionToCheck == "" {
+		versionToCheck = source
+	}
+	if len(versionToCheck) == 0 {
+		return errors.New("no version defined")
+	}
+
+	publishedVersions, err := n.publishedVersions()
+	if err != nil {
+		return fmt.Errorf("searching nuget package version: %w", err)
+	}
+
+	for _, v := range publishedVersions {
+		// NuGet versions are case insensitive
+		if strings.EqualFold(v, versionToCheck) {
+			resultCondition.Pass = true
+			resultCondition.Result = result.SUCCESS
+			resultCondition.Description = fmt.Sprintf("version %q available for nuget package %q", versionToCheck, n.spec.Name)
+			return nil
+		}
+	}
+
+	resultCondition.Pass = false
+	resultCondition.Result = result.FAILURE
+	resultCondition.Description = fmt.Sprintf("version %q doesn't exist for nuget package %q", versionToCheck, n.spec.Name)
+
+	return nil
+}
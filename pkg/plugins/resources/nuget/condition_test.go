@@ -0,0 +1,70 @@
+package nuget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           Spec
+		source         string
+		expectedResult bool
+		expectedError  bool
+	}{
+		{
+			name: "Version published",
+			spec: Spec{
+				Name:    "Newtonsoft.Json",
+				Version: "13.0.3",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "Prerelease version published, using a different case",
+			spec: Spec{
+				Name: "Newtonsoft.Json",
+			},
+			source:         "13.0.4-BETA1",
+			expectedResult: true,
+		},
+		{
+			name: "Version not published",
+			spec: Spec{
+				Name:    "Newtonsoft.Json",
+				Version: "1.0.0",
+			},
+			expectedResult: false,
+		},
+		{
+			name: "No version defined",
+			spec: Spec{
+				Name: "Newtonsoft.Json",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestFeed(t, "")
+			tt.spec.URL = server.URL + "/v3/index.json"
+
+			n, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = n.Condition(tt.source, nil, &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Pass)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package nuget
+
+import (
+	"net/http"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+/*
+	https://learn.microsoft.com/en-us/nuget/api/overview
+*/
+
+const (
+	// nugetDefaultURL defines the nuget.org v3 service index url
+	nugetDefaultURL string = "https://api.nuget.org/v3/index.json"
+	// nugetDefaultUsername defines the username used when only a token is defined, ignored by Azure Artifacts
+	nugetDefaultUsername string = "updatecli"
+)
+
+// Nuget defines a resource of type "nuget"
+type Nuget struct {
+	spec Spec
+	// versionFilter holds the "valid" version.filter, that might be different from the user-specified filter (Spec.VersionFilter)
+	versionFilter version.Filter
+	foundVersion  version.Version
+	webClient     httpclient.HTTPClient
+}
+
+// New returns a reference to a newly initialized Nuget object from a nuget.Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*Nuget, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if newSpec.URL == "" {
+		newSpec.URL = nugetDefaultURL
+	}
+
+	newFilter, err := newSpec.VersionFilter.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Nuget{
+		spec:          newSpec,
+		versionFilter: newFilter,
+		webClient:     http.DefaultClient,
+	}, nil
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (n *Nuget) Changelog() string {
+	return ""
+}
@@ -0,0 +1,49 @@
+package nuget
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestFeed returns a NuGet v3 feed serving the newtonsoft.json package,
+// requiring basic authentication with token if defined
+func newTestFeed(t *testing.T, token string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			if _, password, ok := r.BasicAuth(); !ok || password != token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var body interface{}
+		switch r.URL.Path {
+		case "/v3/index.json":
+			body = map[string]interface{}{
+				"version": "3.0.0",
+				"resources": []map[string]string{
+					{"@id": server.URL + "/query", "@type": "SearchQueryService"},
+					{"@id": server.URL + "/v3-flatcontainer/", "@type": packageBaseAddressType},
+				},
+			}
+		case "/v3-flatcontainer/newtonsoft.json/index.json":
+			body = map[string][]string{
+				"versions": {"9.0.1", "12.0.3", "13.0.1", "13.0.3", "13.0.4-beta1", "13.0.3.1"},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
@@ -0,0 +1,105 @@
+package nuget
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+	https://learn.microsoft.com/en-us/nuget/concepts/package-versioning
+*/
+
+// nugetVersion defines a parsed NuGet version, a semantic version allowing a fourth "revision" number
+type nugetVersion struct {
+	numbers    [4]int
+	preRelease []string
+}
+
+// parseNugetVersion parses version according to NuGet versioning, ignoring its metadata
+func parseNugetVersion(version string) (nugetVersion, error) {
+	var v nugetVersion
+
+	version, _, _ = strings.Cut(strings.TrimSpace(version), "+")
+
+	release, preRelease, hasPreRelease := strings.Cut(version, "-")
+
+	numbers := strings.Split(release, ".")
+	if len(numbers) < 1 || len(numbers) > len(v.numbers) {
+		return v, fmt.Errorf("invalid nuget version %q", version)
+	}
+
+	for i, number := range numbers {
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid nuget version %q", version)
+		}
+		v.numbers[i] = n
+	}
+
+	if hasPreRelease {
+		if preRelease == "" {
+			return v, fmt.Errorf("invalid nuget version %q", version)
+		}
+		v.preRelease = strings.Split(preRelease, ".")
+	}
+
+	return v, nil
+}
+
+// isPreRelease returns true if the version is a prerelease version
+func (v nugetVersion) isPreRelease() bool {
+	return len(v.preRelease) > 0
+}
+
+// compare returns -1, 0, or 1 depending on whether v sorts before, the same as, or after o
+func (v nugetVersion) compare(o nugetVersion) int {
+	for i := range v.numbers {
+		if c := compareInt(v.numbers[i], o.numbers[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A release version sorts after its prerelease versions
+	switch {
+	case !v.isPreRelease() && !o.isPreRelease():
+		return 0
+	case !v.isPreRelease():
+		return 1
+	case !o.isPreRelease():
+		return -1
+	}
+
+	// Prerelease labels are compared as semantic versioning ones, but case insensitively
+	for i := 0; i < len(v.preRelease) && i < len(o.preRelease); i++ {
+		a, errA := strconv.Atoi(v.preRelease[i])
+		b, errB := strconv.Atoi(o.preRelease[i])
+
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareInt(a, b); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(strings.ToLower(v.preRelease[i]), strings.ToLower(o.preRelease[i])); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInt(len(v.preRelease), len(o.preRelease))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package nuget
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNugetVersionOrdering(t *testing.T) {
+	// Versions sorted from the oldest to the newest
+	expected := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-Beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0.1",
+		"1.2",
+		"1.10.0",
+	}
+
+	got := []string{}
+	parsed := map[string]nugetVersion{}
+	for i := len(expected) - 1; i >= 0; i-- {
+		v, err := parseNugetVersion(expected[i])
+		require.NoError(t, err)
+		parsed[expected[i]] = v
+		got = append(got, expected[i])
+	}
+
+	sort.SliceStable(got, func(i, j int) bool {
+		return parsed[got[i]].compare(parsed[got[j]]) < 0
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func TestParseNugetVersion(t *testing.T) {
+	tests := []struct {
+		version            string
+		equivalent         string
+		expectedPreRelease bool
+		expectedError      bool
+	}{
+		{version: "1.0", equivalent: "1.0.0.0"},
+		{version: "1.0.0+metadata", equivalent: "1.0.0"},
+		{version: "1.0.0-RC.1", equivalent: "1.0.0-rc.1", expectedPreRelease: true},
+		{version: "1.0.0.0.0", expectedError: true},
+		{version: "1.0.0-", expectedError: true},
+		{version: "latest", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parseNugetVersion(tt.version)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			equivalent, err := parseNugetVersion(tt.equivalent)
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, got.compare(equivalent))
+			assert.Equal(t, tt.expectedPreRelease, got.isPreRelease())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package nuget
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Source returns the latest NuGet package version
+func (n *Nuget) Source(workingDir string, resultSource *result.Source) error {
+	version, _, err := n.versions()
+	if err != nil {
+		return fmt.Errorf("searching nuget package version: %w", err)
+	}
+
+	if version == "" {
+		return fmt.Errorf("no version found for nuget package %q", n.spec.Name)
+	}
+
+	resultSource.Information = version
+	resultSource.Result = result.SUCCESS
+	resultSource.Description = fmt.Sprintf("version %s found for nuget package %q", version, n.spec.Name)
+
+	return nil
+}
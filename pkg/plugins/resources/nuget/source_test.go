@@ -0,0 +1,91 @@
+package nuget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           Spec
+		token          string
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name: "Retrieve the latest stable Newtonsoft.Json version",
+			spec: Spec{
+				Name: "Newtonsoft.Json",
+			},
+			expectedResult: "13.0.3.1",
+		},
+		{
+			name: "Retrieve the latest Newtonsoft.Json prerelease version",
+			spec: Spec{
+				Name:       "Newtonsoft.Json",
+				PreRelease: true,
+			},
+			expectedResult: "13.0.4-beta1",
+		},
+		{
+			name: "Retrieve the latest Newtonsoft.Json version matching a semver constraint",
+			spec: Spec{
+				Name: "Newtonsoft.Json",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~12",
+				},
+			},
+			expectedResult: "12.0.3",
+		},
+		{
+			name: "Retrieve the latest Newtonsoft.Json version from an authenticated feed",
+			spec: Spec{
+				Name:  "Newtonsoft.Json",
+				Token: "mytoken",
+			},
+			token:          "mytoken",
+			expectedResult: "13.0.3.1",
+		},
+		{
+			name: "Fail to retrieve the latest Newtonsoft.Json version from an authenticated feed with a wrong token",
+			spec: Spec{
+				Name:  "Newtonsoft.Json",
+				Token: "badtoken",
+			},
+			token:         "mytoken",
+			expectedError: true,
+		},
+		{
+			name: "Fail to retrieve a nonexistent package version",
+			spec: Spec{
+				Name: "nonexistent",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestFeed(t, tt.token)
+			tt.spec.URL = server.URL + "/v3/index.json"
+
+			n, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = n.Source("", &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package nuget
+
+import (
+	"errors"
+
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+// Spec defines a specification for a "nuget" resource
+// parsed from an updatecli manifest file
+type Spec struct {
+	// [S][C] Name defines the NuGet package id
+	Name string `yaml:",omitempty" jsonschema:"required"`
+	// [C] Version defines a specific package version
+	Version string `yaml:",omitempty"`
+	// [S][C] URL defines the NuGet v3 service index url (defaults to `https://api.nuget.org/v3/index.json`)
+	//
+	// An Azure Artifacts feed service index looks like `https://pkgs.dev.azure.com/<organization>/<project>/_packaging/<feed>/nuget/v3/index.json`
+	URL string `yaml:",omitempty"`
+	// [S][C] Username defines the username used to authenticate with a private NuGet feed
+	Username string `yaml:",omitempty"`
+	// [S][C] Token defines the token, such as an Azure DevOps personal access token, used to authenticate with a private NuGet feed
+	Token string `yaml:",omitempty"`
+	// [S] VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest.
+	VersionFilter version.Filter `yaml:",omitempty"`
+	// [S] PreRelease allows prerelease versions, such as "2.0.0-beta.1", to be retrieved
+	PreRelease bool `yaml:",omitempty"`
+}
+
+// Validate ensures that the provided Spec is valid
+func (s Spec) Validate() error {
+	if len(s.Name) == 0 {
+		return errors.New("nuget package name not defined")
+	}
+	return nil
+}
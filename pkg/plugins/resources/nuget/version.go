@@ -0,0 +1,140 @@
+package nuget
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// packageBaseAddressType defines the service index resource type listing package versions
+	packageBaseAddressType string = "PackageBaseAddress/3.0.0"
+)
+
+// serviceIndex defines the NuGet v3 service index, listing the resources provided by a feed
+type serviceIndex struct {
+	Resources []struct {
+		ID   string `json:"@id"`
+		Type string `json:"@type"`
+	} `json:"resources"`
+}
+
+// packageVersions defines the versions returned by the package base address resource
+type packageVersions struct {
+	Versions []string `json:"versions"`
+}
+
+// versions returns the version matching the version filter and the versions it was searched in,
+// sorted according to NuGet versioning and excluding prerelease versions unless allowed
+func (n *Nuget) versions() (v string, versions []string, err error) {
+	publishedVersions, err := n.publishedVersions()
+	if err != nil {
+		return "", nil, err
+	}
+
+	parsedVersions := map[string]nugetVersion{}
+	for _, publishedVersion := range publishedVersions {
+		parsedVersion, err := parseNugetVersion(publishedVersion)
+		if err != nil {
+			logrus.Debugf("skipping nuget package %q version %q: %s", n.spec.Name, publishedVersion, err)
+			continue
+		}
+
+		if parsedVersion.isPreRelease() && !n.spec.PreRelease {
+			continue
+		}
+
+		parsedVersions[publishedVersion] = parsedVersion
+		versions = append(versions, publishedVersion)
+	}
+
+	if len(versions) == 0 {
+		return "", versions, nil
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return parsedVersions[versions[i]].compare(parsedVersions[versions[j]]) < 0
+	})
+
+	n.foundVersion, err = n.versionFilter.Search(versions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return n.foundVersion.GetVersion(), versions, nil
+}
+
+// publishedVersions returns every version published for the package
+func (n *Nuget) publishedVersions() ([]string, error) {
+	var index serviceIndex
+	if err := n.get(n.spec.URL, &index); err != nil {
+		return nil, fmt.Errorf("retrieving nuget service index: %w", err)
+	}
+
+	baseAddress := ""
+	for _, resource := range index.Resources {
+		if resource.Type == packageBaseAddressType {
+			baseAddress = resource.ID
+			break
+		}
+	}
+
+	if baseAddress == "" {
+		return nil, fmt.Errorf("nuget service index %q doesn't provide a %q resource", n.spec.URL, packageBaseAddressType)
+	}
+
+	// Package ids are lowercased by the package base address resource
+	URL, err := url.JoinPath(baseAddress, strings.ToLower(n.spec.Name), "index.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var d packageVersions
+	if err := n.get(URL, &d); err != nil {
+		return nil, fmt.Errorf("retrieving nuget package %q versions: %w", n.spec.Name, err)
+	}
+
+	return d.Versions, nil
+}
+
+// get decodes the JSON document returned by URL into v
+func (n *Nuget) get(URL string, v interface{}) error {
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		return err
+	}
+
+	if n.spec.Token != "" {
+		username := n.spec.Username
+		if username == "" {
+			username = nugetDefaultUsername
+		}
+		req.SetBasicAuth(username, n.spec.Token)
+	}
+
+	res, err := n.webClient.Do(req)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting nuget api data %q\n", err)
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		body, _ := httputil.DumpResponse(res, false)
+		logrus.Debugf("\n%v\n", string(body))
+		return fmt.Errorf("nuget api %q returned %q", URL, res.Status)
+	}
+
+	err = json.NewDecoder(res.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("decoding nuget api data: %w", err)
+	}
+
+	return nil
+}
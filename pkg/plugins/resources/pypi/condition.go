@@ -0,0 +1,40 @@
+package pypi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Condition checks that a Python package version is published
+func (p *Pypi) Condition(source string, scm scm.ScmHandler, resultCondition *result.Condition) error {
+	versionToCheck := p.spec.Version
+	if versionToCheck == "" {
+		versionToCheck = source
+	}
+	if len(versionToCheck) == 0 {
+		return errors.New("no version defined")
+	}
+
+	releases, err := p.releases()
+	if err != nil {
+		return fmt.Errorf("searching pypi package version: %w", err)
+	}
+
+	for _, v := range releases {
+		if v == versionToCheck {
+			resultCondition.Pass = true
+			resultCondition.Result = result.SUCCESS
+			resultCondition.Description = fmt.Sprintf("version %q available for pypi package %q", versionToCheck, p.spec.Name)
+			return nil
+		}
+	}
+
+	resultCondition.Pass = false
+	resultCondition.Result = result.FAILURE
+	resultCondition.Description = fmt.Sprintf("version %q doesn't exist for pypi package %q", versionToCheck, p.spec.Name)
+
+	return nil
+}
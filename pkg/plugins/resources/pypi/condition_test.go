@@ -0,0 +1,78 @@
+package pypi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           Spec
+		source         string
+		expectedResult bool
+		expectedError  bool
+	}{
+		{
+			name: "Version published",
+			spec: Spec{
+				Name:    "requests",
+				Version: "2.31.0",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "Pre-release version published",
+			spec: Spec{
+				Name: "requests",
+			},
+			source:         "3.0.0rc1",
+			expectedResult: true,
+		},
+		{
+			name: "Version yanked",
+			spec: Spec{
+				Name:    "requests",
+				Version: "2.32.0",
+			},
+			expectedResult: false,
+		},
+		{
+			name: "Version not published",
+			spec: Spec{
+				Name:    "requests",
+				Version: "1.0.0",
+			},
+			expectedResult: false,
+		},
+		{
+			name: "No version defined",
+			spec: Spec{
+				Name: "requests",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestIndex(t, "", "")
+			tt.spec.URL = server.URL
+
+			p, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = p.Condition(tt.source, nil, &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Pass)
+		})
+	}
+}
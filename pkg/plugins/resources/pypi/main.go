@@ -0,0 +1,63 @@
+package pypi
+
+import (
+	"net/http"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+/*
+	https://warehouse.pypa.io/api-reference/json.html
+*/
+
+const (
+	// pypiDefaultURL defines the default Python package index url
+	pypiDefaultURL string = "https://pypi.org/"
+)
+
+// Pypi defines a resource of type "pypi"
+type Pypi struct {
+	spec Spec
+	// versionFilter holds the "valid" version.filter, that might be different from the user-specified filter (Spec.VersionFilter)
+	versionFilter version.Filter
+	foundVersion  version.Version
+	webClient     httpclient.HTTPClient
+}
+
+// New returns a reference to a newly initialized Pypi object from a pypi.Spec
+// or an error if the provided Spec triggers a validation error.
+func New(spec interface{}) (*Pypi, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = newSpec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if newSpec.URL == "" {
+		newSpec.URL = pypiDefaultURL
+	}
+
+	newFilter, err := newSpec.VersionFilter.Init()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pypi{
+		spec:          newSpec,
+		versionFilter: newFilter,
+		webClient:     http.DefaultClient,
+	}, nil
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (p *Pypi) Changelog() string {
+	return ""
+}
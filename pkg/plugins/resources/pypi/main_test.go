@@ -0,0 +1,47 @@
+package pypi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const requestsPackageData = `{
+	"info": {"name": "requests", "version": "2.31.0"},
+	"releases": {
+		"2.9.0": [{"filename": "requests-2.9.0.tar.gz", "yanked": false}],
+		"2.10.0": [{"filename": "requests-2.10.0.tar.gz", "yanked": false}],
+		"2.31.0": [{"filename": "requests-2.31.0.tar.gz", "yanked": false}],
+		"2.32.0": [{"filename": "requests-2.32.0.tar.gz", "yanked": true}],
+		"3.0.0rc1": [{"filename": "requests-3.0.0rc1.tar.gz", "yanked": false}],
+		"3.0.0.dev1": [{"filename": "requests-3.0.0.dev1.tar.gz", "yanked": false}],
+		"4.0.0": []
+	}
+}`
+
+// newTestIndex returns a Python package index server serving the requests package,
+// requiring basic authentication if username is defined
+func newTestIndex(t *testing.T, username, password string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username != "" {
+			u, p, ok := r.BasicAuth()
+			if !ok || u != username || p != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if r.URL.Path != "/pypi/requests/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(requestsPackageData)); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
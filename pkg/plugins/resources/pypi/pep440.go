@@ -0,0 +1,209 @@
+package pypi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+	https://peps.python.org/pep-0440/
+*/
+
+// pep440Regex matches a version as defined by PEP 440, including the alternative spellings it normalizes
+var pep440Regex = regexp.MustCompile(`^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440PreReleasePhases defines the order of the normalized pre-release phases
+var pep440PreReleasePhases = map[string]int{
+	"a":  0,
+	"b":  1,
+	"rc": 2,
+}
+
+// pep440Version defines a parsed PEP 440 version
+type pep440Version struct {
+	epoch   int
+	release []int
+	// pre is the pre-release phase, such as "a", "b", or "rc", empty if it isn't a pre-release
+	pre   string
+	preN  int
+	post  bool
+	postN int
+	dev   bool
+	devN  int
+	local []string
+}
+
+// parsePEP440 parses version according to PEP 440
+func parsePEP440(version string) (pep440Version, error) {
+	var v pep440Version
+
+	match := pep440Regex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(version)))
+	if match == nil {
+		return v, fmt.Errorf("invalid PEP 440 version %q", version)
+	}
+
+	group := func(name string) string {
+		return match[pep440Regex.SubexpIndex(name)]
+	}
+
+	number := func(s string) int {
+		// The regex only matches digits, so only an overflow can fail
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	v.epoch = number(group("epoch"))
+
+	for _, segment := range strings.Split(group("release"), ".") {
+		v.release = append(v.release, number(segment))
+	}
+
+	switch group("pre_l") {
+	case "":
+	case "a", "alpha":
+		v.pre = "a"
+	case "b", "beta":
+		v.pre = "b"
+	default:
+		v.pre = "rc"
+	}
+	v.preN = number(group("pre_n"))
+
+	if group("post_n1") != "" || group("post_l") != "" {
+		v.post = true
+		v.postN = number(group("post_n1") + group("post_n2"))
+	}
+
+	if group("dev_l") != "" {
+		v.dev = true
+		v.devN = number(group("dev_n"))
+	}
+
+	if local := group("local"); local != "" {
+		v.local = strings.FieldsFunc(local, func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return v, nil
+}
+
+// isPreRelease returns true if the version is a pre-release or a development release
+func (v pep440Version) isPreRelease() bool {
+	return v.pre != "" || v.dev
+}
+
+// compare returns -1, 0, or 1 depending on whether v sorts before, the same as, or after o according to PEP 440
+func (v pep440Version) compare(o pep440Version) int {
+	if c := compareInt(v.epoch, o.epoch); c != 0 {
+		return c
+	}
+
+	// Trailing zeros are ignored, so "1.0" and "1.0.0" are equal
+	for i := 0; i < len(v.release) || i < len(o.release); i++ {
+		var a, b int
+		if i < len(v.release) {
+			a = v.release[i]
+		}
+		if i < len(o.release) {
+			b = o.release[i]
+		}
+		if c := compareInt(a, b); c != 0 {
+			return c
+		}
+	}
+
+	if c := compareInt(v.preRank(), o.preRank()); c != 0 {
+		return c
+	}
+	if v.pre != "" {
+		if c := compareInt(v.preN, o.preN); c != 0 {
+			return c
+		}
+	}
+
+	// A post-release sorts after the release it follows
+	if c := compareBool(v.post, o.post); c != 0 {
+		return c
+	}
+	if c := compareInt(v.postN, o.postN); c != 0 {
+		return c
+	}
+
+	// A development release sorts before the release it precedes
+	if c := compareBool(!v.dev, !o.dev); c != 0 {
+		return c
+	}
+	if c := compareInt(v.devN, o.devN); c != 0 {
+		return c
+	}
+
+	return compareLocal(v.local, o.local)
+}
+
+// preRank returns the rank of the pre-release phase, a development release of a final release,
+// such as "1.0.dev1", sorting before its pre-releases and a final release after them
+func (v pep440Version) preRank() int {
+	switch {
+	case v.pre != "":
+		return pep440PreReleasePhases[v.pre]
+	case v.dev && !v.post:
+		return -1
+	default:
+		return len(pep440PreReleasePhases)
+	}
+}
+
+// compareLocal compares local version labels, numeric segments sorting after alphanumeric ones
+// and a version with a local label sorting after the same version without one
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareInt(na, nb); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return 1
+		case errB == nil:
+			return -1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInt(len(a), len(b))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
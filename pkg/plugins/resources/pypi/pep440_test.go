@@ -0,0 +1,80 @@
+package pypi
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPEP440Ordering(t *testing.T) {
+	// Versions sorted from the oldest to the newest according to PEP 440
+	expected := []string{
+		"1.0.dev0",
+		"1.0.dev1",
+		"1.0a1.dev1",
+		"1.0a1",
+		"1.0b1",
+		"1.0rc1",
+		"1.0",
+		"1.0+local.1",
+		"1.0+local.2",
+		"1.0.post1.dev1",
+		"1.0.post1",
+		"1.0.1",
+		"1.2",
+		"1.10",
+		"2.0",
+		"1!0.1",
+	}
+
+	got := []string{}
+	parsed := map[string]pep440Version{}
+	for i := len(expected) - 1; i >= 0; i-- {
+		v, err := parsePEP440(expected[i])
+		require.NoError(t, err)
+		parsed[expected[i]] = v
+		got = append(got, expected[i])
+	}
+
+	sort.SliceStable(got, func(i, j int) bool {
+		return parsed[got[i]].compare(parsed[got[j]]) < 0
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func TestParsePEP440(t *testing.T) {
+	tests := []struct {
+		version            string
+		equivalent         string
+		expectedPreRelease bool
+		expectedError      bool
+	}{
+		{version: "1.0", equivalent: "1.0.0"},
+		{version: "v1.0", equivalent: "1.0"},
+		{version: "1.0-alpha.1", equivalent: "1.0a1", expectedPreRelease: true},
+		{version: "1.0c1", equivalent: "1.0rc1", expectedPreRelease: true},
+		{version: "1.0-1", equivalent: "1.0.post1"},
+		{version: "1.0.dev", equivalent: "1.0.dev0", expectedPreRelease: true},
+		{version: "latest", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := parsePEP440(tt.version)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			equivalent, err := parsePEP440(tt.equivalent)
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, got.compare(equivalent))
+			assert.Equal(t, tt.expectedPreRelease, got.isPreRelease())
+		})
+	}
+}
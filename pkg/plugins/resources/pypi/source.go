@@ -0,0 +1,25 @@
+package pypi
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Source returns the latest Python package version
+func (p *Pypi) Source(workingDir string, resultSource *result.Source) error {
+	version, _, err := p.versions()
+	if err != nil {
+		return fmt.Errorf("searching pypi package version: %w", err)
+	}
+
+	if version == "" {
+		return fmt.Errorf("no version found for pypi package %q", p.spec.Name)
+	}
+
+	resultSource.Information = version
+	resultSource.Result = result.SUCCESS
+	resultSource.Description = fmt.Sprintf("version %s found for pypi package %q", version, p.spec.Name)
+
+	return nil
+}
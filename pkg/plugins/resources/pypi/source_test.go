@@ -0,0 +1,103 @@
+package pypi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           Spec
+		username       string
+		password       string
+		expectedResult string
+		expectedError  bool
+	}{
+		{
+			name: "Retrieve the latest requests version",
+			spec: Spec{
+				Name: "requests",
+			},
+			expectedResult: "2.31.0",
+		},
+		{
+			name: "Retrieve the latest requests version using a non normalized name",
+			spec: Spec{
+				Name: "Requests",
+			},
+			expectedResult: "2.31.0",
+		},
+		{
+			name: "Retrieve the latest requests pre-release version",
+			spec: Spec{
+				Name:       "requests",
+				PreRelease: true,
+			},
+			expectedResult: "3.0.0rc1",
+		},
+		{
+			name: "Retrieve the latest requests version matching a semver constraint",
+			spec: Spec{
+				Name: "requests",
+				VersionFilter: version.Filter{
+					Kind:    "semver",
+					Pattern: "~2.10",
+				},
+			},
+			expectedResult: "2.10.0",
+		},
+		{
+			name: "Retrieve the latest requests version from a private index",
+			spec: Spec{
+				Name:     "requests",
+				Username: "updatecli",
+				Password: "secret",
+			},
+			username:       "updatecli",
+			password:       "secret",
+			expectedResult: "2.31.0",
+		},
+		{
+			name: "Fail to retrieve the latest requests version from a private index with a wrong password",
+			spec: Spec{
+				Name:     "requests",
+				Username: "updatecli",
+				Password: "wrong",
+			},
+			username:      "updatecli",
+			password:      "secret",
+			expectedError: true,
+		},
+		{
+			name: "Fail to retrieve a nonexistent package version",
+			spec: Spec{
+				Name: "nonexistent",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestIndex(t, tt.username, tt.password)
+			tt.spec.URL = server.URL
+
+			p, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Source{}
+			err = p.Source("", &gotResult)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, gotResult.Information)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package pypi
+
+import (
+	"errors"
+
+	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
+)
+
+// Spec defines a specification for a "pypi" resource
+// parsed from an updatecli manifest file
+type Spec struct {
+	// [S][C] Name defines the Python package name
+	Name string `yaml:",omitempty" jsonschema:"required"`
+	// [C] Version defines a specific package version
+	Version string `yaml:",omitempty"`
+	// [S][C] URL defines the Python package index url (defaults to `https://pypi.org/`)
+	URL string `yaml:",omitempty"`
+	// [S][C] Username defines the username used to authenticate with a private Python package index
+	Username string `yaml:",omitempty"`
+	// [S][C] Password defines the password used to authenticate with a private Python package index
+	Password string `yaml:",omitempty"`
+	// [S] VersionFilter provides parameters to specify version pattern and its type like regex, semver, or just latest.
+	VersionFilter version.Filter `yaml:",omitempty"`
+	// [S] PreRelease allows pre-release and development versions, such as "2.0.0rc1", to be retrieved
+	PreRelease bool `yaml:",omitempty"`
+}
+
+// Validate ensures that the provided Spec is valid
+func (s Spec) Validate() error {
+	if len(s.Name) == 0 {
+		return errors.New("pypi package name not defined")
+	}
+	return nil
+}
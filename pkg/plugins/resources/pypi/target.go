@@ -0,0 +1,13 @@
+package pypi
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+// Target is not supported for pypi
+func (p *Pypi) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
+	return fmt.Errorf("Target not supported for the plugin pypi")
+}
@@ -0,0 +1,122 @@
+package pypi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// releaseFile defines a file, such as a wheel, published for a package release
+type releaseFile struct {
+	Yanked bool `json:"yanked"`
+}
+
+// packageData defines the package metadata returned by the Python package index JSON api
+type packageData struct {
+	Releases map[string][]releaseFile `json:"releases"`
+}
+
+// nameSeparators matches the separators normalized in Python package names
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName returns the Python package name normalized as defined by PEP 503
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(name, "-"))
+}
+
+// versions returns the version matching the version filter and the versions it was searched in,
+// sorted according to PEP 440 and excluding pre-releases unless allowed
+func (p *Pypi) versions() (v string, versions []string, err error) {
+	releases, err := p.releases()
+	if err != nil {
+		return "", nil, err
+	}
+
+	parsedVersions := map[string]pep440Version{}
+	for _, release := range releases {
+		parsedVersion, err := parsePEP440(release)
+		if err != nil {
+			logrus.Debugf("skipping pypi package %q version %q: %s", p.spec.Name, release, err)
+			continue
+		}
+
+		if parsedVersion.isPreRelease() && !p.spec.PreRelease {
+			continue
+		}
+
+		parsedVersions[release] = parsedVersion
+		versions = append(versions, release)
+	}
+
+	if len(versions) == 0 {
+		return "", versions, nil
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return parsedVersions[versions[i]].compare(parsedVersions[versions[j]]) < 0
+	})
+
+	p.foundVersion, err = p.versionFilter.Search(versions)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return p.foundVersion.GetVersion(), versions, nil
+}
+
+// releases returns the package versions published with at least one file not yanked
+func (p *Pypi) releases() ([]string, error) {
+	URL, err := url.JoinPath(p.spec.URL, "pypi", normalizeName(p.spec.Name), "json")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.spec.Username != "" || p.spec.Password != "" {
+		req.SetBasicAuth(p.spec.Username, p.spec.Password)
+	}
+
+	res, err := p.webClient.Do(req)
+	if err != nil {
+		logrus.Errorf("something went wrong while getting pypi api data %q\n", err)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		body, _ := httputil.DumpResponse(res, false)
+		logrus.Debugf("\n%v\n", string(body))
+		return nil, fmt.Errorf("pypi api %q returned %q", URL, res.Status)
+	}
+
+	var d packageData
+	err = json.NewDecoder(res.Body).Decode(&d)
+	if err != nil {
+		return nil, fmt.Errorf("decoding pypi api data: %w", err)
+	}
+
+	var releases []string
+	for release, files := range d.Releases {
+		for _, file := range files {
+			if !file.Yanked {
+				releases = append(releases, release)
+				break
+			}
+		}
+	}
+
+	sort.Strings(releases)
+
+	return releases, nil
+}
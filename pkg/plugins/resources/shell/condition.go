@@ -31,6 +31,12 @@ func (s *Shell) Condition(source string, scm scm.ScmHandler, resultCondition *re
 		Value: "condition",
 	})
 
+	// Provides the "UPDATECLI_SOURCE_VALUE" environment variable so the command doesn't need to parse its arguments
+	env = append(env, Environment{
+		Name:  SourceVariableName,
+		Value: source,
+	})
+
 	err = s.success.PreCommand(s.getWorkingDirPath(workingDir))
 	if err != nil {
 		return err
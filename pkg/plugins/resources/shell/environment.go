@@ -10,6 +10,8 @@ const (
 	DryRunVariableName = "DRY_RUN"
 	// CurrentStageVariableName is the environment variable containing the current pipeline stage such as source, condition, target
 	CurrentStageVariableName = "UPDATECLI_PIPELINE_STAGE"
+	// SourceVariableName is the environment variable containing the source value passed to a condition or a target
+	SourceVariableName = "UPDATECLI_SOURCE_VALUE"
 )
 
 // Environment is a struct containing information for an environment variable such as its name and its value
@@ -43,7 +43,7 @@ func (e Environments) isDuplicate() bool {
 	return false
 }
 
-// Validate ensures that we don't have duplicated value for a variable and that the user is not attempting to override the DRY_RUN reserved variable.
+// Validate ensures that we don't have duplicated value for a variable and that the user is not attempting to override the DRY_RUN or UPDATECLI_SOURCE_VALUE reserved variables.
 func (e Environments) Validate() error {
 
 	gotErr := false
@@ -54,9 +54,9 @@ func (e Environments) Validate() error {
 			logrus.Errorf("error with environment variable %q - %q", environment.Name, err)
 		}
 
-		if environment.Name == DryRunVariableName {
+		if environment.Name == DryRunVariableName || environment.Name == SourceVariableName {
 			gotErr = true
-			logrus.Errorf("environment variable %q is defined and overridden by the Updatecli process", DryRunVariableName)
+			logrus.Errorf("environment variable %q is defined and overridden by the Updatecli process", environment.Name)
 
 		}
 	}
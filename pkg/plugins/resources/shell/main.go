@@ -13,6 +13,9 @@ import (
 // parsed from an updatecli manifest file
 type Spec struct {
 	// command specifies the shell command to execute by Updatecli
+	//
+	// In a condition or a target, the source value is appended as last argument of the command
+	// and is also available from the environment variable "UPDATECLI_SOURCE_VALUE"
 	Command string `yaml:",omitempty" jsonschema:"required"`
 	// environments allows to pass environment variable(s) to the shell script. By default no environment variable are shared.
 	Environments Environments `yaml:",omitempty"`
@@ -140,6 +140,31 @@ func TestShell_New(t *testing.T) {
 				interpreter: getDefaultShell(),
 			},
 		},
+		{
+			name: "Not allowed to specify UPDATECLI_SOURCE_VALUE environment variable",
+			spec: Spec{
+				Command: "echo Hello",
+				Environments: Environments{
+					Environment{
+						Name: "UPDATECLI_SOURCE_VALUE",
+					},
+				},
+			},
+			wantErr: true,
+			wantShell: &Shell{
+				executor: &nativeCommandExecutor{},
+				spec: Spec{
+					Command: "echo Hello",
+					Shell:   "/bin/sh",
+					Environments: Environments{
+						Environment{
+							Name: "PATH",
+						},
+					},
+				},
+				interpreter: getDefaultShell(),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,6 +37,7 @@ func (s *Shell) Target(source string, scm scm.ScmHandler, dryRun bool, resultTar
 //   - Any other exit code means "failed command with no change"
 //
 // The environment variable 'DRY_RUN' is set to true or false based on the input parameter (e.g. 'updatecli diff' or 'apply'?)
+// and the environment variable 'UPDATECLI_SOURCE_VALUE' is set to the source value
 func (s *Shell) target(source, workingDir string, dryRun bool, resultTarget *result.Target) error {
 
 	// Ensure environment variable(s) are up to date
@@ -56,6 +57,12 @@ func (s *Shell) target(source, workingDir string, dryRun bool, resultTarget *res
 		Value: "target",
 	})
 
+	// Provides the "UPDATECLI_SOURCE_VALUE" environment variable so the command doesn't need to parse its arguments
+	env = append(env, Environment{
+		Name:  SourceVariableName,
+		Value: source,
+	})
+
 	// Provides the "DRY_RUN" environment variable to the shell command (true if "diff", false if "apply")
 	env = append(env, Environment{
 		Name:  DryRunVariableName,
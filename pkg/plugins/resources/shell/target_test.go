@@ -34,7 +34,7 @@ func TestShell_Target(t *testing.T) {
 				ExitCode: 0,
 				Stdout:   "",
 			},
-			commandEnv: []string{"DRY_RUN=false"},
+			commandEnv: []string{"DRY_RUN=false", "UPDATECLI_SOURCE_VALUE=1.2.3"},
 		},
 		{
 			name:              "runs a target that changes a value and no dryrun",
@@ -47,7 +47,7 @@ func TestShell_Target(t *testing.T) {
 				ExitCode: 0,
 				Stdout:   "1.2.3",
 			},
-			commandEnv: []string{"DRY_RUN=false"},
+			commandEnv: []string{"DRY_RUN=false", "UPDATECLI_SOURCE_VALUE=1.2.3"},
 			shell:      "/bin/bash",
 		},
 		{
@@ -12,7 +12,7 @@ import (
 )
 
 // CreateAction opens a Pull Request on the Bitbucket server
-func (s *Stash) CreateAction(report reports.Action) error {
+func (s *Stash) CreateAction(report *reports.Action) error {
 
 	title := report.Title
 	if len(s.spec.Title) > 0 {
@@ -99,6 +99,7 @@ func (s *Stash) CreateAction(report reports.Action) error {
 	}
 
 	logrus.Infof("Bitbucket pullrequest successfully opened on %q", pr.Link)
+	report.Link = pr.Link
 
 	return nil
 }
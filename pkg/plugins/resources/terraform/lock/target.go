@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
 func (t *TerraformLock) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
@@ -72,12 +73,14 @@ func (t *TerraformLock) Target(source string, scm scm.ScmHandler, dryRun bool, r
 			valueToWrite,
 			resourceFile.originalFilePath)
 
-		if !dryRun {
+		if err := t.Apply(fileKey, valueToWrite, remoteHashes); err != nil {
+			return err
+		}
 
-			if err := t.Apply(fileKey, valueToWrite, remoteHashes); err != nil {
-				return err
-			}
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, t.files[fileKey].content))
 
+		if !dryRun {
 			if err := t.contentRetriever.WriteToFile(
 				t.files[fileKey].content,
 				t.files[fileKey].filePath,
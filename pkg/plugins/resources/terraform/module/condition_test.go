@@ -0,0 +1,59 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestCondition(t *testing.T) {
+	testData := []struct {
+		name           string
+		spec           Spec
+		source         string
+		expectedResult bool
+	}{
+		{
+			name: "Success - Using Value",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+				Value:  "5.1.0",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "Success - Using Source",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git",
+			},
+			source:         "19.16.0",
+			expectedResult: true,
+		},
+		{
+			name: "Failure - Outdated version",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			source:         "5.2.0",
+			expectedResult: false,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Condition{}
+			err = l.Condition(tt.source, nil, &gotResult)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, gotResult.Pass)
+		})
+	}
+}
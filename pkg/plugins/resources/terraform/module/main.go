@@ -0,0 +1,195 @@
+package module
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/minamijoyo/tfupdate/tfupdate"
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
+	terraformUtils "github.com/updatecli/updatecli/pkg/plugins/resources/terraform"
+	"github.com/updatecli/updatecli/pkg/plugins/utils"
+)
+
+type TerraformModule struct {
+	spec             Spec
+	contentRetriever text.TextRetriever
+	files            map[string]file // map of file paths to file contents
+}
+
+type file struct {
+	originalFilePath string
+	filePath         string
+	content          string
+}
+
+func New(spec interface{}) (*TerraformModule, error) {
+	newSpec := Spec{}
+
+	err := mapstructure.Decode(spec, &newSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	newResource := &TerraformModule{
+		spec:             newSpec,
+		contentRetriever: &text.Text{},
+	}
+
+	err = newResource.spec.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	newResource.files = make(map[string]file)
+	// File as unique element of newResource.files
+	if len(newResource.spec.File) > 0 {
+		filePath := strings.TrimPrefix(newResource.spec.File, "file://")
+		newResource.files[filePath] = file{
+			originalFilePath: filePath,
+			filePath:         filePath,
+		}
+	}
+	// Files
+	for _, filePath := range newResource.spec.Files {
+		filePath := strings.TrimPrefix(filePath, "file://")
+		newResource.files[filePath] = file{
+			originalFilePath: filePath,
+			filePath:         filePath,
+		}
+	}
+
+	return newResource, nil
+}
+
+// moduleSourceRegexp matches a module source with a version reference, as updated by tfupdate
+var moduleSourceRegexp = regexp.MustCompile(`(.+)\?ref=v([0-9]+(\.[0-9]+)*(-.*)*)`)
+
+func (t *TerraformModule) Query(resourceFile file) (string, error) {
+	file, err := terraformUtils.ParseHcl(resourceFile.content, resourceFile.originalFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	var version string
+
+	for _, block := range file.Body().Blocks() {
+		if block.Type() != "module" {
+			continue
+		}
+
+		source := quotedLiteral(block.Body().GetAttribute("source"))
+
+		// Git modules specify their version as a reference in their source
+		if matched := moduleSourceRegexp.FindStringSubmatch(source); len(matched) > 0 {
+			if matched[1] == t.spec.Module {
+				version = matched[2]
+				break
+			}
+			continue
+		}
+
+		if source == t.spec.Module {
+			version = quotedLiteral(block.Body().GetAttribute("version"))
+			if version != "" {
+				break
+			}
+		}
+	}
+
+	if version == "" {
+		err := fmt.Errorf("%s cannot find value for %q from file %q",
+			result.FAILURE,
+			t.spec.Module,
+			resourceFile.originalFilePath)
+		return "", err
+	}
+
+	return version, nil
+}
+
+// quotedLiteral returns the value of an attribute defined as a quoted string, or an empty string otherwise
+func quotedLiteral(attr *hclwrite.Attribute) string {
+	if attr == nil {
+		return ""
+	}
+
+	tokens := attr.Expr().BuildTokens(nil)
+	if len(tokens) == 3 &&
+		tokens[0].Type == hclsyntax.TokenOQuote &&
+		tokens[1].Type == hclsyntax.TokenQuotedLit &&
+		tokens[2].Type == hclsyntax.TokenCQuote {
+		return string(tokens[1].Bytes)
+	}
+
+	return ""
+}
+
+func (t *TerraformModule) Apply(filePath string, versionToWrite string) error {
+	resourceFile := t.files[filePath]
+
+	file, err := terraformUtils.ParseHcl(resourceFile.content, resourceFile.originalFilePath)
+	if err != nil {
+		return err
+	}
+
+	updater, err := tfupdate.NewModuleUpdater(t.spec.Module, versionToWrite)
+	if err != nil {
+		return err
+	}
+
+	// Second arguments not used downstream
+	if err := updater.Update(context.Background(), nil, resourceFile.originalFilePath, file); err != nil {
+		return err
+	}
+
+	resourceFile.content = string(hclwrite.Format(file.BuildTokens(nil).Bytes()))
+
+	t.files[filePath] = resourceFile
+
+	return nil
+}
+
+// Read puts the content of the file(s) as value of the y.files map if the file(s) exist(s) or log the non existence of the file
+func (t *TerraformModule) Read() error {
+	var err error
+
+	// Retrieve files content
+	for filePath := range t.files {
+		f := t.files[filePath]
+		if t.contentRetriever.FileExists(f.filePath) {
+			f.content, err = t.contentRetriever.ReadAll(f.filePath)
+			if err != nil {
+				return err
+			}
+			t.files[filePath] = f
+
+		} else {
+			return fmt.Errorf("%s The specified file %q does not exist", result.FAILURE, f.filePath)
+		}
+	}
+	return nil
+}
+
+func (t *TerraformModule) UpdateAbsoluteFilePath(workDir string) {
+	for filePath := range t.files {
+		if workDir != "" {
+			f := t.files[filePath]
+			f.filePath = utils.JoinFilePathWithWorkingDirectoryPath(f.originalFilePath, workDir)
+			logrus.Debugf(workDir)
+			logrus.Debugf("Relative path detected: changing from %q to absolute path from SCM: %q", f.originalFilePath, f.filePath)
+			t.files[filePath] = f
+		}
+	}
+}
+
+// Changelog returns the changelog for this resource, or an empty string if not supported
+func (t *TerraformModule) Changelog() string {
+	return ""
+}
@@ -0,0 +1,145 @@
+package module
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	testData := []struct {
+		name             string
+		spec             Spec
+		expectedErrorMsg error
+		wantErr          bool
+		expectedResult   string
+	}{
+		{
+			name: "Success - Query registry module",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			expectedResult: "5.1.0",
+		},
+		{
+			name: "Success - Query git module",
+			spec: Spec{
+				Files:  []string{"testdata/main.tf"},
+				Module: "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git",
+			},
+			expectedResult: "19.16.0",
+		},
+		{
+			name: "Failure - module without version",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "./modules/local",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New(`✗ cannot find value for "./modules/local" from file "testdata/main.tf"`),
+		},
+		{
+			name: "Failure - missing",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/iam/aws",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New(`✗ cannot find value for "terraform-aws-modules/iam/aws" from file "testdata/main.tf"`),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(tt.spec)
+			require.NoError(t, err)
+
+			err = h.Read()
+			require.NoError(t, err)
+
+			resultVersion, err := h.Query(h.files["testdata/main.tf"])
+
+			if tt.wantErr {
+				assert.Equal(t, tt.expectedErrorMsg.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedResult, resultVersion)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	testData := []struct {
+		name           string
+		spec           Spec
+		value          string
+		expectedResult string
+	}{
+		{
+			name: "Success - Update registry module",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			value: "5.2.0",
+			expectedResult: `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.2.0"
+
+  name = "updatecli"
+}
+
+module "eks" {
+  source = "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git?ref=v19.16.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}
+`,
+		},
+		{
+			name: "Success - Update git module",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git",
+			},
+			value: "19.17.0",
+			expectedResult: `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.1.0"
+
+  name = "updatecli"
+}
+
+module "eks" {
+  source = "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git?ref=v19.17.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}
+`,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(tt.spec)
+			require.NoError(t, err)
+
+			err = h.Read()
+			require.NoError(t, err)
+
+			err = h.Apply(tt.spec.File, tt.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedResult, h.files[tt.spec.File].content)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package module
+
+import (
+	"fmt"
+
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func (t *TerraformModule) Source(workingDir string, resultSource *result.Source) error {
+	return fmt.Errorf("Source not supported for the plugin terraform/module")
+}
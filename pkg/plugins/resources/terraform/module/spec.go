@@ -0,0 +1,104 @@
+package module
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+/*
+"terraform/module" defines the specification for manipulating module versions in terraform files.
+It can be used as a "condition", or a "target".
+*/
+type Spec struct {
+	/*
+		"file" defines the file path to interact with.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* "file" and "files" are mutually exclusive
+			* protocols "https://", "http://", and "file://" are supported in path for condition
+	*/
+	File string `yaml:",omitempty"`
+	/*
+		"files" defines the list of files path to interact with.
+
+		compatible:
+			* condition
+			* target
+
+		remark:
+			* file and files are mutually exclusive
+			* when using as a condition only one file is supported
+			* protocols "https://", "http://", and "file://" are supported in file path for condition
+	*/
+	Files []string `yaml:",omitempty"`
+	/*
+		"value" is the version associated with a terraform module.
+
+		compatible:
+			* condition
+			* target
+
+		default:
+			When used from a condition or a target, the default value is set to linked source output.
+	*/
+	Value string `yaml:",omitempty"`
+
+	/*
+		"module" is the source of the terraform module you wish to update, without its version reference.
+
+		compatible:
+			* condition
+			* target
+
+		example:
+			* terraform-aws-modules/vpc/aws
+			* git::https://github.com/terraform-aws-modules/terraform-aws-vpc.git
+
+		remark:
+			* the "version" argument of registry modules is updated
+			* the "?ref=v<version>" reference of git modules is updated
+	*/
+	Module string `yaml:",omitempty"`
+}
+
+var (
+	// ErrSpecFileUndefined is returned if a file wasn't specified
+	ErrSpecFileUndefined = errors.New("terraform/module file undefined")
+	// ErrSpecModuleUndefined is returned if a module wasn't specified
+	ErrSpecModuleUndefined = errors.New("terraform/module module undefined")
+	// ErrSpecFileAndFilesDefined when we both spec File and Files have been specified
+	ErrSpecFileAndFilesDefined = errors.New("parameter \"file\" and \"files\" are mutually exclusive")
+	// ErrWrongSpec is returned when the Spec has wrong content
+	ErrWrongSpec error = errors.New("wrong spec content")
+)
+
+func (s *Spec) Validate() error {
+	var errs []error
+
+	if len(s.File) == 0 && len(s.Files) == 0 {
+		errs = append(errs, ErrSpecFileUndefined)
+	}
+
+	if len(s.File) > 0 && len(s.Files) > 0 {
+		errs = append(errs, ErrSpecFileAndFilesDefined)
+	}
+
+	if len(s.Module) == 0 {
+		errs = append(errs, ErrSpecModuleUndefined)
+	}
+
+	for _, e := range errs {
+		logrus.Errorln(e)
+	}
+
+	if len(errs) > 0 {
+		return ErrWrongSpec
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package module
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testData := []struct {
+		name             string
+		spec             Spec
+		expectedErrorMsg error
+		wantErr          bool
+	}{
+		{
+			name: "Success - File",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+		},
+		{
+			name: "Failure - No file or files",
+			spec: Spec{
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New("wrong spec content"),
+		},
+		{
+			name: "Failure - Both file and files",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Files:  []string{"testdata/main.tf"},
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New("wrong spec content"),
+		},
+		{
+			name: "Failure - No module",
+			spec: Spec{
+				File: "testdata/main.tf",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New("wrong spec content"),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+
+			if tt.wantErr {
+				assert.Equal(t, tt.expectedErrorMsg.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
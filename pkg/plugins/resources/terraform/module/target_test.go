@@ -0,0 +1,76 @@
+package module
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/result"
+)
+
+func TestTarget(t *testing.T) {
+	testData := []struct {
+		name             string
+		spec             Spec
+		sourceInput      string
+		expectedResult   bool
+		expectedErrorMsg error
+		wantErr          bool
+	}{
+		{
+			name: "Success - No change",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			sourceInput:    "5.1.0",
+			expectedResult: false,
+		},
+		{
+			name: "Success - Expected change",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			sourceInput:    "5.2.0",
+			expectedResult: true,
+		},
+		{
+			name: "Success - Expected change using Value",
+			spec: Spec{
+				File:   "testdata/main.tf",
+				Module: "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git",
+				Value:  "19.17.0",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "Failure - HTTP Target",
+			spec: Spec{
+				File:   "http://localhost/doNotExist.tf",
+				Module: "terraform-aws-modules/vpc/aws",
+			},
+			wantErr:          true,
+			expectedErrorMsg: errors.New(`✗ URL scheme is not supported for HCL target: "http://localhost/doNotExist.tf"`),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := New(tt.spec)
+			require.NoError(t, err)
+
+			gotResult := result.Target{}
+			err = l.Target(tt.sourceInput, nil, true, &gotResult)
+
+			if tt.wantErr {
+				assert.Equal(t, tt.expectedErrorMsg.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedResult, gotResult.Changed)
+		})
+	}
+}
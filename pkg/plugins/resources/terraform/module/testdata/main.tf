@@ -0,0 +1,14 @@
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.1.0"
+
+  name = "updatecli"
+}
+
+module "eks" {
+  source = "git::https://github.com/terraform-aws-modules/terraform-aws-eks.git?ref=v19.16.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}
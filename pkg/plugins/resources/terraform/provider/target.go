@@ -8,6 +8,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
 func (t *TerraformProvider) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarget *result.Target) error {
@@ -66,12 +67,14 @@ func (t *TerraformProvider) Target(source string, scm scm.ScmHandler, dryRun boo
 			valueToWrite,
 			resourceFile.originalFilePath)
 
-		if !dryRun {
+		if err := t.Apply(fileKey, valueToWrite); err != nil {
+			return err
+		}
 
-			if err := t.Apply(fileKey, valueToWrite); err != nil {
-				return err
-			}
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(resourceFile.originalFilePath, resourceFile.originalFilePath, resourceFile.content, t.files[fileKey].content))
 
+		if !dryRun {
 			if err := t.contentRetriever.WriteToFile(
 				t.files[fileKey].content,
 				t.files[fileKey].filePath,
@@ -14,7 +14,7 @@ type Spec struct {
 	Files []string `yaml:",omitempty"`
 	// [s][c][t] Query allows to used advanced query. Override the parameter key
 	Query string `yaml:",omitempty"`
-	// [s][c][t] Key specifies the query to retrieve an information from a toml file
+	// [s][c][t] Key specifies the query to retrieve an information from a toml file, using a dotted path such as "package.version"
 	Key string `yaml:",omitempty"`
 	// [s][c][t] Value specifies the value for a specific key. Default to source output
 	Value string `yaml:",omitempty"`
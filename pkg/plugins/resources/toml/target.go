@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
 )
@@ -91,7 +93,7 @@ func (t *Toml) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 			}
 		}
 
-		if !changedFile || dryRun {
+		if !changedFile {
 			continue
 		}
 
@@ -112,6 +114,16 @@ func (t *Toml) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 			}
 		}
 
+		diff, err := t.contents[i].Diff()
+		if err != nil {
+			return err
+		}
+		logrus.Infof("```\n%s\n```\n", diff)
+
+		if dryRun {
+			continue
+		}
+
 		err = t.contents[i].Write()
 		if err != nil {
 			return err
@@ -2,6 +2,9 @@ package toml
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -145,3 +148,27 @@ func TestTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetPreservesFormatting(t *testing.T) {
+	original, err := os.ReadFile("testdata/data.toml")
+	require.NoError(t, err)
+
+	filePath := filepath.Join(t.TempDir(), "data.toml")
+	require.NoError(t, os.WriteFile(filePath, original, 0o600))
+
+	j, err := New(Spec{
+		File: filePath,
+		Key:  "servers.beta.role",
+	})
+	require.NoError(t, err)
+
+	gotResult := result.Target{}
+	require.NoError(t, j.Target("frontend", nil, false, &gotResult))
+	assert.True(t, gotResult.Changed)
+
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	expected := strings.Replace(string(original), "role = \"backend\"", "role = \"frontend\"", 1)
+	assert.Equal(t, expected, string(got))
+}
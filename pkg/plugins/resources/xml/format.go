@@ -0,0 +1,107 @@
+package xml
+
+import (
+	"bytes"
+	encodingxml "encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/beevik/etree"
+)
+
+/*
+replaceElementText returns the XML content where only the text of the element is replaced by value,
+so formatting, comments, and entities of the rest of the document are kept.
+It returns false if the element contains other nodes than text, such as child elements or comments,
+or if it can't be located in the content.
+*/
+func replaceElementText(content string, elem *etree.Element, value string) (string, bool) {
+	target := elementPath(elem)
+
+	decoder := encodingxml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+
+	// counts holds the number of child elements found at each depth, starting with the document
+	counts := []int{0}
+	var path []int
+	start := -1
+
+	for {
+		offset := int(decoder.InputOffset())
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			return "", false
+		}
+		if err != nil {
+			return "", false
+		}
+
+		switch token.(type) {
+		case encodingxml.StartElement:
+			if start >= 0 {
+				// The element contains a child element
+				return "", false
+			}
+
+			path = append(path, counts[len(counts)-1])
+			counts[len(counts)-1]++
+			counts = append(counts, 0)
+
+			if equalPath(path, target) {
+				start = int(decoder.InputOffset())
+			}
+
+		case encodingxml.EndElement:
+			if start >= 0 {
+				// Self-closing elements don't have a closing tag to keep
+				if !strings.HasPrefix(content[offset:], "</") {
+					return "", false
+				}
+
+				var b bytes.Buffer
+				if err := encodingxml.EscapeText(&b, []byte(value)); err != nil {
+					return "", false
+				}
+
+				return content[:start] + b.String() + content[offset:], true
+			}
+
+			path = path[:len(path)-1]
+			counts = counts[:len(counts)-1]
+
+		case encodingxml.Comment, encodingxml.ProcInst, encodingxml.Directive:
+			if start >= 0 {
+				return "", false
+			}
+		}
+	}
+}
+
+// elementPath returns the index of the element, and of each of its ancestors, among their sibling elements
+func elementPath(elem *etree.Element) []int {
+	var path []int
+	for e := elem; e.Parent() != nil; e = e.Parent() {
+		index := 0
+		for _, sibling := range e.Parent().ChildElements() {
+			if sibling == e {
+				break
+			}
+			index++
+		}
+		path = append([]int{index}, path...)
+	}
+	return path
+}
+
+// equalPath returns true if both element paths are identical
+func equalPath(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -29,8 +29,13 @@ type Spec struct {
 
 		example:
 			* path: "/project/parent/version"
+			* path: "/project/properties/jenkins.version"
 			* path: "//breakfast_menu/food[0]/name"
 			* path: "//book[@category='WEB']/title"
+
+		remark:
+			* when used from a target, only the text of the element is updated so the rest of the file,
+			  such as comments and formatting, is kept
 	*/
 	Path string `yaml:",omitempty"`
 	/*
@@ -5,9 +5,11 @@ import (
 	"strings"
 
 	"github.com/beevik/etree"
+	"github.com/sirupsen/logrus"
 
 	"github.com/updatecli/updatecli/pkg/core/pipeline/scm"
 	"github.com/updatecli/updatecli/pkg/core/result"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
 // Target updates a scm repository based on the modified yaml file.
@@ -70,10 +72,23 @@ func (x *XML) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarge
 		value,
 		resourceFile)
 
-	if !dryRun {
+	newContent, ok := replaceElementText(x.currentContent, elem, value)
+	if !ok {
+		logrus.Debugf("unable to preserve the formatting of %q, rewriting it entirely", resourceFile)
+
 		elem.SetText(value)
 
-		if err := doc.WriteToFile(resourceFile); err != nil {
+		newContent, err = doc.WriteToString()
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.Infof("```\n%s\n```\n",
+		text.Diff(resourceFile, resourceFile, x.currentContent, newContent))
+
+	if !dryRun {
+		if err := x.contentRetriever.WriteToFile(newContent, resourceFile); err != nil {
 			return err
 		}
 	}
@@ -2,8 +2,12 @@ package xml
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/beevik/etree"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/updatecli/updatecli/pkg/core/result"
@@ -110,3 +114,78 @@ func TestTarget(t *testing.T) {
 	}
 
 }
+
+func TestTargetPreservesFormatting(t *testing.T) {
+	original, err := os.ReadFile("testdata/pom.xml")
+	require.NoError(t, err)
+
+	testData := []struct {
+		name     string
+		path     string
+		value    string
+		expected map[string]string
+	}{
+		{
+			name:     "Update the parent version keeping its comment",
+			path:     "/project/parent/version",
+			value:    "4.51",
+			expected: map[string]string{"<version>4.40</version> <!-- plugin parent pom -->": "<version>4.51</version> <!-- plugin parent pom -->"},
+		},
+		{
+			name:     "Update a property",
+			path:     "/project/properties/jenkins.version",
+			value:    "2.387.3",
+			expected: map[string]string{"<jenkins.version>2.361.4</jenkins.version>": "<jenkins.version>2.387.3</jenkins.version>"},
+		},
+		{
+			name:     "Update a CDATA value, escaping it",
+			path:     "/project/properties/description",
+			value:    "<i>demo</i>",
+			expected: map[string]string{"<description><![CDATA[<b>demo</b>]]></description>": "<description>&lt;i&gt;demo&lt;/i&gt;</description>"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pom.xml")
+			require.NoError(t, os.WriteFile(filePath, original, 0o600))
+
+			x, err := New(Spec{
+				File:  filePath,
+				Path:  tt.path,
+				Value: tt.value,
+			})
+			require.NoError(t, err)
+
+			gotResult := result.Target{}
+			require.NoError(t, x.Target("", nil, false, &gotResult))
+			assert.True(t, gotResult.Changed)
+
+			expected := string(original)
+			for oldContent, newContent := range tt.expected {
+				require.Contains(t, expected, oldContent)
+				expected = strings.Replace(expected, oldContent, newContent, 1)
+			}
+
+			got, err := os.ReadFile(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, expected, string(got))
+		})
+	}
+}
+
+func TestReplaceElementText(t *testing.T) {
+	content := "<a><b>1</b><b>2</b><c><d/></c><e/><f><!-- g -->3</f></a>"
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(content))
+
+	got, ok := replaceElementText(content, doc.FindElement("/a/b[2]"), "4")
+	require.True(t, ok)
+	assert.Equal(t, "<a><b>1</b><b>4</b><c><d/></c><e/><f><!-- g -->3</f></a>", got)
+
+	for _, path := range []string{"/a/c", "/a/e", "/a/f"} {
+		_, ok := replaceElementText(content, doc.FindElement(path), "4")
+		assert.False(t, ok, path)
+	}
+}
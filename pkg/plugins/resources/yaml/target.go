@@ -105,9 +105,13 @@ func (y *Yaml) Target(source string, scm scm.ScmHandler, dryRun bool, resultTarg
 		}
 
 		f := y.files[filePath]
+		originalContent := f.content
 		f.content = yamlFile.String()
 		y.files[filePath] = f
 
+		logrus.Infof("```\n%s\n```\n",
+			text.Diff(originFilePath, originFilePath, originalContent, f.content))
+
 		resultTarget.Changed = true
 		resultTarget.Files = append(resultTarget.Files, y.files[filePath].filePath)
 		resultTarget.Result = result.ATTENTION
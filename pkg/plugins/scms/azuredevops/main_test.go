@@ -0,0 +1,90 @@
+package azuredevops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name              string
+		spec              map[string]interface{}
+		wantURL           string
+		wantBranch        string
+		wantGitUsername   string
+		wantWorkingBranch string
+		wantErr           bool
+	}{
+		{
+			name: "Azure DevOps Services",
+			spec: map[string]interface{}{
+				"organization": "updatecli",
+				"project":      "Updatecli Project",
+				"repository":   "website",
+				"token":        "pat",
+				"directory":    t.TempDir(),
+			},
+			wantURL:           "https://dev.azure.com/updatecli/Updatecli%20Project/_git/website",
+			wantBranch:        "main",
+			wantGitUsername:   "updatecli",
+			wantWorkingBranch: "updatecli_xxx",
+		},
+		{
+			name: "Azure DevOps Server",
+			spec: map[string]interface{}{
+				"url":          "azuredevops.example.com/tfs/",
+				"organization": "DefaultCollection",
+				"project":      "updatecli",
+				"repository":   "updatecli",
+				"username":     "olblak",
+				"token":        "pat",
+				"branch":       "dev",
+				"directory":    t.TempDir(),
+			},
+			wantURL:           "https://azuredevops.example.com/tfs/DefaultCollection/updatecli/_git/updatecli",
+			wantBranch:        "dev",
+			wantGitUsername:   "olblak",
+			wantWorkingBranch: "updatecli_xxx",
+		},
+		{
+			name: "Missing project",
+			spec: map[string]interface{}{
+				"organization": "updatecli",
+				"repository":   "updatecli",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing organization",
+			spec: map[string]interface{}{
+				"project":    "updatecli",
+				"repository": "updatecli",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := New(tt.spec, "xxx")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantURL, a.GetURL())
+
+			sourceBranch, workingBranch, targetBranch := a.GetBranches()
+			assert.Equal(t, tt.wantBranch, sourceBranch)
+			assert.Equal(t, tt.wantWorkingBranch, workingBranch)
+			assert.Equal(t, tt.wantBranch, targetBranch)
+
+			username, password := a.Spec.GitCredentials()
+			assert.Equal(t, tt.wantGitUsername, username)
+			assert.Equal(t, "pat", password)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package azuredevops
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (a *AzureDevOps) setDirectory() {
+
+	if _, err := os.Stat(a.Spec.Directory); os.IsNotExist(err) {
+
+		err := os.MkdirAll(a.Spec.Directory, 0755)
+		if err != nil {
+			logrus.Errorf("err - %s", err)
+		}
+	}
+}
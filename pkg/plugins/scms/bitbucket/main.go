@@ -0,0 +1,211 @@
+package bitbucket
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/tmp"
+	"github.com/updatecli/updatecli/pkg/plugins/resources/bitbucket/client"
+	"github.com/updatecli/updatecli/pkg/plugins/scms/git/commit"
+	"github.com/updatecli/updatecli/pkg/plugins/scms/git/sign"
+
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+// Spec defines settings used to interact with Bitbucket Cloud
+type Spec struct {
+	client.Spec `yaml:",inline,omitempty"`
+	/*
+		"commitMessage" is used to generate the final commit message.
+
+		compatible:
+			* scm
+
+		remark:
+			it's worth mentioning that the commit message settings is applied to all targets linked to the same scm.
+	*/
+	CommitMessage commit.Commit `yaml:",omitempty"`
+	/*
+		"directory" defines the local path where the git repository is cloned.
+
+		compatible:
+			* scm
+
+		remark:
+			Unless you know what you are doing, it is recommended to use the default value.
+			The reason is that Updatecli may automatically clean up the directory after a pipeline execution.
+
+		default:
+			/tmp/updatecli/bitbucket/<owner>/<repository>
+	*/
+	Directory string `yaml:",omitempty"`
+	/*
+		"email" defines the email used to commit changes.
+
+		compatible:
+			* scm
+
+		default:
+			default set to your global git configuration
+	*/
+	Email string `yaml:",omitempty"`
+	/*
+		"force" is used during the git push phase to run `git push --force`.
+
+		compatible:
+			* scm
+
+		default:
+			false
+	*/
+	Force bool `yaml:",omitempty"`
+	/*
+		"gpg" specifies the GPG key and passphrased used for commit signing
+
+		compatible:
+			* scm
+	*/
+	GPG sign.GPGSpec `yaml:",omitempty"`
+	/*
+		"owner" defines the Bitbucket Cloud workspace owning the repository.
+
+		compatible:
+			* scm
+	*/
+	Owner string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		"repository" specifies the name of a repository for a specific owner.
+
+		compatible:
+			* scm
+	*/
+	Repository string `yaml:",omitempty" jsonschema:"required"`
+	/*
+		"user" specifies the user associated with new git commit messages created by Updatecli
+
+		compatible:
+			* scm
+	*/
+	User string `yaml:",omitempty"`
+	/*
+		"branch" defines the git branch to work on.
+
+		compatible:
+			* scm
+
+		default:
+			main
+
+		remark:
+			depending on which resource references the Bitbucket scm, the behavior will be different.
+
+			If the scm is linked to a source or a condition (using scmid), the branch will be used to retrieve
+			file(s) from that branch.
+
+			If the scm is linked to target then Updatecli creates a new "working branch" based on the branch value.
+			The working branch created by Updatecli looks like "updatecli_<pipelineID>".
+			It is worth mentioning that it is not possible to bypass the working branch in the current situation.
+			For more information, please refer to the following issue:
+			https://github.com/updatecli/updatecli/issues/1139
+
+			If you need to push changes to a specific branch, you must use the plugin "git" instead of this
+	*/
+	Branch string `yaml:",omitempty"`
+}
+
+// Bitbucket contains information to interact with Bitbucket Cloud api
+type Bitbucket struct {
+	// Spec contains inputs coming from updatecli configuration
+	Spec Spec
+	// client handle the api authentication
+	client client.Client
+	// pipelineID is used to create a unique working branch
+	pipelineID string
+	// nativeGitHandler is used to interact with the local git repository
+	nativeGitHandler gitgeneric.GitHandler
+}
+
+// New returns a new valid Bitbucket Cloud object.
+func New(spec interface{}, pipelineID string) (*Bitbucket, error) {
+	var s Spec
+	var clientSpec client.Spec
+
+	// mapstructure.Decode cannot handle embedded fields
+	// hence we decode it in two steps
+	err := mapstructure.Decode(spec, &clientSpec)
+	if err != nil {
+		return &Bitbucket{}, err
+	}
+
+	err = mapstructure.Decode(spec, &s)
+	if err != nil {
+		return &Bitbucket{}, err
+	}
+
+	s.Spec = clientSpec
+
+	err = s.Validate()
+	if err != nil {
+		return &Bitbucket{}, err
+	}
+
+	if s.Directory == "" {
+		s.Directory = path.Join(tmp.Directory, "bitbucket", s.Owner, s.Repository)
+	}
+
+	if len(s.Branch) == 0 {
+		logrus.Warningf("no git branch specified, fallback to %q", "main")
+		s.Branch = "main"
+	}
+
+	c, err := client.New(clientSpec)
+	if err != nil {
+		return &Bitbucket{}, err
+	}
+
+	nativeGitHandler := gitgeneric.GoGit{}
+	b := Bitbucket{
+		Spec:             s,
+		client:           c,
+		pipelineID:       pipelineID,
+		nativeGitHandler: nativeGitHandler,
+	}
+
+	b.setDirectory()
+
+	return &b, nil
+}
+
+// Validate ensures that the provided Spec is valid
+func (s *Spec) Validate() error {
+	gotError := false
+	missingParameters := []string{}
+
+	if len(s.Owner) == 0 {
+		gotError = true
+		missingParameters = append(missingParameters, "owner")
+	}
+
+	if len(s.Repository) == 0 {
+		gotError = true
+		missingParameters = append(missingParameters, "repository")
+	}
+
+	if len(s.Token) == 0 && len(s.Password) > 0 && len(s.Username) == 0 {
+		gotError = true
+		missingParameters = append(missingParameters, "username")
+	}
+
+	if len(missingParameters) > 0 {
+		logrus.Errorf("missing parameter(s) [%s]", strings.Join(missingParameters, ","))
+	}
+
+	if gotError {
+		return fmt.Errorf("wrong bitbucket configuration")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name              string
+		spec              map[string]interface{}
+		wantURL           string
+		wantBranch        string
+		wantGitUsername   string
+		wantGitPassword   string
+		wantWorkingBranch string
+		wantErr           bool
+	}{
+		{
+			name: "App password",
+			spec: map[string]interface{}{
+				"owner":      "updatecli",
+				"repository": "updatecli",
+				"username":   "olblak",
+				"password":   "app_password",
+				"directory":  t.TempDir(),
+			},
+			wantURL:           "https://bitbucket.org/updatecli/updatecli.git",
+			wantBranch:        "main",
+			wantGitUsername:   "olblak",
+			wantGitPassword:   "app_password",
+			wantWorkingBranch: "updatecli_xxx",
+		},
+		{
+			name: "Access token",
+			spec: map[string]interface{}{
+				"owner":      "updatecli",
+				"repository": "website",
+				"branch":     "dev",
+				"token":      "access_token",
+				"directory":  t.TempDir(),
+			},
+			wantURL:           "https://bitbucket.org/updatecli/website.git",
+			wantBranch:        "dev",
+			wantGitUsername:   "x-token-auth",
+			wantGitPassword:   "access_token",
+			wantWorkingBranch: "updatecli_xxx",
+		},
+		{
+			name: "App password without username",
+			spec: map[string]interface{}{
+				"owner":      "updatecli",
+				"repository": "updatecli",
+				"password":   "app_password",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing repository",
+			spec: map[string]interface{}{
+				"owner": "updatecli",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := New(tt.spec, "xxx")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantURL, b.GetURL())
+
+			sourceBranch, workingBranch, targetBranch := b.GetBranches()
+			assert.Equal(t, tt.wantBranch, sourceBranch)
+			assert.Equal(t, tt.wantWorkingBranch, workingBranch)
+			assert.Equal(t, tt.wantBranch, targetBranch)
+
+			username, password := b.Spec.GitCredentials()
+			assert.Equal(t, tt.wantGitUsername, username)
+			assert.Equal(t, tt.wantGitPassword, password)
+		})
+	}
+}
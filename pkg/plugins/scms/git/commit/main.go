@@ -55,6 +55,18 @@ type Commit struct {
 			none
 	*/
 	Footers string `yaml:",omitempty"`
+	/*
+		"coAuthors" defines the list of co-authors credited in the commit message footer,
+		using the format "Name <email>", as recognized by git hosting services
+		-> https://docs.github.com/en/pull-requests/committing-changes-to-your-project/creating-and-editing-commits/creating-a-commit-with-multiple-authors
+
+		example:
+			* "Jane Doe <jane@example.com>"
+
+		default:
+			none
+	*/
+	CoAuthors []string `yaml:",omitempty"`
 	/*
 		"title" defines the title of the commit message as defined by the
 		conventional commit specification. More information on
@@ -133,7 +145,7 @@ func (c *Commit) ParseMessage(message string) (Commit, error) {
 
 	commit.Type = c.Type
 	commit.Scope = c.Scope
-	commit.Footers = c.Footers
+	commit.Footers = c.footers()
 	commit.HideCredit = c.HideCredit
 
 	lines := strings.Split(message, "\n")
@@ -183,6 +195,32 @@ func (c *Commit) ParseMessage(message string) (Commit, error) {
 	return commit, nil
 }
 
+// footers returns the commit message footers, including the co-authors trailers
+func (c *Commit) footers() string {
+	footers := []string{}
+
+	if len(c.Footers) > 0 {
+		footers = append(footers, c.Footers)
+	}
+
+	for _, coAuthor := range c.CoAuthors {
+		footers = append(footers, "Co-authored-by: "+coAuthor)
+	}
+
+	return strings.Join(footers, "\n")
+}
+
+// IsZero reports whether no commit message setting is defined
+func (c Commit) IsZero() bool {
+	return c.Type == "" &&
+		c.Scope == "" &&
+		c.Footers == "" &&
+		len(c.CoAuthors) == 0 &&
+		c.Title == "" &&
+		c.Body == "" &&
+		!c.HideCredit
+}
+
 /*
 Validate validates "conventional commit" default parameters.
 */
@@ -61,6 +61,31 @@ var (
 				HideCredit: true,
 			},
 		},
+		{
+			Message:        "Bump updatecli version",
+			ExpectedOutput: "chore: Bump updatecli version\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: John Doe <john@example.com>",
+			ExpectedError:  nil,
+			ExpectedBody:   "",
+			ExpectedTitle:  "Bump updatecli version",
+			Commit: Commit{
+				Type:       "chore",
+				CoAuthors:  []string{"Jane Doe <jane@example.com>", "John Doe <john@example.com>"},
+				HideCredit: true,
+			},
+		},
+		{
+			Message:        "Bump updatecli version",
+			ExpectedOutput: "feat(deps): Bump updatecli version\n\nMade with ❤️️ by updatecli\n\nBREAKING CHANGE\nCo-authored-by: Jane Doe <jane@example.com>",
+			ExpectedError:  nil,
+			ExpectedBody:   "",
+			ExpectedTitle:  "Bump updatecli version",
+			Commit: Commit{
+				Type:      "feat",
+				Scope:     "deps",
+				Footers:   "BREAKING CHANGE",
+				CoAuthors: []string{"Jane Doe <jane@example.com>"},
+			},
+		},
 		{
 			Message:        strings.Repeat("a", 75),
 			ExpectedOutput: "chore: aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa...\n\n... aaaaaaaaaaaaa\n\nBREAKING CHANGE",
@@ -144,3 +169,13 @@ func TestParseMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestIsZero(t *testing.T) {
+	if !(Commit{}).IsZero() {
+		t.Errorf("Expected empty commit settings to be zero")
+	}
+
+	if (Commit{CoAuthors: []string{"Jane Doe <jane@example.com>"}}).IsZero() {
+		t.Errorf("Expected commit settings with co-authors not to be zero")
+	}
+}
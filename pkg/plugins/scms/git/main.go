@@ -1,6 +1,7 @@
 package git
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/core/tmp"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/commit"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/sign"
@@ -27,8 +29,8 @@ type Spec struct {
 			* https://github.com/updatecli/updatecli.git
 
 		remarks:
-			when using the ssh protocol, the user must have the right to clone the repository
-			based on its local ssh configuration
+			when using the ssh protocol, Updatecli authenticates using the private key defined by "ssh",
+			otherwise using the ssh agent listening on SSH_AUTH_SOCK
 	*/
 	URL string `yaml:",omitempty" jsonschema:"required"`
 	/*
@@ -104,6 +106,22 @@ type Spec struct {
 			* scm
 	*/
 	Force bool `yaml:",omitempty"`
+	/*
+		"pushOptions" defines the push options transmitted to the git server during the git push phase,
+		like `git push --push-option` does.
+
+		compatible:
+			* scm
+
+		example:
+			* "ci.skip"
+			* "merge_request.create"
+			* "merge_request.target=main"
+
+		remark:
+			options are formatted as "key" or "key=value", and git servers not supporting push options ignore them.
+	*/
+	PushOptions []string `yaml:",omitempty"`
 	/*
 		"commitMessage" is used to generate the final commit message.
 
@@ -124,6 +142,89 @@ type Spec struct {
 			* scm
 	*/
 	GPG sign.GPGSpec `yaml:",omitempty"`
+	/*
+		"ssh" specifies the ssh private key used to authenticate with git remotes using the ssh protocol
+
+		compatible:
+			* scm
+
+		remark:
+			when no private key is specified, Updatecli authenticates using the ssh agent
+	*/
+	SSH SSHSpec `yaml:",omitempty"`
+	/*
+		"depth" defines the number of commits fetched when cloning the git repository, for a shallow clone
+
+		compatible:
+			* scm
+
+		default:
+			0, which clones the full history
+	*/
+	Depth int `yaml:",omitempty"`
+	/*
+		"singleBranch" restricts the git clone, and the following fetches, to the branch defined by "branch"
+
+		compatible:
+			* scm
+
+		remark:
+			it considerably speeds up the clone of repositories with many branches
+
+		default:
+			false
+	*/
+	SingleBranch bool `yaml:",omitempty"`
+	/*
+		"sparsecheckout" defines the directories, relative to the git repository root, written to the
+		local clone, like a git sparse checkout in cone mode. The other files are never written to disk
+		but commits keep them.
+
+		compatible:
+			* scm
+
+		example:
+			* ["charts/updatecli", "docs"]
+
+		default:
+			empty, which writes every file of the git repository
+
+		remark:
+			files at the git repository root are always written.
+			it considerably reduces the disk usage, and the time to check out branches, of large monorepos
+			when a pipeline only updates a few directories. Combined with "depth" and "singlebranch",
+			it also reduces what is fetched from the git remote.
+	*/
+	SparseCheckout []string `yaml:",omitempty"`
+	/*
+		"proxy", "cafile", and "insecureskipverify" define how the git repository is reached using the http protocol,
+		such as a self-hosted git server behind a corporate proxy or using a private certificate authority.
+
+		compatible:
+			* scm
+
+		default:
+			the proxy defined by the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables, and the system certificate authorities
+
+		remark:
+			"insecureskipverify" disables the TLS certificate verification, and must only be used with self-hosted servers
+	*/
+	httpclient.TransportSpec `yaml:",inline" mapstructure:",squash"`
+}
+
+// SSHSpec defines the ssh authentication used with git remotes using the ssh protocol
+type SSHSpec struct {
+	/*
+		"privateKey" defines the path of the ssh private key file
+
+		default:
+			none, the ssh agent is used
+	*/
+	PrivateKey string `yaml:",omitempty"`
+	/*
+		"passphrase" defines the passphrase of the encrypted ssh private key
+	*/
+	Passphrase string `yaml:",omitempty"`
 }
 
 // Git contains the git scm handler
@@ -136,9 +237,20 @@ type Git struct {
 
 // New returns a new git object
 func New(s Spec) (*Git, error) {
+	if err := gitgeneric.ValidateSparseCheckoutDirectories(s.SparseCheckout); err != nil {
+		return nil, err
+	}
+
 	var err error
 	if len(s.Directory) == 0 {
-		s.Directory, err = newDirectory(s.URL)
+		directoryKey := s.URL
+		if len(s.SparseCheckout) > 0 {
+			// A sparse clone can't be shared with clones of the same git repository using other directories
+			sum := sha256.Sum256([]byte(strings.Join(s.SparseCheckout, ",")))
+			directoryKey = fmt.Sprintf("%s_sparse_%x", s.URL, sum[:4])
+		}
+
+		s.Directory, err = newDirectory(directoryKey)
 		if err != nil {
 			return nil, err
 		}
@@ -148,7 +260,28 @@ func New(s Spec) (*Git, error) {
 		s.Branch = "main"
 	}
 
-	nativeGitHandler := gitgeneric.GoGit{}
+	if s.Depth < 0 {
+		return nil, fmt.Errorf("wrong git depth %d, it must be a positive number", s.Depth)
+	}
+
+	if err := s.TransportSpec.Validate(); err != nil {
+		return nil, err
+	}
+
+	nativeGitHandler := gitgeneric.GoGit{
+		SSHKeyPath:                s.SSH.PrivateKey,
+		SSHKeyPassphrase:          s.SSH.Passphrase,
+		CloneDepth:                s.Depth,
+		PushOptions:               s.PushOptions,
+		ProxyURL:                  s.Proxy,
+		CABundlePath:              s.CAFile,
+		InsecureSkipTLS:           s.InsecureSkipVerify,
+		SparseCheckoutDirectories: s.SparseCheckout,
+	}
+
+	if s.SingleBranch {
+		nativeGitHandler.CloneSingleBranch = s.Branch
+	}
 
 	return &Git{
 		spec:             s,
@@ -167,9 +300,15 @@ func (gs *Spec) Merge(child interface{}) error {
 	if childGHSpec.Branch != "" {
 		gs.Branch = childGHSpec.Branch
 	}
-	if childGHSpec.CommitMessage != (commit.Commit{}) {
+	if !childGHSpec.CommitMessage.IsZero() {
 		gs.CommitMessage = childGHSpec.CommitMessage
 	}
+	if len(childGHSpec.PushOptions) > 0 {
+		gs.PushOptions = childGHSpec.PushOptions
+	}
+	if childGHSpec.Depth != 0 {
+		gs.Depth = childGHSpec.Depth
+	}
 	if childGHSpec.Directory != "" {
 		gs.Directory = childGHSpec.Directory
 	}
@@ -182,6 +321,18 @@ func (gs *Spec) Merge(child interface{}) error {
 	if childGHSpec.GPG != (sign.GPGSpec{}) {
 		gs.GPG = childGHSpec.GPG
 	}
+	if childGHSpec.SingleBranch {
+		gs.SingleBranch = childGHSpec.SingleBranch
+	}
+	if len(childGHSpec.SparseCheckout) > 0 {
+		gs.SparseCheckout = childGHSpec.SparseCheckout
+	}
+	if childGHSpec.SSH != (SSHSpec{}) {
+		gs.SSH = childGHSpec.SSH
+	}
+	if childGHSpec.TransportSpec != (httpclient.TransportSpec{}) {
+		gs.TransportSpec = childGHSpec.TransportSpec
+	}
 	if childGHSpec.URL != "" {
 		gs.URL = childGHSpec.URL
 	}
@@ -207,6 +358,9 @@ func (gs *Spec) MergeFromEnv(envPrefix string) {
 	if os.Getenv(fmt.Sprintf("%s%s", prefix, "EMAIL")) != "" {
 		gs.Email = os.Getenv(fmt.Sprintf("%s%s", prefix, "EMAIL"))
 	}
+	if os.Getenv(fmt.Sprintf("%s%s", prefix, "SSH_PRIVATE_KEY")) != "" {
+		gs.SSH.PrivateKey = os.Getenv(fmt.Sprintf("%s%s", prefix, "SSH_PRIVATE_KEY"))
+	}
 	if os.Getenv(fmt.Sprintf("%s%s", prefix, "URL")) != "" {
 		gs.URL = os.Getenv(fmt.Sprintf("%s%s", prefix, "URL"))
 	}
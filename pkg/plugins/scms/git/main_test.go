@@ -9,6 +9,7 @@ import (
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/commit"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/sign"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/github"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
 )
 
 type DataSet []Data
@@ -110,6 +111,12 @@ func TestMerge(t *testing.T) {
 				CommitMessage: commit.Commit{
 					Title: "Hello There",
 				},
+				SSH: SSHSpec{
+					PrivateKey: "/home/obiwan/.ssh/id_ed25519",
+				},
+				Depth:        1,
+				SingleBranch: true,
+				PushOptions:  []string{"ci.skip"},
 			},
 			want: Spec{
 				Branch:    "dev",
@@ -125,6 +132,12 @@ func TestMerge(t *testing.T) {
 				CommitMessage: commit.Commit{
 					Title: "Hello There",
 				},
+				SSH: SSHSpec{
+					PrivateKey: "/home/obiwan/.ssh/id_ed25519",
+				},
+				Depth:        1,
+				SingleBranch: true,
+				PushOptions:  []string{"ci.skip"},
 			},
 		},
 		{
@@ -213,12 +226,13 @@ func TestSpec_MergeFromEnv(t *testing.T) {
 			name:      "Passing case with empty struct",
 			envPrefix: "UPDATECLI_SCM_LOCAL",
 			mockEnv: map[string]string{
-				"UPDATECLI_SCM_LOCAL_BRANCH":    "main",
-				"UPDATECLI_SCM_LOCAL_DIRECTORY": "/tmp",
-				"UPDATECLI_SCM_LOCAL_EMAIL":     "foo@bar.com",
-				"UPDATECLI_SCM_LOCAL_URL":       "git@github.com:foo/bar.git",
-				"UPDATECLI_SCM_LOCAL_USERNAME":  "userName",
-				"UPDATECLI_SCM_LOCAL_USER":      "user",
+				"UPDATECLI_SCM_LOCAL_BRANCH":          "main",
+				"UPDATECLI_SCM_LOCAL_DIRECTORY":       "/tmp",
+				"UPDATECLI_SCM_LOCAL_EMAIL":           "foo@bar.com",
+				"UPDATECLI_SCM_LOCAL_URL":             "git@github.com:foo/bar.git",
+				"UPDATECLI_SCM_LOCAL_USERNAME":        "userName",
+				"UPDATECLI_SCM_LOCAL_USER":            "user",
+				"UPDATECLI_SCM_LOCAL_SSH_PRIVATE_KEY": "/home/user/.ssh/id_ed25519",
 			},
 			want: Spec{
 				Branch:    "main",
@@ -227,6 +241,9 @@ func TestSpec_MergeFromEnv(t *testing.T) {
 				URL:       "git@github.com:foo/bar.git",
 				Username:  "userName",
 				User:      "user",
+				SSH: SSHSpec{
+					PrivateKey: "/home/user/.ssh/id_ed25519",
+				},
 			},
 		},
 	}
@@ -241,3 +258,103 @@ func TestSpec_MergeFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSSHAuthentication(t *testing.T) {
+	g, err := New(Spec{
+		URL:       "git@github.com:updatecli/updatecli.git",
+		Directory: t.TempDir(),
+		SSH: SSHSpec{
+			PrivateKey: "/home/user/.ssh/id_ed25519",
+			Passphrase: "secret",
+		},
+	})
+	require.NoError(t, err)
+
+	nativeGitHandler, ok := g.nativeGitHandler.(gitgeneric.GoGit)
+	require.True(t, ok)
+	assert.Equal(t, "/home/user/.ssh/id_ed25519", nativeGitHandler.SSHKeyPath)
+	assert.Equal(t, "secret", nativeGitHandler.SSHKeyPassphrase)
+}
+
+func TestNewCloneOptions(t *testing.T) {
+	tests := []struct {
+		name                  string
+		spec                  Spec
+		wantCloneDepth        int
+		wantCloneSingleBranch string
+		wantErr               bool
+	}{
+		{
+			name: "Full clone by default",
+			spec: Spec{Branch: "dev"},
+		},
+		{
+			name:                  "Shallow single branch clone",
+			spec:                  Spec{Branch: "dev", Depth: 1, SingleBranch: true},
+			wantCloneDepth:        1,
+			wantCloneSingleBranch: "dev",
+		},
+		{
+			name:                  "Single branch clone of the default branch",
+			spec:                  Spec{SingleBranch: true},
+			wantCloneSingleBranch: "main",
+		},
+		{
+			name:    "Negative depth",
+			spec:    Spec{Depth: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.URL = "https://github.com/updatecli/updatecli.git"
+			tt.spec.Directory = t.TempDir()
+
+			g, err := New(tt.spec)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			nativeGitHandler, ok := g.nativeGitHandler.(gitgeneric.GoGit)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCloneDepth, nativeGitHandler.CloneDepth)
+			assert.Equal(t, tt.wantCloneSingleBranch, nativeGitHandler.CloneSingleBranch)
+		})
+	}
+}
+
+func TestNewPushOptions(t *testing.T) {
+	g, err := New(Spec{
+		URL:         "https://gitlab.com/updatecli/updatecli.git",
+		Directory:   t.TempDir(),
+		PushOptions: []string{"merge_request.create", "merge_request.target=main"},
+	})
+	require.NoError(t, err)
+
+	nativeGitHandler, ok := g.nativeGitHandler.(gitgeneric.GoGit)
+	require.True(t, ok)
+	assert.Equal(t, []string{"merge_request.create", "merge_request.target=main"}, nativeGitHandler.PushOptions)
+}
+
+func TestNewSparseCheckout(t *testing.T) {
+	g, err := New(Spec{
+		URL:            "https://github.com/updatecli/updatecli.git",
+		Directory:      t.TempDir(),
+		SparseCheckout: []string{"charts/updatecli"},
+	})
+	require.NoError(t, err)
+
+	nativeGitHandler, ok := g.nativeGitHandler.(gitgeneric.GoGit)
+	require.True(t, ok)
+	assert.Equal(t, []string{"charts/updatecli"}, nativeGitHandler.SparseCheckoutDirectories)
+
+	_, err = New(Spec{
+		URL:            "https://github.com/updatecli/updatecli.git",
+		Directory:      t.TempDir(),
+		SparseCheckout: []string{"../charts"},
+	})
+	require.Error(t, err)
+}
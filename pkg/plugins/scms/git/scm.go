@@ -103,7 +103,7 @@ func (g *Git) Commit(message string) error {
 		return err
 	}
 
-	err = g.nativeGitHandler.Commit(
+	_, err = g.nativeGitHandler.Commit(
 		g.spec.User,
 		g.spec.Email,
 		commitMessage,
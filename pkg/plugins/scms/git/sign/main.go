@@ -9,7 +9,13 @@ import (
 // GPGSpec defines the specification for manipulating gpg keys in the context of git commits.
 type GPGSpec struct {
 	/*
-		signingKey defines the gpg key used to sign the commit message
+		signingKey defines the armored gpg private key, or the path to an armored gpg keyring,
+		used to sign the commit message
+
+		It also accepts an ssh private key, or the path to one, to sign commits using ssh
+
+		GitHub shows the commit as "Verified" when the public key is uploaded to the GitHub account
+		owning the commit email
 
 		default:
 			none
@@ -0,0 +1,203 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+)
+
+const (
+	// appGitUsername is the git username authenticating with a GitHub App installation token
+	appGitUsername = "x-access-token"
+	// appJWTLifetime is the lifetime of the JWT authenticating as the GitHub App, GitHub accepting at most 10 minutes
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockDrift is subtracted from the JWT issue time to allow for clock drift with GitHub
+	appJWTClockDrift = time.Minute
+)
+
+// AppSpec defines the GitHub App authenticating with GitHub, instead of a personal access token
+type AppSpec struct {
+	// "appid" specifies the GitHub App ID
+	AppID string `yaml:",omitempty"`
+	// "installationid" specifies the ID of the GitHub App installation on the repository owner
+	InstallationID string `yaml:",omitempty"`
+	// "privatekey" specifies the PEM encoded private key of the GitHub App
+	PrivateKey string `yaml:",omitempty"`
+	// "privatekeypath" specifies the file containing the PEM encoded private key of the GitHub App
+	PrivateKeyPath string `yaml:",omitempty"`
+}
+
+// IsZero returns true if no GitHub App is defined
+func (a AppSpec) IsZero() bool {
+	return a == AppSpec{}
+}
+
+// Validate returns every missing or invalid GitHub App parameter
+func (a AppSpec) Validate() (errs []error) {
+	required := []string{}
+
+	if a.AppID == "" {
+		required = append(required, "app.appid")
+	}
+
+	if a.InstallationID == "" {
+		required = append(required, "app.installationid")
+	}
+
+	if a.PrivateKey == "" && a.PrivateKeyPath == "" {
+		required = append(required, "app.privatekey or app.privatekeypath")
+	}
+
+	if len(required) > 0 {
+		errs = append(errs, fmt.Errorf("github app parameter(s) required: [%v]", strings.Join(required, ",")))
+	}
+
+	if a.PrivateKey != "" && a.PrivateKeyPath != "" {
+		errs = append(errs, errors.New("github app parameters privatekey and privatekeypath are mutually exclusive"))
+	}
+
+	return errs
+}
+
+// privateKey returns the PEM encoded private key of the GitHub App
+func (a AppSpec) privateKey() ([]byte, error) {
+	if a.PrivateKeyPath == "" {
+		return []byte(a.PrivateKey), nil
+	}
+
+	privateKey, err := os.ReadFile(a.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading github app private key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// appTokenSource generates GitHub App installation tokens, each valid for one hour
+type appTokenSource struct {
+	app        AppSpec
+	apiURL     string
+	webClient  httpclient.HTTPClient
+	privateKey []byte
+}
+
+// newAppTokenSource returns a token source generating installation tokens of the GitHub App app,
+// from the GitHub API apiURL. Tokens are reused until they expire, so long runs keep authenticating.
+func newAppTokenSource(app AppSpec, apiURL string, webClient httpclient.HTTPClient) (oauth2.TokenSource, error) {
+	privateKey, err := app.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey); err != nil {
+		return nil, fmt.Errorf("parsing github app private key: %w", err)
+	}
+
+	return oauth2.ReuseTokenSource(nil, &appTokenSource{
+		app:        app,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		webClient:  webClient,
+		privateKey: privateKey,
+	}), nil
+}
+
+// Token requests a new installation token to the GitHub API
+func (a *appTokenSource) Token() (*oauth2.Token, error) {
+	signedJWT, err := a.jwt()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL := fmt.Sprintf("%s/app/installations/%s/access_tokens", a.apiURL, url.PathEscape(a.app.InstallationID))
+
+	req, err := http.NewRequest(http.MethodPost, tokenURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+signedJWT)
+
+	logrus.Debugf("generating GitHub App %q installation token", a.app.AppID)
+
+	res, err := a.webClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("generating github app installation token: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("generating github app installation token: unexpected http status %q", res.Status)
+	}
+
+	installationToken := struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{}
+
+	if err := json.NewDecoder(res.Body).Decode(&installationToken); err != nil {
+		return nil, fmt.Errorf("decoding github app installation token: %w", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: installationToken.Token,
+		TokenType:   "token",
+		Expiry:      installationToken.ExpiresAt,
+	}, nil
+}
+
+// jwt returns a JWT authenticating as the GitHub App
+func (a *appTokenSource) jwt() (string, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(a.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("parsing github app private key: %w", err)
+	}
+
+	now := time.Now()
+
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    a.app.AppID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-appJWTClockDrift)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appJWTLifetime)),
+	}).SignedString(key)
+}
+
+// apiURL returns the GitHub REST API URL of the GitHub instance URL
+func apiURL(instanceURL string) (string, error) {
+	u, err := url.Parse(instanceURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Hostname() == "github.com" {
+		return "https://api.github.com", nil
+	}
+
+	// For GH enterprise the REST API path is /api/v3
+	return url.JoinPath(instanceURL, "/api/v3")
+}
+
+// credentials returns the username and password authenticating git operations,
+// the GitHub App installation token if a GitHub App is defined, otherwise the token
+func (g *Github) credentials() (username, password string, err error) {
+	if g.tokenSource == nil {
+		return g.Spec.Username, g.Spec.Token, nil
+	}
+
+	token, err := g.tokenSource.Token()
+	if err != nil {
+		return "", "", err
+	}
+
+	return appGitUsername, token.AccessToken, nil
+}
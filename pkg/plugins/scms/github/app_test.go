@@ -0,0 +1,136 @@
+package github
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAppServer(t *testing.T, key *rsa.PrivateKey, lifetime time.Duration) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+
+		claims := jwt.RegisteredClaims{}
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), &claims,
+			func(token *jwt.Token) (interface{}, error) {
+				return &key.PublicKey, nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "1234", claims.Issuer)
+
+		w.WriteHeader(http.StatusCreated)
+		err = json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_installation",
+			"expires_at": time.Now().Add(lifetime).UTC().Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+	}))
+
+	return server, &requests
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	app := AppSpec{
+		AppID:          "1234",
+		InstallationID: "42",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+	}
+
+	t.Run("reuses the installation token until it expires", func(t *testing.T) {
+		server, requests := newAppServer(t, key, time.Hour)
+		defer server.Close()
+
+		src, err := newAppTokenSource(app, server.URL, http.DefaultClient)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			token, err := src.Token()
+			require.NoError(t, err)
+			assert.Equal(t, "ghs_installation", token.AccessToken)
+		}
+		assert.Equal(t, 1, *requests)
+	})
+
+	t.Run("refreshes the expired installation token", func(t *testing.T) {
+		server, requests := newAppServer(t, key, time.Second)
+		defer server.Close()
+
+		src, err := newAppTokenSource(app, server.URL, http.DefaultClient)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err := src.Token()
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, *requests)
+	})
+
+	t.Run("git credentials use the installation token", func(t *testing.T) {
+		server, _ := newAppServer(t, key, time.Hour)
+		defer server.Close()
+
+		src, err := newAppTokenSource(app, server.URL, http.DefaultClient)
+		require.NoError(t, err)
+
+		g := Github{tokenSource: src}
+		username, password, err := g.credentials()
+		require.NoError(t, err)
+		assert.Equal(t, "x-access-token", username)
+		assert.Equal(t, "ghs_installation", password)
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		invalidApp := app
+		invalidApp.PrivateKey = "not a private key"
+
+		_, err := newAppTokenSource(invalidApp, "https://api.github.com", http.DefaultClient)
+		require.Error(t, err)
+	})
+}
+
+func TestAppSpecValidate(t *testing.T) {
+	assert.Empty(t, AppSpec{AppID: "1234", InstallationID: "42", PrivateKeyPath: "key.pem"}.Validate())
+	assert.Len(t, AppSpec{AppID: "1234"}.Validate(), 1)
+	assert.Len(t, AppSpec{AppID: "1234", InstallationID: "42", PrivateKey: "key", PrivateKeyPath: "key.pem"}.Validate(), 1)
+
+	spec := Spec{
+		Owner:      "updatecli",
+		Repository: "updatecli",
+		App:        AppSpec{AppID: "1234", InstallationID: "42", PrivateKeyPath: "key.pem"},
+	}
+	assert.Empty(t, spec.Validate())
+
+	spec.Token = "ghp_token"
+	assert.Len(t, spec.Validate(), 1)
+}
+
+func TestAPIURL(t *testing.T) {
+	got, err := apiURL("https://github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.github.com", got)
+
+	got, err = apiURL("https://github.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3", got)
+}
@@ -16,6 +16,7 @@ type GitHubClient interface {
 // GithubHandler must be implemented by any GitHub module
 type GithubHandler interface {
 	SearchReleases(releaseType ReleaseType) (releases []string, err error)
+	SearchReleaseAssets(tag string) (assets []ReleaseAsset, err error)
 	SearchTags() (tags []string, err error)
 	Changelog(version.Version) (string, error)
 }
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"fmt"
+)
+
+// groupWorkingBranchPrefix is the prefix of the working branch shared by the pipelines of a group
+const groupWorkingBranchPrefix = workingBranchPrefix + "group_"
+
+// setGroup shares the working branch of the group between every pipeline using it,
+// so their changes are committed to one branch and proposed by one Pull Request.
+func (g *Github) setGroup(group string) error {
+	if group == "" {
+		return nil
+	}
+
+	if g.group != "" && g.group != group {
+		return fmt.Errorf("github scm already used by the pull request group %q, can't be used by the group %q", g.group, group)
+	}
+
+	g.group = group
+
+	return nil
+}
+
+// workingBranchID returns the identifier of the working branch, the group if one is defined
+// otherwise the pipeline ID
+func (g *Github) workingBranchID() string {
+	if g.group != "" {
+		return groupWorkingBranchPrefix + g.group
+	}
+
+	return workingBranchPrefix + g.pipelineID
+}
+
+// groupTitle returns the default title of the Pull Request grouping the changes of multiple pipelines
+func groupTitle(group string) string {
+	return fmt.Sprintf("Update %s", group)
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/updatecli/updatecli/pkg/core/reports"
+	"github.com/updatecli/updatecli/pkg/plugins/utils/gitgeneric"
+)
+
+func TestGroupWorkingBranch(t *testing.T) {
+	newGithub := func(pipelineID string) *Github {
+		return &Github{
+			Spec:             Spec{Branch: "main"},
+			pipelineID:       pipelineID,
+			nativeGitHandler: &gitgeneric.GoGit{},
+		}
+	}
+
+	t.Run("pipelines without group use their own working branch", func(t *testing.T) {
+		_, err := NewAction(ActionSpec{}, newGithub("pipeline1"))
+		require.NoError(t, err)
+
+		_, workingBranch, _ := newGithub("pipeline1").GetBranches()
+		assert.Equal(t, "updatecli_pipeline1", workingBranch)
+	})
+
+	t.Run("pipelines of a group share the working branch", func(t *testing.T) {
+		first := newGithub("pipeline1")
+		second := newGithub("pipeline2")
+
+		_, err := NewAction(ActionSpec{Group: "k8s-images"}, first)
+		require.NoError(t, err)
+		_, err = NewAction(ActionSpec{Group: "k8s-images"}, second)
+		require.NoError(t, err)
+
+		_, firstBranch, _ := first.GetBranches()
+		_, secondBranch, _ := second.GetBranches()
+		assert.Equal(t, "updatecli_group_k8s-images", firstBranch)
+		assert.Equal(t, firstBranch, secondBranch)
+	})
+
+	t.Run("scm used by two groups", func(t *testing.T) {
+		gh := newGithub("pipeline1")
+
+		_, err := NewAction(ActionSpec{Group: "k8s-images"}, gh)
+		require.NoError(t, err)
+		_, err = NewAction(ActionSpec{Group: "golang"}, gh)
+		require.Error(t, err)
+	})
+}
+
+func TestGroupPullRequestReport(t *testing.T) {
+	first := reports.Action{
+		ID:            "1234",
+		PipelineTitle: "Bump nginx",
+		Targets:       []reports.ActionTarget{{ID: "4567", Title: "Update nginx to 1.25.3"}},
+	}
+	second := reports.Action{
+		ID:            "1235",
+		PipelineTitle: "Bump redis",
+		Targets:       []reports.ActionTarget{{ID: "4568", Title: "Update redis to 7.2.3"}},
+	}
+
+	tests := []struct {
+		name         string
+		bodyTemplate string
+	}{
+		{
+			name: "default body",
+		},
+		{
+			name:         "body template",
+			bodyTemplate: "# {{ .Title }}\n\n<p>{{ .Description }}</p>\n\n{{ .Report }}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ActionSpec{
+				Group:        "k8s-images",
+				Description:  "Updates the <b>k8s</b> images",
+				BodyTemplate: tt.bodyTemplate,
+			}
+
+			// The first pipeline opens the Pull Request of the group
+			p := PullRequest{spec: spec, Title: groupTitle(spec.Group), Report: first.ToActionsString(), action: first}
+			remoteBody, err := p.generateBody()
+			require.NoError(t, err)
+
+			// The second pipeline updates it, without dropping the report of the first one
+			p = PullRequest{spec: spec, Title: groupTitle(spec.Group), Report: second.ToActionsString(), action: second}
+			p.Report = mergeRemoteReport(p.Report, remoteBody)
+
+			body, err := p.generateBody()
+			require.NoError(t, err)
+			assert.Contains(t, body, "Update nginx to 1.25.3")
+			assert.Contains(t, body, "Update redis to 7.2.3")
+		})
+	}
+}
+
+func TestGroupWorkingBranchCommits(t *testing.T) {
+	// origin is the local repository standing for the GitHub one, located at <URL>/<owner>/<repository>.git
+	URL := t.TempDir()
+	origin := filepath.Join(URL, "updatecli", "website.git")
+
+	r, err := git.PlainInit(origin, false)
+	require.NoError(t, err)
+	require.NoError(t, r.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main"))))
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	sourceHash, err := w.Commit("initial commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "updatecli", Email: "updatecli@updatecli.io", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	// Each pipeline of the group clones the repository to its own directory
+	runPipeline := func(pipelineID, file string) plumbing.Hash {
+		gh := &Github{
+			Spec: Spec{
+				URL:        URL,
+				Owner:      "updatecli",
+				Repository: "website",
+				Branch:     "main",
+				Directory:  t.TempDir(),
+				User:       "updatecli",
+				Email:      "updatecli@updatecli.io",
+			},
+			pipelineID:       pipelineID,
+			nativeGitHandler: &gitgeneric.GoGit{},
+		}
+
+		_, err := NewAction(ActionSpec{Group: "k8s-images"}, gh)
+		require.NoError(t, err)
+
+		_, err = gh.Clone()
+		require.NoError(t, err)
+		require.NoError(t, gh.Checkout())
+
+		require.NoError(t, os.WriteFile(filepath.Join(gh.GetDirectory(), file), []byte("updated"), 0600))
+		require.NoError(t, gh.Add([]string{file}))
+		require.NoError(t, gh.Commit("update "+file))
+		require.NoError(t, gh.Push())
+
+		head, err := git.PlainOpen(gh.GetDirectory())
+		require.NoError(t, err)
+		ref, err := head.Head()
+		require.NoError(t, err)
+
+		return ref.Hash()
+	}
+
+	firstHash := runPipeline("pipeline1", "nginx.yaml")
+	secondHash := runPipeline("pipeline2", "redis.yaml")
+
+	// The remote working branch holds the commit of the second pipeline, on top of the one of the first pipeline
+	ref, err := r.Reference(plumbing.NewBranchReferenceName("updatecli_group_k8s-images"), true)
+	require.NoError(t, err)
+	assert.Equal(t, secondHash, ref.Hash())
+
+	second, err := r.CommitObject(secondHash)
+	require.NoError(t, err)
+	require.Equal(t, []plumbing.Hash{firstHash}, second.ParentHashes, "the second pipeline must commit on top of the first one")
+
+	first, err := r.CommitObject(firstHash)
+	require.NoError(t, err)
+	assert.Equal(t, []plumbing.Hash{sourceHash}, first.ParentHashes)
+
+	tree, err := second.Tree()
+	require.NoError(t, err)
+	for _, file := range []string{"nginx.yaml", "redis.yaml"} {
+		_, err = tree.File(file)
+		assert.NoError(t, err, file)
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/shurcooL/githubv4"
 
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/core/tmp"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/commit"
 	"github.com/updatecli/updatecli/pkg/plugins/scms/git/sign"
@@ -39,7 +41,8 @@ type Spec struct {
 			file(s) from that branch.
 
 			If the scm is linked to target then Updatecli creates a new "working branch" based on the branch value.
-			The working branch created by Updatecli looks like "updatecli_<pipelineID>".
+			The working branch created by Updatecli looks like "updatecli_<pipelineID>",
+			or "updatecli_group_<group>" when the pull request action defines a group.
 			It is worth mentioning that it is not possible to by pass the working branch in the current situation.
 			For more information, please refer to the following issue:
 			https://github.com/updatecli/updatecli/issues/1139
@@ -91,8 +94,24 @@ type Spec struct {
 
 		compatible:
 			* scm
+
+		remark:
+			the token is required unless "app" is defined
 	*/
-	Token string `yaml:",omitempty" jsonschema:"required"`
+	Token string `yaml:",omitempty"`
+	/*
+		"app" specifies the GitHub App used to authenticate with GitHub API, instead of "token".
+
+		compatible:
+			* scm
+
+		remark:
+			installation tokens are generated and refreshed on the fly, and git operations authenticate
+			with them as the "x-access-token" user.
+			The installation must grant access to the repository, with the "contents" and "pull requests" write permissions
+			to push changes and open pull requests.
+	*/
+	App AppSpec `yaml:",omitempty"`
 	/*
 		url specifies the default github url in case of GitHub enterprise
 
@@ -147,16 +166,44 @@ type Spec struct {
 			it's worth mentioning that the commit message settings is applied to all targets linked to the same scm.
 	*/
 	CommitMessage commit.Commit `yaml:",omitempty"`
+	/*
+		"proxy", "cafile", and "insecureskipverify" define how the GitHub API and git repository are reached, such as a self-hosted GitHub Enterprise
+		behind a corporate proxy or using a private certificate authority. They apply to git operations too.
+
+		compatible:
+			* scm
+
+		default:
+			the proxy defined by the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables, and the system certificate authorities
+
+		remark:
+			"insecureskipverify" disables the TLS certificate verification, and must only be used with self-hosted servers
+	*/
+	httpclient.TransportSpec `yaml:",inline" mapstructure:",squash"`
+	/*
+		"retry" defines how failed GitHub API requests, such as network errors, rate limits, or 5xx responses, are retried.
+
+		compatible:
+			* scm
+
+		default:
+			the global retry policy set by the "--http-retries" flag
+	*/
+	Retry httpclient.RetryPolicy `yaml:",omitempty"`
 }
 
 // GitHub contains settings to interact with GitHub
 type Github struct {
 	// Spec contains inputs coming from updatecli configuration
-	Spec             Spec
-	pipelineID       string
+	Spec       Spec
+	pipelineID string
+	// group is the pull request group sharing the working branch of multiple pipelines
+	group            string
 	client           GitHubClient
 	nativeGitHandler gitgeneric.GitHandler
-	mu               sync.RWMutex
+	// tokenSource generates the GitHub App installation tokens, if a GitHub App is defined
+	tokenSource oauth2.TokenSource
+	mu          sync.RWMutex
 }
 
 // Repository contains GitHub repository data
@@ -194,16 +241,44 @@ func New(s Spec, pipelineID string) (*Github, error) {
 	}
 
 	// Initialize github client
+	ctx := context.Background()
+	webClient := http.DefaultClient
+	if s.TransportSpec != (httpclient.TransportSpec{}) || !s.Retry.IsZero() {
+		client, err := httpclient.NewClient(s.TransportSpec, s.Retry)
+		if err != nil {
+			return nil, err
+		}
+		webClient = client
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+	}
+
+	var tokenSource oauth2.TokenSource
 	src := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: s.Token},
 	)
-	httpClient := oauth2.NewClient(context.Background(), src)
-	nativeGitHandler := gitgeneric.GoGit{}
+	if !s.App.IsZero() {
+		restURL, err := apiURL(s.URL)
+		if err != nil {
+			return nil, err
+		}
+		tokenSource, err = newAppTokenSource(s.App, restURL, webClient)
+		if err != nil {
+			return nil, err
+		}
+		src = tokenSource
+	}
+	httpClient := oauth2.NewClient(ctx, src)
+	nativeGitHandler := gitgeneric.GoGit{
+		ProxyURL:        s.Proxy,
+		CABundlePath:    s.CAFile,
+		InsecureSkipTLS: s.InsecureSkipVerify,
+	}
 
 	g := Github{
 		Spec:             s,
 		pipelineID:       pipelineID,
 		nativeGitHandler: nativeGitHandler,
+		tokenSource:      tokenSource,
 	}
 
 	if strings.HasSuffix(s.URL, "github.com") {
@@ -227,7 +302,7 @@ func New(s Spec, pipelineID string) (*Github, error) {
 func (s *Spec) Validate() (errs []error) {
 	required := []string{}
 
-	if len(s.Token) == 0 {
+	if len(s.Token) == 0 && s.App.IsZero() {
 		required = append(required, "token")
 	}
 
@@ -243,6 +318,21 @@ func (s *Spec) Validate() (errs []error) {
 		errs = append(errs, fmt.Errorf("github parameter(s) required: [%v]", strings.Join(required, ",")))
 	}
 
+	if !s.App.IsZero() {
+		if len(s.Token) > 0 {
+			errs = append(errs, fmt.Errorf("github parameters token and app are mutually exclusive"))
+		}
+		errs = append(errs, s.App.Validate()...)
+	}
+
+	if err := s.Retry.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := s.TransportSpec.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errs
 }
 
@@ -257,7 +347,7 @@ func (gs *Spec) Merge(child interface{}) error {
 	if childGHSpec.Branch != "" {
 		gs.Branch = childGHSpec.Branch
 	}
-	if childGHSpec.CommitMessage != (commit.Commit{}) {
+	if !childGHSpec.CommitMessage.IsZero() {
 		gs.CommitMessage = childGHSpec.CommitMessage
 	}
 	if childGHSpec.Directory != "" {
@@ -282,12 +372,21 @@ func (gs *Spec) Merge(child interface{}) error {
 	if childGHSpec.Token != "" {
 		gs.Token = childGHSpec.Token
 	}
+	if !childGHSpec.App.IsZero() {
+		gs.App = childGHSpec.App
+	}
 	if childGHSpec.URL != "" {
 		gs.URL = childGHSpec.URL
 	}
 	if childGHSpec.User != "" {
 		gs.User = childGHSpec.User
 	}
+	if !childGHSpec.Retry.IsZero() {
+		gs.Retry = childGHSpec.Retry
+	}
+	if childGHSpec.TransportSpec != (httpclient.TransportSpec{}) {
+		gs.TransportSpec = childGHSpec.TransportSpec
+	}
 	if childGHSpec.Username != "" {
 		gs.Username = childGHSpec.Username
 	}
@@ -316,6 +415,15 @@ func (gs *Spec) MergeFromEnv(envPrefix string) {
 	if os.Getenv(fmt.Sprintf("%s%s", prefix, "TOKEN")) != "" {
 		gs.Token = os.Getenv(fmt.Sprintf("%s%s", prefix, "TOKEN"))
 	}
+	if os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_ID")) != "" {
+		gs.App.AppID = os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_ID"))
+	}
+	if os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_INSTALLATION_ID")) != "" {
+		gs.App.InstallationID = os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_INSTALLATION_ID"))
+	}
+	if os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_PRIVATE_KEY")) != "" {
+		gs.App.PrivateKey = os.Getenv(fmt.Sprintf("%s%s", prefix, "APP_PRIVATE_KEY"))
+	}
 	if os.Getenv(fmt.Sprintf("%s%s", prefix, "URL")) != "" {
 		gs.URL = os.Getenv(fmt.Sprintf("%s%s", prefix, "URL"))
 	}
@@ -24,6 +24,11 @@ func (mock *MockGitHubClient) Query(ctx context.Context, q interface{}, variable
 		mt, _ := mock.mockedQuery.(*releasesQuery)
 		*qt = *mt
 		return mock.mockedErr
+	case *releaseAssetsQuery:
+		qt, _ := q.(*releaseAssetsQuery)
+		mt, _ := mock.mockedQuery.(*releaseAssetsQuery)
+		*qt = *mt
+		return mock.mockedErr
 	case *labelsQuery:
 		qt, _ := q.(*labelsQuery)
 		mt, _ := mock.mockedQuery.(*labelsQuery)
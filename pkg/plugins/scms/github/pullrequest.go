@@ -49,6 +49,11 @@ type ActionSpec struct {
 	Title string `yaml:",omitempty"`
 	// Specifies user input description used during pull body creation
 	Description string `yaml:",omitempty"`
+	// Specifies a Go template, supporting the sprig functions, generating the Pull Request body instead of the default one.
+	// It accesses the fields ".Title", ".Description", ".PipelineTitle", ".Report" (the default report), ".Files" (every modified file),
+	// and ".Targets", each one with the fields ".Title", ".Description", ".OldValue", ".NewValue", ".Files", and ".Changelogs"
+	// holding the ".Title" and ".Description" of the source changelogs, such as GitHub release notes
+	BodyTemplate string `yaml:",omitempty"`
 	// Specifies repository labels used for the Pull Request. !! Labels must already exist on the repository
 	Labels []string `yaml:",omitempty"`
 	// Specifies if a Pull Request is set to draft, default false
@@ -61,11 +66,24 @@ type ActionSpec struct {
 	UseTitleForAutoMerge bool `yaml:",omitempty"`
 	// Specifies if a Pull Request should be sent to the parent of a fork.
 	Parent bool `yaml:",omitempty"`
+	// Specifies the logins of the users assigned to the Pull Request
+	Assignees []string `yaml:",omitempty"`
+	// Specifies the logins of the users, or the teams formatted as "organization/team-slug", requested to review the Pull Request once opened
+	Reviewers []string `yaml:",omitempty"`
+	// Specifies to close the open Pull Requests superseded by this one, opened by Updatecli for the same action from another working branch,
+	// such as with a previous version of the manifest
+	CloseSuperseded bool `yaml:",omitempty"`
+	// Specifies a group name, such as "k8s-images", shared by the pipelines targeting the same repository and branch.
+	// Their changes are committed to one working branch, "updatecli_group_<group>", and proposed by one Pull Request
+	// instead of one per pipeline. The Pull Request title defaults to "Update <group>".
+	Group string `yaml:",omitempty"`
 }
 
 type PullRequest struct {
-	gh                *Github
-	Report            string
+	gh     *Github
+	Report string
+	// action holds the action report of the current pipeline run
+	action            reports.Action
 	Title             string
 	spec              ActionSpec
 	remotePullRequest PullRequestApi
@@ -90,6 +108,18 @@ func (s *ActionSpec) Validate() error {
 	if _, err := isMergeMethodValid(s.MergeMethod); err != nil {
 		return err
 	}
+
+	if s.BodyTemplate != "" {
+		if _, err := utils.ParsePullRequestBodyTemplate(s.BodyTemplate); err != nil {
+			return err
+		}
+	}
+
+	for _, reviewer := range s.Reviewers {
+		if organization, team, found := strings.Cut(reviewer, "/"); found && (organization == "" || team == "") {
+			return fmt.Errorf("wrong reviewer %q, teams must be formatted as \"organization/team-slug\"", reviewer)
+		}
+	}
 	return nil
 }
 
@@ -103,6 +133,13 @@ type mutationEnablePullRequestAutoMerge struct {
 
 func NewAction(spec ActionSpec, gh *Github) (PullRequest, error) {
 	err := spec.Validate()
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	if gh != nil {
+		err = gh.setGroup(spec.Group)
+	}
 
 	return PullRequest{
 		gh:   gh,
@@ -110,13 +147,19 @@ func NewAction(spec ActionSpec, gh *Github) (PullRequest, error) {
 	}, err
 }
 
-func (p *PullRequest) CreateAction(report reports.Action) error {
+func (p *PullRequest) CreateAction(report *reports.Action) error {
 
 	// One GitHub pullrequest body can contain multiple action report
 	// It would be better to refactor CreateAction
 	p.Report = report.ToActionsString()
+	p.action = *report
 	p.Title = report.Title
 
+	// The Pull Request of a group reports multiple pipelines, so it can't be named after one of them
+	if p.spec.Group != "" {
+		p.Title = groupTitle(p.spec.Group)
+	}
+
 	if p.spec.Title != "" {
 		p.Title = p.spec.Title
 	}
@@ -153,11 +196,13 @@ func (p *PullRequest) CreateAction(report reports.Action) error {
 	}
 
 	// If there is not already a Pull Request ID then no existing PR so let's open it
+	opened := false
 	if len(p.remotePullRequest.ID) == 0 {
 		err = p.OpenPullRequest()
 		if err != nil {
 			return err
 		}
+		opened = true
 	}
 
 	// Once the remote Pull Request exists, we can than update it with additional information such as
@@ -168,6 +213,28 @@ func (p *PullRequest) CreateAction(report reports.Action) error {
 		return err
 	}
 
+	report.Link = p.remotePullRequest.Url
+
+	err = p.addAssignees()
+	if err != nil {
+		return err
+	}
+
+	// Reviews are only requested once, to not notify reviewers again on every update
+	if opened {
+		err = p.requestReviews()
+		if err != nil {
+			return err
+		}
+	}
+
+	if p.spec.CloseSuperseded {
+		err = p.closeSupersededPullRequests(report.ID)
+		if err != nil {
+			return err
+		}
+	}
+
 	if p.spec.AutoMerge {
 		err = p.EnablePullRequestAutoMerge()
 		if err != nil {
@@ -209,7 +276,7 @@ func (p *PullRequest) updatePullRequest() error {
 
 	title := p.Title
 
-	bodyPR, err := utils.GeneratePullRequestBody(p.spec.Description, p.Report)
+	bodyPR, err := p.generateBody()
 	if err != nil {
 		return err
 	}
@@ -262,6 +329,13 @@ func (p *PullRequest) updatePullRequest() error {
 	return nil
 }
 
+// generateBody generates the Pull Request body, from the body template if one is defined
+func (p *PullRequest) generateBody() (string, error) {
+	return utils.GeneratePullRequestBodyFromTemplate(
+		p.spec.BodyTemplate,
+		utils.NewPullRequestBody(p.Title, p.spec.Description, p.Report, p.action))
+}
+
 // EnablePullRequestAutoMerge updates an existing pullrequest with the flag automerge
 func (p *PullRequest) EnablePullRequestAutoMerge() error {
 
@@ -336,7 +410,7 @@ func (p *PullRequest) OpenPullRequest() error {
 		} `graphql:"createPullRequest(input: $input)"`
 	}
 
-	bodyPR, err := utils.GeneratePullRequestBody(p.spec.Description, p.Report)
+	bodyPR, err := p.generateBody()
 	if err != nil {
 		return err
 	}
@@ -458,7 +532,7 @@ func (p *PullRequest) getRemotePullRequest() error {
 	if len(query.Repository.PullRequests.Nodes) > 0 {
 		p.remotePullRequest = query.Repository.PullRequests.Nodes[0]
 		// If a remote pullrequest already exist, then we reuse its body to generate the final one
-		p.Report = reports.MergeFromString(p.Report, p.remotePullRequest.Body)
+		p.Report = mergeRemoteReport(p.Report, p.remotePullRequest.Body)
 		logrus.Debugf("Existing pull-request found: %s", p.remotePullRequest.ID)
 	} else {
 		logrus.Debugf("No existing pull-request found in repo: %s/%s", owner, name)
@@ -467,6 +541,19 @@ func (p *PullRequest) getRemotePullRequest() error {
 	return nil
 }
 
+// mergeRemoteReport merges report with the one embedded in the body of the remote Pull Request.
+// The body also contains the description and anything added by the body template around the report,
+// so the report is extracted first, otherwise the reports of the other pipelines of a group would be lost.
+func mergeRemoteReport(report, remoteBody string) string {
+	remoteReport := reports.ExtractFromString(remoteBody)
+	if remoteReport == "" {
+		logrus.Debugf("No report found in the existing pull-request body")
+		return report
+	}
+
+	return reports.MergeFromString(report, remoteReport)
+}
+
 // getPullRequestLabelsInformation queries GitHub Api to retrieve every labels assigned to a pullRequest
 func (p *PullRequest) GetPullRequestLabelsInformation() ([]repositoryLabelApi, error) {
 
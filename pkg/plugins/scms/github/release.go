@@ -2,11 +2,16 @@ package github
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/cache"
 )
 
+// releaseCacheNamespace is the lookup cache namespace of the GitHub releases
+const releaseCacheNamespace = "githubrelease"
+
 // releasesQuery defines a github v4 API query to retrieve a list of releases sorted by reverse order of created time.
 /*
 https://developer.github.com/v4/explorer/
@@ -69,6 +74,12 @@ type repositoryRelease struct {
 // ordered by reverse order of created time.
 // Draft and pre-releases are filtered out.
 func (g *Github) SearchReleases(releaseType ReleaseType) (releases []string, err error) {
+	// Releases are cached per repository and identity, as the token must not be part of the cache key
+	cacheKey := cache.Key(g.Spec.URL, g.Spec.Owner, g.Spec.Repository, fmt.Sprintf("%+v", releaseType), g.Spec.Username, g.Spec.App.AppID, g.Spec.App.InstallationID)
+	if cache.Get(releaseCacheNamespace, cacheKey, &releases) {
+		return releases, nil
+	}
+
 	var query releasesQuery
 
 	variables := map[string]interface{}{
@@ -127,6 +138,9 @@ func (g *Github) SearchReleases(releaseType ReleaseType) (releases []string, err
 	}
 
 	logrus.Debugf("%d releases found", len(releases))
+
+	cache.Set(releaseCacheNamespace, cacheKey, releases)
+
 	return releases, nil
 
 }
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"context"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+// releaseAssetsQuery defines a github v4 API query to retrieve the assets of the release of a given tag.
+/*
+https://developer.github.com/v4/explorer/
+# Query
+query getReleaseAssets($owner: String!, $repository: String!, $tag: String!, $after: String){
+	rateLimit {
+		cost
+		remaining
+		resetAt
+	}
+	repository(owner: $owner, name: $repository){
+		release(tagName: $tag){
+			releaseAssets(first: 100, after: $after){
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					name
+					downloadUrl
+				}
+			}
+		}
+	}
+}
+# Variables
+{
+	"owner": "updatecli",
+	"repository": "updatecli",
+	"tag": "v0.60.0"
+}
+*/
+type releaseAssetsQuery struct {
+	RateLimit  RateLimit
+	Repository struct {
+		Release struct {
+			ReleaseAssets struct {
+				PageInfo PageInfo
+				Nodes    []ReleaseAsset
+			} `graphql:"releaseAssets(first: 100, after: $after)"`
+		} `graphql:"release(tagName: $tag)"`
+	} `graphql:"repository(owner: $owner, name: $repository)"`
+}
+
+// ReleaseAsset defines a file attached to a GitHub release
+type ReleaseAsset struct {
+	Name        string
+	DownloadURL string `graphql:"downloadUrl"`
+}
+
+// SearchReleaseAssets returns every asset attached to the GitHub release of the git tag tag
+func (g *Github) SearchReleaseAssets(tag string) (assets []ReleaseAsset, err error) {
+	var query releaseAssetsQuery
+
+	variables := map[string]interface{}{
+		"owner":      githubv4.String(g.Spec.Owner),
+		"repository": githubv4.String(g.Spec.Repository),
+		"tag":        githubv4.String(tag),
+		"after":      (*githubv4.String)(nil),
+	}
+
+	for {
+		err := g.client.Query(context.Background(), &query, variables)
+		if err != nil {
+			logrus.Errorf("\t%s", err)
+			return assets, err
+		}
+
+		query.RateLimit.Show()
+
+		assets = append(assets, query.Repository.Release.ReleaseAssets.Nodes...)
+
+		if !query.Repository.Release.ReleaseAssets.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["after"] = githubv4.NewString(githubv4.String(query.Repository.Release.ReleaseAssets.PageInfo.EndCursor))
+	}
+
+	logrus.Debugf("%d release assets found", len(assets))
+	return assets, nil
+}
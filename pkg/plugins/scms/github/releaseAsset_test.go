@@ -0,0 +1,72 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchReleaseAssets(t *testing.T) {
+	assetsQuery := &releaseAssetsQuery{}
+	assetsQuery.Repository.Release.ReleaseAssets.Nodes = []ReleaseAsset{
+		{
+			Name:        "updatecli_Linux_x86_64.tar.gz",
+			DownloadURL: "https://github.com/updatecli/updatecli/releases/download/v0.60.0/updatecli_Linux_x86_64.tar.gz",
+		},
+		{
+			Name:        "checksums.txt",
+			DownloadURL: "https://github.com/updatecli/updatecli/releases/download/v0.60.0/checksums.txt",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		mockedQuery *releaseAssetsQuery
+		mockedError error
+		wantAssets  []ReleaseAsset
+		wantErr     bool
+	}{
+		{
+			name:        "Release with 2 assets",
+			mockedQuery: assetsQuery,
+			wantAssets:  assetsQuery.Repository.Release.ReleaseAssets.Nodes,
+		},
+		{
+			name:        "Release without assets",
+			mockedQuery: &releaseAssetsQuery{},
+		},
+		{
+			name:        "Error: random error from GitHub API",
+			mockedQuery: &releaseAssetsQuery{},
+			mockedError: fmt.Errorf("Random error from GitHub API."),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sut := Github{
+				Spec: Spec{
+					Owner:      "updatecli",
+					Repository: "updatecli",
+				},
+				client: &MockGitHubClient{
+					mockedQuery: tt.mockedQuery,
+					mockedErr:   tt.mockedError,
+				},
+			}
+
+			got, err := sut.SearchReleaseAssets("v0.60.0")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantAssets, got)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+// userQuery defines a github v4 API query to retrieve the ID of a user from its login
+type userQuery struct {
+	User struct {
+		ID string
+	} `graphql:"user(login: $login)"`
+}
+
+// teamQuery defines a github v4 API query to retrieve the ID of an organization team from its slug
+type teamQuery struct {
+	Organization struct {
+		Team struct {
+			ID string
+		} `graphql:"team(slug: $slug)"`
+	} `graphql:"organization(login: $organization)"`
+}
+
+// getUserID returns the ID of the GitHub user login
+func (g *Github) getUserID(login string) (githubv4.ID, error) {
+	var query userQuery
+
+	variables := map[string]interface{}{
+		"login": githubv4.String(login),
+	}
+
+	err := g.client.Query(context.Background(), &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving GitHub user %q: %w", login, err)
+	}
+
+	if query.User.ID == "" {
+		return nil, fmt.Errorf("GitHub user %q not found", login)
+	}
+
+	return githubv4.ID(query.User.ID), nil
+}
+
+// getTeamID returns the ID of the GitHub team formatted as "organization/team-slug"
+func (g *Github) getTeamID(team string) (githubv4.ID, error) {
+	organization, slug, _ := strings.Cut(team, "/")
+
+	var query teamQuery
+
+	variables := map[string]interface{}{
+		"organization": githubv4.String(organization),
+		"slug":         githubv4.String(slug),
+	}
+
+	err := g.client.Query(context.Background(), &query, variables)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving GitHub team %q: %w", team, err)
+	}
+
+	if query.Organization.Team.ID == "" {
+		return nil, fmt.Errorf("GitHub team %q not found", team)
+	}
+
+	return githubv4.ID(query.Organization.Team.ID), nil
+}
+
+// addAssignees assigns the Pull Request to the users defined by the spec.
+// Users already assigned stay assigned.
+func (p *PullRequest) addAssignees() error {
+	if len(p.spec.Assignees) == 0 {
+		return nil
+	}
+
+	var mutation struct {
+		AddAssigneesToAssignable struct {
+			ClientMutationID string
+		} `graphql:"addAssigneesToAssignable(input: $input)"`
+	}
+
+	assigneeIDs := []githubv4.ID{}
+	for _, assignee := range p.spec.Assignees {
+		id, err := p.gh.getUserID(assignee)
+		if err != nil {
+			return err
+		}
+		assigneeIDs = append(assigneeIDs, id)
+	}
+
+	input := githubv4.AddAssigneesToAssignableInput{
+		AssignableID: githubv4.ID(p.remotePullRequest.ID),
+		AssigneeIDs:  assigneeIDs,
+	}
+
+	logrus.Debugf("Assigning GitHub Pull Request to %v", p.spec.Assignees)
+
+	err := p.gh.client.Mutate(context.Background(), &mutation, input, nil)
+	if err != nil {
+		return fmt.Errorf("assigning pull request: %w", err)
+	}
+
+	return nil
+}
+
+// requestReviews requests a review of the Pull Request from the users, and the teams formatted as
+// "organization/team-slug", defined by the spec. Reviews already requested are kept.
+func (p *PullRequest) requestReviews() error {
+	if len(p.spec.Reviewers) == 0 {
+		return nil
+	}
+
+	var mutation struct {
+		RequestReviews struct {
+			ClientMutationID string
+		} `graphql:"requestReviews(input: $input)"`
+	}
+
+	userIDs := []githubv4.ID{}
+	teamIDs := []githubv4.ID{}
+	for _, reviewer := range p.spec.Reviewers {
+		if strings.Contains(reviewer, "/") {
+			id, err := p.gh.getTeamID(reviewer)
+			if err != nil {
+				return err
+			}
+			teamIDs = append(teamIDs, id)
+			continue
+		}
+
+		id, err := p.gh.getUserID(reviewer)
+		if err != nil {
+			return err
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	input := githubv4.RequestReviewsInput{
+		PullRequestID: githubv4.ID(p.remotePullRequest.ID),
+		Union:         githubv4.NewBoolean(true),
+	}
+
+	if len(userIDs) > 0 {
+		input.UserIDs = &userIDs
+	}
+
+	if len(teamIDs) > 0 {
+		input.TeamIDs = &teamIDs
+	}
+
+	logrus.Debugf("Requesting GitHub Pull Request review from %v", p.spec.Reviewers)
+
+	err := p.gh.client.Mutate(context.Background(), &mutation, input, nil)
+	if err != nil {
+		return fmt.Errorf("requesting pull request reviews: %w", err)
+	}
+
+	return nil
+}
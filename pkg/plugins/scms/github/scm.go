@@ -1,7 +1,6 @@
 package github
 
 import (
-	"fmt"
 	"net/url"
 	"os"
 
@@ -10,7 +9,7 @@ import (
 
 func (g *Github) GetBranches() (sourceBranch, workingBranch, targetBranch string) {
 	sourceBranch = g.Spec.Branch
-	workingBranch = g.nativeGitHandler.SanitizeBranchName(fmt.Sprintf("updatecli_%v", g.pipelineID))
+	workingBranch = g.nativeGitHandler.SanitizeBranchName(g.workingBranchID())
 	targetBranch = g.Spec.Branch
 
 	return sourceBranch, workingBranch, targetBranch
@@ -45,9 +44,14 @@ func (g *Github) Clean() error {
 func (g *Github) Clone() (string, error) {
 	g.setDirectory()
 
-	err := g.nativeGitHandler.Clone(
-		g.Spec.Username,
-		g.Spec.Token,
+	username, password, err := g.credentials()
+	if err != nil {
+		return "", err
+	}
+
+	err = g.nativeGitHandler.Clone(
+		username,
+		password,
 		g.GetURL(),
 		g.GetDirectory())
 	if err != nil {
@@ -59,8 +63,8 @@ func (g *Github) Clone() (string, error) {
 
 	if len(workingBranch) > 0 && len(g.GetDirectory()) > 0 {
 		err = g.nativeGitHandler.Checkout(
-			g.Spec.Username,
-			g.Spec.Token,
+			username,
+			password,
 			sourceBranch,
 			workingBranch,
 			g.GetDirectory(),
@@ -84,7 +88,7 @@ func (g *Github) Commit(message string) error {
 		return err
 	}
 
-	err = g.nativeGitHandler.Commit(g.Spec.User, g.Spec.Email, commitMessage, g.GetDirectory(), g.Spec.GPG.SigningKey, g.Spec.GPG.Passphrase)
+	_, err = g.nativeGitHandler.Commit(g.Spec.User, g.Spec.Email, commitMessage, g.GetDirectory(), g.Spec.GPG.SigningKey, g.Spec.GPG.Passphrase)
 	if err != nil {
 		return err
 	}
@@ -95,9 +99,14 @@ func (g *Github) Commit(message string) error {
 func (g *Github) Checkout() error {
 	sourceBranch, workingBranch, _ := g.GetBranches()
 
-	err := g.nativeGitHandler.Checkout(
-		g.Spec.Username,
-		g.Spec.Token,
+	username, password, err := g.credentials()
+	if err != nil {
+		return err
+	}
+
+	err = g.nativeGitHandler.Checkout(
+		username,
+		password,
 		sourceBranch,
 		workingBranch,
 		g.Spec.Directory,
@@ -122,18 +131,28 @@ func (g *Github) Add(files []string) error {
 func (g *Github) IsRemoteBranchUpToDate() (bool, error) {
 	sourceBranch, workingBranch, _ := g.GetBranches()
 
+	username, password, err := g.credentials()
+	if err != nil {
+		return false, err
+	}
+
 	return g.nativeGitHandler.IsLocalBranchPublished(
 		sourceBranch,
 		workingBranch,
-		g.Spec.Username,
-		g.Spec.Token,
+		username,
+		password,
 		g.GetDirectory())
 }
 
 // Push run `git push` on the GitHub remote branch if not already created.
 func (g *Github) Push() error {
 
-	err := g.nativeGitHandler.Push(g.Spec.Username, g.Spec.Token, g.GetDirectory(), g.Spec.Force)
+	username, password, err := g.credentials()
+	if err != nil {
+		return err
+	}
+
+	err = g.nativeGitHandler.Push(username, password, g.GetDirectory(), g.Spec.Force)
 	if err != nil {
 		return err
 	}
@@ -144,7 +163,12 @@ func (g *Github) Push() error {
 // PushTag push tags
 func (g *Github) PushTag(tag string) error {
 
-	err := g.nativeGitHandler.PushTag(tag, g.Spec.Username, g.Spec.Token, g.GetDirectory(), g.Spec.Force)
+	username, password, err := g.credentials()
+	if err != nil {
+		return err
+	}
+
+	err = g.nativeGitHandler.PushTag(tag, username, password, g.GetDirectory(), g.Spec.Force)
 	if err != nil {
 		return err
 	}
@@ -155,10 +179,15 @@ func (g *Github) PushTag(tag string) error {
 // PushBranch push tags
 func (g *Github) PushBranch(branch string) error {
 
-	err := g.nativeGitHandler.PushBranch(
+	username, password, err := g.credentials()
+	if err != nil {
+		return err
+	}
+
+	err = g.nativeGitHandler.PushBranch(
 		branch,
-		g.Spec.Username,
-		g.Spec.Token,
+		username,
+		password,
 		g.GetDirectory(),
 		g.Spec.Force)
 	if err != nil {
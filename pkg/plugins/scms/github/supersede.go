@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+// workingBranchPrefix is the prefix of every working branch created by Updatecli
+const workingBranchPrefix = "updatecli_"
+
+// openPullRequestsQuery defines a github v4 API query to retrieve the open pull requests targeting a branch
+/*
+query($owner: String!, $name: String!, $baseRefName: String!, $after: String){
+	repository(owner: $owner, name: $name){
+		pullRequests(baseRefName: $baseRefName, states: [OPEN], first: 100, after: $after){
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				id
+				number
+				headRefName
+				body
+				url
+			}
+		}
+	}
+}
+*/
+type openPullRequestsQuery struct {
+	Repository struct {
+		PullRequests struct {
+			PageInfo PageInfo
+			Nodes    []PullRequestApi
+		} `graphql:"pullRequests(baseRefName: $baseRefName, states: [OPEN], first: 100, after: $after)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// isSuperseded returns true if the open pull request pr, opened by Updatecli from another working branch,
+// reports the action actionID, so it is superseded by the pull request opened from the working branch
+func isSuperseded(pr PullRequestApi, workingBranch, actionID string) bool {
+	return pr.HeadRefName != workingBranch &&
+		strings.HasPrefix(pr.HeadRefName, workingBranchPrefix) &&
+		actionID != "" &&
+		strings.Contains(pr.Body, fmt.Sprintf("id=%q", actionID))
+}
+
+// getSupersededPullRequests returns the open pull requests superseded by the current one,
+// opened by Updatecli for the same action from another working branch, like a previous version of the manifest
+func (p *PullRequest) getSupersededPullRequests(actionID string) ([]PullRequestApi, error) {
+	owner := githubv4.String(p.repository.Owner)
+	name := githubv4.String(p.repository.Name)
+
+	if p.spec.Parent {
+		owner = githubv4.String(p.repository.ParentOwner)
+		name = githubv4.String(p.repository.ParentName)
+	}
+
+	_, workingBranch, targetBranch := p.gh.GetBranches()
+
+	variables := map[string]interface{}{
+		"owner":       owner,
+		"name":        name,
+		"baseRefName": githubv4.String(targetBranch),
+		"after":       (*githubv4.String)(nil),
+	}
+
+	superseded := []PullRequestApi{}
+	for {
+		var query openPullRequestsQuery
+
+		err := p.gh.client.Query(context.Background(), &query, variables)
+		if err != nil {
+			return nil, fmt.Errorf("searching superseded pull requests: %w", err)
+		}
+
+		for _, pr := range query.Repository.PullRequests.Nodes {
+			if pr.ID != p.remotePullRequest.ID && isSuperseded(pr, workingBranch, actionID) {
+				superseded = append(superseded, pr)
+			}
+		}
+
+		if !query.Repository.PullRequests.PageInfo.HasNextPage {
+			break
+		}
+
+		variables["after"] = githubv4.NewString(githubv4.String(query.Repository.PullRequests.PageInfo.EndCursor))
+	}
+
+	return superseded, nil
+}
+
+// closeSupersededPullRequests comments then closes every open pull request superseded by the current one
+func (p *PullRequest) closeSupersededPullRequests(actionID string) error {
+	superseded, err := p.getSupersededPullRequests(actionID)
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range superseded {
+		var commentMutation struct {
+			AddComment struct {
+				ClientMutationID string
+			} `graphql:"addComment(input: $input)"`
+		}
+
+		err = p.gh.client.Mutate(context.Background(), &commentMutation, githubv4.AddCommentInput{
+			SubjectID: githubv4.ID(pr.ID),
+			Body:      githubv4.String(fmt.Sprintf("Superseded by %s", p.remotePullRequest.Url)),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("commenting superseded pull request %s: %w", pr.Url, err)
+		}
+
+		var closeMutation struct {
+			ClosePullRequest struct {
+				PullRequest PullRequestApi
+			} `graphql:"closePullRequest(input: $input)"`
+		}
+
+		err = p.gh.client.Mutate(context.Background(), &closeMutation, githubv4.ClosePullRequestInput{
+			PullRequestID: githubv4.ID(pr.ID),
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("closing superseded pull request %s: %w", pr.Url, err)
+		}
+
+		logrus.Infof("Superseded Pull Request %s closed", pr.Url)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSuperseded(t *testing.T) {
+	body := `<Actions>
+    <action id="0123abcd">
+        <h3>Bump golang version</h3>
+    </action>
+</Actions>`
+
+	dataset := []struct {
+		name     string
+		pr       PullRequestApi
+		expected bool
+	}{
+		{
+			name:     "same action from another working branch",
+			pr:       PullRequestApi{HeadRefName: "updatecli_oldpipeline", Body: body},
+			expected: true,
+		},
+		{
+			name: "current working branch",
+			pr:   PullRequestApi{HeadRefName: "updatecli_pipeline", Body: body},
+		},
+		{
+			name: "branch not created by updatecli",
+			pr:   PullRequestApi{HeadRefName: "feature", Body: body},
+		},
+		{
+			name: "another action",
+			pr:   PullRequestApi{HeadRefName: "updatecli_other", Body: `<action id="4567ef">`},
+		},
+	}
+
+	for _, d := range dataset {
+		t.Run(d.name, func(t *testing.T) {
+			assert.Equal(t, d.expected, isSuperseded(d.pr, "updatecli_pipeline", "0123abcd"))
+		})
+	}
+}
+
+func TestActionSpecValidateReviewers(t *testing.T) {
+	assert.NoError(t, (&ActionSpec{Reviewers: []string{"olblak", "updatecli/maintainers"}}).Validate())
+	assert.Error(t, (&ActionSpec{Reviewers: []string{"updatecli/"}}).Validate())
+}
@@ -65,6 +65,23 @@ type Spec struct {
 			false
 	*/
 	Force bool `yaml:",omitempty"`
+	/*
+		"pushOptions" defines the push options transmitted to GitLab during the git push phase,
+		like `git push --push-option` does.
+
+		compatible:
+			* scm
+
+		example:
+			* "ci.skip"
+			* "merge_request.create"
+			* "merge_request.label=dependencies"
+
+		remark:
+			options are formatted as "key" or "key=value".
+			More information on https://docs.gitlab.com/ee/user/project/push_options.html
+	*/
+	PushOptions []string `yaml:",omitempty"`
 	/*
 		"gpg" specifies the GPG key and passphrased used for commit signing
 
@@ -172,7 +189,9 @@ func New(spec interface{}, pipelineID string) (*Gitlab, error) {
 		return &Gitlab{}, err
 	}
 
-	nativeGitHandler := gitgeneric.GoGit{}
+	nativeGitHandler := gitgeneric.GoGit{
+		PushOptions: s.PushOptions,
+	}
 	g := Gitlab{
 		Spec:             s,
 		client:           c,
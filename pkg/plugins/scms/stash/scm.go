@@ -84,7 +84,7 @@ func (s *Stash) Commit(message string) error {
 		return err
 	}
 
-	err = s.nativeGitHandler.Commit(s.Spec.User, s.Spec.Email, commitMessage, s.GetDirectory(), s.Spec.GPG.SigningKey, s.Spec.GPG.Passphrase)
+	_, err = s.nativeGitHandler.Commit(s.Spec.User, s.Spec.Email, commitMessage, s.GetDirectory(), s.Spec.GPG.SigningKey, s.Spec.GPG.Passphrase)
 	if err != nil {
 		return err
 	}
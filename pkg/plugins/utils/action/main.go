@@ -2,11 +2,23 @@ package action
 
 import (
 	"bytes"
+	"fmt"
+	"slices"
+	"sort"
 	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/updatecli/updatecli/pkg/core/reports"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/truncate"
 )
 
+// GitHub Issues/PRs messages have a max size limit on the
+// message body payload.
+// `body is too long (maximum is 65536 characters)`.
+// To avoid that, we ensure to cap the message to 65k chars.
+const MAX_CHARACTERS_PER_MESSAGE = 65000
+
 // PULLREQUESTBODYTEMPLATE is the template used as a Pull Request description
 // Please note that triple backticks are concatenated with the literals, as they cannot be escaped
 const PULLREQUESTBODYTEMPLATE = `
@@ -68,11 +80,75 @@ func GeneratePullRequestBody(Description, Report string) (string, error) {
 		return "", err
 	}
 
-	// GitHub Issues/PRs messages have a max size limit on the
-	// message body payload.
-	// `body is too long (maximum is 65536 characters)`.
-	// To avoid that, we ensure to cap the message to 65k chars.
-	const MAX_CHARACTERS_PER_MESSAGE = 65000
+	return truncate.String(buffer.String(), MAX_CHARACTERS_PER_MESSAGE), nil
+}
+
+// PullRequestBody holds the data available to a Pull Request body template
+type PullRequestBody struct {
+	// Title is the Pull Request title
+	Title string
+	// Description is the description defined by the action spec
+	Description string
+	// PipelineTitle is the title of the pipeline updating the Pull Request
+	PipelineTitle string
+	// Report is the default report, also listing the changes of the previous pipeline runs
+	Report string
+	// Targets holds the targets changed by the pipeline run, with their old and new values,
+	// the files they modified, and the changelogs of their source
+	Targets []reports.ActionTarget
+	// Files holds every file modified by the pipeline run
+	Files []string
+}
+
+// NewPullRequestBody returns the data of a Pull Request body template describing the action report.
+// report is the default report, which may contain additional targets from previous pipeline runs.
+func NewPullRequestBody(title, description, report string, action reports.Action) PullRequestBody {
+	files := []string{}
+	for _, target := range action.Targets {
+		for _, file := range target.Files {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+
+	return PullRequestBody{
+		Title:         title,
+		Description:   description,
+		PipelineTitle: action.PipelineTitle,
+		Report:        report,
+		Targets:       action.Targets,
+		Files:         files,
+	}
+}
+
+// GeneratePullRequestBodyFromTemplate generates the Pull Request's body based on the Go template bodyTemplate,
+// supporting the sprig functions, or based on PULLREQUESTBODYTEMPLATE if bodyTemplate is empty
+func GeneratePullRequestBodyFromTemplate(bodyTemplate string, data PullRequestBody) (string, error) {
+	if bodyTemplate == "" {
+		return GeneratePullRequestBody(data.Description, data.Report)
+	}
+
+	t, err := ParsePullRequestBodyTemplate(bodyTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := t.Execute(buffer, data); err != nil {
+		return "", fmt.Errorf("executing pull request body template: %w", err)
+	}
 
 	return truncate.String(buffer.String(), MAX_CHARACTERS_PER_MESSAGE), nil
 }
+
+// ParsePullRequestBodyTemplate parses the Pull Request body template bodyTemplate
+func ParsePullRequestBodyTemplate(bodyTemplate string) (*template.Template, error) {
+	t, err := template.New("pullRequestBody").Funcs(sprig.TxtFuncMap()).Parse(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing pull request body template: %w", err)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,61 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/reports"
+)
+
+func TestGeneratePullRequestBodyFromTemplate(t *testing.T) {
+	data := NewPullRequestBody("Bump golang", "Weekly update", "<Actions></Actions>", reports.Action{
+		PipelineTitle: "Golang",
+		Targets: []reports.ActionTarget{
+			{
+				Title:    "Update go.mod",
+				OldValue: "1.20.0",
+				NewValue: "1.21.0",
+				Files:    []string{"go.mod"},
+				Changelogs: []reports.ActionTargetChangelog{
+					{Title: "1.21.0", Description: "https://github.com/golang/go/releases/tag/go1.21.0"},
+				},
+			},
+			{
+				Title:    "Update Dockerfile",
+				OldValue: "1.20.0",
+				NewValue: "1.21.0",
+				Files:    []string{"go.mod", "Dockerfile"},
+			},
+		},
+	})
+
+	assert.Equal(t, []string{"Dockerfile", "go.mod"}, data.Files)
+
+	t.Run("custom template", func(t *testing.T) {
+		got, err := GeneratePullRequestBodyFromTemplate(`{{ .Description }}
+{{ range .Targets }}* {{ .Title }}: {{ .OldValue }} -> {{ .NewValue }}
+{{ range .Changelogs }}  {{ .Description }}
+{{ end }}{{ end }}Files: {{ join ", " .Files }}`, data)
+		require.NoError(t, err)
+		assert.Equal(t, `Weekly update
+* Update go.mod: 1.20.0 -> 1.21.0
+  https://github.com/golang/go/releases/tag/go1.21.0
+* Update Dockerfile: 1.20.0 -> 1.21.0
+Files: Dockerfile, go.mod`, got)
+	})
+
+	t.Run("default template", func(t *testing.T) {
+		got, err := GeneratePullRequestBodyFromTemplate("", data)
+		require.NoError(t, err)
+
+		expected, err := GeneratePullRequestBody("Weekly update", "<Actions></Actions>")
+		require.NoError(t, err)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := GeneratePullRequestBodyFromTemplate("{{ .Title ", data)
+		require.Error(t, err)
+	})
+}
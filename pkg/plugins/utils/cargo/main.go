@@ -18,6 +18,7 @@ type Registry struct {
 	// [A][S][C] Auth specifies the cargo registry auth to use for authentication.
 	Auth InlineKeyChain `yaml:",omitempty"`
 	// [A][S][C] URL specifies the cargo registry URL to use for authentication.
+	// A registry using the sparse index protocol is specified with the "sparse+" prefix, such as "sparse+https://index.crates.io/".
 	URL string `yaml:",omitempty"`
 	// [A][S][C] RootDir specifies the cargo registry root directory to use as FS index.
 	RootDir string `yaml:",omitempty"`
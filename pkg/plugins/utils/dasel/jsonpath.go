@@ -0,0 +1,145 @@
+package dasel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// jsonPathFilterRegex matches JSONPath filter expressions such as `?(@.name=="react")`
+	jsonPathFilterRegex = regexp.MustCompile(`^\?\(\s*@\.([A-Za-z0-9_\-]+)\s*(==|!=)\s*(?:"([^"]*)"|'([^']*)'|([^\s'"]+))\s*\)$`)
+	// jsonPathPropertyRegex matches JSONPath dot notation properties such as `.dependencies`
+	jsonPathPropertyRegex = regexp.MustCompile(`^[^.\[\]]+`)
+)
+
+// IsJSONPath returns true if the query is a JSONPath expression, such as `$.dependencies["react"]`
+func IsJSONPath(query string) bool {
+	return strings.HasPrefix(query, "$")
+}
+
+/*
+JSONPathToDasel converts a JSONPath expression into the equivalent Dasel query. It supports:
+  - dot and bracket notation properties such as `$.dependencies["react"]`
+  - array indexes and wildcards such as `$.children[1]` or `$.phoneNumbers[*].type`
+  - equality filters such as `$.phoneNumbers[?(@.type=="home")].number`
+*/
+func JSONPathToDasel(query string) (string, error) {
+	if !IsJSONPath(query) {
+		return "", fmt.Errorf("JSONPath %q must start with \"$\"", query)
+	}
+
+	remaining := strings.TrimPrefix(query, "$")
+	var b strings.Builder
+
+	for remaining != "" {
+		switch {
+		case strings.HasPrefix(remaining, ".."):
+			return "", fmt.Errorf("JSONPath %q: recursive descent isn't supported", query)
+
+		case strings.HasPrefix(remaining, ".*"):
+			b.WriteString(".[*]")
+			remaining = remaining[len(".*"):]
+
+		case strings.HasPrefix(remaining, "."):
+			property := jsonPathPropertyRegex.FindString(remaining[1:])
+			if property == "" {
+				return "", fmt.Errorf("JSONPath %q: missing property name after %q", query, ".")
+			}
+			b.WriteString("." + escapeDaselProperty(property))
+			remaining = remaining[1+len(property):]
+
+		case strings.HasPrefix(remaining, "["):
+			end := closingBracket(remaining)
+			if end < 0 {
+				return "", fmt.Errorf("JSONPath %q: missing closing bracket", query)
+			}
+
+			selector, err := bracketToDasel(strings.TrimSpace(remaining[1:end]))
+			if err != nil {
+				return "", fmt.Errorf("JSONPath %q: %w", query, err)
+			}
+			b.WriteString(selector)
+			remaining = remaining[end+1:]
+
+		default:
+			return "", fmt.Errorf("JSONPath %q: unexpected %q", query, remaining)
+		}
+	}
+
+	if b.Len() == 0 {
+		return ".", nil
+	}
+
+	return b.String(), nil
+}
+
+// bracketToDasel converts the content of a JSONPath bracket, such as `"react"`, `0`, `*` or a filter, into a Dasel selector
+func bracketToDasel(content string) (string, error) {
+	switch {
+	case content == "*":
+		return ".[*]", nil
+
+	case len(content) >= 2 && (content[0] == '"' || content[0] == '\'') && content[len(content)-1] == content[0]:
+		property := content[1 : len(content)-1]
+		if strings.ContainsRune(property, rune(content[0])) {
+			return "", fmt.Errorf("unions aren't supported in %q", content)
+		}
+		return "." + escapeDaselProperty(property), nil
+
+	case strings.HasPrefix(content, "?"):
+		matches := jsonPathFilterRegex.FindStringSubmatch(content)
+		if matches == nil {
+			return "", fmt.Errorf("only equality filters such as `?(@.key==\"value\")` are supported, got %q", content)
+		}
+
+		comparison := "="
+		if matches[2] == "!=" {
+			comparison = "!="
+		}
+		value := matches[3] + matches[4] + matches[5]
+		if strings.ContainsAny(value, "()") {
+			return "", fmt.Errorf("parentheses aren't supported in filter values, got %q", content)
+		}
+
+		return fmt.Sprintf(".(%s%s%s)", matches[1], comparison, value), nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil || index < 0 {
+		return "", fmt.Errorf("unsupported array index %q", content)
+	}
+
+	return fmt.Sprintf(".[%d]", index), nil
+}
+
+// closingBracket returns the index of the bracket closing the one starting s, ignoring brackets in quotes
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+// escapeDaselProperty escapes the characters having a meaning in Dasel queries
+func escapeDaselProperty(property string) string {
+	var b strings.Builder
+	for _, r := range property {
+		if strings.ContainsRune(`.[]()\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package dasel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathToDasel(t *testing.T) {
+	testData := []struct {
+		jsonPath string
+		expected string
+		wantErr  bool
+	}{
+		{jsonPath: "$", expected: "."},
+		{jsonPath: "$.name", expected: ".name"},
+		{jsonPath: `$.dependencies["react"]`, expected: ".dependencies.react"},
+		{jsonPath: `$.dependencies['@types/react']`, expected: ".dependencies.@types/react"},
+		{jsonPath: `$["phoneNumbers.[*].type"]`, expected: `.phoneNumbers\.\[*\]\.type`},
+		{jsonPath: "$.children[1]", expected: ".children.[1]"},
+		{jsonPath: "$.phoneNumbers[*].type", expected: ".phoneNumbers.[*].type"},
+		{jsonPath: "$.phoneNumbers.*.type", expected: ".phoneNumbers.[*].type"},
+		{jsonPath: `$.phoneNumbers[?(@.type=="home")].number`, expected: ".phoneNumbers.(type=home).number"},
+		{jsonPath: `$[?(@.lts != 'false')].version`, expected: ".(lts!=false).version"},
+		{jsonPath: "$..version", wantErr: true},
+		{jsonPath: "$.children[-1]", wantErr: true},
+		{jsonPath: "$.children[0,1]", wantErr: true},
+		{jsonPath: `$.children[?(@.age>1)]`, wantErr: true},
+		{jsonPath: "$.children[1", wantErr: true},
+		{jsonPath: "name", wantErr: true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.jsonPath, func(t *testing.T) {
+			got, err := JSONPathToDasel(tt.jsonPath)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
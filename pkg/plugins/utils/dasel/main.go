@@ -28,4 +28,6 @@ type FileContent struct {
 	ContentRetriever text.TextRetriever
 	// DaselNode contains the dasel representation of the file
 	DaselNode *dasel.Node
+	// rawContent contains the file content as read from disk
+	rawContent string
 }
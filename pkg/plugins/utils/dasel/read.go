@@ -48,6 +48,7 @@ func (f *FileContent) Read(rootDir string) error {
 
 	if daselNode != nil {
 		f.DaselNode = daselNode
+		f.rawContent = textContent
 		return nil
 	}
 
@@ -0,0 +1,550 @@
+package dasel
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlScalar defines the location of a scalar value, such as a string or a number, in a TOML document
+type tomlScalar struct {
+	// path is the list of table keys and array indexes leading to the value
+	path []interface{}
+	// start and end are the byte offsets of the value, as written in the document
+	start, end int
+}
+
+/*
+preserveTOMLFormatting returns the original TOML content where only the values modified in the updated TOML content
+are rewritten, so comments, key ordering, and formatting are kept. It returns false if the updated content
+can't be obtained that way, such as when keys were added or removed.
+*/
+func preserveTOMLFormatting(original, updated string) (string, bool) {
+	var originalData, updatedData map[string]interface{}
+	if err := toml.Unmarshal([]byte(original), &originalData); err != nil {
+		return "", false
+	}
+	if err := toml.Unmarshal([]byte(updated), &updatedData); err != nil {
+		return "", false
+	}
+
+	scalars, err := scanTOML(original)
+	if err != nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, scalar := range scalars {
+		originalValue, foundOriginal := tomlLookup(originalData, scalar.path)
+		updatedValue, foundUpdated := tomlLookup(updatedData, scalar.path)
+		if !foundOriginal || !foundUpdated {
+			return "", false
+		}
+
+		if sameTOMLValue(originalValue, updatedValue) {
+			continue
+		}
+
+		value, ok := encodeTOMLScalar(updatedValue, original[scalar.start:scalar.end])
+		if !ok {
+			return "", false
+		}
+
+		b.WriteString(original[last:scalar.start])
+		b.WriteString(value)
+		last = scalar.end
+	}
+	b.WriteString(original[last:])
+
+	// Ensure no other modification, such as a new key, was lost
+	var resultData map[string]interface{}
+	if err := toml.Unmarshal([]byte(b.String()), &resultData); err != nil {
+		return "", false
+	}
+	if !sameTOMLValue(resultData, updatedData) {
+		return "", false
+	}
+
+	return b.String(), true
+}
+
+// tomlLookup returns the value found at path in data decoded from a TOML document
+func tomlLookup(data interface{}, path []interface{}) (interface{}, bool) {
+	for _, element := range path {
+		switch key := element.(type) {
+		case string:
+			m, ok := data.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if data, ok = m[key]; !ok {
+				return nil, false
+			}
+		case int:
+			v := reflect.ValueOf(data)
+			if v.Kind() != reflect.Slice || key >= v.Len() {
+				return nil, false
+			}
+			data = v.Index(key).Interface()
+		}
+	}
+
+	return data, true
+}
+
+// sameTOMLValue returns true if both values decoded from TOML documents are equal, comparing dates by their instant
+func sameTOMLValue(a, b interface{}) bool {
+	if timeA, ok := a.(time.Time); ok {
+		timeB, ok := b.(time.Time)
+		return ok && timeA.Equal(timeB)
+	}
+
+	valueA, valueB := reflect.ValueOf(a), reflect.ValueOf(b)
+	if valueA.Kind() != valueB.Kind() {
+		return false
+	}
+
+	switch valueA.Kind() {
+	case reflect.Map:
+		if valueA.Len() != valueB.Len() {
+			return false
+		}
+		for _, key := range valueA.MapKeys() {
+			elementB := valueB.MapIndex(key)
+			if !elementB.IsValid() || !sameTOMLValue(valueA.MapIndex(key).Interface(), elementB.Interface()) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice:
+		if valueA.Len() != valueB.Len() {
+			return false
+		}
+		for i := 0; i < valueA.Len(); i++ {
+			if !sameTOMLValue(valueA.Index(i).Interface(), valueB.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// encodeTOMLScalar returns the TOML representation of value, using the same string quoting as the original value when possible
+func encodeTOMLScalar(value interface{}, original string) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(original, "'") && !strings.ContainsAny(v, "'\n\r") {
+			return "'" + v + "'", true
+		}
+		return quoteTOMLString(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return "inf", true
+		case math.IsInf(v, -1):
+			return "-inf", true
+		case math.IsNaN(v):
+			return "nan", true
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+
+	return "", false
+}
+
+// quoteTOMLString returns s as a TOML basic string
+func quoteTOMLString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// errTOMLSyntax is returned when the TOML document can't be scanned
+var errTOMLSyntax = errors.New("unsupported toml syntax")
+
+// tomlScanner locates the scalar values of a TOML document, as defined on https://toml.io/en/v1.0.0
+type tomlScanner struct {
+	data string
+	pos  int
+	// scalars holds the scalar values found, in the order they appear
+	scalars []tomlScalar
+	// arrayTables holds the number of tables of each array of tables, by path
+	arrayTables map[string]int
+}
+
+// scanTOML returns the scalar values of the TOML document, in the order they appear
+func scanTOML(data string) ([]tomlScalar, error) {
+	s := tomlScanner{
+		data:        data,
+		arrayTables: map[string]int{},
+	}
+
+	var table []interface{}
+	for {
+		s.skipBlank(true)
+		if s.eof() {
+			return s.scalars, nil
+		}
+
+		var err error
+		if s.peek() == '[' {
+			table, err = s.scanTableHeader()
+		} else {
+			err = s.scanKeyValue(table)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.scanEndOfLine(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *tomlScanner) eof() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *tomlScanner) peek() byte {
+	if s.eof() {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+// skipBlank skips whitespaces and comments, and newlines if multiline is true
+func (s *tomlScanner) skipBlank(multiline bool) {
+	for !s.eof() {
+		switch s.peek() {
+		case ' ', '\t':
+			s.pos++
+		case '\r', '\n':
+			if !multiline {
+				return
+			}
+			s.pos++
+		case '#':
+			for !s.eof() && s.peek() != '\n' {
+				s.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// scanEndOfLine ensures nothing but a comment follows on the current line
+func (s *tomlScanner) scanEndOfLine() error {
+	s.skipBlank(false)
+	if s.eof() {
+		return nil
+	}
+	switch s.peek() {
+	case '\r', '\n':
+		return nil
+	}
+	return errTOMLSyntax
+}
+
+// scanTableHeader scans a table or array of tables header, and returns the path of the table
+func (s *tomlScanner) scanTableHeader() ([]interface{}, error) {
+	s.pos++
+	arrayTable := s.peek() == '['
+	if arrayTable {
+		s.pos++
+	}
+
+	keys, err := s.scanKey()
+	if err != nil {
+		return nil, err
+	}
+
+	closing := "]"
+	if arrayTable {
+		closing = "]]"
+	}
+	s.skipBlank(false)
+	if !strings.HasPrefix(s.data[s.pos:], closing) {
+		return nil, errTOMLSyntax
+	}
+	s.pos += len(closing)
+
+	// Keys designating an array of tables refer to its last table
+	var path []interface{}
+	for i, key := range keys {
+		path = append(path, key)
+		pathKey := fmt.Sprintf("%#v", path)
+
+		if i == len(keys)-1 && arrayTable {
+			count := s.arrayTables[pathKey]
+			s.arrayTables[pathKey] = count + 1
+			path = append(path, count)
+			continue
+		}
+
+		if count, ok := s.arrayTables[pathKey]; ok {
+			path = append(path, count-1)
+		}
+	}
+
+	return path, nil
+}
+
+// scanKeyValue scans a key/value pair, defined in the table
+func (s *tomlScanner) scanKeyValue(table []interface{}) error {
+	keys, err := s.scanKey()
+	if err != nil {
+		return err
+	}
+
+	s.skipBlank(false)
+	if s.peek() != '=' {
+		return errTOMLSyntax
+	}
+	s.pos++
+	s.skipBlank(false)
+
+	path := append(append([]interface{}{}, table...), keys...)
+	return s.scanValue(path)
+}
+
+// scanKey scans a bare, quoted, or dotted key, and returns its parts
+func (s *tomlScanner) scanKey() ([]interface{}, error) {
+	var keys []interface{}
+	for {
+		s.skipBlank(false)
+
+		var key string
+		switch s.peek() {
+		case '"':
+			start := s.pos
+			if err := s.skipBasicString(); err != nil {
+				return nil, err
+			}
+			var err error
+			key, err = strconv.Unquote(s.data[start:s.pos])
+			if err != nil {
+				return nil, errTOMLSyntax
+			}
+		case '\'':
+			start := s.pos
+			if err := s.skipLiteralString(); err != nil {
+				return nil, err
+			}
+			key = s.data[start+1 : s.pos-1]
+		default:
+			start := s.pos
+			for !s.eof() && isBareKeyChar(s.peek()) {
+				s.pos++
+			}
+			if start == s.pos {
+				return nil, errTOMLSyntax
+			}
+			key = s.data[start:s.pos]
+		}
+		keys = append(keys, key)
+
+		s.skipBlank(false)
+		if s.peek() != '.' {
+			return keys, nil
+		}
+		s.pos++
+	}
+}
+
+// scanValue scans the value found at path
+func (s *tomlScanner) scanValue(path []interface{}) error {
+	start := s.pos
+
+	switch s.peek() {
+	case '"':
+		if err := s.skipBasicString(); err != nil {
+			return err
+		}
+	case '\'':
+		if err := s.skipLiteralString(); err != nil {
+			return err
+		}
+	case '[':
+		return s.scanArray(path)
+	case '{':
+		return s.scanInlineTable(path)
+	default:
+		for !s.eof() && !strings.ContainsRune(" \t,]}#\r\n", rune(s.peek())) {
+			s.pos++
+		}
+		// A space can separate the date and the time of a datetime, such as "1979-05-27 07:32:00Z"
+		if s.pos-start == len("1979-05-27") && s.data[start+4] == '-' &&
+			s.peek() == ' ' && s.pos+1 < len(s.data) && s.data[s.pos+1] >= '0' && s.data[s.pos+1] <= '9' {
+			s.pos++
+			for !s.eof() && !strings.ContainsRune(" \t,]}#\r\n", rune(s.peek())) {
+				s.pos++
+			}
+		}
+		if start == s.pos {
+			return errTOMLSyntax
+		}
+	}
+
+	s.scalars = append(s.scalars, tomlScalar{path: path, start: start, end: s.pos})
+	return nil
+}
+
+// scanArray scans the array found at path
+func (s *tomlScanner) scanArray(path []interface{}) error {
+	s.pos++
+	for i := 0; ; i++ {
+		s.skipBlank(true)
+		if s.peek() == ']' {
+			s.pos++
+			return nil
+		}
+
+		if err := s.scanValue(append(append([]interface{}{}, path...), i)); err != nil {
+			return err
+		}
+
+		s.skipBlank(true)
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case ']':
+			s.pos++
+			return nil
+		default:
+			return errTOMLSyntax
+		}
+	}
+}
+
+// scanInlineTable scans the inline table found at path
+func (s *tomlScanner) scanInlineTable(path []interface{}) error {
+	s.pos++
+	for {
+		s.skipBlank(true)
+		if s.peek() == '}' {
+			s.pos++
+			return nil
+		}
+
+		if err := s.scanKeyValue(path); err != nil {
+			return err
+		}
+
+		s.skipBlank(true)
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case '}':
+			s.pos++
+			return nil
+		default:
+			return errTOMLSyntax
+		}
+	}
+}
+
+// skipBasicString skips a basic string, or a multi-line basic string
+func (s *tomlScanner) skipBasicString() error {
+	if strings.HasPrefix(s.data[s.pos:], `"""`) {
+		return s.skipMultilineString(`"""`, true)
+	}
+
+	for s.pos++; !s.eof(); s.pos++ {
+		switch s.peek() {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+			return nil
+		case '\n':
+			return errTOMLSyntax
+		}
+	}
+	return errTOMLSyntax
+}
+
+// skipLiteralString skips a literal string, or a multi-line literal string
+func (s *tomlScanner) skipLiteralString() error {
+	if strings.HasPrefix(s.data[s.pos:], `'''`) {
+		return s.skipMultilineString(`'''`, false)
+	}
+
+	i := strings.IndexAny(s.data[s.pos+1:], "'\n")
+	if i < 0 || s.data[s.pos+1+i] != '\'' {
+		return errTOMLSyntax
+	}
+	s.pos += i + 2
+	return nil
+}
+
+// skipMultilineString skips a multi-line string delimited by quotes, which can contain escape sequences if escapes is true
+func (s *tomlScanner) skipMultilineString(quotes string, escapes bool) error {
+	for s.pos += len(quotes); !s.eof(); s.pos++ {
+		if escapes && s.peek() == '\\' {
+			s.pos++
+			continue
+		}
+
+		if strings.HasPrefix(s.data[s.pos:], quotes) {
+			s.pos += len(quotes)
+			// Up to two quotes can precede the closing delimiter
+			for i := 0; i < 2 && s.peek() == quotes[0]; i++ {
+				s.pos++
+			}
+			return nil
+		}
+	}
+	return errTOMLSyntax
+}
+
+// isBareKeyChar returns true if c is allowed in a bare key
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '_' || c == '-'
+}
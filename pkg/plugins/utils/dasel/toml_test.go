@@ -0,0 +1,165 @@
+package dasel
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/updatecli/updatecli/pkg/core/text"
+)
+
+const cargoManifest = `# Cargo manifest
+[package]
+name = "updatecli"   # crate name
+version = "0.1.0"
+edition = '2021'
+authors = [
+  "Olivier", # maintainer
+  "Jane",
+]
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+tokio.version = "1.28"
+
+[[bin]]
+name = "first"
+path = "src/first.rs"
+
+[[bin]]
+name = "second"
+path = "src/second.rs"
+`
+
+func TestWriteTOMLPreservesFormatting(t *testing.T) {
+	testData := []struct {
+		name     string
+		query    string
+		value    string
+		expected map[string]string
+	}{
+		{
+			name:     "Update a table value keeping its comment and alignment",
+			query:    ".package.name",
+			value:    "updatecli-rs",
+			expected: map[string]string{`name = "updatecli"   # crate name`: `name = "updatecli-rs"   # crate name`},
+		},
+		{
+			name:     "Update a literal string",
+			query:    ".package.edition",
+			value:    "2024",
+			expected: map[string]string{`edition = '2021'`: `edition = '2024'`},
+		},
+		{
+			name:     "Update a multi-line array element",
+			query:    ".package.authors.[0]",
+			value:    "Olivier V",
+			expected: map[string]string{`"Olivier", # maintainer`: `"Olivier V", # maintainer`},
+		},
+		{
+			name:     "Update an inline table value",
+			query:    ".dependencies.serde.version",
+			value:    "1.1",
+			expected: map[string]string{`serde = { version = "1.0",`: `serde = { version = "1.1",`},
+		},
+		{
+			name:     "Update a dotted key value",
+			query:    ".dependencies.tokio.version",
+			value:    "1.29",
+			expected: map[string]string{`tokio.version = "1.28"`: `tokio.version = "1.29"`},
+		},
+		{
+			name:     "Update an array of tables value",
+			query:    ".bin.[1].path",
+			value:    "src/main.rs",
+			expected: map[string]string{`path = "src/second.rs"`: `path = "src/main.rs"`},
+		},
+		{
+			name:     "Update every array of tables value",
+			query:    ".bin.[*].path",
+			value:    "src/main.rs",
+			expected: map[string]string{`path = "src/first.rs"`: `path = "src/main.rs"`, `path = "src/second.rs"`: `path = "src/main.rs"`},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			f := FileContent{
+				DataType: "toml",
+				FilePath: "Cargo.toml",
+				ContentRetriever: &text.MockTextRetriever{
+					Contents: map[string]string{"Cargo.toml": cargoManifest},
+				},
+			}
+			require.NoError(t, f.Read(""))
+			require.NoError(t, f.PutMultiple(tt.query, tt.value))
+
+			expected := cargoManifest
+			for oldLine, newLine := range tt.expected {
+				require.Contains(t, expected, oldLine)
+				expected = strings.ReplaceAll(expected, oldLine, newLine)
+			}
+
+			got, ok := preserveTOMLFormatting(cargoManifest, daselOutput(t, &f))
+			require.True(t, ok)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestWriteTOMLAddingKey(t *testing.T) {
+	f := FileContent{
+		DataType: "toml",
+		FilePath: "Cargo.toml",
+		ContentRetriever: &text.MockTextRetriever{
+			Contents: map[string]string{"Cargo.toml": cargoManifest},
+		},
+	}
+	require.NoError(t, f.Read(""))
+	require.NoError(t, f.Put(".package.license", "Apache-2.0"))
+
+	_, ok := preserveTOMLFormatting(cargoManifest, daselOutput(t, &f))
+	assert.False(t, ok)
+
+	diff, err := f.Diff()
+	require.NoError(t, err)
+	assert.Contains(t, diff, `+  license = "Apache-2.0"`)
+}
+
+func TestScanTOML(t *testing.T) {
+	scalars, err := scanTOML(`a = """multi
+"line"""""
+b = 1979-05-27 07:32:00Z # date
+"c.d" = [ [1, 2], { e = true } ]
+`)
+	require.NoError(t, err)
+
+	var got [][]interface{}
+	for _, scalar := range scalars {
+		got = append(got, scalar.path)
+	}
+	assert.Equal(t, [][]interface{}{
+		{"a"},
+		{"b"},
+		{"c.d", 0, 0},
+		{"c.d", 0, 1},
+		{"c.d", 1, "e"},
+	}, got)
+	assert.Equal(t, "1979-05-27 07:32:00Z", `a = """multi
+"line"""""
+b = 1979-05-27 07:32:00Z # date
+`[scalars[1].start:scalars[1].end])
+
+	_, err = scanTOML("a = 1 b = 2\n")
+	assert.Error(t, err)
+}
+
+// daselOutput returns the file content as written by dasel
+func daselOutput(t *testing.T, f *FileContent) string {
+	var b bytes.Buffer
+	require.NoError(t, f.writeDaselNode(&b))
+	return b.String()
+}
@@ -1,14 +1,18 @@
 package dasel
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/user"
 
 	"github.com/sirupsen/logrus"
 	"github.com/tomwright/dasel/storage"
+	"github.com/updatecli/updatecli/pkg/core/text"
 )
 
+// Write saves the dasel node to the file
 func (f *FileContent) Write() error {
 	fileInfo, err := os.Stat(f.FilePath)
 	if err != nil {
@@ -31,48 +35,63 @@ func (f *FileContent) Write() error {
 
 	defer newFile.Close()
 
+	if err := f.write(newFile); err != nil {
+		return fmt.Errorf("unable to write to file %s: %w", f.FilePath, err)
+	}
+
+	return nil
+}
+
+// Diff returns the unified diff between the file content read from disk and the dasel node, as it would be written by Write
+func (f *FileContent) Diff() (string, error) {
+	var b bytes.Buffer
+	if err := f.write(&b); err != nil {
+		return "", err
+	}
+
+	return text.Diff(f.FilePath, f.FilePath, f.rawContent, b.String()), nil
+}
+
+// write writes the dasel node to w, formatted according to its data type.
+// TOML files keep their original formatting when only existing values are modified.
+func (f *FileContent) write(w io.Writer) error {
 	switch f.DataType {
 	case "json":
-		err = f.DaselNode.Write(
-			newFile,
-			f.DataType,
-			[]storage.ReadWriteOption{
-				{
-					Key:   storage.OptionIndent,
-					Value: "  ",
-				},
-				{
-					Key:   storage.OptionPrettyPrint,
-					Value: true,
-				},
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("unable to write to file %s: %w", f.FilePath, err)
-		}
-
+		return f.writeDaselNode(w)
 	case "toml":
-		err = f.DaselNode.Write(
-			newFile,
-			f.DataType,
-			[]storage.ReadWriteOption{
-				{
-					Key:   storage.OptionIndent,
-					Value: "  ",
-				},
-				{
-					Key:   storage.OptionPrettyPrint,
-					Value: true,
-				},
-			},
-		)
+		var b bytes.Buffer
+		if err := f.writeDaselNode(&b); err != nil {
+			return err
+		}
 
-		if err != nil {
-			return fmt.Errorf("unable to write to file %s: %w", f.FilePath, err)
+		content := b.String()
+		if preserved, ok := preserveTOMLFormatting(f.rawContent, content); ok {
+			content = preserved
+		} else {
+			logrus.Debugf("unable to preserve the formatting of %q, rewriting it entirely", f.FilePath)
 		}
+
+		_, err := io.WriteString(w, content)
+		return err
 	default:
 		return fmt.Errorf("data type %q no supported", f.DataType)
 	}
+}
 
-	return nil
+// writeDaselNode writes the dasel node to w, as generated by dasel
+func (f *FileContent) writeDaselNode(w io.Writer) error {
+	return f.DaselNode.Write(
+		w,
+		f.DataType,
+		[]storage.ReadWriteOption{
+			{
+				Key:   storage.OptionIndent,
+				Value: "  ",
+			},
+			{
+				Key:   storage.OptionPrettyPrint,
+				Value: true,
+			},
+		},
+	)
 }
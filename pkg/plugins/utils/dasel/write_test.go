@@ -0,0 +1,63 @@
+package dasel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/updatecli/updatecli/pkg/core/text"
+)
+
+func TestDiff(t *testing.T) {
+	testData := []struct {
+		name     string
+		dataType string
+		content  string
+		query    string
+		value    string
+		expected string
+	}{
+		{
+			name:     "json",
+			dataType: "json",
+			content:  "{\n  \"name\": \"updatecli\",\n  \"version\": \"1.0.0\"\n}\n",
+			query:    ".version",
+			value:    "1.1.0",
+			expected: "--- data.json\n+++ data.json\n@@ -1,4 +1,4 @@\n {\n   \"name\": \"updatecli\",\n-  \"version\": \"1.0.0\"\n+  \"version\": \"1.1.0\"\n }\n",
+		},
+		{
+			name:     "toml",
+			dataType: "toml",
+			content:  "name = \"updatecli\"\nversion = \"1.0.0\"\n",
+			query:    ".version",
+			value:    "1.1.0",
+			expected: "--- data.toml\n+++ data.toml\n@@ -1,2 +1,2 @@\n name = \"updatecli\"\n-version = \"1.0.0\"\n+version = \"1.1.0\"\n",
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := "data." + tt.dataType
+			f := FileContent{
+				DataType: tt.dataType,
+				FilePath: filename,
+				ContentRetriever: &text.MockTextRetriever{
+					Contents: map[string]string{filename: tt.content},
+				},
+			}
+
+			require.NoError(t, f.Read(""))
+
+			diff, err := f.Diff()
+			require.NoError(t, err)
+			assert.Equal(t, "", diff)
+
+			require.NoError(t, f.Put(tt.query, tt.value))
+
+			diff, err = f.Diff()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff)
+		})
+	}
+}
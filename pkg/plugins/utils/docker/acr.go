@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/httpclient"
+)
+
+const (
+	// acrUsername is the username used to authenticate with an Azure Container Registry refresh token
+	acrUsername = "00000000-0000-0000-0000-000000000000"
+	// acrResource is the Azure resource of the Azure Active Directory token exchanged for a registry refresh token
+	acrResource = "https://management.azure.com/"
+)
+
+// acrRegistryRegex matches Azure Container Registry registries such as `updatecli.azurecr.io`
+var acrRegistryRegex = regexp.MustCompile(`^[a-z0-9]+\.azurecr\.(?:io|cn|us)$`)
+
+// acrKeychain resolves Azure Container Registry credentials by exchanging an Azure Active Directory token
+// for a registry refresh token
+type acrKeychain struct {
+	// getAADToken retrieves an Azure Active Directory access token
+	getAADToken func() (string, error)
+	// client is used to query the registry token exchange endpoint
+	client httpclient.HTTPClient
+	// mu protects refreshTokens
+	mu sync.Mutex
+	// refreshTokens caches the refresh token of each registry
+	refreshTokens map[string]string
+}
+
+// newACRKeychain returns an acrKeychain retrieving Azure Active Directory tokens
+// from the Azure environment or the Azure CLI
+func newACRKeychain() *acrKeychain {
+	return &acrKeychain{
+		getAADToken:   getAADToken,
+		client:        &http.Client{},
+		refreshTokens: map[string]string{},
+	}
+}
+
+// Resolve returns the credentials of an Azure Container Registry, or anonymous credentials for any other registry
+func (k *acrKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := target.RegistryStr()
+
+	if !acrRegistryRegex.MatchString(registry) {
+		return authn.Anonymous, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	refreshToken, ok := k.refreshTokens[registry]
+	if !ok {
+		logrus.Debugf("retrieving Azure Container Registry credentials for registry %q", registry)
+
+		aadToken, err := k.getAADToken()
+		if err != nil {
+			return nil, fmt.Errorf("retrieving Azure Active Directory token for registry %q: %w", registry, err)
+		}
+
+		refreshToken, err = exchangeACRRefreshToken(k.client, "https://"+registry+"/oauth2/exchange", registry, aadToken)
+		if err != nil {
+			return nil, err
+		}
+
+		k.refreshTokens[registry] = refreshToken
+	}
+
+	return authn.FromConfig(authn.AuthConfig{
+		Username: acrUsername,
+		Password: refreshToken,
+	}), nil
+}
+
+// exchangeACRRefreshToken exchanges the Azure Active Directory token aadToken for a refresh token of registry
+// using its token exchange endpoint exchangeURL
+func exchangeACRRefreshToken(client httpclient.HTTPClient, exchangeURL, registry, aadToken string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {registry},
+		"access_token": {aadToken},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, exchangeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exchanging Azure Active Directory token for registry %q: %w", registry, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode >= 300 {
+		logrus.Debugf("HTTP Response:\n\tReturn code: %d\n\tBody: %s\n", res.StatusCode, body)
+		return "", fmt.Errorf("exchanging Azure Active Directory token for registry %q: unexpected return code %d", registry, res.StatusCode)
+	}
+
+	token := struct {
+		RefreshToken string `json:"refresh_token"`
+	}{}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("decoding refresh token for registry %q: %w", registry, err)
+	}
+
+	if token.RefreshToken == "" {
+		return "", fmt.Errorf("no refresh token returned for registry %q", registry)
+	}
+
+	return token.RefreshToken, nil
+}
+
+// getAADToken retrieves an Azure Active Directory access token from the Azure environment,
+// such as a service principal or a managed identity, falling back to the Azure CLI
+func getAADToken() (string, error) {
+	token, err := getAADTokenFromAuthorizer(auth.NewAuthorizerFromEnvironmentWithResource(acrResource))
+	if err == nil {
+		return token, nil
+	}
+
+	logrus.Debugf("retrieving Azure Active Directory token from the environment: %s, fallback to the Azure CLI", err)
+
+	return getAADTokenFromAuthorizer(auth.NewAuthorizerFromCLIWithResource(acrResource))
+}
+
+// getAADTokenFromAuthorizer returns a fresh access token from an Azure bearer authorizer
+func getAADTokenFromAuthorizer(authorizer autorest.Authorizer, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+
+	bearer, ok := authorizer.(*autorest.BearerAuthorizer)
+	if !ok {
+		return "", fmt.Errorf("unsupported Azure authorizer %T", authorizer)
+	}
+
+	tokenProvider := bearer.TokenProvider()
+	if refresher, ok := tokenProvider.(adal.Refresher); ok {
+		if err := refresher.EnsureFresh(); err != nil {
+			return "", err
+		}
+	}
+
+	return tokenProvider.OAuthToken(), nil
+}
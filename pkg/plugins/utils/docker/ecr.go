@@ -0,0 +1,117 @@
+package docker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/sirupsen/logrus"
+)
+
+// ecrRegistryRegex matches AWS ECR registries such as `123456789012.dkr.ecr.eu-west-1.amazonaws.com`
+// and captures their AWS account ID and region
+var ecrRegistryRegex = regexp.MustCompile(`^(\d{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+// ecrKeychain resolves AWS ECR credentials from an authorization token retrieved using the AWS credential chain
+type ecrKeychain struct {
+	// getAuthorizationToken retrieves an ECR authorization token for the registry of an AWS account in a region
+	getAuthorizationToken func(region, registryID string) (*ecr.AuthorizationData, error)
+	// mu protects authConfigs
+	mu sync.Mutex
+	// authConfigs caches the credentials of each registry until they expire
+	authConfigs map[string]cachedAuthConfig
+}
+
+// cachedAuthConfig defines registry credentials valid until expiresAt
+type cachedAuthConfig struct {
+	authConfig authn.AuthConfig
+	expiresAt  time.Time
+}
+
+// newECRKeychain returns an ecrKeychain using the AWS credential chain, from the environment,
+// the shared configuration, or an IAM role assumed with STS
+func newECRKeychain() *ecrKeychain {
+	return &ecrKeychain{
+		getAuthorizationToken: getECRAuthorizationToken,
+		authConfigs:           map[string]cachedAuthConfig{},
+	}
+}
+
+// Resolve returns the credentials of an AWS ECR registry, or anonymous credentials for any other registry
+func (k *ecrKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := target.RegistryStr()
+
+	matches := ecrRegistryRegex.FindStringSubmatch(registry)
+	if matches == nil {
+		return authn.Anonymous, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if cached, ok := k.authConfigs[registry]; ok && time.Now().Before(cached.expiresAt) {
+		return authn.FromConfig(cached.authConfig), nil
+	}
+
+	logrus.Debugf("retrieving AWS ECR credentials for registry %q", registry)
+
+	data, err := k.getAuthorizationToken(matches[2], matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("retrieving AWS ECR authorization token for registry %q: %w", registry, err)
+	}
+
+	token, err := base64.StdEncoding.DecodeString(aws.StringValue(data.AuthorizationToken))
+	if err != nil {
+		return nil, fmt.Errorf("decoding AWS ECR authorization token for registry %q: %w", registry, err)
+	}
+
+	username, password, found := strings.Cut(string(token), ":")
+	if !found {
+		return nil, fmt.Errorf("wrong AWS ECR authorization token format for registry %q", registry)
+	}
+
+	authConfig := authn.AuthConfig{
+		Username: username,
+		Password: password,
+	}
+
+	if data.ExpiresAt != nil {
+		k.authConfigs[registry] = cachedAuthConfig{
+			authConfig: authConfig,
+			expiresAt:  *data.ExpiresAt,
+		}
+	}
+
+	return authn.FromConfig(authConfig), nil
+}
+
+// getECRAuthorizationToken retrieves an ECR authorization token for the registry registryID in region
+func getECRAuthorizationToken(region, registryID string) (*ecr.AuthorizationData, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(region)},
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := ecr.New(sess).GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{
+		RegistryIds: []*string{aws.String(registryID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output.AuthorizationData) == 0 {
+		return nil, fmt.Errorf("no authorization data returned")
+	}
+
+	return output.AuthorizationData[0], nil
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// IsSameImage returns true if both container image names designate the same repository,
+// such as "nginx" and "docker.io/library/nginx"
+func IsSameImage(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	repositoryA, errA := name.NewRepository(a, name.WeakValidation)
+	repositoryB, errB := name.NewRepository(b, name.WeakValidation)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return repositoryA.Name() == repositoryB.Name()
+}
+
+// SplitImage splits a container image reference into its name and its version, the tag and/or digest
+// such as "1.25.3", "1.25.3@sha256:..." or "sha256:..."
+func SplitImage(image string) (imageName, version string) {
+	imageName = image
+
+	if i := strings.Index(imageName, "@"); i >= 0 {
+		version = imageName[i+1:]
+		imageName = imageName[:i]
+	}
+
+	// A colon after the last slash separates the tag, a colon before it separates the registry port
+	if i := strings.LastIndex(imageName, ":"); i > strings.LastIndex(imageName, "/") {
+		tag := imageName[i+1:]
+		imageName = imageName[:i]
+
+		if version != "" {
+			return imageName, tag + "@" + version
+		}
+		return imageName, tag
+	}
+
+	return imageName, version
+}
+
+// JoinImage returns the container image reference defined by its name and version
+func JoinImage(imageName, version string) string {
+	if strings.HasPrefix(version, "sha256:") {
+		return imageName + "@" + version
+	}
+	return imageName + ":" + version
+}
@@ -0,0 +1,40 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSameImage(t *testing.T) {
+	assert.True(t, IsSameImage("nginx", "docker.io/library/nginx"))
+	assert.True(t, IsSameImage("ghcr.io/updatecli/web", "ghcr.io/updatecli/web"))
+	assert.False(t, IsSameImage("nginx", "ghcr.io/nginx"))
+	assert.False(t, IsSameImage("nginx", "{{ .Values.image }}"))
+}
+
+func TestSplitImage(t *testing.T) {
+	testData := []struct {
+		image           string
+		expectedName    string
+		expectedVersion string
+	}{
+		{image: "nginx", expectedName: "nginx"},
+		{image: "nginx:1.25", expectedName: "nginx", expectedVersion: "1.25"},
+		{image: "nginx@sha256:abc", expectedName: "nginx", expectedVersion: "sha256:abc"},
+		{image: "nginx:1.25@sha256:abc", expectedName: "nginx", expectedVersion: "1.25@sha256:abc"},
+		{image: "localhost:5000/web", expectedName: "localhost:5000/web"},
+		{image: "localhost:5000/web:0.1.0", expectedName: "localhost:5000/web", expectedVersion: "0.1.0"},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.image, func(t *testing.T) {
+			gotName, gotVersion := SplitImage(tt.image)
+			assert.Equal(t, tt.expectedName, gotName)
+			assert.Equal(t, tt.expectedVersion, gotVersion)
+			if tt.expectedVersion != "" {
+				assert.Equal(t, tt.image, JoinImage(gotName, gotVersion))
+			}
+		})
+	}
+}
@@ -54,6 +54,27 @@ type InlineKeyChain struct {
 			Not compatible with username/password
 	*/
 	Token string `yaml:",omitempty"`
+	/*
+		provider specifies the credential provider used to authenticate with the container registry.
+
+		compatible:
+			* source
+			* condition
+			* target
+
+		accepted values:
+			* `ecr` retrieves AWS ECR credentials using the AWS credential chain, including IAM roles assumed with STS
+			* `google` retrieves Google Artifact Registry and Container Registry credentials using the application default credentials
+			* `acr` exchanges an Azure Active Directory token, retrieved from the Azure environment, for an Azure Container Registry token
+			* `dockerconfig` only reads credentials from the local environment such as `~/.docker/config.json` and its credential helpers
+
+		default:
+			by default credentials are fetch from the local environment such as `~/.docker/config.json`.
+
+		remark:
+			Not compatible with username/password and token
+	*/
+	Provider string `yaml:",omitempty"`
 }
 
 // Resolve the inline keychain and return an authenticator
@@ -67,7 +88,7 @@ func (kc InlineKeyChain) Resolve(authn.Resource) (authn.Authenticator, error) {
 
 // Empty returns true if the keychain is empty
 func (kc InlineKeyChain) Empty() bool {
-	return kc.Username == "" && kc.Password == "" && kc.Token == ""
+	return kc.Username == "" && kc.Password == "" && kc.Token == "" && kc.Provider == ""
 }
 
 // Validate validates the object and returns an error (with all the failed validation messages) if it is not valid
@@ -86,6 +107,16 @@ func (kc InlineKeyChain) Validate() error {
 		validationErrors = append(validationErrors, "Docker registry password provided but not the username")
 	}
 
+	if len(kc.Provider) > 0 {
+		if !isProviderSupported(kc.Provider) {
+			validationErrors = append(validationErrors, fmt.Sprintf("Docker registry credential provider %q not supported, accepted values are %q", kc.Provider, providers))
+		}
+
+		if len(kc.Username) > 0 || len(kc.Password) > 0 || len(kc.Token) > 0 {
+			validationErrors = append(validationErrors, "Specifying a Docker registry credential provider is invalid when a username, a password, or a token are provided.")
+		}
+	}
+
 	// Return all the validation errors if found any
 	if len(validationErrors) > 0 {
 		return fmt.Errorf("validation error: the provided manifest configuration had the following validation errors:\n%s", strings.Join(validationErrors, "\n\n"))
@@ -0,0 +1,60 @@
+package docker
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/google"
+)
+
+const (
+	// ProviderECR retrieves credentials for AWS Elastic Container Registry
+	ProviderECR = "ecr"
+	// ProviderGoogle retrieves credentials for Google Artifact Registry and Google Container Registry
+	ProviderGoogle = "google"
+	// ProviderACR retrieves credentials for Azure Container Registry
+	ProviderACR = "acr"
+	// ProviderDockerConfig retrieves credentials from the local Docker configuration
+	ProviderDockerConfig = "dockerconfig"
+)
+
+var (
+	// providers defines the accepted credential providers
+	providers = []string{ProviderECR, ProviderGoogle, ProviderACR, ProviderDockerConfig}
+)
+
+// isProviderSupported returns true if provider is an accepted credential provider
+func isProviderSupported(provider string) bool {
+	for _, p := range providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Keychain returns the keychain resolving the container registry credentials:
+the inline credentials or the credential provider if specified, falling back to
+the credentials from the local environment such as `~/.docker/config.json`.
+*/
+func (kc InlineKeyChain) Keychain() authn.Keychain {
+	keychains := []authn.Keychain{}
+
+	switch kc.Provider {
+	case ProviderECR:
+		keychains = append(keychains, newECRKeychain())
+	case ProviderGoogle:
+		keychains = append(keychains, google.Keychain)
+	case ProviderACR:
+		keychains = append(keychains, newACRKeychain())
+	case ProviderDockerConfig:
+		// Credentials from the local environment are always used as fallback
+	default:
+		if !kc.Empty() {
+			keychains = append(keychains, kc)
+		}
+	}
+
+	keychains = append(keychains, authn.DefaultKeychain)
+
+	return authn.NewMultiKeychain(keychains...)
+}
@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProvider(t *testing.T) {
+	testdata := []struct {
+		name     string
+		keychain InlineKeyChain
+		wantErr  bool
+	}{
+		{
+			name:     "ECR provider",
+			keychain: InlineKeyChain{Provider: ProviderECR},
+		},
+		{
+			name:     "Unsupported provider",
+			keychain: InlineKeyChain{Provider: "quay"},
+			wantErr:  true,
+		},
+		{
+			name:     "Provider with inline credentials",
+			keychain: InlineKeyChain{Provider: ProviderACR, Username: "john", Password: "secret"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.keychain.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestECRKeychain(t *testing.T) {
+	calls := 0
+	keychain := newECRKeychain()
+	keychain.getAuthorizationToken = func(region, registryID string) (*ecr.AuthorizationData, error) {
+		calls++
+		assert.Equal(t, "eu-west-1", region)
+		assert.Equal(t, "123456789012", registryID)
+		return &ecr.AuthorizationData{
+			AuthorizationToken: aws.String(base64.StdEncoding.EncodeToString([]byte("AWS:secret"))),
+			ExpiresAt:          aws.Time(time.Now().Add(time.Hour)),
+		}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		repo, err := name.NewRepository("123456789012.dkr.ecr.eu-west-1.amazonaws.com/updatecli")
+		require.NoError(t, err)
+
+		authenticator, err := keychain.Resolve(repo)
+		require.NoError(t, err)
+
+		authConfig, err := authenticator.Authorization()
+		require.NoError(t, err)
+		assert.Equal(t, &authn.AuthConfig{Username: "AWS", Password: "secret"}, authConfig)
+	}
+	assert.Equal(t, 1, calls)
+
+	repo, err := name.NewRepository("ghcr.io/updatecli/updatecli")
+	require.NoError(t, err)
+
+	authenticator, err := keychain.Resolve(repo)
+	require.NoError(t, err)
+	assert.Equal(t, authn.Anonymous, authenticator)
+}
+
+func TestExchangeACRRefreshToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "access_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "updatecli.azurecr.io", r.PostForm.Get("service"))
+
+		if r.PostForm.Get("access_token") != "aad-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"refresh_token": "refresh-token"}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	refreshToken, err := exchangeACRRefreshToken(&http.Client{}, server.URL, "updatecli.azurecr.io", "aad-token")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", refreshToken)
+
+	_, err = exchangeACRRefreshToken(&http.Client{}, server.URL, "updatecli.azurecr.io", "wrong-token")
+	assert.Error(t, err)
+}
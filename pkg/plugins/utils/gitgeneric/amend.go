@@ -0,0 +1,129 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+CommitAmend replaces the HEAD commit by a new one including the working directory changes,
+like `git commit --all --amend` does, then returns the hash of the amended commit.
+An empty message keeps the HEAD commit message.
+
+The HEAD commit author, including its date, is preserved unless ResetAmendAuthor is set,
+while user and email define the committer.
+*/
+func (g GoGit) CommitAmend(user, email, message, workingDir string, signingKey string, passphrase string) (string, error) {
+
+	logrus.Debugf("stage: git-commit-amend\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return "", err
+	}
+
+	if err := g.checkExpectedBranch(r); err != nil {
+		return "", err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return "", err
+	}
+
+	user, email, err = commitIdentity(r, user, email)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := r.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", &ErrEmptyRepository{Path: workingDir}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	previous, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return "", err
+	}
+
+	// go-git defaults to HEAD when no parents are specified, which would stack a new commit instead
+	if len(previous.ParentHashes) == 0 {
+		return "", fmt.Errorf("amending the root commit %q isn't supported", previous.Hash.String())
+	}
+
+	authorTime, committerTime := g.commitTimes()
+
+	committer := object.Signature{
+		Name:  user,
+		Email: email,
+		When:  committerTime,
+	}
+
+	author := previous.Author
+	if g.ResetAmendAuthor {
+		author = object.Signature{
+			Name:  user,
+			Email: email,
+			When:  authorTime,
+		}
+	}
+
+	if message == "" {
+		message = previous.Message
+	}
+	if !g.KeepCommitMessageFormatting {
+		message = normalizeCommitMessage(message)
+	}
+	message = appendCommitTrailers(message, g.commitTrailers(user, email))
+
+	commitOptions := git.CommitOptions{
+		All:       true,
+		Author:    &author,
+		Committer: &committer,
+		Parents:   previous.ParentHashes,
+	}
+
+	gpgKey, sshSigner, err := commitSigningKey(signingKey, passphrase)
+	if err != nil {
+		return "", err
+	}
+	commitOptions.SignKey = gpgKey
+
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if err := g.stageCommitAll(w, &commitOptions); err != nil {
+		return "", err
+	}
+
+	commit, err := w.Commit(message, &commitOptions)
+	if err != nil {
+		return "", err
+	}
+
+	if sshSigner != nil {
+		commit, err = signCommitSSH(r, commit, sshSigner)
+		if err != nil {
+			return "", fmt.Errorf("signing commit: %w", err)
+		}
+	}
+
+	logrus.Debugf("git commit %q amended to %q", previous.Hash.String(), commit.String())
+
+	return commit.String(), nil
+}
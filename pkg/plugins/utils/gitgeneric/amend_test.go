@@ -0,0 +1,97 @@
+package gitgeneric
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitAmend(t *testing.T) {
+	authorDate := time.Date(2023, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		g          GoGit
+		message    string
+		wantAuthor string
+		wantDate   bool
+		wantMsg    string
+	}{
+		{
+			name:       "Author preserved",
+			wantAuthor: "author",
+			wantDate:   true,
+			wantMsg:    "update README.md\n",
+		},
+		{
+			name:       "Author reset",
+			g:          GoGit{ResetAmendAuthor: true},
+			message:    "amended",
+			wantAuthor: "updatecli",
+			wantMsg:    "amended\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			parent, err := r.Head()
+			require.NoError(t, err)
+
+			w, err := r.Worktree()
+			require.NoError(t, err)
+			writeTestFile(t, workingDir, "README.md", "v2")
+			_, err = w.Add("README.md")
+			require.NoError(t, err)
+			previous, err := w.Commit("update README.md\n", &git.CommitOptions{
+				Author: &object.Signature{Name: "author", Email: "author@updatecli.io", When: authorDate},
+			})
+			require.NoError(t, err)
+
+			writeTestFile(t, workingDir, "README.md", "v3")
+			hash, err := tt.g.CommitAmend("updatecli", "updatecli@updatecli.io", tt.message, workingDir, "", "")
+			require.NoError(t, err)
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, head.Hash().String(), hash)
+			assert.NotEqual(t, previous.String(), hash)
+			commit, err := r.CommitObject(head.Hash())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMsg, commit.Message)
+			assert.Equal(t, tt.wantAuthor, commit.Author.Name)
+			assert.Equal(t, tt.wantDate, commit.Author.When.Equal(authorDate))
+			assert.Equal(t, "updatecli", commit.Committer.Name)
+			assert.Equal(t, []plumbing.Hash{parent.Hash()}, commit.ParentHashes)
+
+			file, err := commit.File("README.md")
+			require.NoError(t, err)
+			content, err := file.Contents()
+			require.NoError(t, err)
+			assert.Equal(t, "v3", content)
+		})
+	}
+
+	// The root commit can't be amended
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	_, err := GoGit{}.CommitAmend("updatecli", "updatecli@updatecli.io", "", workingDir, "", "")
+	require.Error(t, err)
+
+	// Nor a repository without any commit
+	emptyDir := t.TempDir()
+	_, err = git.PlainInit(emptyDir, false)
+	require.NoError(t, err)
+	_, err = GoGit{}.CommitAmend("updatecli", "updatecli@updatecli.io", "amended", emptyDir, "", "")
+	var emptyErr *ErrEmptyRepository
+	require.True(t, errors.As(err, &emptyErr), err)
+}
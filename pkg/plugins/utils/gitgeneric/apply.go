@@ -0,0 +1,827 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/sirupsen/logrus"
+)
+
+// hunkHeaderRegex matches a unified diff hunk header such as "@@ -1,3 +1,4 @@"
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+/*
+ApplyPatch applies the patch file located at patchPath to the working directory, similarly to `git apply`.
+Both git patches and plain unified diffs are supported, as well as git binary patches containing literal data.
+
+Each hunk is applied at its expected line, or nearby if lines were added or removed since,
+but its context must match exactly. When a hunk fails and the patch index line contains
+the full hash of the original file, available in the repository, a 3-way merge is attempted
+like `git apply --3way` does.
+
+The patch is applied atomically: no file is modified if any hunk fails to apply,
+and the returned error reports every failed hunk with its expected content.
+*/
+func (g GoGit) ApplyPatch(patchPath, workingDir string) error {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	data, err := os.ReadFile(patchPath)
+	if err != nil {
+		return err
+	}
+
+	filePatches, err := parsePatch(string(data))
+	if err != nil {
+		return fmt.Errorf("parsing patch %q: %w", patchPath, err)
+	}
+
+	if len(filePatches) == 0 {
+		return fmt.Errorf("no file change found in patch %q", patchPath)
+	}
+
+	var results []patchResult
+	var errs []error
+	for _, filePatch := range filePatches {
+		result, err := applyFilePatch(r, workingDir, filePatch)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, result...)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("applying patch %q: %w", patchPath, err)
+	}
+
+	// Removing files first allows a patch to replace a file by another one
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].remove && !results[j].remove
+	})
+
+	for _, result := range results {
+		if err := result.write(workingDir); err != nil {
+			return err
+		}
+	}
+
+	logrus.Debugf("patch %q applied to %d files", patchPath, len(filePatches))
+
+	return nil
+}
+
+// parsedFilePatch contains the changes of a single file parsed from a patch
+type parsedFilePatch struct {
+	// oldPath and newPath are empty when the file is respectively created or deleted
+	oldPath string
+	newPath string
+	// oldHash is the original blob hash retrieved from the git index line
+	oldHash   string
+	newMode   filemode.FileMode
+	isNew     bool
+	isDeleted bool
+	isBinary  bool
+	// binaryData contains the new file content of a git binary patch, nil if the patch has no data
+	binaryData []byte
+	hunks      []*patchHunk
+}
+
+// patchHunk contains a single hunk of a unified diff
+type patchHunk struct {
+	header   string
+	oldStart int
+	oldLines int
+	newStart int
+	newLines int
+	lines    []patchLine
+}
+
+// patchLine is a hunk line, where op is one of ' ', '-' or '+', and text includes its end of line
+type patchLine struct {
+	op   byte
+	text string
+}
+
+// patchResult is a file modification resulting from a patch
+type patchResult struct {
+	path    string
+	content string
+	mode    filemode.FileMode
+	remove  bool
+}
+
+// parsePatch parses the file changes contained in a git patch or a unified diff
+func parsePatch(patch string) ([]*parsedFilePatch, error) {
+	lines := strings.Split(patch, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	var filePatches []*parsedFilePatch
+	var current *parsedFilePatch
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = &parsedFilePatch{}
+			current.oldPath, current.newPath = parseGitDiffPaths(strings.TrimPrefix(line, "diff --git "))
+			filePatches = append(filePatches, current)
+
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			// A plain unified diff doesn't have a "diff --git" line to delimit files
+			plain := current == nil || len(current.hunks) > 0 || current.isBinary
+			if plain {
+				current = &parsedFilePatch{}
+				filePatches = append(filePatches, current)
+			}
+			current.oldPath = parsePatchPath(strings.TrimPrefix(line, "--- "))
+			current.newPath = parsePatchPath(strings.TrimPrefix(lines[i+1], "+++ "))
+			// Plain diffs often name the original file "<file>.orig", which isn't a rename
+			if plain && current.oldPath != "" && current.newPath != "" {
+				current.oldPath = current.newPath
+			}
+			current.isNew = current.isNew || current.oldPath == ""
+			current.isDeleted = current.isDeleted || current.newPath == ""
+			i++
+
+		case current == nil:
+			// Ignore any content preceding the first file, such as a commit message
+			continue
+
+		case strings.HasPrefix(line, "@@ "):
+			hunk, last, err := parseHunk(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			current.hunks = append(current.hunks, hunk)
+			i = last
+
+		case strings.HasPrefix(line, "new file mode "):
+			current.isNew = true
+			current.oldPath = ""
+			mode, err := filemode.New(strings.TrimPrefix(line, "new file mode "))
+			if err != nil {
+				return nil, err
+			}
+			current.newMode = mode
+
+		case strings.HasPrefix(line, "deleted file mode "):
+			current.isDeleted = true
+			current.newPath = ""
+
+		case strings.HasPrefix(line, "new mode "):
+			mode, err := filemode.New(strings.TrimPrefix(line, "new mode "))
+			if err != nil {
+				return nil, err
+			}
+			current.newMode = mode
+
+		case strings.HasPrefix(line, "rename from "):
+			current.oldPath = strings.TrimPrefix(line, "rename from ")
+
+		case strings.HasPrefix(line, "rename to "):
+			current.newPath = strings.TrimPrefix(line, "rename to ")
+
+		case strings.HasPrefix(line, "index "):
+			hashes, _, _ := strings.Cut(strings.TrimPrefix(line, "index "), " ")
+			current.oldHash, _, _ = strings.Cut(hashes, "..")
+
+		case strings.HasPrefix(line, "Binary files "):
+			current.isBinary = true
+
+		case line == "GIT binary patch":
+			current.isBinary = true
+			data, last, err := parseBinaryPatch(lines, i+1)
+			if err != nil {
+				return nil, fmt.Errorf("binary patch for %q: %w", current.newPath, err)
+			}
+			current.binaryData = data
+			i = last
+		}
+	}
+
+	for _, filePatch := range filePatches {
+		for _, path := range []string{filePatch.oldPath, filePatch.newPath} {
+			if err := validatePatchPath(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return filePatches, nil
+}
+
+// validatePatchPath rejects, like `git apply`, the patch paths which would modify a file
+// outside of the repository or inside its .git directory
+func validatePatchPath(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	localPath := filepath.FromSlash(path)
+	if filepath.IsAbs(localPath) || strings.HasPrefix(path, "/") || !filepath.IsLocal(localPath) {
+		return fmt.Errorf("invalid path %q: outside of the repository", path)
+	}
+
+	for _, element := range strings.Split(filepath.ToSlash(filepath.Clean(localPath)), "/") {
+		if strings.EqualFold(element, ".git") {
+			return fmt.Errorf("invalid path %q: inside the .git directory", path)
+		}
+	}
+
+	return nil
+}
+
+// patchFilePath returns the location of the patch path in workingDir,
+// making sure it's neither outside of workingDir nor beyond a symbolic link
+func patchFilePath(workingDir, path string) (string, error) {
+	filePath := filepath.Join(workingDir, filepath.FromSlash(path))
+
+	rel, err := filepath.Rel(workingDir, filePath)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("invalid path %q: outside of the repository", path)
+	}
+
+	// A symbolic link to a directory would allow to write outside of the repository
+	for dir := filepath.Dir(rel); dir != "."; dir = filepath.Dir(dir) {
+		info, err := os.Lstat(filepath.Join(workingDir, dir))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("invalid path %q: beyond a symbolic link", path)
+		}
+	}
+
+	return filePath, nil
+}
+
+// parseGitDiffPaths returns the old and new paths from a "diff --git a/old b/new" line
+func parseGitDiffPaths(paths string) (string, string) {
+	if strings.HasPrefix(paths, "a/") {
+		if i := strings.Index(paths, " b/"); i >= 0 {
+			return paths[2:i], paths[i+3:]
+		}
+	}
+
+	oldPath, newPath, _ := strings.Cut(paths, " ")
+	return parsePatchPath(oldPath), parsePatchPath(newPath)
+}
+
+// parsePatchPath returns the file path from a "---" or "+++" line, without its leading directory,
+// like `git apply -p1` does. An empty path is returned for "/dev/null".
+func parsePatchPath(path string) string {
+	path, _, _ = strings.Cut(path, "\t")
+	path = strings.TrimSpace(path)
+
+	if path == "/dev/null" {
+		return ""
+	}
+
+	if _, p, found := strings.Cut(path, "/"); found {
+		return p
+	}
+
+	return path
+}
+
+// parseHunk parses the hunk starting at lines[i] and returns the index of its last line
+func parseHunk(lines []string, i int) (*patchHunk, int, error) {
+	m := hunkHeaderRegex.FindStringSubmatch(lines[i])
+	if m == nil {
+		return nil, i, fmt.Errorf("invalid hunk header %q", lines[i])
+	}
+
+	hunk := patchHunk{
+		header:   m[0],
+		oldLines: 1,
+		newLines: 1,
+	}
+
+	hunk.oldStart, _ = strconv.Atoi(m[1])
+	hunk.newStart, _ = strconv.Atoi(m[3])
+	if m[2] != "" {
+		hunk.oldLines, _ = strconv.Atoi(m[2])
+	}
+	if m[4] != "" {
+		hunk.newLines, _ = strconv.Atoi(m[4])
+	}
+
+	oldCount, newCount := 0, 0
+	for i++; i < len(lines) && (oldCount < hunk.oldLines || newCount < hunk.newLines); i++ {
+		line := lines[i]
+		if line == "" {
+			// Empty context line whose leading space was removed
+			line = " "
+		}
+
+		switch line[0] {
+		case ' ':
+			oldCount++
+			newCount++
+		case '-':
+			oldCount++
+		case '+':
+			newCount++
+		case '\\':
+			hunk.trimLastNewline()
+			continue
+		default:
+			return nil, i, fmt.Errorf("unexpected line %q in hunk %q", line, hunk.header)
+		}
+
+		hunk.lines = append(hunk.lines, patchLine{op: line[0], text: line[1:] + "\n"})
+	}
+
+	if oldCount != hunk.oldLines || newCount != hunk.newLines {
+		return nil, i, fmt.Errorf("truncated hunk %q", hunk.header)
+	}
+
+	// The last hunk line may be followed by "\ No newline at end of file"
+	if i < len(lines) && strings.HasPrefix(lines[i], "\\") {
+		hunk.trimLastNewline()
+		i++
+	}
+
+	return &hunk, i - 1, nil
+}
+
+// trimLastNewline removes the end of line from the last hunk line
+func (h *patchHunk) trimLastNewline() {
+	if len(h.lines) > 0 {
+		last := &h.lines[len(h.lines)-1]
+		last.text = strings.TrimSuffix(last.text, "\n")
+	}
+}
+
+// content returns the hunk lines, before applying it if old is true, or after otherwise
+func (h *patchHunk) content(old bool) []string {
+	result := []string{}
+	for _, line := range h.lines {
+		if line.op == ' ' || (old && line.op == '-') || (!old && line.op == '+') {
+			result = append(result, line.text)
+		}
+	}
+	return result
+}
+
+// context returns the hunk lines expected in the original file, prefixed by their operation
+func (h *patchHunk) context() string {
+	sb := strings.Builder{}
+	for _, line := range h.lines {
+		if line.op == '+' {
+			continue
+		}
+		sb.WriteByte(line.op)
+		sb.WriteString(strings.TrimSuffix(line.text, "\n"))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// parseBinaryPatch parses the literal git binary patch starting at lines[i],
+// returns the decoded content, and the index of its last line
+func parseBinaryPatch(lines []string, i int) ([]byte, int, error) {
+	if i >= len(lines) || !strings.HasPrefix(lines[i], "literal ") {
+		return nil, i, fmt.Errorf("only git binary patches containing literal data are supported")
+	}
+
+	size, err := strconv.Atoi(strings.TrimPrefix(lines[i], "literal "))
+	if err != nil {
+		return nil, i, err
+	}
+
+	encoded := []string{}
+	for i++; i < len(lines) && lines[i] != ""; i++ {
+		encoded = append(encoded, lines[i])
+	}
+
+	data, err := decodeBinaryLiteral(encoded, size)
+	if err != nil {
+		return nil, i, err
+	}
+
+	// Skip the optional reverse hunk, only needed to revert the patch
+	if i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "literal ") || strings.HasPrefix(lines[i+1], "delta ")) {
+		for i++; i < len(lines) && lines[i] != ""; i++ {
+		}
+	}
+
+	return data, i, nil
+}
+
+// decodeBinaryLiteral decodes the base85 encoded and zlib compressed lines of a git binary patch literal
+func decodeBinaryLiteral(lines []string, size int) ([]byte, error) {
+	compressed := []byte{}
+	for _, line := range lines {
+		if len(line) < 6 {
+			return nil, fmt.Errorf("invalid binary patch line %q", line)
+		}
+
+		// The first character of each line encodes its decoded length
+		var n int
+		switch c := line[0]; {
+		case c >= 'A' && c <= 'Z':
+			n = int(c-'A') + 1
+		case c >= 'a' && c <= 'z':
+			n = int(c-'a') + 27
+		default:
+			return nil, fmt.Errorf("invalid binary patch line %q", line)
+		}
+
+		decoded, err := decodeBase85(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n > len(decoded) {
+			return nil, fmt.Errorf("invalid binary patch line %q", line)
+		}
+		compressed = append(compressed, decoded[:n]...)
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != size {
+		return nil, fmt.Errorf("binary patch size %d doesn't match expected size %d", len(data), size)
+	}
+
+	return data, nil
+}
+
+// decodeBase85 decodes groups of 5 characters into 4 bytes
+func decodeBase85(s string) ([]byte, error) {
+	if len(s)%5 != 0 {
+		return nil, fmt.Errorf("invalid base85 length %d", len(s))
+	}
+
+	result := make([]byte, 0, len(s)/5*4)
+	for ; len(s) > 0; s = s[5:] {
+		var acc uint64
+		for i := 0; i < 5; i++ {
+			d := strings.IndexByte(base85Alphabet, s[i])
+			if d < 0 {
+				return nil, fmt.Errorf("invalid base85 character %q", s[i])
+			}
+			acc = acc*85 + uint64(d)
+		}
+		if acc > 0xffffffff {
+			return nil, fmt.Errorf("invalid base85 group %q", s[:5])
+		}
+		result = append(result, byte(acc>>24), byte(acc>>16), byte(acc>>8), byte(acc))
+	}
+
+	return result, nil
+}
+
+// applyFilePatch computes the file modifications resulting from filePatch
+func applyFilePatch(r *git.Repository, workingDir string, filePatch *parsedFilePatch) ([]patchResult, error) {
+
+	path := filePatch.newPath
+	if filePatch.isDeleted {
+		path = filePatch.oldPath
+	}
+
+	content := ""
+	mode := filemode.Regular
+
+	if filePatch.isNew {
+		filePath, err := patchFilePath(workingDir, path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Lstat(filePath); err == nil {
+			return nil, fmt.Errorf("file %q already exists", path)
+		}
+	} else {
+		if _, err := patchFilePath(workingDir, filePatch.oldPath); err != nil {
+			return nil, err
+		}
+		f, err := worktreePatchFile(workingDir, filePatch.oldPath)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			return nil, fmt.Errorf("file %q doesn't exist", filePatch.oldPath)
+		}
+		content = f.content
+		mode = f.mode
+	}
+
+	var result string
+	switch {
+	case filePatch.isBinary && filePatch.binaryData == nil:
+		return nil, fmt.Errorf("binary patch for %q doesn't contain any data, it must be generated using `git diff --binary`", path)
+	case filePatch.isBinary:
+		result = string(filePatch.binaryData)
+	default:
+		var err error
+		result, err = applyHunks(path, content, filePatch.hunks)
+		if err != nil {
+			merged, mergeErr := threeWayApply(r, path, content, filePatch)
+			if mergeErr != nil {
+				logrus.Debugf("3-way merge of %q not possible: %s", path, mergeErr)
+				return nil, err
+			}
+			logrus.Infof("patch applied to %q using a 3-way merge", path)
+			result = merged
+		}
+	}
+
+	if filePatch.isDeleted {
+		if result != "" {
+			return nil, fmt.Errorf("file %q isn't empty after applying its deletion patch", path)
+		}
+		return []patchResult{{path: path, remove: true}}, nil
+	}
+
+	if filePatch.newMode != 0 {
+		mode = filePatch.newMode
+	}
+
+	results := []patchResult{{path: path, content: result, mode: mode}}
+
+	// Renamed file
+	if !filePatch.isNew && filePatch.oldPath != filePatch.newPath {
+		results = append(results, patchResult{path: filePatch.oldPath, remove: true})
+	}
+
+	return results, nil
+}
+
+// applyHunks applies hunks to content, and reports every hunk which couldn't be applied
+func applyHunks(path, content string, hunks []*patchHunk) (string, error) {
+	lines := splitContentLines(content)
+
+	result := []string{}
+	var errs []error
+	// next is the first line not yet copied to result
+	next := 0
+	// offset is the number of lines added or removed before the current hunk
+	offset := 0
+
+	for n, hunk := range hunks {
+		old := hunk.content(true)
+
+		// A hunk without original lines inserts its content after oldStart
+		start := hunk.oldStart - 1
+		if hunk.oldLines == 0 {
+			start = hunk.oldStart
+		}
+
+		at := findLines(lines, old, start+offset, next)
+		if at < 0 {
+			errs = append(errs, &ErrPatchHunk{
+				File:    path,
+				Hunk:    n + 1,
+				Header:  hunk.header,
+				Context: hunk.context(),
+			})
+			continue
+		}
+
+		offset = at - start
+		result = append(result, lines[next:at]...)
+		result = append(result, hunk.content(false)...)
+		next = at + len(old)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+
+	result = append(result, lines[next:]...)
+
+	return strings.Join(result, ""), nil
+}
+
+// findLines returns the position of expected in lines, not before min,
+// and closest to the position at, or -1 if not found.
+func findLines(lines, expected []string, at, min int) int {
+	matches := func(i int) bool {
+		if i < min || i+len(expected) > len(lines) {
+			return false
+		}
+		for j := range expected {
+			if lines[i+j] != expected[j] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for d := 0; d <= len(lines); d++ {
+		if matches(at - d) {
+			return at - d
+		}
+		if matches(at + d) {
+			return at + d
+		}
+	}
+
+	return -1
+}
+
+// splitContentLines splits content into lines, keeping their end of line
+func splitContentLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// threeWayApply applies filePatch to the original file identified in the patch index line,
+// then merges the result with the current content which may contain local modifications.
+func threeWayApply(r *git.Repository, path, current string, filePatch *parsedFilePatch) (string, error) {
+	if !plumbing.IsHash(filePatch.oldHash) {
+		return "", fmt.Errorf("patch doesn't contain the full hash of the original file %q", path)
+	}
+
+	blob, err := r.BlobObject(plumbing.NewHash(filePatch.oldHash))
+	if err != nil {
+		return "", fmt.Errorf("original file %q %s: %w", path, filePatch.oldHash, err)
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	base, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	patched, err := applyHunks(path, string(base), filePatch.hunks)
+	if err != nil {
+		return "", err
+	}
+
+	return mergeLines(path, string(base), current, patched)
+}
+
+// lineChange replaces the base lines from start to end, excluded, by lines
+type lineChange struct {
+	start int
+	end   int
+	lines []string
+}
+
+// lineChanges returns the line changes needed to turn base into content
+func lineChanges(base, content string) []lineChange {
+	changes := []lineChange{}
+	var current *lineChange
+	i := 0
+
+	for _, d := range diff.Do(base, content) {
+		lines := splitContentLines(d.Text)
+
+		if d.Type == diffmatchpatch.DiffEqual {
+			if current != nil {
+				changes = append(changes, *current)
+				current = nil
+			}
+			i += len(lines)
+			continue
+		}
+
+		if current == nil {
+			current = &lineChange{start: i, end: i}
+		}
+
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			current.end += len(lines)
+			i += len(lines)
+		case diffmatchpatch.DiffInsert:
+			current.lines = append(current.lines, lines...)
+		}
+	}
+
+	if current != nil {
+		changes = append(changes, *current)
+	}
+
+	return changes
+}
+
+// mergeLines merges both ours and theirs modifications of base.
+// It fails if both modify the same, or adjacent, lines differently.
+func mergeLines(path, base, ours, theirs string) (string, error) {
+	changes := lineChanges(base, ours)
+
+	for _, change := range lineChanges(base, theirs) {
+		conflict := false
+		duplicate := false
+		for _, c := range changes {
+			if change.start <= c.end && c.start <= change.end {
+				if change.start == c.start && change.end == c.end && strings.Join(change.lines, "") == strings.Join(c.lines, "") {
+					duplicate = true
+					continue
+				}
+				conflict = true
+			}
+		}
+		if conflict {
+			return "", fmt.Errorf("conflict between the patch and local modifications of %q at line %d", path, change.start+1)
+		}
+		if !duplicate {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].start < changes[j].start
+	})
+
+	lines := splitContentLines(base)
+	result := []string{}
+	next := 0
+	for _, change := range changes {
+		result = append(result, lines[next:change.start]...)
+		result = append(result, change.lines...)
+		next = change.end
+	}
+	result = append(result, lines[next:]...)
+
+	return strings.Join(result, ""), nil
+}
+
+// write applies the file modification into workingDir
+func (p patchResult) write(workingDir string) error {
+	filePath, err := patchFilePath(workingDir, p.path)
+	if err != nil {
+		return err
+	}
+
+	if p.remove {
+		return os.Remove(filePath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	if p.mode == filemode.Symlink {
+		if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return os.Symlink(p.content, filePath)
+	}
+
+	perm := os.FileMode(0o644)
+	if p.mode == filemode.Executable {
+		perm = 0o755
+	}
+
+	if err := os.WriteFile(filePath, []byte(p.content), perm); err != nil {
+		return err
+	}
+
+	// WriteFile doesn't update the permissions of an existing file
+	return os.Chmod(filePath, perm)
+}
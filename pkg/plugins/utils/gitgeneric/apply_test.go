@@ -0,0 +1,265 @@
+package gitgeneric
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenLines returns a file content of ten numbered lines, where replace overrides some of them
+func tenLines(replace map[int]string) string {
+	sb := strings.Builder{}
+	for i := 1; i <= 10; i++ {
+		line, ok := replace[i]
+		if !ok {
+			line = "line " + string(rune('0'+i%10))
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+// exportTestPatch writes files into a new clone of origin, then exports the resulting patch
+func exportTestPatch(t *testing.T, origin string, files map[string]string, remove ...string) string {
+	t.Helper()
+
+	workingDir := newTestClone(t, origin)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		writeTestFile(t, workingDir, name, content)
+		_, err = w.Add(name)
+		require.NoError(t, err)
+	}
+	for _, name := range remove {
+		require.NoError(t, os.Remove(filepath.Join(workingDir, name)))
+	}
+
+	patchFile := filepath.Join(t.TempDir(), "updatecli.patch")
+	require.NoError(t, GoGit{}.ExportPatch(workingDir, patchFile))
+
+	return patchFile
+}
+
+func readTestFile(t *testing.T, workingDir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(workingDir, name))
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestApplyPatchExported(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{
+		"README.md":  tenLines(nil),
+		"DELETED.md": "deleted\n",
+		"logo.png":   "\x89PNG\x00\x01\x02",
+	})
+
+	expected := map[string]string{
+		"README.md":      tenLines(map[int]string{1: "first", 10: "last"}),
+		"logo.png":       "\x89PNG\x00\x03\x04\x05",
+		"docs/NEW.md":    "new file\nwithout end of line",
+		"docs/empty.bin": "\x00",
+	}
+	patchFile := exportTestPatch(t, origin, expected, "DELETED.md")
+
+	workingDir := newTestClone(t, origin)
+	require.NoError(t, GoGit{}.ApplyPatch(patchFile, workingDir))
+
+	for name, content := range expected {
+		assert.Equal(t, content, readTestFile(t, workingDir, name), name)
+	}
+	assert.NoFileExists(t, filepath.Join(workingDir, "DELETED.md"))
+}
+
+func TestApplyPatchUnifiedDiff(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{
+		"README.md": "header 1\nheader 2\n" + tenLines(nil),
+	})
+
+	// Lines numbers don't take into account the header lines
+	patch := `--- README.md.orig	2023-01-01 00:00:00
++++ README.md	2023-01-01 00:00:00
+@@ -2,4 +2,4 @@
+ line 2
+ line 3
+-line 4
++line four
+ line 5
+@@ -10,1 +10,2 @@
+ line 0
++line 11
+`
+	patchFile := filepath.Join(t.TempDir(), "unified.diff")
+	require.NoError(t, os.WriteFile(patchFile, []byte(patch), 0o644))
+
+	require.NoError(t, GoGit{}.ApplyPatch(patchFile, workingDir))
+	assert.Equal(t,
+		"header 1\nheader 2\n"+tenLines(map[int]string{4: "line four"})+"line 11\n",
+		readTestFile(t, workingDir, "README.md"))
+}
+
+func TestApplyPatchFailedHunk(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{
+		"README.md": tenLines(nil),
+		"OTHER.md":  "other\n",
+	})
+
+	patch := `diff --git a/OTHER.md b/OTHER.md
+--- a/OTHER.md
++++ b/OTHER.md
+@@ -1 +1 @@
+-other
++updated
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,2 @@
+-line 1
++line one
+ line 2
+@@ -5,2 +5,2 @@
+-unknown line
++line five
+ line 6
+`
+	patchFile := filepath.Join(t.TempDir(), "failed.patch")
+	require.NoError(t, os.WriteFile(patchFile, []byte(patch), 0o644))
+
+	err := GoGit{}.ApplyPatch(patchFile, workingDir)
+	require.Error(t, err)
+
+	var hunkErr *ErrPatchHunk
+	require.True(t, errors.As(err, &hunkErr))
+	assert.Equal(t, "README.md", hunkErr.File)
+	assert.Equal(t, 2, hunkErr.Hunk)
+	assert.Equal(t, "@@ -5,2 +5,2 @@", hunkErr.Header)
+	assert.Equal(t, "-unknown line\n line 6\n", hunkErr.Context)
+
+	// No file is modified when a hunk fails
+	assert.Equal(t, tenLines(nil), readTestFile(t, workingDir, "README.md"))
+	assert.Equal(t, "other\n", readTestFile(t, workingDir, "OTHER.md"))
+}
+
+func TestApplyPatchThreeWay(t *testing.T) {
+	tests := []struct {
+		name     string
+		local    map[int]string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Local modification in the hunk context",
+			local:    map[int]string{3: "local"},
+			expected: tenLines(map[int]string{3: "local", 6: "patched"}),
+		},
+		{
+			name:    "Conflicting local modification",
+			local:   map[int]string{6: "local"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": tenLines(nil)})
+			patchFile := exportTestPatch(t, origin, map[string]string{
+				"README.md": tenLines(map[int]string{6: "patched"}),
+			})
+
+			workingDir := newTestClone(t, origin)
+			writeTestFile(t, workingDir, "README.md", tenLines(tt.local))
+
+			err := GoGit{}.ApplyPatch(patchFile, workingDir)
+			if tt.wantErr {
+				var hunkErr *ErrPatchHunk
+				require.True(t, errors.As(err, &hunkErr))
+				assert.Equal(t, tenLines(tt.local), readTestFile(t, workingDir, "README.md"))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, readTestFile(t, workingDir, "README.md"))
+		})
+	}
+}
+
+func TestApplyPatchInvalidPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{
+			name:    "parent directory",
+			path:    "../escaped.txt",
+			wantErr: `invalid path "../escaped.txt": outside of the repository`,
+		},
+		{
+			name:    "nested parent directory",
+			path:    "docs/../../escaped.txt",
+			wantErr: `invalid path "docs/../../escaped.txt": outside of the repository`,
+		},
+		{
+			name:    "git directory",
+			path:    ".git/hooks/pre-commit",
+			wantErr: `invalid path ".git/hooks/pre-commit": inside the .git directory`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentDir := t.TempDir()
+			workingDir := filepath.Join(parentDir, "repository")
+			require.NoError(t, os.Rename(newTestRepository(t, map[string]string{"README.md": "readme\n"}), workingDir))
+
+			patch := "diff --git a/" + tt.path + " b/" + tt.path + `
+new file mode 100644
+--- /dev/null
++++ b/` + tt.path + `
+@@ -0,0 +1 @@
++escaped
+`
+			patchFile := filepath.Join(t.TempDir(), "invalid.patch")
+			require.NoError(t, os.WriteFile(patchFile, []byte(patch), 0o644))
+
+			err := GoGit{}.ApplyPatch(patchFile, workingDir)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			assert.NoFileExists(t, filepath.Join(parentDir, "escaped.txt"))
+			assert.NoFileExists(t, filepath.Join(workingDir, ".git", "hooks", "pre-commit"))
+		})
+	}
+
+	t.Run("beyond a symbolic link", func(t *testing.T) {
+		outsideDir := t.TempDir()
+		workingDir := newTestRepository(t, map[string]string{"README.md": "readme\n"})
+		require.NoError(t, os.Symlink(outsideDir, filepath.Join(workingDir, "link")))
+
+		patch := `diff --git a/link/escaped.txt b/link/escaped.txt
+new file mode 100644
+--- /dev/null
++++ b/link/escaped.txt
+@@ -0,0 +1 @@
++escaped
+`
+		patchFile := filepath.Join(t.TempDir(), "symlink.patch")
+		require.NoError(t, os.WriteFile(patchFile, []byte(patch), 0o644))
+
+		err := GoGit{}.ApplyPatch(patchFile, workingDir)
+		assert.ErrorContains(t, err, `invalid path "link/escaped.txt": beyond a symbolic link`)
+		assert.NoFileExists(t, filepath.Join(outsideDir, "escaped.txt"))
+	})
+}
@@ -0,0 +1,110 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	transportSsh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultSSHUser is the user used to authenticate with ssh git remotes not specifying one
+	DefaultSSHUser = "git"
+)
+
+/*
+transportAuth returns the authentication method to use with the git remote URL, based on its scheme.
+
+Remotes using the ssh transport, such as "ssh://git@github.com/updatecli/updatecli.git"
+or "git@github.com:updatecli/updatecli.git", authenticate using the private key SSHKeyPath if set,
+otherwise using the ssh agent listening on SSH_AUTH_SOCK.
+Other remotes authenticate using BearerToken if set, otherwise using username and password, if any.
+Http remotes called without username and password fall back to the credentials found by fallbackCredentials.
+
+A nil authentication method is returned when there is no credential to use.
+*/
+func (g GoGit) transportAuth(URL, username, password string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(URL)
+	if err == nil && endpoint.Protocol == "ssh" {
+		return g.sshAuth(endpoint)
+	}
+
+	if g.BearerToken != "" {
+		return &transportHttp.TokenAuth{Token: g.BearerToken}, nil
+	}
+
+	if username == "" && password == "" && err == nil && (endpoint.Protocol == "http" || endpoint.Protocol == "https") {
+		username, password = g.fallbackCredentials(endpoint)
+	}
+
+	if username == "" && password == "" {
+		return nil, nil
+	}
+
+	return &transportHttp.BasicAuth{
+		Username: username, // anything except an empty string
+		Password: password,
+	}, nil
+}
+
+// CloneWithToken run `git clone`, authenticating with the git remote using token as a bearer token.
+// See BearerToken to know which git providers accept bearer tokens.
+func (g GoGit) CloneWithToken(token, URL, workingDir string) error {
+	g.BearerToken = token
+	return g.Clone("", "", URL, workingDir)
+}
+
+// PushWithToken run `git push`, authenticating with the git remote using token as a bearer token.
+// See BearerToken to know which git providers accept bearer tokens.
+func (g GoGit) PushWithToken(token, workingDir string, force bool) error {
+	g.BearerToken = token
+	return g.Push("", "", workingDir, force)
+}
+
+// sshAuth returns the ssh authentication method to use with the git remote endpoint
+func (g GoGit) sshAuth(endpoint *transport.Endpoint) (transport.AuthMethod, error) {
+	user := endpoint.User
+	if user == "" {
+		user = DefaultSSHUser
+	}
+
+	if g.SSHKeyPath == "" {
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			return nil, fmt.Errorf("no ssh private key specified nor ssh agent running, to authenticate with git host %q", endpoint.Host)
+		}
+
+		logrus.Debugf("authenticating with git host %q using the ssh agent", endpoint.Host)
+
+		auth, err := transportSsh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("ssh agent: %w", err)
+		}
+		return auth, nil
+	}
+
+	pemBytes, err := os.ReadFile(g.SSHKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading ssh private key: %w", err)
+	}
+
+	if g.SSHKeyPassphrase == "" {
+		var passphraseMissingErr *ssh.PassphraseMissingError
+		if _, err := ssh.ParsePrivateKey(pemBytes); errors.As(err, &passphraseMissingErr) {
+			return nil, &ErrEncryptedSSHKey{Path: g.SSHKeyPath}
+		}
+	}
+
+	logrus.Debugf("authenticating with git host %q using the ssh private key %q", endpoint.Host, g.SSHKeyPath)
+
+	auth, err := transportSsh.NewPublicKeys(user, pemBytes, g.SSHKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("ssh private key %q: %w", g.SSHKeyPath, err)
+	}
+
+	return auth, nil
+}
@@ -0,0 +1,255 @@
+package gitgeneric
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	transportSsh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSSHKey generates an ssh private key, encrypted using passphrase if not empty, and returns its path
+func newTestSSHKey(t *testing.T, passphrase string) string {
+	t.Helper()
+
+	sshKeygen, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("ssh-keygen binary required to generate ssh keys")
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, exec.Command(sshKeygen, "-q", "-t", "ed25519", "-N", passphrase, "-f", keyPath).Run())
+
+	return keyPath
+}
+
+func TestTransportAuth(t *testing.T) {
+	keyPath := newTestSSHKey(t, "")
+	encryptedKeyPath := newTestSSHKey(t, "secret")
+
+	// No ssh agent available, nor fallback credentials
+	t.Setenv("SSH_AUTH_SOCK", "")
+	t.Setenv(GitPasswordEnvVariable, "")
+	t.Setenv(GitHubTokenEnvVariable, "")
+
+	tests := []struct {
+		name     string
+		g        GoGit
+		URL      string
+		username string
+		password string
+		wantAuth transport.AuthMethod
+		wantUser string
+		wantErr  bool
+	}{
+		{
+			name:     "Basic authentication over https",
+			URL:      "https://github.com/updatecli/updatecli.git",
+			username: "updatecli",
+			password: "secret",
+			wantAuth: &transportHttp.BasicAuth{Username: "updatecli", Password: "secret"},
+		},
+		{
+			name:     "Bearer token over https",
+			g:        GoGit{BearerToken: "ghs_token"},
+			URL:      "https://github.com/updatecli/updatecli.git",
+			username: "updatecli",
+			password: "secret",
+			wantAuth: &transportHttp.TokenAuth{Token: "ghs_token"},
+		},
+		{
+			name:     "Bearer token ignored over ssh",
+			g:        GoGit{SSHKeyPath: keyPath, BearerToken: "ghs_token"},
+			URL:      "git@github.com:updatecli/updatecli.git",
+			wantUser: "git",
+		},
+		{
+			name: "No credentials over https",
+			URL:  "https://github.com/updatecli/updatecli.git",
+		},
+		{
+			name: "Local repository",
+			URL:  "/tmp/updatecli",
+		},
+		{
+			name:     "Ssh private key with scp-style URL",
+			g:        GoGit{SSHKeyPath: keyPath},
+			URL:      "git@github.com:updatecli/updatecli.git",
+			username: "ignored",
+			wantUser: "git",
+		},
+		{
+			name:     "Ssh private key with ssh URL",
+			g:        GoGit{SSHKeyPath: keyPath},
+			URL:      "ssh://deploy@example.com:2222/updatecli/updatecli.git",
+			wantUser: "deploy",
+		},
+		{
+			name:     "Encrypted ssh private key",
+			g:        GoGit{SSHKeyPath: encryptedKeyPath, SSHKeyPassphrase: "secret"},
+			URL:      "git@github.com:updatecli/updatecli.git",
+			wantUser: "git",
+		},
+		{
+			name:    "Encrypted ssh private key without passphrase",
+			g:       GoGit{SSHKeyPath: encryptedKeyPath},
+			URL:     "git@github.com:updatecli/updatecli.git",
+			wantErr: true,
+		},
+		{
+			name:    "Encrypted ssh private key with wrong passphrase",
+			g:       GoGit{SSHKeyPath: encryptedKeyPath, SSHKeyPassphrase: "wrong"},
+			URL:     "git@github.com:updatecli/updatecli.git",
+			wantErr: true,
+		},
+		{
+			name:    "Missing ssh private key",
+			g:       GoGit{SSHKeyPath: filepath.Join(t.TempDir(), "doNotExist")},
+			URL:     "git@github.com:updatecli/updatecli.git",
+			wantErr: true,
+		},
+		{
+			name:    "Neither ssh private key nor ssh agent",
+			URL:     "git@github.com:updatecli/updatecli.git",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := tt.g.transportAuth(tt.URL, tt.username, tt.password)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantUser == "" {
+				assert.Equal(t, tt.wantAuth, auth)
+				return
+			}
+
+			publicKeys, ok := auth.(*transportSsh.PublicKeys)
+			require.True(t, ok, "expected ssh public keys authentication, got %T", auth)
+			assert.Equal(t, tt.wantUser, publicKeys.User)
+		})
+	}
+
+	_, err := GoGit{SSHKeyPath: encryptedKeyPath}.transportAuth("git@github.com:updatecli/updatecli.git", "", "")
+	var encryptedKeyErr *ErrEncryptedSSHKey
+	require.ErrorAs(t, err, &encryptedKeyErr)
+	assert.Equal(t, encryptedKeyPath, encryptedKeyErr.Path)
+}
+
+func TestFallbackCredentials(t *testing.T) {
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary required to run the git credential helper")
+	}
+
+	// A credential helper answering for every git host, isolated from the user git configuration
+	globalConfig := filepath.Join(t.TempDir(), "gitconfig")
+	require.NoError(t, exec.Command(gitBinary, "config", "--file", globalConfig, "credential.helper",
+		"!f() { echo username=helper; echo password=helper_secret; }; f").Run())
+	t.Setenv("GIT_CONFIG_GLOBAL", globalConfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	tests := []struct {
+		name     string
+		g        GoGit
+		env      map[string]string
+		URL      string
+		username string
+		password string
+		wantAuth transport.AuthMethod
+	}{
+		{
+			name:     "Explicit credentials take precedence",
+			g:        GoGit{UseCredentialHelper: true},
+			env:      map[string]string{GitUsernameEnvVariable: "env", GitPasswordEnvVariable: "env_secret", GitHubTokenEnvVariable: "ghs_token"},
+			URL:      "https://github.com/updatecli/updatecli.git",
+			username: "updatecli",
+			password: "secret",
+			wantAuth: &transportHttp.BasicAuth{Username: "updatecli", Password: "secret"},
+		},
+		{
+			name:     "Git username and password environment variables",
+			g:        GoGit{UseCredentialHelper: true},
+			env:      map[string]string{GitUsernameEnvVariable: "env", GitPasswordEnvVariable: "env_secret", GitHubTokenEnvVariable: "ghs_token"},
+			URL:      "https://github.com/updatecli/updatecli.git",
+			wantAuth: &transportHttp.BasicAuth{Username: "env", Password: "env_secret"},
+		},
+		{
+			name: "Git username without password",
+			env:  map[string]string{GitUsernameEnvVariable: "env"},
+			URL:  "https://github.com/updatecli/updatecli.git",
+		},
+		{
+			name:     "GitHub token environment variable",
+			g:        GoGit{UseCredentialHelper: true},
+			env:      map[string]string{GitHubTokenEnvVariable: "ghs_token"},
+			URL:      "https://github.com/updatecli/updatecli.git",
+			wantAuth: &transportHttp.BasicAuth{Username: "x-access-token", Password: "ghs_token"},
+		},
+		{
+			name:     "GitHub token sent to the GitHub Enterprise Server host",
+			env:      map[string]string{GitHubTokenEnvVariable: "ghs_token", "GITHUB_SERVER_URL": "https://github.example.com"},
+			URL:      "https://github.example.com/updatecli/updatecli.git",
+			wantAuth: &transportHttp.BasicAuth{Username: "x-access-token", Password: "ghs_token"},
+		},
+		{
+			name: "GitHub token not sent to other git hosts",
+			env:  map[string]string{GitHubTokenEnvVariable: "ghs_token"},
+			URL:  "https://gitlab.com/updatecli/updatecli.git",
+		},
+		{
+			name:     "Git credential helper",
+			g:        GoGit{UseCredentialHelper: true},
+			env:      map[string]string{GitHubTokenEnvVariable: "ghs_token"},
+			URL:      "https://gitlab.com/updatecli/updatecli.git",
+			wantAuth: &transportHttp.BasicAuth{Username: "helper", Password: "helper_secret"},
+		},
+		{
+			name: "Git credential helper disabled by default",
+			URL:  "https://gitlab.com/updatecli/updatecli.git",
+		},
+		{
+			name: "No fallback for local repositories",
+			g:    GoGit{UseCredentialHelper: true},
+			env:  map[string]string{GitPasswordEnvVariable: "env_secret"},
+			URL:  "/tmp/updatecli",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{GitUsernameEnvVariable, GitPasswordEnvVariable, GitHubTokenEnvVariable, "GITHUB_SERVER_URL"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			auth, err := tt.g.transportAuth(tt.URL, tt.username, tt.password)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuth, auth)
+		})
+	}
+}
+
+func TestCloneWithToken(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	workingDir := filepath.Join(t.TempDir(), "clone")
+
+	g := GoGit{}
+	require.NoError(t, g.CloneWithToken("ghs_token", origin, workingDir))
+	assert.Empty(t, g.BearerToken, "the token must only be used by this clone")
+
+	pushed := commitTestFile(t, workingDir, "README.md", "v2")
+	require.NoError(t, g.PushWithToken("ghs_token", workingDir, false))
+
+	count, err := g.CommitCount(pushed.String(), origin)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
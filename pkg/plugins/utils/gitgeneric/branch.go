@@ -1,20 +1,26 @@
 package gitgeneric
 
 import (
+	"bytes"
 	"errors"
-	"os"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
-	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/sirupsen/logrus"
 )
 
 // Tags return a list of git tags ordered by latest commit time
 func (g GoGit) Branches(workingDir string) (branches []string, err error) {
+
+	workingDir, err = g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return branches, err
+	}
 	r, err := git.PlainOpen(workingDir)
 	if err != nil {
 		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
@@ -84,10 +90,82 @@ func (g GoGit) Branches(workingDir string) (branches []string, err error) {
 
 }
 
+// RemoteBranches returns the list of branches of the RemoteName git remote, ordered by latest commit time.
+// Only the already fetched remote-tracking branches, such as "refs/remotes/origin/<name>", are known, see Fetch.
+// An empty list is returned when the repository has no remote-tracking branch.
+func (g GoGit) RemoteBranches(workingDir string) (branches []string, err error) {
+
+	workingDir, err = g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return branches, err
+	}
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return branches, err
+	}
+
+	refs, err := r.References()
+	if err != nil {
+		return branches, err
+	}
+
+	prefix := "refs/remotes/" + g.remoteName() + "/"
+
+	type DatedBranch struct {
+		when time.Time
+		name string
+	}
+	listOfDatedBranches := []DatedBranch{}
+
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		// Skip the symbolic "refs/remotes/<remote>/HEAD" reference
+		if ref.Type() != plumbing.HashReference || !strings.HasPrefix(ref.Name().String(), prefix) {
+			return nil
+		}
+
+		commit, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			return err
+		}
+
+		listOfDatedBranches = append(
+			listOfDatedBranches,
+			DatedBranch{
+				name: strings.TrimPrefix(ref.Name().String(), prefix),
+				when: commit.Committer.When,
+			},
+		)
+
+		return nil
+	})
+	if err != nil {
+		return branches, err
+	}
+
+	// Sort branches by time
+	sort.SliceStable(listOfDatedBranches, func(i, j int) bool {
+		return listOfDatedBranches[i].when.Before(listOfDatedBranches[j].when)
+	})
+
+	for _, datedBranch := range listOfDatedBranches {
+		branches = append(branches, datedBranch.name)
+	}
+
+	logrus.Debugf("got remote branches: %v", branches)
+
+	return branches, nil
+}
+
 // NewBranch create a tag then return a boolean to indicate if
 // the tag was created or not.
 func (g GoGit) NewBranch(branch, workingDir string) (bool, error) {
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
+	}
+
 	r, err := git.PlainOpen(workingDir)
 
 	if err != nil {
@@ -124,12 +202,14 @@ func (g GoGit) NewBranch(branch, workingDir string) (bool, error) {
 	return true, nil
 }
 
-// PushBranch publish a single branch created locally
+// PushBranch publish a single branch created locally, force pushing only if force is set.
+// A push rejected because the remote branch diverged returns the git error, without its progress output,
+// matching ErrNonFastForward, which can also be detected using IsNonFastForwardError.
 func (g GoGit) PushBranch(branch string, username string, password string, workingDir string, force bool) error {
 
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything except an empty string
-		Password: password,
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
 	}
 
 	r, err := git.PlainOpen(workingDir)
@@ -139,6 +219,25 @@ func (g GoGit) PushBranch(branch string, username string, password string, worki
 		return err
 	}
 
+	remote, err := g.remote(r)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkRemoteAllowed(remoteURL(remote)); err != nil {
+		return err
+	}
+
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
 	logrus.Debugf("Pushing git branch: %q", branch)
 
 	// By default don't force push
@@ -148,23 +247,605 @@ func (g GoGit) PushBranch(branch string, username string, password string, worki
 		refspec = config.RefSpec("+refs/heads/" + branch + ":refs/heads/" + branch)
 	}
 
+	serverOptions, err := g.serverPushOptions()
+	if err != nil {
+		return err
+	}
+
+	b := bytes.Buffer{}
 	po := &git.PushOptions{
-		RemoteName: "origin",
-		Progress:   os.Stdout,
-		RefSpecs:   []config.RefSpec{refspec},
-		Auth:       &auth,
+		RemoteName:      remote.Config().Name,
+		Progress:        &b,
+		RefSpecs:        []config.RefSpec{refspec},
+		Options:         serverOptions,
+		Auth:            auth,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
 	}
 
 	err = r.Push(po)
 
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+	b.Reset()
+
 	if err != nil {
 		if err == git.NoErrAlreadyUpToDate {
-			logrus.Info("origin remote was up to date, no push done")
+			logrus.Infof("%q remote was up to date, no push done", remote.Config().Name)
 			return nil
 		}
-		logrus.Infof("push to remote origin error: %s", err)
+		logrus.Infof("push to remote %q error: %s", remote.Config().Name, redactCredentials(err.Error(), password))
+		if IsNonFastForwardError(err) {
+			return wrapError(err)
+		}
+		return g.progressError("push", err, progress)
+	}
+
+	return nil
+}
+
+// RenameBranch renames the local branch oldName, then returns the new branch name
+// sanitized by SanitizeBranchName. HEAD follows the renamed branch if it was checked out.
+func (g GoGit) RenameBranch(oldName, newName, workingDir string) (string, error) {
+
+	logrus.Debugf("stage: git-rename-branch\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return "", err
+	}
+
+	newName = g.SanitizeBranchName(newName)
+	if newName == "" {
+		return "", fmt.Errorf("invalid new branch name for %q", oldName)
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return "", err
+	}
+
+	oldRef, err := r.Reference(plumbing.NewBranchReferenceName(oldName), true)
+	if err != nil {
+		return "", branchNotFoundError(oldName, err)
+	}
+
+	if oldName == newName {
+		return newName, nil
+	}
+
+	newRefName := plumbing.NewBranchReferenceName(newName)
+	if _, err := r.Reference(newRefName, false); err == nil {
+		return "", fmt.Errorf("branch %q: %w", newName, git.ErrBranchExists)
+	}
+
+	if err := r.Storer.SetReference(plumbing.NewHashReference(newRefName, oldRef.Hash())); err != nil {
+		return "", err
+	}
+
+	// Keep the branch upstream configuration, if any, like `git branch -m` does.
+	// The upstream still is the remote branch oldName until RenameRemoteBranch renames it.
+	cfg, err := r.Config()
+	if err != nil {
+		return "", err
+	}
+	if branchConfig, ok := cfg.Branches[oldName]; ok {
+		delete(cfg.Branches, oldName)
+		branchConfig.Name = newName
+		cfg.Branches[newName] = branchConfig
+		if err := r.Storer.SetConfig(cfg); err != nil {
+			return "", err
+		}
+	}
+
+	head, err := r.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() == plumbing.SymbolicReference && head.Target() == oldRef.Name() {
+		err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, newRefName))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := r.Storer.RemoveReference(oldRef.Name()); err != nil {
+		return "", err
+	}
+
+	logrus.Debugf("branch %q renamed to %q", oldName, newName)
+
+	return newName, nil
+}
+
+// RenameRemoteBranch publishes the local branch newName then deletes the remote branch oldName,
+// which is the remote counterpart of RenameBranch.
+// The local branch newName then tracks the remote branch newName, if it was tracking oldName.
+func (g GoGit) RenameRemoteBranch(oldName, newName, username, password, workingDir string) error {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	remote, err := g.remote(r)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkRemoteAllowed(remoteURL(remote)); err != nil {
+		return err
+	}
+
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Renaming remote git branch %q to %q", oldName, newName)
+
+	refspecs := []config.RefSpec{
+		config.RefSpec("refs/heads/" + newName + ":refs/heads/" + newName),
+		config.RefSpec(":refs/heads/" + oldName),
+	}
+
+	b := bytes.Buffer{}
+	po := &git.PushOptions{
+		RemoteName:      remote.Config().Name,
+		Progress:        &b,
+		RefSpecs:        refspecs,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
+	}
+
+	if auth != nil {
+		po.Auth = auth
+	}
+
+	err = r.Push(po)
+
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+	b.Reset()
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		logrus.Infof("push to remote %q error: %s", remote.Config().Name, redactCredentials(err.Error(), password))
+		return g.progressError("push", err, progress)
+	}
+
+	cfg, err := r.Config()
+	if err != nil {
+		return err
+	}
+	branchConfig, ok := cfg.Branches[newName]
+	if ok && branchConfig.Remote == remote.Config().Name && branchConfig.Merge == plumbing.NewBranchReferenceName(oldName) {
+		branchConfig.Merge = plumbing.NewBranchReferenceName(newName)
+		if err := r.Storer.SetConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// BranchExists returns true if the local branch name exists, without fetching from the git remote
+func (g GoGit) BranchExists(name, workingDir string) (bool, error) {
+	return g.referenceExists(plumbing.NewBranchReferenceName(name), workingDir)
+}
+
+// RemoteBranchExists returns true if the remote-tracking branch of name, such as "refs/remotes/origin/<name>"
+// for the RemoteName remote, exists. Only the already fetched remote branches are known, see Fetch.
+func (g GoGit) RemoteBranchExists(name, workingDir string) (bool, error) {
+	return g.referenceExists(plumbing.NewRemoteReferenceName(g.remoteName(), name), workingDir)
+}
+
+// referenceExists returns true if the git reference refName exists in the repository located at workingDir
+func (g GoGit) referenceExists(refName plumbing.ReferenceName, workingDir string) (bool, error) {
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return false, err
+	}
+
+	_, err = r.Reference(refName, false)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		logrus.Debugf("reference %q doesn't exist", refName.String())
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// DeleteBranch deletes the local branch name, like `git branch --delete --force` does,
+// and its upstream configuration. Deleting a branch which doesn't exist does nothing.
+// The branch checked out in the worktree can't be deleted.
+func (g GoGit) DeleteBranch(name, workingDir string) error {
+
+	logrus.Debugf("stage: git-delete-branch\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	refName := plumbing.NewBranchReferenceName(name)
+
+	head, err := r.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return err
+	}
+	if head.Type() == plumbing.SymbolicReference && head.Target() == refName {
+		return &ErrDeleteCheckedOutBranch{Name: name}
+	}
+
+	_, err = r.Reference(refName, false)
+	if err == plumbing.ErrReferenceNotFound {
+		logrus.Debugf("branch %q doesn't exist, nothing to delete", name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cfg, err := r.Config()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Branches[name]; ok {
+		delete(cfg.Branches, name)
+		if err := r.Storer.SetConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	if err := r.Storer.RemoveReference(refName); err != nil {
+		return err
+	}
+
+	logrus.Debugf("branch %q deleted", name)
+
+	return nil
+}
+
+// DeleteRemoteBranch deletes the branch name from the git remote, like `git push --delete` does,
+// and its remote-tracking branch. Deleting a remote branch which doesn't exist does nothing.
+func (g GoGit) DeleteRemoteBranch(username, password, name, workingDir string) error {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	remote, err := g.remote(r)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkRemoteAllowed(remoteURL(remote)); err != nil {
+		return err
+	}
+
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
+	logrus.Debugf("Deleting remote git branch %q", name)
+
+	b := bytes.Buffer{}
+	po := &git.PushOptions{
+		RemoteName:      remote.Config().Name,
+		Progress:        &b,
+		RefSpecs:        []config.RefSpec{config.RefSpec(":refs/heads/" + name)},
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
+	}
+
+	if auth != nil {
+		po.Auth = auth
+	}
+
+	err = r.Push(po)
+
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+	b.Reset()
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		logrus.Infof("push to remote %q error: %s", remote.Config().Name, redactCredentials(err.Error(), password))
+		return g.progressError("push", err, progress)
+	}
+
+	err = r.Storer.RemoveReference(plumbing.NewRemoteReferenceName(remote.Config().Name, name))
+	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// SetBranchTo moves the local branch to commit, identified by any git reference, like `git branch --force` does.
+// The branch is created if it doesn't exist yet. Unless force is set, the branch can only move forward,
+// to a descendant of its current commit, so no commit is lost.
+// If the branch is checked out, the worktree is hard reset to commit. Unless force is set,
+// the branch isn't moved if the worktree isn't clean, as the reset would discard uncommitted changes
+// and untracked files.
+// The branch can then be published using PushBranch.
+func (g GoGit) SetBranchTo(branch, commit, workingDir string, force bool) error {
+
+	logrus.Debugf("stage: git-set-branch\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	hash, err := resolveRevision(r, commit)
+	if err != nil {
+		return err
+	}
+
+	target, err := r.CommitObject(hash)
+	if err != nil {
+		return fmt.Errorf("commit %q: %w", commit, err)
+	}
+
+	refName := plumbing.NewBranchReferenceName(branch)
+
+	current, err := r.Reference(refName, true)
+	switch {
+	case err == plumbing.ErrReferenceNotFound:
+		logrus.Debugf("creating branch %q", branch)
+	case err != nil:
+		return err
+	case current.Hash() == hash:
+		logrus.Debugf("branch %q already set to commit %q", branch, hash.String())
+		return nil
+	case !force:
+		currentCommit, err := r.CommitObject(current.Hash())
+		if err != nil {
+			return err
+		}
+
+		isForward, err := currentCommit.IsAncestor(target)
+		if err != nil {
+			return err
+		}
+
+		if !isForward {
+			return fmt.Errorf("moving branch %q from commit %q to %q would lose commits, force is required",
+				branch, current.Hash().String(), hash.String())
+		}
+	}
+
+	head, err := r.Head()
+	if err == nil && head.Name() == refName {
+		w, err := r.Worktree()
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			status, err := g.worktreeStatus(w)
+			if err != nil {
+				return err
+			}
+			if !status.IsClean() {
+				return fmt.Errorf("moving the checked out branch %q would discard uncommitted changes, force is required", branch)
+			}
+		}
+
+		return g.resetWorktree(r, w, hash, git.HardReset)
+	}
+
+	logrus.Debugf("setting branch %q to commit %q", branch, hash.String())
+
+	return r.Storer.SetReference(plumbing.NewHashReference(refName, hash))
+}
+
+// CheckoutDefaultBranch fetches the default branch of the git remote, as advertised by its HEAD,
+// then checks it out and hard resets it to the remote branch tip.
+// The local branch is created if it doesn't exist yet.
+// It returns the name of the branch checked out.
+func (g GoGit) CheckoutDefaultBranch(remote, workingDir string) (string, error) {
+
+	logrus.Debugf("stage: git-checkout-default-branch\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return "", err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return "", err
+	}
+
+	remoteURL := repositoryRemoteURL(r, remote)
+	if err := g.checkRemoteAllowed(remoteURL); err != nil {
+		return "", err
+	}
+
+	branch, err := g.remoteDefaultBranch(r, remote)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.Debugf("remote %q default branch is %q", remote, branch)
+
+	remoteRefName := plumbing.NewRemoteReferenceName(remote, branch)
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return "", err
+	}
+
+	b := bytes.Buffer{}
+	err = r.Fetch(&git.FetchOptions{
+		RemoteName: remote,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec("+" + plumbing.NewBranchReferenceName(branch).String() + ":" + remoteRefName.String()),
+		},
+		Progress:        &b,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL),
+		ProxyOptions:    g.proxyOptions(remoteURL),
+		CABundle:        caBundle,
+	})
+
+	progress := redactCredentials(b.String())
+	g.writeProgress(progress)
+	b.Reset()
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return "", g.progressError("fetch", err, progress)
+	}
+
+	remoteRef, err := r.Reference(remoteRefName, true)
+	if err != nil {
+		return "", branchNotFoundError(remoteRefName.Short(), err)
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	refName := plumbing.NewBranchReferenceName(branch)
+	_, err = r.Reference(refName, false)
+	create := err == plumbing.ErrReferenceNotFound
+
+	checkoutOptions := git.CheckoutOptions{
+		Branch: refName,
+		Create: create,
+		Force:  true,
+	}
+	if create {
+		checkoutOptions.Hash = remoteRef.Hash()
+	}
+
+	if err := g.checkout(r, w, &checkoutOptions); err != nil {
+		return "", fmt.Errorf("checkout branch %q: %w", branch, err)
+	}
+
+	if err := g.resetWorktree(r, w, remoteRef.Hash(), git.HardReset); err != nil {
+		return "", err
+	}
+
+	return branch, nil
+}
+
+// remoteDefaultBranch returns the branch referenced by the HEAD of the git remote
+func (g GoGit) remoteDefaultBranch(r *git.Repository, remote string) (string, error) {
+	gitRemote, err := repositoryRemote(r, remote)
+	if err != nil {
+		return "", err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return "", err
+	}
+
+	refs, err := gitRemote.List(&git.ListOptions{
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(gitRemote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(gitRemote)),
+		CABundle:        caBundle,
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing remote references from %q: %w", remote, err)
+	}
+
+	var head *plumbing.Reference
+	branches := map[string]plumbing.Hash{}
+	for _, ref := range refs {
+		switch {
+		case ref.Name() == plumbing.HEAD:
+			head = ref
+		case ref.Name().IsBranch():
+			branches[ref.Name().Short()] = ref.Hash()
+		}
+	}
+
+	if head == nil {
+		return "", fmt.Errorf("git remote %q doesn't advertise its HEAD", remote)
+	}
+
+	if head.Type() == plumbing.SymbolicReference {
+		return head.Target().Short(), nil
+	}
+
+	// Without the symref capability, HEAD is only advertised as a commit hash,
+	// so look for the branch pointing to it, preferring the usual default branch names
+	candidates := []string{}
+	for branch, hash := range branches {
+		if hash == head.Hash() {
+			candidates = append(candidates, branch)
+		}
+	}
+	sort.Strings(candidates)
+
+	for _, name := range []string{"main", "master"} {
+		for _, candidate := range candidates {
+			if candidate == name {
+				return candidate, nil
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no branch found matching git remote %q HEAD", remote)
+	}
+
+	return candidates[0], nil
+}
@@ -1,9 +1,16 @@
 package gitgeneric
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test that we can correctly retrieve a list of tags from a remote git repository
@@ -36,3 +43,315 @@ func TestBranchIntegration(t *testing.T) {
 	}
 	os.Remove(workingDir)
 }
+
+func TestRenameBranch(t *testing.T) {
+	g := GoGit{}
+
+	origin := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+	workingDir := newTestClone(t, origin)
+
+	_, err := g.NewBranch("updatecli_old", workingDir)
+	require.NoError(t, err)
+	require.NoError(t, g.PushBranch("updatecli_old", "", "", workingDir, false))
+
+	// Renaming the checked out branch
+	got, err := g.RenameBranch("main", "updatecli/main", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "updatecli_main", got)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("updatecli_main"), head.Name())
+
+	_, err = r.Reference(plumbing.NewBranchReferenceName("main"), false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	// The renamed branch keeps tracking its upstream, which isn't renamed
+	upstream := func(branch string) plumbing.ReferenceName {
+		cfg, err := r.Config()
+		require.NoError(t, err)
+		require.Contains(t, cfg.Branches, branch)
+		return cfg.Branches[branch].Merge
+	}
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), upstream("updatecli_main"))
+
+	// Renaming to an existing branch
+	_, err = g.RenameBranch("updatecli_old", "updatecli_main", workingDir)
+	require.ErrorIs(t, err, git.ErrBranchExists)
+
+	// Renaming an unknown branch
+	_, err = g.RenameBranch("doNotExist", "updatecli_new", workingDir)
+	require.Error(t, err)
+
+	// Renaming the remote branch
+	cfg, err := r.Config()
+	require.NoError(t, err)
+	cfg.Branches["updatecli_old"] = &config.Branch{
+		Name:   "updatecli_old",
+		Remote: DefaultRemoteReferenceName,
+		Merge:  plumbing.NewBranchReferenceName("updatecli_old"),
+	}
+	require.NoError(t, r.Storer.SetConfig(cfg))
+
+	got, err = g.RenameBranch("updatecli_old", "updatecli_new", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("updatecli_old"), upstream("updatecli_new"))
+
+	require.NoError(t, g.RenameRemoteBranch("updatecli_old", got, "", "", workingDir))
+	assert.Equal(t, plumbing.NewBranchReferenceName("updatecli_new"), upstream("updatecli_new"))
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), upstream("updatecli_main"))
+
+	remote, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("updatecli_new"), false)
+	require.NoError(t, err)
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("updatecli_old"), false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
+
+func TestDeleteBranch(t *testing.T) {
+	g := GoGit{}
+
+	origin := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+	workingDir := newTestClone(t, origin)
+
+	_, err := g.NewBranch("updatecli_stale", workingDir)
+	require.NoError(t, err)
+	require.NoError(t, g.PushBranch("updatecli_stale", "", "", workingDir, false))
+
+	// Deleting the checked out branch
+	err = g.DeleteBranch("main", workingDir)
+	var checkedOutErr *ErrDeleteCheckedOutBranch
+	require.True(t, errors.As(err, &checkedOutErr), err)
+	assert.Equal(t, "main", checkedOutErr.Name)
+
+	// Deleting the local branch
+	require.NoError(t, g.DeleteBranch("updatecli_stale", workingDir))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	_, err = r.Reference(plumbing.NewBranchReferenceName("updatecli_stale"), false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	_, err = r.Reference(plumbing.NewBranchReferenceName("main"), false)
+	require.NoError(t, err)
+
+	// Deleting the remote branch
+	require.NoError(t, g.DeleteRemoteBranch("", "", "updatecli_stale", workingDir))
+
+	remote, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	_, err = remote.Reference(plumbing.NewBranchReferenceName("updatecli_stale"), false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	_, err = r.Reference(plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, "updatecli_stale"), false)
+	require.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	// Deleting branches which don't exist
+	require.NoError(t, g.DeleteBranch("updatecli_stale", workingDir))
+	require.NoError(t, g.DeleteRemoteBranch("", "", "updatecli_stale", workingDir))
+}
+
+func TestSetBranchTo(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, workingDir, "README.md", "v2")
+	second := commitTestFile(t, workingDir, "README.md", "v3")
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	branchHash := func(branch string) plumbing.Hash {
+		ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+		require.NoError(t, err)
+		return ref.Hash()
+	}
+
+	g := GoGit{}
+
+	// Create the branch
+	require.NoError(t, g.SetBranchTo("release", first.String(), workingDir, false))
+	assert.Equal(t, first, branchHash("release"))
+
+	// Fast-forward using a branch name
+	require.NoError(t, g.SetBranchTo("release", "main", workingDir, false))
+	assert.Equal(t, second, branchHash("release"))
+
+	// Moving backward requires force
+	require.Error(t, g.SetBranchTo("release", first.String(), workingDir, false))
+	assert.Equal(t, second, branchHash("release"))
+	require.NoError(t, g.SetBranchTo("release", first.String(), workingDir, true))
+	assert.Equal(t, first, branchHash("release"))
+
+	// The worktree follows the checked out branch
+	require.NoError(t, g.SetBranchTo("main", first.String(), workingDir, true))
+	assert.Equal(t, first, branchHash("main"))
+	content, err := os.ReadFile(filepath.Join(workingDir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+
+	require.Error(t, g.SetBranchTo("release", "0000000000000000000000000000000000000001", workingDir, true))
+}
+
+func TestSetBranchToUncommittedChanges(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, workingDir, "README.md", "v2")
+	second := commitTestFile(t, workingDir, "README.md", "v3")
+
+	g := GoGit{}
+	require.NoError(t, g.SetBranchTo("main", first.String(), workingDir, true))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	// Fast-forwarding the checked out branch would discard the uncommitted modification
+	writeTestFile(t, workingDir, "README.md", "local change")
+	err = g.SetBranchTo("main", second.String(), workingDir, false)
+	require.ErrorContains(t, err, "would discard uncommitted changes")
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, first, head.Hash())
+	assert.Equal(t, "local change", readTestFile(t, workingDir, "README.md"))
+
+	// The reset would also remove untracked files
+	writeTestFile(t, workingDir, "README.md", "v2")
+	writeTestFile(t, workingDir, "untracked.txt", "untracked")
+	err = g.SetBranchTo("main", second.String(), workingDir, false)
+	require.ErrorContains(t, err, "would discard uncommitted changes")
+	assert.Equal(t, "untracked", readTestFile(t, workingDir, "untracked.txt"))
+
+	// Force discards them
+	writeTestFile(t, workingDir, "README.md", "local change")
+	require.NoError(t, g.SetBranchTo("main", second.String(), workingDir, true))
+	assert.Equal(t, "v3", readTestFile(t, workingDir, "README.md"))
+}
+
+func TestCheckoutDefaultBranch(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	workingDir := filepath.Join(t.TempDir(), "clone")
+	g := GoGit{}
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	// The default branch moved forward on the remote
+	latest := commitTestFile(t, origin, "README.md", "v2")
+
+	// The existing clone is on another branch, with local changes
+	_, err := g.NewBranch("updatecli", workingDir)
+	require.NoError(t, err)
+	require.NoError(t, g.Checkout("", "", "main", "updatecli", workingDir, false))
+	writeTestFile(t, workingDir, "README.md", "local change")
+
+	branch, err := g.CheckoutDefaultBranch(DefaultRemoteReferenceName, workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), head.Name())
+	assert.Equal(t, latest, head.Hash())
+
+	content, err := os.ReadFile(filepath.Join(workingDir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+
+	// The remote default branch doesn't exist locally yet
+	o, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("trunk"), latest)))
+	require.NoError(t, o.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("trunk"))))
+
+	branch, err = g.CheckoutDefaultBranch(DefaultRemoteReferenceName, workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", branch)
+
+	head, err = r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("trunk"), head.Name())
+	assert.Equal(t, latest, head.Hash())
+
+	_, err = g.CheckoutDefaultBranch("doNotExist", workingDir)
+	require.Error(t, err)
+}
+
+func TestBranchExists(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	workingDir := newTestClone(t, origin)
+	g := GoGit{}
+
+	require.NoError(t, g.Checkout("", "", "main", "updatecli_local", workingDir, false))
+
+	tests := []struct {
+		name       string
+		branch     string
+		wantLocal  bool
+		wantRemote bool
+	}{
+		{
+			name:       "Published branch",
+			branch:     "main",
+			wantLocal:  true,
+			wantRemote: true,
+		},
+		{
+			name:      "Local branch",
+			branch:    "updatecli_local",
+			wantLocal: true,
+		},
+		{
+			name:   "Missing branch",
+			branch: "doNotExist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := g.BranchExists(tt.branch, workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLocal, exists)
+
+			exists, err = g.RemoteBranchExists(tt.branch, workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRemote, exists)
+		})
+	}
+
+	// Remote branches are looked up from RemoteName
+	exists, err := GoGit{RemoteName: "upstream"}.RemoteBranchExists("main", workingDir)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Missing repository
+	_, err = g.BranchExists("main", t.TempDir())
+	require.Error(t, err)
+	_, err = g.RemoteBranchExists("main", t.TempDir())
+	require.Error(t, err)
+}
+
+func TestRemoteBranches(t *testing.T) {
+	g := GoGit{}
+
+	origin := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+	workingDir := newTestClone(t, origin)
+
+	for _, branch := range []string{"release-1.0", "release-1.1"} {
+		_, err := g.NewBranch(branch, workingDir)
+		require.NoError(t, err)
+		require.NoError(t, g.PushBranch(branch, "", "", workingDir, false))
+	}
+
+	// Local branches without remote-tracking branch are ignored
+	_, err := g.NewBranch("local", workingDir)
+	require.NoError(t, err)
+
+	branches, err := g.RemoteBranches(workingDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"main", "release-1.0", "release-1.1"}, branches)
+
+	// A repository without git remote has no remote branches
+	branches, err = g.RemoteBranches(origin)
+	require.NoError(t, err)
+	assert.Empty(t, branches)
+}
@@ -0,0 +1,305 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+// CloneCommit retrieves the git repository located at URL then checks out, in detached HEAD,
+// the commit identified by its full hash, which may not be the tip of any branch.
+// It first tries to only fetch that commit, if the git server allows fetching by hash,
+// and falls back to a full clone otherwise.
+func (g GoGit) CloneCommit(username, password, URL, commit, workingDir string) error {
+
+	logrus.Debugf("stage: git-clone-commit\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, false)
+	if err != nil {
+		return err
+	}
+
+	if !plumbing.IsHash(commit) {
+		return fmt.Errorf("invalid commit hash %q", commit)
+	}
+	hash := plumbing.NewHash(commit)
+
+	if err := g.checkRemoteAllowed(URL); err != nil {
+		return err
+	}
+
+	r, created, err := g.fetchCommit(context.Background(), username, password, URL, hash, workingDir)
+	if err != nil {
+		logrus.Debugf("fetching commit %q failed, falling back to a full clone: %s", commit, err)
+
+		// Only remove a git repository initialized by fetchCommit
+		if created {
+			if err := os.RemoveAll(workingDir); err != nil {
+				return err
+			}
+		}
+
+		if err := g.Clone(username, password, URL, workingDir); err != nil {
+			return err
+		}
+
+		r, err = git.PlainOpen(workingDir)
+		if err != nil {
+			return err
+		}
+	} else if g.VerifyAfterClone {
+		if err := verifyRepository(r); err != nil {
+			return err
+		}
+	}
+
+	return g.checkoutHash(r, hash)
+}
+
+// fetchCommit only fetches the commit hash from URL into the git repository located in workingDir.
+// The git repository is initialized if it doesn't exist yet, in which case created is set to true
+// and the history is limited to CloneDepth commits, if set. An existing repository is never made shallow.
+func (g GoGit) fetchCommit(ctx context.Context, username, password, URL string, hash plumbing.Hash, workingDir string) (r *git.Repository, created bool, err error) {
+
+	auth, err := g.transportAuth(URL, username, password)
+	if err != nil {
+		return nil, false, err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return nil, false, err
+	}
+
+	r, err = git.PlainInit(workingDir, false)
+	switch err {
+	case nil:
+		created = true
+		_, err = r.CreateRemote(&config.RemoteConfig{
+			Name: g.remoteName(),
+			URLs: []string{URL},
+		})
+		if err != nil {
+			return nil, created, err
+		}
+	case git.ErrRepositoryAlreadyExists:
+		r, err = git.PlainOpen(workingDir)
+		if err != nil {
+			return nil, created, err
+		}
+	default:
+		return nil, created, err
+	}
+
+	remote, err := g.remote(r)
+	if err != nil {
+		return nil, created, err
+	}
+
+	b := bytes.Buffer{}
+	fetchOptions := git.FetchOptions{
+		Progress: &b,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("%s:refs/updatecli/%s", hash.String(), hash.String())),
+		},
+		InsecureSkipTLS: g.insecureSkipTLS(URL),
+		ProxyOptions:    g.proxyOptions(URL),
+		CABundle:        caBundle,
+	}
+
+	if created {
+		fetchOptions.Depth = g.CloneDepth
+	}
+
+	if auth != nil {
+		fetchOptions.Auth = auth
+	}
+
+	err = g.retryNetwork(ctx, "fetch", func() error {
+		b.Reset()
+		return remote.FetchContext(ctx, &fetchOptions)
+	})
+
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+	b.Reset()
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, created, g.progressError("fetch", err, progress)
+	}
+
+	if _, err := r.CommitObject(hash); err != nil {
+		return nil, created, fmt.Errorf("commit %q not fetched: %w", hash.String(), err)
+	}
+
+	return r, created, nil
+}
+
+// CheckoutCommit checks out, in detached HEAD, the commit identified by its full hash,
+// which may not be the tip of any branch, discarding the uncommitted changes of tracked files.
+// See GetLatestCommit to retrieve the commit hash to come back to.
+func (g GoGit) CheckoutCommit(hash, workingDir string) error {
+
+	logrus.Debugf("stage: git-checkout-commit\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	if !plumbing.IsHash(hash) {
+		return fmt.Errorf("invalid commit hash %q", hash)
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return err
+	}
+
+	return g.checkoutHash(r, plumbing.NewHash(hash))
+}
+
+// checkoutHash moves the worktree to the commit hash, in detached HEAD
+func (g GoGit) checkoutHash(r *git.Repository, hash plumbing.Hash) error {
+	if _, err := r.CommitObject(hash); err != nil {
+		return fmt.Errorf("commit %q: %w", hash.String(), err)
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	err = g.checkout(r, w, &git.CheckoutOptions{
+		Hash:  hash,
+		Force: true,
+	})
+	if err != nil {
+		return fmt.Errorf("checkout commit %q: %w", hash.String(), err)
+	}
+
+	return nil
+}
+
+const (
+	// CloneUpdateMerge updates an existing clone by merging the remote branch, like `git pull`
+	CloneUpdateMerge = "merge"
+	// CloneUpdateRebase updates an existing clone by rebasing its local commits on top of the remote branch,
+	// like `git pull --rebase`
+	CloneUpdateRebase = "rebase"
+	// CloneUpdateReset updates an existing clone by resetting it to the remote branch,
+	// discarding its local commits and changes, like `git reset --hard`
+	CloneUpdateReset = "reset"
+)
+
+// updateClone updates the checked out branch of the existing clone r from its remote branch,
+// according to CloneUpdateStrategy. It returns git.NoErrAlreadyUpToDate if there was nothing to update.
+func (g GoGit) updateClone(ctx context.Context, r *git.Repository, w *git.Worktree, pullOptions *git.PullOptions) error {
+	strategy := g.CloneUpdateStrategy
+	if strategy == "" {
+		strategy = CloneUpdateMerge
+	}
+
+	switch strategy {
+	case CloneUpdateMerge:
+		if g.CloneDepth > 0 {
+			return g.pullShallow(ctx, r, w, pullOptions)
+		}
+		return g.pull(ctx, r, w, pullOptions)
+	case CloneUpdateRebase, CloneUpdateReset:
+	default:
+		return fmt.Errorf("unsupported clone update strategy %q, accepted values are %q, %q, and %q",
+			strategy, CloneUpdateMerge, CloneUpdateRebase, CloneUpdateReset)
+	}
+
+	head, remoteRef, err := fetchHeadBranch(ctx, r, pullOptions)
+	if err != nil {
+		return err
+	}
+
+	if remoteRef.Hash() == head.Hash() {
+		return git.NoErrAlreadyUpToDate
+	}
+
+	if strategy == CloneUpdateRebase {
+		logrus.Debugf("rebasing branch %q on top of %q", head.Name().Short(), remoteRef.Name().Short())
+		return g.rebaseOnto(r, remoteRef.Hash())
+	}
+
+	logrus.Debugf("resetting branch %q to %q", head.Name().Short(), remoteRef.Name().Short())
+
+	if err := r.Storer.SetReference(plumbing.NewHashReference(head.Name(), remoteRef.Hash())); err != nil {
+		return err
+	}
+
+	return g.resetWorktree(r, w, remoteRef.Hash(), git.HardReset)
+}
+
+// fetchHeadBranch fetches, using pullOptions, the remote branch of the branch checked out in r,
+// then returns the HEAD and remote branch references
+func fetchHeadBranch(ctx context.Context, r *git.Repository, pullOptions *git.PullOptions) (head, remoteRef *plumbing.Reference, err error) {
+	head, err = r.Head()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !head.Name().IsBranch() {
+		return nil, nil, fmt.Errorf("not pulling from a branch")
+	}
+
+	remoteRefName := plumbing.NewRemoteReferenceName(pullOptions.RemoteName, head.Name().Short())
+
+	err = r.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: pullOptions.RemoteName,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+%s:%s", head.Name(), remoteRefName)),
+		},
+		Depth:           pullOptions.Depth,
+		Auth:            pullOptions.Auth,
+		Progress:        pullOptions.Progress,
+		InsecureSkipTLS: pullOptions.InsecureSkipTLS,
+		ProxyOptions:    pullOptions.ProxyOptions,
+		CABundle:        pullOptions.CABundle,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, nil, err
+	}
+
+	remoteRef, err = r.Reference(remoteRefName, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return head, remoteRef, nil
+}
+
+// pullShallow updates the checked out branch of the shallow clone r to its remote branch tip, as Pull does.
+// go-git can't pull into shallow clones, as checking for a fast-forward requires the full history,
+// so the branch is reset to the fetched remote branch tip instead.
+func (g GoGit) pullShallow(ctx context.Context, r *git.Repository, w *git.Worktree, pullOptions *git.PullOptions) error {
+	head, remoteRef, err := fetchHeadBranch(ctx, r, pullOptions)
+	if err != nil {
+		return err
+	}
+
+	if remoteRef.Hash() == head.Hash() {
+		return git.NoErrAlreadyUpToDate
+	}
+
+	if err := r.Storer.SetReference(plumbing.NewHashReference(head.Name(), remoteRef.Hash())); err != nil {
+		return err
+	}
+
+	return g.resetWorktree(r, w, remoteRef.Hash(), git.MergeReset)
+}
@@ -0,0 +1,364 @@
+package gitgeneric
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneCommit(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+	commitTestFile(t, origin, "README.md", "v3")
+
+	tests := []struct {
+		name    string
+		commit  string
+		wantErr bool
+	}{
+		{
+			name:   "Commit which is not a branch tip",
+			commit: first.String(),
+		},
+		{
+			name:    "Invalid commit hash",
+			commit:  "main",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown commit hash",
+			commit:  "0000000000000000000000000000000000000001",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := filepath.Join(t.TempDir(), "clone")
+
+			err := GoGit{}.CloneCommit("", "", origin, tt.commit, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.False(t, head.Name().IsBranch(), "HEAD should be detached")
+			assert.Equal(t, tt.commit, head.Hash().String())
+		})
+	}
+}
+
+func TestCloneCommitExistingRepository(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+
+	workingDir := newTestClone(t, origin)
+	commitTestFile(t, origin, "README.md", "v3")
+
+	require.NoError(t, GoGit{}.CloneCommit("", "", origin, first.String(), workingDir))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, first.String(), head.Hash().String())
+}
+
+func TestCloneCommitDepth(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary required to run a git server fetching commits by hash")
+	}
+
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+	commitTestFile(t, origin, "README.md", "v3")
+
+	// Allows fetchCommit to only fetch the commit, instead of falling back to a full clone
+	cmd := exec.Command("git", "config", "uploadpack.allowReachableSHA1InWant", "true")
+	cmd.Dir = origin
+	require.NoError(t, cmd.Run())
+
+	isShallow := func(workingDir string) bool {
+		r, err := git.PlainOpen(workingDir)
+		require.NoError(t, err)
+		head, err := r.Head()
+		require.NoError(t, err)
+		assert.Equal(t, first, head.Hash())
+
+		shallows, err := r.Storer.Shallow()
+		require.NoError(t, err)
+		return len(shallows) > 0
+	}
+
+	t.Run("New repository", func(t *testing.T) {
+		workingDir := filepath.Join(t.TempDir(), "clone")
+		require.NoError(t, GoGit{}.CloneCommit("", "", origin, first.String(), workingDir))
+		assert.False(t, isShallow(workingDir))
+	})
+
+	t.Run("New shallow repository", func(t *testing.T) {
+		workingDir := filepath.Join(t.TempDir(), "clone")
+		require.NoError(t, GoGit{CloneDepth: 1}.CloneCommit("", "", origin, first.String(), workingDir))
+		assert.True(t, isShallow(workingDir))
+	})
+
+	t.Run("Existing repository isn't made shallow", func(t *testing.T) {
+		workingDir := newTestClone(t, origin)
+		require.NoError(t, GoGit{CloneDepth: 1}.CloneCommit("", "", origin, first.String(), workingDir))
+		assert.False(t, isShallow(workingDir))
+	})
+}
+
+func TestCloneDepth(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	commitTestFile(t, origin, "README.md", "v2")
+	commitTestFile(t, origin, "README.md", "v3")
+
+	tests := []struct {
+		name               string
+		depth              int
+		wantCommits        int
+		wantUpdatedCommits int
+		wantShallow        bool
+	}{
+		{
+			name:               "Full clone",
+			wantCommits:        3,
+			wantUpdatedCommits: 4,
+		},
+		{
+			name:               "Shallow clone",
+			depth:              1,
+			wantCommits:        1,
+			wantUpdatedCommits: 1,
+			wantShallow:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := filepath.Join(t.TempDir(), "clone")
+			g := GoGit{CloneDepth: tt.depth}
+
+			require.NoError(t, g.Clone("", "", origin, workingDir))
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+
+			shallow, err := r.Storer.Shallow()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantShallow, len(shallow) > 0)
+
+			count, err := g.CommitCount("HEAD", workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommits, count)
+
+			// Updating the existing clone keeps it shallow
+			latest := commitTestFile(t, origin, "README.md", tt.name)
+			require.NoError(t, g.Clone("", "", origin, workingDir))
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, latest, head.Hash())
+
+			count, err = g.CommitCount("HEAD", workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUpdatedCommits, count)
+		})
+	}
+}
+
+func TestCloneUpdateStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategy   string
+		wantHead   string
+		wantLocal  bool
+		wantErr    bool
+		wantRemote bool
+	}{
+		{
+			name:       "Rebase local commits on top of the remote branch",
+			strategy:   CloneUpdateRebase,
+			wantHead:   "local",
+			wantLocal:  true,
+			wantRemote: true,
+		},
+		{
+			name:       "Reset to the remote branch",
+			strategy:   CloneUpdateReset,
+			wantHead:   "remote",
+			wantRemote: true,
+		},
+		{
+			name:     "Unsupported strategy",
+			strategy: "squash",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			workingDir := newTestClone(t, origin)
+			g := GoGit{CloneUpdateStrategy: tt.strategy}
+
+			if !tt.wantErr {
+				// Nothing to update
+				require.NoError(t, g.Clone("", "", origin, workingDir))
+			}
+
+			writeTestFile(t, origin, "CHANGELOG.md", "remote")
+			require.NoError(t, g.Add([]string{"CHANGELOG.md"}, origin))
+			_, err := g.Commit("updatecli", "updatecli@updatecli.io", "remote", origin, "", "")
+			require.NoError(t, err)
+
+			writeTestFile(t, workingDir, "LOCAL.md", "local")
+			require.NoError(t, g.Add([]string{"LOCAL.md"}, workingDir))
+			_, err = g.Commit("updatecli", "updatecli@updatecli.io", "local", workingDir, "", "")
+			require.NoError(t, err)
+
+			err = g.Clone("", "", origin, workingDir)
+			if tt.wantErr {
+				require.ErrorContains(t, err, `unsupported clone update strategy "squash"`)
+				return
+			}
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			head, err := r.Head()
+			require.NoError(t, err)
+			commit, err := r.CommitObject(head.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHead+"\n", commit.Message)
+
+			_, err = os.Stat(filepath.Join(workingDir, "LOCAL.md"))
+			assert.Equal(t, tt.wantLocal, err == nil, "local commit file")
+			_, err = os.Stat(filepath.Join(workingDir, "CHANGELOG.md"))
+			assert.Equal(t, tt.wantRemote, err == nil, "remote commit file")
+		})
+	}
+}
+
+func TestCloneSingleBranch(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+	latest := commitTestFile(t, origin, "README.md", "v3")
+
+	o, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	feature := plumbing.NewBranchReferenceName("feature")
+	require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(feature, first)))
+	require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("other"), latest)))
+
+	workingDir := filepath.Join(t.TempDir(), "clone")
+	g := GoGit{CloneSingleBranch: "feature"}
+
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	assertSingleBranch := func(want plumbing.Hash) {
+		t.Helper()
+
+		head, err := r.Head()
+		require.NoError(t, err)
+		assert.Equal(t, feature, head.Name())
+		assert.Equal(t, want, head.Hash())
+
+		for _, name := range []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName("main"),
+			plumbing.NewBranchReferenceName("other"),
+			plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, "main"),
+			plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, "other"),
+		} {
+			_, err := r.Reference(name, false)
+			assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound, name.String())
+		}
+	}
+
+	assertSingleBranch(first)
+
+	// Updating the existing clone only fetches the same branch
+	require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(feature, latest)))
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	assertSingleBranch(latest)
+}
+
+func TestCheckoutCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		commit  func(first, unreachable plumbing.Hash) string
+		wantErr string
+	}{
+		{
+			name:   "Commit which is not a branch tip",
+			commit: func(first, _ plumbing.Hash) string { return first.String() },
+		},
+		{
+			name:   "Commit which isn't part of any branch",
+			commit: func(_, unreachable plumbing.Hash) string { return unreachable.String() },
+		},
+		{
+			name:    "Invalid commit hash",
+			commit:  func(_, _ plumbing.Hash) string { return "main" },
+			wantErr: `invalid commit hash "main"`,
+		},
+		{
+			name:    "Unknown commit hash",
+			commit:  func(_, _ plumbing.Hash) string { return "0000000000000000000000000000000000000001" },
+			wantErr: `commit "0000000000000000000000000000000000000001": object not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			first := commitTestFile(t, workingDir, "README.md", "v2")
+			unreachable := commitTestFile(t, workingDir, "README.md", "v3")
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			mainRef := plumbing.NewBranchReferenceName("main")
+			require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(mainRef, first)))
+			latest := commitTestFile(t, workingDir, "README.md", "v4")
+
+			commit := tt.commit(first, unreachable)
+			err = GoGit{}.CheckoutCommit(commit, workingDir)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+
+				head, err := r.Head()
+				require.NoError(t, err)
+				assert.Equal(t, mainRef, head.Name())
+				assert.Equal(t, latest, head.Hash())
+				return
+			}
+			require.NoError(t, err)
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.False(t, head.Name().IsBranch(), "HEAD should be detached")
+			assert.Equal(t, commit, head.Hash().String())
+
+			latestCommit, err := GoGit{}.GetLatestCommit(workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, commit, latestCommit.String())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/sirupsen/logrus"
+)
+
+// cloneCacheLocks holds the mutex of every clone cache mirror, indexed by mirror directory,
+// so pipelines run concurrently don't update, or clone from, the same mirror at once
+var cloneCacheLocks sync.Map
+
+// DefaultCloneCacheDir defines the clone cache directory used by every GoGit not defining CloneCacheDir,
+// such as the one returned by UserCloneCacheDir. Default to empty which disables the clone cache.
+var DefaultCloneCacheDir string
+
+// UserCloneCacheDir returns the clone cache directory located in the user cache directory,
+// such as "$XDG_CACHE_HOME/updatecli/git" on Linux.
+func UserCloneCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "updatecli", "git"), nil
+}
+
+// cloneCacheDir returns the clone cache directory, or an empty string if the clone cache is disabled
+func (g GoGit) cloneCacheDir() string {
+	if g.CloneCacheDir != "" {
+		return g.CloneCacheDir
+	}
+	return DefaultCloneCacheDir
+}
+
+// cloneCacheMirror returns the directory of the bare mirror of the git repository URL, in the clone cache cacheDir.
+// Credentials are ignored so the mirror is shared by every user of the git repository.
+func cloneCacheMirror(cacheDir, URL string) string {
+	key := URL
+	if u, err := url.Parse(URL); err == nil && u.User != nil {
+		u.User = nil
+		key = u.String()
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:]))
+}
+
+// lockCloneCacheMirror locks the clone cache mirror directory, then returns the function unlocking it
+func lockCloneCacheMirror(mirror string) (unlock func()) {
+	lock, _ := cloneCacheLocks.LoadOrStore(mirror, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// cloneCacheRemote returns the git remote URL saved in the mirror config, without password,
+// and the authentication of auth, or of the URL password if auth isn't defined, used to update the mirror
+func cloneCacheRemote(URL string, auth transport.AuthMethod) (string, transport.AuthMethod) {
+	u, err := url.Parse(URL)
+	if err != nil || u.User == nil {
+		return URL, auth
+	}
+
+	password, hasPassword := u.User.Password()
+	if !hasPassword {
+		return URL, auth
+	}
+
+	if auth == nil {
+		auth = &transportHttp.BasicAuth{
+			Username: u.User.Username(),
+			Password: password,
+		}
+	}
+
+	// The username is kept as ssh remotes need it
+	u.User = url.User(u.User.Username())
+	if u.User.Username() == "" {
+		u.User = nil
+	}
+
+	return u.String(), auth
+}
+
+/*
+cloneFromCache clones the git repository defined by cloneOptions into workingDir from its bare mirror
+in the clone cache cacheDir, mirroring it first or updating it from the git remote,
+then configures the git remote of workingDir back to the git repository URL.
+It returns git.ErrRepositoryAlreadyExists, without updating the mirror, if workingDir is already cloned.
+*/
+func (g GoGit) cloneFromCache(ctx context.Context, cacheDir, workingDir string, cloneOptions git.CloneOptions, password string) (*git.Repository, error) {
+	if _, err := git.PlainOpen(workingDir); err == nil {
+		return nil, git.ErrRepositoryAlreadyExists
+	}
+
+	unlock := lockCloneCacheMirror(cloneCacheMirror(cacheDir, cloneOptions.URL))
+	defer unlock()
+
+	mirror, err := g.updateCloneCacheMirror(ctx, cacheDir, cloneOptions, password)
+	if err != nil {
+		return nil, fmt.Errorf("update clone cache %q: %w", cacheDir, err)
+	}
+
+	localOptions := cloneOptions
+	localOptions.URL = mirror
+	localOptions.Auth = nil
+	localOptions.InsecureSkipTLS = false
+	localOptions.ProxyOptions = transport.ProxyOptions{}
+	localOptions.CABundle = nil
+
+	var b bytes.Buffer
+	localOptions.Progress = &b
+	b.WriteString(fmt.Sprintf("cloning git repository: %s in %s from clone cache %s\n",
+		redactCredentials(cloneOptions.URL), workingDir, mirror))
+
+	r, err := git.PlainCloneContext(ctx, workingDir, false, &localOptions)
+	g.writeProgress(b.String())
+	if err != nil {
+		return nil, g.progressError("clone", err, b.String())
+	}
+
+	cfg, err := r.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Remotes[g.remoteName()].URLs = []string{cloneOptions.URL}
+	if err := r.SetConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// updateCloneCacheMirror mirrors the git repository defined by cloneOptions in the clone cache cacheDir,
+// or updates its mirror if it already exists, then returns the mirror directory.
+// The mirror must be locked by lockCloneCacheMirror, and its git remote URL never contains the password.
+func (g GoGit) updateCloneCacheMirror(ctx context.Context, cacheDir string, cloneOptions git.CloneOptions, password string) (string, error) {
+	mirror := cloneCacheMirror(cacheDir, cloneOptions.URL)
+	remoteURL, auth := cloneCacheRemote(cloneOptions.URL, cloneOptions.Auth)
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+
+	r, err := git.PlainOpen(mirror)
+	switch err {
+	case nil:
+		b.WriteString(fmt.Sprintf("updating clone cache mirror %s\n", mirror))
+
+		fetchOptions := git.FetchOptions{
+			RemoteName:      git.DefaultRemoteName,
+			Progress:        &b,
+			RefSpecs:        []config.RefSpec{"+refs/*:refs/*"},
+			Force:           true,
+			Auth:            auth,
+			InsecureSkipTLS: cloneOptions.InsecureSkipTLS,
+			ProxyOptions:    cloneOptions.ProxyOptions,
+			CABundle:        cloneOptions.CABundle,
+		}
+
+		err = g.retryNetwork(ctx, "fetch", func() error {
+			return r.FetchContext(ctx, &fetchOptions)
+		})
+		if err == git.NoErrAlreadyUpToDate {
+			err = nil
+		}
+
+		// Remove the references deleted from the git remote
+		if err == nil {
+			var remote *git.Remote
+			remote, err = r.Remote(git.DefaultRemoteName)
+			if err == nil {
+				err = g.pruneRemote(r, remote, auth, cloneOptions.CABundle)
+			}
+		}
+
+	case git.ErrRepositoryNotExists:
+		b.WriteString(fmt.Sprintf("creating clone cache mirror %s\n", mirror))
+
+		mirrorOptions := git.CloneOptions{
+			URL:             cloneOptions.URL,
+			Mirror:          true,
+			Progress:        &b,
+			Auth:            cloneOptions.Auth,
+			InsecureSkipTLS: cloneOptions.InsecureSkipTLS,
+			ProxyOptions:    cloneOptions.ProxyOptions,
+			CABundle:        cloneOptions.CABundle,
+		}
+
+		err = g.retryNetwork(ctx, "clone", func() (err error) {
+			r, err = git.PlainCloneContext(ctx, mirror, true, &mirrorOptions)
+			return err
+		})
+
+	default:
+		return "", err
+	}
+
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+
+	if err != nil {
+		return "", g.progressError("mirror", err, progress)
+	}
+
+	if err := setCloneCacheRemoteURL(r, remoteURL); err != nil {
+		return "", err
+	}
+
+	logrus.Debugf("git repository %q mirrored in clone cache %q", redactCredentials(cloneOptions.URL), mirror)
+
+	return mirror, nil
+}
+
+// setCloneCacheRemoteURL sets the git remote URL of the mirror r, such as the one returned by cloneCacheRemote,
+// also removing the password saved by mirrors created before
+func setCloneCacheRemoteURL(r *git.Repository, URL string) error {
+	cfg, err := r.Config()
+	if err != nil {
+		return err
+	}
+
+	remote, ok := cfg.Remotes[git.DefaultRemoteName]
+	if !ok || (len(remote.URLs) == 1 && remote.URLs[0] == URL) {
+		return nil
+	}
+
+	remote.URLs = []string{URL}
+
+	return r.SetConfig(cfg)
+}
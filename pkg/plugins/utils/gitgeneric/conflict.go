@@ -0,0 +1,207 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ConflictSideOurs resolves a conflict using the version from the current branch
+	ConflictSideOurs = "ours"
+	// ConflictSideTheirs resolves a conflict using the version from the merged branch
+	ConflictSideTheirs = "theirs"
+)
+
+// ConflictedFiles returns the list of files left unmerged, after a failed merge or pull,
+// ordered by path. An empty list means that there is no conflict to resolve.
+func (g GoGit) ConflictedFiles(workingDir string) ([]string, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return []string{}, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return []string{}, err
+	}
+
+	idx, err := r.Storer.Index()
+	if err != nil {
+		return []string{}, err
+	}
+
+	return conflictedFiles(idx), nil
+}
+
+// conflictedFiles returns the paths having at least one index entry
+// in a conflict stage (base, ours, or theirs).
+func conflictedFiles(idx *index.Index) []string {
+	found := map[string]bool{}
+	files := []string{}
+
+	for _, entry := range idx.Entries {
+		// Fully merged entries are at stage 0.
+		// index.Merged can't be used as go-git defines it with the same value as index.AncestorMode
+		if entry.Stage == 0 || found[entry.Name] {
+			continue
+		}
+		found[entry.Name] = true
+		files = append(files, entry.Name)
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+// ResolveConflict resolves the conflict on the file path by taking
+// either "ours" or "theirs" version of the file, then stages it.
+// If the chosen side deleted the file, the file deletion is staged.
+func (g GoGit) ResolveConflict(path, side, workingDir string) error {
+
+	logrus.Debugf("stage: git-resolve-conflict\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	var stage index.Stage
+	switch side {
+	case ConflictSideOurs:
+		stage = index.OurMode
+	case ConflictSideTheirs:
+		stage = index.TheirMode
+	default:
+		return fmt.Errorf("unknown conflict side %q, accepted values are %q or %q",
+			side, ConflictSideOurs, ConflictSideTheirs)
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	idx, err := r.Storer.Index()
+	if err != nil {
+		return err
+	}
+
+	conflicted := false
+	var chosen *index.Entry
+	entries := []*index.Entry{}
+	for _, entry := range idx.Entries {
+		if entry.Name != path {
+			entries = append(entries, entry)
+			continue
+		}
+		// Fully merged entries are at stage 0, cfr conflictedFiles
+		if entry.Stage != 0 {
+			conflicted = true
+		}
+		if entry.Stage == stage {
+			chosen = entry
+		}
+	}
+
+	if !conflicted {
+		return fmt.Errorf("file %q is not in conflict", path)
+	}
+
+	logrus.Debugf("resolving conflict on %q using %q version", path, side)
+
+	filePath := filepath.Join(workingDir, filepath.FromSlash(path))
+	if chosen != nil {
+		if err := writeBlob(r, chosen.Hash, chosen.Mode, filePath); err != nil {
+			return err
+		}
+	} else if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Remove the conflict entries before staging the resolved file
+	idx.Entries = entries
+	if err := r.Storer.SetIndex(idx); err != nil {
+		return err
+	}
+
+	if chosen == nil {
+		return nil
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Add(path)
+	return err
+}
+
+// writeBlob writes the content of the blob identified by hash to filePath, using the file mode of its tree entry:
+// a symbolic link is created to the blob content, and an executable file is made executable
+func writeBlob(r *git.Repository, hash plumbing.Hash, mode filemode.FileMode, filePath string) error {
+	blob, err := r.BlobObject(hash)
+	if err != nil {
+		return err
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	// A symbolic link is replaced instead of writing to its target
+	if info, err := os.Lstat(filePath); err == nil && (mode == filemode.Symlink || info.Mode()&os.ModeSymlink != 0) {
+		if err := os.Remove(filePath); err != nil {
+			return err
+		}
+	}
+
+	if mode == filemode.Symlink {
+		target, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(string(target), filePath)
+	}
+
+	perm := os.FileMode(0644)
+	if mode == filemode.Executable {
+		perm = 0755
+	}
+
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// OpenFile doesn't update the permissions of an existing file
+	return os.Chmod(filePath, perm)
+}
@@ -0,0 +1,169 @@
+package gitgeneric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConflict updates the repository index so the file conflicts
+// between the "ours" and "theirs" content, as a failed merge would do.
+func newTestConflict(t *testing.T, workingDir, file, ours, theirs string) {
+	t.Helper()
+
+	newTestConflictWithModes(t, workingDir, file, ours, filemode.Regular, theirs, filemode.Regular)
+}
+
+// newTestConflictWithModes is newTestConflict, using a specific file mode for each side
+func newTestConflictWithModes(t *testing.T, workingDir, file, ours string, oursMode filemode.FileMode, theirs string, theirsMode filemode.FileMode) {
+	t.Helper()
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	idx, err := r.Storer.Index()
+	require.NoError(t, err)
+
+	blob := func(content string) plumbing.Hash {
+		obj := r.Storer.NewEncodedObject()
+		obj.SetType(plumbing.BlobObject)
+		w, err := obj.Writer()
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		h, err := r.Storer.SetEncodedObject(obj)
+		require.NoError(t, err)
+		return h
+	}
+
+	entries := []*index.Entry{}
+	for _, e := range idx.Entries {
+		if e.Name != file {
+			entries = append(entries, e)
+		}
+	}
+	entries = append(entries,
+		&index.Entry{Name: file, Hash: blob(ours), Mode: oursMode, Stage: index.OurMode},
+		&index.Entry{Name: file, Hash: blob(theirs), Mode: theirsMode, Stage: index.TheirMode},
+	)
+	idx.Entries = entries
+
+	require.NoError(t, r.Storer.SetIndex(idx))
+	writeTestFile(t, workingDir, file, "<<<<<<< ours\n"+ours+"=======\n"+theirs+">>>>>>> theirs\n")
+}
+
+func TestConflictedFiles(t *testing.T) {
+	g := GoGit{}
+
+	workingDir := newTestRepository(t, map[string]string{
+		"README.md":  "updatecli",
+		"values.yml": "version: 1",
+		"go.sum":     "sum",
+	})
+
+	got, err := g.ConflictedFiles(workingDir)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	newTestConflict(t, workingDir, "values.yml", "version: 2\n", "version: 3\n")
+	newTestConflict(t, workingDir, "go.sum", "ours\n", "theirs\n")
+
+	got, err = g.ConflictedFiles(workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.sum", "values.yml"}, got)
+}
+
+func TestResolveConflict(t *testing.T) {
+	tests := []struct {
+		name            string
+		side            string
+		expectedContent string
+		wantErr         bool
+	}{
+		{
+			name:            "Take ours",
+			side:            ConflictSideOurs,
+			expectedContent: "version: 2\n",
+		},
+		{
+			name:            "Take theirs",
+			side:            ConflictSideTheirs,
+			expectedContent: "version: 3\n",
+		},
+		{
+			name:    "Unknown side",
+			side:    "both",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := GoGit{}
+			workingDir := newTestRepository(t, map[string]string{
+				"README.md":  "updatecli",
+				"values.yml": "version: 1\n",
+			})
+			newTestConflict(t, workingDir, "values.yml", "version: 2\n", "version: 3\n")
+
+			err := g.ResolveConflict("values.yml", tt.side, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			conflicts, err := g.ConflictedFiles(workingDir)
+			require.NoError(t, err)
+			assert.Empty(t, conflicts)
+
+			content, err := os.ReadFile(filepath.Join(workingDir, "values.yml"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, string(content))
+
+			_, err = g.Commit("updatecli", "updatecli@updatecli.io", "resolve conflict", workingDir, "", "")
+			require.NoError(t, err)
+			committed, err := ReadFileFromRevision(workingDir, "HEAD", "values.yml")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, string(committed))
+		})
+	}
+
+	t.Run("File not in conflict", func(t *testing.T) {
+		workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+		require.Error(t, GoGit{}.ResolveConflict("README.md", ConflictSideOurs, workingDir))
+	})
+}
+
+func TestResolveConflictFileMode(t *testing.T) {
+	t.Run("Executable file", func(t *testing.T) {
+		workingDir := newTestRepository(t, map[string]string{"build.sh": "#!/bin/sh\n"})
+		newTestConflictWithModes(t, workingDir, "build.sh", "#!/bin/sh\nmake\n", filemode.Executable, "#!/bin/sh\n", filemode.Regular)
+
+		require.NoError(t, GoGit{}.ResolveConflict("build.sh", ConflictSideOurs, workingDir))
+
+		info, err := os.Lstat(filepath.Join(workingDir, "build.sh"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+		assert.Equal(t, "#!/bin/sh\nmake\n", readTestFile(t, workingDir, "build.sh"))
+	})
+
+	t.Run("Symbolic link", func(t *testing.T) {
+		workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli", "latest": "v1"})
+		newTestConflictWithModes(t, workingDir, "latest", "v2", filemode.Regular, "README.md", filemode.Symlink)
+
+		require.NoError(t, GoGit{}.ResolveConflict("latest", ConflictSideTheirs, workingDir))
+
+		target, err := os.Readlink(filepath.Join(workingDir, "latest"))
+		require.NoError(t, err)
+		assert.Equal(t, "README.md", target)
+	})
+}
@@ -0,0 +1,130 @@
+package gitgeneric
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// GitUsernameEnvVariable defines the environment variable holding the username
+	// used with http git remotes called without credentials
+	GitUsernameEnvVariable = "GIT_USERNAME"
+	// GitPasswordEnvVariable defines the environment variable holding the password
+	// used with http git remotes called without credentials
+	GitPasswordEnvVariable = "GIT_PASSWORD"
+	// GitHubTokenEnvVariable defines the environment variable holding the GitHub token
+	// used with GitHub http git remotes called without credentials
+	GitHubTokenEnvVariable = "GITHUB_TOKEN"
+	// gitHubTokenUsername is the basic authentication username sent with GitHub tokens,
+	// GitHub accepting any non-empty one
+	gitHubTokenUsername = "x-access-token"
+)
+
+/*
+fallbackCredentials returns the username and password to authenticate with the http git remote endpoint,
+when the caller didn't provide any. The first source defining credentials wins, in this order:
+
+ 1. the GIT_USERNAME and GIT_PASSWORD environment variables, GIT_PASSWORD being required
+ 2. the GITHUB_TOKEN environment variable, only sent to github.com, or to the GitHub host
+    defined by GITHUB_SERVER_URL such as on GitHub Enterprise Server runners
+ 3. the git credential helpers, using `git credential fill`, only if UseCredentialHelper is set
+
+Empty credentials are returned when none of them defines any.
+*/
+func (g GoGit) fallbackCredentials(endpoint *transport.Endpoint) (username, password string) {
+	if password := os.Getenv(GitPasswordEnvVariable); password != "" {
+		logrus.Debugf("authenticating with git host %q using the %s and %s environment variables",
+			endpoint.Host, GitUsernameEnvVariable, GitPasswordEnvVariable)
+		return os.Getenv(GitUsernameEnvVariable), password
+	}
+
+	if token := os.Getenv(GitHubTokenEnvVariable); token != "" && isGitHubHost(endpoint.Host) {
+		logrus.Debugf("authenticating with git host %q using the %s environment variable",
+			endpoint.Host, GitHubTokenEnvVariable)
+		return gitHubTokenUsername, token
+	}
+
+	if g.UseCredentialHelper {
+		username, password, err := credentialHelperFill(endpoint)
+		if err != nil {
+			logrus.Debugf("git credential helper: %s", err)
+			return "", ""
+		}
+		if password != "" {
+			logrus.Debugf("authenticating with git host %q using the git credential helper", endpoint.Host)
+			return username, password
+		}
+	}
+
+	logrus.Debugf("no credentials found to authenticate with git host %q", endpoint.Host)
+
+	return "", ""
+}
+
+// isGitHubHost reports if host is github.com or the GitHub host defined by GITHUB_SERVER_URL
+func isGitHubHost(host string) bool {
+	if strings.EqualFold(host, "github.com") {
+		return true
+	}
+
+	serverURL, err := url.Parse(os.Getenv("GITHUB_SERVER_URL"))
+	if err != nil || serverURL.Hostname() == "" {
+		return false
+	}
+
+	return strings.EqualFold(host, serverURL.Hostname())
+}
+
+// credentialHelperFill asks the git credential helpers for the username and password
+// of the http git remote endpoint, like `git credential fill`, without ever prompting
+func credentialHelperFill(endpoint *transport.Endpoint) (username, password string, err error) {
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		return "", "", err
+	}
+
+	host := endpoint.Host
+	if endpoint.Port != 0 {
+		host = fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+	}
+
+	input := fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n\n",
+		endpoint.Protocol, host, strings.TrimPrefix(endpoint.Path, "/"))
+
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	cmd := exec.Command(gitBinary, "credential", "fill")
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Fail instead of prompting for the missing credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "username":
+			username = value
+		case "password":
+			password = value
+		}
+	}
+
+	return username, password, scanner.Err()
+}
@@ -0,0 +1,116 @@
+package gitgeneric
+
+import (
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FileAdded is the status of a file which only exists in the diff target reference
+	FileAdded = "added"
+	// FileModified is the status of a file whose content or mode differs between the diff references
+	FileModified = "modified"
+	// FileDeleted is the status of a file which only exists in the diff source reference
+	FileDeleted = "deleted"
+)
+
+// FileChange describes a file changed between two git references
+type FileChange struct {
+	// Path is the file path relative to the repository root
+	Path string
+	// Status is one of FileAdded, FileModified, or FileDeleted
+	Status string
+}
+
+/*
+Diff run `git diff --name-status from to`, then returns, sorted by path, the files changed between
+the commits pointed by from and to, any git reference such as a branch, a tag, or a commit hash.
+
+No file is returned if from and to point to the same commit.
+An ErrUnknownRevision error is returned if either reference can't be resolved.
+Renamed files are reported as a deleted file and an added one.
+*/
+func (g GoGit) Diff(from, to, workingDir string) ([]FileChange, error) {
+
+	logrus.Debugf("stage: git-diff\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return nil, err
+	}
+
+	fromCommit, err := revisionCommit(r, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toCommit, err := revisionCommit(r, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromCommit.Hash == toCommit.Hash {
+		return []FileChange{}, nil
+	}
+
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []FileChange{}
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+
+		switch action {
+		case merkletrie.Insert:
+			files = append(files, FileChange{Path: change.To.Name, Status: FileAdded})
+		case merkletrie.Delete:
+			files = append(files, FileChange{Path: change.From.Name, Status: FileDeleted})
+		case merkletrie.Modify:
+			files = append(files, FileChange{Path: change.To.Name, Status: FileModified})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	logrus.Debugf("%d files changed between %q and %q", len(files), from, to)
+
+	return files, nil
+}
+
+// revisionCommit returns the commit pointed by ref, resolved like every other git reference by resolveRevision,
+// or an ErrUnknownRevision error if ref can't be resolved
+func revisionCommit(r *git.Repository, ref string) (*object.Commit, error) {
+	hash, err := resolveRevision(r, ref)
+	if err != nil {
+		return nil, &ErrUnknownRevision{Revision: ref, Err: err}
+	}
+
+	return r.CommitObject(hash)
+}
@@ -0,0 +1,105 @@
+package gitgeneric
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{
+		"README.md":     "v1",
+		"deleted.txt":   "deleted",
+		"renamed.txt":   "renamed",
+		"unchanged.txt": "unchanged",
+	})
+	workingDir := newTestClone(t, origin)
+	g := GoGit{}
+
+	base, err := g.GetLatestCommit(workingDir)
+	require.NoError(t, err)
+	require.NoError(t, g.Checkout("", "", "main", "updatecli_diff", workingDir, false))
+
+	writeTestFile(t, workingDir, "README.md", "v2")
+	writeTestFile(t, workingDir, "docs/added.md", "added")
+	require.NoError(t, os.Remove(filepath.Join(workingDir, "deleted.txt")))
+	require.NoError(t, os.Rename(filepath.Join(workingDir, "renamed.txt"), filepath.Join(workingDir, "moved.txt")))
+	require.NoError(t, g.AddAll(workingDir))
+	_, err = g.Commit("updatecli", "updatecli@updatecli.io", "update", workingDir, "", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		want        []FileChange
+		wantUnknown string
+	}{
+		{
+			name: "Changes between branches",
+			from: "main",
+			to:   "updatecli_diff",
+			want: []FileChange{
+				{Path: "README.md", Status: FileModified},
+				{Path: "deleted.txt", Status: FileDeleted},
+				{Path: "docs/added.md", Status: FileAdded},
+				{Path: "moved.txt", Status: FileAdded},
+				{Path: "renamed.txt", Status: FileDeleted},
+			},
+		},
+		{
+			name: "Reversed references",
+			from: "HEAD",
+			to:   base.String(),
+			want: []FileChange{
+				{Path: "README.md", Status: FileModified},
+				{Path: "deleted.txt", Status: FileAdded},
+				{Path: "docs/added.md", Status: FileDeleted},
+				{Path: "moved.txt", Status: FileDeleted},
+				{Path: "renamed.txt", Status: FileAdded},
+			},
+		},
+		{
+			name: "Remote branch and relative reference",
+			from: "origin/main",
+			to:   "HEAD~1",
+			want: []FileChange{},
+		},
+		{
+			name: "Identical references",
+			from: "main",
+			to:   base.String(),
+			want: []FileChange{},
+		},
+		{
+			name:        "Unknown source reference",
+			from:        "doNotExist",
+			to:          "HEAD",
+			wantUnknown: "doNotExist",
+		},
+		{
+			name:        "Unknown target commit",
+			from:        "HEAD",
+			to:          "0000000000000000000000000000000000000001",
+			wantUnknown: "0000000000000000000000000000000000000001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.Diff(tt.from, tt.to, workingDir)
+			if tt.wantUnknown != "" {
+				var unknown *ErrUnknownRevision
+				require.True(t, errors.As(err, &unknown), err)
+				assert.Equal(t, tt.wantUnknown, unknown.Revision)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// The following errors are matched, using errors.Is, by the errors returned for the outcomes
+// callers usually branch on, so they don't need to compare against go-git errors.
+// The returned errors still wrap the underlying go-git error.
+var (
+	// ErrBranchNotFound is matched when a branch doesn't exist
+	ErrBranchNotFound = errors.New("branch not found")
+	// ErrAuthFailed is matched when the git remote rejects, or requires, credentials, whatever the transport
+	ErrAuthFailed = errors.New("git authentication failed")
+	// ErrNonFastForward is matched when a push is rejected because the remote branch diverged
+	ErrNonFastForward = errors.New("non-fast-forward update")
+	// ErrNothingToCommit is matched when committing a clean worktree
+	ErrNothingToCommit = errors.New("nothing to commit")
+)
+
+// wrappedError ties err to the package error kind describing it, both matching errors.Is
+type wrappedError struct {
+	kind error
+	err  error
+}
+
+func (e *wrappedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *wrappedError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// wrapError wraps err, returned by go-git, with the package error describing it, if any
+func wrapError(err error) error {
+	var kind error
+	switch {
+	case err == nil:
+		return nil
+	case isAuthError(err):
+		kind = ErrAuthFailed
+	case IsNonFastForwardError(err):
+		kind = ErrNonFastForward
+	case errors.Is(err, git.ErrEmptyCommit):
+		kind = ErrNothingToCommit
+	default:
+		return err
+	}
+
+	if errors.Is(err, kind) {
+		return err
+	}
+
+	return &wrappedError{kind: kind, err: err}
+}
+
+// branchNotFoundError wraps err with ErrBranchNotFound if the branch name doesn't exist
+func branchNotFoundError(name string, err error) error {
+	err = fmt.Errorf("branch %q: %w", name, err)
+	if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+
+	return &wrappedError{kind: ErrBranchNotFound, err: err}
+}
+
+// isAuthError returns true if err means that the git remote rejected, or required, credentials.
+// The ssh transport doesn't return a typed error for rejected keys.
+func isAuthError(err error) bool {
+	return errors.Is(err, transport.ErrAuthenticationRequired) ||
+		errors.Is(err, transport.ErrAuthorizationFailed) ||
+		errors.Is(err, transport.ErrInvalidAuthMethod) ||
+		strings.Contains(err.Error(), "ssh: unable to authenticate")
+}
+
+// ErrUnexpectedBranch is returned when HEAD isn't on the expected branch
+type ErrUnexpectedBranch struct {
+	Expected string
+	Actual   string
+}
+
+func (e *ErrUnexpectedBranch) Error() string {
+	return fmt.Sprintf("current branch %q doesn't match expected branch %q", e.Actual, e.Expected)
+}
+
+// ErrEmptyCommitIdentity is returned when the commit author name or email can't be determined
+type ErrEmptyCommitIdentity struct {
+	Field string
+}
+
+func (e *ErrEmptyCommitIdentity) Error() string {
+	return fmt.Sprintf("empty commit author %s, please specify it or configure git user.name and user.email", e.Field)
+}
+
+// ErrPatchHunk is returned when a patch hunk can't be applied to a file
+type ErrPatchHunk struct {
+	File    string
+	Hunk    int
+	Header  string
+	Context string
+}
+
+func (e *ErrPatchHunk) Error() string {
+	return fmt.Sprintf("hunk #%d %q failed to apply to %q, expected:\n%s", e.Hunk, e.Header, e.File, e.Context)
+}
+
+// ErrCorruptedRepository is returned when the git repository integrity verification fails
+type ErrCorruptedRepository struct {
+	Objects []string
+}
+
+func (e *ErrCorruptedRepository) Error() string {
+	return fmt.Sprintf("corrupted git repository, %d bad objects:\n%s", len(e.Objects), strings.Join(e.Objects, "\n"))
+}
+
+// ErrIndexLocked is returned when the git index is locked by another, or a crashed, git process
+type ErrIndexLocked struct {
+	Path  string
+	Age   time.Duration
+	Stale bool
+}
+
+func (e *ErrIndexLocked) Error() string {
+	if e.Stale {
+		return fmt.Sprintf("git index locked by %q since %s, probably left by a crashed git process, remove it or enable stale lock removal", e.Path, e.Age.Round(time.Second))
+	}
+	return fmt.Sprintf("git index locked by %q since %s, another git process may be running", e.Path, e.Age.Round(time.Second))
+}
+
+// ErrGitProgress wraps an error returned by a git remote operation with the progress output
+// reported until it failed, which often contains the git server explanation
+type ErrGitProgress struct {
+	Operation string
+	Err       error
+	Progress  string
+}
+
+func (e *ErrGitProgress) Error() string {
+	return redactCredentials(fmt.Sprintf("git %s: %s\n%s", e.Operation, e.Err, e.Progress))
+}
+
+func (e *ErrGitProgress) Unwrap() error {
+	return e.Err
+}
+
+// ErrDisallowedRemote is returned when a git remote URL scheme or host isn't part of the allowed remotes
+type ErrDisallowedRemote struct {
+	Scheme  string
+	Host    string
+	Allowed []string
+}
+
+func (e *ErrDisallowedRemote) Error() string {
+	return fmt.Sprintf("git remote %s://%s isn't allowed, allowed remotes are %q", e.Scheme, e.Host, e.Allowed)
+}
+
+// ErrEncryptedSSHKey is returned when the ssh private key is encrypted but no passphrase is specified
+type ErrEncryptedSSHKey struct {
+	Path string
+}
+
+func (e *ErrEncryptedSSHKey) Error() string {
+	return fmt.Sprintf("ssh private key %q is encrypted but no passphrase specified", e.Path)
+}
+
+// ErrRemoteNotFound is returned when the git repository doesn't define the git remote
+type ErrRemoteNotFound struct {
+	Name string
+}
+
+func (e *ErrRemoteNotFound) Error() string {
+	return fmt.Sprintf("git remote %q not found", e.Name)
+}
+
+// ErrInvalidReferenceName is returned when a branch or tag name isn't a valid git reference name
+type ErrInvalidReferenceName struct {
+	Name   string
+	Reason string
+}
+
+func (e *ErrInvalidReferenceName) Error() string {
+	return fmt.Sprintf("invalid git reference name %q: %s", e.Name, e.Reason)
+}
+
+// ErrTagExists is returned when creating a git tag which already exists
+type ErrTagExists struct {
+	Name string
+}
+
+func (e *ErrTagExists) Error() string {
+	return fmt.Sprintf("git tag %q already exists", e.Name)
+}
+
+func (e *ErrTagExists) Unwrap() error {
+	return git.ErrTagExists
+}
+
+// ErrDeleteCheckedOutBranch is returned when deleting the branch checked out in the worktree
+type ErrDeleteCheckedOutBranch struct {
+	Name string
+}
+
+func (e *ErrDeleteCheckedOutBranch) Error() string {
+	return fmt.Sprintf("can't delete branch %q checked out in the worktree, checkout another branch first", e.Name)
+}
+
+// ErrEmptyRepository is returned when reading the history of a git repository without any commit
+type ErrEmptyRepository struct {
+	Path string
+}
+
+func (e *ErrEmptyRepository) Error() string {
+	return fmt.Sprintf("git repository %q has no commit yet", e.Path)
+}
+
+// ErrPathNotFound is returned when adding a path which neither exists in the worktree nor is tracked by git
+type ErrPathNotFound struct {
+	Path string
+}
+
+func (e *ErrPathNotFound) Error() string {
+	return fmt.Sprintf("path %q doesn't exist in the git worktree", e.Path)
+}
+
+func (e *ErrPathNotFound) Unwrap() error {
+	return os.ErrNotExist
+}
+
+// ErrUnknownRevision is returned when a git reference, such as a branch or a commit hash, can't be resolved
+type ErrUnknownRevision struct {
+	Revision string
+	Err      error
+}
+
+func (e *ErrUnknownRevision) Error() string {
+	return fmt.Sprintf("unknown git revision %q: %s", e.Revision, e.Err)
+}
+
+func (e *ErrUnknownRevision) Unwrap() error {
+	return e.Err
+}
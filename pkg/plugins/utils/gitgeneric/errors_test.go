@@ -0,0 +1,100 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind error
+	}{
+		{
+			name:     "Http authentication required",
+			err:      transport.ErrAuthenticationRequired,
+			wantKind: ErrAuthFailed,
+		},
+		{
+			name:     "Http authorization failed",
+			err:      fmt.Errorf("push: %w", transport.ErrAuthorizationFailed),
+			wantKind: ErrAuthFailed,
+		},
+		{
+			name:     "Ssh key rejected",
+			err:      errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey], no supported methods remain"),
+			wantKind: ErrAuthFailed,
+		},
+		{
+			name:     "Push rejected",
+			err:      errors.New("non-fast-forward update: refs/heads/main"),
+			wantKind: ErrNonFastForward,
+		},
+		{
+			name:     "Empty commit",
+			err:      git.ErrEmptyCommit,
+			wantKind: ErrNothingToCommit,
+		},
+		{
+			name: "Other error",
+			err:  transport.ErrRepositoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapError(tt.err)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.err.Error(), err.Error())
+
+			for _, kind := range []error{ErrAuthFailed, ErrNonFastForward, ErrNothingToCommit, ErrBranchNotFound} {
+				assert.Equal(t, kind == tt.wantKind, errors.Is(err, kind), kind.Error())
+			}
+
+			// Wrapping twice doesn't change anything
+			assert.Equal(t, err, wrapError(err))
+		})
+	}
+
+	assert.NoError(t, wrapError(nil))
+}
+
+func TestTypedErrors(t *testing.T) {
+	g := GoGit{}
+
+	// Authentication failures are matched whatever their origin
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	err := g.Clone("updatecli", "wrong", server.URL+"/updatecli.git", filepath.Join(t.TempDir(), "clone"))
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	assert.ErrorIs(t, err, transport.ErrAuthenticationRequired)
+
+	_, _, _, err = g.CompareRemoteRefs("updatecli", "wrong", server.URL+"/updatecli.git", "main", "v1")
+	assert.ErrorIs(t, err, ErrAuthFailed)
+
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	_, err = g.Commit("updatecli", "updatecli@updatecli.io", "nothing", workingDir, "", "")
+	assert.ErrorIs(t, err, ErrNothingToCommit)
+
+	_, err = g.RenameBranch("doNotExist", "renamed", workingDir)
+	assert.ErrorIs(t, err, ErrBranchNotFound)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	err = g.Checkout("", "", "doNotExist", "updatecli_working", workingDir, false)
+	require.Error(t, err)
+}
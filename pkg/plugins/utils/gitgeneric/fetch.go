@@ -0,0 +1,145 @@
+package gitgeneric
+
+import (
+	"bytes"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Fetch run `git fetch --all`, updating the remote-tracking branches, such as "refs/remotes/origin/main",
+and the tags of every configured remote without merging them into the local branches.
+
+Tags pointing into the fetched history are fetched, and every remote tag if FetchTags is set, like `--tags`.
+Remote-tracking branches deleted from their remote are removed if FetchPrune is set, like `--prune`.
+*/
+func (g GoGit) Fetch(username, password, workingDir string) error {
+
+	logrus.Debugf("stage: git-fetch\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	remotes, err := r.Remotes()
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
+	b := bytes.Buffer{}
+	for _, remote := range remotes {
+		auth, err := g.transportAuth(remoteURL(remote), username, password)
+		if err != nil {
+			return err
+		}
+
+		fetchOptions := git.FetchOptions{
+			RemoteName:      remote.Config().Name,
+			Progress:        &b,
+			InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+			ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+			CABundle:        caBundle,
+		}
+		if g.FetchTags {
+			fetchOptions.RefSpecs = append(fetchOptions.RefSpecs, remote.Config().Fetch...)
+			fetchOptions.RefSpecs = append(fetchOptions.RefSpecs, "+refs/tags/*:refs/tags/*")
+			fetchOptions.Tags = git.AllTags
+		}
+		if auth != nil {
+			fetchOptions.Auth = auth
+		}
+
+		err = remote.Fetch(&fetchOptions)
+
+		progress := redactCredentials(b.String(), password)
+		g.writeProgress(progress)
+		b.Reset()
+
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return g.progressError("fetch", err, progress)
+		}
+
+		if g.FetchPrune {
+			if err := g.pruneRemote(r, remote, auth, caBundle); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// pruneRemote deletes the remote-tracking references of remote, matching its fetch refspecs,
+// whose branch doesn't exist on the remote anymore
+func (g GoGit) pruneRemote(r *git.Repository, remote *git.Remote, auth transport.AuthMethod, caBundle []byte) error {
+	listOptions := git.ListOptions{
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
+	}
+	if auth != nil {
+		listOptions.Auth = auth
+	}
+
+	remoteRefs, err := remote.List(&listOptions)
+	if err != nil {
+		return err
+	}
+
+	refSpecs := remote.Config().Fetch
+
+	tracked := map[plumbing.ReferenceName]bool{}
+	for _, ref := range remoteRefs {
+		for _, refSpec := range refSpecs {
+			if refSpec.Match(ref.Name()) {
+				tracked[refSpec.Dst(ref.Name())] = true
+			}
+		}
+	}
+
+	refs, err := r.References()
+	if err != nil {
+		return err
+	}
+
+	stale := []plumbing.ReferenceName{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference || tracked[ref.Name()] {
+			return nil
+		}
+		for _, refSpec := range refSpecs {
+			if refSpec.Reverse().Match(ref.Name()) {
+				stale = append(stale, ref.Name())
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range stale {
+		logrus.Debugf("pruning %q deleted from remote %q", name.Short(), remote.Config().Name)
+		if err := r.Storer.RemoveReference(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package gitgeneric
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name           string
+		g              GoGit
+		wantUnrelated  bool
+		wantStaleFound bool
+	}{
+		{
+			name:           "Default",
+			wantStaleFound: true,
+		},
+		{
+			name:           "Fetch every tag",
+			g:              GoGit{FetchTags: true},
+			wantUnrelated:  true,
+			wantStaleFound: true,
+		},
+		{
+			name: "Prune deleted remote branches",
+			g:    GoGit{FetchPrune: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			o, err := git.PlainOpen(origin)
+			require.NoError(t, err)
+
+			stale := plumbing.NewBranchReferenceName("stale")
+			base := commitTestFile(t, origin, "README.md", "v2")
+			require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(stale, base)))
+
+			workingDir := newTestClone(t, origin)
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(
+				plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, "stale"), base)))
+
+			// A tag on a commit which no branch contains anymore
+			commitTestFile(t, origin, "README.md", "unrelated")
+			createTestTag(t, origin, "unrelated")
+			require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), base)))
+
+			latest := commitTestFile(t, origin, "README.md", "v3")
+			createTestTag(t, origin, "v3")
+			feature := plumbing.NewBranchReferenceName("feature")
+			require.NoError(t, o.Storer.SetReference(plumbing.NewHashReference(feature, latest)))
+			require.NoError(t, o.Storer.RemoveReference(stale))
+
+			require.NoError(t, tt.g.Fetch("", "", workingDir))
+			// Nothing new to fetch
+			require.NoError(t, tt.g.Fetch("", "", workingDir))
+
+			for _, branch := range []string{"main", "feature"} {
+				ref, err := r.Reference(plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, branch), false)
+				require.NoError(t, err, branch)
+				assert.Equal(t, latest, ref.Hash(), branch)
+			}
+
+			// Local branches aren't updated
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, base, head.Hash())
+
+			_, err = r.Reference(plumbing.NewTagReferenceName("v3"), false)
+			assert.NoError(t, err, "tags pointing into the fetched history are always fetched")
+
+			_, err = r.Reference(plumbing.NewTagReferenceName("unrelated"), false)
+			assert.Equal(t, tt.wantUnrelated, err == nil, "unrelated tag")
+
+			_, err = r.Reference(plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, "stale"), false)
+			assert.Equal(t, tt.wantStaleFound, err == nil, "remote branch deleted from the remote")
+		})
+	}
+}
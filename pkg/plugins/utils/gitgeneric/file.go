@@ -12,6 +12,11 @@ import (
 // ReadFileFromRevision reads a file from a git repository at a given revision.
 func ReadFileFromRevision(repoPath, revision, filePath string) ([]byte, error) {
 
+	repoPath, err := absWorkingDir("", repoPath, true)
+	if err != nil {
+		return nil, err
+	}
+
 	repo, err := git.PlainOpen(repoPath)
 
 	if err != nil {
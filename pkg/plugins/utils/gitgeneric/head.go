@@ -0,0 +1,62 @@
+package gitgeneric
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// AssertBranch returns an ErrUnexpectedBranch error if HEAD isn't on a branch matching expected.
+// expected is either a branch name or a glob pattern such as "updatecli-*".
+func (g GoGit) AssertBranch(expected, workingDir string) error {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	return assertBranch(r, expected)
+}
+
+// checkExpectedBranch ensures that HEAD is on the expected branch, if one is configured,
+// before running a mutating git operation.
+func (g GoGit) checkExpectedBranch(r *git.Repository) error {
+	if g.ExpectedBranch == "" {
+		return nil
+	}
+	return assertBranch(r, g.ExpectedBranch)
+}
+
+func assertBranch(r *git.Repository, expected string) error {
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	actual := head.Name().Short()
+	if !head.Name().IsBranch() {
+		// Detached HEAD
+		actual = head.Hash().String()
+	}
+
+	matched, err := path.Match(expected, actual)
+	if err != nil {
+		return fmt.Errorf("invalid expected branch pattern %q: %w", expected, err)
+	}
+
+	if !head.Name().IsBranch() || !matched {
+		return &ErrUnexpectedBranch{
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package gitgeneric
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertBranch(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Matching branch name",
+			expected: "main",
+		},
+		{
+			name:     "Matching glob pattern",
+			expected: "ma*",
+		},
+		{
+			name:     "Not matching glob pattern",
+			expected: "updatecli-*",
+			wantErr:  true,
+		},
+		{
+			name:     "Not matching branch name",
+			expected: "master",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GoGit{}.AssertBranch(tt.expected, workingDir)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			var e *ErrUnexpectedBranch
+			require.True(t, errors.As(err, &e))
+			assert.Equal(t, tt.expected, e.Expected)
+			assert.Equal(t, "main", e.Actual)
+		})
+	}
+}
+
+func TestCommitExpectedBranch(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+	writeTestFile(t, workingDir, "README.md", "updated")
+
+	g := GoGit{ExpectedBranch: "updatecli-*"}
+
+	err := g.Add([]string{"README.md"}, workingDir)
+	var e *ErrUnexpectedBranch
+	require.True(t, errors.As(err, &e))
+
+	_, err = g.Commit("updatecli", "updatecli@updatecli.io", "test", workingDir, "", "")
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, "main", e.Actual)
+
+	err = g.Push("", "", workingDir, false)
+	require.True(t, errors.As(err, &e))
+}
@@ -0,0 +1,46 @@
+package gitgeneric
+
+import (
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/sirupsen/logrus"
+)
+
+// commitIdentity returns the commit author name and email.
+// Empty values fall back to the git configuration "user.name" and "user.email",
+// from the repository, then the global git configuration.
+// An ErrEmptyCommitIdentity error is returned if either of them is still empty,
+// as git forges reject commits without author.
+func commitIdentity(r *git.Repository, user, email string) (string, string, error) {
+	user = strings.TrimSpace(user)
+	email = strings.TrimSpace(email)
+
+	if user == "" || email == "" {
+		cfg, err := r.ConfigScoped(config.GlobalScope)
+		if err != nil {
+			logrus.Debugf("reading git configuration: %s", err)
+		} else {
+			if user == "" {
+				user = strings.TrimSpace(cfg.User.Name)
+				logrus.Debugf("using commit author name %q from git configuration", user)
+			}
+			if email == "" {
+				email = strings.TrimSpace(cfg.User.Email)
+				logrus.Debugf("using commit author email %q from git configuration", email)
+			}
+		}
+	}
+
+	switch {
+	case user == "" && email == "":
+		return "", "", &ErrEmptyCommitIdentity{Field: "name and email"}
+	case user == "":
+		return "", "", &ErrEmptyCommitIdentity{Field: "name"}
+	case email == "":
+		return "", "", &ErrEmptyCommitIdentity{Field: "email"}
+	}
+
+	return user, email, nil
+}
@@ -0,0 +1,92 @@
+package gitgeneric
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitEmptyIdentity(t *testing.T) {
+	// Ensure no global git configuration is used
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		name          string
+		user          string
+		email         string
+		localUser     string
+		localEmail    string
+		expectedUser  string
+		expectedEmail string
+		expectedField string
+	}{
+		{
+			name:          "Empty identity",
+			expectedField: "name and email",
+		},
+		{
+			name:          "Empty email",
+			user:          "updatecli",
+			expectedField: "email",
+		},
+		{
+			name:          "Whitespace name",
+			user:          "  ",
+			email:         "updatecli@updatecli.io",
+			expectedField: "name",
+		},
+		{
+			name:          "Fallback to the repository git configuration",
+			localUser:     "updatecli bot",
+			localEmail:    "bot@updatecli.io",
+			expectedUser:  "updatecli bot",
+			expectedEmail: "bot@updatecli.io",
+		},
+		{
+			name:          "Explicit identity takes precedence",
+			user:          "updatecli",
+			email:         "updatecli@updatecli.io",
+			localUser:     "updatecli bot",
+			localEmail:    "bot@updatecli.io",
+			expectedUser:  "updatecli",
+			expectedEmail: "updatecli@updatecli.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+			writeTestFile(t, workingDir, "README.md", "updated")
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			if tt.localUser != "" {
+				cfg, err := r.Config()
+				require.NoError(t, err)
+				cfg.User.Name = tt.localUser
+				cfg.User.Email = tt.localEmail
+				require.NoError(t, r.SetConfig(cfg))
+			}
+
+			_, err = GoGit{}.Commit(tt.user, tt.email, "update", workingDir, "", "")
+			if tt.expectedField != "" {
+				var e *ErrEmptyCommitIdentity
+				require.True(t, errors.As(err, &e))
+				assert.Equal(t, tt.expectedField, e.Field)
+				return
+			}
+			require.NoError(t, err)
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			commit, err := r.CommitObject(head.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedUser, commit.Author.Name)
+			assert.Equal(t, tt.expectedEmail, commit.Author.Email)
+		})
+	}
+}
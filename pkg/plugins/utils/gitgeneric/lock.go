@@ -0,0 +1,61 @@
+package gitgeneric
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStaleIndexLockAge defines the default age after which a git index lock is considered stale
+const DefaultStaleIndexLockAge = 10 * time.Minute
+
+// checkIndexLock returns an ErrIndexLocked if the ".git/index.lock" file exists.
+// go-git doesn't use that lock itself, but a lock file means another git process
+// is modifying the index, or crashed while doing so.
+// A stale lock file, older than StaleIndexLockAge, is removed if RemoveStaleIndexLock is set.
+func (g GoGit) checkIndexLock(r *git.Repository) error {
+	s, ok := r.Storer.(*filesystem.Storage)
+	if !ok {
+		return nil
+	}
+
+	lockFile := filepath.Join(s.Filesystem().Root(), "index.lock")
+
+	info, err := os.Stat(lockFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	maxAge := g.StaleIndexLockAge
+	if maxAge == 0 {
+		maxAge = DefaultStaleIndexLockAge
+	}
+
+	age := time.Since(info.ModTime())
+	locked := ErrIndexLocked{
+		Path:  lockFile,
+		Age:   age,
+		Stale: age >= maxAge,
+	}
+
+	if !locked.Stale || !g.RemoveStaleIndexLock {
+		return &locked
+	}
+
+	logrus.Warningf("removing stale git index lock %q, created %s ago. If it keeps happening, check that no other git process uses the repository at the same time",
+		lockFile, age.Round(time.Second))
+
+	if err := os.Remove(lockFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package gitgeneric
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexLock(t *testing.T) {
+	tests := []struct {
+		name      string
+		lockAge   time.Duration
+		remove    bool
+		wantErr   bool
+		wantStale bool
+	}{
+		{
+			name:    "Recent lock not removed",
+			lockAge: time.Minute,
+			remove:  true,
+			wantErr: true,
+		},
+		{
+			name:      "Stale lock without removal",
+			lockAge:   time.Hour,
+			wantErr:   true,
+			wantStale: true,
+		},
+		{
+			name:    "Stale lock removed",
+			lockAge: time.Hour,
+			remove:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := test.NewGlobal()
+			defer hook.Reset()
+
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			writeTestFile(t, workingDir, "README.md", "v2")
+
+			lockFile := filepath.Join(workingDir, ".git", "index.lock")
+			require.NoError(t, os.WriteFile(lockFile, nil, 0o644))
+			modTime := time.Now().Add(-tt.lockAge)
+			require.NoError(t, os.Chtimes(lockFile, modTime, modTime))
+
+			g := GoGit{RemoveStaleIndexLock: tt.remove}
+			err := g.Add([]string{"README.md"}, workingDir)
+
+			if tt.wantErr {
+				var locked *ErrIndexLocked
+				require.True(t, errors.As(err, &locked), err)
+				assert.Equal(t, tt.wantStale, locked.Stale)
+				assert.FileExists(t, lockFile)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NoFileExists(t, lockFile)
+
+			warned := false
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.WarnLevel {
+					warned = true
+				}
+			}
+			assert.True(t, warned, "removing a stale lock must be logged")
+		})
+	}
+}
+
+func TestIndexLockAge(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	writeTestFile(t, workingDir, "README.md", "v2")
+
+	lockFile := filepath.Join(workingDir, ".git", "index.lock")
+	require.NoError(t, os.WriteFile(lockFile, nil, 0o644))
+	modTime := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(lockFile, modTime, modTime))
+
+	g := GoGit{RemoveStaleIndexLock: true, StaleIndexLockAge: time.Minute}
+	_, err := g.Commit("updatecli", "updatecli@updatecli.io", "test", workingDir, "", "")
+	require.NoError(t, err)
+	assert.NoFileExists(t, lockFile)
+}
@@ -0,0 +1,315 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/sirupsen/logrus"
+)
+
+// CommitsByAuthor returns the commits reachable from HEAD authored by email, newest first.
+// If since isn't the zero time, only commits created after it are returned.
+// No matching commit isn't considered as an error.
+func (g GoGit) CommitsByAuthor(email, workingDir string, since time.Time) ([]*object.Commit, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return nil, err
+	}
+
+	logOptions := git.LogOptions{}
+	if !since.IsZero() {
+		logOptions.Since = &since
+	}
+
+	commitIter, err := r.Log(&logOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer commitIter.Close()
+
+	commits := []*object.Commit{}
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if strings.EqualFold(c.Author.Email, email) {
+			commits = append(commits, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("%d commits found from author %q", len(commits), email)
+
+	return commits, nil
+}
+
+const (
+	// MergeCommitFirstParent only compares a merge commit with its first parent, like `git log --first-parent`
+	MergeCommitFirstParent = "first-parent"
+	// MergeCommitAnyParent considers a file touched by a merge commit if it differs from any of its parents
+	MergeCommitAnyParent = "any-parent"
+	// MergeCommitAllParents considers a file touched by a merge commit if it differs from all its parents,
+	// meaning the merge itself modified it, like git combined diffs
+	MergeCommitAllParents = "all-parents"
+)
+
+// CommitTouchedFile returns true if the commit, identified by any git reference, modified path
+// compared to its parent. path can be a file or a directory, using "/" as separator.
+// A root commit touched every file it contains.
+// Merge commits are compared to their parents according to MergeCommitStrategy.
+func (g GoGit) CommitTouchedFile(commit, path, workingDir string) (bool, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return false, err
+	}
+
+	hash, err := resolveRevision(r, commit)
+	if err != nil {
+		return false, err
+	}
+
+	c, err := r.CommitObject(hash)
+	if err != nil {
+		return false, err
+	}
+
+	path = strings.Trim(path, "/")
+
+	entry, err := commitTreeEntry(c, path)
+	if err != nil {
+		return false, err
+	}
+
+	parents := []*object.Commit{}
+	err = c.Parents().ForEach(func(p *object.Commit) error {
+		parents = append(parents, p)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if len(parents) == 0 {
+		return entry != nil, nil
+	}
+
+	strategy := g.MergeCommitStrategy
+	if strategy == "" {
+		strategy = MergeCommitFirstParent
+	}
+
+	switch strategy {
+	case MergeCommitFirstParent:
+		parents = parents[:1]
+	case MergeCommitAnyParent, MergeCommitAllParents:
+	default:
+		return false, fmt.Errorf("unsupported merge commit strategy %q, accepted values are %q, %q, and %q",
+			strategy, MergeCommitFirstParent, MergeCommitAnyParent, MergeCommitAllParents)
+	}
+
+	touchedParents := 0
+	for _, p := range parents {
+		parentEntry, err := commitTreeEntry(p, path)
+		if err != nil {
+			return false, err
+		}
+		if !sameTreeEntry(entry, parentEntry) {
+			touchedParents++
+		}
+	}
+
+	touched := touchedParents > 0
+	if strategy == MergeCommitAllParents {
+		touched = touchedParents == len(parents)
+	}
+
+	logrus.Debugf("commit %q touched %q: %t", hash.String(), path, touched)
+
+	return touched, nil
+}
+
+// commitTreeEntry returns the tree entry located at path in the commit c, or nil if it doesn't exist
+func commitTreeEntry(c *object.Commit, path string) (*object.TreeEntry, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := tree.FindEntry(path)
+	if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// sameTreeEntry returns true if both entries have the same content and mode, or are both missing
+func sameTreeEntry(a, b *object.TreeEntry) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hash == b.Hash && a.Mode == b.Mode
+}
+
+/*
+CommitCount returns the number of commits reachable from ref, like `git rev-list --count` does.
+ref can be any git reference, such as a branch or a commit hash, or a range "<since>..<ref>",
+such as "v1.0.0..main", to only count the commits reachable from ref but not from since.
+
+If CommitCountLimit is set, counting stops once that limit is reached, and the limit is returned.
+*/
+func (g GoGit) CommitCount(ref, workingDir string) (int, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return 0, err
+	}
+
+	since, tip, isRange := strings.Cut(ref, "..")
+	if !isRange {
+		tip = ref
+	}
+
+	tipHash, err := resolveRevision(r, tip)
+	if err != nil {
+		return 0, err
+	}
+
+	tipCommit, err := r.CommitObject(tipHash)
+	if err != nil {
+		return 0, err
+	}
+
+	// Commits missing from shallow clones are never loaded
+	shallow, err := shallowParents(r)
+	if err != nil {
+		return 0, err
+	}
+
+	// Commits reachable from since are never visited
+	excluded := map[plumbing.Hash]bool{}
+	if isRange {
+		sinceHash, err := resolveRevision(r, since)
+		if err != nil {
+			return 0, err
+		}
+
+		sinceCommit, err := r.CommitObject(sinceHash)
+		if err != nil {
+			return 0, err
+		}
+
+		err = object.NewCommitPreorderIter(sinceCommit, nil, shallow).ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	err = object.NewCommitPreorderIter(tipCommit, excluded, shallow).ForEach(func(c *object.Commit) error {
+		count++
+		if g.CommitCountLimit > 0 && count >= g.CommitCountLimit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	logrus.Debugf("%d commits reachable from %q", count, ref)
+
+	return count, nil
+}
+
+// CommitCountSince returns the number of commits reachable from HEAD but not from ref,
+// such as a tag or a branch, like `git rev-list --count <ref>..HEAD` does.
+// An ErrEmptyRepository error is returned if the repository has no commit yet.
+func (g GoGit) CommitCountSince(ref, workingDir string) (int, error) {
+	if _, err := g.GetLatestCommit(workingDir); err != nil {
+		return 0, err
+	}
+
+	return g.CommitCount(ref+"..HEAD", workingDir)
+}
+
+// GetLatestCommit returns the hash of the commit checked out by HEAD.
+// An ErrEmptyRepository error is returned if the repository has no commit yet.
+func (g GoGit) GetLatestCommit(workingDir string) (plumbing.Hash, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return plumbing.ZeroHash, err
+	}
+
+	head, err := r.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return plumbing.ZeroHash, &ErrEmptyRepository{Path: workingDir}
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	logrus.Debugf("latest commit is %q", head.Hash().String())
+
+	return head.Hash(), nil
+}
+
+// shallowParents returns the parents of the shallow commits of a shallow clone,
+// which are missing from the repository so history walks must stop before them
+func shallowParents(r *git.Repository) ([]plumbing.Hash, error) {
+	parents := []plumbing.Hash{}
+
+	shallow, err := r.Storer.Shallow()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hash := range shallow {
+		c, err := r.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, c.ParentHashes...)
+	}
+
+	return parents, nil
+}
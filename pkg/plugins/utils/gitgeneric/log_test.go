@@ -0,0 +1,187 @@
+package gitgeneric
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitsByAuthor(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	commitAs := func(email, content string, when time.Time) {
+		writeTestFile(t, workingDir, "README.md", content)
+		_, err := w.Commit(content, &git.CommitOptions{
+			All:    true,
+			Author: &object.Signature{Name: email, Email: email, When: when},
+		})
+		require.NoError(t, err)
+	}
+
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+	commitAs("bot@updatecli.io", "v2", lastWeek)
+	commitAs("human@updatecli.io", "v3", time.Now())
+	commitAs("bot@updatecli.io", "v4", time.Now())
+
+	g := GoGit{}
+
+	got, err := g.CommitsByAuthor("bot@updatecli.io", workingDir, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "v4", got[0].Message)
+	assert.Equal(t, "v2", got[1].Message)
+
+	got, err = g.CommitsByAuthor("BOT@updatecli.io", workingDir, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "v4", got[0].Message)
+
+	got, err = g.CommitsByAuthor("nobody@updatecli.io", workingDir, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestCommitTouchedFile(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1", "docs/A.md": "v1"})
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	initial := head.Hash()
+
+	// main modifies README.md while a side branch modifies docs/A.md, then both are merged
+	mainCommit := commitTestFile(t, workingDir, "README.md", "v2")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Hash: initial}))
+	sideCommit := commitTestFile(t, workingDir, "docs/A.md", "v2")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("main")}))
+
+	writeTestFile(t, workingDir, "docs/A.md", "v2")
+	writeTestFile(t, workingDir, "MERGE.md", "merge")
+	_, err = w.Add(".")
+	require.NoError(t, err)
+	_, err = w.Commit("merge", &git.CommitOptions{
+		Author:  &object.Signature{Name: "updatecli", Email: "updatecli@updatecli.io", When: time.Now()},
+		Parents: []plumbing.Hash{mainCommit, sideCommit},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		strategy string
+		commit   string
+		path     string
+		expected bool
+		wantErr  bool
+	}{
+		{name: "Root commit", commit: initial.String(), path: "README.md", expected: true},
+		{name: "Modified file", commit: mainCommit.String(), path: "README.md", expected: true},
+		{name: "Unmodified file", commit: mainCommit.String(), path: "docs/A.md", expected: false},
+		{name: "Directory", commit: sideCommit.String(), path: "docs", expected: true},
+		{name: "Missing file", commit: "main", path: "MISSING.md", expected: false},
+		{name: "Merge first parent", commit: "main", path: "docs/A.md", expected: true},
+		{name: "Merge first parent unmodified", commit: "main", path: "README.md", expected: false},
+		{name: "Merge any parent", strategy: MergeCommitAnyParent, commit: "main", path: "README.md", expected: true},
+		{name: "Merge all parents", strategy: MergeCommitAllParents, commit: "main", path: "docs/A.md", expected: false},
+		{name: "Merge all parents new file", strategy: MergeCommitAllParents, commit: "main", path: "MERGE.md", expected: true},
+		{name: "Unsupported strategy", strategy: "octopus", commit: "main", path: "README.md", wantErr: true},
+		{name: "Unknown commit", commit: "unknown", path: "README.md", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoGit{MergeCommitStrategy: tt.strategy}.CommitTouchedFile(tt.commit, tt.path, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestCommitCount(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	commitTestFile(t, workingDir, "README.md", "v2")
+	createTestTag(t, workingDir, "v1.0.0")
+	commitTestFile(t, workingDir, "README.md", "v3")
+	commitTestFile(t, workingDir, "README.md", "v4")
+	commitTestFile(t, workingDir, "README.md", "v5")
+
+	tests := []struct {
+		name     string
+		limit    int
+		ref      string
+		expected int
+		wantErr  bool
+	}{
+		{name: "Branch", ref: "main", expected: 5},
+		{name: "Tag", ref: "v1.0.0", expected: 2},
+		{name: "Since tag", ref: "v1.0.0..main", expected: 3},
+		{name: "Since same reference", ref: "main..main", expected: 0},
+		{name: "Limit reached", limit: 2, ref: "main", expected: 2},
+		{name: "Limit not reached", limit: 10, ref: "v1.0.0..HEAD", expected: 3},
+		{name: "Unknown reference", ref: "unknown..main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoGit{CommitCountLimit: tt.limit}.CommitCount(tt.ref, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestCommitCountSince(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	createTestTag(t, workingDir, "v1.0.0")
+	commitTestFile(t, workingDir, "README.md", "v2")
+	latest := commitTestFile(t, workingDir, "README.md", "v3")
+
+	got, err := GoGit{}.GetLatestCommit(workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, latest, got)
+
+	count, err := GoGit{}.CommitCountSince("v1.0.0", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = GoGit{}.CommitCountSince("main", workingDir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	_, err = GoGit{}.CommitCountSince("doNotExist", workingDir)
+	require.Error(t, err)
+
+	// Repository without any commit
+	emptyDir := t.TempDir()
+	_, err = git.PlainInit(emptyDir, false)
+	require.NoError(t, err)
+
+	var emptyErr *ErrEmptyRepository
+	_, err = GoGit{}.GetLatestCommit(emptyDir)
+	require.True(t, errors.As(err, &emptyErr), err)
+
+	_, err = GoGit{}.CommitCountSince("v1.0.0", emptyDir)
+	require.True(t, errors.As(err, &emptyErr), err)
+}
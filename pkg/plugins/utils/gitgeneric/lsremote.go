@@ -0,0 +1,173 @@
+package gitgeneric
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+CompareRemoteRefs retrieves, like `git ls-remote`, the commit hashes of two references
+from the git repository located at URL, without cloning it, and reports if they are equal.
+
+A reference can be a full reference name such as "refs/heads/main", or a short branch or tag name,
+in which case branches take precedence over tags. Annotated tags are resolved to the commit they point to.
+
+Without the repository history, it can't tell if a reference is ahead or behind the other one,
+only if they point to the same commit.
+*/
+func (g GoGit) CompareRemoteRefs(username, password, URL, refA, refB string) (equal bool, hashA string, hashB string, err error) {
+
+	hashes, err := g.listRemoteHashes(username, password, URL)
+	if err != nil {
+		return false, "", "", err
+	}
+
+	hashA, err = remoteRefHash(hashes, refA)
+	if err != nil {
+		return false, "", "", err
+	}
+
+	hashB, err = remoteRefHash(hashes, refB)
+	if err != nil {
+		return false, "", "", err
+	}
+
+	logrus.Debugf("remote reference %q is %q and %q is %q", refA, hashA, refB, hashB)
+
+	return hashA == hashB, hashA, hashB, nil
+}
+
+// RemoteRefHash retrieves, like `git ls-remote`, the commit hash of a reference
+// from the git repository located at URL, without cloning it.
+// The reference is resolved like with CompareRemoteRefs.
+func (g GoGit) RemoteRefHash(username, password, URL, ref string) (string, error) {
+
+	hashes, err := g.listRemoteHashes(username, password, URL)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := remoteRefHash(hashes, ref)
+	if err != nil {
+		return "", err
+	}
+
+	logrus.Debugf("remote reference %q is %q", ref, hash)
+
+	return hash, nil
+}
+
+// RemoteTagRefs retrieves, like `git ls-remote --tags`, the tags of the git repository located at URL,
+// without cloning it. Annotated tags are resolved to the commit they point to.
+// Remote tags don't provide a creation date, so they are ordered by name.
+func (g GoGit) RemoteTagRefs(username, password, URL string) ([]DatedTag, error) {
+
+	hashes, err := g.listRemoteHashes(username, password, URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []DatedTag
+	for name := range hashes {
+		if !name.IsTag() || strings.HasSuffix(name.String(), "^{}") {
+			continue
+		}
+
+		hash, err := remoteRefHash(hashes, name.String())
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, DatedTag{
+			Name: name.Short(),
+			Hash: hash,
+		})
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+
+	logrus.Debugf("got remote tags: %v", tags)
+
+	return tags, nil
+}
+
+// listRemoteHashes returns the hash of every reference of the git repository located at URL
+func (g GoGit) listRemoteHashes(username, password, URL string) (map[plumbing.ReferenceName]plumbing.Hash, error) {
+
+	logrus.Debugf("stage: git-ls-remote\n\n")
+
+	if err := g.checkRemoteAllowed(URL); err != nil {
+		return nil, err
+	}
+
+	auth, err := g.transportAuth(URL, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: DefaultRemoteReferenceName,
+		URLs: []string{URL},
+	})
+
+	listOptions := git.ListOptions{
+		PeelingOption:   git.AppendPeeled,
+		InsecureSkipTLS: g.insecureSkipTLS(URL),
+		ProxyOptions:    g.proxyOptions(URL),
+		CABundle:        caBundle,
+	}
+	if auth != nil {
+		listOptions.Auth = auth
+	}
+
+	refs, err := remote.List(&listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("listing remote references from %q: %w", redactCredentials(URL), wrapError(err))
+	}
+
+	hashes := map[plumbing.ReferenceName]plumbing.Hash{}
+	for _, ref := range refs {
+		if ref.Type() == plumbing.HashReference {
+			hashes[ref.Name()] = ref.Hash()
+		}
+	}
+
+	return hashes, nil
+}
+
+// remoteRefHash returns the commit hash of the remote reference ref
+func remoteRefHash(hashes map[plumbing.ReferenceName]plumbing.Hash, ref string) (string, error) {
+	candidates := []plumbing.ReferenceName{plumbing.ReferenceName(ref)}
+	if !strings.HasPrefix(ref, "refs/") {
+		candidates = []plumbing.ReferenceName{
+			plumbing.NewBranchReferenceName(ref),
+			plumbing.NewTagReferenceName(ref),
+		}
+	}
+
+	for _, name := range candidates {
+		// Annotated tags are followed by their peeled reference pointing to the tagged commit
+		if hash, ok := hashes[name+"^{}"]; ok {
+			return hash.String(), nil
+		}
+		if hash, ok := hashes[name]; ok {
+			return hash.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("remote reference %q not found", ref)
+}
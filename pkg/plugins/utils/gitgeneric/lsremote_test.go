@@ -0,0 +1,128 @@
+package gitgeneric
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareRemoteRefs(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+
+	r, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+
+	createTestTag(t, origin, "v1.0.0")
+	_, err = r.CreateTag("v1.0.0-annotated", first, &git.CreateTagOptions{
+		Message: "annotated",
+		Tagger:  &object.Signature{Name: "updatecli", Email: "updatecli@updatecli.io"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("release"), first)))
+
+	second := commitTestFile(t, origin, "README.md", "v3")
+
+	tests := []struct {
+		name      string
+		refA      string
+		refB      string
+		wantEqual bool
+		wantHashA string
+		wantHashB string
+		wantErr   bool
+	}{
+		{
+			name:      "Same commit",
+			refA:      "release",
+			refB:      "v1.0.0",
+			wantEqual: true,
+			wantHashA: first.String(),
+			wantHashB: first.String(),
+		},
+		{
+			name:      "Annotated tag resolved to its commit",
+			refA:      "refs/heads/release",
+			refB:      "refs/tags/v1.0.0-annotated",
+			wantEqual: true,
+			wantHashA: first.String(),
+			wantHashB: first.String(),
+		},
+		{
+			name:      "Different commits",
+			refA:      "main",
+			refB:      "release",
+			wantHashA: second.String(),
+			wantHashB: first.String(),
+		},
+		{
+			name:    "Unknown reference",
+			refA:    "main",
+			refB:    "unknown",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, hashA, hashB, err := GoGit{}.CompareRemoteRefs("", "", origin, tt.refA, tt.refB)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEqual, equal)
+			assert.Equal(t, tt.wantHashA, hashA)
+			assert.Equal(t, tt.wantHashB, hashB)
+		})
+	}
+}
+
+func TestRemoteRefHash(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+
+	r, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+
+	_, err = r.CreateTag("v1.0.0", first, &git.CreateTagOptions{
+		Message: "annotated",
+		Tagger:  &object.Signature{Name: "updatecli", Email: "updatecli@updatecli.io"},
+	})
+	require.NoError(t, err)
+
+	hash, err := GoGit{}.RemoteRefHash("", "", origin, "v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, first.String(), hash)
+
+	_, err = GoGit{}.RemoteRefHash("", "", origin, "v2.0.0")
+	assert.Error(t, err)
+}
+
+func TestRemoteTagRefs(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	first := commitTestFile(t, origin, "README.md", "v2")
+
+	r, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+
+	_, err = r.CreateTag("v1.0.0", first, &git.CreateTagOptions{
+		Message: "annotated",
+		Tagger:  &object.Signature{Name: "updatecli", Email: "updatecli@updatecli.io"},
+	})
+	require.NoError(t, err)
+
+	second := commitTestFile(t, origin, "README.md", "v3")
+	createTestTag(t, origin, "v1.1.0")
+
+	tags, err := GoGit{}.RemoteTagRefs("", "", origin)
+	require.NoError(t, err)
+	assert.Equal(t, []DatedTag{
+		{Name: "v1.0.0", Hash: first.String()},
+		{Name: "v1.1.0", Hash: second.String()},
+	}, tags)
+}
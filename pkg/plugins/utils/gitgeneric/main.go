@@ -2,49 +2,276 @@ package gitgeneric
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/sirupsen/logrus"
-	"github.com/updatecli/updatecli/pkg/plugins/scms/git/sign"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
+// commitTrailerRegex matches commit message trailers such as "Co-authored-by: updatecli <updatecli@updatecli.io>"
+var commitTrailerRegex = regexp.MustCompile(`^[A-Za-z0-9-]+: \S`)
+
 const (
 	DefaultRemoteReferenceName = "origin"
+	// maxBranchNameLength defines the maximum length, in bytes, of branch names returned by SanitizeBranchName
+	maxBranchNameLength = 255
 )
 
 type GitHandler interface {
 	Add(files []string, workingDir string) error
+	AddAll(workingDir string) error
+	ApplyPatch(patchPath, workingDir string) error
+	AssertBranch(expected, workingDir string) error
+	BranchExists(name, workingDir string) (bool, error)
 	Checkout(username, password, branch, remoteBranch, workingDir string, forceReset bool) error
+	CheckoutWithContext(ctx context.Context, username, password, branch, remoteBranch, workingDir string, forceReset bool) error
+	CheckoutCommit(hash, workingDir string) error
+	CheckoutDefaultBranch(remote, workingDir string) (string, error)
 	Clone(username, password, URL, workingDir string) error
-	Commit(user, email, message, workingDir string, signingKey string, passphrase string) error
+	CloneWithContext(ctx context.Context, username, password, URL, workingDir string) error
+	CloneCommit(username, password, URL, commit, workingDir string) error
+	CloneWithToken(token, URL, workingDir string) error
+	ConflictedFiles(workingDir string) ([]string, error)
+	Commit(user, email, message, workingDir string, signingKey string, passphrase string) (string, error)
+	CommitWithContext(ctx context.Context, user, email, message, workingDir string, signingKey string, passphrase string) (string, error)
+	CommitAmend(user, email, message, workingDir string, signingKey string, passphrase string) (string, error)
+	CommitCount(ref, workingDir string) (int, error)
+	CommitCountSince(ref, workingDir string) (int, error)
+	CommitsByAuthor(email, workingDir string, since time.Time) ([]*object.Commit, error)
+	CommitTouchedFile(commit, path, workingDir string) (bool, error)
+	CompareRemoteRefs(username, password, URL, refA, refB string) (equal bool, hashA string, hashB string, err error)
+	Diff(from, to, workingDir string) ([]FileChange, error)
+	DeleteBranch(name, workingDir string) error
+	DeleteRemoteBranch(username, password, name, workingDir string) error
+	ExportPatch(workingDir, outputPath string) error
+	Fetch(username, password, workingDir string) error
+	FetchNewTags(username, password, constraint, workingDir string) ([]string, error)
 	GetChangedFiles(workingDir string) ([]string, error)
+	GetLatestCommit(workingDir string) (plumbing.Hash, error)
+	HasUncommittedGeneratedFiles(patterns []string, workingDir string) (bool, []string, error)
+	InMemoryTagRefs(username, password, URL string) ([]DatedTag, error)
 	IsSimilarBranch(a, b, workingDir string) (bool, error)
+	IsClean(workingDir string) (bool, error)
 	IsLocalBranchPublished(baseBranch, workingBranch, username, password, workingDir string) (bool, error)
 	NewTag(tag, message, workingDir string) (bool, error)
 	NewBranch(branch, workingDir string) (bool, error)
 	Push(username string, password string, workingDir string, force bool) error
+	PushWithContext(ctx context.Context, username string, password string, workingDir string, force bool) error
 	PushTag(tag string, username string, password string, workingDir string, force bool) error
 	PushBranch(branch string, username string, password string, workingDir string, force bool) error
+	PushWithOptions(username, password, workingDir string, options PushOptions) error
+	PushWithToken(token, workingDir string, force bool) error
+	RemoteBranchExists(name, workingDir string) (bool, error)
+	RemoteRefHash(username, password, URL, ref string) (string, error)
+	RemoteTagRefs(username, password, URL string) ([]DatedTag, error)
 	RemoteURLs(workingDir string) (map[string]string, error)
+	RenameBranch(oldName, newName, workingDir string) (string, error)
+	RenameRemoteBranch(oldName, newName, username, password, workingDir string) error
+	Reset(commit, workingDir, mode string) error
+	ResolveConflict(path, side, workingDir string) error
+	Repack(workingDir string) error
 	SanitizeBranchName(branch string) string
+	SetBranchTo(branch, commit, workingDir string, force bool) error
+	Status(workingDir string) (git.Status, error)
 	Tags(workingDir string) (tags []string, err error)
 	TagHashes(workingDir string) (hashes []string, err error)
 	TagRefs(workingDir string) (refs []DatedTag, err error)
 	Branches(workingDir string) (branches []string, err error)
+	RemoteBranches(workingDir string) (branches []string, err error)
+	VerifyCommitAllowedSigners(hash, workingDir string, allowed []openpgp.EntityList) (bool, string, error)
+	WorktreeHash(workingDir string) (string, error)
 }
 
 type GoGit struct {
+	// ResetRef defines the reference used by Checkout to hard reset the working branch
+	// when forceReset is set. It accepts a branch, a tag, a commit hash,
+	// or a remote branch such as "origin/main".
+	// Default to the remote working branch.
+	ResetRef string
+	// ExpectedBranch defines the branch name, or glob pattern such as "updatecli-*",
+	// that HEAD must be on before running Add, Commit, or Push.
+	// Those operations abort with an ErrUnexpectedBranch error otherwise.
+	// Default to no check.
+	ExpectedBranch string
+	// RootDir defines the directory from which relative working directories are resolved.
+	// Default to the process current working directory.
+	RootDir string
+	// RepackAfterCommits defines the number of unpacked commits from which Commit
+	// runs Repack on the repository. Default to 0 which never repacks.
+	RepackAfterCommits int
+	// InsecureSkipTLS disables the TLS certificate verification for every network git operation.
+	// Each operation logs a warning naming the host when enabled.
+	// It must only be used with self-hosted git servers, and default to false.
+	InsecureSkipTLS bool
+	// CABundlePath defines a file containing PEM encoded certificate authorities, trusted in addition
+	// to the system ones to verify the TLS certificate of git remotes, such as self-hosted git servers
+	// using a private certificate authority. Submodules are fetched using the system ones only.
+	// Default to the system certificate authorities.
+	CABundlePath string
+	// ProxyURL defines the proxy, such as "http://proxy.example.com:3128" or "socks5://proxy.example.com:1080",
+	// used by every network git operation, whatever the git remote transport.
+	// Default to the proxy defined by the HTTP_PROXY, HTTPS_PROXY, and NO_PROXY environment variables
+	// for git remotes using the http transport.
+	ProxyURL string
+	// ProxyUsername defines the user authenticating with the proxy ProxyURL.
+	// Default to empty.
+	ProxyUsername string
+	// ProxyPassword defines the password authenticating with the proxy ProxyURL, never logged.
+	// Default to empty.
+	ProxyPassword string
+	// PushRebaseRetries defines how many times a non forced Push, rejected as non-fast-forward,
+	// fetches the remote branch, rebases local commits on top of it, then retries.
+	// Default to 0 which doesn't retry.
+	PushRebaseRetries int
+	// MergeCommitStrategy defines how CommitTouchedFile handles merge commits,
+	// accepted values are MergeCommitFirstParent, MergeCommitAnyParent, and MergeCommitAllParents.
+	// Default to MergeCommitFirstParent
+	MergeCommitStrategy string
+	// VerifyAfterClone enables, after Clone and CloneCommit, an integrity check of every git object
+	// to fail early on a corrupted clone. It may be slow on large repositories.
+	VerifyAfterClone bool
+	// RemoveStaleIndexLock allows to remove a ".git/index.lock" file older than StaleIndexLockAge,
+	// usually left by a crashed git process, instead of failing.
+	RemoveStaleIndexLock bool
+	// StaleIndexLockAge defines the age after which a ".git/index.lock" file is considered stale.
+	// Default to DefaultStaleIndexLockAge
+	StaleIndexLockAge time.Duration
+	// SubmoduleCredentials defines the credentials used by Clone to fetch private git submodules,
+	// indexed by submodule name, submodule URL, or host. Submodules without matching
+	// credentials are fetched with the Clone ones.
+	SubmoduleCredentials map[string]SubmoduleCredentials
+	// DiscardProgressOnError disables attaching the git progress output, such as the git server messages,
+	// to the errors returned by remote operations like Clone, Pull, Fetch, and Push.
+	// The progress output is always written to ProgressWriter.
+	DiscardProgressOnError bool
+	// ProgressWriter receives the git progress output of remote operations like Clone, Pull, Fetch, and Push,
+	// once each operation completed and with credentials redacted, for instance to display it in a UI.
+	// Use io.Discard to silence it.
+	// Default to nil which logs the progress output at debug level.
+	ProgressWriter io.Writer
+	// AllowedRemotes restricts the git remotes used by clone and push operations, to prevent
+	// sending data to an unexpected host. Each value is either a host such as "github.com" or "*.example.com",
+	// or a scheme and a host such as "https://github.com".
+	// Default to allow every remote.
+	AllowedRemotes []string
+	// CommitCountLimit defines the number of commits after which CommitCount stops counting.
+	// Default to 0 which counts every commit.
+	CommitCountLimit int
+	// DisableCreateWorkingDir prevents Clone from creating the missing parent directories of the working directory,
+	// in which case Clone fails if the parent directory doesn't exist.
+	// Default to false which creates them.
+	DisableCreateWorkingDir bool
+	// KeepCommitMessageFormatting prevents Commit from normalizing the commit message whitespaces,
+	// for messages intentionally formatted.
+	// Default to false which trims trailing whitespaces from each line and collapses consecutive blank lines.
+	KeepCommitMessageFormatting bool
+	// ResetAmendAuthor defines if CommitAmend replaces the amended commit author by the committer,
+	// like `git commit --amend --reset-author` does.
+	// Default to false which preserves the amended commit author, including its date.
+	ResetAmendAuthor bool
+	// SSHKeyPath defines the ssh private key file used to authenticate with git remotes using the ssh transport,
+	// such as "git@github.com:updatecli/updatecli.git".
+	// Default to the ssh agent listening on SSH_AUTH_SOCK.
+	SSHKeyPath string
+	// SSHKeyPassphrase defines the passphrase of the encrypted ssh private key SSHKeyPath.
+	// Default to empty.
+	SSHKeyPassphrase string
+	// RemoteName defines the git remote used by remote operations such as Checkout, Push, or PushTag,
+	// and the name of the remote created by Clone and CloneCommit.
+	// Default to "origin".
+	RemoteName string
+	// CloneDepth defines the number of commits fetched by Clone, for a shallow clone.
+	// The following fetches keep the clone shallow.
+	// Default to 0 which clones the full history.
+	CloneDepth int
+	// CloneUpdateStrategy defines how Clone updates the repository when it was already cloned,
+	// accepted values are CloneUpdateMerge, CloneUpdateRebase, and CloneUpdateReset.
+	// Default to CloneUpdateMerge
+	CloneUpdateStrategy string
+	// CloneSingleBranch defines the only branch, such as "main", fetched by Clone and checked out,
+	// reducing the fetch overhead for repositories with many branches.
+	// The following Clone updates and fetches only retrieve that branch.
+	// Default to empty which clones every branch.
+	CloneSingleBranch string
+	// SparseCheckoutDirectories defines the directories, relative to the git repository root, materialized
+	// in the working directory by Clone and the following checkouts, resets, and pulls, like the
+	// `git sparse-checkout` cone mode, for monorepos where only a few directories are updated.
+	// Files at the repository root are always materialized. The other files are never written
+	// to the working directory, while commits leave them untouched.
+	// Default to empty which materializes every file.
+	SparseCheckoutDirectories []string
+	// FetchTags makes Fetch retrieve every remote tag, like `git fetch --tags`.
+	// Default to false which only fetches tags pointing into the fetched history.
+	FetchTags bool
+	// FetchPrune makes Fetch remove the remote-tracking branches deleted from their remote, like `git fetch --prune`.
+	// Default to false.
+	FetchPrune bool
+	// CommitTrailers defines the trailers, such as "Co-authored-by: updatecli <updatecli@updatecli.io>",
+	// appended to the message of commits created by Commit and CommitAmend.
+	// Trailers already present in the message aren't duplicated.
+	// Default to no trailer.
+	CommitTrailers []string
+	// SignOff appends a "Signed-off-by" trailer, using the committer identity, to the message of commits
+	// created by Commit and CommitAmend, like `git commit --signoff` does, for repositories requiring a DCO.
+	// Default to false.
+	SignOff bool
+	// CommitTime defines the author and committer time, including its timezone, of commits created by Commit
+	// and amended by CommitAmend, which only replaces the author time if ResetAmendAuthor is set,
+	// for reproducible commits or to align them with an upstream source timestamp.
+	// Default to the current time.
+	CommitTime time.Time
+	// CommitterTime defines the committer time of commits created by Commit and CommitAmend,
+	// when it must differ from the CommitTime author time.
+	// Default to CommitTime.
+	CommitterTime time.Time
+	// NetworkRetries defines how many times Clone and Push retry their network operations
+	// failing with a transient error, such as a connection reset or a git server rate limit.
+	// Authentication failures and rejected pushes are never retried.
+	// Default to 0 which doesn't retry.
+	NetworkRetries int
+	// NetworkRetryBackoff defines the delay before the first network retry, doubled after each attempt.
+	// Default to DefaultNetworkRetryBackoff
+	NetworkRetryBackoff time.Duration
+	// BearerToken defines the token sent as an "Authorization: Bearer" header to authenticate with
+	// git remotes using the http transport, instead of the username and password basic authentication.
+	// Bitbucket and Azure DevOps accept OAuth access tokens as bearer tokens, while GitHub,
+	// including App installation tokens and fine-grained personal access tokens, GitLab, and Gitea
+	// expect the token as the basic authentication password.
+	// Default to empty which uses basic authentication.
+	BearerToken string
+	// UseCredentialHelper makes operations on http git remotes, called without credentials,
+	// ask the git credential helpers configured for the git host, using `git credential fill`,
+	// when the environment doesn't define credentials. It requires the git binary.
+	// Default to false.
+	UseCredentialHelper bool
+	// PushOptions defines the push options, such as "merge_request.create" or "ci.skip" for GitLab,
+	// transmitted to the git server by Push, PushWithOptions, PushBranch, and PushTag,
+	// like `git push --push-option` does. Options are formatted as "key" or "key=value",
+	// an option without value being sent as "key=". Git servers not supporting push options ignore them.
+	// Default to no push option.
+	PushOptions []string
+	// CloneCacheDir defines the directory keeping a bare mirror of every git repository cloned by Clone,
+	// shared between runs and working directories. Clone updates the mirror from the git remote, clones
+	// the working directory from it, then sets the git remote back, falling back to a regular clone if the
+	// clone cache fails. Concurrent clones of the same git repository wait for each other to use its mirror,
+	// but Updatecli processes run at the same time must not share a clone cache.
+	// Default to DefaultCloneCacheDir
+	CloneCacheDir string
 }
 
 /*
@@ -73,13 +300,13 @@ returns true if both the remote and local reference are similar.
 */
 func (g GoGit) IsLocalBranchPublished(baseBranch, workingBranch, username, password, workingDir string) (bool, error) {
 
-	logrus.Debugln("Checking if local changes have been done that should be published")
-
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything excepted an empty string
-		Password: password,
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
 	}
 
+	logrus.Debugln("Checking if local changes have been done that should be published")
+
 	// Check if base branch and working branch have the same reference
 	matching, err := g.IsSimilarBranch(baseBranch, workingBranch, workingDir)
 	if err != nil {
@@ -93,15 +320,29 @@ func (g GoGit) IsLocalBranchPublished(baseBranch, workingBranch, username, passw
 
 	workingBranchReferenceName := workingBranch
 	//
-	rem, err := gitRepository.Remote(DefaultRemoteReferenceName)
+	rem, err := g.remote(gitRepository)
 	if err != nil {
 		logrus.Errorf("reference %q - %s", workingBranchReferenceName, err)
 		return false, err
 	}
 
-	listOptions := git.ListOptions{}
-	if !isAuthEmpty(&auth) {
-		listOptions.Auth = &auth
+	auth, err := g.transportAuth(remoteURL(rem), username, password)
+	if err != nil {
+		return false, err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return false, err
+	}
+
+	listOptions := git.ListOptions{
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(rem)),
+		ProxyOptions:    g.proxyOptions(remoteURL(rem)),
+		CABundle:        caBundle,
+	}
+	if auth != nil {
+		listOptions.Auth = auth
 	}
 
 	remoteRefs, err := rem.List(&listOptions)
@@ -156,6 +397,11 @@ func (g GoGit) IsLocalBranchPublished(baseBranch, workingBranch, username, passw
 // true if it's the case
 func (g GoGit) IsSimilarBranch(a, b, workingDir string) (bool, error) {
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
+	}
+
 	gitRepository, err := git.PlainOpen(workingDir)
 	if err != nil {
 		return false, err
@@ -184,17 +430,8 @@ func (g GoGit) IsSimilarBranch(a, b, workingDir string) (bool, error) {
 }
 
 func (g GoGit) GetChangedFiles(workingDir string) ([]string, error) {
-	gitRepository, err := git.PlainOpen(workingDir)
-	if err != nil {
-		return []string{}, err
-	}
 
-	gitWorktree, err := gitRepository.Worktree()
-	if err != nil {
-		return []string{}, err
-	}
-
-	gitStatus, err := gitWorktree.Status()
+	gitStatus, err := g.Status(workingDir)
 	if err != nil {
 		return []string{}, err
 	}
@@ -210,11 +447,40 @@ func (g GoGit) GetChangedFiles(workingDir string) ([]string, error) {
 	return filesChanged, nil
 }
 
-// Add run `git add`.
+// Add run `git add` with files, either files or directories, staging their changes
+// including deletions. Untracked files ignored by .gitignore aren't staged.
 func (g GoGit) Add(files []string, workingDir string) error {
 
 	logrus.Debugf("stage: git-add\n\n")
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkExpectedBranch(r); err != nil {
+		return err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	status, err := g.worktreeStatus(w)
+	if err != nil {
+		return err
+	}
+
 	for _, file := range files {
 		logrus.Debugf("adding file: %q\n", file)
 
@@ -228,29 +494,83 @@ func (g GoGit) Add(files []string, workingDir string) error {
 			file = relativeFilePath
 		}
 
-		r, err := git.PlainOpen(workingDir)
-		if err != nil {
-			return err
+		// The git status only lists changed files, including deleted ones, and untracked files not ignored
+		if _, changed := status[filepath.ToSlash(file)]; !changed {
+			info, err := os.Lstat(filepath.Join(workingDir, file))
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				return &ErrPathNotFound{Path: file}
+			case err != nil:
+				return err
+			case !info.IsDir():
+				logrus.Debugf("nothing to stage for %q, unmodified or ignored\n", file)
+				continue
+			}
 		}
 
-		w, err := r.Worktree()
+		_, err = w.Add(file)
 		if err != nil {
-			return err
+			return fmt.Errorf("adding %q: %w", file, err)
 		}
+	}
+	return nil
+}
 
-		_, err = w.Add(file)
+// AddAll run `git add --all`, staging every change of the worktree including deletions.
+// Untracked files ignored by .gitignore aren't staged.
+func (g GoGit) AddAll(workingDir string) error {
+
+	logrus.Debugf("stage: git-add-all\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkExpectedBranch(r); err != nil {
+		return err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	if g.sparse() {
+		status, err := g.worktreeStatus(w)
 		if err != nil {
 			return err
 		}
+		return g.stageChanges(w, status, true)
 	}
-	return nil
+
+	return w.AddWithOptions(&git.AddOptions{All: true})
 }
 
 // Checkout create and then uses a temporary git branch.
 func (g GoGit) Checkout(username, password, branch, remoteBranch, workingDir string, forceReset bool) error {
+	return g.CheckoutWithContext(context.Background(), username, password, branch, remoteBranch, workingDir, forceReset)
+}
+
+// CheckoutWithContext is Checkout, aborting the pull from the git remote as soon as ctx is done.
+func (g GoGit) CheckoutWithContext(ctx context.Context, username, password, branch, remoteBranch, workingDir string, forceReset bool) error {
 
 	logrus.Debugf("stage: git-checkout\n\n")
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
 	logrus.Debugf("checkout branch %q, based on %q to directory %q",
 		remoteBranch,
 		branch,
@@ -261,164 +581,173 @@ func (g GoGit) Checkout(username, password, branch, remoteBranch, workingDir str
 		return err
 	}
 
+	if err := g.checkIndexLock(r); err != nil {
+		return err
+	}
+
+	remote, err := g.remote(r)
+	if err != nil {
+		return err
+	}
+
 	w, err := r.Worktree()
 	if err != nil {
 		logrus.Debugln(err)
 		return err
 	}
 
-	//// Retrieve local branch
-	//head, err := r.Head()
-	//if err != nil {
-	//	return err
-	//}
-
-	//if head.Name().IsBranch() {
-	//	if head.Name().Short() == branch {
-	//		return nil
-	//	}
-	//}
-
 	b := bytes.Buffer{}
 
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything except an empty string
-		Password: password,
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
 	}
 
 	pullOptions := git.PullOptions{
-		Force:    true,
-		Progress: &b,
+		RemoteName:      remote.Config().Name,
+		Force:           true,
+		Progress:        &b,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
 	}
 
-	if !isAuthEmpty(&auth) {
-		pullOptions.Auth = &auth
+	if auth != nil {
+		pullOptions.Auth = auth
 	}
 
-	err = w.Pull(&pullOptions)
+	err = g.pull(ctx, r, w, &pullOptions)
 
-	logrus.Debugln(b.String())
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
 	b.Reset()
 
 	if err != nil &&
 		err != git.ErrNonFastForwardUpdate &&
 		err != git.NoErrAlreadyUpToDate {
 		logrus.Debugln(err)
-		return err
+		return g.progressError("pull", err, progress)
 	}
 
-	// If remoteBranch already exist, use it
-	// otherwise use the one define in the spec
-	err = w.Checkout(&git.CheckoutOptions{
-		Branch: plumbing.NewBranchReferenceName(remoteBranch),
-		Create: false,
-		Keep:   false,
-		Force:  true,
-	})
-
-	switch err {
-	case nil:
-		/*
-			Means that a local branch named remoteBranch already exist
-			so we want to be sure that the local branch is
-			aligned with the remote one.
-		*/
-
-		remote, err := r.Remote(DefaultRemoteReferenceName)
-		if err != nil {
-			return err
+	_, err = r.Reference(plumbing.NewBranchReferenceName(remoteBranch), false)
+	switch {
+	case err == nil:
+		// The working branch already exists locally
+		listOptions := git.ListOptions{
+			InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+			ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+			CABundle:        caBundle,
 		}
-
-		listOptions := git.ListOptions{}
-
-		if !isAuthEmpty(&auth) {
-			listOptions.Auth = &auth
+		if auth != nil {
+			listOptions.Auth = auth
 		}
+		return g.checkoutWorkingBranch(r, w, remote, &listOptions, remoteBranch, forceReset)
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		return g.createWorkingBranch(r, w, remote.Config().Name, branch, remoteBranch, forceReset)
+	default:
+		return err
+	}
+}
 
-		refs, err := remote.List(&listOptions)
-
-		if err != nil {
-			return err
-		}
+// checkoutWorkingBranch checks out the existing local working branch remoteBranch then,
+// if forceReset is set, aligns it with the reset reference if specified,
+// otherwise with its remote branch if it was published.
+func (g GoGit) checkoutWorkingBranch(r *git.Repository, w *git.Worktree, remote *git.Remote, listOptions *git.ListOptions, remoteBranch string, forceReset bool) error {
+	branchRefName := plumbing.NewBranchReferenceName(remoteBranch)
 
-		if !g.exists(
-			plumbing.NewBranchReferenceName(remoteBranch),
-			refs) {
-			logrus.Debugf("No remote name %q", remoteBranch)
-			return nil
-		}
-
-		remoteBranchRef := plumbing.NewRemoteReferenceName(DefaultRemoteReferenceName, remoteBranch)
+	err := g.checkout(r, w, &git.CheckoutOptions{
+		Branch: branchRefName,
+		Force:  true,
+	})
+	if err != nil {
+		logrus.Debugln(err)
+		return err
+	}
 
-		remoteRef, err := r.Reference(remoteBranchRef, true)
+	if !forceReset {
+		return nil
+	}
 
-		if err != nil {
-			return err
-		}
+	if g.ResetRef != "" {
+		return g.resetToRef(r, w)
+	}
 
-		if forceReset {
-			err = w.Reset(&git.ResetOptions{
-				Commit: remoteRef.Hash(),
-				Mode:   git.HardReset,
-			})
+	refs, err := remote.List(listOptions)
+	if err != nil {
+		return err
+	}
 
-			if err != nil {
-				logrus.Debugln(err)
-				return err
-			}
-		}
+	if !g.exists(branchRefName, refs) {
+		logrus.Debugf("No remote name %q", remoteBranch)
+		return nil
+	}
 
-		err = w.Checkout(&git.CheckoutOptions{
-			Branch: plumbing.NewBranchReferenceName(remoteBranch),
-			Create: false,
-			Keep:   false,
-			Force:  true,
-		})
+	remoteRef, err := r.Reference(plumbing.NewRemoteReferenceName(remote.Config().Name, remoteBranch), true)
+	if err != nil {
+		return err
+	}
 
-		if err != nil {
-			logrus.Debugln(err)
-			return err
-		}
+	return g.resetWorktree(r, w, remoteRef.Hash(), git.HardReset)
+}
 
-	case plumbing.ErrReferenceNotFound:
-		/*
-			Checkout source branch without creating it yet
-		*/
+// createWorkingBranch creates then checks out the local working branch remoteBranch,
+// either from its remote branch if it was already published, or from the branch branch.
+// If forceReset is set, the created branch is then reset to the reset reference if specified.
+func (g GoGit) createWorkingBranch(r *git.Repository, w *git.Worktree, remoteName, branch, remoteBranch string, forceReset bool) error {
+	checkoutOptions := git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(remoteBranch),
+		Create: true,
+		Force:  true,
+	}
 
-		logrus.Debugf("branch '%v' doesn't exist, creating it from branch '%v'", remoteBranch, branch)
+	remoteRef, err := r.Reference(plumbing.NewRemoteReferenceName(remoteName, remoteBranch), true)
+	switch {
+	case err == nil:
+		logrus.Debugf("branch %q doesn't exist, creating it from remote branch %q", remoteBranch, remoteRef.Name().Short())
+		checkoutOptions.Hash = remoteRef.Hash()
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		logrus.Debugf("branch %q doesn't exist, creating it from branch %q", remoteBranch, branch)
 
-		err = w.Checkout(&git.CheckoutOptions{
+		err = g.checkout(r, w, &git.CheckoutOptions{
 			Branch: plumbing.NewBranchReferenceName(branch),
-			Create: false,
-			Keep:   false,
 			Force:  true,
 		})
-
 		if err != nil {
-			logrus.Debugf("branch: '%v' - \n\t%v", branch, err)
-			return err
+			logrus.Debugf("branch: %q - \n\t%v", branch, err)
+			return branchNotFoundError(branch, err)
 		}
+	default:
+		return err
+	}
 
-		// Checkout locale branch without creating it yet
-		err = w.Checkout(&git.CheckoutOptions{
-			Branch: plumbing.NewBranchReferenceName(remoteBranch),
-			Create: true,
-			Keep:   false,
-			Force:  true,
-		})
+	if err := g.checkout(r, w, &checkoutOptions); err != nil {
+		return err
+	}
 
-		logrus.Debugf("branch %q successfully created", remoteBranch)
+	logrus.Debugf("branch %q successfully created", remoteBranch)
 
-		if err != nil {
-			return err
-		}
+	if forceReset && g.ResetRef != "" {
+		return g.resetToRef(r, w)
+	}
 
-	default:
-		logrus.Debugln(err)
+	return nil
+}
+
+// resetToRef hard resets the current branch to the commit pointed by the reset reference
+func (g GoGit) resetToRef(r *git.Repository, w *git.Worktree) error {
+	hash, err := resolveRevision(r, g.ResetRef)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	logrus.Debugf("resetting branch to reference %q (%s)", g.ResetRef, hash.String())
+
+	return g.resetWorktree(r, w, hash, git.HardReset)
 }
 
 func (g GoGit) exists(ref plumbing.ReferenceName, refs []*plumbing.Reference) bool {
@@ -430,26 +759,57 @@ func (g GoGit) exists(ref plumbing.ReferenceName, refs []*plumbing.Reference) bo
 	return false
 }
 
-// Commit run `git commit`.
-func (g GoGit) Commit(user, email, message, workingDir string, signingKey string, passphrase string) error {
+// Commit run `git commit`, then returns the hash of the created commit.
+// The commit is signed if signingKey, either an armored gpg private key, or an ssh private key or the path to one, is set.
+// An error matching ErrNothingToCommit is returned if there is no change to commit.
+func (g GoGit) Commit(user, email, message, workingDir string, signingKey string, passphrase string) (string, error) {
+	return g.CommitWithContext(context.Background(), user, email, message, workingDir, signingKey, passphrase)
+}
+
+// CommitWithContext is Commit, returning the ctx error without committing if ctx is already done.
+func (g GoGit) CommitWithContext(ctx context.Context, user, email, message, workingDir string, signingKey string, passphrase string) (string, error) {
 
 	logrus.Debugf("stage: git-commit\n\n")
 
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return "", err
+	}
+
 	r, err := git.PlainOpen(workingDir)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if err := g.checkExpectedBranch(r); err != nil {
+		return "", err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return "", err
+	}
+
+	user, email, err = commitIdentity(r, user, email)
+	if err != nil {
+		return "", err
 	}
 
 	w, err := r.Worktree()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	status, err := w.Status()
+	status, err := g.worktreeStatus(w)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	authorTime, committerTime := g.commitTimes()
+
 	commitOptions := git.CommitOptions{
 		// Several plugin
 		// We assume that updatecli is working from a clean worktree and can add all files that need to be tracked by git
@@ -458,62 +818,265 @@ func (g GoGit) Commit(user, email, message, workingDir string, signingKey string
 		Author: &object.Signature{
 			Name:  user,
 			Email: email,
-			When:  time.Now(),
+			When:  authorTime,
+		},
+		Committer: &object.Signature{
+			Name:  user,
+			Email: email,
+			When:  committerTime,
 		},
 	}
 
 	logrus.Debugf("status: %q\n", status)
 
-	if len(signingKey) > 0 {
-		key, err := sign.GetCommitSignKey(signingKey, passphrase)
-		if err != nil {
-			return err
-		}
-		commitOptions.SignKey = key
+	if !hasCommittableChanges(status) {
+		return "", wrapError(git.ErrEmptyCommit)
+	}
+
+	gpgKey, sshSigner, err := commitSigningKey(signingKey, passphrase)
+	if err != nil {
+		return "", err
+	}
+	commitOptions.SignKey = gpgKey
+
+	if !g.KeepCommitMessageFormatting {
+		message = normalizeCommitMessage(message)
+	}
+	message = appendCommitTrailers(message, g.commitTrailers(user, email))
+
+	if err := g.stageCommitAll(w, &commitOptions); err != nil {
+		return "", err
 	}
 
 	commit, err := w.Commit(message, &commitOptions)
 	if err != nil {
-		return err
+		return "", wrapError(err)
+	}
+
+	if sshSigner != nil {
+		commit, err = signCommitSSH(r, commit, sshSigner)
+		if err != nil {
+			return "", fmt.Errorf("signing commit: %w", err)
+		}
 	}
+
 	obj, err := r.CommitObject(commit)
 	if err != nil {
-		return err
+		return "", err
 	}
 	logrus.Debugf("git commit object:\n%s\n", obj)
 
-	return nil
+	// The commit is already created, repacking only speeds up subsequent git operations
+	// so failing to do so isn't an error
+	if g.RepackAfterCommits > 0 {
+		count, err := looseCommitCount(r)
+		switch {
+		case err != nil:
+			logrus.Warningf("counting unpacked git commits: %s", err)
+		case count >= g.RepackAfterCommits:
+			logrus.Debugf("%d unpacked commits, repacking git repository", count)
+			if err := repack(r); err != nil {
+				logrus.Warningf("repacking git repository after commit %s: %s", commit.String(), err)
+			}
+		}
+	}
+
+	return commit.String(), nil
 
 }
 
+// normalizeCommitMessage trims trailing whitespaces from each line of the commit message,
+// collapses consecutive blank lines, and removes leading and trailing blank lines
+// so the message ends with a single newline.
+func normalizeCommitMessage(message string) string {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// commitTimes returns the author and committer times of created commits, CommitTime and CommitterTime,
+// defaulting to the current time
+func (g GoGit) commitTimes() (author, committer time.Time) {
+	author = g.CommitTime
+	if author.IsZero() {
+		author = time.Now()
+	}
+
+	committer = g.CommitterTime
+	if committer.IsZero() {
+		committer = author
+	}
+
+	return author, committer
+}
+
+// commitTrailers returns the trailers appended to commit messages,
+// including the "Signed-off-by" one for the committer user and email if SignOff is set
+func (g GoGit) commitTrailers(user, email string) []string {
+	trailers := g.CommitTrailers
+	if g.SignOff {
+		trailers = append(trailers[:len(trailers):len(trailers)], fmt.Sprintf("Signed-off-by: %s <%s>", user, email))
+	}
+	return trailers
+}
+
+// appendCommitTrailers appends, one per line, the trailers missing from the commit message,
+// separated from the message body by a blank line unless the message already ends with trailers.
+func appendCommitTrailers(message string, trailers []string) string {
+	trimmed := strings.TrimRight(message, "\n")
+	lines := strings.Split(trimmed, "\n")
+
+	existing := map[string]bool{}
+	for _, line := range lines {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	missing := []string{}
+	for _, trailer := range trailers {
+		trailer = strings.TrimSpace(trailer)
+		if trailer == "" || existing[trailer] {
+			continue
+		}
+		existing[trailer] = true
+		missing = append(missing, trailer)
+	}
+
+	if len(missing) == 0 {
+		return message
+	}
+
+	separator := "\n\n"
+	switch {
+	case trimmed == "":
+		separator = ""
+	case isCommitTrailerParagraph(lines):
+		separator = "\n"
+	}
+
+	return trimmed + separator + strings.Join(missing, "\n") + "\n"
+}
+
+// isCommitTrailerParagraph returns true if the last paragraph of the commit message lines,
+// other than its subject, only contains trailers such as "Signed-off-by: updatecli <updatecli@updatecli.io>"
+func isCommitTrailerParagraph(lines []string) bool {
+	for i := len(lines) - 1; i > 0; i-- {
+		if lines[i] == "" {
+			return i < len(lines)-1
+		}
+		if !commitTrailerRegex.MatchString(lines[i]) {
+			return false
+		}
+	}
+	return false
+}
+
 // Clone run `git clone`.
 func (g GoGit) Clone(username, password, URL, workingDir string) error {
+	return g.CloneWithContext(context.Background(), username, password, URL, workingDir)
+}
+
+// CloneWithContext is Clone, aborting the clone, pull, or fetch from the git remote as soon as ctx is done.
+func (g GoGit) CloneWithContext(ctx context.Context, username, password, URL, workingDir string) error {
 
 	logrus.Debugf("stage: git-clone\n\n")
 
+	workingDir, err := g.absWorkingDir(workingDir, false)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkRemoteAllowed(URL); err != nil {
+		return err
+	}
+
+	if err := g.createParentDir(workingDir); err != nil {
+		return err
+	}
+
 	var repo *git.Repository
+	// rebasedHead is the branch rebased by CloneUpdateRebase, fetching every remote reference would move it back
+	var rebasedHead *plumbing.Reference
 
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything except an empty string
-		Password: password,
+	auth, err := g.transportAuth(URL, username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
 	}
 
 	var b bytes.Buffer
 	cloneOptions := git.CloneOptions{
 		URL:               URL,
+		RemoteName:        g.remoteName(),
 		Progress:          &b,
-		RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		Depth:             g.CloneDepth,
+		RecurseSubmodules: git.NoRecurseSubmodules,
+		InsecureSkipTLS:   g.insecureSkipTLS(URL),
+		ProxyOptions:      g.proxyOptions(URL),
+		CABundle:          caBundle,
+		// The sparse checkout is done once cloned
+		NoCheckout: g.sparse(),
 	}
 
-	if !isAuthEmpty(&auth) {
-		cloneOptions.Auth = &auth
+	if g.CloneSingleBranch != "" {
+		cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(g.CloneSingleBranch)
+		cloneOptions.SingleBranch = true
 	}
 
-	b.WriteString(fmt.Sprintf("cloning git repository: %s in %s\n", URL, workingDir))
-	repo, err := git.PlainClone(workingDir, false, &cloneOptions)
+	if auth != nil {
+		cloneOptions.Auth = auth
+	}
 
-	logrus.Debugln(b.String())
-	b.Reset()
+	cloned := false
+	if cacheDir := g.cloneCacheDir(); cacheDir != "" {
+		repo, err = g.cloneFromCache(ctx, cacheDir, workingDir, cloneOptions, password)
+		switch err {
+		case nil, git.ErrRepositoryAlreadyExists:
+			cloned = true
+		default:
+			logrus.Warningf("cloning git repository %q without clone cache: %s", redactCredentials(URL), err)
+		}
+	}
+
+	progress := ""
+	if !cloned {
+		b.WriteString(fmt.Sprintf("cloning git repository: %s in %s\n", URL, workingDir))
+		err = g.retryNetwork(ctx, "clone", func() (err error) {
+			repo, err = git.PlainCloneContext(ctx, workingDir, false, &cloneOptions)
+			return err
+		})
+
+		progress = redactCredentials(b.String(), password)
+		g.writeProgress(progress)
+		b.Reset()
+	}
+
+	if err == nil && g.sparse() {
+		err = g.sparseCheckoutHead(repo)
+	}
+
+	if err == nil {
+		// Submodules are updated separately so each one can use its own credentials
+		err = g.updateSubmodules(ctx, repo, username, password)
+	}
 
 	if err == git.ErrRepositoryAlreadyExists {
 		b.Reset()
@@ -528,36 +1091,51 @@ func (g GoGit) Clone(username, password, URL, workingDir string) error {
 			return err
 		}
 
-		status, err := w.Status()
+		status, err := g.worktreeStatus(w)
 		if err != nil {
 			return err
 		}
-		b.WriteString(status.String())
+		logrus.Debugf("updating git repository %q with %d changed file(s)", workingDir, len(status))
 
 		pullOptions := git.PullOptions{
-			Force:    true,
-			Progress: &b,
+			RemoteName:      g.remoteName(),
+			Force:           true,
+			Progress:        &b,
+			Depth:           g.CloneDepth,
+			InsecureSkipTLS: g.insecureSkipTLS(URL),
+			ProxyOptions:    g.proxyOptions(URL),
+			CABundle:        caBundle,
 		}
 
-		if !isAuthEmpty(&auth) {
-			pullOptions.Auth = &auth
+		if auth != nil {
+			pullOptions.Auth = auth
 		}
 
-		err = w.Pull(&pullOptions)
+		err = g.retryNetwork(ctx, "pull", func() error {
+			return g.updateClone(ctx, repo, w, &pullOptions)
+		})
 
-		logrus.Debugln(b.String())
+		progress := redactCredentials(b.String(), password)
+		g.writeProgress(progress)
 		b.Reset()
 
 		if err != nil &&
 			err != git.ErrNonFastForwardUpdate &&
 			err != git.NoErrAlreadyUpToDate {
 			logrus.Debugln(err)
-			return err
+			return g.progressError("pull", err, progress)
+		}
+
+		if g.CloneUpdateStrategy == CloneUpdateRebase {
+			rebasedHead, err = repo.Head()
+			if err != nil {
+				return err
+			}
 		}
 
 	} else if err != nil &&
 		err != git.NoErrAlreadyUpToDate {
-		return err
+		return g.progressError("clone", err, progress)
 	}
 
 	remotes, err := repo.Remotes()
@@ -566,26 +1144,51 @@ func (g GoGit) Clone(username, password, URL, workingDir string) error {
 		return err
 	}
 
+	refSpecs := []config.RefSpec{"refs/*:refs/*"}
+	if g.CloneSingleBranch != "" {
+		branchRef := plumbing.NewBranchReferenceName(g.CloneSingleBranch)
+		refSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", branchRef, branchRef))}
+	}
+
 	b.WriteString("fetching remote branches")
 	for _, r := range remotes {
 
 		fetchOptions := git.FetchOptions{
-			Progress: &b,
-			RefSpecs: []config.RefSpec{"refs/*:refs/*"},
-			Force:    true,
+			Progress:        &b,
+			RefSpecs:        refSpecs,
+			Depth:           g.CloneDepth,
+			Force:           true,
+			InsecureSkipTLS: g.insecureSkipTLS(remoteURL(r)),
+			ProxyOptions:    g.proxyOptions(remoteURL(r)),
+			CABundle:        caBundle,
 		}
-		if !isAuthEmpty(&auth) {
-			fetchOptions.Auth = &auth
+		if auth != nil {
+			fetchOptions.Auth = auth
 		}
 
-		err := r.Fetch(&fetchOptions)
+		err := g.retryNetwork(ctx, "fetch", func() error {
+			return r.FetchContext(ctx, &fetchOptions)
+		})
 
-		logrus.Debugln(b.String())
+		progress := redactCredentials(b.String(), password)
+		g.writeProgress(progress)
 		b.Reset()
 
 		if err != nil &&
 			err != git.NoErrAlreadyUpToDate &&
 			err != git.ErrBranchExists {
+			return g.progressError("fetch", err, progress)
+		}
+	}
+
+	if rebasedHead != nil {
+		if err := repo.Storer.SetReference(rebasedHead); err != nil {
+			return err
+		}
+	}
+
+	if g.VerifyAfterClone {
+		if err := verifyRepository(repo); err != nil {
 			return err
 		}
 	}
@@ -593,14 +1196,78 @@ func (g GoGit) Clone(username, password, URL, workingDir string) error {
 	return err
 }
 
-// Push run `git push`.
+// PushOptions defines the references published by PushWithOptions.
+// go-git never sends thin packs, so pushing to git servers which don't support them requires no option.
+type PushOptions struct {
+	// RefSpecs defines the references pushed, such as "refs/heads/main:refs/heads/main" or "refs/tags/v1.0.0:refs/tags/v1.0.0",
+	// to push a branch with its tags in one call.
+	// Default to the current branch pushed to its remote namesake, like Push.
+	RefSpecs []string
+	// Force force pushes every refspec, as if prefixed by "+".
+	// Default to false.
+	Force bool
+	// Atomic requests the git remote to update either all references or none of them,
+	// if the git server supports atomic pushes.
+	// Default to false which updates every reference accepted by the git server.
+	Atomic bool
+	// FollowTags also pushes the annotated tags pointing to the pushed commits, like `git push --follow-tags`.
+	// Default to false.
+	FollowTags bool
+	// Options defines push options transmitted to the git server in addition to the GoGit PushOptions ones.
+	// Default to no push option.
+	Options []string
+}
+
+// serverPushOptions returns the GoGit PushOptions, followed by options, as the go-git push options,
+// or an error if one of them can't be transmitted to the git server
+func (g GoGit) serverPushOptions(options ...string) (map[string]string, error) {
+	pushOptions := append(append([]string{}, g.PushOptions...), options...)
+	if len(pushOptions) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]string{}
+	for _, option := range pushOptions {
+		if option == "" || strings.ContainsAny(option, "\n\x00") {
+			return nil, fmt.Errorf("invalid push option %q", option)
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid push option %q, missing key", option)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// Push run `git push`, force pushing only if force is set.
+// A push rejected because the remote branch diverged returns the git error, without its progress output,
+// matching ErrNonFastForward, which can also be detected using IsNonFastForwardError.
 func (g GoGit) Push(username string, password string, workingDir string, force bool) error {
+	return g.PushWithContext(context.Background(), username, password, workingDir, force)
+}
+
+// PushWithContext is Push, aborting the push, and its fetch and rebase retries, as soon as ctx is done.
+func (g GoGit) PushWithContext(ctx context.Context, username string, password string, workingDir string, force bool) error {
+	return g.push(ctx, username, password, workingDir, PushOptions{Force: force})
+}
+
+// PushWithOptions run `git push` publishing the references defined by options,
+// by default the current branch like Push does. See PushOptions.
+func (g GoGit) PushWithOptions(username, password, workingDir string, options PushOptions) error {
+	return g.push(context.Background(), username, password, workingDir, options)
+}
+
+// push publishes the references defined by options, or the current branch if options doesn't define any
+func (g GoGit) push(ctx context.Context, username, password, workingDir string, options PushOptions) error {
 
 	logrus.Debugf("stage: git-push\n\n")
 
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything excepted an empty string
-		Password: password,
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
 	}
 
 	r, err := git.PlainOpen(workingDir)
@@ -608,64 +1275,143 @@ func (g GoGit) Push(username string, password string, workingDir string, force b
 		return err
 	}
 
-	// Retrieve local branch
-	head, err := r.Head()
+	force := options.Force
+
+	// localBranch is only set when pushing the current branch, the only push retried after a rebase
+	localBranch := ""
+	refSpecs := []config.RefSpec{}
+	for _, refSpec := range options.RefSpecs {
+		if force && !strings.HasPrefix(refSpec, "+") {
+			refSpec = "+" + refSpec
+		}
+		refSpecs = append(refSpecs, config.RefSpec(refSpec))
+	}
+
+	if len(refSpecs) == 0 {
+		// Retrieve local branch
+		head, err := r.Head()
+		if err != nil {
+			return err
+		}
+
+		if !head.Name().IsBranch() {
+			return fmt.Errorf("not pushing from a branch")
+		}
+
+		localBranch = strings.TrimPrefix(head.Name().String(), "refs/heads/")
+		localRefSpec := head.Name().String()
+
+		// By default don't force push
+		refspec := config.RefSpec(fmt.Sprintf("%s:refs/heads/%s",
+			localRefSpec,
+			localBranch))
+
+		if force {
+			refspec = config.RefSpec(fmt.Sprintf("+%s:refs/heads/%s",
+				localRefSpec,
+				localBranch))
+		}
+
+		refSpecs = append(refSpecs, refspec)
+	}
+
+	if err := g.checkExpectedBranch(r); err != nil {
+		return err
+	}
+
+	remote, err := g.remote(r)
 	if err != nil {
 		return err
 	}
 
-	if !head.Name().IsBranch() {
-		return fmt.Errorf("not pushing from a branch")
+	if err := g.checkRemoteAllowed(remoteURL(remote)); err != nil {
+		return err
 	}
 
-	localBranch := strings.TrimPrefix(head.Name().String(), "refs/heads/")
-	localRefSpec := head.Name().String()
+	for _, refspec := range refSpecs {
+		if err := refspec.Validate(); err != nil {
+			return fmt.Errorf("refspec %q: %w", refspec, err)
+		}
+	}
 
-	// By default don't force push
-	refspec := config.RefSpec(fmt.Sprintf("%s:refs/heads/%s",
-		localRefSpec,
-		localBranch))
+	serverOptions, err := g.serverPushOptions(options.Options...)
+	if err != nil {
+		return err
+	}
 
-	if force {
-		refspec = config.RefSpec(fmt.Sprintf("+%s:refs/heads/%s",
-			localRefSpec,
-			localBranch))
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
 	}
 
-	if err := refspec.Validate(); err != nil {
+	caBundle, err := g.caBundle()
+	if err != nil {
 		return err
 	}
 
 	b := bytes.Buffer{}
 
 	pushOptions := git.PushOptions{
-		Auth:     &auth,
-		Progress: &b,
-		RefSpecs: []config.RefSpec{refspec},
+		RemoteName:      remote.Config().Name,
+		Progress:        &b,
+		RefSpecs:        refSpecs,
+		Atomic:          options.Atomic,
+		FollowTags:      options.FollowTags,
+		Options:         serverOptions,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
 	}
 
-	if !isAuthEmpty(&auth) {
-		pushOptions.Auth = &auth
+	if auth != nil {
+		pushOptions.Auth = auth
 	}
 
-	// Only push one branch at a time
-	err = r.Push(&pushOptions)
+	progress := ""
+	for attempt := 1; ; attempt++ {
+		err = g.retryNetwork(ctx, "push", func() error {
+			return r.PushContext(ctx, &pushOptions)
+		})
 
-	logrus.Debugln(b.String())
-	b.Reset()
+		progress = redactCredentials(b.String(), password)
+		g.writeProgress(progress)
+		b.Reset()
+
+		if !IsNonFastForwardError(err) || force || g.PushRebaseRetries == 0 || localBranch == "" {
+			break
+		}
+
+		if attempt > g.PushRebaseRetries {
+			return fmt.Errorf("push still rejected after %d fetch and rebase attempts: %w",
+				g.PushRebaseRetries, g.progressError("push", err, progress))
+		}
+
+		logrus.Infof("push rejected as non-fast-forward, fetching and rebasing branch %q (attempt %d/%d)",
+			localBranch, attempt, g.PushRebaseRetries)
+
+		if err := g.fetchRebase(ctx, r, pushOptions.Auth, localBranch); err != nil {
+			return fmt.Errorf("fetch and rebase branch %q: %w", localBranch, err)
+		}
+	}
+
+	if IsNonFastForwardError(err) {
+		return wrapError(err)
+	}
 
 	if err != nil {
-		return err
+		return g.progressError("push", err, progress)
 	}
 
 	return nil
 }
 
-// SanitizeBranchName replace wrong character in the branch name
+// SanitizeBranchName replace wrong character in the branch name,
+// so it follows the git reference name rules, as checked by `git check-ref-format`.
+// The sanitized branch name is truncated to maxBranchNameLength bytes, without splitting a character.
 func (g GoGit) SanitizeBranchName(branch string) string {
 
 	removedCharacter := []string{
-		":", "=", "+", "$", "&", "#", "!", "@", "*", " ",
+		":", "=", "+", "$", "&", "#", "!", "@", "*", " ", "~", "^", "?",
 	}
 
 	replacedByUnderscore := []string{
@@ -679,14 +1425,51 @@ func (g GoGit) SanitizeBranchName(branch string) string {
 		branch = strings.ReplaceAll(branch, character, "_")
 	}
 
-	if len(branch) > 255 {
-		return branch[0:255]
+	// Control characters
+	branch = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, branch)
+
+	for strings.Contains(branch, "__") {
+		branch = strings.ReplaceAll(branch, "__", "_")
+	}
+	for strings.Contains(branch, "..") {
+		branch = strings.ReplaceAll(branch, "..", ".")
+	}
+
+	for len(branch) > maxBranchNameLength {
+		_, size := utf8.DecodeLastRuneInString(branch)
+		branch = branch[:len(branch)-size]
+	}
+
+	return trimBranchName(branch)
+}
+
+// trimBranchName removes the leading and trailing characters, or ".lock" suffix,
+// not allowed, or confusing, at the beginning or the end of a branch name
+func trimBranchName(branch string) string {
+	for {
+		trimmed := strings.TrimLeft(branch, "._-")
+		trimmed = strings.TrimRight(trimmed, "._")
+		trimmed = strings.TrimSuffix(trimmed, ".lock")
+
+		if trimmed == branch {
+			return branch
+		}
+		branch = trimmed
 	}
-	return branch
 }
 
 // TagHashes returns a list of the commit hashes for git tags ordered by creation time
 func (g GoGit) TagHashes(workingDir string) (hashes []string, err error) {
+
+	workingDir, err = g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return hashes, err
+	}
 	refs, err := g.TagRefs(workingDir)
 	if err != nil {
 		logrus.Errorf("problem finding tag references for %q, err: %s", workingDir, err)
@@ -711,6 +1494,11 @@ func (g GoGit) TagHashes(workingDir string) (hashes []string, err error) {
 
 // Tags returns a list of the names for git tags ordered by creation time
 func (g GoGit) Tags(workingDir string) (names []string, err error) {
+
+	workingDir, err = g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return names, err
+	}
 	refs, err := g.TagRefs(workingDir)
 	if err != nil {
 		logrus.Errorf("problem finding tag references for %q, err: %s", workingDir, err)
@@ -740,11 +1528,22 @@ type DatedTag struct {
 
 // TagRefs returns a list of git tags ordered by creation time
 func (g GoGit) TagRefs(workingDir string) (tags []DatedTag, err error) {
+
+	workingDir, err = g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return tags, err
+	}
 	r, err := git.PlainOpen(workingDir)
 	if err != nil {
 		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
 		return tags, err
 	}
+
+	return datedTagRefs(r)
+}
+
+// datedTagRefs returns the tags of the git repository r ordered by creation time
+func datedTagRefs(r *git.Repository) (tags []DatedTag, err error) {
 	tagrefs, err := r.Tags()
 	if err != nil {
 		return tags, err
@@ -794,10 +1593,22 @@ func (g GoGit) TagRefs(workingDir string) (tags []DatedTag, err error) {
 	return listOfDatedTags, err
 }
 
-// NewTag create a tag then return a boolean to indicate if
+// NewTag create a tag on HEAD then return a boolean to indicate if
 // the tag was created or not.
+// The tag is annotated, using a tagger identity resolved like the Commit author,
+// unless message is empty in which case a lightweight tag is created.
+// An ErrTagExists error is returned if the tag already exists.
 func (g GoGit) NewTag(tag, message, workingDir string) (bool, error) {
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return false, err
+	}
+
+	if err := validateReferenceName(tag); err != nil {
+		return false, err
+	}
+
 	r, err := git.PlainOpen(workingDir)
 
 	if err != nil {
@@ -811,9 +1622,28 @@ func (g GoGit) NewTag(tag, message, workingDir string) (bool, error) {
 		return false, err
 	}
 
-	_, err = r.CreateTag(tag, h.Hash(), &git.CreateTagOptions{
-		Message: message,
-	})
+	// A nil options creates a lightweight tag
+	var tagOptions *git.CreateTagOptions
+	if message != "" {
+		user, email, err := commitIdentity(r, "", "")
+		if err != nil {
+			return false, err
+		}
+
+		tagOptions = &git.CreateTagOptions{
+			Message: message,
+			Tagger: &object.Signature{
+				Name:  user,
+				Email: email,
+				When:  time.Now(),
+			},
+		}
+	}
+
+	_, err = r.CreateTag(tag, h.Hash(), tagOptions)
+	if err == git.ErrTagExists {
+		return false, &ErrTagExists{Name: tag}
+	}
 	if err != nil {
 		logrus.Errorf("create git tag error: %s", err)
 		return false, err
@@ -824,9 +1654,9 @@ func (g GoGit) NewTag(tag, message, workingDir string) (bool, error) {
 // PushTag publish a single tag created locally
 func (g GoGit) PushTag(tag string, username string, password string, workingDir string, force bool) error {
 
-	auth := transportHttp.BasicAuth{
-		Username: username, // anything except an empty string
-		Password: password,
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
 	}
 
 	r, err := git.PlainOpen(workingDir)
@@ -836,6 +1666,25 @@ func (g GoGit) PushTag(tag string, username string, password string, workingDir
 		return err
 	}
 
+	remote, err := g.remote(r)
+	if err != nil {
+		return err
+	}
+
+	if err := g.checkRemoteAllowed(remoteURL(remote)); err != nil {
+		return err
+	}
+
+	auth, err := g.transportAuth(remoteURL(remote), username, password)
+	if err != nil {
+		return err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
 	logrus.Debugf("Pushing git Tag: %q", tag)
 
 	// By default don't force push
@@ -845,29 +1694,39 @@ func (g GoGit) PushTag(tag string, username string, password string, workingDir
 		refspec = config.RefSpec("+refs/tags/" + tag + ":refs/tags/" + tag)
 	}
 
+	serverOptions, err := g.serverPushOptions()
+	if err != nil {
+		return err
+	}
+
 	b := bytes.Buffer{}
 	po := &git.PushOptions{
-		RemoteName: DefaultRemoteReferenceName,
-		Progress:   &b,
-		RefSpecs:   []config.RefSpec{refspec},
+		RemoteName:      remote.Config().Name,
+		Progress:        &b,
+		RefSpecs:        []config.RefSpec{refspec},
+		Options:         serverOptions,
+		InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+		ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+		CABundle:        caBundle,
 	}
 
-	if !isAuthEmpty(&auth) {
-		po.Auth = &auth
+	if auth != nil {
+		po.Auth = auth
 	}
 
 	err = r.Push(po)
 
-	logrus.Debugln(b.String())
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
 	b.Reset()
 
 	if err != nil {
 		if err == git.NoErrAlreadyUpToDate {
-			logrus.Infof("%q remote was up to date, no push done", DefaultRemoteReferenceName)
+			logrus.Infof("%q remote was up to date, no push done", remote.Config().Name)
 			return nil
 		}
-		logrus.Infof("push to remote %q error: %s", DefaultRemoteReferenceName, err)
-		return err
+		logrus.Infof("push to remote %q error: %s", remote.Config().Name, redactCredentials(err.Error(), password))
+		return g.progressError("push", err, progress)
 	}
 
 	return nil
@@ -877,6 +1736,11 @@ func (g GoGit) PushTag(tag string, username string, password string, workingDir
 func (g GoGit) RemoteURLs(workingDir string) (map[string]string, error) {
 	remoteList := make(map[string]string)
 
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return remoteList, err
+	}
+
 	gitRepository, err := git.PlainOpenWithOptions(workingDir, &git.PlainOpenOptions{DetectDotGit: true})
 	if err != nil {
 		return remoteList, err
@@ -893,8 +1757,3 @@ func (g GoGit) RemoteURLs(workingDir string) (map[string]string, error) {
 
 	return remoteList, nil
 }
-
-// isAuthEmpty return true if no username/password are defined
-func isAuthEmpty(auth *transportHttp.BasicAuth) bool {
-	return auth.Username == "" && auth.Password == ""
-}
@@ -1,11 +1,18 @@
 package gitgeneric
 
 import (
+	"context"
+	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -106,6 +113,42 @@ func TestSanitizeBranchName(t *testing.T) {
 			branch:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaabbb",
 			expected: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
 		},
+		{
+			branch:   "updatecli_v1.2.3~rc1^2?",
+			expected: "updatecli_v1.2.3rc12",
+		},
+		{
+			branch:   "/updatecli//main/",
+			expected: "updatecli_main",
+		},
+		{
+			branch:   "updatecli\x00\t\x7fmain",
+			expected: "updatecli_main",
+		},
+		{
+			branch:   "updatecli...v1..2",
+			expected: "updatecli.v1.2",
+		},
+		{
+			branch:   ".updatecli.lock",
+			expected: "updatecli",
+		},
+		{
+			branch:   "-updatecli.lock.",
+			expected: "updatecli",
+		},
+		{
+			branch:   "updatecli@{main}",
+			expected: "updatecli_main",
+		},
+		{
+			branch:   strings.Repeat("a", 254) + "éb",
+			expected: strings.Repeat("a", 254),
+		},
+		{
+			branch:   strings.Repeat("a", 250) + "....lock.",
+			expected: strings.Repeat("a", 250),
+		},
 	}
 	g := GoGit{}
 	for _, d := range datasets {
@@ -113,6 +156,9 @@ func TestSanitizeBranchName(t *testing.T) {
 		if got != d.expected {
 			t.Errorf("Branch name isn't correctly got %s, expected %s", got, d.expected)
 		}
+		if err := validateReferenceName(got); err != nil {
+			t.Errorf("Sanitized branch name %q is invalid: %s", got, err)
+		}
 	}
 }
 
@@ -250,3 +296,753 @@ func TestGoGit_RemoteURLs(t *testing.T) {
 		})
 	}
 }
+
+// newTestRepository initializes a local git repository, containing the provided files,
+// committed on the "main" branch, and returns its path.
+func newTestRepository(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	workingDir := t.TempDir()
+
+	r, err := git.PlainInit(workingDir, false)
+	require.NoError(t, err)
+
+	err = r.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main")))
+	require.NoError(t, err)
+
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		writeTestFile(t, workingDir, name, content)
+		_, err = w.Add(name)
+		require.NoError(t, err)
+	}
+
+	_, err = w.Commit("initial commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author: &object.Signature{
+			Name:  "updatecli",
+			Email: "updatecli@updatecli.io",
+			When:  time.Now(),
+		},
+	})
+	require.NoError(t, err)
+
+	return workingDir
+}
+
+// writeTestFile writes content to the file name located in workingDir
+func writeTestFile(t *testing.T, workingDir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(workingDir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+// newTestClone clones the local repository located at origin and returns the clone path
+func newTestClone(t *testing.T, origin string) string {
+	t.Helper()
+
+	workingDir := t.TempDir()
+	require.NoError(t, GoGit{}.Clone("", "", origin, workingDir))
+
+	return workingDir
+}
+
+// commitTestFile writes and commits a file, then returns the commit hash
+func commitTestFile(t *testing.T, workingDir, name, content string) plumbing.Hash {
+	t.Helper()
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	writeTestFile(t, workingDir, name, content)
+	_, err = w.Add(name)
+	require.NoError(t, err)
+
+	hash, err := w.Commit("update "+name, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "updatecli",
+			Email: "updatecli@updatecli.io",
+			When:  time.Now(),
+		},
+	})
+	require.NoError(t, err)
+
+	return hash
+}
+
+func TestCommitDeletionsAndModifications(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{
+		"deleted/a.txt":  "a",
+		"b.txt":          "b",
+		"modified/c.txt": "c",
+		"d.txt":          "d",
+	})
+
+	require.NoError(t, os.RemoveAll(filepath.Join(workingDir, "deleted")))
+	require.NoError(t, os.Remove(filepath.Join(workingDir, "b.txt")))
+	writeTestFile(t, workingDir, "modified/c.txt", "c updated")
+	writeTestFile(t, workingDir, "d.txt", "d updated")
+
+	g := GoGit{}
+
+	// Same sequence as the scm plugins
+	files, err := g.GetChangedFiles(workingDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"deleted/a.txt", "b.txt", "modified/c.txt", "d.txt"}, files)
+
+	// Targets may report absolute file paths, including for deleted files
+	files[0] = filepath.Join(workingDir, files[0])
+
+	require.NoError(t, g.Add(files, workingDir))
+	_, err = g.Commit("updatecli", "updatecli@updatecli.io", "update", workingDir, "", "")
+	require.NoError(t, err)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	commit, err := r.CommitObject(head.Hash())
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+
+	got := map[string]string{}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		content, err := f.Contents()
+		got[f.Name] = content
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"modified/c.txt": "c updated",
+		"d.txt":          "d updated",
+	}, got)
+
+	// Nothing left to stage
+	files, err = g.GetChangedFiles(workingDir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestCommitMessageFormatting(t *testing.T) {
+	tests := []struct {
+		name        string
+		g           GoGit
+		message     string
+		wantMessage string
+	}{
+		{
+			name:        "Single line message",
+			message:     "update",
+			wantMessage: "update\n",
+		},
+		{
+			name:        "Trailing whitespaces and excess blank lines",
+			message:     "\n\nupdate README.md  \n\n\n\t\nchanged:\r\n  * README.md \t\n\n\n",
+			wantMessage: "update README.md\n\nchanged:\n  * README.md\n",
+		},
+		{
+			name:        "Formatting kept",
+			g:           GoGit{KeepCommitMessageFormatting: true},
+			message:     "update README.md  \n\n\n",
+			wantMessage: "update README.md  \n\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			writeTestFile(t, workingDir, "README.md", "v2")
+
+			hash, err := tt.g.Commit("updatecli", "updatecli@updatecli.io", tt.message, workingDir, "", "")
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, head.Hash().String(), hash)
+			commit, err := r.CommitObject(head.Hash())
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantMessage, commit.Message)
+		})
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	workingDir := filepath.Join(t.TempDir(), "clone")
+
+	g := GoGit{}
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+	commitTestFile(t, workingDir, "README.md", "v2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := g.CloneWithContext(ctx, "", "", origin, filepath.Join(t.TempDir(), "canceled"))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = g.CheckoutWithContext(ctx, "", "", "main", "main", workingDir, false)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = g.PushWithContext(ctx, "", "", workingDir, false)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	writeTestFile(t, workingDir, "README.md", "v3")
+	_, err = g.CommitWithContext(ctx, "updatecli", "updatecli@updatecli.io", "update", workingDir, "", "")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	err = g.PushWithContext(expired, "", "", workingDir, false)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Nothing was pushed
+	o, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	originHead, err := o.Head()
+	require.NoError(t, err)
+	count, err := g.CommitCount(originHead.Hash().String(), origin)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestPushForce(t *testing.T) {
+	tests := []struct {
+		name    string
+		force   bool
+		wantErr bool
+	}{
+		{
+			name:    "Diverged remote branch rejects the push",
+			wantErr: true,
+		},
+		{
+			name:  "Force push replaces the remote branch",
+			force: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			workingDir := newTestClone(t, origin)
+
+			remoteHash := commitTestFile(t, origin, "CHANGELOG.md", "remote")
+			localHash := commitTestFile(t, workingDir, "README.md", "local")
+
+			for _, push := range []func() error{
+				func() error { return GoGit{}.Push("", "", workingDir, tt.force) },
+				func() error { return GoGit{}.PushBranch("main", "", "", workingDir, tt.force) },
+			} {
+				err := push()
+
+				o, openErr := git.PlainOpen(origin)
+				require.NoError(t, openErr)
+				originHead, headErr := o.Head()
+				require.NoError(t, headErr)
+
+				if tt.wantErr {
+					require.Error(t, err)
+					assert.True(t, IsNonFastForwardError(err), err)
+					assert.ErrorIs(t, err, ErrNonFastForward)
+					assert.Equal(t, "non-fast-forward update: refs/heads/main", err.Error())
+					assert.Equal(t, remoteHash, originHead.Hash(), "the remote branch must be left untouched")
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, localHash, originHead.Hash())
+			}
+		})
+	}
+}
+
+func TestAppendCommitTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		trailers []string
+		expected string
+	}{
+		{
+			name:     "No trailer",
+			message:  "update\n\n",
+			expected: "update\n\n",
+		},
+		{
+			name:     "Subject only",
+			message:  "update\n",
+			trailers: []string{"Signed-off-by: updatecli <updatecli@updatecli.io>"},
+			expected: "update\n\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+		},
+		{
+			name:     "Message body",
+			message:  "update\n\nchanged:\n  * README.md\n",
+			trailers: []string{"Co-authored-by: alice <alice@updatecli.io>", " ", "Signed-off-by: updatecli <updatecli@updatecli.io>"},
+			expected: "update\n\nchanged:\n  * README.md\n\nCo-authored-by: alice <alice@updatecli.io>\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+		},
+		{
+			name:     "Existing trailers",
+			message:  "update\n\nCo-authored-by: alice <alice@updatecli.io>\n",
+			trailers: []string{"Co-authored-by: alice <alice@updatecli.io>", "Signed-off-by: updatecli <updatecli@updatecli.io>"},
+			expected: "update\n\nCo-authored-by: alice <alice@updatecli.io>\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+		},
+		{
+			name:     "Duplicated trailers",
+			message:  "update",
+			trailers: []string{"Signed-off-by: updatecli <updatecli@updatecli.io>", "Signed-off-by: updatecli <updatecli@updatecli.io>"},
+			expected: "update\n\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+		},
+		{
+			name:     "Every trailer already present",
+			message:  "update\n\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+			trailers: []string{"Signed-off-by: updatecli <updatecli@updatecli.io>"},
+			expected: "update\n\nSigned-off-by: updatecli <updatecli@updatecli.io>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, appendCommitTrailers(tt.message, tt.trailers))
+		})
+	}
+}
+
+func TestCommitSignOff(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	writeTestFile(t, workingDir, "README.md", "v2")
+
+	g := GoGit{
+		CommitTrailers: []string{"Co-authored-by: alice <alice@updatecli.io>"},
+		SignOff:        true,
+	}
+
+	hash, err := g.Commit("updatecli", "updatecli@updatecli.io", "update\n\nCo-authored-by: alice <alice@updatecli.io>", workingDir, "", "")
+	require.NoError(t, err)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	commit, err := r.CommitObject(plumbing.NewHash(hash))
+	require.NoError(t, err)
+	assert.Equal(t, "update\n\nCo-authored-by: alice <alice@updatecli.io>\nSigned-off-by: updatecli <updatecli@updatecli.io>\n", commit.Message)
+	assert.Equal(t, []string{"Co-authored-by: alice <alice@updatecli.io>"}, g.CommitTrailers)
+}
+
+func TestCheckout(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		forceReset bool
+		// setup prepares the origin and cloned repositories, then returns the expected HEAD commit
+		setup func(t *testing.T, origin, workingDir string) plumbing.Hash
+	}{
+		{
+			name:   "Remote working branch exists",
+			branch: "main",
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				_, err := GoGit{}.NewBranch("updatecli", origin)
+				require.NoError(t, err)
+				// The source branch moves forward, the working branch must not be based on it
+				commitTestFile(t, origin, "README.md", "v2")
+				return branchTestHash(t, origin, "updatecli")
+			},
+		},
+		{
+			name:   "Only the source branch exists",
+			branch: "main",
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				return commitTestFile(t, origin, "README.md", "v2")
+			},
+		},
+		{
+			name:   "First time branch creation from another source branch",
+			branch: "v1",
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				v1 := branchTestHash(t, origin, "v1")
+				commitTestFile(t, origin, "README.md", "v2")
+				return v1
+			},
+		},
+		{
+			name:       "Local branch aligned with the remote branch",
+			branch:     "main",
+			forceReset: true,
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				_, err := GoGit{}.NewBranch("updatecli", workingDir)
+				require.NoError(t, err)
+				require.NoError(t, GoGit{}.PushBranch("updatecli", "", "", workingDir, false))
+				return branchTestHash(t, workingDir, "updatecli")
+			},
+		},
+		{
+			name:       "Local branch diverged from the remote branch is reset",
+			branch:     "main",
+			forceReset: true,
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				_, err := GoGit{}.NewBranch("updatecli", workingDir)
+				require.NoError(t, err)
+				require.NoError(t, GoGit{}.PushBranch("updatecli", "", "", workingDir, false))
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "updatecli", workingDir, false))
+				commitTestFile(t, workingDir, "README.md", "local")
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "main", workingDir, false))
+				return branchTestHash(t, origin, "updatecli")
+			},
+		},
+		{
+			name:   "Local branch diverged from the remote branch is kept without reset",
+			branch: "main",
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				_, err := GoGit{}.NewBranch("updatecli", workingDir)
+				require.NoError(t, err)
+				require.NoError(t, GoGit{}.PushBranch("updatecli", "", "", workingDir, false))
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "updatecli", workingDir, false))
+				local := commitTestFile(t, workingDir, "README.md", "local")
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "main", workingDir, false))
+				return local
+			},
+		},
+		{
+			name:       "Unpublished local branch is kept",
+			branch:     "main",
+			forceReset: true,
+			setup: func(t *testing.T, origin, workingDir string) plumbing.Hash {
+				_, err := GoGit{}.NewBranch("updatecli", workingDir)
+				require.NoError(t, err)
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "updatecli", workingDir, false))
+				local := commitTestFile(t, workingDir, "README.md", "local")
+				require.NoError(t, GoGit{}.Checkout("", "", "main", "main", workingDir, false))
+				return local
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			_, err := GoGit{}.NewBranch("v1", origin)
+			require.NoError(t, err)
+			workingDir := newTestClone(t, origin)
+
+			expected := tt.setup(t, origin, workingDir)
+
+			require.NoError(t, GoGit{}.Checkout("", "", tt.branch, "updatecli", workingDir, tt.forceReset))
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, plumbing.NewBranchReferenceName("updatecli"), head.Name())
+			assert.Equal(t, expected, head.Hash())
+		})
+	}
+}
+
+// branchTestHash returns the commit hash of the local branch
+func branchTestHash(t *testing.T, workingDir, branch string) plumbing.Hash {
+	t.Helper()
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	require.NoError(t, err)
+
+	return ref.Hash()
+}
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      []string
+		all        bool
+		wantStaged []string
+		wantErr    bool
+	}{
+		{
+			name:       "Named files and directories",
+			files:      []string{"modified.txt", "deleted.txt", "dir", "ignored.log"},
+			wantStaged: []string{"modified.txt", "deleted.txt", "dir/new.txt"},
+		},
+		{
+			name:    "Missing path",
+			files:   []string{"modified.txt", "doNotExist.txt"},
+			wantErr: true,
+		},
+		{
+			name:       "All changes",
+			all:        true,
+			wantStaged: []string{"modified.txt", "deleted.txt", "dir/new.txt", "new.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{
+				".gitignore":   "*.log\n",
+				"modified.txt": "v1",
+				"deleted.txt":  "v1",
+			})
+			writeTestFile(t, workingDir, "modified.txt", "v2")
+			require.NoError(t, os.Remove(filepath.Join(workingDir, "deleted.txt")))
+			writeTestFile(t, workingDir, "dir/new.txt", "new")
+			writeTestFile(t, workingDir, "new.txt", "new")
+			writeTestFile(t, workingDir, "ignored.log", "ignored")
+
+			g := GoGit{}
+			var err error
+			if tt.all {
+				err = g.AddAll(workingDir)
+			} else {
+				err = g.Add(tt.files, workingDir)
+			}
+
+			if tt.wantErr {
+				var notFound *ErrPathNotFound
+				require.True(t, errors.As(err, &notFound), err)
+				assert.Equal(t, "doNotExist.txt", notFound.Path)
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+
+			status, err := g.Status(workingDir)
+			require.NoError(t, err)
+
+			staged := []string{}
+			for file, fileStatus := range status {
+				if fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked {
+					staged = append(staged, file)
+				}
+			}
+			assert.ElementsMatch(t, tt.wantStaged, staged)
+		})
+	}
+}
+
+func TestCommitTime(t *testing.T) {
+	upstream := time.Date(2023, time.March, 14, 15, 9, 26, 0, time.FixedZone("IST", 5*3600+1800))
+	committed := time.Date(2023, time.March, 15, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		g             GoGit
+		wantAuthor    time.Time
+		wantCommitter time.Time
+	}{
+		{
+			name: "Current time by default",
+		},
+		{
+			name:          "Author and committer time",
+			g:             GoGit{CommitTime: upstream},
+			wantAuthor:    upstream,
+			wantCommitter: upstream,
+		},
+		{
+			name:          "Separate committer time",
+			g:             GoGit{CommitTime: upstream, CommitterTime: committed},
+			wantAuthor:    upstream,
+			wantCommitter: committed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			writeTestFile(t, workingDir, "README.md", "v2")
+
+			before := time.Now().Truncate(time.Second)
+			hash, err := tt.g.Commit("updatecli", "updatecli@updatecli.io", "update", workingDir, "", "")
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			commit, err := r.CommitObject(plumbing.NewHash(hash))
+			require.NoError(t, err)
+
+			if tt.wantAuthor.IsZero() {
+				assert.False(t, commit.Author.When.Before(before), commit.Author.When)
+				assert.Equal(t, commit.Author.When, commit.Committer.When)
+				return
+			}
+
+			assert.Equal(t, tt.wantAuthor.Unix(), commit.Author.When.Unix())
+			assert.Equal(t, tt.wantAuthor.Format("-0700"), commit.Author.When.Format("-0700"))
+			assert.Equal(t, tt.wantCommitter.Unix(), commit.Committer.When.Unix())
+			assert.Equal(t, tt.wantCommitter.Format("-0700"), commit.Committer.When.Format("-0700"))
+
+			// Amending only replaces the committer time, unless the author is reset
+			amended, err := tt.g.CommitAmend("updatecli", "updatecli@updatecli.io", "", workingDir, "", "")
+			require.NoError(t, err)
+			commit, err = r.CommitObject(plumbing.NewHash(amended))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAuthor.Unix(), commit.Author.When.Unix())
+			assert.Equal(t, tt.wantCommitter.Unix(), commit.Committer.When.Unix())
+		})
+	}
+}
+
+func TestPushServerOptions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary required to run the git server hooks")
+	}
+
+	tests := []struct {
+		name        string
+		pushOptions []string
+		options     PushOptions
+		wantOptions string
+		wantErr     bool
+	}{
+		{
+			name:        "GitLab push options",
+			pushOptions: []string{"merge_request.create", "merge_request.target=main"},
+			wantOptions: "merge_request.create=\nmerge_request.target=main\n",
+		},
+		{
+			name:        "Push options per call",
+			pushOptions: []string{"ci.skip"},
+			options:     PushOptions{Options: []string{"merge_request.label=dependencies"}},
+			wantOptions: "ci.skip=\nmerge_request.label=dependencies\n",
+		},
+		{
+			name: "No push option",
+		},
+		{
+			name:        "Invalid push option",
+			pushOptions: []string{"=main"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			received := filepath.Join(t.TempDir(), "push-options")
+			// The git server records the received push options, sorted as go-git sends them in any order
+			hook := filepath.Join(origin, ".git", "hooks", "pre-receive")
+			require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o755))
+			require.NoError(t, os.WriteFile(hook, []byte(`#!/bin/sh
+i=0
+while [ "$i" -lt "${GIT_PUSH_OPTION_COUNT:-0}" ]; do
+	eval "echo \"\$GIT_PUSH_OPTION_$i\""
+	i=$((i+1))
+done | sort > `+received+`
+`), 0o755))
+			cmd := exec.Command("git", "config", "receive.advertisePushOptions", "true")
+			cmd.Dir = origin
+			require.NoError(t, cmd.Run())
+
+			workingDir := newTestClone(t, origin)
+			commitTestFile(t, workingDir, "README.md", "v2")
+
+			g := GoGit{PushOptions: tt.pushOptions}
+			err := g.PushWithOptions("", "", workingDir, tt.options)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(received)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOptions, string(got))
+		})
+	}
+}
+
+func TestPushWithOptions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary required to run the git server hooks")
+	}
+
+	tests := []struct {
+		name         string
+		options      PushOptions
+		wantErr      string
+		wantAccepted bool
+		wantTag      bool
+	}{
+		{
+			name: "Branch and tag",
+			options: PushOptions{RefSpecs: []string{
+				"refs/heads/updatecli_accepted:refs/heads/updatecli_accepted",
+				"refs/tags/v1.0.0:refs/tags/v1.0.0",
+			}},
+			wantAccepted: true,
+			wantTag:      true,
+		},
+		{
+			name: "Partially rejected push",
+			options: PushOptions{RefSpecs: []string{
+				"refs/heads/updatecli_accepted:refs/heads/updatecli_accepted",
+				"refs/heads/updatecli_rejected:refs/heads/updatecli_rejected",
+			}},
+			wantErr:      "refs/heads/updatecli_rejected",
+			wantAccepted: true,
+		},
+		{
+			name: "Atomic push rejected",
+			options: PushOptions{
+				RefSpecs: []string{
+					"refs/heads/updatecli_accepted:refs/heads/updatecli_accepted",
+					"refs/heads/updatecli_rejected:refs/heads/updatecli_rejected",
+				},
+				Atomic: true,
+			},
+			wantErr: "refs/heads/updatecli_rejected",
+		},
+		{
+			name:    "Invalid refspec",
+			options: PushOptions{RefSpecs: []string{"refs/heads/*:refs/heads/main"}},
+			wantErr: `refspec "refs/heads/*:refs/heads/main"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			// The git server rejects any update of the updatecli_rejected branch
+			hook := filepath.Join(origin, ".git", "hooks", "update")
+			require.NoError(t, os.MkdirAll(filepath.Dir(hook), 0o755))
+			require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\n[ \"$1\" != refs/heads/updatecli_rejected ]\n"), 0o755))
+
+			workingDir := newTestClone(t, origin)
+			g := GoGit{}
+			latest := commitTestFile(t, workingDir, "README.md", "v2")
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			for _, branch := range []string{"updatecli_accepted", "updatecli_rejected"} {
+				require.NoError(t, r.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), latest)))
+			}
+			createTestTag(t, workingDir, "v1.0.0")
+
+			err = g.PushWithOptions("", "", workingDir, tt.options)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			o, err := git.PlainOpen(origin)
+			require.NoError(t, err)
+			_, err = o.Reference(plumbing.NewBranchReferenceName("updatecli_accepted"), false)
+			assert.Equal(t, tt.wantAccepted, err == nil, "accepted branch pushed")
+			_, err = o.Reference(plumbing.NewBranchReferenceName("updatecli_rejected"), false)
+			assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound, "rejected branch pushed")
+			_, err = o.Reference(plumbing.NewTagReferenceName("v1.0.0"), false)
+			assert.Equal(t, tt.wantTag, err == nil, "tag pushed")
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+The in-memory operations fetch the git repository located at URL into go-git's memory storage,
+instead of cloning it into a working directory, for read-only operations such as
+listing tags.
+
+Nothing is written on disk, but the whole fetched history is held in memory,
+so CloneDepth should be considered for big git repositories.
+*/
+
+// InMemoryTagRefs fetches in memory the git repository located at URL, then returns its tags ordered by creation time,
+// like TagRefs, without cloning it into a working directory.
+func (g GoGit) InMemoryTagRefs(username, password, URL string) ([]DatedTag, error) {
+	r, err := g.fetchInMemory(context.Background(), username, password, URL)
+	if err != nil {
+		return nil, err
+	}
+
+	return datedTagRefs(r)
+}
+
+// fetchInMemory fetches the branches and tags of the git repository located at URL into a bare in-memory repository.
+// Remote branches are fetched as local branches, like `git clone --mirror`, so they can be resolved by name.
+func (g GoGit) fetchInMemory(ctx context.Context, username, password, URL string) (*git.Repository, error) {
+
+	logrus.Debugf("stage: git-fetch-in-memory\n\n")
+
+	if err := g.checkRemoteAllowed(URL); err != nil {
+		return nil, err
+	}
+
+	auth, err := g.transportAuth(URL, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := r.CreateRemote(&config.RemoteConfig{
+		Name: g.remoteName(),
+		URLs: []string{URL},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fetchOptions := git.FetchOptions{
+		RemoteName: g.remoteName(),
+		RefSpecs: []config.RefSpec{
+			"+refs/heads/*:refs/heads/*",
+			"+refs/tags/*:refs/tags/*",
+		},
+		Depth:           g.CloneDepth,
+		Tags:            git.AllTags,
+		Progress:        &b,
+		InsecureSkipTLS: g.insecureSkipTLS(URL),
+		ProxyOptions:    g.proxyOptions(URL),
+		CABundle:        caBundle,
+	}
+	if auth != nil {
+		fetchOptions.Auth = auth
+	}
+
+	err = g.retryNetwork(ctx, "fetch", func() error {
+		b.Reset()
+		return remote.FetchContext(ctx, &fetchOptions)
+	})
+
+	progress := redactCredentials(b.String(), password)
+	g.writeProgress(progress)
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, g.progressError("fetch", err, progress)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,55 @@
+package gitgeneric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryOperations(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{
+		"README.md": "v1",
+	})
+
+	r, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.0", head.Hash(), nil)
+	require.NoError(t, err)
+
+	// Commit dates have a one second precision
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	writeTestFile(t, origin, "README.md", "v2")
+	_, err = w.Add("README.md")
+	require.NoError(t, err)
+	hash, err := w.Commit("update README.md", &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "updatecli",
+			Email: "updatecli@updatecli.io",
+			When:  time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	_, err = r.CreateTag("v0.9.0", hash, nil)
+	require.NoError(t, err)
+
+	g := GoGit{}
+
+	t.Run("tags ordered by creation time", func(t *testing.T) {
+		tags, err := g.InMemoryTagRefs("", "", origin)
+		require.NoError(t, err)
+
+		var names []string
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		assert.Equal(t, []string{"v1.0.0", "v0.9.0"}, names)
+	})
+}
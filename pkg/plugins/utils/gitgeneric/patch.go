@@ -0,0 +1,396 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// patchContextLines defines the number of unchanged lines surrounding each hunk, like git does by default
+	patchContextLines = 3
+	// binaryPatchLineLength defines the maximum number of bytes encoded per line of a git binary patch
+	binaryPatchLineLength = 52
+)
+
+// base85Alphabet is the base85 alphabet used by git binary patches
+const base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// ExportPatch writes the differences between HEAD and the working directory into outputPath,
+// using the git patch format which can be consumed by `git apply`.
+// Both staged and unstaged modifications of tracked files are exported, while untracked files are ignored.
+// Binary files are exported using the git binary patch format.
+func (g GoGit) ExportPatch(workingDir, outputPath string) error {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	filePatches, err := g.worktreeFilePatches(r)
+	if err != nil {
+		return err
+	}
+
+	b := bytes.Buffer{}
+	for _, filePatch := range filePatches {
+		if err := writeFilePatch(&b, filePatch); err != nil {
+			return err
+		}
+	}
+
+	logrus.Debugf("exporting %d file changes to %q", len(filePatches), outputPath)
+
+	return os.WriteFile(outputPath, b.Bytes(), 0o644)
+}
+
+// worktreeFilePatches returns, sorted by path, the file patches between HEAD and the working directory
+func (g GoGit) worktreeFilePatches(r *git.Repository) ([]*worktreeFilePatch, error) {
+
+	head, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := g.worktreeStatus(w)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for file, s := range status {
+		if s.Worktree == git.Untracked {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	filePatches := []*worktreeFilePatch{}
+	for _, file := range files {
+		from, err := treePatchFile(headTree, file)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := worktreePatchFile(w.Filesystem.Root(), file)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case from == nil && to == nil:
+			continue
+		case from != nil && to != nil && from.hash == to.hash && from.mode == to.mode:
+			continue
+		}
+
+		filePatches = append(filePatches, newWorktreeFilePatch(from, to))
+	}
+
+	return filePatches, nil
+}
+
+// treePatchFile returns the file located at path in tree, or nil if it doesn't exist
+func treePatchFile(tree *object.Tree, path string) (*patchFile, error) {
+	f, err := tree.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := f.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return &patchFile{
+		path:    path,
+		hash:    f.Hash,
+		mode:    f.Mode,
+		content: content,
+	}, nil
+}
+
+// worktreePatchFile returns the file located at path in the worktree, or nil if it doesn't exist
+func worktreePatchFile(rootDir, path string) (*patchFile, error) {
+	filePath := filepath.Join(rootDir, filepath.FromSlash(path))
+
+	info, err := os.Lstat(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if mode == filemode.Symlink {
+		target, err := os.Readlink(filePath)
+		if err != nil {
+			return nil, err
+		}
+		content = []byte(target)
+	} else {
+		content, err = os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &patchFile{
+		path:    path,
+		hash:    plumbing.ComputeHash(plumbing.BlobObject, content),
+		mode:    mode,
+		content: string(content),
+	}, nil
+}
+
+// writeFilePatch writes filePatch into b using the git patch format
+func writeFilePatch(b *bytes.Buffer, filePatch *worktreeFilePatch) error {
+	patch := worktreePatch{filePatches: []fdiff.FilePatch{filePatch}}
+
+	if !filePatch.binary {
+		return fdiff.NewUnifiedEncoder(b, patchContextLines).Encode(patch)
+	}
+
+	// The unified encoder only reports that binary files differ,
+	// so its last line is replaced by the binary patch which git needs to apply it.
+	header := bytes.Buffer{}
+	if err := fdiff.NewUnifiedEncoder(&header, patchContextLines).Encode(patch); err != nil {
+		return err
+	}
+
+	h := header.String()
+	if i := strings.LastIndex(h, "\nBinary files "); i >= 0 {
+		h = h[:i+1]
+	}
+	b.WriteString(h)
+
+	content := ""
+	if filePatch.to != nil {
+		content = filePatch.to.content
+	}
+
+	b.WriteString("GIT binary patch\n")
+	return writeBinaryLiteral(b, []byte(content))
+}
+
+// writeBinaryLiteral writes data as a git binary patch literal, zlib compressed then base85 encoded
+func writeBinaryLiteral(b *bytes.Buffer, data []byte) error {
+	compressed := bytes.Buffer{}
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, "literal %d\n", len(data))
+
+	c := compressed.Bytes()
+	for len(c) > 0 {
+		n := len(c)
+		if n > binaryPatchLineLength {
+			n = binaryPatchLineLength
+		}
+
+		// The first character of each line encodes its decoded length
+		if n <= 26 {
+			b.WriteByte(byte('A' + n - 1))
+		} else {
+			b.WriteByte(byte('a' + n - 27))
+		}
+		b.WriteString(encodeBase85(c[:n]))
+		b.WriteByte('\n')
+
+		c = c[n:]
+	}
+	b.WriteByte('\n')
+
+	return nil
+}
+
+// encodeBase85 encodes data by groups of 4 bytes, padded with zeros, into 5 characters
+func encodeBase85(data []byte) string {
+	sb := strings.Builder{}
+	for len(data) > 0 {
+		var acc uint32
+		for i := 0; i < 4; i++ {
+			acc <<= 8
+			if i < len(data) {
+				acc |= uint32(data[i])
+			}
+		}
+
+		var group [5]byte
+		for i := 4; i >= 0; i-- {
+			group[i] = base85Alphabet[acc%85]
+			acc /= 85
+		}
+		sb.Write(group[:])
+
+		if len(data) < 4 {
+			break
+		}
+		data = data[4:]
+	}
+	return sb.String()
+}
+
+// worktreePatch implements the go-git diff.Patch interface
+type worktreePatch struct {
+	filePatches []fdiff.FilePatch
+}
+
+func (p worktreePatch) FilePatches() []fdiff.FilePatch {
+	return p.filePatches
+}
+
+func (p worktreePatch) Message() string {
+	return ""
+}
+
+// worktreeFilePatch implements the go-git diff.FilePatch interface
+type worktreeFilePatch struct {
+	from, to *patchFile
+	binary   bool
+	chunks   []fdiff.Chunk
+}
+
+// newWorktreeFilePatch computes the changes from a file to another one, where nil means that the file doesn't exist
+func newWorktreeFilePatch(from, to *patchFile) *worktreeFilePatch {
+	p := worktreeFilePatch{from: from, to: to}
+
+	var fromContent, toContent string
+	if from != nil {
+		fromContent = from.content
+		p.binary = p.binary || isBinaryContent(fromContent)
+	}
+	if to != nil {
+		toContent = to.content
+		p.binary = p.binary || isBinaryContent(toContent)
+	}
+
+	if p.binary {
+		return &p
+	}
+
+	for _, d := range diff.Do(fromContent, toContent) {
+		var op fdiff.Operation
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = fdiff.Equal
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		}
+		p.chunks = append(p.chunks, patchChunk{content: d.Text, op: op})
+	}
+
+	return &p
+}
+
+func (p *worktreeFilePatch) IsBinary() bool {
+	return p.binary
+}
+
+func (p *worktreeFilePatch) Files() (from, to fdiff.File) {
+	// Avoid returning typed nil values which wouldn't be detected as missing files
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
+
+func (p *worktreeFilePatch) Chunks() []fdiff.Chunk {
+	return p.chunks
+}
+
+// patchFile implements the go-git diff.File interface
+type patchFile struct {
+	path    string
+	hash    plumbing.Hash
+	mode    filemode.FileMode
+	content string
+}
+
+func (f *patchFile) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f *patchFile) Mode() filemode.FileMode {
+	return f.mode
+}
+
+func (f *patchFile) Path() string {
+	return f.path
+}
+
+// patchChunk implements the go-git diff.Chunk interface
+type patchChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c patchChunk) Content() string {
+	return c.content
+}
+
+func (c patchChunk) Type() fdiff.Operation {
+	return c.op
+}
+
+// isBinaryContent returns true if content looks like binary data, using the same heuristic as git
+func isBinaryContent(content string) bool {
+	isBinary, err := binary.IsBinary(strings.NewReader(content))
+	return err == nil && isBinary
+}
@@ -0,0 +1,97 @@
+package gitgeneric
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPatch(t *testing.T) {
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary required to apply the exported patch")
+	}
+
+	origin := newTestRepository(t, map[string]string{
+		"README.md":    "line 1\nline 2\nline 3\n",
+		"DELETED.md":   "deleted\n",
+		"logo.png":     "\x89PNG\x00\x01\x02",
+		"NO_EOL.md":    "no end of line",
+		"UNCHANGED.md": "unchanged\n",
+	})
+	workingDir := newTestClone(t, origin)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	// Large enough to span several binary patch lines once compressed
+	largeBinary := make([]byte, 4096)
+	for i, seed := 0, uint32(1); i < len(largeBinary); i++ {
+		seed = seed*1103515245 + 12345
+		largeBinary[i] = byte(seed >> 16)
+	}
+
+	expected := map[string]string{
+		"README.md":    "line 1\nline 2 updated\nline 3\n",
+		"logo.png":     "\x89PNG\x00\x03\x04\x05",
+		"NO_EOL.md":    "no end of line updated",
+		"NEW.md":       "new\n",
+		"icon.bin":     "\x00\x01\x02\x03",
+		"large.bin":    string(largeBinary),
+		"UNCHANGED.md": "unchanged\n",
+	}
+	for name, content := range expected {
+		writeTestFile(t, workingDir, name, content)
+	}
+	// New files must be tracked to be exported
+	_, err = w.Add("NEW.md")
+	require.NoError(t, err)
+	_, err = w.Add("icon.bin")
+	require.NoError(t, err)
+	_, err = w.Add("large.bin")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(workingDir, "DELETED.md")))
+	writeTestFile(t, workingDir, "UNTRACKED.md", "untracked\n")
+
+	patchFile := filepath.Join(t.TempDir(), "updatecli.patch")
+	require.NoError(t, GoGit{}.ExportPatch(workingDir, patchFile))
+
+	patch, err := os.ReadFile(patchFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(patch), "GIT binary patch")
+	assert.NotContains(t, string(patch), "UNTRACKED.md")
+	assert.NotContains(t, string(patch), "UNCHANGED.md")
+
+	checkout := newTestClone(t, origin)
+
+	cmd := exec.Command(gitBinary, "apply", patchFile)
+	cmd.Dir = checkout
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	for name, content := range expected {
+		got, err := os.ReadFile(filepath.Join(checkout, name))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(got), name)
+	}
+	assert.NoFileExists(t, filepath.Join(checkout, "DELETED.md"))
+	assert.NoFileExists(t, filepath.Join(checkout, "UNTRACKED.md"))
+}
+
+func TestExportPatchNoChange(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	patchFile := filepath.Join(t.TempDir(), "updatecli.patch")
+	require.NoError(t, GoGit{}.ExportPatch(workingDir, patchFile))
+
+	patch, err := os.ReadFile(patchFile)
+	require.NoError(t, err)
+	assert.Empty(t, patch)
+}
@@ -0,0 +1,234 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+)
+
+// IsNonFastForwardError returns true if err is a push rejected because
+// the remote branch contains commits missing from the local one, such as returned
+// by Push and PushBranch when not forcing.
+func IsNonFastForwardError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "non-fast-forward")
+}
+
+// fetchRebase fetches the remote branch then rebases the local branch on top of it
+func (g GoGit) fetchRebase(ctx context.Context, r *git.Repository, auth transport.AuthMethod, branch string) error {
+
+	remoteRefName := plumbing.NewRemoteReferenceName(g.remoteName(), branch)
+
+	caBundle, err := g.caBundle()
+	if err != nil {
+		return err
+	}
+
+	b := bytes.Buffer{}
+	fetchOptions := git.FetchOptions{
+		RemoteName: g.remoteName(),
+		Progress:   &b,
+		RefSpecs: []config.RefSpec{
+			config.RefSpec(fmt.Sprintf("+%s:%s", plumbing.NewBranchReferenceName(branch), remoteRefName)),
+		},
+		InsecureSkipTLS: g.insecureSkipTLS(repositoryRemoteURL(r, g.remoteName())),
+		ProxyOptions:    g.proxyOptions(repositoryRemoteURL(r, g.remoteName())),
+		CABundle:        caBundle,
+	}
+	if auth != nil {
+		fetchOptions.Auth = auth
+	}
+
+	err = r.FetchContext(ctx, &fetchOptions)
+
+	progress := redactCredentials(b.String())
+	g.writeProgress(progress)
+	b.Reset()
+
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return g.progressError("fetch", err, progress)
+	}
+
+	remoteRef, err := r.Reference(remoteRefName, true)
+	if err != nil {
+		return err
+	}
+
+	return g.rebaseOnto(r, remoteRef.Hash())
+}
+
+/*
+rebaseOnto replays the commits from the current branch, that are not reachable from upstream,
+on top of the upstream commit, similarly to `git rebase`.
+
+Each commit is replayed by applying its file changes, keeping its original author and message.
+The rebase is aborted, and the branch left untouched, as soon as a replayed file
+was also modified upstream, or if a merge commit needs to be replayed.
+Replayed commits are not signed.
+*/
+func (g GoGit) rebaseOnto(r *git.Repository, upstream plumbing.Hash) error {
+
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+
+	upstreamCommit, err := r.CommitObject(upstream)
+	if err != nil {
+		return err
+	}
+
+	bases, err := headCommit.MergeBase(upstreamCommit)
+	if err != nil {
+		return err
+	}
+	if len(bases) == 0 {
+		return fmt.Errorf("no common ancestor between %q and %q", head.Hash(), upstream)
+	}
+	base := bases[0].Hash
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	switch base {
+	case upstream:
+		// The local branch already contains upstream
+		return nil
+	case head.Hash():
+		// Fast forward
+		return g.resetWorktree(r, w, upstream, git.HardReset)
+	}
+
+	// Retrieve the local commits to replay, from the oldest to the newest
+	commits := []*object.Commit{}
+	for c := headCommit; c.Hash != base; {
+		if c.NumParents() != 1 {
+			return fmt.Errorf("rebasing commit %q with %d parents not supported", c.Hash, c.NumParents())
+		}
+		commits = append([]*object.Commit{c}, commits...)
+
+		c, err = c.Parent(0)
+		if err != nil {
+			return err
+		}
+	}
+
+	logrus.Debugf("rebasing %d commits on top of %q", len(commits), upstream)
+
+	if err := g.resetWorktree(r, w, upstream, git.HardReset); err != nil {
+		return err
+	}
+
+	for _, c := range commits {
+		if err := replayCommit(r, w, c); err != nil {
+			logrus.Debugf("aborting rebase: %s", err)
+			if resetErr := g.resetWorktree(r, w, head.Hash(), git.HardReset); resetErr != nil {
+				return errors.Join(err, resetErr)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replayCommit applies the file changes introduced by commit c on top of the worktree HEAD,
+// then commits them with the original author and message.
+func replayCommit(r *git.Repository, w *git.Worktree, c *object.Commit) error {
+
+	parent, err := c.Parent(0)
+	if err != nil {
+		return err
+	}
+
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return err
+	}
+
+	commitTree, err := c.Tree()
+	if err != nil {
+		return err
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return err
+	}
+
+	changes, err := object.DiffTree(parentTree, commitTree)
+	if err != nil {
+		return err
+	}
+
+	// Check for conflicts before modifying the worktree
+	for _, change := range changes {
+		if change.From.Name != "" {
+			f, err := headTree.File(change.From.Name)
+			if err != nil || f.Hash != change.From.TreeEntry.Hash {
+				return fmt.Errorf("conflict on file %q while rebasing commit %q", change.From.Name, c.Hash)
+			}
+		}
+		if change.To.Name != "" && change.To.Name != change.From.Name {
+			if _, err := headTree.File(change.To.Name); err == nil {
+				return fmt.Errorf("conflict on file %q while rebasing commit %q", change.To.Name, c.Hash)
+			}
+		}
+	}
+
+	for _, change := range changes {
+		if change.From.Name != "" && change.From.Name != change.To.Name {
+			if _, err := w.Remove(change.From.Name); err != nil {
+				return err
+			}
+		}
+
+		if change.To.Name != "" {
+			filePath := filepath.Join(w.Filesystem.Root(), filepath.FromSlash(change.To.Name))
+			if err := writeBlob(r, change.To.TreeEntry.Hash, change.To.TreeEntry.Mode, filePath); err != nil {
+				return err
+			}
+			if _, err := w.Add(change.To.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	committer := c.Author
+	committer.When = time.Now()
+
+	_, err = w.Commit(c.Message, &git.CommitOptions{
+		Author:            &c.Author,
+		Committer:         &committer,
+		AllowEmptyCommits: true,
+	})
+
+	return err
+}
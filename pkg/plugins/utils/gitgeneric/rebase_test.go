@@ -0,0 +1,86 @@
+package gitgeneric
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushRebaseRetries(t *testing.T) {
+	tests := []struct {
+		name        string
+		retries     int
+		remoteFile  string
+		wantErr     bool
+		wantRebased bool
+	}{
+		{
+			name:        "Rebase on top of a remote change",
+			retries:     1,
+			remoteFile:  "CHANGELOG.md",
+			wantRebased: true,
+		},
+		{
+			name:       "No retry by default",
+			remoteFile: "CHANGELOG.md",
+			wantErr:    true,
+		},
+		{
+			name:       "Conflicting remote change",
+			retries:    3,
+			remoteFile: "README.md",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+			workingDir := newTestClone(t, origin)
+
+			remoteHash := commitTestFile(t, origin, tt.remoteFile, "remote")
+			localHash := commitTestFile(t, workingDir, "README.md", "local")
+
+			err := GoGit{PushRebaseRetries: tt.retries}.Push("", "", workingDir, false)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				// The local branch is left untouched
+				r, err := git.PlainOpen(workingDir)
+				require.NoError(t, err)
+				head, err := r.Head()
+				require.NoError(t, err)
+				assert.Equal(t, localHash, head.Hash())
+				return
+			}
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(origin)
+			require.NoError(t, err)
+
+			ref, err := r.Reference(plumbing.NewBranchReferenceName("main"), true)
+			require.NoError(t, err)
+
+			commit, err := r.CommitObject(ref.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, "update README.md", commit.Message)
+
+			parent, err := commit.Parent(0)
+			require.NoError(t, err)
+			assert.Equal(t, remoteHash, parent.Hash, "local commit should be rebased on top of the remote one")
+
+			tree, err := commit.Tree()
+			require.NoError(t, err)
+			for name, content := range map[string]string{"README.md": "local", "CHANGELOG.md": "remote"} {
+				f, err := tree.File(name)
+				require.NoError(t, err)
+				got, err := f.Contents()
+				require.NoError(t, err)
+				assert.Equal(t, content, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package gitgeneric
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+// resolveRevision returns the commit hash pointed by ref.
+// ref can be a commit hash, a local branch, a tag, or a remote branch such as "origin/main".
+// Annotated tags are resolved to the commit they point to.
+func resolveRevision(r *git.Repository, ref string) (plumbing.Hash, error) {
+	if ref == "" {
+		return plumbing.ZeroHash, fmt.Errorf("empty git reference")
+	}
+
+	h, err := r.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("resolve git reference %q: %w", ref, err)
+	}
+
+	return *h, nil
+}
+
+// resetWorktree resets the worktree w, of the git repository r, to the commit hash, using the reset mode.
+// With a sparse checkout, only the sparse checkout directories are reset in the working directory.
+func (g GoGit) resetWorktree(r *git.Repository, w *git.Worktree, hash plumbing.Hash, mode git.ResetMode) error {
+	logrus.Debugf("resetting worktree to commit %q", hash.String())
+
+	var err error
+	if g.sparse() {
+		// Only move the branch, as go-git would write every file to the working directory
+		err = w.Reset(&git.ResetOptions{
+			Commit: hash,
+			Mode:   git.SoftReset,
+		})
+		if err == nil && mode != git.SoftReset {
+			err = g.sparseReset(r, hash, mode != git.MixedReset)
+		}
+	} else {
+		err = w.Reset(&git.ResetOptions{
+			Commit: hash,
+			Mode:   mode,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("reset worktree to commit %q: %w", hash.String(), err)
+	}
+
+	return nil
+}
+
+// validateReferenceName returns an ErrInvalidReferenceName error if name, a branch or tag name,
+// doesn't follow the git reference name rules, as checked by `git check-ref-format`
+func validateReferenceName(name string) error {
+	invalid := func(reason string) error {
+		return &ErrInvalidReferenceName{Name: name, Reason: reason}
+	}
+
+	switch {
+	case name == "":
+		return invalid("empty name")
+	case name == "@":
+		return invalid(`"@" is reserved`)
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/"):
+		return invalid(`can't begin or end with "/"`)
+	case strings.HasSuffix(name, "."):
+		return invalid(`can't end with "."`)
+	case strings.Contains(name, "//"):
+		return invalid(`can't contain "//"`)
+	case strings.Contains(name, ".."):
+		return invalid(`can't contain ".."`)
+	case strings.Contains(name, "@{"):
+		return invalid(`can't contain "@{"`)
+	}
+
+	for _, c := range name {
+		if c < 0x20 || c == 0x7f {
+			return invalid("can't contain control characters")
+		}
+		if strings.ContainsRune(" ~^:?*[\\", c) {
+			return invalid(fmt.Sprintf("can't contain %q", c))
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return invalid(`path components can't begin with "."`)
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return invalid(`path components can't end with ".lock"`)
+		}
+	}
+
+	return nil
+}
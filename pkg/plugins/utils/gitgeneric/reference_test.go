@@ -0,0 +1,74 @@
+package gitgeneric
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutResetRef(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	r, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.0", head.Hash(), nil)
+	require.NoError(t, err)
+
+	latest := commitTestFile(t, origin, "README.md", "v2")
+
+	tests := []struct {
+		name         string
+		resetRef     string
+		expectedHash string
+		wantErr      bool
+	}{
+		{
+			name:         "Default reset to the remote working branch",
+			expectedHash: latest.String(),
+		},
+		{
+			name:         "Reset to a tag",
+			resetRef:     "v1.0.0",
+			expectedHash: head.Hash().String(),
+		},
+		{
+			name:         "Reset to a commit hash",
+			resetRef:     head.Hash().String(),
+			expectedHash: head.Hash().String(),
+		},
+		{
+			name:         "Reset to a remote branch",
+			resetRef:     "origin/main",
+			expectedHash: latest.String(),
+		},
+		{
+			name:     "Reset to a nonexistent reference",
+			resetRef: "doNotExist",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestClone(t, origin)
+
+			g := GoGit{ResetRef: tt.resetRef}
+			err := g.Checkout("", "", "main", "main", workingDir, true)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			clone, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			got, err := clone.Head()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHash, got.Hash().String())
+		})
+	}
+}
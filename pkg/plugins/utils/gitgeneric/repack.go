@@ -0,0 +1,114 @@
+package gitgeneric
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+Repack packs every object reachable from the repository references into a single packfile,
+then removes the loose objects that were packed.
+
+Each commit writes at least three loose objects (commit, tree, and blob) to the repository.
+Calling Repack is only worth it when a pipeline creates many commits in the same working directory,
+such as dozens of targets committing to the same branch, as the number of loose objects
+slows down subsequent git operations. A freshly cloned repository is already packed.
+
+Loose objects not reachable from any reference, like files staged but not committed yet, are kept.
+Shallow repositories, such as the ones cloned with CloneDepth, aren't repacked as the objects
+reachable from their references are partially missing.
+*/
+func (g GoGit) Repack(workingDir string) error {
+
+	logrus.Debugf("stage: git-repack\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	return repack(r)
+}
+
+func repack(r *git.Repository) error {
+
+	los, ok := r.Storer.(storer.LooseObjectStorer)
+	if !ok {
+		return git.ErrLooseObjectsNotSupported
+	}
+
+	shallows, err := r.Storer.Shallow()
+	if err != nil {
+		return err
+	}
+	if len(shallows) > 0 {
+		logrus.Debugf("skipping repack of shallow git repository")
+		return nil
+	}
+
+	if err := r.RepackObjects(&git.RepackConfig{}); err != nil {
+		return fmt.Errorf("repack git objects: %w", err)
+	}
+
+	// Prune walks every object reachable from references, which is the set of objects just packed,
+	// and calls the handler for the remaining ones.
+	unreachable := map[plumbing.Hash]bool{}
+	err = r.Prune(git.PruneOptions{
+		Handler: func(hash plumbing.Hash) error {
+			unreachable[hash] = true
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	packed := []plumbing.Hash{}
+	err = los.ForEachObjectHash(func(hash plumbing.Hash) error {
+		if !unreachable[hash] {
+			packed = append(packed, hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hash := range packed {
+		if err := los.DeleteLooseObject(hash); err != nil {
+			return err
+		}
+	}
+
+	logrus.Debugf("%d loose objects packed", len(packed))
+
+	return nil
+}
+
+// looseCommitCount returns the number of commits not packed yet
+func looseCommitCount(r *git.Repository) (int, error) {
+	los, ok := r.Storer.(storer.LooseObjectStorer)
+	if !ok {
+		return 0, git.ErrLooseObjectsNotSupported
+	}
+
+	count := 0
+	err := los.ForEachObjectHash(func(hash plumbing.Hash) error {
+		if _, err := r.Storer.EncodedObject(plumbing.CommitObject, hash); err == nil {
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
@@ -0,0 +1,100 @@
+package gitgeneric
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// looseObjectCount returns the number of loose objects from the repository located in workingDir
+func looseObjectCount(t *testing.T, workingDir string) int {
+	t.Helper()
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	count := 0
+	err = r.Storer.(storer.LooseObjectStorer).ForEachObjectHash(func(_ plumbing.Hash) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+
+	return count
+}
+
+func TestRepack(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	for _, content := range []string{"v2", "v3", "v4"} {
+		commitTestFile(t, workingDir, "README.md", content)
+	}
+
+	// Staged but not committed files must survive a repack
+	writeTestFile(t, workingDir, "staged.txt", "staged")
+	require.NoError(t, GoGit{}.Add([]string{"staged.txt"}, workingDir))
+
+	require.Greater(t, looseObjectCount(t, workingDir), 1)
+
+	g := GoGit{}
+	require.NoError(t, g.Repack(workingDir))
+	assert.Equal(t, 1, looseObjectCount(t, workingDir))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	commits, err := r.Log(&git.LogOptions{})
+	require.NoError(t, err)
+
+	count := 0
+	require.NoError(t, commits.ForEach(func(_ *object.Commit) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 4, count)
+
+	content, err := ReadFileFromRevision(workingDir, "HEAD", "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "v4", string(content))
+}
+
+func TestCommitRepackAfterCommits(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+	g := GoGit{RepackAfterCommits: 2}
+
+	writeTestFile(t, workingDir, "README.md", "v2")
+	_, err := g.Commit("updatecli", "updatecli@updatecli.io", "v2", workingDir, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 0, looseObjectCount(t, workingDir))
+}
+
+func TestCommitRepackShallowClone(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	for _, content := range []string{"v2", "v3"} {
+		commitTestFile(t, origin, "README.md", content)
+	}
+
+	g := GoGit{CloneDepth: 1, RepackAfterCommits: 1}
+
+	workingDir := t.TempDir()
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	shallows, err := r.Storer.Shallow()
+	require.NoError(t, err)
+	require.NotEmpty(t, shallows, "the clone must be shallow")
+
+	writeTestFile(t, workingDir, "README.md", "v4")
+	hash, err := g.Commit("updatecli", "updatecli@updatecli.io", "v4", workingDir, "", "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	head, err := r.Head()
+	require.NoError(t, err)
+	assert.Equal(t, hash, head.Hash().String())
+}
@@ -0,0 +1,78 @@
+package gitgeneric
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ResetSoft moves the current branch to the reset commit, keeping the index and the worktree,
+	// like `git reset --soft`
+	ResetSoft = "soft"
+	// ResetMixed moves the current branch to the reset commit and resets the index, keeping the worktree,
+	// like `git reset --mixed`
+	ResetMixed = "mixed"
+	// ResetHard moves the current branch to the reset commit and resets the index and the worktree,
+	// discarding every uncommitted change, like `git reset --hard`
+	ResetHard = "hard"
+)
+
+/*
+Reset run `git reset`, moving the current branch to commit, a commit hash or any git reference
+such as "HEAD~1" or "origin/main", then resetting the index and the worktree according to mode,
+one of ResetSoft, ResetMixed, or ResetHard.
+
+commit defaults to HEAD, so a hard reset discards the uncommitted changes, and mode defaults to ResetMixed.
+Untracked files are left untouched.
+*/
+func (g GoGit) Reset(commit, workingDir, mode string) error {
+
+	logrus.Debugf("stage: git-reset\n\n")
+
+	var resetMode git.ResetMode
+	switch mode {
+	case ResetSoft:
+		resetMode = git.SoftReset
+	case ResetMixed, "":
+		resetMode = git.MixedReset
+	case ResetHard:
+		resetMode = git.HardReset
+	default:
+		return fmt.Errorf("unsupported reset mode %q, accepted values are %q, %q, and %q",
+			mode, ResetSoft, ResetMixed, ResetHard)
+	}
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return err
+	}
+
+	if err := g.checkIndexLock(r); err != nil {
+		return err
+	}
+
+	if commit == "" {
+		commit = plumbing.HEAD.String()
+	}
+
+	hash, err := resolveRevision(r, commit)
+	if err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	return g.resetWorktree(r, w, hash, resetMode)
+}
@@ -0,0 +1,110 @@
+package gitgeneric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name        string
+		commit      string
+		mode        string
+		wantHead    string
+		wantContent string
+		wantStaged  bool
+		wantErr     bool
+	}{
+		{
+			name:        "Hard reset discards uncommitted changes",
+			mode:        ResetHard,
+			wantHead:    "HEAD",
+			wantContent: "v2",
+		},
+		{
+			name:        "Hard reset to the previous commit",
+			commit:      "HEAD~1",
+			mode:        ResetHard,
+			wantHead:    "HEAD~1",
+			wantContent: "v1",
+		},
+		{
+			name:        "Soft reset keeps the index and the worktree",
+			commit:      "HEAD~1",
+			mode:        ResetSoft,
+			wantHead:    "HEAD~1",
+			wantContent: "uncommitted",
+			wantStaged:  true,
+		},
+		{
+			name:        "Mixed reset by default keeps the worktree",
+			commit:      "HEAD~1",
+			wantHead:    "HEAD~1",
+			wantContent: "uncommitted",
+		},
+		{
+			name:    "Unknown commit",
+			commit:  "0000000000000000000000000000000000000001",
+			mode:    ResetHard,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown reference",
+			commit:  "doNotExist",
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported mode",
+			mode:    "keep",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			commitTestFile(t, workingDir, "README.md", "v2")
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			g := GoGit{}
+
+			var want plumbing.Hash
+			if tt.wantHead != "" {
+				want, err = resolveRevision(r, tt.wantHead)
+				require.NoError(t, err)
+			}
+
+			writeTestFile(t, workingDir, "README.md", "uncommitted")
+			require.NoError(t, g.Add([]string{"README.md"}, workingDir))
+
+			err = g.Reset(tt.commit, workingDir, tt.mode)
+			if tt.wantErr {
+				require.Error(t, err)
+				content, err := os.ReadFile(filepath.Join(workingDir, "README.md"))
+				require.NoError(t, err)
+				assert.Equal(t, "uncommitted", string(content), "nothing must be reset")
+				return
+			}
+			require.NoError(t, err)
+
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, want, head.Hash())
+
+			content, err := os.ReadFile(filepath.Join(workingDir, "README.md"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContent, string(content))
+
+			status, err := g.Status(workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStaged, status.File("README.md").Staging == git.Modified)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package gitgeneric
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	transportHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultNetworkRetryBackoff defines the default delay before retrying a git network operation,
+// doubled after each attempt
+const DefaultNetworkRetryBackoff = time.Second
+
+// retryNetwork runs the git network operation fn, then retries it up to NetworkRetries times
+// as long as it fails with a transient error, waiting an exponential backoff between attempts.
+func (g GoGit) retryNetwork(ctx context.Context, operation string, fn func() error) error {
+	backoff := g.NetworkRetryBackoff
+	if backoff == 0 {
+		backoff = DefaultNetworkRetryBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if attempt > g.NetworkRetries || !isTransientError(err) {
+			return err
+		}
+
+		logrus.Warningf("git %s failed with a transient error, retrying in %s (attempt %d/%d): %s",
+			operation, backoff, attempt, g.NetworkRetries, redactCredentials(err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isTransientError returns true if err is a network failure, or a git server error,
+// such as a rate limit, which may not happen again.
+// Authentication failures, missing repositories, and rejected pushes are never transient.
+func isTransientError(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, git.NoErrAlreadyUpToDate),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, transport.ErrAuthenticationRequired),
+		errors.Is(err, transport.ErrAuthorizationFailed),
+		errors.Is(err, transport.ErrInvalidAuthMethod),
+		errors.Is(err, transport.ErrRepositoryNotFound),
+		errors.Is(err, transport.ErrEmptyRemoteRepository),
+		IsNonFastForwardError(err):
+		return false
+	}
+
+	// go-git doesn't allow unwrapping its unexpected http errors
+	var unexpectedErr *plumbing.UnexpectedError
+	if errors.As(err, &unexpectedErr) {
+		var httpErr *transportHttp.Err
+		if errors.As(unexpectedErr.Err, &httpErr) {
+			return httpErr.StatusCode() == http.StatusTooManyRequests ||
+				httpErr.StatusCode() >= http.StatusInternalServerError
+		}
+		err = unexpectedErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,169 @@
+package gitgeneric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "No error"},
+		{name: "Already up to date", err: git.NoErrAlreadyUpToDate},
+		{name: "Authentication required", err: transport.ErrAuthenticationRequired},
+		{name: "Authorization failed", err: fmt.Errorf("push: %w", transport.ErrAuthorizationFailed)},
+		{name: "Repository not found", err: transport.ErrRepositoryNotFound},
+		{name: "Non-fast-forward push", err: errors.New("non-fast-forward update: refs/heads/main")},
+		{name: "Canceled", err: context.Canceled},
+		{name: "Unknown error", err: errors.New("object not found")},
+		{name: "Connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, expected: true},
+		{name: "Connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), expected: true},
+		{name: "Unexpected EOF", err: io.ErrUnexpectedEOF, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isTransientError(tt.err))
+		})
+	}
+}
+
+func TestRetryNetwork(t *testing.T) {
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	tests := []struct {
+		name         string
+		retries      int
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "Retries disabled",
+			errs:         []error{io.ErrUnexpectedEOF},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "Transient error then success",
+			retries:      3,
+			errs:         []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Retries exhausted",
+			retries:      2,
+			errs:         []error{io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, io.ErrUnexpectedEOF, nil},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "Authentication failure isn't retried",
+			retries:      3,
+			errs:         []error{transport.ErrAuthenticationRequired, nil},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook.Reset()
+
+			g := GoGit{NetworkRetries: tt.retries, NetworkRetryBackoff: time.Millisecond}
+
+			attempts := 0
+			err := g.retryNetwork(context.Background(), "fetch", func() error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantAttempts, attempts)
+
+			require.Len(t, hook.AllEntries(), tt.wantAttempts-1)
+			for i, entry := range hook.AllEntries() {
+				assert.Equal(t, logrus.WarnLevel, entry.Level)
+				assert.Contains(t, entry.Message, fmt.Sprintf("(attempt %d/%d)", i+1, tt.retries))
+			}
+		})
+	}
+
+	t.Run("Canceled context stops retrying", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		err := GoGit{NetworkRetries: 3, NetworkRetryBackoff: time.Hour}.retryNetwork(ctx, "fetch", func() error {
+			attempts++
+			cancel()
+			return io.ErrUnexpectedEOF
+		})
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestCloneNetworkRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRequests int32
+	}{
+		{
+			name:         "Unavailable git server",
+			status:       http.StatusServiceUnavailable,
+			wantRequests: 3,
+		},
+		{
+			name:         "Rate limited",
+			status:       http.StatusTooManyRequests,
+			wantRequests: 3,
+		},
+		{
+			name:         "Authentication failure",
+			status:       http.StatusUnauthorized,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			g := GoGit{NetworkRetries: 2, NetworkRetryBackoff: time.Millisecond}
+			err := g.Clone("", "", server.URL+"/updatecli.git", filepath.Join(t.TempDir(), "clone"))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantRequests, requests.Load())
+		})
+	}
+}
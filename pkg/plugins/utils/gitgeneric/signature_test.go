@@ -0,0 +1,258 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSigningKey returns a new gpg key and its armored private key ring used to sign commits
+func newTestSigningKey(t *testing.T, name string) (*openpgp.Entity, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity(name, "", name+"@updatecli.io", nil)
+	require.NoError(t, err)
+
+	b := bytes.Buffer{}
+	w, err := armor.Encode(&b, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivate(w, nil))
+	require.NoError(t, w.Close())
+
+	return entity, b.String()
+}
+
+func TestVerifyCommitAllowedSigners(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	g := GoGit{}
+
+	alice, aliceKey := newTestSigningKey(t, "alice")
+	bob, bobKey := newTestSigningKey(t, "bob")
+	_, malloryKey := newTestSigningKey(t, "mallory")
+
+	commitSigned := func(content, key string) {
+		writeTestFile(t, workingDir, "README.md", content)
+		require.NoError(t, g.Add([]string{"README.md"}, workingDir))
+		_, err := g.Commit("updatecli", "updatecli@updatecli.io", content, workingDir, key, "")
+		require.NoError(t, err)
+	}
+
+	commitSigned("alice", aliceKey)
+	commitSigned("bob", bobKey)
+	commitSigned("mallory", malloryKey)
+	commitSigned("unsigned", "")
+
+	allowed := []openpgp.EntityList{{alice}, {bob}}
+
+	tests := []struct {
+		name       string
+		commit     string
+		wantSigned bool
+		wantSigner string
+		wantErr    bool
+	}{
+		{
+			name:       "Commit signed by the first allowed key",
+			commit:     "HEAD~3",
+			wantSigned: true,
+			wantSigner: fmt.Sprintf("%X", alice.PrimaryKey.Fingerprint),
+		},
+		{
+			name:       "Commit signed by the second allowed key",
+			commit:     "HEAD~2",
+			wantSigned: true,
+			wantSigner: fmt.Sprintf("%X", bob.PrimaryKey.Fingerprint),
+		},
+		{
+			name:   "Commit signed by a key which isn't allowed",
+			commit: "HEAD~1",
+		},
+		{
+			name:   "Unsigned commit",
+			commit: "HEAD",
+		},
+		{
+			name:    "Unknown commit",
+			commit:  "doNotExist",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, signer, err := g.VerifyCommitAllowedSigners(tt.commit, workingDir, allowed)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSigned, signed)
+			assert.Equal(t, tt.wantSigner, signer)
+		})
+	}
+}
+
+func TestVerifyCommitAllowedSignersSSH(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+	g := GoGit{}
+
+	alice, _ := newTestSigningKey(t, "alice")
+
+	writeTestFile(t, workingDir, "README.md", "v2")
+	hash, err := g.Commit("updatecli", "updatecli@updatecli.io", "update", workingDir, newTestSSHKey(t, ""), "")
+	require.NoError(t, err)
+
+	signed, signer, err := g.VerifyCommitAllowedSigners(hash, workingDir, []openpgp.EntityList{{alice}})
+	require.NoError(t, err)
+	assert.False(t, signed)
+	assert.Empty(t, signer)
+}
+
+func TestCommitSigning(t *testing.T) {
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary required to verify commit signatures")
+	}
+
+	// Passphrase protected gpg key
+	gpgEntity, err := openpgp.NewEntity("updatecli", "", "updatecli@updatecli.io", nil)
+	require.NoError(t, err)
+	require.NoError(t, gpgEntity.PrivateKey.Encrypt([]byte("secret")))
+	gpgKey := bytes.Buffer{}
+	w, err := armor.Encode(&gpgKey, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, gpgEntity.SerializePrivateWithoutSigning(w, nil))
+	require.NoError(t, w.Close())
+
+	gpgKeyPath := filepath.Join(t.TempDir(), "private.asc")
+	require.NoError(t, os.WriteFile(gpgKeyPath, gpgKey.Bytes(), 0600))
+
+	sshKeyPath := newTestSSHKey(t, "")
+	encryptedSSHKeyPath := newTestSSHKey(t, "secret")
+	sshKey, err := os.ReadFile(sshKeyPath)
+	require.NoError(t, err)
+
+	// Ssh signatures are verified using the git allowed signers file
+	allowedSigners := ""
+	for _, keyPath := range []string{sshKeyPath, encryptedSSHKeyPath} {
+		publicKey, err := os.ReadFile(keyPath + ".pub")
+		require.NoError(t, err)
+		allowedSigners += "updatecli@updatecli.io " + string(publicKey)
+	}
+	allowedSignersPath := filepath.Join(t.TempDir(), "allowed_signers")
+	require.NoError(t, os.WriteFile(allowedSignersPath, []byte(allowedSigners), 0600))
+
+	tests := []struct {
+		name       string
+		signingKey string
+		passphrase string
+		wantSigned string
+		wantErr    bool
+	}{
+		{
+			name: "No signing key",
+		},
+		{
+			name:       "Passphrase protected gpg key",
+			signingKey: gpgKey.String(),
+			passphrase: "secret",
+			wantSigned: "gpg",
+		},
+		{
+			name:       "Gpg key with wrong passphrase",
+			signingKey: gpgKey.String(),
+			passphrase: "wrong",
+			wantErr:    true,
+		},
+		{
+			name:       "Gpg keyring path",
+			signingKey: gpgKeyPath,
+			passphrase: "secret",
+			wantSigned: "gpg",
+		},
+		{
+			name:       "Gpg keyring path with wrong passphrase",
+			signingKey: gpgKeyPath,
+			passphrase: "wrong",
+			wantErr:    true,
+		},
+		{
+			name:       "Ssh key path",
+			signingKey: sshKeyPath,
+			wantSigned: "ssh",
+		},
+		{
+			name:       "Ssh key",
+			signingKey: string(sshKey),
+			wantSigned: "ssh",
+		},
+		{
+			name:       "Passphrase protected ssh key",
+			signingKey: encryptedSSHKeyPath,
+			passphrase: "secret",
+			wantSigned: "ssh",
+		},
+		{
+			name:       "Passphrase protected ssh key without passphrase",
+			signingKey: encryptedSSHKeyPath,
+			wantErr:    true,
+		},
+		{
+			name:       "Missing ssh key",
+			signingKey: filepath.Join(t.TempDir(), "doNotExist"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			writeTestFile(t, workingDir, "README.md", "v2")
+
+			hash, err := GoGit{}.Commit("updatecli", "updatecli@updatecli.io", "update", workingDir, tt.signingKey, tt.passphrase)
+			if tt.wantErr {
+				require.Error(t, err)
+				content, err := ReadFileFromRevision(workingDir, "HEAD", "README.md")
+				require.NoError(t, err)
+				assert.Equal(t, "v1", string(content), "nothing must be committed")
+				return
+			}
+			require.NoError(t, err)
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			head, err := r.Head()
+			require.NoError(t, err)
+			assert.Equal(t, head.Hash().String(), hash)
+
+			commit, err := r.CommitObject(head.Hash())
+			require.NoError(t, err)
+
+			switch tt.wantSigned {
+			case "":
+				assert.Empty(t, commit.PGPSignature)
+			case "gpg":
+				signed, _, err := GoGit{}.VerifyCommitAllowedSigners(hash, workingDir, []openpgp.EntityList{{gpgEntity}})
+				require.NoError(t, err)
+				assert.True(t, signed)
+			case "ssh":
+				cmd := exec.Command(gitBinary,
+					"-c", "gpg.ssh.allowedSignersFile="+allowedSignersPath,
+					"verify-commit", hash)
+				cmd.Dir = workingDir
+				output, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(output))
+				assert.Contains(t, string(output), `Good "git" signature for updatecli@updatecli.io`)
+			}
+		})
+	}
+}
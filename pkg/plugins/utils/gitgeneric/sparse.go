@@ -0,0 +1,377 @@
+package gitgeneric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+The sparse checkout is implemented by Updatecli, instead of relying on go-git,
+as go-git doesn't keep the files outside the sparse checkout when resetting the worktree
+nor ignore them when computing the worktree status.
+
+Files outside the sparse checkout directories are kept in the index, flagged as skip-worktree like git does,
+so commits leave them untouched, but they are never written to the working directory.
+*/
+
+// ValidateSparseCheckoutDirectories returns an error if one of the sparse checkout directories
+// isn't a relative path inside the git repository
+func ValidateSparseCheckoutDirectories(directories []string) error {
+	for _, directory := range directories {
+		cleaned := path.Clean(filepath.ToSlash(directory))
+
+		if directory == "" || cleaned == "." {
+			return fmt.Errorf("sparse checkout directory %q must not be empty", directory)
+		}
+
+		if path.IsAbs(cleaned) || filepath.IsAbs(directory) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+			return fmt.Errorf("sparse checkout directory %q must be relative to the git repository", directory)
+		}
+	}
+
+	return nil
+}
+
+// sparse returns true if only the sparse checkout directories are materialized in the working directory
+func (g GoGit) sparse() bool {
+	return len(g.SparseCheckoutDirectories) > 0
+}
+
+// inSparseCheckout returns true if the file, relative to the git repository, is materialized
+// in the working directory. Like the git sparse checkout cone mode, files located at the
+// root of the git repository are always materialized.
+func (g GoGit) inSparseCheckout(file string) bool {
+	if !g.sparse() {
+		return true
+	}
+
+	file = filepath.ToSlash(file)
+
+	if !strings.Contains(file, "/") {
+		return true
+	}
+
+	for _, directory := range g.SparseCheckoutDirectories {
+		directory = path.Clean(filepath.ToSlash(directory))
+		if file == directory || strings.HasPrefix(file, directory+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// worktreeStatus returns the status of the worktree w, without the files outside the sparse checkout
+// reported as deleted from the working directory as they are never written to it.
+func (g GoGit) worktreeStatus(w *git.Worktree) (git.Status, error) {
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.sparse() {
+		return status, nil
+	}
+
+	for file, fileStatus := range status {
+		if !g.inSparseCheckout(file) && fileStatus.Worktree == git.Deleted {
+			delete(status, file)
+		}
+	}
+
+	return status, nil
+}
+
+// stageChanges stages the changes of the worktree w reported by status, as `git commit --all` does
+// but without staging the deletion of the files outside the sparse checkout.
+// Untracked files are staged too if untracked is set, like `git add --all`.
+func (g GoGit) stageChanges(w *git.Worktree, status git.Status, untracked bool) error {
+	for file, fileStatus := range status {
+		switch fileStatus.Worktree {
+		case git.Unmodified:
+			continue
+		case git.Untracked:
+			if !untracked {
+				continue
+			}
+		case git.Deleted:
+			if _, err := w.Remove(file); err != nil {
+				return fmt.Errorf("removing %q: %w", file, err)
+			}
+			continue
+		}
+
+		if _, err := w.Add(file); err != nil {
+			return fmt.Errorf("adding %q: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// stageCommitAll stages, with a sparse checkout, the changes committed by commitOptions.All,
+// as go-git would also commit the deletion of every file outside the sparse checkout.
+func (g GoGit) stageCommitAll(w *git.Worktree, commitOptions *git.CommitOptions) error {
+	if !g.sparse() || !commitOptions.All {
+		return nil
+	}
+
+	status, err := g.worktreeStatus(w)
+	if err != nil {
+		return err
+	}
+
+	commitOptions.All = false
+
+	return g.stageChanges(w, status, false)
+}
+
+// checkout checks out the branch, or the commit, defined by checkoutOptions.
+// Only the sparse checkout directories are materialized, discarding their uncommitted changes.
+func (g GoGit) checkout(r *git.Repository, w *git.Worktree, checkoutOptions *git.CheckoutOptions) error {
+	if !g.sparse() {
+		return w.Checkout(checkoutOptions)
+	}
+
+	hash := checkoutOptions.Hash
+
+	if checkoutOptions.Create {
+		if _, err := r.Reference(checkoutOptions.Branch, false); err == nil {
+			return fmt.Errorf("a branch named %q already exists", checkoutOptions.Branch)
+		}
+
+		if hash.IsZero() {
+			head, err := r.Head()
+			if err != nil {
+				return err
+			}
+			hash = head.Hash()
+		}
+
+		if err := r.Storer.SetReference(plumbing.NewHashReference(checkoutOptions.Branch, hash)); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case checkoutOptions.Branch != "":
+		ref, err := r.Reference(checkoutOptions.Branch, true)
+		if err != nil {
+			return err
+		}
+		hash = ref.Hash()
+
+		err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, checkoutOptions.Branch))
+		if err != nil {
+			return err
+		}
+	case !hash.IsZero():
+		if err := r.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, hash)); err != nil {
+			return err
+		}
+	default:
+		return git.ErrBranchHashExclusive
+	}
+
+	return g.sparseReset(r, hash, true)
+}
+
+// sparseCheckoutHead materializes the sparse checkout of the HEAD commit of r, cloned without checkout
+func (g GoGit) sparseCheckoutHead(r *git.Repository) error {
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+
+	return g.sparseReset(r, head.Hash(), true)
+}
+
+// pull fast forwards the checked out branch to its remote branch, like `git pull --ff-only`.
+// With a sparse checkout, the remote branch is fetched then only the sparse checkout directories are updated.
+func (g GoGit) pull(ctx context.Context, r *git.Repository, w *git.Worktree, pullOptions *git.PullOptions) error {
+	if !g.sparse() {
+		return w.PullContext(ctx, pullOptions)
+	}
+
+	head, remoteRef, err := fetchHeadBranch(ctx, r, pullOptions)
+	if err != nil {
+		return err
+	}
+
+	if remoteRef.Hash() == head.Hash() {
+		return git.NoErrAlreadyUpToDate
+	}
+
+	headCommit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return err
+	}
+
+	remoteCommit, err := r.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return err
+	}
+
+	isForward, err := headCommit.IsAncestor(remoteCommit)
+	if err != nil {
+		return err
+	}
+
+	if !isForward {
+		return git.ErrNonFastForwardUpdate
+	}
+
+	if err := r.Storer.SetReference(plumbing.NewHashReference(head.Name(), remoteRef.Hash())); err != nil {
+		return err
+	}
+
+	return g.sparseReset(r, remoteRef.Hash(), true)
+}
+
+// sparseReset resets the index to the commit hash, flagging the files outside the sparse checkout
+// as skip-worktree, then, if updateWorktree is set, writes the files of the sparse checkout
+// to the working directory and removes the ones deleted since the previous index.
+func (g GoGit) sparseReset(r *git.Repository, hash plumbing.Hash, updateWorktree bool) error {
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	previousIndex, err := r.Storer.Index()
+	if err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+	rootDir := w.Filesystem.Root()
+
+	idx := &index.Index{Version: 3}
+	files := map[string]bool{}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if entry.Mode == filemode.Dir {
+			continue
+		}
+
+		files[name] = true
+
+		e := &index.Entry{
+			Name: name,
+			Hash: entry.Hash,
+			Mode: entry.Mode,
+		}
+
+		if !g.inSparseCheckout(name) {
+			e.SkipWorktree = true
+		} else if updateWorktree && entry.Mode != filemode.Submodule {
+			if err := writeWorktreeFile(r, rootDir, name, entry); err != nil {
+				return err
+			}
+
+			if info, err := os.Lstat(filepath.Join(rootDir, filepath.FromSlash(name))); err == nil {
+				e.ModifiedAt = info.ModTime()
+				e.Size = uint32(info.Size())
+			}
+		}
+
+		idx.Entries = append(idx.Entries, e)
+	}
+
+	if updateWorktree {
+		for _, e := range previousIndex.Entries {
+			if files[e.Name] || !g.inSparseCheckout(e.Name) {
+				continue
+			}
+
+			err := os.Remove(filepath.Join(rootDir, filepath.FromSlash(e.Name)))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	logrus.Debugf("sparse checkout of %v at commit %q", g.SparseCheckoutDirectories, hash.String())
+
+	return r.Storer.SetIndex(idx)
+}
+
+// writeWorktreeFile writes the content of the git tree entry, located at name, to the working directory rootDir
+func writeWorktreeFile(r *git.Repository, rootDir, name string, entry object.TreeEntry) error {
+	blob, err := r.BlobObject(entry.Hash)
+	if err != nil {
+		return err
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file := filepath.Join(rootDir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if entry.Mode == filemode.Symlink {
+		target, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(string(target), file)
+	}
+
+	perm := os.FileMode(0o644)
+	if entry.Mode == filemode.Executable {
+		perm = 0o755
+	}
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, reader); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,102 @@
+package gitgeneric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparseCheckout(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{
+		"README.md":              "v1",
+		"charts/app/Chart.yaml":  "version: 1.0.0",
+		"charts/db/Chart.yaml":   "version: 1.0.0",
+		"docs/guide/index.md":    "v1",
+		"charts/application.txt": "v1",
+	})
+
+	g := GoGit{SparseCheckoutDirectories: []string{"charts/app"}}
+	workingDir := filepath.Join(t.TempDir(), "clone")
+
+	assertMaterialized := func(t *testing.T, expected map[string]bool) {
+		t.Helper()
+		for file, materialized := range expected {
+			_, err := os.Stat(filepath.Join(workingDir, file))
+			assert.Equal(t, materialized, err == nil, file)
+		}
+	}
+
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	assertMaterialized(t, map[string]bool{
+		"README.md":              true,
+		"charts/app/Chart.yaml":  true,
+		"charts/db/Chart.yaml":   false,
+		"docs/guide/index.md":    false,
+		"charts/application.txt": false,
+	})
+
+	clean, err := g.IsClean(workingDir)
+	require.NoError(t, err)
+	assert.True(t, clean, "files outside the sparse checkout must not be reported as deleted")
+
+	// Updating the clone only materializes the sparse checkout
+	commitTestFile(t, origin, "charts/app/values.yaml", "replicas: 1")
+	commitTestFile(t, origin, "docs/guide/index.md", "v2")
+
+	require.NoError(t, g.Clone("", "", origin, workingDir))
+
+	assertMaterialized(t, map[string]bool{
+		"charts/app/values.yaml": true,
+		"docs/guide/index.md":    false,
+	})
+
+	require.NoError(t, g.Checkout("", "", "main", "updatecli", workingDir, true))
+	assertMaterialized(t, map[string]bool{
+		"charts/app/Chart.yaml": true,
+		"charts/db/Chart.yaml":  false,
+	})
+
+	writeTestFile(t, workingDir, "charts/app/Chart.yaml", "version: 2.0.0")
+	require.NoError(t, g.Add([]string{"charts/app/Chart.yaml"}, workingDir))
+
+	hash, err := g.Commit("updatecli", "updatecli@updatecli.io", "bump app chart", workingDir, "", "")
+	require.NoError(t, err)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+
+	commit, err := r.CommitObject(plumbing.NewHash(hash))
+	require.NoError(t, err)
+
+	files := map[string]bool{}
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	require.NoError(t, tree.Files().ForEach(func(f *object.File) error {
+		files[f.Name] = true
+		return nil
+	}))
+	assert.Equal(t, map[string]bool{
+		"README.md":              true,
+		"charts/app/Chart.yaml":  true,
+		"charts/app/values.yaml": true,
+		"charts/db/Chart.yaml":   true,
+		"docs/guide/index.md":    true,
+		"charts/application.txt": true,
+	}, files, "the commit must keep the files outside the sparse checkout")
+
+}
+
+func TestValidateSparseCheckoutDirectories(t *testing.T) {
+	assert.NoError(t, ValidateSparseCheckoutDirectories([]string{"charts/app", "docs/"}))
+	assert.Error(t, ValidateSparseCheckoutDirectories([]string{""}))
+	assert.Error(t, ValidateSparseCheckoutDirectories([]string{"."}))
+	assert.Error(t, ValidateSparseCheckoutDirectories([]string{"/charts"}))
+	assert.Error(t, ValidateSparseCheckoutDirectories([]string{"../charts"}))
+}
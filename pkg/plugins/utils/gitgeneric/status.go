@@ -0,0 +1,62 @@
+package gitgeneric
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// Status run `git status`, then returns the status of every changed file in the working directory,
+// whether it's staged or not, including untracked files
+func (g GoGit) Status(workingDir string) (git.Status, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return nil, err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := g.worktreeStatus(w)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Debugf("status: %q\n", status)
+
+	return status, nil
+}
+
+// IsClean returns true if the working directory has nothing to commit,
+// so committing and pushing can be skipped
+func (g GoGit) IsClean(workingDir string) (bool, error) {
+	status, err := g.Status(workingDir)
+	if err != nil {
+		return false, err
+	}
+
+	return status.IsClean(), nil
+}
+
+// hasCommittableChanges returns true if status contains changes committed by `git commit --all`,
+// either staged changes or changes to tracked files, untracked files being ignored
+func hasCommittableChanges(status git.Status) bool {
+	for _, fileStatus := range status {
+		if fileStatus.Staging == git.Untracked {
+			continue
+		}
+		if fileStatus.Staging != git.Unmodified || fileStatus.Worktree != git.Unmodified {
+			return true
+		}
+	}
+
+	return false
+}
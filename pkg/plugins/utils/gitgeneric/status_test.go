@@ -0,0 +1,86 @@
+package gitgeneric
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsClean(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(t *testing.T, workingDir string)
+		wantClean  bool
+		wantStatus map[string]git.StatusCode
+	}{
+		{
+			name:      "No change",
+			change:    func(t *testing.T, workingDir string) {},
+			wantClean: true,
+		},
+		{
+			name: "Modified file",
+			change: func(t *testing.T, workingDir string) {
+				writeTestFile(t, workingDir, "README.md", "v2")
+			},
+			wantStatus: map[string]git.StatusCode{"README.md": git.Modified},
+		},
+		{
+			name: "Untracked file",
+			change: func(t *testing.T, workingDir string) {
+				writeTestFile(t, workingDir, "CHANGELOG.md", "v1")
+			},
+			wantStatus: map[string]git.StatusCode{"CHANGELOG.md": git.Untracked},
+		},
+		{
+			name: "Staged file",
+			change: func(t *testing.T, workingDir string) {
+				writeTestFile(t, workingDir, "CHANGELOG.md", "v1")
+				require.NoError(t, GoGit{}.Add([]string{"CHANGELOG.md"}, workingDir))
+			},
+			wantStatus: map[string]git.StatusCode{"CHANGELOG.md": git.Added},
+		},
+		{
+			name: "Committed change",
+			change: func(t *testing.T, workingDir string) {
+				commitTestFile(t, workingDir, "README.md", "v2")
+			},
+			wantClean: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+			tt.change(t, workingDir)
+
+			clean, err := GoGit{}.IsClean(workingDir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantClean, clean)
+
+			status, err := GoGit{}.Status(workingDir)
+			require.NoError(t, err)
+
+			gotStatus := map[string]git.StatusCode{}
+			for file, s := range status {
+				code := s.Worktree
+				if s.Staging != git.Unmodified && s.Staging != git.Untracked {
+					code = s.Staging
+				}
+				gotStatus[file] = code
+			}
+			if tt.wantStatus == nil {
+				tt.wantStatus = map[string]git.StatusCode{}
+			}
+			assert.Equal(t, tt.wantStatus, gotStatus)
+		})
+	}
+
+	t.Run("Not a git repository", func(t *testing.T) {
+		_, err := GoGit{}.IsClean(filepath.Join(t.TempDir(), "doNotExist"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package gitgeneric
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/sirupsen/logrus"
+)
+
+// SubmoduleCredentials defines the credentials used to fetch a git submodule
+type SubmoduleCredentials struct {
+	Username string
+	Password string
+}
+
+/*
+updateSubmodules initializes and fetches, recursively, the submodules of the git repository r.
+
+The credentials used for each submodule are resolved in the following order:
+ 1. SubmoduleCredentials matching the submodule name, as defined in ".gitmodules"
+ 2. SubmoduleCredentials matching the submodule URL, as defined in ".gitmodules"
+ 3. SubmoduleCredentials matching the submodule URL host, such as "github.com"
+ 4. username and password, the credentials used to clone the parent repository
+
+Nested submodules are fetched using the credentials of their parent submodule.
+*/
+func (g GoGit) updateSubmodules(ctx context.Context, r *git.Repository, username, password string) error {
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	submodules, err := w.Submodules()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range submodules {
+		submoduleConfig := s.Config()
+
+		if !g.inSparseCheckout(submoduleConfig.Path) {
+			logrus.Debugf("skipping git submodule %q outside the sparse checkout", submoduleConfig.Name)
+			continue
+		}
+		submoduleUsername, submodulePassword := g.submoduleCredentials(submoduleConfig, username, password)
+		submoduleAuth, err := g.transportAuth(submoduleConfig.URL, submoduleUsername, submodulePassword)
+		if err != nil {
+			return fmt.Errorf("git submodule %q: %w", submoduleConfig.Name, err)
+		}
+
+		updateOptions := git.SubmoduleUpdateOptions{
+			Init:              true,
+			RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+		}
+		if submoduleAuth != nil {
+			updateOptions.Auth = submoduleAuth
+		}
+
+		logrus.Debugf("updating git submodule %q from %q", submoduleConfig.Name, remoteHost(submoduleConfig.URL))
+
+		if err := s.UpdateContext(ctx, &updateOptions); err != nil {
+			return fmt.Errorf("updating git submodule %q: %w", submoduleConfig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// submoduleCredentials returns the username and password to use to fetch the submodule
+func (g GoGit) submoduleCredentials(submodule *config.Submodule, username, password string) (string, string) {
+	for _, key := range []string{submodule.Name, submodule.URL, remoteHost(submodule.URL)} {
+		if credentials, ok := g.SubmoduleCredentials[key]; ok && key != "" && key != unknownRemoteHost {
+			return credentials.Username, credentials.Password
+		}
+	}
+
+	return username, password
+}
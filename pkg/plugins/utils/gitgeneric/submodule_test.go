@@ -0,0 +1,169 @@
+package gitgeneric
+
+import (
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGitServer serves, over http, the git repositories located in rootDir using `git http-backend`.
+// Repositories whose name starts with "private" require the "updatecli" user and "secret" password.
+func newTestGitServer(t *testing.T, rootDir string) *httptest.Server {
+	t.Helper()
+
+	gitBinary, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git binary required to serve git repositories over http")
+	}
+
+	backend := &cgi.Handler{
+		Path: gitBinary,
+		Args: []string{"http-backend"},
+		Env: []string{
+			"GIT_PROJECT_ROOT=" + rootDir,
+			"GIT_HTTP_EXPORT_ALL=1",
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/private") {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "updatecli" || password != "secret" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="updatecli"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// addTestSubmodule commits, into the git repository located in workingDir, the submodule path
+// pointing to the HEAD commit of the git repository located in submoduleDir and available at URL
+func addTestSubmodule(t *testing.T, workingDir, submoduleDir, path, URL string) {
+	t.Helper()
+
+	submodule, err := git.PlainOpen(submoduleDir)
+	require.NoError(t, err)
+	submoduleHead, err := submodule.Head()
+	require.NoError(t, err)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	w, err := r.Worktree()
+	require.NoError(t, err)
+
+	writeTestFile(t, workingDir, ".gitmodules",
+		"[submodule \""+path+"\"]\n\tpath = "+path+"\n\turl = "+URL+"\n")
+	_, err = w.Add(".gitmodules")
+	require.NoError(t, err)
+
+	idx, err := r.Storer.Index()
+	require.NoError(t, err)
+	entry := idx.Add(path)
+	entry.Hash = submoduleHead.Hash()
+	entry.Mode = filemode.Submodule
+	entry.ModifiedAt = time.Now()
+	require.NoError(t, r.Storer.SetIndex(idx))
+
+	_, err = w.Commit("add submodule "+path, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "updatecli",
+			Email: "updatecli@updatecli.io",
+			When:  time.Now(),
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestCloneSubmoduleCredentials(t *testing.T) {
+	rootDir := t.TempDir()
+	server := newTestGitServer(t, rootDir)
+
+	submoduleDir := filepath.Join(rootDir, "private-submodule")
+	require.NoError(t, os.Rename(newTestRepository(t, map[string]string{"SUBMODULE.md": "submodule"}), submoduleDir))
+
+	tests := []struct {
+		name        string
+		repository  string
+		username    string
+		password    string
+		credentials map[string]SubmoduleCredentials
+		wantErr     bool
+	}{
+		{
+			name:       "Private submodule using the same credentials",
+			repository: "private-main",
+			username:   "updatecli",
+			password:   "secret",
+		},
+		{
+			name:       "Private submodule using its own credentials",
+			repository: "public-main",
+			credentials: map[string]SubmoduleCredentials{
+				"modules/private": {Username: "updatecli", Password: "secret"},
+			},
+		},
+		{
+			name:       "Private submodule credentials resolved by host",
+			repository: "public-main",
+			credentials: map[string]SubmoduleCredentials{
+				"127.0.0.1": {Username: "updatecli", Password: "secret"},
+			},
+		},
+		{
+			name:       "Private submodule without credentials",
+			repository: "public-main",
+			wantErr:    true,
+		},
+		{
+			name:       "Private submodule with wrong credentials",
+			repository: "private-main",
+			username:   "updatecli",
+			password:   "secret",
+			credentials: map[string]SubmoduleCredentials{
+				server.URL + "/private-submodule": {Username: "updatecli", Password: "wrong"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainDir := filepath.Join(rootDir, tt.repository)
+			if _, err := os.Stat(mainDir); os.IsNotExist(err) {
+				require.NoError(t, os.Rename(newTestRepository(t, map[string]string{"README.md": "main"}), mainDir))
+				addTestSubmodule(t, mainDir, submoduleDir, "modules/private", server.URL+"/private-submodule")
+			}
+
+			workingDir := filepath.Join(t.TempDir(), "clone")
+			g := GoGit{SubmoduleCredentials: tt.credentials}
+
+			err := g.Clone(tt.username, tt.password, server.URL+"/"+tt.repository, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(workingDir, "modules", "private", "SUBMODULE.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "submodule", string(content))
+		})
+	}
+}
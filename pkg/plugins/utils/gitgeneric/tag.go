@@ -0,0 +1,128 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"fmt"
+
+	sv "github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+FetchNewTags fetches tags from every remote then returns the tags that didn't exist locally
+before the fetch, ordered by creation time.
+
+If constraint is not empty, only tags that are valid semantic versions matching it are returned,
+so for example "^1" only reports new 1.x releases.
+
+On the first fetch, when the repository doesn't contain any tag yet,
+all fetched tags are considered as new.
+*/
+func (g GoGit) FetchNewTags(username, password, constraint, workingDir string) ([]string, error) {
+
+	logrus.Debugf("stage: git-fetch-tags\n\n")
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return []string{}, err
+	}
+
+	var c *sv.Constraints
+	if constraint != "" {
+		c, err = sv.NewConstraint(constraint)
+		if err != nil {
+			return []string{}, fmt.Errorf("wrong semantic versioning constraint %q: %w", constraint, err)
+		}
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return []string{}, err
+	}
+
+	knownTags := map[string]bool{}
+	tagrefs, err := r.Tags()
+	if err != nil {
+		return []string{}, err
+	}
+	err = tagrefs.ForEach(func(ref *plumbing.Reference) error {
+		knownTags[ref.Name().Short()] = true
+		return nil
+	})
+	if err != nil {
+		return []string{}, err
+	}
+
+	remotes, err := r.Remotes()
+	if err != nil {
+		return []string{}, err
+	}
+
+	b := bytes.Buffer{}
+	for _, remote := range remotes {
+		auth, err := g.transportAuth(remoteURL(remote), username, password)
+		if err != nil {
+			return []string{}, err
+		}
+
+		caBundle, err := g.caBundle()
+		if err != nil {
+			return []string{}, err
+		}
+
+		fetchOptions := git.FetchOptions{
+			Progress:        &b,
+			RefSpecs:        []config.RefSpec{"+refs/tags/*:refs/tags/*"},
+			Tags:            git.AllTags,
+			InsecureSkipTLS: g.insecureSkipTLS(remoteURL(remote)),
+			ProxyOptions:    g.proxyOptions(remoteURL(remote)),
+			CABundle:        caBundle,
+		}
+		if auth != nil {
+			fetchOptions.Auth = auth
+		}
+
+		err = remote.Fetch(&fetchOptions)
+
+		progress := redactCredentials(b.String(), password)
+		g.writeProgress(progress)
+		b.Reset()
+
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return []string{}, g.progressError("fetch", err, progress)
+		}
+	}
+
+	refs, err := g.TagRefs(workingDir)
+	if err != nil {
+		// No tag at all, hence no new tag
+		if len(knownTags) == 0 {
+			return []string{}, nil
+		}
+		return []string{}, err
+	}
+
+	newTags := []string{}
+	for _, ref := range refs {
+		if knownTags[ref.Name] {
+			continue
+		}
+
+		if c != nil {
+			v, err := sv.NewVersion(ref.Name)
+			if err != nil || !c.Check(v) {
+				continue
+			}
+		}
+
+		newTags = append(newTags, ref.Name)
+	}
+
+	logrus.Debugf("new tags: %v", newTags)
+
+	return newTags, nil
+}
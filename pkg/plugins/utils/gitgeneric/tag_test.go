@@ -0,0 +1,132 @@
+package gitgeneric
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestTag creates a lightweight tag on the repository HEAD
+func createTestTag(t *testing.T, workingDir, tag string) {
+	t.Helper()
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	head, err := r.Head()
+	require.NoError(t, err)
+	_, err = r.CreateTag(tag, head.Hash(), nil)
+	require.NoError(t, err)
+}
+
+func TestFetchNewTags(t *testing.T) {
+	g := GoGit{}
+
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	createTestTag(t, origin, "v1.0.0")
+
+	workingDir := newTestClone(t, origin)
+
+	// Nothing new since the clone
+	got, err := g.FetchNewTags("", "", "", workingDir)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	commitTestFile(t, origin, "README.md", "v2")
+	createTestTag(t, origin, "v1.1.0")
+	createTestTag(t, origin, "v2.0.0")
+	createTestTag(t, origin, "nightly")
+
+	// Using a semver constraint on a second clone
+	secondWorkingDir := newTestClone(t, origin)
+	commitTestFile(t, origin, "README.md", "v3")
+	createTestTag(t, origin, "v1.2.0")
+	createTestTag(t, origin, "v3.0.0")
+
+	got, err = g.FetchNewTags("", "", "", workingDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v1.1.0", "v2.0.0", "nightly", "v1.2.0", "v3.0.0"}, got)
+
+	got, err = g.FetchNewTags("", "", "^1", secondWorkingDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.2.0"}, got)
+
+	// All tags are new on the first fetch
+	emptyClone := newTestRepository(t, nil)
+	r, err := git.PlainOpen(emptyClone)
+	require.NoError(t, err)
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{origin}})
+	require.NoError(t, err)
+
+	got, err = g.FetchNewTags("", "", ">=2", emptyClone)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v2.0.0", "v3.0.0"}, got)
+
+	_, err = g.FetchNewTags("", "", "not a constraint", workingDir)
+	require.Error(t, err)
+}
+
+func TestNewTag(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1"})
+	workingDir := newTestClone(t, origin)
+
+	r, err := git.PlainOpen(workingDir)
+	require.NoError(t, err)
+	cfg, err := r.Config()
+	require.NoError(t, err)
+	cfg.User.Name = "updatecli"
+	cfg.User.Email = "updatecli@updatecli.io"
+	require.NoError(t, r.SetConfig(cfg))
+
+	head, err := r.Head()
+	require.NoError(t, err)
+
+	g := GoGit{}
+
+	// Lightweight tag
+	created, err := g.NewTag("v1.0.0", "", workingDir)
+	require.NoError(t, err)
+	assert.True(t, created)
+	ref, err := r.Tag("v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, head.Hash(), ref.Hash())
+
+	// Annotated tag
+	created, err = g.NewTag("v1.1.0+build.1", "release v1.1.0", workingDir)
+	require.NoError(t, err)
+	assert.True(t, created)
+	ref, err = r.Tag("v1.1.0+build.1")
+	require.NoError(t, err)
+	tag, err := r.TagObject(ref.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, "release v1.1.0\n", tag.Message)
+	assert.Equal(t, "updatecli", tag.Tagger.Name)
+	assert.Equal(t, "updatecli@updatecli.io", tag.Tagger.Email)
+	assert.Equal(t, head.Hash(), tag.Target)
+
+	// Existing tag
+	created, err = g.NewTag("v1.0.0", "", workingDir)
+	assert.False(t, created)
+	var tagExists *ErrTagExists
+	require.True(t, errors.As(err, &tagExists), err)
+	assert.True(t, errors.Is(err, git.ErrTagExists))
+
+	// Invalid tag names
+	for _, name := range []string{"", "v1..0", "v1.0 beta", "release/", "v1.lock", ".v1", "v1:0", "v1@{0}"} {
+		_, err := g.NewTag(name, "", workingDir)
+		var invalid *ErrInvalidReferenceName
+		assert.True(t, errors.As(err, &invalid), "tag name %q: %v", name, err)
+	}
+
+	// Only the tag is published
+	require.NoError(t, g.PushTag("v1.0.0", "", "", workingDir, false))
+	o, err := git.PlainOpen(origin)
+	require.NoError(t, err)
+	_, err = o.Tag("v1.0.0")
+	require.NoError(t, err)
+	_, err = o.Tag("v1.1.0+build.1")
+	assert.ErrorIs(t, err, git.ErrTagNotFound)
+}
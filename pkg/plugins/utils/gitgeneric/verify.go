@@ -0,0 +1,180 @@
+package gitgeneric
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/sirupsen/logrus"
+)
+
+// verifyRepository checks the git repository integrity, similarly to `git fsck`.
+// Every stored object must match its hash, and the whole tree of every
+// commit pointed by a reference must be available.
+// It returns an ErrCorruptedRepository listing the bad objects.
+func verifyRepository(r *git.Repository) error {
+
+	logrus.Debugf("verifying git repository integrity")
+
+	corrupted := ErrCorruptedRepository{}
+
+	count := 0
+	verify := func(o plumbing.EncodedObject, hash plumbing.Hash) {
+		count++
+		if err := verifyObject(o, hash); err != nil {
+			corrupted.Objects = append(corrupted.Objects, fmt.Sprintf("%s: %s", hash, err))
+		}
+	}
+
+	// The hash of a loose object is computed from its content,
+	// so it must be compared with the hash identifying its file.
+	loose := map[plumbing.Hash]bool{}
+	if s, ok := r.Storer.(storer.LooseObjectStorer); ok {
+		err := s.ForEachObjectHash(func(hash plumbing.Hash) error {
+			loose[hash] = true
+
+			o, err := r.Storer.EncodedObject(plumbing.AnyObject, hash)
+			if err != nil {
+				corrupted.Objects = append(corrupted.Objects, fmt.Sprintf("%s: %s", hash, err))
+				return nil
+			}
+			verify(o, hash)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	objects, err := r.Storer.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		return err
+	}
+
+	err = objects.ForEach(func(o plumbing.EncodedObject) error {
+		if !loose[o.Hash()] {
+			verify(o, o.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	refs, err := r.References()
+	if err != nil {
+		return err
+	}
+
+	verifiedTrees := map[plumbing.Hash]bool{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		commit, err := resolveReferenceCommit(r, ref.Hash())
+		if err != nil {
+			corrupted.Objects = append(corrupted.Objects, fmt.Sprintf("%s (%s): %s", ref.Hash(), ref.Name(), err))
+			return nil
+		}
+		if commit == nil {
+			// Reference to a tree or a blob, already checked
+			return nil
+		}
+
+		corrupted.Objects = append(corrupted.Objects, verifyTree(r, commit.TreeHash, verifiedTrees)...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(corrupted.Objects) > 0 {
+		return &corrupted
+	}
+
+	logrus.Debugf("%d git objects verified", count)
+
+	return nil
+}
+
+// verifyObject checks that the object content matches hash
+func verifyObject(o plumbing.EncodedObject, hash plumbing.Hash) error {
+	reader, err := o.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	hasher := plumbing.NewHasher(o.Type(), o.Size())
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return err
+	}
+
+	if sum := hasher.Sum(); sum != hash {
+		return fmt.Errorf("hash mismatch, content hash is %s", sum)
+	}
+
+	return nil
+}
+
+// resolveReferenceCommit returns the commit pointed by hash, peeling annotated tags,
+// or nil if hash doesn't identify a commit.
+func resolveReferenceCommit(r *git.Repository, hash plumbing.Hash) (*object.Commit, error) {
+	o, err := r.Object(plumbing.AnyObject, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch obj := o.(type) {
+		case *object.Commit:
+			return obj, nil
+		case *object.Tag:
+			o, err = obj.Object()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, nil
+		}
+	}
+}
+
+// verifyTree checks that the tree identified by hash, its subtrees, and its files are available.
+// Already verified trees are skipped, and returned strings describe the bad objects.
+func verifyTree(r *git.Repository, hash plumbing.Hash, verified map[plumbing.Hash]bool) []string {
+	if verified[hash] {
+		return nil
+	}
+	verified[hash] = true
+
+	tree, err := r.TreeObject(hash)
+	if err != nil {
+		return []string{fmt.Sprintf("%s (tree): %s", hash, err)}
+	}
+
+	bad := []string{}
+	for _, entry := range tree.Entries {
+		switch entry.Mode {
+		case filemode.Dir:
+			bad = append(bad, verifyTree(r, entry.Hash, verified)...)
+		case filemode.Submodule:
+			// Submodule commits belong to another repository
+		default:
+			_, err := r.Storer.EncodedObject(plumbing.BlobObject, entry.Hash)
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				bad = append(bad, fmt.Sprintf("%s (%s): missing blob", entry.Hash, entry.Name))
+			} else if err != nil {
+				bad = append(bad, fmt.Sprintf("%s (%s): %s", entry.Hash, entry.Name, err))
+			}
+		}
+	}
+
+	return bad
+}
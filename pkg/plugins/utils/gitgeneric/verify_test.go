@@ -0,0 +1,85 @@
+package gitgeneric
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// looseObjectPath returns the path of the loose object identified by hash
+func looseObjectPath(workingDir string, hash plumbing.Hash) string {
+	h := hash.String()
+	return filepath.Join(workingDir, ".git", "objects", h[:2], h[2:])
+}
+
+func TestVerifyAfterClone(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "v1", "docs/index.md": "docs"})
+	commit := commitTestFile(t, origin, "README.md", "v2")
+
+	g := GoGit{VerifyAfterClone: true}
+
+	require.NoError(t, g.Clone("", "", origin, filepath.Join(t.TempDir(), "clone")))
+	require.NoError(t, g.CloneCommit("", "", origin, commit.String(), filepath.Join(t.TempDir(), "commit")))
+}
+
+func TestVerifyRepository(t *testing.T) {
+	tests := []struct {
+		name     string
+		corrupt  func(t *testing.T, workingDir string, blob plumbing.Hash)
+		expected string
+	}{
+		{
+			name: "Altered blob",
+			corrupt: func(t *testing.T, workingDir string, blob plumbing.Hash) {
+				b := bytes.Buffer{}
+				zw := zlib.NewWriter(&b)
+				fmt.Fprint(zw, "blob 8\x00altered!")
+				require.NoError(t, zw.Close())
+
+				path := looseObjectPath(workingDir, blob)
+				require.NoError(t, os.Chmod(path, 0o644))
+				require.NoError(t, os.WriteFile(path, b.Bytes(), 0o644))
+			},
+			expected: "hash mismatch",
+		},
+		{
+			name: "Missing blob",
+			corrupt: func(t *testing.T, workingDir string, blob plumbing.Hash) {
+				require.NoError(t, os.Remove(looseObjectPath(workingDir, blob)))
+			},
+			expected: "missing blob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := newTestRepository(t, map[string]string{"README.md": "v1"})
+
+			r, err := git.PlainOpen(workingDir)
+			require.NoError(t, err)
+			require.NoError(t, verifyRepository(r))
+
+			blob := plumbing.ComputeHash(plumbing.BlobObject, []byte("v1"))
+			tt.corrupt(t, workingDir, blob)
+
+			r, err = git.PlainOpen(workingDir)
+			require.NoError(t, err)
+
+			err = verifyRepository(r)
+			var corrupted *ErrCorruptedRepository
+			require.True(t, errors.As(err, &corrupted), err)
+			require.Len(t, corrupted.Objects, 1)
+			assert.Contains(t, corrupted.Objects[0], blob.String())
+			assert.Contains(t, corrupted.Objects[0], tt.expected)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package gitgeneric
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// absWorkingDir returns the absolute path of the working directory.
+// A relative working directory is resolved from the RootDir if set,
+// otherwise from the process current working directory.
+// If mustExist is true, the working directory must be an existing directory.
+func (g GoGit) absWorkingDir(workingDir string, mustExist bool) (string, error) {
+	return absWorkingDir(g.RootDir, workingDir, mustExist)
+}
+
+func absWorkingDir(rootDir, workingDir string, mustExist bool) (string, error) {
+	if workingDir == "" {
+		return "", fmt.Errorf("git working directory not specified")
+	}
+
+	if !filepath.IsAbs(workingDir) && rootDir != "" {
+		workingDir = filepath.Join(rootDir, workingDir)
+	}
+
+	absWorkingDir, err := filepath.Abs(workingDir)
+	if err != nil {
+		return "", fmt.Errorf("resolve git working directory %q: %w", workingDir, err)
+	}
+
+	if !mustExist {
+		return absWorkingDir, nil
+	}
+
+	info, err := os.Stat(absWorkingDir)
+	if err != nil {
+		return "", fmt.Errorf("git working directory %q: %w", absWorkingDir, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("git working directory %q is not a directory", absWorkingDir)
+	}
+
+	return absWorkingDir, nil
+}
+
+// createParentDir creates the missing parent directories of the working directory,
+// unless DisableCreateWorkingDir is set, in which case it reports the missing parent directory.
+func (g GoGit) createParentDir(workingDir string) error {
+	parentDir := filepath.Dir(workingDir)
+
+	if g.DisableCreateWorkingDir {
+		info, err := os.Stat(parentDir)
+		if err != nil {
+			return fmt.Errorf("parent directory %q of git working directory %q: %w", parentDir, workingDir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("parent directory %q of git working directory %q is not a directory", parentDir, workingDir)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return fmt.Errorf("creating parent directory %q of git working directory %q: %w", parentDir, workingDir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package gitgeneric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelativeWorkingDir(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+	writeTestFile(t, workingDir, "README.md", "updated")
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Dir(workingDir)))
+	defer func() {
+		require.NoError(t, os.Chdir(cwd))
+	}()
+
+	relativeWorkingDir := filepath.Base(workingDir)
+
+	g := GoGit{}
+	changedFiles, err := g.GetChangedFiles(relativeWorkingDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, changedFiles)
+
+	// Absolute file paths are converted relative to the resolved working directory
+	require.NoError(t, g.Add([]string{filepath.Join(workingDir, "README.md")}, relativeWorkingDir))
+
+	// Relative working directories are resolved from RootDir when specified
+	require.NoError(t, os.Chdir(cwd))
+	g = GoGit{RootDir: filepath.Dir(workingDir)}
+	_, err = g.Commit("updatecli", "updatecli@updatecli.io", "update", relativeWorkingDir, "", "")
+	require.NoError(t, err)
+
+	content, err := ReadFileFromRevision(workingDir, "HEAD", "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(content))
+
+	_, err = g.GetChangedFiles("doNotExist")
+	require.ErrorContains(t, err, filepath.Join(filepath.Dir(workingDir), "doNotExist"))
+}
+
+func TestCloneNestedWorkingDir(t *testing.T) {
+	origin := newTestRepository(t, map[string]string{"README.md": "updatecli"})
+
+	tests := []struct {
+		name    string
+		g       GoGit
+		wantErr bool
+	}{
+		{
+			name: "Missing parent directories created",
+			g:    GoGit{},
+		},
+		{
+			name:    "Missing parent directories not created",
+			g:       GoGit{DisableCreateWorkingDir: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workingDir := filepath.Join(t.TempDir(), "a", "b", "c", "clone")
+
+			err := tt.g.Clone("", "", origin, workingDir)
+			if tt.wantErr {
+				require.ErrorContains(t, err, filepath.Dir(workingDir))
+				assert.NoDirExists(t, filepath.Dir(workingDir))
+				return
+			}
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(workingDir, "README.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "updatecli", string(content))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package gitgeneric
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/sirupsen/logrus"
+)
+
+// WorktreeHash returns a stable hash of the tracked files content from the working directory.
+// Files are read from the worktree, so uncommitted modifications to tracked files
+// change the resulting hash, while untracked files and the ".git" directory are ignored.
+// Symbolic links are hashed using their target, and submodules using their commit recorded in the index.
+// The result doesn't depend on the order in which files are listed.
+func (g GoGit) WorktreeHash(workingDir string) (string, error) {
+
+	workingDir, err := g.absWorkingDir(workingDir, true)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := git.PlainOpen(workingDir)
+	if err != nil {
+		logrus.Errorf("opening %q git directory err: %s", workingDir, err)
+		return "", err
+	}
+
+	index, err := r.Storer.Index()
+	if err != nil {
+		return "", err
+	}
+
+	entries := index.Entries
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	h := sha256.New()
+	for _, entry := range entries {
+		// Both the file path and its content are part of the hash
+		// so renaming a file without modifying it is also detected.
+		fmt.Fprintf(h, "%s\x00", entry.Name)
+
+		// The submodule working directory is a git repository of its own
+		if entry.Mode == filemode.Submodule {
+			fmt.Fprintf(h, "submodule %s\x00", entry.Hash)
+			continue
+		}
+
+		filePath := filepath.Join(workingDir, filepath.FromSlash(entry.Name))
+
+		info, err := os.Lstat(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			// Tracked file deleted from the worktree
+			fmt.Fprint(h, "deleted\x00")
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(filePath)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "symlink %s\x00", target)
+			continue
+		case info.IsDir():
+			// Tracked file replaced by a directory
+			fmt.Fprint(h, "directory\x00")
+			continue
+		}
+
+		f, err := os.Open(filePath)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprint(h, "\x00")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HasUncommittedGeneratedFiles returns true, with their paths, if some modified or untracked files
+// match any of the glob patterns, such as "go.sum" or "docs/*.md".
+// A pattern without "/" is matched against file names, in any directory,
+// otherwise it's matched against the file path relative to the working directory,
+// or its parent directories, using "/" as separator.
+func (g GoGit) HasUncommittedGeneratedFiles(patterns []string, workingDir string) (bool, []string, error) {
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false, nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	changedFiles, err := g.GetChangedFiles(workingDir)
+	if err != nil {
+		return false, nil, err
+	}
+
+	matchingFiles := []string{}
+	for _, file := range changedFiles {
+		for _, pattern := range patterns {
+			if matchGeneratedFile(pattern, file) {
+				matchingFiles = append(matchingFiles, file)
+				break
+			}
+		}
+	}
+	sort.Strings(matchingFiles)
+
+	logrus.Debugf("%d uncommitted files matching %q", len(matchingFiles), patterns)
+
+	return len(matchingFiles) > 0, matchingFiles, nil
+}
+
+// matchGeneratedFile returns true if the slash separated file path matches pattern
+func matchGeneratedFile(pattern, file string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(file))
+		return matched
+	}
+
+	// Match the file itself, or one of its parent directories
+	for p := file; p != "." && p != "/"; p = path.Dir(p) {
+		if matched, _ := path.Match(pattern, p); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,163 @@
+package gitgeneric
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorktreeHash(t *testing.T) {
+	g := GoGit{}
+
+	files := map[string]string{
+		"README.md":       "# updatecli",
+		"docs/index.adoc": "= Documentation",
+	}
+
+	workingDirA := newTestRepository(t, files)
+	workingDirB := newTestRepository(t, files)
+
+	hashA, err := g.WorktreeHash(workingDirA)
+	require.NoError(t, err)
+	assert.NotEmpty(t, hashA)
+
+	hashB, err := g.WorktreeHash(workingDirB)
+	require.NoError(t, err)
+	assert.Equal(t, hashA, hashB, "same content should produce the same hash")
+
+	// Untracked files are ignored
+	writeTestFile(t, workingDirB, "untracked.txt", "not tracked")
+	hashB, err = g.WorktreeHash(workingDirB)
+	require.NoError(t, err)
+	assert.Equal(t, hashA, hashB)
+
+	// Modifying a tracked file changes the hash
+	writeTestFile(t, workingDirB, "README.md", "# updatecli v2")
+	hashB, err = g.WorktreeHash(workingDirB)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashA, hashB)
+
+	// Deleting a tracked file changes the hash
+	require.NoError(t, os.Remove(filepath.Join(workingDirA, "docs", "index.adoc")))
+	hashDeleted, err := g.WorktreeHash(workingDirA)
+	require.NoError(t, err)
+	assert.NotEqual(t, hashA, hashDeleted)
+
+	_, err = g.WorktreeHash(t.TempDir())
+	require.Error(t, err)
+}
+
+func TestWorktreeHashSymlink(t *testing.T) {
+	g := GoGit{}
+
+	workingDir := newTestRepository(t, map[string]string{"docs/index.md": "# updatecli"})
+	require.NoError(t, os.Symlink("docs", filepath.Join(workingDir, "link")))
+	require.NoError(t, g.Add([]string{"link"}, workingDir))
+
+	hash, err := g.WorktreeHash(workingDir)
+	require.NoError(t, err)
+
+	// Changing the symlink target changes the hash
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, "website"), 0o755))
+	require.NoError(t, os.Remove(filepath.Join(workingDir, "link")))
+	require.NoError(t, os.Symlink("website", filepath.Join(workingDir, "link")))
+
+	hashRetargeted, err := g.WorktreeHash(workingDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hashRetargeted)
+}
+
+func TestWorktreeHashSubmodule(t *testing.T) {
+	g := GoGit{}
+
+	workingDir := newTestRepository(t, map[string]string{"README.md": "# updatecli"})
+
+	// A submodule is tracked as a gitlink entry, pointing to a commit of the submodule repository,
+	// while its working directory is a directory
+	addGitlink := func(commit string) {
+		r, err := git.PlainOpen(workingDir)
+		require.NoError(t, err)
+		idx, err := r.Storer.Index()
+		require.NoError(t, err)
+
+		entry, err := idx.Entry("vendor/lib")
+		if err != nil {
+			entry = idx.Add("vendor/lib")
+		}
+		entry.Mode = filemode.Submodule
+		entry.Hash = plumbing.NewHash(commit)
+		require.NoError(t, r.Storer.SetIndex(idx))
+	}
+
+	writeTestFile(t, workingDir, "vendor/lib/main.go", "package lib")
+	addGitlink("0000000000000000000000000000000000000001")
+
+	hash, err := g.WorktreeHash(workingDir)
+	require.NoError(t, err)
+
+	addGitlink("0000000000000000000000000000000000000002")
+	hashUpdated, err := g.WorktreeHash(workingDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hashUpdated, "updating the submodule commit changes the hash")
+}
+
+func TestHasUncommittedGeneratedFiles(t *testing.T) {
+	workingDir := newTestRepository(t, map[string]string{
+		"go.sum":           "v1",
+		"docs/index.md":    "v1",
+		"docs/api/sdk.md":  "v1",
+		"pkg/generated.go": "v1",
+	})
+
+	// Modified and untracked files
+	writeTestFile(t, workingDir, "go.sum", "v2")
+	writeTestFile(t, workingDir, "docs/api/sdk.md", "v2")
+	writeTestFile(t, workingDir, "pkg/zz_generated.go", "v1")
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "File name in any directory",
+			patterns: []string{"*generated.go", "go.sum"},
+			expected: []string{"go.sum", "pkg/zz_generated.go"},
+		},
+		{
+			name:     "Parent directory",
+			patterns: []string{"docs/api"},
+			expected: []string{"docs/api/sdk.md"},
+		},
+		{
+			name:     "Unmodified files",
+			patterns: []string{"docs/*.md", "README.md"},
+			expected: []string{},
+		},
+		{
+			name:     "Invalid pattern",
+			patterns: []string{"[docs"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, files, err := GoGit{}.HasUncommittedGeneratedFiles(tt.patterns, workingDir)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expected) > 0, found)
+			assert.Equal(t, tt.expected, files)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	"github.com/updatecli/updatecli/pkg/core/cache"
 	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/core/result"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
@@ -22,6 +23,11 @@ type DefaultHandler struct {
 
 // New returns a newly initialized DefaultHandler object
 func New(metadataURL string, versionFilter version.Filter) *DefaultHandler {
+	return NewWithClient(metadataURL, versionFilter, http.DefaultClient)
+}
+
+// NewWithClient returns a newly initialized DefaultHandler object sending requests with webClient
+func NewWithClient(metadataURL string, versionFilter version.Filter, webClient httpclient.HTTPClient) *DefaultHandler {
 
 	if versionFilter.IsZero() {
 		versionFilter.Kind = "latest"
@@ -29,13 +35,21 @@ func New(metadataURL string, versionFilter version.Filter) *DefaultHandler {
 
 	return &DefaultHandler{
 		metadataURL:   metadataURL,
-		webClient:     http.DefaultClient,
+		webClient:     webClient,
 		versionFilter: versionFilter,
 	}
 }
 
+// cacheNamespace is the lookup cache namespace of the maven metadata files
+const cacheNamespace = "maven"
+
 // getMetadataFile is an internal method that returns the parsed metadata object
 func (d *DefaultHandler) getMetadataFile() (metadata, error) {
+	data := metadata{}
+	if cache.Get(cacheNamespace, d.metadataURL, &data) {
+		return data, nil
+	}
+
 	req, err := http.NewRequest("GET", d.metadataURL, nil)
 	if err != nil {
 		return metadata{}, err
@@ -60,13 +74,13 @@ func (d *DefaultHandler) getMetadataFile() (metadata, error) {
 
 	logrus.Debugf("Received the following response (HTTP status %d):\n%s", res.StatusCode, body)
 
-	data := metadata{}
-
 	err = xml.Unmarshal(body, &data)
 	if err != nil {
 		return metadata{}, err
 	}
 
+	cache.Set(cacheNamespace, d.metadataURL, data)
+
 	return data, nil
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/updatecli/updatecli/pkg/core/cache"
 	"github.com/updatecli/updatecli/pkg/core/httpclient"
 	"github.com/updatecli/updatecli/pkg/plugins/utils/version"
 )
@@ -218,3 +219,28 @@ func TestDefaultHandler_GetVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersionsCached(t *testing.T) {
+	defaultDir := cache.Dir
+	defer func() { cache.Dir = defaultDir }()
+	cache.Dir = t.TempDir()
+
+	requests := 0
+	handler := NewWithClient("https://repo.example.com/maven-metadata.xml", version.Filter{}, &httpclient.MockClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(wikitextCoreMavenMetadata)),
+			}, nil
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		got, err := handler.GetLatestVersion()
+		require.NoError(t, err)
+		assert.Equal(t, "1.7.4.v20130429", got)
+	}
+
+	assert.Equal(t, 1, requests)
+}
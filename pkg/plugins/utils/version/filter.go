@@ -3,6 +3,7 @@ package version
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -17,6 +18,8 @@ const (
 	SEMVERVERSIONKIND string = "semver"
 	// LATESTVERSIONKIND specifies that we are looking for the latest version of an array
 	LATESTVERSIONKIND string = "latest"
+	// LEXICOGRAPHICVERSIONKIND represents versions as strings sorted in lexicographic order
+	LEXICOGRAPHICVERSIONKIND string = "lexicographic"
 )
 
 var (
@@ -25,17 +28,23 @@ var (
 		REGEXVERSIONKIND,
 		SEMVERVERSIONKIND,
 		LATESTVERSIONKIND,
+		LEXICOGRAPHICVERSIONKIND,
 	}
 )
 
 // Filter defines parameters to apply different kind of version matching based on a list of versions
 type Filter struct {
-	// specifies the version kind such as semver, regex, or latest
+	// specifies the version kind such as semver, regex, latest, or lexicographic
 	Kind string `yaml:",omitempty"`
-	// specifies the version pattern according the version kind
+	// specifies the version pattern according the version kind.
+	// It is a semantic version constraint such as ">=1.2 <2.0" for semver, and a regular expression for regex and lexicographic
 	Pattern string `yaml:",omitempty"`
 	// strict enforce strict versioning rule. Only used for semantic versioning at this time
 	Strict bool `yaml:",omitempty"`
+	// prerelease allows prerelease versions, such as "1.3.0-rc.1", to match the semantic version constraint
+	// according to their release version. By default, they only match constraints containing a prerelease.
+	// Only used for semantic versioning at this time
+	Prerelease bool `yaml:",omitempty"`
 }
 
 // Init returns a new (copy) valid instantiated filter
@@ -50,7 +59,7 @@ func (f Filter) Init() (Filter, error) {
 		f.Pattern = LATESTVERSIONKIND
 	} else if f.Kind == SEMVERVERSIONKIND && len(f.Pattern) == 0 {
 		f.Pattern = "*"
-	} else if f.Kind == REGEXVERSIONKIND && len(f.Pattern) == 0 {
+	} else if (f.Kind == REGEXVERSIONKIND || f.Kind == LEXICOGRAPHICVERSIONKIND) && len(f.Pattern) == 0 {
 		f.Pattern = ".*"
 	}
 
@@ -111,10 +120,28 @@ func (f *Filter) Search(versions []string) (Version, error) {
 				return foundVersion, nil
 			}
 		}
+	case LEXICOGRAPHICVERSIONKIND:
+		re, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return foundVersion, err
+		}
+
+		// Sort a copy of the versions, newest first, to not alter the publishing order
+		sortedVersions := append([]string{}, versions...)
+		sort.Sort(sort.Reverse(sort.StringSlice(sortedVersions)))
+
+		for _, v := range sortedVersions {
+			if re.MatchString(v) {
+				foundVersion.ParsedVersion = v
+				foundVersion.OriginalVersion = v
+				return foundVersion, nil
+			}
+		}
 	case SEMVERVERSIONKIND:
 		s := Semver{
 			Constraint: f.Pattern,
 			Strict:     f.Strict,
+			Prerelease: f.Prerelease,
 		}
 
 		err := s.Search(versions)
@@ -142,7 +169,7 @@ func (f *Filter) GreaterThanPattern(version string) (string, error) {
 	case LATESTVERSIONKIND:
 		return LATESTVERSIONKIND, nil
 
-	case REGEXVERSIONKIND:
+	case REGEXVERSIONKIND, LEXICOGRAPHICVERSIONKIND:
 		return f.Pattern, nil
 
 	case SEMVERVERSIONKIND:
@@ -106,6 +106,53 @@ func TestSearch(t *testing.T) {
 			want:     Version{},
 			wantErr:  &ErrNoVersionFoundForPattern{Pattern: "^updatecli-4.(\\d*)$"},
 		},
+		{
+			name: "Passing case with semver range excluding prerelease",
+			filter: Filter{
+				Kind:    SEMVERVERSIONKIND,
+				Pattern: ">=1.2 <2.0",
+			},
+			versions: []string{"1.2.0", "1.3.0-rc.1", "2.0.0"},
+			want: Version{
+				ParsedVersion:   "1.2.0",
+				OriginalVersion: "1.2.0",
+			},
+		},
+		{
+			name: "Passing case with semver range including prerelease",
+			filter: Filter{
+				Kind:       SEMVERVERSIONKIND,
+				Pattern:    ">=1.2 <2.0",
+				Prerelease: true,
+			},
+			versions: []string{"1.2.0", "1.3.0-rc.1", "2.0.0"},
+			want: Version{
+				ParsedVersion:   "1.3.0-rc.1",
+				OriginalVersion: "1.3.0-rc.1",
+			},
+		},
+		{
+			name: "Passing case with lexicographic filter and pattern",
+			filter: Filter{
+				Kind:    LEXICOGRAPHICVERSIONKIND,
+				Pattern: "^2023",
+			},
+			versions: []string{"20230102", "20231201", "20230605", "20240101"},
+			want: Version{
+				ParsedVersion:   "20231201",
+				OriginalVersion: "20231201",
+			},
+		},
+		{
+			name: "Failing case with lexicographic filter (+pattern)",
+			filter: Filter{
+				Kind:    LEXICOGRAPHICVERSIONKIND,
+				Pattern: "^2022",
+			},
+			versions: []string{"20230102", "20231201"},
+			want:     Version{},
+			wantErr:  &ErrNoVersionFoundForPattern{Pattern: "^2022"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -211,6 +258,16 @@ func TestNewFilter(t *testing.T) {
 				Pattern: ".*",
 			},
 		},
+		{
+			name: "Case with empty pattern for lexicographic",
+			filter: Filter{
+				Kind: LEXICOGRAPHICVERSIONKIND,
+			},
+			want: Filter{
+				Kind:    LEXICOGRAPHICVERSIONKIND,
+				Pattern: ".*",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
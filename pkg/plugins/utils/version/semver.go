@@ -13,6 +13,8 @@ type Semver struct {
 	versions     []*sv.Version
 	FoundVersion Version
 	Strict       bool
+	// Prerelease allows prerelease versions to match the constraint according to their release version
+	Prerelease bool
 }
 
 // Init creates a new semver object
@@ -76,7 +78,7 @@ func (s *Semver) Search(versions []string) error {
 
 	for _, v := range s.versions {
 
-		if c.Check(v) {
+		if s.check(c, v) {
 			s.FoundVersion.ParsedVersion = v.String()
 			s.FoundVersion.OriginalVersion = v.Original()
 			break
@@ -88,3 +90,23 @@ func (s *Semver) Search(versions []string) error {
 
 	return nil
 }
+
+// check tests if the version v satisfies the constraint c.
+// Prerelease versions are tested according to their release version if allowed,
+// as the constraint only matches them if it contains a prerelease.
+func (s *Semver) check(c *sv.Constraints, v *sv.Version) bool {
+	if c.Check(v) {
+		return true
+	}
+
+	if !s.Prerelease || v.Prerelease() == "" {
+		return false
+	}
+
+	release, err := v.SetPrerelease("")
+	if err != nil {
+		return false
+	}
+
+	return c.Check(&release)
+}
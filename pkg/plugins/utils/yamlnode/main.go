@@ -0,0 +1,131 @@
+package yamlnode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Documents returns each yaml document of file as its own file, so yamlpath queries only apply to one document.
+// The returned files share their nodes with file, so updating them updates file.
+func Documents(file *ast.File) []*ast.File {
+	var documents []*ast.File
+	for _, doc := range file.Docs {
+		documents = append(documents, &ast.File{Docs: []*ast.DocumentNode{doc}})
+	}
+	return documents
+}
+
+// Lookup returns the node found at the yamlpath query, or nil if not found
+func Lookup(doc *ast.File, query string) (ast.Node, error) {
+	path, err := yaml.PathString(query)
+	if err != nil {
+		return nil, fmt.Errorf("crafting yamlpath query: %w", err)
+	}
+
+	node, err := path.FilterFile(doc)
+	if errors.Is(err, yaml.ErrNotFoundNode) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("searching in yaml file: %w", err)
+	}
+
+	return node, nil
+}
+
+// LookupString returns the scalar value found at the yamlpath query, as written in the yaml document,
+// or an empty string if not found
+func LookupString(doc *ast.File, query string) (string, error) {
+	node, err := Lookup(doc, query)
+	if err != nil || node == nil {
+		return "", err
+	}
+
+	switch n := node.(type) {
+	case *ast.StringNode:
+		return n.Value, nil
+	case ast.ScalarNode:
+		return n.GetToken().Value, nil
+	}
+
+	return "", nil
+}
+
+// LookupSequenceLength returns the length of the sequence found at the yamlpath query, or 0 if not found
+func LookupSequenceLength(doc *ast.File, query string) (int, error) {
+	node, err := Lookup(doc, query)
+	if err != nil || node == nil {
+		return 0, err
+	}
+
+	if sequence, ok := node.(*ast.SequenceNode); ok {
+		return len(sequence.Values), nil
+	}
+
+	return 0, nil
+}
+
+// SetString sets the string value of the scalar found at the yamlpath query,
+// keeping its comment and quoting it if needed so it isn't read as another type, such as a number.
+func SetString(doc *ast.File, query, value string) error {
+	node, err := Lookup(doc, query)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("couldn't find key %q", query)
+	}
+
+	if n, ok := node.(*ast.StringNode); ok {
+		n.Value = value
+		n.Token.Value = value
+		if !isQuoted(n.Token) && !isPlainString(value) {
+			n.Token.Type = token.DoubleQuoteType
+		}
+		return nil
+	}
+
+	if _, ok := node.(ast.ScalarNode); !ok {
+		return fmt.Errorf("key %q isn't a scalar", query)
+	}
+
+	// Other scalars, such as numbers, are replaced by a quoted string
+	tk := token.New(value, value, node.GetToken().Position)
+	tk.Type = token.DoubleQuoteType
+	newNode := ast.String(tk)
+	if comment := node.GetComment(); comment != nil {
+		if err := newNode.SetComment(comment); err != nil {
+			return err
+		}
+	}
+
+	path, err := yaml.PathString(query)
+	if err != nil {
+		return fmt.Errorf("crafting yamlpath query: %w", err)
+	}
+
+	return path.ReplaceWithNode(doc, newNode)
+}
+
+// isQuoted returns true if the token is a quoted string
+func isQuoted(tk *token.Token) bool {
+	return tk.Type == token.SingleQuoteType || tk.Type == token.DoubleQuoteType
+}
+
+// isPlainString returns true if value is read as the same string when written without quotes
+func isPlainString(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+		return false
+	}
+
+	s, ok := v.(string)
+	return ok && s == value
+}
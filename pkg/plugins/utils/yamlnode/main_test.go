@@ -0,0 +1,92 @@
+package yamlnode
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetString(t *testing.T) {
+	testData := []struct {
+		name            string
+		content         string
+		query           string
+		value           string
+		expectedContent string
+		wantErr         bool
+	}{
+		{
+			name:            "Update a plain string, keeping its comment",
+			content:         "image: nginx:1.24 # proxy\n",
+			query:           "$.image",
+			value:           "nginx:1.25",
+			expectedContent: "image: nginx:1.25 # proxy\n",
+		},
+		{
+			name:            "Update a quoted string",
+			content:         "tag: '1.24'\n",
+			query:           "$.tag",
+			value:           "1.25",
+			expectedContent: "tag: '1.25'\n",
+		},
+		{
+			name:            "Quote a plain string which would be read as a number",
+			content:         "tag: v1\n",
+			query:           "$.tag",
+			value:           "1.25",
+			expectedContent: "tag: \"1.25\"\n",
+		},
+		{
+			name:            "Replace a number by a quoted string, keeping its comment",
+			content:         "tags:\n  - 1.0 # previous\n",
+			query:           "$.tags[0]",
+			value:           "1.1",
+			expectedContent: "tags:\n  - \"1.1\" # previous\n",
+		},
+		{
+			name:    "Missing key",
+			content: "tag: v1\n",
+			query:   "$.version",
+			value:   "1.25",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseBytes([]byte(tt.content), parser.ParseComments)
+			require.NoError(t, err)
+
+			err = SetString(file, tt.query, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedContent, file.String())
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	file, err := parser.ParseBytes([]byte("a: 1\n---\nimages:\n  - name: nginx\n    newTag: 1.0\n"), parser.ParseComments)
+	require.NoError(t, err)
+
+	documents := Documents(file)
+	require.Len(t, documents, 2)
+
+	count, err := LookupSequenceLength(documents[1], "$.images")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	tag, err := LookupString(documents[1], "$.images[0].newTag")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", tag)
+
+	missing, err := LookupString(documents[0], "$.images[0].newTag")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}